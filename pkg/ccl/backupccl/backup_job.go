@@ -9,6 +9,7 @@
 package backupccl
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"math/rand"
@@ -303,7 +304,10 @@ func backup(
 			span := allSpans[i]
 			g.GoCtx(func(ctx context.Context) error {
 				defer func() { <-exportsSem }()
-				header := roachpb.Header{Timestamp: span.end}
+				header := roachpb.Header{
+					Timestamp:         span.end,
+					AdmissionPriority: roachpb.BulkAdmissionPriority,
+				}
 				req := &roachpb.ExportRequest{
 					RequestHeader:                       roachpb.RequestHeaderFromSpan(span.span),
 					Storage:                             defaultStore.Conf(),
@@ -544,12 +548,32 @@ func (b *backupResumer) Resume(
 		return err
 	}
 
+	// An incremental backup which added KMS master keys records them in its own
+	// directory now that it is complete, rather than in the backups it builds on.
+	if len(details.EncryptionInfo) > 0 {
+		if err := defaultStore.WriteFile(
+			ctx, encryptionInfoFilename, bytes.NewReader(details.EncryptionInfo),
+		); err != nil {
+			return errors.Wrap(err, "writing encryption info")
+		}
+	}
+
 	err = b.clearStats(ctx, p.ExecCfg().DB)
 	if err != nil {
 		log.Warningf(ctx, "unable to clear stats from job payload: %+v", err)
 	}
 	b.deleteCheckpoint(ctx, p.ExecCfg())
 
+	// A new full backup in a collection becomes the latest one in it, i.e. the
+	// one that BACKUP INTO LATEST and RESTORE FROM LATEST use.
+	if details.CollectionURI != "" {
+		if err := writeLatestFile(
+			ctx, details.CollectionURI, details.CollectionSubdir, p.ExecCfg().DistSQLSrv.ExternalStorageFromURI,
+		); err != nil {
+			return err
+		}
+	}
+
 	if ptsID != nil && !b.testingKnobs.ignoreProtectedTimestamps {
 		if err := p.ExecCfg().DB.Txn(ctx, func(ctx context.Context, txn *kv.Txn) error {
 			return b.releaseProtectedTimestamp(ctx, txn, p.ExecCfg().ProtectedTimestampProvider)
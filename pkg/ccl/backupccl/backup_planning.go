@@ -11,6 +11,7 @@ package backupccl
 import (
 	"context"
 	"net/url"
+	"path"
 	"sort"
 
 	"github.com/cockroachdb/cockroach/pkg/build"
@@ -27,6 +28,8 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/settings"
 	"github.com/cockroachdb/cockroach/pkg/sql"
 	"github.com/cockroachdb/cockroach/pkg/sql/covering"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
@@ -48,7 +51,9 @@ import (
 const (
 	backupOptRevisionHistory = "revision_history"
 	backupOptEncPassphrase   = "encryption_passphrase"
+	backupOptEncKMS          = "kms"
 	backupOptWithPrivileges  = "privileges"
+	backupOptDetached        = "detached"
 	localityURLParam         = "COCKROACH_LOCALITY"
 	defaultLocalityValue     = "default"
 )
@@ -78,6 +83,7 @@ var useTBI = settings.RegisterBoolSetting(
 var backupOptionExpectValues = map[string]sql.KVStringOptValidate{
 	backupOptRevisionHistory: sql.KVStringOptRequireNoValue,
 	backupOptEncPassphrase:   sql.KVStringOptRequireValue,
+	backupOptDetached:        sql.KVStringOptRequireNoValue,
 }
 
 type tableAndIndex struct {
@@ -161,6 +167,10 @@ func optsToKVOptions(opts map[string]string) tree.KVOptions {
 	return kvopts
 }
 
+// fullBackupSubdirFormat is the format of the subdirectory, inside a backup
+// collection, where each full backup is written.
+const fullBackupSubdirFormat = "2006/01/02-150405.00"
+
 // getURIsByLocalityKV takes a slice of URIs for a single (possibly partitioned)
 // backup, and returns the default backup destination URI and a map of all other
 // URIs by locality KV, apppending appendPath to the path component of both the
@@ -231,11 +241,23 @@ func getURIsByLocalityKV(to []string, appendPath string) (string, map[string]str
 	return defaultURI, urisByLocalityKV, nil
 }
 
+// appendPath returns the uri with the specified subdirectory appended to its
+// path; the rest of the uri (e.g. query parameters) is preserved.
+func appendPath(uri string, subdir string) (string, error) {
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return "", err
+	}
+	parsed.Path = path.Join(parsed.Path, subdir)
+	return parsed.String(), nil
+}
+
 func backupJobDescription(
 	p sql.PlanHookState,
 	backup *tree.Backup,
 	to []string,
 	incrementalFrom []string,
+	kmsURIs []string,
 	opts map[string]string,
 ) (string, error) {
 	b := &tree.Backup{
@@ -243,6 +265,13 @@ func backupJobDescription(
 		Options: optsToKVOptions(opts),
 		Targets: backup.Targets,
 	}
+	if len(kmsURIs) > 0 {
+		kmsOpt, err := makeKMSOption(kmsURIs)
+		if err != nil {
+			return "", err
+		}
+		b.Options = append(b.Options, kmsOpt)
+	}
 
 	for _, t := range to {
 		sanitizedTo, err := cloud.SanitizeExternalStorageURI(t, nil /* extraParams */)
@@ -264,15 +293,40 @@ func backupJobDescription(
 	return tree.AsStringWithFQNames(b, ann), nil
 }
 
+// annotatedBackupStatement is a tree.Backup, optionally annotated with the
+// scheduling information.
+type annotatedBackupStatement struct {
+	*tree.Backup
+	*jobs.CreatedByInfo
+}
+
+func getBackupStatement(stmt tree.Statement) *annotatedBackupStatement {
+	switch backup := stmt.(type) {
+	case *annotatedBackupStatement:
+		return backup
+	case *tree.Backup:
+		return &annotatedBackupStatement{Backup: backup}
+	default:
+		return nil
+	}
+}
+
 // backupPlanHook implements PlanHookFn.
 func backupPlanHook(
 	ctx context.Context, stmt tree.Statement, p sql.PlanHookState,
 ) (sql.PlanHookRowFn, sqlbase.ResultColumns, []sql.PlanNode, bool, error) {
-	backupStmt, ok := stmt.(*tree.Backup)
-	if !ok {
+	backupStmt := getBackupStatement(stmt)
+	if backupStmt == nil {
 		return nil, nil, nil, false, nil
 	}
 
+	detached := false
+	for _, opt := range backupStmt.Options {
+		if string(opt.Key) == backupOptDetached {
+			detached = true
+		}
+	}
+
 	toFn, err := p.TypeAsStringArray(ctx, tree.Exprs(backupStmt.To), "BACKUP")
 	if err != nil {
 		return nil, nil, nil, false, err
@@ -281,10 +335,21 @@ func backupPlanHook(
 	if err != nil {
 		return nil, nil, nil, false, err
 	}
-	optsFn, err := p.TypeAsStringOpts(ctx, backupStmt.Options, backupOptionExpectValues)
+	backupOpts, kmsFn, err := splitKMSOption(ctx, p, backupStmt.Options, "BACKUP")
+	if err != nil {
+		return nil, nil, nil, false, err
+	}
+	optsFn, err := p.TypeAsStringOpts(ctx, backupOpts, backupOptionExpectValues)
 	if err != nil {
 		return nil, nil, nil, false, err
 	}
+	var subdirFn func() (string, error)
+	if backupStmt.Subdir != nil {
+		subdirFn, err = p.TypeAsString(ctx, backupStmt.Subdir, "BACKUP")
+		if err != nil {
+			return nil, nil, nil, false, err
+		}
+	}
 
 	header := sqlbase.ResultColumns{
 		{Name: "job_id", Typ: types.Int},
@@ -294,6 +359,9 @@ func backupPlanHook(
 		{Name: "index_entries", Typ: types.Int},
 		{Name: "bytes", Typ: types.Int},
 	}
+	if detached {
+		header = sqlbase.ResultColumns{{Name: "job_id", Typ: types.Int}}
+	}
 
 	fn := func(ctx context.Context, _ []sql.PlanNode, resultsCh chan<- tree.Datums) error {
 		// TODO(dan): Move this span into sql.
@@ -310,8 +378,8 @@ func backupPlanHook(
 			return err
 		}
 
-		if !p.ExtendedEvalContext().TxnImplicit {
-			return errors.Errorf("BACKUP cannot be used inside a transaction")
+		if !detached && !p.ExtendedEvalContext().TxnImplicit {
+			return errors.Errorf("BACKUP cannot be used inside a transaction without DETACHED option")
 		}
 
 		to, err := toFn()
@@ -398,6 +466,45 @@ func backupPlanHook(
 		if passphrase, ok := opts[backupOptEncPassphrase]; ok {
 			encryptionPassphrase = []byte(passphrase)
 		}
+		kmsURIs, err := kmsFn()
+		if err != nil {
+			return err
+		}
+		if encryptionPassphrase != nil && len(kmsURIs) > 0 {
+			return errors.Errorf("cannot specify both %q and %q", backupOptEncPassphrase, backupOptEncKMS)
+		}
+		kmsEnv := makeBackupKMSEnv(p.ExecCfg())
+
+		// A backup INTO a collection is written to a subdirectory of it: either
+		// the one explicitly specified, the one holding the latest full backup,
+		// or, for a new full backup, one named after the backup's end time.
+		var collectionURI, chosenSubdir string
+		appendToExisting := false
+		if backupStmt.Nested {
+			collectionURI, _, err = getURIsByLocalityKV(to, "")
+			if err != nil {
+				return err
+			}
+			switch {
+			case subdirFn != nil:
+				if chosenSubdir, err = subdirFn(); err != nil {
+					return err
+				}
+				appendToExisting = true
+			case backupStmt.AppendToLatest:
+				if chosenSubdir, err = readLatestFile(ctx, collectionURI, makeCloudStorage); err != nil {
+					return err
+				}
+				appendToExisting = true
+			default:
+				chosenSubdir = endTime.GoTime().UTC().Format(fullBackupSubdirFormat)
+			}
+			for i := range to {
+				if to[i], err = appendPath(to[i], chosenSubdir); err != nil {
+					return err
+				}
+			}
+		}
 
 		defaultURI, urisByLocalityKV, err := getURIsByLocalityKV(to, "")
 		if err != nil {
@@ -415,22 +522,51 @@ func backupPlanHook(
 		}()
 
 		var encryption *roachpb.FileEncryptionOptions
+		// layerEncInfo is the encryption info to record in the directory of this
+		// backup once it completes, if it adds KMS master keys to those of the
+		// prior backups that it builds on.
+		var layerEncInfo *EncryptionInfo
+		// usePriorEncryption sets up the backup to be encrypted with the key of
+		// the prior backups that it builds on, the first of which is in store
+		// and the rest of which are in layerURIs. Any KMS master keys which do not
+		// yet wrap that key are recorded in layerEncInfo rather than added to the
+		// prior backups, so that the backups as a whole can be decrypted using
+		// any of them once this backup completes.
+		usePriorEncryption := func(store cloud.ExternalStorage, layerURIs []string) error {
+			if encryptionPassphrase == nil && len(kmsURIs) == 0 {
+				return nil
+			}
+			encInfo, err := readEncryptionInfoLayers(ctx, store, layerURIs, makeCloudStorage)
+			if err != nil {
+				return err
+			}
+			key, err := getEncryptionKey(ctx, encInfo, encryptionPassphrase, kmsURIs, kmsEnv)
+			if err != nil {
+				return err
+			}
+			encryption = &roachpb.FileEncryptionOptions{Key: key}
+			added, err := addKMSMasterKeys(ctx, encInfo, key, kmsURIs, kmsEnv)
+			if err != nil {
+				return err
+			}
+			if added {
+				layerEncInfo = encInfo
+			}
+			return nil
+		}
+
 		var prevBackups []BackupManifest
 		g := ctxgroup.WithContext(ctx)
 		if len(incrementalFrom) > 0 {
-			if encryptionPassphrase != nil {
+			if encryptionPassphrase != nil || len(kmsURIs) > 0 {
 				exportStore, err := makeCloudStorage(ctx, incrementalFrom[0])
 				if err != nil {
 					return err
 				}
 				defer exportStore.Close()
-				opts, err := readEncryptionOptions(ctx, exportStore)
-				if err != nil {
+				if err := usePriorEncryption(exportStore, incrementalFrom[1:]); err != nil {
 					return err
 				}
-				encryption = &roachpb.FileEncryptionOptions{
-					Key: storageccl.GenerateKey(encryptionPassphrase, opts.Salt),
-				}
 			}
 			prevBackups = make([]BackupManifest, len(incrementalFrom))
 			for i := range incrementalFrom {
@@ -461,15 +597,18 @@ func backupPlanHook(
 			if err != nil {
 				return err
 			}
+			if backupStmt.Nested {
+				if appendToExisting && !exists {
+					return errors.Errorf("backup collection does not contain a full backup in %q", chosenSubdir)
+				}
+				if !appendToExisting && exists {
+					return pgerror.Newf(pgcode.FileAlreadyExists,
+						"backup collection already contains a backup in %q", chosenSubdir)
+				}
+			}
 			if exists {
-				if encryptionPassphrase != nil {
-					encOpts, err := readEncryptionOptions(ctx, defaultStore)
-					if err != nil {
-						return err
-					}
-					encryption = &roachpb.FileEncryptionOptions{
-						Key: storageccl.GenerateKey(encryptionPassphrase, encOpts.Salt),
-					}
+				if err := usePriorEncryption(defaultStore, nil /* layerURIs */); err != nil {
+					return err
 				}
 
 				prev, err := findPriorBackups(ctx, defaultStore)
@@ -640,27 +779,38 @@ func backupPlanHook(
 			return err
 		}
 
-		description, err := backupJobDescription(p, backupStmt, to, incrementalFrom, opts)
+		description, err := backupJobDescription(p, backupStmt.Backup, to, incrementalFrom, kmsURIs, opts)
 		if err != nil {
 			return err
 		}
 
 		// If we didn't load any prior backups from which get encryption info, we
-		// need to pick a new salt and record it.
-		if encryptionPassphrase != nil && encryption == nil {
-			salt, err := storageccl.GenerateSalt()
-			if err != nil {
-				return err
+		// need to pick a new salt or data key and record it.
+		if (encryptionPassphrase != nil || len(kmsURIs) > 0) && encryption == nil {
+			var encInfo *EncryptionInfo
+			var key []byte
+			if encryptionPassphrase != nil {
+				salt, err := storageccl.GenerateSalt()
+				if err != nil {
+					return err
+				}
+				encInfo = &EncryptionInfo{Salt: salt}
+				key = storageccl.GenerateKey(encryptionPassphrase, salt)
+			} else {
+				var err error
+				if encInfo, key, err = makeKMSEncryptionInfo(ctx, kmsURIs, kmsEnv); err != nil {
+					return err
+				}
 			}
 			exportStore, err := makeCloudStorage(ctx, defaultURI)
 			if err != nil {
 				return err
 			}
 			defer exportStore.Close()
-			if err := writeEncryptionOptions(ctx, &EncryptionInfo{Salt: salt}, exportStore); err != nil {
+			if err := writeEncryptionOptions(ctx, encInfo, exportStore); err != nil {
 				return err
 			}
-			encryption = &roachpb.FileEncryptionOptions{Key: storageccl.GenerateKey(encryptionPassphrase, salt)}
+			encryption = &roachpb.FileEncryptionOptions{Key: key}
 		}
 
 		// TODO (lucy): For partitioned backups, also add verification for other
@@ -679,6 +829,15 @@ func backupPlanHook(
 			BackupManifest:   descBytes,
 			Encryption:       encryption,
 		}
+		if backupStmt.Nested && !appendToExisting {
+			backupDetails.CollectionURI = collectionURI
+			backupDetails.CollectionSubdir = chosenSubdir
+		}
+		if layerEncInfo != nil {
+			if backupDetails.EncryptionInfo, err = protoutil.Marshal(layerEncInfo); err != nil {
+				return err
+			}
+		}
 		if len(spans) > 0 {
 			protectedtsID := uuid.MakeV4()
 			backupDetails.ProtectedTimestampRecord = &protectedtsID
@@ -693,31 +852,20 @@ func backupPlanHook(
 				}
 				return sqlDescIDs
 			}(),
-			Details:  backupDetails,
-			Progress: jobspb.BackupProgress{},
+			Details:   backupDetails,
+			Progress:  jobspb.BackupProgress{},
+			CreatedBy: backupStmt.CreatedByInfo,
 		}
-		var sj *jobs.StartableJob
-		if err := p.ExecCfg().DB.Txn(ctx, func(ctx context.Context, txn *kv.Txn) (err error) {
-			sj, err = p.ExecCfg().JobRegistry.CreateStartableJobWithTxn(ctx, jr, txn, resultsCh)
-			if err != nil {
-				return err
-			}
-			if len(spans) > 0 {
-				tsToProtect := endTime
-				rec := jobsprotectedts.MakeRecord(*backupDetails.ProtectedTimestampRecord, *sj.ID(), tsToProtect, spans)
-				return p.ExecCfg().ProtectedTimestampProvider.Protect(ctx, txn, rec)
-			}
-			return nil
-		}); err != nil {
-			if sj != nil {
-				if cleanupErr := sj.CleanupOnRollback(ctx); cleanupErr != nil {
-					log.Warningf(ctx, "failed to cleanup StartableJob: %v", cleanupErr)
-				}
+
+		protectSpans := func(ctx context.Context, txn *kv.Txn, jobID int64) error {
+			if len(spans) == 0 {
+				return nil
 			}
+			rec := jobsprotectedts.MakeRecord(*backupDetails.ProtectedTimestampRecord, jobID, endTime, spans)
+			return p.ExecCfg().ProtectedTimestampProvider.Protect(ctx, txn, rec)
 		}
 
-		// Collect telemetry.
-		{
+		collectTelemetry := func() {
 			telemetry.Count("backup.total.started")
 			if startTime.IsEmpty() {
 				telemetry.Count("backup.span.full")
@@ -736,12 +884,48 @@ func backupPlanHook(
 			}
 			if encryption != nil {
 				telemetry.Count("backup.encrypted")
+				if len(kmsURIs) > 0 {
+					telemetry.Count("backup.encryption.kms")
+				}
 			}
 			if backupStmt.DescriptorCoverage == tree.AllDescriptors {
 				telemetry.Count("backup.targets.full_cluster")
 			}
 		}
 
+		if detached {
+			// When running inside an explicit transaction, we simply create the job
+			// record. We do not wait for the job to finish.
+			txn := p.ExtendedEvalContext().Txn
+			job, err := p.ExecCfg().JobRegistry.CreateJobWithTxn(ctx, jr, txn)
+			if err != nil {
+				return err
+			}
+			if err := protectSpans(ctx, txn, *job.ID()); err != nil {
+				return err
+			}
+			collectTelemetry()
+			resultsCh <- tree.Datums{tree.NewDInt(tree.DInt(*job.ID()))}
+			return nil
+		}
+
+		var sj *jobs.StartableJob
+		if err := p.ExecCfg().DB.Txn(ctx, func(ctx context.Context, txn *kv.Txn) (err error) {
+			sj, err = p.ExecCfg().JobRegistry.CreateStartableJobWithTxn(ctx, jr, txn, resultsCh)
+			if err != nil {
+				return err
+			}
+			return protectSpans(ctx, txn, *sj.ID())
+		}); err != nil {
+			if sj != nil {
+				if cleanupErr := sj.CleanupOnRollback(ctx); cleanupErr != nil {
+					log.Warningf(ctx, "failed to cleanup StartableJob: %v", cleanupErr)
+				}
+			}
+		}
+
+		collectTelemetry()
+
 		errCh, err := sj.Start(ctx)
 		if err != nil {
 			return err
@@ -452,6 +452,54 @@ func TestBackupRestoreAppend(t *testing.T) {
 	// TODO(dt): test restoring to other backups via AOST.
 }
 
+func TestBackupRestoreCollection(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	const numAccounts = 10
+	_, _, sqlDB, _, cleanupFn := backupRestoreTestSetup(t, singleNode, numAccounts, initNone)
+	defer cleanupFn()
+
+	sqlDB.ExpectErr(t, "path does not contain a completed latest backup",
+		"BACKUP TABLE data.bank INTO LATEST IN $1", localFoo)
+
+	sqlDB.Exec(t, "BACKUP TABLE data.bank INTO $1", localFoo)
+	var first string
+	sqlDB.QueryRow(t, "SHOW BACKUPS IN $1", localFoo).Scan(&first)
+
+	// Appending to the latest full backup adds an incremental layer to it.
+	sqlDB.Exec(t, "UPDATE data.bank SET balance = 100")
+	rowsFirst := sqlDB.QueryStr(t, "SELECT * FROM data.bank ORDER BY id")
+	sqlDB.Exec(t, "BACKUP TABLE data.bank INTO LATEST IN $1", localFoo)
+
+	// A second full backup becomes the latest one in the collection.
+	sqlDB.Exec(t, "UPDATE data.bank SET balance = 200")
+	rowsSecond := sqlDB.QueryStr(t, "SELECT * FROM data.bank ORDER BY id")
+	sqlDB.Exec(t, "BACKUP TABLE data.bank INTO $1", localFoo)
+
+	backups := sqlDB.QueryStr(t, "SHOW BACKUPS IN $1", localFoo)
+	require.Equal(t, 2, len(backups))
+	require.Equal(t, first, backups[0][0])
+	second := backups[1][0]
+
+	sqlDB.ExpectErr(t, "does not contain a full backup",
+		"BACKUP TABLE data.bank INTO 'bogus' IN $1", localFoo)
+
+	sqlDB.Exec(t, "CREATE DATABASE restored")
+	sqlDB.Exec(t, "RESTORE data.bank FROM LATEST IN $1 WITH into_db = 'restored'", localFoo)
+	sqlDB.CheckQueryResults(t, "SELECT * FROM restored.bank ORDER BY id", rowsSecond)
+
+	// Explicitly restoring the first full backup includes its incremental layer.
+	sqlDB.Exec(t, "DROP TABLE restored.bank")
+	sqlDB.Exec(t, "RESTORE data.bank FROM $1 IN $2 WITH into_db = 'restored'", first, localFoo)
+	sqlDB.CheckQueryResults(t, "SELECT * FROM restored.bank ORDER BY id", rowsFirst)
+
+	var numTables int
+	sqlDB.QueryRow(t, `SELECT count(*) FROM [SHOW BACKUP LATEST IN $1]`, localFoo).Scan(&numTables)
+	require.Equal(t, 1, numTables)
+	sqlDB.QueryRow(t, `SELECT count(*) FROM [SHOW BACKUP $1 IN $2]`, second, localFoo).Scan(&numTables)
+	require.Equal(t, 1, numTables)
+}
+
 func TestBackupRestorePartitionedMergeDirectories(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 
@@ -0,0 +1,463 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package backupccl
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/utilccl"
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+	pbtypes "github.com/gogo/protobuf/types"
+	"github.com/gorhill/cronexpr"
+)
+
+const (
+	optFirstRun          = "first_run"
+	optOnExecFailure     = "on_execution_failure"
+	optOnPreviousRunning = "on_previous_running"
+)
+
+var scheduledBackupOptionExpectValues = map[string]sql.KVStringOptValidate{
+	optFirstRun:          sql.KVStringOptRequireValue,
+	optOnExecFailure:     sql.KVStringOptRequireValue,
+	optOnPreviousRunning: sql.KVStringOptRequireValue,
+}
+
+// scheduledBackupEval is a representation of tree.ScheduledBackup, prepared
+// for evaluation.
+type scheduledBackupEval struct {
+	*tree.ScheduledBackup
+
+	// Schedule specific properties that get evaluated.
+	scheduleName         func() (string, error)
+	recurrence           func() (string, error)
+	fullBackupRecurrence func() (string, error)
+	scheduleOpts         func() (map[string]string, error)
+
+	// Backup specific properties that get evaluated.
+	destination func() ([]string, error)
+	backupOpts  func() (map[string]string, error)
+	kmsURIs     func() ([]string, error)
+}
+
+func setScheduleOptions(
+	evalCtx *tree.EvalContext, opts map[string]string, sj *jobs.ScheduledJob,
+) error {
+	if v, ok := opts[optFirstRun]; ok {
+		firstRun, err := tree.ParseDTimestampTZ(evalCtx, v, time.Microsecond)
+		if err != nil {
+			return err
+		}
+		sj.SetNextRun(firstRun.Time)
+	}
+
+	var details jobspb.ScheduleDetails
+	if v, ok := opts[optOnExecFailure]; ok {
+		switch v {
+		case "retry":
+			details.OnError = jobspb.ScheduleDetails_RETRY_SOON
+		case "reschedule":
+			details.OnError = jobspb.ScheduleDetails_RETRY_SCHED
+		case "pause":
+			details.OnError = jobspb.ScheduleDetails_PAUSE_SCHED
+		default:
+			return pgerror.Newf(pgcode.InvalidParameterValue,
+				"%q is not a valid %s value; expected retry, reschedule or pause", v, optOnExecFailure)
+		}
+	}
+	if v, ok := opts[optOnPreviousRunning]; ok {
+		switch v {
+		case "start":
+			details.Wait = jobspb.ScheduleDetails_NO_WAIT
+		case "skip":
+			details.Wait = jobspb.ScheduleDetails_SKIP
+		case "wait":
+			details.Wait = jobspb.ScheduleDetails_WAIT
+		default:
+			return pgerror.Newf(pgcode.InvalidParameterValue,
+				"%q is not a valid %s value; expected start, skip or wait", v, optOnPreviousRunning)
+		}
+	}
+	sj.SetScheduleDetails(details)
+	return nil
+}
+
+// pickFullRecurrenceFromIncremental picks a reasonable cadence for full
+// backups, given the cadence of incremental backups: the more frequent the
+// incremental backups, the more frequent the full backups.  Returns an empty
+// string if every backup should be a full backup.
+func pickFullRecurrenceFromIncremental(inc *cronexpr.Expression, now time.Time) string {
+	nextInc := inc.Next(now)
+	incFrequency := inc.Next(nextInc).Sub(nextInc)
+
+	if incFrequency <= time.Hour {
+		// If incremental is once an hour or more often, set full to daily.
+		return "@daily"
+	}
+
+	if incFrequency <= 24*time.Hour {
+		// If incremental is less than once a day, set full to weekly.
+		return "@weekly"
+	}
+
+	// Incremental backups are infrequent; always run full backups.
+	return ""
+}
+
+const scheduleBackupOp = "CREATE SCHEDULE FOR BACKUP"
+
+func doCreateBackupSchedules(
+	ctx context.Context, p sql.PlanHookState, eval *scheduledBackupEval, resultsCh chan<- tree.Datums,
+) error {
+	if err := utilccl.CheckEnterpriseEnabled(
+		p.ExecCfg().Settings, p.ExecCfg().ClusterID(), p.ExecCfg().Organization(), scheduleBackupOp,
+	); err != nil {
+		return err
+	}
+
+	if err := p.RequireAdminRole(ctx, scheduleBackupOp); err != nil {
+		return err
+	}
+
+	scheduleName, err := eval.scheduleName()
+	if err != nil {
+		return err
+	}
+
+	recurrence, err := eval.recurrence()
+	if err != nil {
+		return err
+	}
+	incExpr, err := cronexpr.Parse(recurrence)
+	if err != nil {
+		return pgerror.Wrapf(err, pgcode.InvalidParameterValue,
+			"error parsing schedule expression: %q", recurrence)
+	}
+
+	now := p.ExecCfg().Clock.PhysicalTime()
+
+	fullRecurrence := recurrence
+	incRecurrence := ""
+	if eval.FullBackup == nil {
+		// Pick the full backup cadence based on the requested recurrence; if one
+		// is picked, the requested recurrence is used for incremental backups.
+		if picked := pickFullRecurrenceFromIncremental(incExpr, now); picked != "" {
+			fullRecurrence, incRecurrence = picked, recurrence
+		}
+	} else if !eval.FullBackup.AlwaysFull {
+		fullRecurrence, err = eval.fullBackupRecurrence()
+		if err != nil {
+			return err
+		}
+		if _, err := cronexpr.Parse(fullRecurrence); err != nil {
+			return pgerror.Wrapf(err, pgcode.InvalidParameterValue,
+				"error parsing schedule expression: %q", fullRecurrence)
+		}
+		incRecurrence = recurrence
+	}
+
+	scheduleOpts, err := eval.scheduleOpts()
+	if err != nil {
+		return err
+	}
+
+	// Prepare backup statement.
+	backupNode, err := makeScheduledBackupStatement(eval)
+	if err != nil {
+		return err
+	}
+
+	evalCtx := &p.ExtendedEvalContext().EvalContext
+	ex := p.ExecCfg().InternalExecutor
+	txn := p.ExtendedEvalContext().Txn
+
+	makeSchedule := func(
+		name string, cron string, args *ScheduledBackupExecutionArgs,
+	) (*jobs.ScheduledJob, error) {
+		sj := jobs.NewScheduledJob(nil /* env */)
+		sj.SetScheduleName(name)
+		sj.SetOwner(p.User())
+		if err := sj.SetSchedule(cron); err != nil {
+			return nil, err
+		}
+		if err := setScheduleOptions(evalCtx, scheduleOpts, sj); err != nil {
+			return nil, err
+		}
+		any, err := pbtypes.MarshalAny(args)
+		if err != nil {
+			return nil, err
+		}
+		sj.SetExecutionDetails(ScheduledBackupExecutorName, jobspb.ExecutionArguments{Args: any})
+		return sj, nil
+	}
+
+	var inc *jobs.ScheduledJob
+	if incRecurrence != "" {
+		// Incremental backups are appended to the latest full backup in the
+		// collection, so they can only run once a full backup completes: the
+		// incremental schedule starts paused, and is unpaused by the full backup
+		// schedule.
+		incNode := *backupNode
+		incNode.AppendToLatest = true
+		inc, err = makeSchedule(scheduleName+": INCREMENTAL", incRecurrence,
+			&ScheduledBackupExecutionArgs{
+				BackupType:      ScheduledBackupExecutionArgs_INCREMENTAL,
+				BackupStatement: tree.AsStringWithFlags(&incNode, tree.FmtParsable),
+			})
+		if err != nil {
+			return err
+		}
+		inc.Pause("waiting for initial full backup to complete")
+		if err := inc.Create(ctx, ex, txn); err != nil {
+			return err
+		}
+		if err := emitSchedule(inc, &incNode, resultsCh); err != nil {
+			return err
+		}
+	}
+
+	fullArgs := &ScheduledBackupExecutionArgs{
+		BackupType:      ScheduledBackupExecutionArgs_FULL,
+		BackupStatement: tree.AsStringWithFlags(backupNode, tree.FmtParsable),
+	}
+	if inc != nil {
+		fullArgs.DependentScheduleID = inc.ScheduleID()
+	}
+	full, err := makeSchedule(scheduleName, fullRecurrence, fullArgs)
+	if err != nil {
+		return err
+	}
+	if _, ok := scheduleOpts[optFirstRun]; !ok {
+		// Unless explicitly requested otherwise, run the first full backup
+		// right away so that incremental backups have something to append to.
+		full.SetNextRun(now)
+	}
+	if err := full.Create(ctx, ex, txn); err != nil {
+		return err
+	}
+	return emitSchedule(full, backupNode, resultsCh)
+}
+
+// makeScheduledBackupStatement constructs the BACKUP statement executed by
+// the full backup schedule, which writes a new full backup INTO the backup
+// collection.
+func makeScheduledBackupStatement(eval *scheduledBackupEval) (*tree.Backup, error) {
+	backupNode := &tree.Backup{Nested: true}
+	if eval.Targets == nil {
+		backupNode.DescriptorCoverage = tree.AllDescriptors
+	} else {
+		backupNode.Targets = *eval.Targets
+	}
+
+	destinations, err := eval.destination()
+	if err != nil {
+		return nil, err
+	}
+	for _, dest := range destinations {
+		backupNode.To = append(backupNode.To, tree.NewStrVal(dest))
+	}
+
+	backupOpts, err := eval.backupOpts()
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := backupOpts[backupOptDetached]; ok {
+		return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+			"%s option is not supported by %s", backupOptDetached, scheduleBackupOp)
+	}
+	kmsURIs, err := eval.kmsURIs()
+	if err != nil {
+		return nil, err
+	}
+	// Preserve the order in which the options were specified.
+	for _, opt := range eval.BackupOptions {
+		key := string(opt.Key)
+		if key == backupOptEncKMS {
+			continue
+		}
+		kv := tree.KVOption{Key: opt.Key}
+		if v := backupOpts[key]; v != "" {
+			kv.Value = tree.NewStrVal(v)
+		}
+		backupNode.Options = append(backupNode.Options, kv)
+	}
+	if len(kmsURIs) > 0 {
+		uris := make(tree.Exprs, len(kmsURIs))
+		for i := range kmsURIs {
+			uris[i] = tree.NewStrVal(kmsURIs[i])
+		}
+		kv := tree.KVOption{Key: backupOptEncKMS, Value: uris[0]}
+		if len(uris) > 1 {
+			kv.Value = &tree.Tuple{Exprs: uris}
+		}
+		backupNode.Options = append(backupNode.Options, kv)
+	}
+	return backupNode, nil
+}
+
+func emitSchedule(
+	sj *jobs.ScheduledJob, backupNode *tree.Backup, resultsCh chan<- tree.Datums,
+) error {
+	var nextRun tree.Datum
+	status := "ACTIVE"
+	if sj.IsPaused() {
+		nextRun = tree.DNull
+		status = "PAUSED"
+	} else {
+		next, err := tree.MakeDTimestampTZ(sj.NextRun(), time.Microsecond)
+		if err != nil {
+			return err
+		}
+		nextRun = next
+	}
+
+	redacted, err := redactBackupStatement(backupNode)
+	if err != nil {
+		return err
+	}
+	resultsCh <- tree.Datums{
+		tree.NewDInt(tree.DInt(sj.ScheduleID())),
+		tree.NewDString(sj.ScheduleName()),
+		tree.NewDString(status),
+		nextRun,
+		tree.NewDString(sj.ScheduleExpr()),
+		tree.NewDString(tree.AsString(redacted)),
+	}
+	return nil
+}
+
+// redactBackupStatement returns a copy of the backup statement with any
+// sensitive options redacted.
+func redactBackupStatement(backupNode *tree.Backup) (*tree.Backup, error) {
+	redacted := *backupNode
+	redacted.Options = nil
+	for _, opt := range backupNode.Options {
+		switch string(opt.Key) {
+		case backupOptEncPassphrase:
+			opt.Value = tree.NewStrVal("redacted")
+		case backupOptEncKMS:
+			var uris tree.Exprs
+			if t, ok := opt.Value.(*tree.Tuple); ok {
+				uris = t.Exprs
+			} else {
+				uris = tree.Exprs{opt.Value}
+			}
+			kmsURIs := make([]string, len(uris))
+			for i := range uris {
+				kmsURIs[i] = uris[i].(*tree.StrVal).RawString()
+			}
+			var err error
+			if opt, err = makeKMSOption(kmsURIs); err != nil {
+				return nil, err
+			}
+		}
+		redacted.Options = append(redacted.Options, opt)
+	}
+	return &redacted, nil
+}
+
+func makeScheduledBackupEval(
+	ctx context.Context, p sql.PlanHookState, schedule *tree.ScheduledBackup,
+) (*scheduledBackupEval, error) {
+	eval := &scheduledBackupEval{ScheduledBackup: schedule}
+	var err error
+
+	if schedule.ScheduleLabel != nil {
+		eval.scheduleName, err = p.TypeAsString(ctx, schedule.ScheduleLabel, scheduleBackupOp)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		eval.scheduleName = func() (string, error) {
+			return fmt.Sprintf("BACKUP %d", p.ExecCfg().Clock.PhysicalNow()), nil
+		}
+	}
+
+	eval.recurrence, err = p.TypeAsString(ctx, schedule.Recurrence, scheduleBackupOp)
+	if err != nil {
+		return nil, err
+	}
+
+	if schedule.FullBackup != nil && !schedule.FullBackup.AlwaysFull {
+		eval.fullBackupRecurrence, err = p.TypeAsString(
+			ctx, schedule.FullBackup.Recurrence, scheduleBackupOp)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	eval.scheduleOpts, err = p.TypeAsStringOpts(
+		ctx, schedule.ScheduleOptions, scheduledBackupOptionExpectValues)
+	if err != nil {
+		return nil, err
+	}
+
+	eval.destination, err = p.TypeAsStringArray(ctx, tree.Exprs(schedule.To), scheduleBackupOp)
+	if err != nil {
+		return nil, err
+	}
+
+	backupOpts, kmsURIs, err := splitKMSOption(ctx, p, schedule.BackupOptions, scheduleBackupOp)
+	if err != nil {
+		return nil, err
+	}
+	eval.kmsURIs = kmsURIs
+	eval.backupOpts, err = p.TypeAsStringOpts(ctx, backupOpts, backupOptionExpectValues)
+	if err != nil {
+		return nil, err
+	}
+	return eval, nil
+}
+
+var scheduledBackupHeader = sqlbase.ResultColumns{
+	{Name: "schedule_id", Typ: types.Int},
+	{Name: "name", Typ: types.String},
+	{Name: "status", Typ: types.String},
+	{Name: "first_run", Typ: types.TimestampTZ},
+	{Name: "schedule", Typ: types.String},
+	{Name: "backup_stmt", Typ: types.String},
+}
+
+// createBackupScheduleHook implements sql.PlanHookFn.
+func createBackupScheduleHook(
+	ctx context.Context, stmt tree.Statement, p sql.PlanHookState,
+) (sql.PlanHookRowFn, sqlbase.ResultColumns, []sql.PlanNode, bool, error) {
+	schedule, ok := stmt.(*tree.ScheduledBackup)
+	if !ok {
+		return nil, nil, nil, false, nil
+	}
+
+	eval, err := makeScheduledBackupEval(ctx, p, schedule)
+	if err != nil {
+		return nil, nil, nil, false, err
+	}
+
+	fn := func(ctx context.Context, _ []sql.PlanNode, resultsCh chan<- tree.Datums) error {
+		err := doCreateBackupSchedules(ctx, p, eval, resultsCh)
+		if err != nil {
+			return errors.Wrapf(err, "failed to create backup schedule")
+		}
+		return nil
+	}
+	return fn, scheduledBackupHeader, nil, false, nil
+}
+
+func init() {
+	sql.AddPlanHook(createBackupScheduleHook)
+}
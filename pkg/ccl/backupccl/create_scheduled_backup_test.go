@@ -0,0 +1,237 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package backupccl_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/require"
+)
+
+type expectedSchedule struct {
+	name       string
+	status     string
+	recurrence string
+	backupStmt string
+}
+
+func readSchedules(t *testing.T, sqlDB *sqlutils.SQLRunner, query string) []expectedSchedule {
+	rows := sqlDB.Query(t, query)
+	defer rows.Close()
+
+	var schedules []expectedSchedule
+	for rows.Next() {
+		var id int64
+		var s expectedSchedule
+		var firstRun interface{}
+		require.NoError(t, rows.Scan(&id, &s.name, &s.status, &firstRun, &s.recurrence, &s.backupStmt))
+		schedules = append(schedules, s)
+	}
+	require.NoError(t, rows.Err())
+	return schedules
+}
+
+func TestCreateBackupSchedule(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	_, _, sqlDB, _, cleanupFn := backupRestoreTestSetup(t, singleNode, 0, initNone)
+	defer cleanupFn()
+
+	// Prevent the scheduler from running the schedules we create.
+	sqlDB.Exec(t, `SET CLUSTER SETTING jobs.scheduler.enabled = false`)
+
+	for _, tc := range []struct {
+		name     string
+		query    string
+		expected []expectedSchedule
+	}{
+		{
+			name:  "full-cluster",
+			query: "CREATE SCHEDULE 'full' FOR BACKUP INTO 'nodelocal://0/backup' RECURRING '@hourly'",
+			expected: []expectedSchedule{
+				{
+					name:       "full: INCREMENTAL",
+					status:     "PAUSED",
+					recurrence: "@hourly",
+					backupStmt: "BACKUP  INTO LATEST IN 'nodelocal://0/backup'",
+				},
+				{
+					name:       "full",
+					status:     "ACTIVE",
+					recurrence: "@daily",
+					backupStmt: "BACKUP  INTO 'nodelocal://0/backup'",
+				},
+			},
+		},
+		{
+			name: "always-full",
+			query: `CREATE SCHEDULE 'tables' FOR BACKUP TABLE data.bank INTO 'nodelocal://0/backup'
+                WITH revision_history RECURRING '@daily' FULL BACKUP ALWAYS`,
+			expected: []expectedSchedule{
+				{
+					name:       "tables",
+					status:     "ACTIVE",
+					recurrence: "@daily",
+					backupStmt: "BACKUP TABLE data.bank INTO 'nodelocal://0/backup' WITH revision_history",
+				},
+			},
+		},
+		{
+			name: "explicit-full-recurrence",
+			query: `CREATE SCHEDULE 'db' FOR BACKUP DATABASE data INTO 'nodelocal://0/backup'
+                WITH encryption_passphrase = 'secret' RECURRING '*/15 * * * *' FULL BACKUP '@weekly'`,
+			expected: []expectedSchedule{
+				{
+					name:       "db: INCREMENTAL",
+					status:     "PAUSED",
+					recurrence: "*/15 * * * *",
+					backupStmt: "BACKUP DATABASE data INTO LATEST IN 'nodelocal://0/backup' WITH encryption_passphrase = 'redacted'",
+				},
+				{
+					name:       "db",
+					status:     "ACTIVE",
+					recurrence: "@weekly",
+					backupStmt: "BACKUP DATABASE data INTO 'nodelocal://0/backup' WITH encryption_passphrase = 'redacted'",
+				},
+			},
+		},
+		{
+			name:  "infrequent-backups-are-always-full",
+			query: "CREATE SCHEDULE 'weekly' FOR BACKUP INTO 'nodelocal://0/backup' RECURRING '@weekly'",
+			expected: []expectedSchedule{
+				{
+					name:       "weekly",
+					status:     "ACTIVE",
+					recurrence: "@weekly",
+					backupStmt: "BACKUP  INTO 'nodelocal://0/backup'",
+				},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, readSchedules(t, sqlDB, tc.query))
+		})
+	}
+
+	t.Run("errors", func(t *testing.T) {
+		sqlDB.ExpectErr(t, "error parsing schedule expression",
+			"CREATE SCHEDULE FOR BACKUP INTO 'nodelocal://0/backup' RECURRING 'bogus'")
+		sqlDB.ExpectErr(t, "error parsing schedule expression",
+			"CREATE SCHEDULE FOR BACKUP INTO 'nodelocal://0/backup' RECURRING '@hourly' FULL BACKUP 'bogus'")
+		sqlDB.ExpectErr(t, "detached option is not supported",
+			"CREATE SCHEDULE FOR BACKUP INTO 'nodelocal://0/backup' WITH detached RECURRING '@hourly'")
+		sqlDB.ExpectErr(t, "not a valid on_previous_running value",
+			`CREATE SCHEDULE FOR BACKUP INTO 'nodelocal://0/backup' RECURRING '@hourly'
+       WITH SCHEDULE OPTIONS on_previous_running = 'bogus'`)
+	})
+
+	t.Run("control-schedules", func(t *testing.T) {
+		var id int64
+		sqlDB.QueryRow(t, `SELECT id FROM [SHOW SCHEDULES] WHERE label = 'weekly'`).Scan(&id)
+
+		status := func() string {
+			var s string
+			sqlDB.QueryRow(t, fmt.Sprintf(`SELECT schedule_status FROM [SHOW SCHEDULE %d]`, id)).Scan(&s)
+			return s
+		}
+		require.Equal(t, "ACTIVE", status())
+
+		sqlDB.Exec(t, fmt.Sprintf(`PAUSE SCHEDULE %d`, id))
+		require.Equal(t, "PAUSED", status())
+
+		sqlDB.Exec(t, fmt.Sprintf(`RESUME SCHEDULE %d`, id))
+		require.Equal(t, "ACTIVE", status())
+
+		sqlDB.Exec(t, fmt.Sprintf(`DROP SCHEDULE %d`, id))
+		sqlDB.CheckQueryResults(t,
+			fmt.Sprintf(`SELECT count(*) FROM [SHOW SCHEDULE %d]`, id), [][]string{{"0"}})
+
+		sqlDB.ExpectErr(t, "does not exist", fmt.Sprintf(`PAUSE SCHEDULE %d`, id))
+	})
+}
+
+func TestScheduledBackupRunsFullAndIncrementalBackups(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	defer func(oldInterval time.Duration) {
+		jobs.DefaultAdoptInterval = oldInterval
+	}(jobs.DefaultAdoptInterval)
+	jobs.DefaultAdoptInterval = 100 * time.Millisecond
+
+	_, _, sqlDB, _, cleanupFn := backupRestoreTestSetup(t, singleNode, 10, initNone)
+	defer cleanupFn()
+
+	sqlDB.Exec(t, `SET CLUSTER SETTING jobs.scheduler.pace = '100ms'`)
+
+	rows := sqlDB.Query(t, `
+CREATE SCHEDULE 'bank' FOR BACKUP TABLE data.bank INTO 'nodelocal://0/backup'
+RECURRING '@hourly' FULL BACKUP '@daily'`)
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		var name, status, recurrence, stmt string
+		var firstRun interface{}
+		require.NoError(t, rows.Scan(&id, &name, &status, &firstRun, &recurrence, &stmt))
+		ids = append(ids, id)
+	}
+	require.NoError(t, rows.Err())
+	require.NoError(t, rows.Close())
+	require.Equal(t, 2, len(ids))
+	incID, fullID := ids[0], ids[1]
+
+	waitForSucceededJob := func(scheduleID int64) {
+		testutils.SucceedsSoon(t, func() error {
+			var status string
+			var errMsg string
+			if err := sqlDB.DB.QueryRowContext(context.Background(), `
+SELECT j.status, COALESCE(j.error, '') FROM crdb_internal.jobs AS j
+JOIN system.jobs AS s ON j.job_id = s.id
+WHERE s.created_by_type = $1 AND s.created_by_id = $2`,
+				jobs.CreatedByScheduledJobs, scheduleID).Scan(&status, &errMsg); err != nil {
+				return err
+			}
+			if status != string(jobs.StatusSucceeded) {
+				return errors.Newf("job created by schedule %d is %s (%s)", scheduleID, status, errMsg)
+			}
+			return nil
+		})
+	}
+
+	// The full backup runs right away; once it completes, the incremental
+	// schedule is unpaused.
+	waitForSucceededJob(fullID)
+	sqlDB.CheckQueryResults(t,
+		fmt.Sprintf(`SELECT next_run IS NOT NULL FROM system.scheduled_jobs WHERE schedule_id = %d`, incID),
+		[][]string{{"true"}})
+
+	// Force the incremental backup to run now.
+	sqlDB.Exec(t, `INSERT INTO data.bank VALUES (100, 100, 'new row')`)
+	sqlDB.Exec(t, `UPDATE system.scheduled_jobs SET next_run = now() WHERE schedule_id = $1`, incID)
+	waitForSucceededJob(incID)
+
+	// The incremental backup was appended to the latest full backup in the
+	// collection: restoring it includes the row inserted after the full backup.
+	sqlDB.CheckQueryResults(t,
+		`SELECT count(DISTINCT end_time) FROM [SHOW BACKUP LATEST IN 'nodelocal://0/backup']`,
+		[][]string{{"2"}})
+	sqlDB.Exec(t, `CREATE DATABASE restored`)
+	sqlDB.Exec(t, `RESTORE data.bank FROM LATEST IN 'nodelocal://0/backup' WITH into_db = 'restored'`)
+	sqlDB.CheckQueryResults(t, `SELECT count(*) FROM restored.bank`, [][]string{{"11"}})
+}
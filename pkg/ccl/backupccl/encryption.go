@@ -0,0 +1,283 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package backupccl
+
+import (
+	"context"
+	"path"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/ccl/storageccl"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/storage/cloud"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+)
+
+// Backups are encrypted either with a key derived from a passphrase, or with a
+// randomly generated data key which is itself encrypted (wrapped) by one or
+// more KMS master keys. In both cases the EncryptionInfo needed to recover the
+// key is stored in plaintext alongside the backup. Since only the data key is
+// encrypted using the master keys, master keys can be added to a backup (see
+// addKMSMasterKeys), e.g. to rotate them, without re-encrypting the backup.
+//
+// The encryption info of a backup is never rewritten once the backup has been
+// created. Instead, an incremental backup which adds master keys records them
+// in an encryption info in its own directory, and the encryption info of all
+// of the layers of a backup is merged when reading it (see
+// readEncryptionInfoLayers). As a consequence, master keys cannot be removed
+// from a backup: a master key that should no longer be able to decrypt a
+// backup requires taking a new full backup and deleting the old one.
+
+// backupKMSEnv is the environment in which the KMSs used by BACKUP, RESTORE
+// and SHOW BACKUP are constructed.
+type backupKMSEnv struct {
+	settings *cluster.Settings
+	conf     *base.ExternalIODirConfig
+}
+
+var _ cloud.KMSEnv = &backupKMSEnv{}
+
+func makeBackupKMSEnv(execCfg *sql.ExecutorConfig) *backupKMSEnv {
+	return &backupKMSEnv{settings: execCfg.Settings, conf: &execCfg.ExternalIODirConfig}
+}
+
+// ClusterSettings implements the cloud.KMSEnv interface.
+func (e *backupKMSEnv) ClusterSettings() *cluster.Settings {
+	return e.settings
+}
+
+// KMSConfig implements the cloud.KMSEnv interface.
+func (e *backupKMSEnv) KMSConfig() *base.ExternalIODirConfig {
+	return e.conf
+}
+
+// splitKMSOption removes the kms option, whose value is either a single KMS
+// URI or a tuple of them, from opts. It returns the remaining options along
+// with a function that evaluates the KMS URIs.
+func splitKMSOption(
+	ctx context.Context, p sql.PlanHookState, opts tree.KVOptions, op string,
+) (tree.KVOptions, func() ([]string, error), error) {
+	var rest tree.KVOptions
+	var uris tree.Exprs
+	for _, opt := range opts {
+		if string(opt.Key) != backupOptEncKMS {
+			rest = append(rest, opt)
+			continue
+		}
+		switch v := opt.Value.(type) {
+		case nil:
+			return nil, nil, errors.Errorf("option %q requires a value", backupOptEncKMS)
+		case *tree.Tuple:
+			uris = append(uris, v.Exprs...)
+		default:
+			uris = append(uris, v)
+		}
+	}
+	urisFn, err := p.TypeAsStringArray(ctx, uris, op)
+	if err != nil {
+		return nil, nil, err
+	}
+	return rest, urisFn, nil
+}
+
+// makeKMSOption returns the kms option specifying the given KMS URIs, with
+// any secrets in them redacted.
+func makeKMSOption(kmsURIs []string) (tree.KVOption, error) {
+	sanitized := make(tree.Exprs, len(kmsURIs))
+	for i, uri := range kmsURIs {
+		s, err := cloud.SanitizeExternalStorageURI(uri, nil /* extraParams */)
+		if err != nil {
+			return tree.KVOption{}, err
+		}
+		sanitized[i] = tree.NewDString(s)
+	}
+	opt := tree.KVOption{Key: backupOptEncKMS, Value: sanitized[0]}
+	if len(sanitized) > 1 {
+		opt.Value = &tree.Tuple{Exprs: sanitized}
+	}
+	return opt, nil
+}
+
+// makeKMSEncryptionInfo generates a new data key for a backup encrypted using
+// KMS. It returns the data key along with the EncryptionInfo recording it,
+// wrapped by each of the KMS master keys.
+func makeKMSEncryptionInfo(
+	ctx context.Context, kmsURIs []string, env cloud.KMSEnv,
+) (*EncryptionInfo, []byte, error) {
+	dataKey, err := storageccl.GenerateDataKey()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "generating data key")
+	}
+	encInfo := &EncryptionInfo{EncryptedDataKeyByKMSMasterKeyID: make(map[string][]byte)}
+	if _, err := addKMSMasterKeys(ctx, encInfo, dataKey, kmsURIs, env); err != nil {
+		return nil, nil, err
+	}
+	return encInfo, dataKey, nil
+}
+
+// addKMSMasterKeys records in encInfo the data key wrapped by each of the KMS
+// master keys that it is not yet wrapped by, returning whether any were added.
+func addKMSMasterKeys(
+	ctx context.Context, encInfo *EncryptionInfo, dataKey []byte, kmsURIs []string, env cloud.KMSEnv,
+) (bool, error) {
+	added := false
+	for _, uri := range kmsURIs {
+		if err := func() error {
+			kms, err := cloud.KMSFromURI(uri, env)
+			if err != nil {
+				return err
+			}
+			defer kms.Close()
+			id, err := kms.MasterKeyID()
+			if err != nil {
+				return err
+			}
+			if _, ok := encInfo.EncryptedDataKeyByKMSMasterKeyID[id]; ok {
+				return nil
+			}
+			wrapped, err := kms.Encrypt(ctx, dataKey)
+			if err != nil {
+				return errors.Wrapf(err, "encrypting data key with KMS master key %q", id)
+			}
+			encInfo.EncryptedDataKeyByKMSMasterKeyID[id] = wrapped
+			added = true
+			return nil
+		}(); err != nil {
+			return false, err
+		}
+	}
+	return added, nil
+}
+
+// getEncryptionKey returns the key that the files of the backup described by
+// encInfo are encrypted with: either derived from the passphrase, or unwrapped
+// using the first of the KMS master keys that wrapped it.
+func getEncryptionKey(
+	ctx context.Context,
+	encInfo *EncryptionInfo,
+	passphrase []byte,
+	kmsURIs []string,
+	env cloud.KMSEnv,
+) ([]byte, error) {
+	if len(kmsURIs) == 0 {
+		if len(encInfo.EncryptedDataKeyByKMSMasterKeyID) > 0 {
+			return nil, errors.Errorf("backup was encrypted using KMS -- try specifying %q", backupOptEncKMS)
+		}
+		return storageccl.GenerateKey(passphrase, encInfo.Salt), nil
+	}
+	if len(encInfo.EncryptedDataKeyByKMSMasterKeyID) == 0 {
+		return nil, errors.Errorf(
+			"backup was encrypted using a passphrase -- try specifying %q", backupOptEncPassphrase)
+	}
+
+	var kmsErr error
+	for _, uri := range kmsURIs {
+		dataKey, err := func() ([]byte, error) {
+			kms, err := cloud.KMSFromURI(uri, env)
+			if err != nil {
+				return nil, err
+			}
+			defer kms.Close()
+			id, err := kms.MasterKeyID()
+			if err != nil {
+				return nil, err
+			}
+			wrapped, ok := encInfo.EncryptedDataKeyByKMSMasterKeyID[id]
+			if !ok {
+				return nil, nil
+			}
+			dataKey, err := kms.Decrypt(ctx, wrapped)
+			if err != nil {
+				return nil, errors.Wrapf(err, "decrypting data key with KMS master key %q", id)
+			}
+			return dataKey, nil
+		}()
+		if err != nil {
+			kmsErr = errors.CombineErrors(kmsErr, err)
+			continue
+		}
+		if dataKey != nil {
+			return dataKey, nil
+		}
+	}
+	if kmsErr != nil {
+		return nil, kmsErr
+	}
+	return nil, errors.New("backup was not encrypted using any of the specified KMS master keys")
+}
+
+// readEncryptionInfoLayers reads the encryption info of the backup in
+// baseStore and merges into it the KMS master keys recorded by its incremental
+// layers: both those given explicitly by layerURIs and those appended to the
+// backup in subdirectories of baseStore.
+func readEncryptionInfoLayers(
+	ctx context.Context,
+	baseStore cloud.ExternalStorage,
+	layerURIs []string,
+	mkStore cloud.ExternalStorageFromURIFactory,
+) (*EncryptionInfo, error) {
+	encInfo, err := readEncryptionOptions(ctx, baseStore)
+	if err != nil {
+		return nil, err
+	}
+	// Backups encrypted using a passphrase cannot gain master keys.
+	if len(encInfo.EncryptedDataKeyByKMSMasterKeyID) == 0 {
+		return encInfo, nil
+	}
+
+	prev, err := findPriorBackups(ctx, baseStore)
+	if err != nil {
+		if !errors.Is(err, cloud.ErrListingUnsupported) {
+			return nil, err
+		}
+		log.Warningf(ctx, "storage sink %T does not support listing, only reading encryption info of the base backup", baseStore)
+		prev = nil
+	}
+	for _, p := range prev {
+		if err := mergeLayerEncryptionInfo(ctx, encInfo, baseStore, path.Dir(p)); err != nil {
+			return nil, err
+		}
+	}
+	for _, uri := range layerURIs {
+		if err := func() error {
+			store, err := mkStore(ctx, uri)
+			if err != nil {
+				return err
+			}
+			defer store.Close()
+			return mergeLayerEncryptionInfo(ctx, encInfo, store, "" /* dir */)
+		}(); err != nil {
+			return nil, err
+		}
+	}
+	return encInfo, nil
+}
+
+// mergeLayerEncryptionInfo adds to encInfo the KMS master keys recorded in the
+// encryption info of the incremental layer in dir of store, if any.
+func mergeLayerEncryptionInfo(
+	ctx context.Context, encInfo *EncryptionInfo, store cloud.ExternalStorage, dir string,
+) error {
+	layerInfo, err := readEncryptionOptionsFile(ctx, store, path.Join(dir, encryptionInfoFilename))
+	if err != nil {
+		if errors.Is(err, cloud.ErrFileDoesNotExist) {
+			return nil
+		}
+		return err
+	}
+	for id, wrapped := range layerInfo.EncryptedDataKeyByKMSMasterKeyID {
+		if _, ok := encInfo.EncryptedDataKeyByKMSMasterKeyID[id]; !ok {
+			encInfo.EncryptedDataKeyByKMSMasterKeyID[id] = wrapped
+		}
+	}
+	return nil
+}
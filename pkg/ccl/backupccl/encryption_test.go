@@ -0,0 +1,145 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package backupccl_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/storageccl"
+	"github.com/cockroachdb/cockroach/pkg/storage/cloud"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/stretchr/testify/require"
+)
+
+// testKMS is a fake KMS for URIs of the form testkms:///<key ID>, which
+// encrypts using a master key derived from the key ID.
+type testKMS struct {
+	keyID string
+}
+
+var _ cloud.KMS = &testKMS{}
+
+func init() {
+	cloud.RegisterKMSFromURIFactory(func(uri string, _ cloud.KMSEnv) (cloud.KMS, error) {
+		parsed, err := url.Parse(uri)
+		if err != nil {
+			return nil, err
+		}
+		return &testKMS{keyID: strings.TrimPrefix(parsed.Path, "/")}, nil
+	}, "testkms")
+}
+
+func (k *testKMS) masterKey() []byte {
+	key := sha256.Sum256([]byte(k.keyID))
+	return key[:]
+}
+
+func (k *testKMS) MasterKeyID() (string, error) {
+	return k.keyID, nil
+}
+
+func (k *testKMS) Encrypt(_ context.Context, data []byte) ([]byte, error) {
+	return storageccl.EncryptFile(data, k.masterKey())
+}
+
+func (k *testKMS) Decrypt(_ context.Context, data []byte) ([]byte, error) {
+	return storageccl.DecryptFile(data, k.masterKey())
+}
+
+func (k *testKMS) Close() error {
+	return nil
+}
+
+func TestBackupEncryptedWithKMS(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	_, _, sqlDB, rawDir, cleanupFn := backupRestoreTestSetup(t, singleNode, 10, initNone)
+	defer cleanupFn()
+
+	const (
+		key1 = "testkms:///key1"
+		key2 = "testkms:///key2?AWS_SECRET_ACCESS_KEY=secret"
+		key3 = "testkms:///key3"
+	)
+
+	sqlDB.Exec(t, `UPDATE data.bank SET payload = 'neverappears'`)
+	sqlDB.Exec(t, `BACKUP DATABASE data TO $1 WITH kms = ($2, $3)`, localFoo, key1, key2)
+
+	// Secrets in the KMS URIs are redacted from the job description.
+	var description string
+	sqlDB.QueryRow(t,
+		`SELECT description FROM [SHOW JOBS] WHERE job_type = 'BACKUP'`,
+	).Scan(&description)
+	require.Contains(t, description,
+		`kms = ('testkms:///key1', 'testkms:///key2?AWS_SECRET_ACCESS_KEY=redacted')`)
+
+	// The backup can be read using any one of its master keys, and only those.
+	sqlDB.Exec(t, `SHOW BACKUP $1 WITH kms = $2`, localFoo, key2)
+	sqlDB.Exec(t, `SHOW BACKUP $1 WITH kms = ($2, $3)`, localFoo, key3, key1)
+	sqlDB.ExpectErr(t, `file appears encrypted -- try specifying "encryption_passphrase" or "kms"`,
+		`SHOW BACKUP $1`, localFoo)
+	sqlDB.ExpectErr(t, `backup was not encrypted using any of the specified KMS master keys`,
+		`SHOW BACKUP $1 WITH kms = $2`, localFoo, key3)
+	sqlDB.ExpectErr(t, `backup was encrypted using KMS -- try specifying "kms"`,
+		`SHOW BACKUP $1 WITH encryption_passphrase = 'abcdefg'`, localFoo)
+	sqlDB.ExpectErr(t, `cannot specify both "encryption_passphrase" and "kms"`,
+		`BACKUP DATABASE data TO $1 WITH encryption_passphrase = 'abcdefg', kms = $2`,
+		localFoo+"/both", key1)
+
+	// Appending an incremental backup requires one of the existing master keys,
+	// and any new master keys given are added to the backup. This allows the
+	// master keys to be rotated without re-encrypting the backup. The new
+	// master keys are recorded by the incremental backup, leaving the
+	// encryption info of the full backup untouched.
+	baseEncInfoPath := filepath.Join(rawDir, "foo", "encryption-info")
+	baseEncInfo, err := ioutil.ReadFile(baseEncInfoPath)
+	require.NoError(t, err)
+	sqlDB.Exec(t, `UPDATE data.bank SET balance = balance + 1`)
+	sqlDB.ExpectErr(t, `backup was not encrypted using any of the specified KMS master keys`,
+		`BACKUP DATABASE data TO $1 WITH kms = $2`, localFoo, key3)
+	sqlDB.Exec(t, `BACKUP DATABASE data TO $1 WITH kms = ($2, $3)`, localFoo, key1, key3)
+	after, err := ioutil.ReadFile(baseEncInfoPath)
+	require.NoError(t, err)
+	require.Equal(t, baseEncInfo, after)
+	expected := sqlDB.QueryStr(t, `SELECT * FROM data.bank ORDER BY id`)
+
+	checkedFiles := 0
+	if err := filepath.Walk(rawDir, func(path string, info os.FileInfo, err error) error {
+		if !info.IsDir() {
+			data, err := ioutil.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if bytes.Contains(data, []byte("neverappears")) {
+				t.Errorf("found cleartext occurrence of sentinel string in %s", path)
+			}
+			checkedFiles++
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if checkedFiles == 0 {
+		t.Fatal("test didn't check any files")
+	}
+
+	// The whole chain can now be restored using only the new master key.
+	sqlDB.Exec(t, `SHOW BACKUP $1 WITH kms = $2`, localFoo, key3)
+	sqlDB.Exec(t, `DROP DATABASE data CASCADE`)
+	sqlDB.Exec(t, `RESTORE DATABASE data FROM $1 WITH kms = $2`, localFoo, key3)
+	sqlDB.CheckQueryResults(t, `SELECT * FROM data.bank ORDER BY id`, expected)
+}
@@ -17,6 +17,7 @@ import (
 	"net/url"
 	"path"
 	"sort"
+	"strings"
 
 	"github.com/cockroachdb/cockroach/pkg/ccl/storageccl"
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
@@ -24,6 +25,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
 	"github.com/cockroachdb/cockroach/pkg/storage/cloud"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
@@ -50,6 +52,16 @@ const (
 	BackupFormatDescriptorTrackingVersion uint32 = 1
 	// ZipType is the format of a GZipped compressed file.
 	ZipType = "application/x-gzip"
+
+	// latestFileName is the name of the file, in the root of a backup
+	// collection, that records the subdirectory of the latest full backup in
+	// that collection.
+	latestFileName = "LATEST"
+
+	// encryptionInfoFilename is the name of the file, in the directory of an
+	// encrypted backup, that records the EncryptionInfo needed to recover the
+	// key it is encrypted with.
+	encryptionInfoFilename = "encryption-info"
 )
 
 // BackupFileDescriptors is an alias on which to implement sort's interface.
@@ -172,7 +184,7 @@ func readBackupManifest(
 	if err := protoutil.Unmarshal(descBytes, &backupManifest); err != nil {
 		if encryption == nil && storageccl.AppearsEncrypted(descBytes) {
 			return BackupManifest{}, errors.Wrapf(
-				err, "file appears encrypted -- try specifying %q", backupOptEncPassphrase)
+				err, "file appears encrypted -- try specifying %q or %q", backupOptEncPassphrase, backupOptEncKMS)
 		}
 		return BackupManifest{}, err
 	}
@@ -372,6 +384,79 @@ func findPriorBackups(ctx context.Context, store cloud.ExternalStorage) ([]strin
 	return prev, nil
 }
 
+// readLatestFile returns the subdirectory of the latest full backup in the
+// backup collection at collectionURI.
+func readLatestFile(
+	ctx context.Context, collectionURI string, mkStore cloud.ExternalStorageFromURIFactory,
+) (string, error) {
+	collection, err := mkStore(ctx, collectionURI)
+	if err != nil {
+		return "", err
+	}
+	defer collection.Close()
+	r, err := collection.ReadFile(ctx, latestFileName)
+	if err != nil {
+		if errors.Is(err, cloud.ErrFileDoesNotExist) {
+			return "", pgerror.Wrapf(err, pgcode.UndefinedFile,
+				"path does not contain a completed latest backup")
+		}
+		return "", errors.Wrap(err, "reading latest backup in collection")
+	}
+	defer r.Close()
+	latest, err := ioutil.ReadAll(r)
+	if err != nil {
+		return "", errors.Wrap(err, "reading latest backup in collection")
+	}
+	if len(latest) == 0 {
+		return "", errors.Errorf("malformed %s file in backup collection", latestFileName)
+	}
+	return string(latest), nil
+}
+
+// writeLatestFile records subdir as the latest full backup in the backup
+// collection at collectionURI.
+func writeLatestFile(
+	ctx context.Context,
+	collectionURI string,
+	subdir string,
+	mkStore cloud.ExternalStorageFromURIFactory,
+) error {
+	collection, err := mkStore(ctx, collectionURI)
+	if err != nil {
+		return err
+	}
+	defer collection.Close()
+	return collection.WriteFile(ctx, latestFileName, bytes.NewReader([]byte(subdir)))
+}
+
+// resolveBackupInCollection returns the URIs of the backup in the specified
+// subdirectory of the (possibly partitioned) backup collection, resolving
+// LATEST to the latest full backup in the collection.
+func resolveBackupInCollection(
+	ctx context.Context,
+	collectionURIs []string,
+	subdir string,
+	mkStore cloud.ExternalStorageFromURIFactory,
+) ([]string, error) {
+	if strings.EqualFold(subdir, tree.LatestBackupSubdir) {
+		collectionURI, _, err := getURIsByLocalityKV(collectionURIs, "")
+		if err != nil {
+			return nil, err
+		}
+		if subdir, err = readLatestFile(ctx, collectionURI, mkStore); err != nil {
+			return nil, err
+		}
+	}
+	uris := make([]string, len(collectionURIs))
+	for i := range collectionURIs {
+		var err error
+		if uris[i], err = appendPath(collectionURIs[i], subdir); err != nil {
+			return nil, err
+		}
+	}
+	return uris, nil
+}
+
 // resolveBackupManifests resolves a list of list of URIs that point to the
 // incremental layers (each of which can be partitioned) of backups into the
 // actual backup manifests and metadata required to RESTORE. If only one layer
@@ -621,7 +706,13 @@ func sanitizeLocalityKV(kv string) string {
 func readEncryptionOptions(
 	ctx context.Context, src cloud.ExternalStorage,
 ) (*EncryptionInfo, error) {
-	r, err := src.ReadFile(ctx, "encryption-info")
+	return readEncryptionOptionsFile(ctx, src, encryptionInfoFilename)
+}
+
+func readEncryptionOptionsFile(
+	ctx context.Context, src cloud.ExternalStorage, filename string,
+) (*EncryptionInfo, error) {
+	r, err := src.ReadFile(ctx, filename)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not find or read encryption information")
 	}
@@ -644,7 +735,7 @@ func writeEncryptionOptions(
 	if err != nil {
 		return err
 	}
-	if err := dest.WriteFile(ctx, "encryption-info", bytes.NewReader(buf)); err != nil {
+	if err := dest.WriteFile(ctx, encryptionInfoFilename, bytes.NewReader(buf)); err != nil {
 		return err
 	}
 	return nil
@@ -12,7 +12,6 @@ import (
 	"context"
 	"sort"
 
-	"github.com/cockroachdb/cockroach/pkg/ccl/storageccl"
 	"github.com/cockroachdb/cockroach/pkg/ccl/utilccl"
 	"github.com/cockroachdb/cockroach/pkg/jobs"
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
@@ -565,7 +564,11 @@ func errOnMissingRange(span covering.Range, start, end hlc.Timestamp) error {
 }
 
 func restoreJobDescription(
-	p sql.PlanHookState, restore *tree.Restore, from [][]string, opts map[string]string,
+	p sql.PlanHookState,
+	restore *tree.Restore,
+	from [][]string,
+	kmsURIs []string,
+	opts map[string]string,
 ) (string, error) {
 	r := &tree.Restore{
 		AsOf:    restore.AsOf,
@@ -573,6 +576,13 @@ func restoreJobDescription(
 		Targets: restore.Targets,
 		From:    make([]tree.PartitionedBackup, len(restore.From)),
 	}
+	if len(kmsURIs) > 0 {
+		kmsOpt, err := makeKMSOption(kmsURIs)
+		if err != nil {
+			return "", err
+		}
+		r.Options = append(r.Options, kmsOpt)
+	}
 
 	for i, backup := range from {
 		r.From[i] = make(tree.PartitionedBackup, len(backup))
@@ -617,7 +627,20 @@ func restorePlanHook(
 		fromFns[i] = fromFn
 	}
 
-	optsFn, err := p.TypeAsStringOpts(ctx, restoreStmt.Options, restoreOptionExpectValues)
+	var subdirFn func() (string, error)
+	if restoreStmt.Subdir != nil {
+		var err error
+		subdirFn, err = p.TypeAsString(ctx, restoreStmt.Subdir, "RESTORE")
+		if err != nil {
+			return nil, nil, nil, false, err
+		}
+	}
+
+	restoreOpts, kmsFn, err := splitKMSOption(ctx, p, restoreStmt.Options, "RESTORE")
+	if err != nil {
+		return nil, nil, nil, false, err
+	}
+	optsFn, err := p.TypeAsStringOpts(ctx, restoreOpts, restoreOptionExpectValues)
 	if err != nil {
 		return nil, nil, nil, false, err
 	}
@@ -648,6 +671,20 @@ func restorePlanHook(
 				return err
 			}
 		}
+		if subdirFn != nil {
+			// RESTORE FROM ... IN restores the backup in a subdirectory of the
+			// collection specified in FROM.
+			subdir, err := subdirFn()
+			if err != nil {
+				return err
+			}
+			from[0], err = resolveBackupInCollection(
+				ctx, from[0], subdir, p.ExecCfg().DistSQLSrv.ExternalStorageFromURI,
+			)
+			if err != nil {
+				return err
+			}
+		}
 		var endTime hlc.Timestamp
 		if restoreStmt.AsOf.Expr != nil {
 			var err error
@@ -661,7 +698,11 @@ func restorePlanHook(
 		if err != nil {
 			return err
 		}
-		return doRestorePlan(ctx, restoreStmt, p, from, endTime, opts, resultsCh)
+		kmsURIs, err := kmsFn()
+		if err != nil {
+			return err
+		}
+		return doRestorePlan(ctx, restoreStmt, p, from, endTime, opts, kmsURIs, resultsCh)
 	}
 	return fn, RestoreHeader, nil, false, nil
 }
@@ -673,6 +714,7 @@ func doRestorePlan(
 	from [][]string,
 	endTime hlc.Timestamp,
 	opts map[string]string,
+	kmsURIs []string,
 	resultsCh chan<- tree.Datums,
 ) error {
 	if len(from) < 1 || len(from[0]) < 1 {
@@ -689,12 +731,27 @@ func doRestorePlan(
 	}
 
 	var encryption *roachpb.FileEncryptionOptions
-	if passphrase, ok := opts[backupOptEncPassphrase]; ok {
-		opts, err := readEncryptionOptions(ctx, baseStores[0])
+	passphrase, hasPassphrase := opts[backupOptEncPassphrase]
+	if hasPassphrase && len(kmsURIs) > 0 {
+		return errors.Errorf("cannot specify both %q and %q", backupOptEncPassphrase, backupOptEncKMS)
+	}
+	if hasPassphrase || len(kmsURIs) > 0 {
+		layerURIs := make([]string, 0, len(from)-1)
+		for _, uris := range from[1:] {
+			layerURIs = append(layerURIs, uris[0])
+		}
+		encInfo, err := readEncryptionInfoLayers(
+			ctx, baseStores[0], layerURIs, p.ExecCfg().DistSQLSrv.ExternalStorageFromURI,
+		)
+		if err != nil {
+			return err
+		}
+		encryptionKey, err := getEncryptionKey(
+			ctx, encInfo, []byte(passphrase), kmsURIs, makeBackupKMSEnv(p.ExecCfg()),
+		)
 		if err != nil {
 			return err
 		}
-		encryptionKey := storageccl.GenerateKey([]byte(passphrase), opts.Salt)
 		encryption = &roachpb.FileEncryptionOptions{Key: encryptionKey}
 	}
 
@@ -756,7 +813,7 @@ func doRestorePlan(
 	if err != nil {
 		return err
 	}
-	description, err := restoreJobDescription(p, restoreStmt, from, opts)
+	description, err := restoreJobDescription(p, restoreStmt, from, kmsURIs, opts)
 	if err != nil {
 		return err
 	}
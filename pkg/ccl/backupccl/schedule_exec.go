@@ -0,0 +1,146 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package backupccl
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlutil"
+	"github.com/cockroachdb/errors"
+	pbtypes "github.com/gogo/protobuf/types"
+)
+
+// ScheduledBackupExecutorName is the name of the executor which runs
+// scheduled backups.
+const ScheduledBackupExecutorName = "scheduled-backup-executor"
+
+type scheduledBackupExecutor struct {
+	ex sqlutil.InternalExecutor
+}
+
+var _ jobs.ScheduledJobExecutor = &scheduledBackupExecutor{}
+
+// ExecuteJob implements jobs.ScheduledJobExecutor interface.
+func (e *scheduledBackupExecutor) ExecuteJob(
+	ctx context.Context, cfg *jobs.JobExecutionConfig, sj *jobs.ScheduledJob, txn *kv.Txn,
+) error {
+	args := &ScheduledBackupExecutionArgs{}
+	if err := pbtypes.UnmarshalAny(sj.ExecutionArgs().Args, args); err != nil {
+		return errors.Wrap(err, "un-marshaling args")
+	}
+
+	backupStmt, err := extractBackupStatement(sj, args)
+	if err != nil {
+		return err
+	}
+
+	// Full backups are written INTO a new subdirectory of the collection, and
+	// incremental backups INTO LATEST IN it, i.e. they are appended to the most
+	// recent full backup.
+	if !backupStmt.Nested {
+		return errors.AssertionFailedf("unexpected backup statement %q", tree.AsString(backupStmt))
+	}
+
+	// Run the backup in the scheduler's transaction: this only creates the
+	// backup job, which is then adopted and executed by the job registry.
+	backupStmt.Options = append(backupStmt.Options, tree.KVOption{Key: backupOptDetached})
+
+	planner, cleanup := cfg.PlanHookMaker("exec-backup", txn, sj.Owner())
+	defer cleanup()
+
+	annotated := &annotatedBackupStatement{
+		Backup: backupStmt,
+		CreatedByInfo: &jobs.CreatedByInfo{
+			Name: jobs.CreatedByScheduledJobs,
+			ID:   sj.ScheduleID(),
+		},
+	}
+	backupFn, _, _, _, err := backupPlanHook(ctx, annotated, planner.(sql.PlanHookState))
+	if err != nil {
+		return errors.Wrapf(err, "backup eval: %q", tree.AsString(backupStmt))
+	}
+	if backupFn == nil {
+		return errors.Newf("backup eval: %q", tree.AsString(backupStmt))
+	}
+	return invokeBackup(ctx, backupFn)
+}
+
+// NotifyJobTermination implements jobs.ScheduledJobExecutor interface.
+func (e *scheduledBackupExecutor) NotifyJobTermination(
+	ctx context.Context, md *jobs.JobMetadata, sj *jobs.ScheduledJob, txn *kv.Txn,
+) error {
+	if md.Status != jobs.StatusSucceeded {
+		jobs.DefaultHandleFailedRun(sj, md.ID, errors.Newf("backup job %d failed: %s", md.ID, md.Payload.Error))
+		return nil
+	}
+
+	args := &ScheduledBackupExecutionArgs{}
+	if err := pbtypes.UnmarshalAny(sj.ExecutionArgs().Args, args); err != nil {
+		return errors.Wrap(err, "un-marshaling args")
+	}
+	if args.BackupType != ScheduledBackupExecutionArgs_FULL || args.DependentScheduleID == 0 {
+		return nil
+	}
+
+	// A full backup completed successfully, so the dependent incremental
+	// schedule, which appends to the latest full backup, can run.
+	inc, err := jobs.LoadScheduledJob(ctx, nil /* env */, args.DependentScheduleID, e.ex, txn)
+	if err != nil {
+		if errors.Is(err, jobs.ErrScheduleNotFound) {
+			// The incremental schedule was dropped; nothing to do.
+			return nil
+		}
+		return err
+	}
+	if !inc.IsPaused() {
+		return nil
+	}
+	if err := inc.Unpause("full backup completed"); err != nil {
+		return err
+	}
+	return inc.Update(ctx, e.ex, txn)
+}
+
+// extractBackupStatement parses the backup statement stored in the schedule
+// arguments.
+func extractBackupStatement(
+	sj *jobs.ScheduledJob, args *ScheduledBackupExecutionArgs,
+) (*tree.Backup, error) {
+	stmt, err := parser.ParseOne(args.BackupStatement)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing backup statement for schedule %d", sj.ScheduleID())
+	}
+	backupStmt, ok := stmt.AST.(*tree.Backup)
+	if !ok {
+		return nil, errors.Newf("schedule %d: expected BACKUP statement, found %s",
+			sj.ScheduleID(), stmt.AST.StatementTag())
+	}
+	return backupStmt, nil
+}
+
+// invokeBackup runs the detached backup plan function, returning once the
+// backup job has been created.
+func invokeBackup(ctx context.Context, backupFn sql.PlanHookRowFn) error {
+	// Detached backup produces a single row containing the job ID.
+	resultsCh := make(chan tree.Datums, 1)
+	return backupFn(ctx, nil, resultsCh)
+}
+
+func init() {
+	jobs.RegisterScheduledJobExecutorFactory(
+		ScheduledBackupExecutorName,
+		func(ex sqlutil.InternalExecutor) (jobs.ScheduledJobExecutor, error) {
+			return &scheduledBackupExecutor{ex: ex}, nil
+		})
+}
@@ -10,10 +10,10 @@ package backupccl
 
 import (
 	"context"
+	"sort"
 	"strings"
 	"time"
 
-	"github.com/cockroachdb/cockroach/pkg/ccl/storageccl"
 	"github.com/cockroachdb/cockroach/pkg/ccl/utilccl"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/sql"
@@ -52,12 +52,23 @@ func showBackupPlanHook(
 	if err != nil {
 		return nil, nil, nil, false, err
 	}
+	var inColFn func() (string, error)
+	if backup.InCollection != nil {
+		inColFn, err = p.TypeAsString(ctx, backup.InCollection, "SHOW BACKUP")
+		if err != nil {
+			return nil, nil, nil, false, err
+		}
+	}
 
 	expected := map[string]sql.KVStringOptValidate{
 		backupOptEncPassphrase:  sql.KVStringOptRequireValue,
 		backupOptWithPrivileges: sql.KVStringOptRequireNoValue,
 	}
-	optsFn, err := p.TypeAsStringOpts(ctx, backup.Options, expected)
+	showOpts, kmsFn, err := splitKMSOption(ctx, p, backup.Options, "SHOW BACKUP")
+	if err != nil {
+		return nil, nil, nil, false, err
+	}
+	optsFn, err := p.TypeAsStringOpts(ctx, showOpts, expected)
 	if err != nil {
 		return nil, nil, nil, false, err
 	}
@@ -85,6 +96,19 @@ func showBackupPlanHook(
 		if err != nil {
 			return err
 		}
+		if inColFn != nil {
+			collection, err := inColFn()
+			if err != nil {
+				return err
+			}
+			uris, err := resolveBackupInCollection(
+				ctx, []string{collection}, str, p.ExecCfg().DistSQLSrv.ExternalStorageFromURI,
+			)
+			if err != nil {
+				return err
+			}
+			str = uris[0]
+		}
 
 		store, err := p.ExecCfg().DistSQLSrv.ExternalStorageFromURI(ctx, str)
 		if err != nil {
@@ -92,13 +116,28 @@ func showBackupPlanHook(
 		}
 		defer store.Close()
 
+		kmsURIs, err := kmsFn()
+		if err != nil {
+			return err
+		}
 		var encryption *roachpb.FileEncryptionOptions
-		if passphrase, ok := opts[backupOptEncPassphrase]; ok {
-			opts, err := readEncryptionOptions(ctx, store)
+		passphrase, hasPassphrase := opts[backupOptEncPassphrase]
+		if hasPassphrase && len(kmsURIs) > 0 {
+			return errors.Errorf("cannot specify both %q and %q", backupOptEncPassphrase, backupOptEncKMS)
+		}
+		if hasPassphrase || len(kmsURIs) > 0 {
+			encInfo, err := readEncryptionInfoLayers(
+				ctx, store, nil /* layerURIs */, p.ExecCfg().DistSQLSrv.ExternalStorageFromURI,
+			)
+			if err != nil {
+				return err
+			}
+			encryptionKey, err := getEncryptionKey(
+				ctx, encInfo, []byte(passphrase), kmsURIs, makeBackupKMSEnv(p.ExecCfg()),
+			)
 			if err != nil {
 				return err
 			}
-			encryptionKey := storageccl.GenerateKey([]byte(passphrase), opts.Salt)
 			encryption = &roachpb.FileEncryptionOptions{Key: encryptionKey}
 		}
 
@@ -347,6 +386,68 @@ var backupShowerFiles = backupShower{
 	},
 }
 
+// fullBackupManifestPattern matches the manifests of the full backups in a
+// backup collection, i.e. those in subdirectories named according to
+// fullBackupSubdirFormat.
+const fullBackupManifestPattern = "[0-9]*/[0-9]*/[0-9]*-[0-9]*.[0-9][0-9]/" + BackupManifestName
+
+// showBackupsInCollectionPlanHook implements PlanHookFn.
+func showBackupsInCollectionPlanHook(
+	ctx context.Context, stmt tree.Statement, p sql.PlanHookState,
+) (sql.PlanHookRowFn, sqlbase.ResultColumns, []sql.PlanNode, bool, error) {
+	backup, ok := stmt.(*tree.ShowBackups)
+	if !ok {
+		return nil, nil, nil, false, nil
+	}
+
+	if err := utilccl.CheckEnterpriseEnabled(
+		p.ExecCfg().Settings, p.ExecCfg().ClusterID(), p.ExecCfg().Organization(), "SHOW BACKUPS",
+	); err != nil {
+		return nil, nil, nil, false, err
+	}
+
+	if err := p.RequireAdminRole(ctx, "SHOW BACKUPS"); err != nil {
+		return nil, nil, nil, false, err
+	}
+
+	collectionFn, err := p.TypeAsString(ctx, backup.InCollection, "SHOW BACKUPS")
+	if err != nil {
+		return nil, nil, nil, false, err
+	}
+
+	fn := func(ctx context.Context, _ []sql.PlanNode, resultsCh chan<- tree.Datums) error {
+		ctx, span := tracing.ChildSpan(ctx, stmt.StatementTag())
+		defer tracing.FinishSpan(span)
+
+		collection, err := collectionFn()
+		if err != nil {
+			return err
+		}
+		store, err := p.ExecCfg().DistSQLSrv.ExternalStorageFromURI(ctx, collection)
+		if err != nil {
+			return errors.Wrapf(err, "connect to external storage")
+		}
+		defer store.Close()
+
+		manifests, err := store.ListFiles(ctx, fullBackupManifestPattern)
+		if err != nil {
+			return errors.Wrapf(err, "listing backups in collection")
+		}
+		sort.Strings(manifests)
+		for _, m := range manifests {
+			subdir := strings.TrimSuffix(m, "/"+BackupManifestName)
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case resultsCh <- tree.Datums{tree.NewDString(subdir)}:
+			}
+		}
+		return nil
+	}
+	return fn, sqlbase.ResultColumns{{Name: "path", Typ: types.String}}, nil, false, nil
+}
+
 func init() {
 	sql.AddPlanHook(showBackupPlanHook)
+	sql.AddPlanHook(showBackupsInCollectionPlanHook)
 }
@@ -66,6 +66,11 @@ const (
 	avroSchemaLong    = `long`
 	avroSchemaNull    = `null`
 	avroSchemaString  = `string`
+
+	// avroSchemaArray and avroSchemaEnum are complex types, which aren't
+	// usable on their own. See avroArrayType and avroEnumType.
+	avroSchemaArray = `array`
+	avroSchemaEnum  = `enum`
 )
 
 type avroLogicalType struct {
@@ -75,12 +80,27 @@ type avroLogicalType struct {
 	Scale       int            `json:"scale,omitempty"`
 }
 
+type avroArrayType struct {
+	SchemaType avroSchemaType `json:"type"`
+	Items      avroSchemaType `json:"items"`
+}
+
+type avroEnumType struct {
+	SchemaType avroSchemaType `json:"type"`
+	Name       string         `json:"name"`
+	Symbols    []string       `json:"symbols"`
+}
+
 func avroUnionKey(t avroSchemaType) string {
 	switch s := t.(type) {
 	case string:
 		return s
 	case avroLogicalType:
 		return avroUnionKey(s.SchemaType) + `.` + s.LogicalType
+	case avroArrayType:
+		return avroSchemaArray
+	case avroEnumType:
+		return s.Name
 	case *avroRecord:
 		return s.Name
 	default:
@@ -140,17 +160,41 @@ type avroEnvelopeRecord struct {
 }
 
 // columnDescToAvroSchema converts a column descriptor into its corresponding
-// avro field schema.
-func columnDescToAvroSchema(colDesc *sqlbase.ColumnDescriptor) (*avroSchemaField, error) {
+// avro field schema. The name of the record the field belongs to is used to
+// give unique names to the named avro types, such as enums, in the field.
+func columnDescToAvroSchema(
+	colDesc *sqlbase.ColumnDescriptor, recordName string,
+) (*avroSchemaField, error) {
+	name := SQLNameToAvroName(colDesc.Name)
+	schema, err := typeToAvroSchema(colDesc.Type, colDesc.Name, recordName+`_`+name)
+	if err != nil {
+		return nil, err
+	}
+	schema.Name = name
+	schema.Metadata = colDesc.SQLString()
+	schema.Default = nil
+
+	// Make every field optional by unioning it with null, so that all schema
+	// evolutions for a table are considered "backward compatible" by avro. This
+	// means that the Avro type doesn't mirror the column's nullability, but it
+	// makes it much easier to work with long histories of table data afterward,
+	// especially for things like loading into analytics databases.
+	makeAvroSchemaNullable(schema)
+
+	return schema, nil
+}
+
+// typeToAvroSchema returns the avro schema for the given SQL type, with the
+// functions that convert datums of the type to and from the avro library's
+// native format. The name of the column of the type is only used in errors.
+// Named avro types, such as enums, are given the provided name.
+func typeToAvroSchema(typ *types.T, colName, namedTypeName string) (*avroSchemaField, error) {
 	schema := &avroSchemaField{
-		Name:     SQLNameToAvroName(colDesc.Name),
-		Metadata: colDesc.SQLString(),
-		Default:  nil,
-		typ:      colDesc.Type,
+		typ: typ,
 	}
 
 	var avroType avroSchemaType
-	switch colDesc.Type.Family() {
+	switch typ.Family() {
 	case types.IntFamily:
 		avroType = avroSchemaLong
 		schema.encodeFn = func(d tree.Datum) (interface{}, error) {
@@ -224,7 +268,7 @@ func columnDescToAvroSchema(colDesc *sqlbase.ColumnDescriptor) (*avroSchemaField
 			date := *d.(*tree.DDate)
 			if !date.IsFinite() {
 				return nil, errors.Errorf(
-					`column %s: infinite date not yet supported with avro`, colDesc.Name)
+					`column %s: infinite date not yet supported with avro`, colName)
 			}
 			// The avro library requires us to return this as a time.Time.
 			return date.ToTime()
@@ -281,15 +325,15 @@ func columnDescToAvroSchema(colDesc *sqlbase.ColumnDescriptor) (*avroSchemaField
 			return tree.MakeDTimestampTZ(x.(time.Time), time.Microsecond)
 		}
 	case types.DecimalFamily:
-		if colDesc.Type.Precision() == 0 {
+		if typ.Precision() == 0 {
 			return nil, errors.Errorf(
-				`column %s: decimal with no precision not yet supported with avro`, colDesc.Name)
+				`column %s: decimal with no precision not yet supported with avro`, colName)
 		}
 		avroType = avroLogicalType{
 			SchemaType:  avroSchemaBytes,
 			LogicalType: `decimal`,
-			Precision:   int(colDesc.Type.Precision()),
-			Scale:       int(colDesc.Type.Width()),
+			Precision:   int(typ.Precision()),
+			Scale:       int(typ.Width()),
 		}
 		schema.encodeFn = func(d tree.Datum) (interface{}, error) {
 			dec := d.(*tree.DDecimal).Decimal
@@ -298,14 +342,14 @@ func columnDescToAvroSchema(colDesc *sqlbase.ColumnDescriptor) (*avroSchemaField
 			// support the unspecified precision/scale case in this branch. We
 			// can't currently do this without surgery to the avro library we're
 			// using and that's too scary leading up to 2.1.0.
-			rat, err := decimalToRat(dec, colDesc.Type.Width())
+			rat, err := decimalToRat(dec, typ.Width())
 			if err != nil {
 				return nil, err
 			}
 			return &rat, nil
 		}
 		schema.decodeFn = func(x interface{}) (tree.Datum, error) {
-			return &tree.DDecimal{Decimal: ratToDecimal(*x.(*big.Rat), colDesc.Type.Width())}, nil
+			return &tree.DDecimal{Decimal: ratToDecimal(*x.(*big.Rat), typ.Width())}, nil
 		}
 	case types.UuidFamily:
 		// Should be logical type of "uuid", but the avro library doesn't support
@@ -333,45 +377,106 @@ func columnDescToAvroSchema(colDesc *sqlbase.ColumnDescriptor) (*avroSchemaField
 		schema.decodeFn = func(x interface{}) (tree.Datum, error) {
 			return tree.ParseDJSON(x.(string))
 		}
-	default:
-		return nil, errors.Errorf(`column %s: type %s not yet supported with avro`,
-			colDesc.Name, colDesc.Type.SQLString())
-	}
-	schema.SchemaType = avroType
-
-	// Make every field optional by unioning it with null, so that all schema
-	// evolutions for a table are considered "backward compatible" by avro. This
-	// means that the Avro type doesn't mirror the column's nullability, but it
-	// makes it much easier to work with long histories of table data afterward,
-	// especially for things like loading into analytics databases.
-	{
-		// The default for a union type is the default for the first element of
-		// the union.
-		schema.SchemaType = []avroSchemaType{avroSchemaNull, avroType}
-		encodeFn := schema.encodeFn
-		decodeFn := schema.decodeFn
-		unionKey := avroUnionKey(avroType)
-		schema.encodeFn = func(d tree.Datum) (interface{}, error) {
-			if d == tree.DNull {
-				return goavro.Union(avroSchemaNull, nil), nil
+	case types.EnumFamily:
+		if typ.TypeMeta.EnumData == nil {
+			return nil, errors.AssertionFailedf(
+				`column %s: type %s is not hydrated`, colName, typ.SQLString())
+		}
+		logicalReps := typ.TypeMeta.EnumData.LogicalRepresentations
+		symbols := make([]string, len(logicalReps))
+		for i, rep := range logicalReps {
+			if rep == `` {
+				return nil, errors.Errorf(
+					`column %s: empty enum value not supported with avro`, colName)
 			}
-			encoded, err := encodeFn(d)
-			if err != nil {
-				return nil, err
+			symbols[i] = SQLNameToAvroName(rep)
+		}
+		avroType = avroEnumType{
+			SchemaType: avroSchemaEnum,
+			Name:       namedTypeName,
+			Symbols:    symbols,
+		}
+		schema.encodeFn = func(d tree.Datum) (interface{}, error) {
+			return SQLNameToAvroName(d.(*tree.DEnum).LogicalRep), nil
+		}
+		schema.decodeFn = func(x interface{}) (tree.Datum, error) {
+			return tree.MakeDEnumFromLogicalRepresentation(typ, AvroNameToSQLName(x.(string)))
+		}
+	case types.ArrayFamily:
+		// Like columns, the elements of an array can be NULL, so they're made
+		// optional.
+		elem, err := typeToAvroSchema(typ.ArrayContents(), colName, namedTypeName)
+		if err != nil {
+			return nil, err
+		}
+		makeAvroSchemaNullable(elem)
+		avroType = avroArrayType{
+			SchemaType: avroSchemaArray,
+			Items:      elem.SchemaType,
+		}
+		schema.encodeFn = func(d tree.Datum) (interface{}, error) {
+			datums := d.(*tree.DArray).Array
+			// The avro library requires us to return this as a []interface{}.
+			native := make([]interface{}, len(datums))
+			for i := range datums {
+				var err error
+				if native[i], err = elem.encodeFn(datums[i]); err != nil {
+					return nil, err
+				}
 			}
-			return goavro.Union(unionKey, encoded), nil
+			return native, nil
 		}
 		schema.decodeFn = func(x interface{}) (tree.Datum, error) {
-			if x == nil {
-				return tree.DNull, nil
+			// The avro library hands this back as a []interface{}.
+			arr := tree.NewDArray(typ.ArrayContents())
+			for _, item := range x.([]interface{}) {
+				d, err := elem.decodeFn(item)
+				if err != nil {
+					return nil, err
+				}
+				if err := arr.Append(d); err != nil {
+					return nil, err
+				}
 			}
-			return decodeFn(x.(map[string]interface{})[unionKey])
+			return arr, nil
 		}
+	default:
+		return nil, errors.Errorf(`column %s: type %s not yet supported with avro`,
+			colName, typ.SQLString())
 	}
+	schema.SchemaType = avroType
 
 	return schema, nil
 }
 
+// makeAvroSchemaNullable makes the given schema optional by unioning it with
+// null.
+func makeAvroSchemaNullable(schema *avroSchemaField) {
+	// The default for a union type is the default for the first element of the
+	// union.
+	avroType := schema.SchemaType
+	schema.SchemaType = []avroSchemaType{avroSchemaNull, avroType}
+	encodeFn := schema.encodeFn
+	decodeFn := schema.decodeFn
+	unionKey := avroUnionKey(avroType)
+	schema.encodeFn = func(d tree.Datum) (interface{}, error) {
+		if d == tree.DNull {
+			return goavro.Union(avroSchemaNull, nil), nil
+		}
+		encoded, err := encodeFn(d)
+		if err != nil {
+			return nil, err
+		}
+		return goavro.Union(unionKey, encoded), nil
+	}
+	schema.decodeFn = func(x interface{}) (tree.Datum, error) {
+		if x == nil {
+			return tree.DNull, nil
+		}
+		return decodeFn(x.(map[string]interface{})[unionKey])
+	}
+}
+
 // indexToAvroSchema converts a column descriptor into its corresponding avro
 // record schema. The fields are kept in the same order as columns in the index.
 func indexToAvroSchema(
@@ -392,7 +497,7 @@ func indexToAvroSchema(
 			return nil, errors.Errorf(`unknown column id: %d`, colID)
 		}
 		col := &tableDesc.Columns[colIdx]
-		field, err := columnDescToAvroSchema(col)
+		field, err := columnDescToAvroSchema(col, schema.Name)
 		if err != nil {
 			return nil, err
 		}
@@ -438,7 +543,7 @@ func tableToAvroSchema(
 	}
 	for colIdx := range tableDesc.Columns {
 		col := &tableDesc.Columns[colIdx]
-		field, err := columnDescToAvroSchema(col)
+		field, err := columnDescToAvroSchema(col, schema.Name)
 		if err != nil {
 			return nil, err
 		}
@@ -164,6 +164,11 @@ func TestAvroSchema(t *testing.T) {
 			schema: `(a INT PRIMARY KEY, b DECIMAL (3,2), c DECIMAL (2, 1))`,
 			values: `(1, 1.23, 4.5)`,
 		},
+		{
+			name:   `ARRAY`,
+			schema: `(a INT PRIMARY KEY, b STRING[])`,
+			values: `(1, ARRAY['a', NULL, 'b']), (2, ARRAY[]), (3, NULL)`,
+		},
 	}
 	// Generate a test for each column type with a random datum of that type.
 	for _, typ := range types.OidToType {
@@ -321,7 +326,7 @@ func TestAvroSchema(t *testing.T) {
 			colType := typ.SQLString()
 			tableDesc, err := parseTableDesc(`CREATE TABLE foo (pk INT PRIMARY KEY, a ` + colType + `)`)
 			require.NoError(t, err)
-			field, err := columnDescToAvroSchema(&tableDesc.Columns[1])
+			field, err := columnDescToAvroSchema(&tableDesc.Columns[1], avroSchemaNoSuffix)
 			require.NoError(t, err)
 			schema, err := json.Marshal(field.SchemaType)
 			require.NoError(t, err)
@@ -0,0 +1,505 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package changefeedccl
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/resolver"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/cache"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/errors"
+)
+
+// CDC queries, i.e. changefeeds created with
+//
+//   CREATE CHANGEFEED [INTO <sink>] [WITH <options>]
+//     AS SELECT <exprs> FROM <table> [WHERE <filter>]
+//
+// only emit the changed rows that pass the filter, and replace the columns of
+// the table in the emitted values with the select list. The expressions are
+// restricted to scalar expressions over the columns of the changed row:
+// subqueries, aggregate, window and set-returning functions, and functions
+// whose result may change from one evaluation to the next are rejected.
+//
+// When the query has a filter, the previous value of every changed row is
+// fetched, as with the diff option, so that a row that stops matching the
+// filter is emitted as a deletion and a deletion is only emitted if the
+// deleted row matched the filter. When the diff option is set, the previous
+// value of the row is projected the same way as the new one, and is null if
+// it didn't match the filter.
+//
+// The query is stored in the job in its textual form, with the user-defined
+// types it references replaced by their IDs. Column names are resolved
+// separately against every version of the table descriptor, so a schema
+// change that drops or renames a column used by the query makes the
+// changefeed fail.
+
+// parseCDCQuery parses the SELECT clause of a CDC query as stored in the
+// changefeed details.
+func parseCDCQuery(sql string) (*tree.SelectClause, error) {
+	stmt, err := parser.ParseOne(sql)
+	if err != nil {
+		return nil, err
+	}
+	if sel, ok := stmt.AST.(*tree.Select); ok {
+		if clause, ok := sel.Select.(*tree.SelectClause); ok {
+			return clause, nil
+		}
+	}
+	return nil, errors.AssertionFailedf("unexpected CDC query: %s", sql)
+}
+
+// serializeCDCQuery returns the textual form of a CDC query that is stored in
+// the changefeed details. The user-defined types referenced by the query are
+// resolved using the given resolver and replaced by their IDs, since the
+// changefeed can't resolve type names.
+func serializeCDCQuery(
+	ctx context.Context, sel *tree.SelectClause, typeResolver tree.TypeReferenceResolver,
+) (string, error) {
+	resolveTypes := func(expr tree.Expr) (tree.Expr, error) {
+		return tree.SimpleVisit(expr, func(expr tree.Expr) (bool, tree.Expr, error) {
+			switch t := expr.(type) {
+			case *tree.CastExpr:
+				typ, err := tree.ResolveType(ctx, t.Type, typeResolver)
+				if err != nil {
+					return false, nil, err
+				}
+				newExpr := *t
+				newExpr.Type = typ
+				return true, &newExpr, nil
+			case *tree.AnnotateTypeExpr:
+				typ, err := tree.ResolveType(ctx, t.Type, typeResolver)
+				if err != nil {
+					return false, nil, err
+				}
+				newExpr := *t
+				newExpr.Type = typ
+				return true, &newExpr, nil
+			case *tree.IsOfTypeExpr:
+				newExpr := *t
+				newExpr.Types = make([]tree.ResolvableTypeReference, len(t.Types))
+				for i := range t.Types {
+					var err error
+					if newExpr.Types[i], err = tree.ResolveType(ctx, t.Types[i], typeResolver); err != nil {
+						return false, nil, err
+					}
+				}
+				return true, &newExpr, nil
+			}
+			return true, expr, nil
+		})
+	}
+
+	resolved := *sel
+	resolved.Exprs = make(tree.SelectExprs, len(sel.Exprs))
+	for i := range sel.Exprs {
+		resolved.Exprs[i] = sel.Exprs[i]
+		var err error
+		if resolved.Exprs[i].Expr, err = resolveTypes(sel.Exprs[i].Expr); err != nil {
+			return "", err
+		}
+	}
+	if sel.Where != nil {
+		where, err := resolveTypes(sel.Where.Expr)
+		if err != nil {
+			return "", err
+		}
+		resolved.Where = tree.NewWhere(sel.Where.Type, where)
+	}
+	return tree.AsStringWithFlags(&resolved, tree.FmtSerializable), nil
+}
+
+// cdcTypeResolver is a tree.TypeReferenceResolver that resolves the
+// user-defined types referenced by a CDC query, which are stored by ID, as of
+// a timestamp.
+type cdcTypeResolver struct {
+	codec keys.SQLCodec
+	db    *kv.DB
+	ts    hlc.Timestamp
+}
+
+var _ tree.TypeReferenceResolver = &cdcTypeResolver{}
+
+// ResolveType implements the tree.TypeReferenceResolver interface.
+func (r *cdcTypeResolver) ResolveType(
+	context.Context, *tree.UnresolvedObjectName,
+) (*types.T, error) {
+	return nil, errors.AssertionFailedf("cannot resolve types in CDC queries by name")
+}
+
+// ResolveTypeByID implements the tree.TypeReferenceResolver interface.
+func (r *cdcTypeResolver) ResolveTypeByID(ctx context.Context, id uint32) (*types.T, error) {
+	var typ *types.T
+	if err := r.db.Txn(ctx, func(ctx context.Context, txn *kv.Txn) error {
+		txn.SetFixedTimestamp(ctx, r.ts)
+		typeLookup := func(id sqlbase.ID) (*tree.TypeName, sqlbase.TypeDescriptorInterface, error) {
+			return resolver.ResolveTypeDescByID(ctx, txn, r.codec, id, tree.ObjectLookupFlags{})
+		}
+		name, typDesc, err := typeLookup(sqlbase.ID(id))
+		if err != nil {
+			return err
+		}
+		typ, err = typDesc.MakeTypesT(name, typeLookup)
+		return err
+	}); err != nil {
+		// Like the lease manager, the type descriptor lookup can return all kinds
+		// of errors during chaos, but none of them should ever be terminal.
+		return nil, MarkRetryableError(err)
+	}
+	return typ, nil
+}
+
+// cdcProjection holds the values of the select list of a CDC query for a
+// row, which are emitted instead of the columns of the table.
+type cdcProjection struct {
+	names  []string
+	datums tree.Datums
+}
+
+func (p *cdcProjection) asJSON() (map[string]interface{}, error) {
+	m := make(map[string]interface{}, len(p.names))
+	for i, name := range p.names {
+		var err error
+		if m[name], err = tree.AsJSON(p.datums[i], time.UTC); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// cdcRowContainer is a tree.IndexedVarContainer backed by a row decoded by
+// the row.Fetcher.
+type cdcRowContainer struct {
+	cols  []sqlbase.ColumnDescriptor
+	row   sqlbase.EncDatumRow
+	alloc *sqlbase.DatumAlloc
+}
+
+var _ tree.IndexedVarContainer = &cdcRowContainer{}
+
+// IndexedVarEval implements the tree.IndexedVarContainer interface.
+func (c *cdcRowContainer) IndexedVarEval(idx int, ctx *tree.EvalContext) (tree.Datum, error) {
+	if err := c.row[idx].EnsureDecoded(c.cols[idx].Type, c.alloc); err != nil {
+		return nil, err
+	}
+	return c.row[idx].Datum.Eval(ctx)
+}
+
+// IndexedVarResolvedType implements the tree.IndexedVarContainer interface.
+func (c *cdcRowContainer) IndexedVarResolvedType(idx int) *types.T {
+	return c.cols[idx].Type
+}
+
+// IndexedVarNodeFormatter implements the tree.IndexedVarContainer interface.
+func (c *cdcRowContainer) IndexedVarNodeFormatter(idx int) tree.NodeFormatter {
+	n := tree.Name(c.cols[idx].Name)
+	return &n
+}
+
+// compiledCDCQuery is a CDC query whose column names have been resolved
+// against a version of the table descriptor.
+type compiledCDCQuery struct {
+	container cdcRowContainer
+	// filter is nil if the query has no WHERE clause.
+	filter tree.TypedExpr
+	// names and exprs are the select list. They are nil if the query selects
+	// `*` only, in which case the row is emitted as is.
+	names []string
+	exprs []tree.TypedExpr
+}
+
+// compileCDCQuery resolves the column names of a CDC query against the given
+// table descriptor and type checks its expressions. The user-defined types of
+// the columns must be hydrated.
+func compileCDCQuery(
+	ctx context.Context,
+	sel *tree.SelectClause,
+	desc *sqlbase.TableDescriptor,
+	searchPath sessiondata.SearchPath,
+	typeResolver tree.TypeReferenceResolver,
+	alloc *sqlbase.DatumAlloc,
+) (*compiledCDCQuery, error) {
+	from, ok := sel.From.Tables[0].(*tree.AliasedTableExpr)
+	if !ok || len(sel.From.Tables) != 1 {
+		return nil, errors.AssertionFailedf("unexpected CDC query FROM clause: %s", &sel.From)
+	}
+	tn := *from.Expr.(*tree.TableName)
+	if from.As.Alias != "" {
+		tn = tree.MakeUnqualifiedTableName(from.As.Alias)
+	}
+
+	c := &compiledCDCQuery{container: cdcRowContainer{cols: desc.Columns, alloc: alloc}}
+	ivarHelper := tree.MakeIndexedVarHelper(&c.container, len(desc.Columns))
+	source := sqlbase.NewSourceInfoForSingleTable(
+		tn, sqlbase.ResultColumnsFromColDescs(desc.ID, desc.Columns),
+	)
+	semaCtx := tree.MakeSemaContext()
+	semaCtx.IVarContainer = &c.container
+	semaCtx.TypeResolver = typeResolver
+
+	typeCheck := func(expr tree.Expr, desired *types.T, context string) (tree.TypedExpr, error) {
+		if _, err := tree.SimpleVisit(expr, func(expr tree.Expr) (bool, tree.Expr, error) {
+			if _, ok := expr.(*tree.Placeholder); ok {
+				return false, nil, pgerror.Newf(pgcode.FeatureNotSupported,
+					"placeholders are not supported in CDC queries")
+			}
+			return true, expr, nil
+		}); err != nil {
+			return nil, err
+		}
+		expr, err := sqlbase.ResolveNames(expr, source, ivarHelper, searchPath)
+		if err != nil {
+			return nil, err
+		}
+		semaCtx.Properties.Require(context,
+			tree.RejectSpecial|tree.RejectSubqueries|tree.RejectImpureFunctions)
+		return tree.TypeCheckAndRequire(ctx, expr, &semaCtx, desired, context)
+	}
+
+	if sel.Where != nil {
+		var err error
+		if c.filter, err = typeCheck(sel.Where.Expr, types.Bool, "CDC query WHERE clause"); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(sel.Exprs) == 1 {
+		if _, ok := sel.Exprs[0].Expr.(tree.UnqualifiedStar); ok {
+			return c, nil
+		}
+	}
+	seen := make(map[string]struct{}, len(sel.Exprs))
+	addRender := func(name string, expr tree.TypedExpr) error {
+		if _, ok := seen[name]; ok {
+			return pgerror.Newf(pgcode.DuplicateColumn,
+				"column name %q specified more than once in CDC query", name)
+		}
+		seen[name] = struct{}{}
+		c.names = append(c.names, name)
+		c.exprs = append(c.exprs, expr)
+		return nil
+	}
+	for _, target := range sel.Exprs {
+		expr := target.Expr
+		if vn, ok := expr.(tree.VarName); ok {
+			var err error
+			if expr, err = vn.NormalizeVarName(); err != nil {
+				return nil, err
+			}
+		}
+		switch t := expr.(type) {
+		case tree.UnqualifiedStar, *tree.AllColumnsSelector:
+			if sel, ok := t.(*tree.AllColumnsSelector); ok && sel.TableName.Parts[0] != string(tn.ObjectName) {
+				return nil, sqlbase.NewUndefinedRelationError(sel.TableName)
+			}
+			if target.As != "" {
+				return nil, pgerror.Newf(pgcode.Syntax, "%q cannot be aliased", tree.ErrString(expr))
+			}
+			for i := range desc.Columns {
+				if col := &desc.Columns[i]; !col.Hidden {
+					if err := addRender(col.Name, ivarHelper.IndexedVar(i)); err != nil {
+						return nil, err
+					}
+				}
+			}
+			continue
+		}
+		name, err := tree.GetRenderColName(searchPath, target)
+		if err != nil {
+			return nil, err
+		}
+		typed, err := typeCheck(target.Expr, types.Any, "CDC query select list")
+		if err != nil {
+			return nil, err
+		}
+		if err := addRender(name, typed); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// validateCDCQuery checks that a CDC query, as stored in the changefeed
+// details, can be evaluated on the given version of the table it watches.
+func validateCDCQuery(
+	ctx context.Context,
+	query string,
+	desc *sqlbase.TableDescriptor,
+	searchPath sessiondata.SearchPath,
+	typeResolver tree.TypeReferenceResolver,
+) error {
+	sel, err := parseCDCQuery(query)
+	if err != nil {
+		return err
+	}
+	// Hydrate the user-defined types of the columns, such as the members of
+	// ENUMs, which are needed to type check the query.
+	hydrated := *desc
+	hydrated.Columns = append([]sqlbase.ColumnDescriptor(nil), desc.Columns...)
+	for i := range hydrated.Columns {
+		if col := &hydrated.Columns[i]; col.Type.UserDefined() {
+			if col.Type, err = typeResolver.ResolveTypeByID(ctx, col.Type.StableTypeID()); err != nil {
+				return err
+			}
+		}
+	}
+	_, err = compileCDCQuery(ctx, sel, &hydrated, searchPath, typeResolver, &sqlbase.DatumAlloc{})
+	return err
+}
+
+// cdcQueryEvaluator filters and projects the rows of a changefeed according
+// to a CDC query. It is not threadsafe.
+type cdcQueryEvaluator struct {
+	sel     *tree.SelectClause
+	evalCtx *tree.EvalContext
+	codec   keys.SQLCodec
+	db      *kv.DB
+	alloc   sqlbase.DatumAlloc
+	// compiled caches the query compiled against each version of the table
+	// descriptor. It maps idVersion to *compiledCDCQuery.
+	compiled *cache.UnorderedCache
+}
+
+// newCDCQueryEvaluator returns an evaluator for the given CDC query, or nil
+// if the query is empty, i.e. if the changefeed is not a CDC query.
+func newCDCQueryEvaluator(
+	query string, evalCtx *tree.EvalContext, codec keys.SQLCodec, db *kv.DB,
+) (*cdcQueryEvaluator, error) {
+	if query == `` {
+		return nil, nil
+	}
+	sel, err := parseCDCQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	return &cdcQueryEvaluator{
+		sel:      sel,
+		evalCtx:  evalCtx,
+		codec:    codec,
+		db:       db,
+		compiled: newIDVersionCache(),
+	}, nil
+}
+
+// needsPrevRow returns whether the previous value of the changed rows is
+// needed to evaluate the query, even if the diff option isn't set. It can be
+// called on a nil evaluator.
+func (e *cdcQueryEvaluator) needsPrevRow() bool {
+	return e != nil && e.sel.Where != nil
+}
+
+// compiledFor returns the query compiled against the given version of the
+// table descriptor, whose user-defined types must be hydrated. The types
+// referenced by the query are resolved as of the given timestamp.
+func (e *cdcQueryEvaluator) compiledFor(
+	ctx context.Context, desc *sqlbase.TableDescriptor, ts hlc.Timestamp,
+) (*compiledCDCQuery, error) {
+	idVer := idVersion{id: desc.ID, version: desc.Version}
+	if c, ok := e.compiled.Get(idVer); ok {
+		return c.(*compiledCDCQuery), nil
+	}
+	typeResolver := &cdcTypeResolver{codec: e.codec, db: e.db, ts: ts}
+	c, err := compileCDCQuery(
+		ctx, e.sel, desc, e.evalCtx.SessionData.SearchPath, typeResolver, &e.alloc,
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "evaluating CDC query on table %s version %d",
+			tree.Name(desc.Name), desc.Version)
+	}
+	e.compiled.Add(idVer, c)
+	return c, nil
+}
+
+// eval applies the query to a changed row. It returns false if the row is
+// filtered out; otherwise, it sets the projections of the row, if the query
+// has a select list other than `*`.
+//
+// A row whose previous value matched the filter but whose new value doesn't
+// is turned into a deletion. A previous value that doesn't match the filter
+// is treated as missing.
+func (e *cdcQueryEvaluator) eval(ctx context.Context, row *encodeRow) (bool, error) {
+	prevPass := false
+	if row.prevDatums != nil && !row.prevDeleted {
+		c, err := e.compiledFor(ctx, row.prevTableDesc, row.updated)
+		if err != nil {
+			return false, err
+		}
+		if prevPass, err = e.evalFilter(c, row.prevDatums); err != nil {
+			return false, err
+		}
+		if !prevPass {
+			row.prevDeleted = true
+		} else if c.exprs != nil {
+			if row.prevProjection, err = e.project(c, row.prevDatums); err != nil {
+				return false, err
+			}
+		}
+	}
+	if row.deleted {
+		// Without a filter, deletions are emitted even though the previous
+		// value of the row isn't known.
+		return prevPass || e.sel.Where == nil, nil
+	}
+
+	c, err := e.compiledFor(ctx, row.tableDesc, row.updated)
+	if err != nil {
+		return false, err
+	}
+	pass, err := e.evalFilter(c, row.datums)
+	if err != nil {
+		return false, err
+	}
+	if !pass {
+		// The row no longer matches the filter, so consumers must forget it.
+		row.deleted = prevPass
+		return prevPass, nil
+	}
+	if c.exprs != nil {
+		if row.projection, err = e.project(c, row.datums); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func (e *cdcQueryEvaluator) evalFilter(
+	c *compiledCDCQuery, datums sqlbase.EncDatumRow,
+) (bool, error) {
+	c.container.row = datums
+	e.evalCtx.PushIVarContainer(&c.container)
+	defer e.evalCtx.PopIVarContainer()
+	return sqlbase.RunFilter(c.filter, e.evalCtx)
+}
+
+func (e *cdcQueryEvaluator) project(
+	c *compiledCDCQuery, datums sqlbase.EncDatumRow,
+) (*cdcProjection, error) {
+	c.container.row = datums
+	e.evalCtx.PushIVarContainer(&c.container)
+	defer e.evalCtx.PopIVarContainer()
+	p := &cdcProjection{names: c.names, datums: make(tree.Datums, len(c.exprs))}
+	for i, expr := range c.exprs {
+		var err error
+		if p.datums[i], err = expr.Eval(e.evalCtx); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
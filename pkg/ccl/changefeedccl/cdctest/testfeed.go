@@ -194,7 +194,17 @@ func (c *sinklessFeed) Resume() error {
 	// both types. This bit turns what we received into the real sinkless
 	// syntax.
 	create := strings.Replace(c.create, `CREATE CHANGEFEED`, `EXPERIMENTAL CHANGEFEED`, 1)
-	if !c.latestResolved.IsEmpty() {
+	if createStmt := parseCDCQueryFeed(c.create); createStmt != nil {
+		// CDC queries have no EXPERIMENTAL syntax: without a sink, they are
+		// already sinkless. Their options go before the query.
+		if !c.latestResolved.IsEmpty() {
+			createStmt.Options = append(createStmt.Options, tree.KVOption{
+				Key:   `cursor`,
+				Value: tree.NewStrVal(c.latestResolved.AsOfSystemTime()),
+			})
+		}
+		create = tree.AsString(createStmt)
+	} else if !c.latestResolved.IsEmpty() {
 		// NB: The TODO in Next means c.latestResolved is currently never set for
 		// non-json feeds.
 		if strings.Contains(create, `WITH`) {
@@ -207,6 +217,19 @@ func (c *sinklessFeed) Resume() error {
 	return err
 }
 
+// parseCDCQueryFeed returns the parsed statement if the given CREATE
+// CHANGEFEED statement is a CDC query, or nil otherwise.
+func parseCDCQueryFeed(create string) *tree.CreateChangefeed {
+	parsed, err := parser.ParseOne(create)
+	if err != nil {
+		return nil
+	}
+	if createStmt, ok := parsed.AST.(*tree.CreateChangefeed); ok && createStmt.Select != nil {
+		return createStmt
+	}
+	return nil
+}
+
 // Close implements the TestFeed interface.
 func (c *sinklessFeed) Close() error {
 	c.rows = nil
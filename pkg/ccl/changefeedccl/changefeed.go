@@ -23,6 +23,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/lease"
 	"github.com/cockroachdb/cockroach/pkg/sql/row"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
 	"github.com/cockroachdb/cockroach/pkg/util/bufalloc"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
@@ -56,11 +57,16 @@ type emitEntry struct {
 func kvsToRows(
 	codec keys.SQLCodec,
 	leaseMgr *lease.Manager,
+	db *kv.DB,
+	evalCtx *tree.EvalContext,
 	details jobspb.ChangefeedDetails,
+	query *cdcQueryEvaluator,
 	inputFn func(context.Context) (kvfeed.Event, error),
 ) func(context.Context) ([]emitEntry, error) {
 	_, withDiff := details.Opts[changefeedbase.OptDiff]
-	rfCache := newRowFetcherCache(codec, leaseMgr)
+	withDiff = withDiff || query.needsPrevRow()
+	rfCache := newRowFetcherCache(codec, leaseMgr, db)
+	virtualCols := newVirtualColumnEvaluator(codec, db, evalCtx)
 
 	var kvs row.SpanKVFetcher
 	appendEmitEntryForKV := func(
@@ -123,16 +129,21 @@ func kvsToRows(
 			if nextRow.row.datums != nil {
 				return nil, errors.AssertionFailedf("unexpected non-empty datums")
 			}
+			if !r.row.deleted {
+				if err := virtualCols.eval(ctx, desc, r.row.datums, schemaTimestamp); err != nil {
+					return nil, err
+				}
+			}
 		}
 
 		// Get prev value, if necessary.
 		if withDiff {
-			prevRF := rf
+			prevRF, prevDesc := rf, desc
 			if prevSchemaTimestamp != schemaTimestamp {
 				// If the previous value is being interpreted under a different
 				// version of the schema, fetch the correct table descriptor and
 				// create a new row.Fetcher with it.
-				prevDesc, err := rfCache.TableDescForKey(ctx, kv.Key, prevSchemaTimestamp)
+				prevDesc, err = rfCache.TableDescForKey(ctx, kv.Key, prevSchemaTimestamp)
 				if err != nil {
 					return nil, err
 				}
@@ -171,6 +182,20 @@ func kvsToRows(
 			if nextRow.row.prevDatums != nil {
 				return nil, errors.AssertionFailedf("unexpected non-empty datums")
 			}
+			if !r.row.prevDeleted {
+				if err := virtualCols.eval(
+					ctx, prevDesc, r.row.prevDatums, prevSchemaTimestamp,
+				); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		// Filter and project the row, if this is a CDC query.
+		if query != nil {
+			if pass, err := query.eval(ctx, &r.row); err != nil || !pass {
+				return nil, err
+			}
 		}
 
 		output = append(output, r)
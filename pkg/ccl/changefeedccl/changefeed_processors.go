@@ -206,10 +206,22 @@ func (ca *changeAggregator) Start(ctx context.Context) context.Context {
 
 	buf := kvfeed.MakeChanBuffer()
 	leaseMgr := ca.flowCtx.Cfg.LeaseManager.(*lease.Manager)
+	evalCtx := ca.flowCtx.NewEvalCtx()
+	query, err := newCDCQueryEvaluator(
+		ca.spec.Feed.Select, evalCtx, ca.flowCtx.Codec(), ca.flowCtx.Cfg.DB,
+	)
+	if err != nil {
+		ca.MoveToDraining(err)
+		ca.cancel()
+		return ctx
+	}
 	_, withDiff := ca.spec.Feed.Opts[changefeedbase.OptDiff]
+	withDiff = withDiff || query.needsPrevRow()
 	kvfeedCfg := makeKVFeedCfg(ca.flowCtx.Cfg, leaseMgr, ca.kvFeedMemMon, ca.spec,
 		spans, withDiff, buf, metrics)
-	rowsFn := kvsToRows(ca.flowCtx.Codec(), leaseMgr, ca.spec.Feed, buf.Get)
+	rowsFn := kvsToRows(
+		ca.flowCtx.Codec(), leaseMgr, ca.flowCtx.Cfg.DB, evalCtx, ca.spec.Feed, query, buf.Get,
+	)
 	ca.tickFn = emitEntries(ca.flowCtx.Cfg.Settings, ca.spec.Feed,
 		kvfeedCfg.InitialHighWater, sf, ca.encoder, ca.sink, rowsFn, knobs, metrics)
 	ca.startKVFeed(ctx, kvfeedCfg)
@@ -195,14 +195,15 @@ func changefeedPlanHook(
 		//   and `format` if the user didn't specify them.
 		// - Then `getEncoder` is run to return any configuration errors.
 		// - Then the changefeed is opted in to `OptKeyInValue` for any cloud
-		//   storage sink. Kafka etc have a key and value field in each message but
-		//   cloud storage sinks don't have anywhere to put the key. So if the key
-		//   is not in the value, then for DELETEs there is no way to recover which
-		//   key was deleted. We could make the user explicitly pass this option for
-		//   every cloud storage sink and error if they don't, but that seems
-		//   user-hostile for insufficient reason. We can't do this any earlier,
-		//   because we might return errors about `key_in_value` being incompatible
-		//   which is confusing when the user didn't type that option.
+		//   storage or webhook sink. Kafka etc have a key and value field in each
+		//   message but cloud storage and webhook sinks don't have anywhere to put
+		//   the key. So if the key is not in the value, then for DELETEs there is
+		//   no way to recover which key was deleted. We could make the user
+		//   explicitly pass this option for every cloud storage sink and error if
+		//   they don't, but that seems user-hostile for insufficient reason. We
+		//   can't do this any earlier, because we might return errors about
+		//   `key_in_value` being incompatible which is confusing when the user
+		//   didn't type that option.
 		// - Finally, we create a "canary" sink to test sink configuration and
 		//   connectivity. This has to go last because it is strange to return sink
 		//   connectivity errors before we've finished validating all the other
@@ -216,14 +217,34 @@ func changefeedPlanHook(
 		if err != nil {
 			return err
 		}
+		if changefeedStmt.Select != nil {
+			details.Select, err = serializeCDCQuery(
+				ctx, changefeedStmt.Select, p.SemaCtx().GetTypeResolver(),
+			)
+			if err != nil {
+				return err
+			}
+		}
 		if details, err = validateDetails(details); err != nil {
 			return err
 		}
+		if details.Select != `` {
+			for _, desc := range targetDescs {
+				if tableDesc := desc.Table(hlc.Timestamp{}); tableDesc != nil {
+					if err := validateCDCQuery(
+						ctx, details.Select, tableDesc, p.SessionData().SearchPath,
+						p.SemaCtx().GetTypeResolver(),
+					); err != nil {
+						return err
+					}
+				}
+			}
+		}
 
 		if _, err := getEncoder(details.Opts); err != nil {
 			return err
 		}
-		if isCloudStorageSink(parsedSink) {
+		if isCloudStorageSink(parsedSink) || isWebhookSink(parsedSink) {
 			details.Opts[changefeedbase.OptKeyInValue] = ``
 		}
 
@@ -372,6 +393,7 @@ func changefeedJobDescription(
 	c := &tree.CreateChangefeed{
 		Targets: changefeed.Targets,
 		SinkURI: tree.NewDString(cleanedSinkURI),
+		Select:  changefeed.Select,
 	}
 	for k, v := range opts {
 		opt := tree.KVOption{Key: tree.Name(k)}
@@ -457,13 +479,21 @@ func validateDetails(details jobspb.ChangefeedDetails) (jobspb.ChangefeedDetails
 		switch v := changefeedbase.FormatType(details.Opts[opt]); v {
 		case ``, changefeedbase.OptFormatJSON:
 			details.Opts[opt] = string(changefeedbase.OptFormatJSON)
-		case changefeedbase.OptFormatAvro:
+		case changefeedbase.OptFormatAvro, changefeedbase.OptFormatDeprecatedExperimentalAvro:
+			details.Opts[opt] = string(changefeedbase.OptFormatAvro)
+		case changefeedbase.OptFormatProtobuf:
 			// No-op.
 		default:
 			return jobspb.ChangefeedDetails{}, errors.Errorf(
 				`unknown %s: %s`, opt, v)
 		}
 	}
+	if details.Select != `` {
+		if v := details.Opts[changefeedbase.OptFormat]; v != string(changefeedbase.OptFormatJSON) {
+			return jobspb.ChangefeedDetails{}, errors.Errorf(
+				`CDC queries are not supported with %s=%s`, changefeedbase.OptFormat, v)
+		}
+	}
 	return details, nil
 }
 
@@ -154,6 +154,125 @@ func TestChangefeedDiff(t *testing.T) {
 	t.Run(`cloudstorage`, cloudStorageTest(testFn))
 }
 
+func TestChangefeedCDCQuery(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	testFn := func(t *testing.T, db *gosql.DB, f cdctest.TestFeedFactory) {
+		sqlDB := sqlutils.MakeSQLRunner(db)
+		sqlDB.Exec(t, `CREATE TABLE foo (a INT PRIMARY KEY, b STRING, c INT)`)
+		sqlDB.Exec(t, `INSERT INTO foo VALUES (0, 'skipped', 0), (1, 'initial', 10)`)
+
+		foo := feed(t, f, `CREATE CHANGEFEED WITH diff `+
+			`AS SELECT a, upper(b) AS b, c * 2 AS double FROM foo AS f WHERE f.c > 5`)
+		defer closeFeed(t, foo)
+
+		assertPayloads(t, foo, []string{
+			`foo: [1]->{"after": {"a": 1, "b": "INITIAL", "double": 20}, "before": null}`,
+		})
+
+		sqlDB.Exec(t, `INSERT INTO foo VALUES (2, 'a', 1), (3, 'b', 30)`)
+		sqlDB.Exec(t, `UPDATE foo SET c = 6 WHERE a = 1`)
+		assertPayloads(t, foo, []string{
+			`foo: [3]->{"after": {"a": 3, "b": "B", "double": 60}, "before": null}`,
+			`foo: [1]->{"after": {"a": 1, "b": "INITIAL", "double": 12}, "before": {"a": 1, "b": "INITIAL", "double": 20}}`,
+		})
+
+		// Deletes are only emitted for rows that matched the filter.
+		sqlDB.Exec(t, `DELETE FROM foo WHERE a IN (2, 3)`)
+		assertPayloads(t, foo, []string{
+			`foo: [3]->{"after": null, "before": {"a": 3, "b": "B", "double": 60}}`,
+		})
+
+		// A row that stops matching the filter is emitted as a delete, and a row
+		// that starts matching it has no before value.
+		sqlDB.Exec(t, `UPDATE foo SET c = 0 WHERE a = 1`)
+		sqlDB.Exec(t, `UPDATE foo SET c = 1 WHERE a = 1`)
+		sqlDB.Exec(t, `UPDATE foo SET c = 7 WHERE a = 1`)
+		assertPayloads(t, foo, []string{
+			`foo: [1]->{"after": null, "before": {"a": 1, "b": "INITIAL", "double": 12}}`,
+			`foo: [1]->{"after": {"a": 1, "b": "INITIAL", "double": 14}, "before": null}`,
+		})
+
+		// Columns added after the changefeed started can't be seen by the
+		// query, but they don't break it either.
+		sqlDB.Exec(t, `ALTER TABLE foo ADD COLUMN d STRING DEFAULT 'd'`)
+		sqlDB.Exec(t, `INSERT INTO foo VALUES (4, 'e', 40, 'x')`)
+		assertPayloads(t, foo, []string{
+			`foo: [1]->{"after": {"a": 1, "b": "INITIAL", "double": 14}, "before": {"a": 1, "b": "INITIAL", "double": 14}}`,
+			`foo: [4]->{"after": {"a": 4, "b": "E", "double": 80}, "before": null}`,
+		})
+
+		// Dropping a column used by the query makes the changefeed fail.
+		sqlDB.Exec(t, `ALTER TABLE foo DROP COLUMN c`)
+		sqlDB.Exec(t, `INSERT INTO foo VALUES (5, 'f')`)
+		_, err := foo.Next()
+		require.Regexp(t, `column "f.c" does not exist`, err)
+	}
+
+	t.Run(`sinkless`, sinklessTest(testFn))
+	t.Run(`enterprise`, enterpriseTest(testFn))
+
+	starFn := func(t *testing.T, db *gosql.DB, f cdctest.TestFeedFactory) {
+		sqlDB := sqlutils.MakeSQLRunner(db)
+		sqlDB.Exec(t, `CREATE TABLE foo (a INT PRIMARY KEY, b STRING)`)
+		sqlDB.Exec(t, `INSERT INTO foo VALUES (0, 'x'), (1, 'y')`)
+
+		foo := feed(t, f, `CREATE CHANGEFEED AS SELECT * FROM foo WHERE b = 'y'`)
+		defer closeFeed(t, foo)
+		assertPayloads(t, foo, []string{
+			`foo: [1]->{"after": {"a": 1, "b": "y"}}`,
+		})
+
+		// Without the diff option, a row that stops matching the filter is still
+		// emitted as a delete, and deletes of rows that didn't match it are not.
+		sqlDB.Exec(t, `UPDATE foo SET b = 'z' WHERE a = 1`)
+		sqlDB.Exec(t, `DELETE FROM foo WHERE a = 0`)
+		sqlDB.Exec(t, `INSERT INTO foo VALUES (2, 'y')`)
+		assertPayloads(t, foo, []string{
+			`foo: [1]->{"after": null}`,
+			`foo: [2]->{"after": {"a": 2, "b": "y"}}`,
+		})
+
+		bar := feed(t, f, `CREATE CHANGEFEED AS SELECT foo.*, a + 1 AS next FROM foo`)
+		defer closeFeed(t, bar)
+		assertPayloads(t, bar, []string{
+			`foo: [1]->{"after": {"a": 1, "b": "z", "next": 2}}`,
+			`foo: [2]->{"after": {"a": 2, "b": "y", "next": 3}}`,
+		})
+	}
+
+	t.Run(`star`, sinklessTest(starFn))
+
+	enumFn := func(t *testing.T, db *gosql.DB, f cdctest.TestFeedFactory) {
+		sqlDB := sqlutils.MakeSQLRunner(db)
+		sqlDB.Exec(t, `SET experimental_enable_enums = true`)
+		sqlDB.Exec(t, `CREATE TYPE status AS ENUM ('open', 'closed', 'ice cream')`)
+		sqlDB.Exec(t, `CREATE TABLE foo (a INT PRIMARY KEY, s status)`)
+		sqlDB.Exec(t, `INSERT INTO foo VALUES (0, 'open'), (1, 'closed'), (2, 'ice cream')`)
+
+		// Enum values are compared with constants and casts to the enum type,
+		// which is resolved when the changefeed is created.
+		foo := feed(t, f, `CREATE CHANGEFEED `+
+			`AS SELECT a, s, s::STRING = 'open' AS is_open FROM foo `+
+			`WHERE s != 'closed' AND s::STRING != 'ice cream'::status::STRING`)
+		defer closeFeed(t, foo)
+		assertPayloads(t, foo, []string{
+			`foo: [0]->{"after": {"a": 0, "is_open": true, "s": "open"}}`,
+		})
+
+		// Renaming the type doesn't break the changefeed.
+		sqlDB.Exec(t, `ALTER TYPE status RENAME TO state`)
+		sqlDB.Exec(t, `UPDATE foo SET s = 'open' WHERE a = 1`)
+		sqlDB.Exec(t, `UPDATE foo SET s = 'closed' WHERE a = 0`)
+		assertPayloads(t, foo, []string{
+			`foo: [1]->{"after": {"a": 1, "is_open": true, "s": "open"}}`,
+			`foo: [0]->{"after": null}`,
+		})
+	}
+
+	t.Run(`enum`, sinklessTest(enumFn))
+}
+
 func TestChangefeedEnvelope(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 
@@ -1238,7 +1357,6 @@ func TestChangefeedComputedColumn(t *testing.T) {
 
 	testFn := func(t *testing.T, db *gosql.DB, f cdctest.TestFeedFactory) {
 		sqlDB := sqlutils.MakeSQLRunner(db)
-		// TODO(dan): Also test a non-STORED computed column once we support them.
 		sqlDB.Exec(t, `CREATE TABLE cc (
 		a INT, b INT AS (a + 1) STORED, c INT AS (a + 2) STORED, PRIMARY KEY (b, a)
 	)`)
@@ -1255,6 +1373,28 @@ func TestChangefeedComputedColumn(t *testing.T) {
 		assertPayloads(t, cc, []string{
 			`cc: [11, 10]->{"after": {"a": 10, "b": 11, "c": 12}}`,
 		})
+
+		// Virtual computed columns are not stored, so they are computed from the
+		// changed row, and from its previous value.
+		sqlDB.Exec(t, `CREATE TABLE vc (
+		a INT PRIMARY KEY, b INT, c INT AS (a + b) VIRTUAL, d STRING AS (concat('x', b::STRING)) VIRTUAL
+	)`)
+		sqlDB.Exec(t, `INSERT INTO vc (a, b) VALUES (1, 2), (2, NULL)`)
+
+		vc := feed(t, f, `CREATE CHANGEFEED FOR vc WITH diff`)
+		defer closeFeed(t, vc)
+
+		assertPayloads(t, vc, []string{
+			`vc: [1]->{"after": {"a": 1, "b": 2, "c": 3, "d": "x2"}, "before": null}`,
+			`vc: [2]->{"after": {"a": 2, "b": null, "c": null, "d": "x"}, "before": null}`,
+		})
+
+		sqlDB.Exec(t, `UPDATE vc SET b = 10 WHERE a = 1`)
+		sqlDB.Exec(t, `DELETE FROM vc WHERE a = 2`)
+		assertPayloads(t, vc, []string{
+			`vc: [1]->{"after": {"a": 1, "b": 10, "c": 11, "d": "x10"}, "before": {"a": 1, "b": 2, "c": 3, "d": "x2"}}`,
+			`vc: [2]->{"after": null, "before": {"a": 2, "b": null, "c": null, "d": "x"}}`,
+		})
 	}
 
 	t.Run(`sinkless`, sinklessTest(testFn))
@@ -1910,15 +2050,15 @@ func TestChangefeedErrors(t *testing.T) {
 
 	// The avro format doesn't support key_in_value yet.
 	sqlDB.ExpectErr(
-		t, `key_in_value is not supported with format=experimental_avro`,
+		t, `key_in_value is not supported with format=avro`,
 		`CREATE CHANGEFEED FOR foo INTO $1 WITH key_in_value, format='experimental_avro'`,
 		`kafka://nope`,
 	)
 
 	// The cloudStorageSink is particular about the options it will work with.
 	sqlDB.ExpectErr(
-		t, `this sink is incompatible with format=experimental_avro`,
-		`CREATE CHANGEFEED FOR foo INTO $1 WITH format='experimental_avro', confluent_schema_registry=$2`,
+		t, `this sink is incompatible with format=avro`,
+		`CREATE CHANGEFEED FOR foo INTO $1 WITH format='avro', confluent_schema_registry=$2`,
 		`experimental-nodelocal://0/bar`, `schemareg-nope`,
 	)
 	sqlDB.ExpectErr(
@@ -1956,6 +2096,41 @@ func TestChangefeedErrors(t *testing.T) {
 		t, `cannot specify both initial_scan and no_initial_scan`,
 		`CREATE CHANGEFEED FOR foo INTO $1 WITH no_initial_scan, initial_scan`, `kafka://nope`,
 	)
+
+	// CDC queries are checked against the watched table.
+	sqlDB.ExpectErr(
+		t, `column "nope" does not exist`,
+		`CREATE CHANGEFEED INTO $1 AS SELECT nope FROM foo`, `kafka://nope`,
+	)
+	sqlDB.ExpectErr(
+		t, `argument of CDC query WHERE clause must be type bool, not type int`,
+		`CREATE CHANGEFEED INTO $1 AS SELECT * FROM foo WHERE a`, `kafka://nope`,
+	)
+	sqlDB.ExpectErr(
+		t, `aggregate functions are not allowed in CDC query select list`,
+		`CREATE CHANGEFEED INTO $1 AS SELECT max(a) FROM foo`, `kafka://nope`,
+	)
+	sqlDB.ExpectErr(
+		t, `impure functions are not allowed in CDC query WHERE clause`,
+		`CREATE CHANGEFEED INTO $1 AS SELECT * FROM foo WHERE random() > 0.5`, `kafka://nope`,
+	)
+	sqlDB.ExpectErr(
+		t, `subqueries are not allowed in CDC query select list`,
+		`CREATE CHANGEFEED INTO $1 AS SELECT (SELECT 1) FROM foo`, `kafka://nope`,
+	)
+	sqlDB.ExpectErr(
+		t, `column name "a" specified more than once in CDC query`,
+		`CREATE CHANGEFEED INTO $1 AS SELECT *, b AS a FROM foo`, `kafka://nope`,
+	)
+	sqlDB.ExpectErr(
+		t, `relation "bar" does not exist`,
+		`CREATE CHANGEFEED INTO $1 AS SELECT bar.* FROM foo`, `kafka://nope`,
+	)
+	sqlDB.ExpectErr(
+		t, `CDC queries are not supported with format=avro`,
+		`CREATE CHANGEFEED INTO $1 WITH format='avro', confluent_schema_registry=$2 `+
+			`AS SELECT * FROM foo`, `kafka://nope`, `schemareg-nope`,
+	)
 }
 
 func TestChangefeedPermissions(t *testing.T) {
@@ -73,8 +73,10 @@ const (
 	OptEnvelopeDeprecatedRow EnvelopeType = `deprecated_row`
 	OptEnvelopeWrapped       EnvelopeType = `wrapped`
 
-	OptFormatJSON FormatType = `json`
-	OptFormatAvro FormatType = `experimental_avro`
+	OptFormatJSON                       FormatType = `json`
+	OptFormatAvro                       FormatType = `avro`
+	OptFormatProtobuf                   FormatType = `protobuf`
+	OptFormatDeprecatedExperimentalAvro FormatType = `experimental_avro`
 
 	SinkParamCACert           = `ca_cert`
 	SinkParamClientCert       = `client_cert`
@@ -90,6 +92,13 @@ const (
 	SinkParamSASLHandshake    = `sasl_handshake`
 	SinkParamSASLUser         = `sasl_user`
 	SinkParamSASLPassword     = `sasl_password`
+
+	SinkSchemeWebhookHTTPS       = `webhook-https`
+	SinkParamWebhookBatchRows    = `batch_rows`
+	SinkParamWebhookBatchBytes   = `batch_bytes`
+	SinkParamWebhookBatchFreq    = `batch_frequency`
+	SinkParamWebhookMaxRetries   = `max_retries`
+	SinkParamWebhookRetryBackoff = `retry_backoff`
 )
 
 // ChangefeedOptionExpectValues is used to parse changefeed options using
@@ -14,18 +14,22 @@ import (
 	"encoding/binary"
 	gojson "encoding/json"
 	"io/ioutil"
+	"net/http"
 	"net/url"
 	"path/filepath"
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/base"
 	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/util/cache"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/httputil"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
 	"github.com/cockroachdb/cockroach/pkg/util/retry"
 	"github.com/cockroachdb/errors"
 )
@@ -59,6 +63,12 @@ type encodeRow struct {
 	// prevTableDesc is a TableDescriptor for the table containing `prevDatums`.
 	// It's valid for interpreting the row at `updated.Prev()`.
 	prevTableDesc *sqlbase.TableDescriptor
+	// projection, if set, is the select list of a CDC query evaluated on
+	// `datums`. It replaces the columns of the table in the encoded value.
+	projection *cdcProjection
+	// prevProjection is the select list of a CDC query evaluated on
+	// `prevDatums`, if any.
+	prevProjection *cdcProjection
 }
 
 // Encoder turns a row into a serialized changefeed key, value, or resolved
@@ -84,8 +94,10 @@ func getEncoder(opts map[string]string) (Encoder, error) {
 	switch changefeedbase.FormatType(opts[changefeedbase.OptFormat]) {
 	case ``, changefeedbase.OptFormatJSON:
 		return makeJSONEncoder(opts)
-	case changefeedbase.OptFormatAvro:
+	case changefeedbase.OptFormatAvro, changefeedbase.OptFormatDeprecatedExperimentalAvro:
 		return newConfluentAvroEncoder(opts)
+	case changefeedbase.OptFormatProtobuf:
+		return makeProtobufEncoder(opts)
 	default:
 		return nil, errors.Errorf(`unknown %s: %s`, changefeedbase.OptFormat, opts[changefeedbase.OptFormat])
 	}
@@ -166,7 +178,12 @@ func (e *jsonEncoder) EncodeValue(_ context.Context, row encodeRow) ([]byte, err
 	}
 
 	var after map[string]interface{}
-	if !row.deleted {
+	if row.projection != nil && !row.deleted {
+		var err error
+		if after, err = row.projection.asJSON(); err != nil {
+			return nil, err
+		}
+	} else if !row.deleted {
 		columns := row.tableDesc.Columns
 		after = make(map[string]interface{}, len(columns))
 		for i := range columns {
@@ -184,7 +201,12 @@ func (e *jsonEncoder) EncodeValue(_ context.Context, row encodeRow) ([]byte, err
 	}
 
 	var before map[string]interface{}
-	if row.prevDatums != nil && !row.prevDeleted {
+	if row.prevProjection != nil {
+		var err error
+		if before, err = row.prevProjection.asJSON(); err != nil {
+			return nil, err
+		}
+	} else if row.prevDatums != nil && !row.prevDeleted {
 		columns := row.prevTableDesc.Columns
 		before = make(map[string]interface{}, len(columns))
 		for i := range columns {
@@ -274,6 +296,20 @@ type confluentAvroEncoder struct {
 	keyCache      map[tableIDAndVersion]confluentRegisteredKeySchema
 	valueCache    map[tableIDAndVersionPair]confluentRegisteredEnvelopeSchema
 	resolvedCache map[string]confluentRegisteredEnvelopeSchema
+
+	// registryIDCache maps schemas already registered under a subject to their
+	// registry ids. Most schema changes don't affect the avro schemas, so this
+	// avoids re-registering them every time the version of a table descriptor
+	// changes. It maps confluentSubjectSchema to int32.
+	registryIDCache *cache.UnorderedCache
+}
+
+// confluentRegistryIDCacheSize is the maximum number of schemas whose registry
+// ids are cached by a confluentAvroEncoder.
+const confluentRegistryIDCacheSize = 256
+
+type confluentSubjectSchema struct {
+	subject, schema string
 }
 
 type tableIDAndVersion uint64
@@ -330,6 +366,12 @@ func newConfluentAvroEncoder(opts map[string]string) (*confluentAvroEncoder, err
 	e.keyCache = make(map[tableIDAndVersion]confluentRegisteredKeySchema)
 	e.valueCache = make(map[tableIDAndVersionPair]confluentRegisteredEnvelopeSchema)
 	e.resolvedCache = make(map[string]confluentRegisteredEnvelopeSchema)
+	e.registryIDCache = cache.NewUnorderedCache(cache.Config{
+		Policy: cache.CacheLRU,
+		ShouldEvict: func(size int, _, _ interface{}) bool {
+			return size > confluentRegistryIDCacheSize
+		},
+	})
 	return e, nil
 }
 
@@ -477,13 +519,18 @@ func (e *confluentAvroEncoder) register(
 		ID int32 `json:"id"`
 	}
 
+	schemaStr := schema.codec.Schema()
+	cacheKey := confluentSubjectSchema{subject: subject, schema: schemaStr}
+	if id, ok := e.registryIDCache.Get(cacheKey); ok {
+		return id.(int32), nil
+	}
+
 	url, err := url.Parse(e.registryURL)
 	if err != nil {
 		return 0, err
 	}
 	url.Path = filepath.Join(url.EscapedPath(), `subjects`, subject, `versions`)
 
-	schemaStr := schema.codec.Schema()
 	if log.V(1) {
 		log.Infof(ctx, "registering avro schema %s %s", url, schemaStr)
 	}
@@ -495,6 +542,9 @@ func (e *confluentAvroEncoder) register(
 	}
 
 	var id int32
+	// rejectedErr is set if the registry refused the schema, which retrying
+	// won't fix.
+	var rejectedErr error
 
 	// Since network services are often a source of flakes, add a few retries here
 	// before we give up and return an error that will bubble up and tear down the
@@ -508,12 +558,25 @@ func (e *confluentAvroEncoder) register(
 	// actionable issues in the operator's environment that which they might be
 	// able to resolve if we made them visible in a failure instead.
 	if err := retry.WithMaxAttempts(ctx, base.DefaultRetryOptions(), 3, func() error {
-		resp, err := httputil.Post(ctx, url.String(), confluentSchemaContentType, &buf)
+		resp, err := httputil.Post(
+			ctx, url.String(), confluentSchemaContentType, bytes.NewReader(buf.Bytes()))
 		if err != nil {
 			return errors.Wrap(err, "contacting confluent schema registry")
 		}
 		defer resp.Body.Close()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		switch {
+		case resp.StatusCode == http.StatusConflict:
+			body, _ := ioutil.ReadAll(resp.Body)
+			rejectedErr = errors.Errorf(
+				`schema for subject %s is incompatible with the schema previously registered to %s: %s`,
+				subject, url.String(), body)
+			return nil
+		case resp.StatusCode == http.StatusUnprocessableEntity:
+			body, _ := ioutil.ReadAll(resp.Body)
+			rejectedErr = errors.Errorf(
+				`schema for subject %s was rejected as invalid by %s: %s`, subject, url.String(), body)
+			return nil
+		case resp.StatusCode < 200 || resp.StatusCode >= 300:
 			body, _ := ioutil.ReadAll(resp.Body)
 			return errors.Errorf(`registering schema to %s %s: %s`, url.String(), resp.Status, body)
 		}
@@ -527,6 +590,114 @@ func (e *confluentAvroEncoder) register(
 		log.Warningf(ctx, "%+v", err)
 		return 0, MarkRetryableError(err)
 	}
+	if rejectedErr != nil {
+		// Unlike the errors above, this is not retryable: the changefeed would
+		// keep producing the same schema.
+		return 0, rejectedErr
+	}
 
+	e.registryIDCache.Add(cacheKey, id)
 	return id, nil
 }
+
+// protobufEncoder encodes changefeed entries as the protobuf messages in
+// changefeedpb. Keys are a Key message with the primary key columns. Values
+// and resolved timestamp payloads are an Envelope message.
+type protobufEncoder struct {
+	updatedField, beforeField, keyOnly, keyInValue bool
+
+	alloc sqlbase.DatumAlloc
+	buf   []byte
+}
+
+var _ Encoder = &protobufEncoder{}
+
+func makeProtobufEncoder(opts map[string]string) (*protobufEncoder, error) {
+	e := &protobufEncoder{}
+
+	switch opts[changefeedbase.OptEnvelope] {
+	case string(changefeedbase.OptEnvelopeKeyOnly):
+		e.keyOnly = true
+	case string(changefeedbase.OptEnvelopeWrapped):
+	default:
+		return nil, errors.Errorf(`%s=%s is not supported with %s=%s`,
+			changefeedbase.OptEnvelope, opts[changefeedbase.OptEnvelope], changefeedbase.OptFormat, changefeedbase.OptFormatProtobuf)
+	}
+	_, e.updatedField = opts[changefeedbase.OptUpdatedTimestamps]
+	if e.updatedField && e.keyOnly {
+		return nil, errors.Errorf(`%s is only usable with %s=%s`,
+			changefeedbase.OptUpdatedTimestamps, changefeedbase.OptEnvelope, changefeedbase.OptEnvelopeWrapped)
+	}
+	_, e.beforeField = opts[changefeedbase.OptDiff]
+	if e.beforeField && e.keyOnly {
+		return nil, errors.Errorf(`%s is only usable with %s=%s`,
+			changefeedbase.OptDiff, changefeedbase.OptEnvelope, changefeedbase.OptEnvelopeWrapped)
+	}
+	_, e.keyInValue = opts[changefeedbase.OptKeyInValue]
+	if e.keyInValue && e.keyOnly {
+		return nil, errors.Errorf(`%s is only usable with %s=%s`,
+			changefeedbase.OptKeyInValue, changefeedbase.OptEnvelope, changefeedbase.OptEnvelopeWrapped)
+	}
+	return e, nil
+}
+
+// EncodeKey implements the Encoder interface.
+func (e *protobufEncoder) EncodeKey(_ context.Context, row encodeRow) ([]byte, error) {
+	key, err := rowToProtobufKey(row.tableDesc, row.datums, &e.alloc)
+	if err != nil {
+		return nil, err
+	}
+	return e.marshal(key)
+}
+
+// EncodeValue implements the Encoder interface.
+func (e *protobufEncoder) EncodeValue(_ context.Context, row encodeRow) ([]byte, error) {
+	if e.keyOnly {
+		return nil, nil
+	}
+
+	var envelope changefeedpb.Envelope
+	if !row.deleted {
+		var err error
+		if envelope.After, err = rowToProtobufRecord(row.tableDesc, row.datums, &e.alloc); err != nil {
+			return nil, err
+		}
+	}
+	if e.beforeField && row.prevDatums != nil && !row.prevDeleted {
+		var err error
+		envelope.Before, err = rowToProtobufRecord(row.prevTableDesc, row.prevDatums, &e.alloc)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if e.keyInValue {
+		var err error
+		if envelope.Key, err = rowToProtobufKey(row.tableDesc, row.datums, &e.alloc); err != nil {
+			return nil, err
+		}
+	}
+	if e.updatedField {
+		envelope.Updated = row.updated.AsOfSystemTime()
+	}
+	return e.marshal(&envelope)
+}
+
+// EncodeResolvedTimestamp implements the Encoder interface.
+func (e *protobufEncoder) EncodeResolvedTimestamp(
+	_ context.Context, _ string, resolved hlc.Timestamp,
+) ([]byte, error) {
+	return e.marshal(&changefeedpb.Envelope{Resolved: resolved.AsOfSystemTime()})
+}
+
+func (e *protobufEncoder) marshal(msg protoutil.Message) ([]byte, error) {
+	size := msg.Size()
+	if cap(e.buf) < size {
+		e.buf = make([]byte, size)
+	}
+	e.buf = e.buf[:size]
+	n, err := msg.MarshalTo(e.buf)
+	if err != nil {
+		return nil, err
+	}
+	return e.buf[:n], nil
+}
@@ -16,17 +16,20 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/cockroachdb/cockroach-go/crdb"
 	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/cdctest"
 	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
 	"github.com/cockroachdb/cockroach/pkg/testutils"
 	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
 	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
 	"github.com/cockroachdb/cockroach/pkg/workload/ledger"
 	"github.com/cockroachdb/cockroach/pkg/workload/workloadsql"
@@ -119,46 +122,46 @@ func TestEncoders(t *testing.T) {
 			delete:   `[1]->{"after": null, "before": {"a": 1, "b": "bar"}, "updated": "1.0000000002"}`,
 			resolved: `{"resolved":"1.0000000002"}`,
 		},
-		`format=experimental_avro,envelope=key_only`: {
+		`format=avro,envelope=key_only`: {
 			insert:   `{"a":{"long":1}}->`,
 			delete:   `{"a":{"long":1}}->`,
 			resolved: `{"resolved":{"string":"1.0000000002"}}`,
 		},
-		`format=experimental_avro,envelope=key_only,updated`: {
+		`format=avro,envelope=key_only,updated`: {
 			err: `updated is only usable with envelope=wrapped`,
 		},
-		`format=experimental_avro,envelope=key_only,diff`: {
+		`format=avro,envelope=key_only,diff`: {
 			err: `diff is only usable with envelope=wrapped`,
 		},
-		`format=experimental_avro,envelope=key_only,updated,diff`: {
+		`format=avro,envelope=key_only,updated,diff`: {
 			err: `updated is only usable with envelope=wrapped`,
 		},
-		`format=experimental_avro,envelope=row`: {
-			err: `envelope=row is not supported with format=experimental_avro`,
+		`format=avro,envelope=row`: {
+			err: `envelope=row is not supported with format=avro`,
 		},
-		`format=experimental_avro,envelope=row,updated`: {
-			err: `envelope=row is not supported with format=experimental_avro`,
+		`format=avro,envelope=row,updated`: {
+			err: `envelope=row is not supported with format=avro`,
 		},
-		`format=experimental_avro,envelope=row,diff`: {
-			err: `envelope=row is not supported with format=experimental_avro`,
+		`format=avro,envelope=row,diff`: {
+			err: `envelope=row is not supported with format=avro`,
 		},
-		`format=experimental_avro,envelope=row,updated,diff`: {
-			err: `envelope=row is not supported with format=experimental_avro`,
+		`format=avro,envelope=row,updated,diff`: {
+			err: `envelope=row is not supported with format=avro`,
 		},
-		`format=experimental_avro,envelope=wrapped`: {
+		`format=avro,envelope=wrapped`: {
 			insert: `{"a":{"long":1}}->` +
 				`{"after":{"foo":{"a":{"long":1},"b":{"string":"bar"}}}}`,
 			delete:   `{"a":{"long":1}}->{"after":null}`,
 			resolved: `{"resolved":{"string":"1.0000000002"}}`,
 		},
-		`format=experimental_avro,envelope=wrapped,updated`: {
+		`format=avro,envelope=wrapped,updated`: {
 			insert: `{"a":{"long":1}}->` +
 				`{"after":{"foo":{"a":{"long":1},"b":{"string":"bar"}}},` +
 				`"updated":{"string":"1.0000000002"}}`,
 			delete:   `{"a":{"long":1}}->{"after":null,"updated":{"string":"1.0000000002"}}`,
 			resolved: `{"resolved":{"string":"1.0000000002"}}`,
 		},
-		`format=experimental_avro,envelope=wrapped,diff`: {
+		`format=avro,envelope=wrapped,diff`: {
 			insert: `{"a":{"long":1}}->` +
 				`{"after":{"foo":{"a":{"long":1},"b":{"string":"bar"}}},` +
 				`"before":null}`,
@@ -167,7 +170,7 @@ func TestEncoders(t *testing.T) {
 				`"before":{"foo_before":{"a":{"long":1},"b":{"string":"bar"}}}}`,
 			resolved: `{"resolved":{"string":"1.0000000002"}}`,
 		},
-		`format=experimental_avro,envelope=wrapped,updated,diff`: {
+		`format=avro,envelope=wrapped,updated,diff`: {
 			insert: `{"a":{"long":1}}->` +
 				`{"after":{"foo":{"a":{"long":1},"b":{"string":"bar"}}},` +
 				`"before":null,` +
@@ -261,12 +264,19 @@ type testSchemaRegistry struct {
 		syncutil.Mutex
 		idAlloc int32
 		schemas map[int32]string
+		// registrations counts the registration requests for each subject.
+		registrations map[string]int
+		// incompatible is the set of subjects for which the registry rejects
+		// every schema as incompatible.
+		incompatible map[string]struct{}
 	}
 }
 
 func makeTestSchemaRegistry() *testSchemaRegistry {
 	r := &testSchemaRegistry{}
 	r.mu.schemas = make(map[int32]string)
+	r.mu.registrations = make(map[string]int)
+	r.mu.incompatible = make(map[string]struct{})
 	r.server = httptest.NewServer(http.HandlerFunc(r.Register))
 	return r
 }
@@ -275,6 +285,26 @@ func (r *testSchemaRegistry) Close() {
 	r.server.Close()
 }
 
+// Registrations returns the number of registration requests for each subject.
+func (r *testSchemaRegistry) Registrations() map[string]int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	registrations := make(map[string]int, len(r.mu.registrations))
+	for subject, n := range r.mu.registrations {
+		registrations[subject] = n
+	}
+	return registrations
+}
+
+// SetIncompatible makes the registry reject every schema registered under the
+// given subject, like a registry with compatibility checks does for schemas
+// that break compatibility.
+func (r *testSchemaRegistry) SetIncompatible(subject string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.mu.incompatible[subject] = struct{}{}
+}
+
 func (r *testSchemaRegistry) Register(hw http.ResponseWriter, hr *http.Request) {
 	type confluentSchemaVersionRequest struct {
 		Schema string `json:"schema"`
@@ -289,7 +319,19 @@ func (r *testSchemaRegistry) Register(hw http.ResponseWriter, hr *http.Request)
 			return err
 		}
 
+		// The path is /subjects/<subject>/versions.
+		subject := strings.TrimSuffix(strings.TrimPrefix(hr.URL.Path, `/subjects/`), `/versions`)
+
 		r.mu.Lock()
+		r.mu.registrations[subject]++
+		if _, ok := r.mu.incompatible[subject]; ok {
+			r.mu.Unlock()
+			hw.Header().Set(`Content-type`, `application/json`)
+			hw.WriteHeader(http.StatusConflict)
+			_, _ = hw.Write([]byte(`{"error_code":409,` +
+				`"message":"Schema being registered is incompatible with an earlier schema"}`))
+			return nil
+		}
 		id := r.mu.idAlloc
 		r.mu.idAlloc++
 		r.mu.schemas[id] = req.Schema
@@ -385,6 +427,197 @@ func TestAvroEncoder(t *testing.T) {
 	t.Run(`enterprise`, enterpriseTest(testFn))
 }
 
+func TestProtobufEncoder(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	tableDesc, err := parseTableDesc(`CREATE TABLE foo (` +
+		`a INT PRIMARY KEY, b STRING, c BOOL, d FLOAT, e BYTES, f DECIMAL, g INT[], h INT)`)
+	require.NoError(t, err)
+	rows, err := parseValues(tableDesc,
+		`VALUES (1, 'bar', true, 1.5, b'\x01', 1.23, ARRAY[2, NULL], NULL)`)
+	require.NoError(t, err)
+	ts := hlc.Timestamp{WallTime: 1, Logical: 2}
+
+	intValue := func(i int64) *changefeedpb.Value {
+		return &changefeedpb.Value{Value: &changefeedpb.Value_Int64Value{Int64Value: i}}
+	}
+	expectedKey := &changefeedpb.Key{Values: []*changefeedpb.Value{intValue(1)}}
+	column := func(name string, value *changefeedpb.Value) *changefeedpb.Record_Column {
+		return &changefeedpb.Record_Column{Name: name, Value: value}
+	}
+	expectedRecord := &changefeedpb.Record{Columns: []*changefeedpb.Record_Column{
+		column(`a`, intValue(1)),
+		column(`b`, &changefeedpb.Value{Value: &changefeedpb.Value_StringValue{StringValue: `bar`}}),
+		column(`c`, &changefeedpb.Value{Value: &changefeedpb.Value_BoolValue{BoolValue: true}}),
+		column(`d`, &changefeedpb.Value{Value: &changefeedpb.Value_DoubleValue{DoubleValue: 1.5}}),
+		column(`e`, &changefeedpb.Value{Value: &changefeedpb.Value_BytesValue{BytesValue: []byte{1}}}),
+		column(`f`, &changefeedpb.Value{Value: &changefeedpb.Value_StringValue{StringValue: `1.23`}}),
+		column(`g`, &changefeedpb.Value{Value: &changefeedpb.Value_ArrayValue{ArrayValue: &changefeedpb.Array{
+			Values: []*changefeedpb.Value{intValue(2), {}},
+		}}}),
+		column(`h`, &changefeedpb.Value{}),
+	}}
+
+	t.Run(`wrapped`, func(t *testing.T) {
+		e, err := getEncoder(map[string]string{
+			changefeedbase.OptFormat:            string(changefeedbase.OptFormatProtobuf),
+			changefeedbase.OptEnvelope:          string(changefeedbase.OptEnvelopeWrapped),
+			changefeedbase.OptDiff:              ``,
+			changefeedbase.OptUpdatedTimestamps: ``,
+			changefeedbase.OptKeyInValue:        ``,
+		})
+		require.NoError(t, err)
+
+		rowDelete := encodeRow{
+			datums:        rows[0],
+			deleted:       true,
+			prevDatums:    rows[0],
+			updated:       ts,
+			tableDesc:     tableDesc,
+			prevTableDesc: tableDesc,
+		}
+		keyBytes, err := e.EncodeKey(ctx, rowDelete)
+		require.NoError(t, err)
+		var key changefeedpb.Key
+		require.NoError(t, protoutil.Unmarshal(keyBytes, &key))
+		require.Equal(t, expectedKey, &key)
+
+		valueBytes, err := e.EncodeValue(ctx, rowDelete)
+		require.NoError(t, err)
+		var value changefeedpb.Envelope
+		require.NoError(t, protoutil.Unmarshal(valueBytes, &value))
+		require.Equal(t, &changefeedpb.Envelope{
+			Before:  expectedRecord,
+			Key:     expectedKey,
+			Updated: `1.0000000002`,
+		}, &value)
+
+		resolvedBytes, err := e.EncodeResolvedTimestamp(ctx, tableDesc.Name, ts)
+		require.NoError(t, err)
+		var resolved changefeedpb.Envelope
+		require.NoError(t, protoutil.Unmarshal(resolvedBytes, &resolved))
+		require.Equal(t, &changefeedpb.Envelope{Resolved: `1.0000000002`}, &resolved)
+	})
+
+	t.Run(`key_only`, func(t *testing.T) {
+		e, err := getEncoder(map[string]string{
+			changefeedbase.OptFormat:   string(changefeedbase.OptFormatProtobuf),
+			changefeedbase.OptEnvelope: string(changefeedbase.OptEnvelopeKeyOnly),
+		})
+		require.NoError(t, err)
+
+		rowInsert := encodeRow{datums: rows[0], updated: ts, tableDesc: tableDesc}
+		keyBytes, err := e.EncodeKey(ctx, rowInsert)
+		require.NoError(t, err)
+		var key changefeedpb.Key
+		require.NoError(t, protoutil.Unmarshal(keyBytes, &key))
+		require.Equal(t, expectedKey, &key)
+		valueBytes, err := e.EncodeValue(ctx, rowInsert)
+		require.NoError(t, err)
+		require.Nil(t, valueBytes)
+	})
+
+	t.Run(`errors`, func(t *testing.T) {
+		_, err := getEncoder(map[string]string{
+			changefeedbase.OptFormat:   string(changefeedbase.OptFormatProtobuf),
+			changefeedbase.OptEnvelope: string(changefeedbase.OptEnvelopeRow),
+		})
+		require.EqualError(t, err, `envelope=row is not supported with format=protobuf`)
+		_, err = getEncoder(map[string]string{
+			changefeedbase.OptFormat:     string(changefeedbase.OptFormatProtobuf),
+			changefeedbase.OptEnvelope:   string(changefeedbase.OptEnvelopeKeyOnly),
+			changefeedbase.OptKeyInValue: ``,
+		})
+		require.EqualError(t, err, `key_in_value is only usable with envelope=wrapped`)
+	})
+}
+
+func TestAvroEncoderEnumsAndArrays(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	testFn := func(t *testing.T, db *gosql.DB, f cdctest.TestFeedFactory) {
+		reg := makeTestSchemaRegistry()
+		defer reg.Close()
+
+		sqlDB := sqlutils.MakeSQLRunner(db)
+		sqlDB.Exec(t, `SET experimental_enable_enums = true`)
+		sqlDB.Exec(t, `CREATE TYPE status AS ENUM ('open', 'closed', 'ice cream')`)
+		sqlDB.Exec(t, `CREATE TABLE foo (a INT PRIMARY KEY, b status, c STRING[])`)
+		sqlDB.Exec(t, `INSERT INTO foo VALUES (1, 'open', ARRAY['x', NULL]), (2, 'ice cream', NULL)`)
+
+		foo := feed(t, f, `CREATE CHANGEFEED FOR foo `+
+			`WITH format=$1, confluent_schema_registry=$2`,
+			changefeedbase.OptFormatAvro, reg.server.URL)
+		defer closeFeed(t, foo)
+		assertPayloadsAvro(t, reg, foo, []string{
+			`foo: {"a":{"long":1}}->{"after":{"foo":{"a":{"long":1},"b":{"foo_b":"open"},` +
+				`"c":{"array":[{"string":"x"},null]}}}}`,
+			`foo: {"a":{"long":2}}->{"after":{"foo":{"a":{"long":2},"b":{"foo_b":"ice_u0020_cream"},` +
+				`"c":null}}}`,
+		})
+
+		sqlDB.Exec(t, `UPDATE foo SET b = 'closed', c = ARRAY[] WHERE a = 2`)
+		assertPayloadsAvro(t, reg, foo, []string{
+			`foo: {"a":{"long":2}}->{"after":{"foo":{"a":{"long":2},"b":{"foo_b":"closed"},` +
+				`"c":{"array":[]}}}}`,
+		})
+	}
+
+	t.Run(`sinkless`, sinklessTest(testFn))
+	t.Run(`enterprise`, enterpriseTest(testFn))
+}
+
+func TestAvroSchemaRegistration(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	reg := makeTestSchemaRegistry()
+	defer reg.Close()
+	e, err := getEncoder(map[string]string{
+		changefeedbase.OptFormat:                  string(changefeedbase.OptFormatAvro),
+		changefeedbase.OptEnvelope:                string(changefeedbase.OptEnvelopeWrapped),
+		changefeedbase.OptConfluentSchemaRegistry: reg.server.URL,
+	})
+	require.NoError(t, err)
+
+	var version sqlbase.DescriptorVersion
+	encode := func(createTableStmt, values string) error {
+		tableDesc, err := parseTableDesc(createTableStmt)
+		require.NoError(t, err)
+		version++
+		tableDesc.Version = version
+		rows, err := parseValues(tableDesc, values)
+		require.NoError(t, err)
+		row := encodeRow{datums: rows[0], tableDesc: tableDesc}
+		if _, err := e.EncodeKey(ctx, row); err != nil {
+			return err
+		}
+		_, err = e.EncodeValue(ctx, row)
+		return err
+	}
+
+	require.NoError(t, encode(`CREATE TABLE foo (a INT PRIMARY KEY, b STRING)`, `VALUES (1, 'a')`))
+	require.Equal(t, map[string]int{`foo-key`: 1, `foo-value`: 1}, reg.Registrations())
+
+	// A new version of the table with the same columns doesn't register the
+	// same schemas again.
+	require.NoError(t, encode(`CREATE TABLE foo (a INT PRIMARY KEY, b STRING)`, `VALUES (1, 'a')`))
+	require.Equal(t, map[string]int{`foo-key`: 1, `foo-value`: 1}, reg.Registrations())
+
+	// Adding a column only changes the value schema.
+	require.NoError(t, encode(
+		`CREATE TABLE foo (a INT PRIMARY KEY, b STRING, c INT)`, `VALUES (1, 'a', 2)`))
+	require.Equal(t, map[string]int{`foo-key`: 1, `foo-value`: 2}, reg.Registrations())
+
+	// A schema rejected by the registry as incompatible is a terminal error.
+	reg.SetIncompatible(`foo-value`)
+	err = encode(`CREATE TABLE foo (a INT PRIMARY KEY, c INT)`, `VALUES (1, 2)`)
+	require.Regexp(t, `schema for subject foo-value is incompatible with the schema previously registered`, err)
+	require.False(t, IsRetryableError(err))
+	require.Equal(t, map[string]int{`foo-key`: 1, `foo-value`: 3}, reg.Registrations())
+}
+
 func TestAvroMigrateToUnsupportedColumn(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 
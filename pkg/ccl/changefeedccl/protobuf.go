@@ -0,0 +1,103 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package changefeedccl
+
+import (
+	"sort"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/errors"
+)
+
+// The file contains the mapping of SQL datums to the messages in changefeedpb.
+// Unlike avro, protobuf messages don't carry a schema specific to the table, so
+// every row of every table is encoded with the same messages and the mapping
+// is done datum by datum, instead of column by column.
+
+// datumToProtobufValue converts a datum into its corresponding protobuf value.
+func datumToProtobufValue(d tree.Datum) *changefeedpb.Value {
+	if d == tree.DNull {
+		return &changefeedpb.Value{}
+	}
+	switch t := d.(type) {
+	case *tree.DBool:
+		return &changefeedpb.Value{Value: &changefeedpb.Value_BoolValue{BoolValue: bool(*t)}}
+	case *tree.DInt:
+		return &changefeedpb.Value{Value: &changefeedpb.Value_Int64Value{Int64Value: int64(*t)}}
+	case *tree.DFloat:
+		return &changefeedpb.Value{Value: &changefeedpb.Value_DoubleValue{DoubleValue: float64(*t)}}
+	case *tree.DBytes:
+		return &changefeedpb.Value{Value: &changefeedpb.Value_BytesValue{BytesValue: []byte(*t)}}
+	case *tree.DGeography:
+		return &changefeedpb.Value{Value: &changefeedpb.Value_BytesValue{BytesValue: []byte(t.EWKB())}}
+	case *tree.DGeometry:
+		return &changefeedpb.Value{Value: &changefeedpb.Value_BytesValue{BytesValue: []byte(t.EWKB())}}
+	case *tree.DArray:
+		arr := &changefeedpb.Array{Values: make([]*changefeedpb.Value, len(t.Array))}
+		for i := range t.Array {
+			arr.Values[i] = datumToProtobufValue(t.Array[i])
+		}
+		return &changefeedpb.Value{Value: &changefeedpb.Value_ArrayValue{ArrayValue: arr}}
+	default:
+		return &changefeedpb.Value{
+			Value: &changefeedpb.Value_StringValue{StringValue: tree.AsStringWithFlags(d, tree.FmtExport)},
+		}
+	}
+}
+
+// rowToProtobufRecord converts a row into a protobuf record holding the name
+// and value of each column. The columns are ordered by column ID, so that the
+// encoding of a row is deterministic.
+func rowToProtobufRecord(
+	tableDesc *sqlbase.TableDescriptor, row sqlbase.EncDatumRow, alloc *sqlbase.DatumAlloc,
+) (*changefeedpb.Record, error) {
+	columns := tableDesc.Columns
+	colIdxs := make([]int, len(columns))
+	for i := range colIdxs {
+		colIdxs[i] = i
+	}
+	sort.Slice(colIdxs, func(i, j int) bool {
+		return columns[colIdxs[i]].ID < columns[colIdxs[j]].ID
+	})
+	record := &changefeedpb.Record{Columns: make([]*changefeedpb.Record_Column, len(columns))}
+	for i, idx := range colIdxs {
+		col := &columns[idx]
+		if err := row[idx].EnsureDecoded(col.Type, alloc); err != nil {
+			return nil, err
+		}
+		record.Columns[i] = &changefeedpb.Record_Column{
+			Name:  col.Name,
+			Value: datumToProtobufValue(row[idx].Datum),
+		}
+	}
+	return record, nil
+}
+
+// rowToProtobufKey converts the primary key columns of a row into a protobuf
+// key.
+func rowToProtobufKey(
+	tableDesc *sqlbase.TableDescriptor, row sqlbase.EncDatumRow, alloc *sqlbase.DatumAlloc,
+) (*changefeedpb.Key, error) {
+	colIdxByID := tableDesc.ColumnIdxMap()
+	key := &changefeedpb.Key{Values: make([]*changefeedpb.Value, len(tableDesc.PrimaryIndex.ColumnIDs))}
+	for i, colID := range tableDesc.PrimaryIndex.ColumnIDs {
+		idx, ok := colIdxByID[colID]
+		if !ok {
+			return nil, errors.Errorf(`unknown column id: %d`, colID)
+		}
+		datum, col := row[idx], &tableDesc.Columns[idx]
+		if err := datum.EnsureDecoded(col.Type, alloc); err != nil {
+			return nil, err
+		}
+		key.Values[i] = datumToProtobufValue(datum.Datum)
+	}
+	return key, nil
+}
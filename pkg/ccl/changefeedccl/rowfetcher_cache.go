@@ -12,13 +12,18 @@ import (
 	"context"
 
 	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/lease"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/resolver"
 	"github.com/cockroachdb/cockroach/pkg/sql/row"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
 	"github.com/cockroachdb/cockroach/pkg/util"
+	"github.com/cockroachdb/cockroach/pkg/util/cache"
 	"github.com/cockroachdb/cockroach/pkg/util/encoding"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
 )
 
 // rowFetcherCache maintains a cache of single table RowFetchers. Given a key
@@ -29,7 +34,12 @@ import (
 type rowFetcherCache struct {
 	codec    keys.SQLCodec
 	leaseMgr *lease.Manager
+	db       *kv.DB
 	fetchers map[idVersion]*row.Fetcher
+	// hydrated caches the copies of the table descriptors with user-defined
+	// types, whose types are hydrated. It maps idVersion to
+	// *sqlbase.ImmutableTableDescriptor.
+	hydrated *cache.UnorderedCache
 
 	a sqlbase.DatumAlloc
 }
@@ -39,11 +49,31 @@ type idVersion struct {
 	version sqlbase.DescriptorVersion
 }
 
-func newRowFetcherCache(codec keys.SQLCodec, leaseMgr *lease.Manager) *rowFetcherCache {
+// idVersionCacheSize is the maximum number of table descriptor versions for
+// which the caches created by newIDVersionCache hold entries.
+const idVersionCacheSize = 256
+
+// newIDVersionCache returns an LRU cache keyed by idVersion. Changed rows are
+// almost always decoded with the latest versions of the table descriptors, so
+// the entries of older versions are evicted first.
+func newIDVersionCache() *cache.UnorderedCache {
+	return cache.NewUnorderedCache(cache.Config{
+		Policy: cache.CacheLRU,
+		ShouldEvict: func(size int, _, _ interface{}) bool {
+			return size > idVersionCacheSize
+		},
+	})
+}
+
+func newRowFetcherCache(
+	codec keys.SQLCodec, leaseMgr *lease.Manager, db *kv.DB,
+) *rowFetcherCache {
 	return &rowFetcherCache{
 		codec:    codec,
 		leaseMgr: leaseMgr,
+		db:       db,
 		fetchers: make(map[idVersion]*row.Fetcher),
+		hydrated: newIDVersionCache(),
 	}
 }
 
@@ -90,7 +120,50 @@ func (c *rowFetcherCache) TableDescForKey(
 		key = remaining
 	}
 
-	return tableDesc, nil
+	return c.hydrateTypes(ctx, tableDesc, ts)
+}
+
+// hydrateTypes returns a copy of the given table descriptor in which the
+// metadata of user-defined types, such as the members of ENUMs, is installed,
+// as of the given timestamp. The table descriptor is returned as is if it
+// doesn't use any user-defined types.
+//
+// The members of an ENUM can't change without the version of the table
+// descriptors that use it changing, so the copies are cached by table
+// descriptor version.
+func (c *rowFetcherCache) hydrateTypes(
+	ctx context.Context, tableDesc *sqlbase.ImmutableTableDescriptor, ts hlc.Timestamp,
+) (*sqlbase.ImmutableTableDescriptor, error) {
+	usesUserDefinedTypes := false
+	for i := range tableDesc.Columns {
+		if tableDesc.Columns[i].Type.UserDefined() {
+			usesUserDefinedTypes = true
+			break
+		}
+	}
+	if !usesUserDefinedTypes {
+		return tableDesc, nil
+	}
+	idVer := idVersion{id: tableDesc.ID, version: tableDesc.Version}
+	if hydrated, ok := c.hydrated.Get(idVer); ok {
+		return hydrated.(*sqlbase.ImmutableTableDescriptor), nil
+	}
+
+	desc := protoutil.Clone(tableDesc.TableDesc()).(*sqlbase.TableDescriptor)
+	if err := c.db.Txn(ctx, func(ctx context.Context, txn *kv.Txn) error {
+		txn.SetFixedTimestamp(ctx, ts)
+		typeLookup := func(id sqlbase.ID) (*tree.TypeName, sqlbase.TypeDescriptorInterface, error) {
+			return resolver.ResolveTypeDescByID(ctx, txn, c.codec, id, tree.ObjectLookupFlags{})
+		}
+		return sqlbase.HydrateTypesInTableDescriptor(desc, typeLookup)
+	}); err != nil {
+		// Like the lease manager, the type descriptor lookup can return all kinds
+		// of errors during chaos, but none of them should ever be terminal.
+		return nil, MarkRetryableError(err)
+	}
+	hydrated := sqlbase.NewImmutableTableDescriptor(*desc)
+	c.hydrated.Add(idVer, hydrated)
+	return hydrated, nil
 }
 
 func (c *rowFetcherCache) RowFetcherForTableDesc(
@@ -195,6 +195,69 @@ func getSink(
 				opts, timestampOracle, makeExternalStorageFromURI,
 			)
 		}
+	case isWebhookSink(u):
+		cfg := webhookSinkConfig{
+			maxRetries: defaultWebhookMaxRetries,
+			retryOpts:  defaultWebhookRetryOptions,
+		}
+		if caCertHex := q.Get(changefeedbase.SinkParamCACert); caCertHex != `` {
+			if cfg.caCert, err = base64.StdEncoding.DecodeString(caCertHex); err != nil {
+				return nil, errors.Errorf(`param %s must be base 64 encoded: %s`, changefeedbase.SinkParamCACert, err)
+			}
+		}
+		q.Del(changefeedbase.SinkParamCACert)
+		if clientCertHex := q.Get(changefeedbase.SinkParamClientCert); clientCertHex != `` {
+			if cfg.clientCert, err = base64.StdEncoding.DecodeString(clientCertHex); err != nil {
+				return nil, errors.Errorf(`param %s must be base 64 encoded: %s`, changefeedbase.SinkParamClientCert, err)
+			}
+		}
+		q.Del(changefeedbase.SinkParamClientCert)
+		if clientKeyHex := q.Get(changefeedbase.SinkParamClientKey); clientKeyHex != `` {
+			if cfg.clientKey, err = base64.StdEncoding.DecodeString(clientKeyHex); err != nil {
+				return nil, errors.Errorf(`param %s must be base 64 encoded: %s`, changefeedbase.SinkParamClientKey, err)
+			}
+		}
+		q.Del(changefeedbase.SinkParamClientKey)
+
+		if rowsParam := q.Get(changefeedbase.SinkParamWebhookBatchRows); rowsParam != `` {
+			if cfg.batchRows, err = strconv.Atoi(rowsParam); err != nil || cfg.batchRows < 0 {
+				return nil, errors.Errorf(`param %s must be a non-negative integer: %s`,
+					changefeedbase.SinkParamWebhookBatchRows, rowsParam)
+			}
+		}
+		q.Del(changefeedbase.SinkParamWebhookBatchRows)
+		if bytesParam := q.Get(changefeedbase.SinkParamWebhookBatchBytes); bytesParam != `` {
+			if cfg.batchBytes, err = humanizeutil.ParseBytes(bytesParam); err != nil {
+				return nil, pgerror.Wrapf(err, pgcode.Syntax, `parsing %s`, bytesParam)
+			}
+		}
+		q.Del(changefeedbase.SinkParamWebhookBatchBytes)
+		if freqParam := q.Get(changefeedbase.SinkParamWebhookBatchFreq); freqParam != `` {
+			if cfg.batchFrequency, err = time.ParseDuration(freqParam); err != nil || cfg.batchFrequency < 0 {
+				return nil, errors.Errorf(`param %s must be a non-negative duration: %s`,
+					changefeedbase.SinkParamWebhookBatchFreq, freqParam)
+			}
+		}
+		q.Del(changefeedbase.SinkParamWebhookBatchFreq)
+		if retriesParam := q.Get(changefeedbase.SinkParamWebhookMaxRetries); retriesParam != `` {
+			if cfg.maxRetries, err = strconv.Atoi(retriesParam); err != nil || cfg.maxRetries < 0 {
+				return nil, errors.Errorf(`param %s must be a non-negative integer: %s`,
+					changefeedbase.SinkParamWebhookMaxRetries, retriesParam)
+			}
+		}
+		q.Del(changefeedbase.SinkParamWebhookMaxRetries)
+		if backoffParam := q.Get(changefeedbase.SinkParamWebhookRetryBackoff); backoffParam != `` {
+			if cfg.retryOpts.InitialBackoff, err = time.ParseDuration(backoffParam); err != nil ||
+				cfg.retryOpts.InitialBackoff <= 0 {
+				return nil, errors.Errorf(`param %s must be a positive duration: %s`,
+					changefeedbase.SinkParamWebhookRetryBackoff, backoffParam)
+			}
+		}
+		q.Del(changefeedbase.SinkParamWebhookRetryBackoff)
+
+		makeSink = func() (Sink, error) {
+			return makeWebhookSink(u, cfg, opts)
+		}
 	case u.Scheme == changefeedbase.SinkSchemeExperimentalSQL:
 		// Swap the changefeed prefix for the sql connection one that sqlSink
 		// expects.
@@ -0,0 +1,446 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package changefeedccl
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/httputil"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/retry"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+)
+
+func isWebhookSink(u *url.URL) bool {
+	return u.Scheme == changefeedbase.SinkSchemeWebhookHTTPS
+}
+
+type webhookSinkConfig struct {
+	caCert     []byte
+	clientCert []byte
+	clientKey  []byte
+
+	// A batch is sent once it reaches batchRows rows or batchBytes bytes, or
+	// once its first row has been buffered for batchFrequency, whichever comes
+	// first. Zero values disable the corresponding trigger. If all of them are
+	// zero, a batch is sent as soon as no other request is in flight.
+	batchRows      int
+	batchBytes     int64
+	batchFrequency time.Duration
+
+	// A failed request is retried up to maxRetries times, with the backoff
+	// configured by retryOpts, before it is given up on and the error is
+	// returned by the next Flush.
+	maxRetries int
+	retryOpts  retry.Options
+}
+
+const defaultWebhookMaxRetries = 3
+
+var defaultWebhookRetryOptions = retry.Options{
+	InitialBackoff: 500 * time.Millisecond,
+	MaxBackoff:     30 * time.Second,
+	Multiplier:     2,
+}
+
+// webhookRequestTimeout bounds the duration of a single POST.
+const webhookRequestTimeout = time.Minute
+
+// webhookRequest is a sealed payload, ready to be POSTed. It accounts for
+// numMessages inflight messages.
+type webhookRequest struct {
+	body        []byte
+	numMessages int64
+}
+
+// webhookSink emits to an HTTPS endpoint. Rows are batched and sent as the
+// body of POST requests, in the following JSON format:
+//
+//   {"payload":[<row>,<row>,...],"length":<number of rows>}
+//
+// where each row is the JSON encoding of the row produced by the changefeed
+// encoder. Resolved timestamps are sent in their own request, with the body
+// produced by the encoder, after every row emitted before them.
+//
+// Requests are sent one at a time and in order by a worker goroutine, so the
+// ordering guarantees of the changefeed are preserved. Like kafkaSink, it is
+// not concurrency-safe; all calls to Emit and Flush should be from the same
+// goroutine.
+type webhookSink struct {
+	cfg    webhookSinkConfig
+	url    string
+	client *httputil.Client
+
+	// workerCtx is canceled by Close to abort the inflight request.
+	workerCtx    context.Context
+	cancelWorker func()
+	worker       sync.WaitGroup
+	// wakeCh signals the worker that a request was queued or that the
+	// current batch can be sealed. It has a capacity of 1 so that signaling
+	// never blocks.
+	wakeCh chan struct{}
+
+	// Only synchronized between the client goroutine and the worker goroutine.
+	mu struct {
+		syncutil.Mutex
+		// batch holds the rows of the batch that is being accumulated,
+		// batchBytes their total size and batchStart the time at which the
+		// first one was added.
+		batch      [][]byte
+		batchBytes int64
+		batchStart time.Time
+		// queue holds the sealed requests, in the order they are to be sent.
+		queue    []webhookRequest
+		inflight int64
+		flushErr error
+		flushCh  chan struct{}
+	}
+}
+
+func makeWebhookSink(u *url.URL, cfg webhookSinkConfig, opts map[string]string) (Sink, error) {
+	switch changefeedbase.FormatType(opts[changefeedbase.OptFormat]) {
+	case changefeedbase.OptFormatJSON:
+	default:
+		return nil, errors.Errorf(`this sink is incompatible with %s=%s`,
+			changefeedbase.OptFormat, opts[changefeedbase.OptFormat])
+	}
+
+	switch changefeedbase.EnvelopeType(opts[changefeedbase.OptEnvelope]) {
+	case changefeedbase.OptEnvelopeWrapped:
+	default:
+		return nil, errors.Errorf(`this sink is incompatible with %s=%s`,
+			changefeedbase.OptEnvelope, opts[changefeedbase.OptEnvelope])
+	}
+
+	if _, ok := opts[changefeedbase.OptKeyInValue]; !ok {
+		return nil, errors.Errorf(`this sink requires the WITH %s option`, changefeedbase.OptKeyInValue)
+	}
+
+	tlsConfig := &tls.Config{}
+	if cfg.caCert != nil {
+		caCertPool := x509.NewCertPool()
+		if !caCertPool.AppendCertsFromPEM(cfg.caCert) {
+			return nil, errors.Errorf(`invalid %s: no certificates found`, changefeedbase.SinkParamCACert)
+		}
+		tlsConfig.RootCAs = caCertPool
+	}
+	if cfg.clientCert != nil {
+		if cfg.clientKey == nil {
+			return nil, errors.Errorf(`%s requires %s to be set`, changefeedbase.SinkParamClientCert, changefeedbase.SinkParamClientKey)
+		}
+		cert, err := tls.X509KeyPair(cfg.clientCert, cfg.clientKey)
+		if err != nil {
+			return nil, errors.Errorf(`invalid client certificate data provided: %s`, err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	} else if cfg.clientKey != nil {
+		return nil, errors.Errorf(`%s requires %s to be set`, changefeedbase.SinkParamClientKey, changefeedbase.SinkParamClientCert)
+	}
+
+	// The changefeed-specific scheme is swapped for the one the endpoint
+	// actually speaks.
+	endpoint := *u
+	endpoint.Scheme = strings.TrimPrefix(endpoint.Scheme, `webhook-`)
+	endpoint.RawQuery = ``
+
+	s := &webhookSink{
+		cfg: cfg,
+		url: endpoint.String(),
+		client: &httputil.Client{Client: &http.Client{
+			Timeout: webhookRequestTimeout,
+			Transport: &http.Transport{
+				DialContext:     (&net.Dialer{Timeout: webhookRequestTimeout}).DialContext,
+				TLSClientConfig: tlsConfig,
+			},
+		}},
+		wakeCh: make(chan struct{}, 1),
+	}
+	s.workerCtx, s.cancelWorker = context.WithCancel(context.Background())
+	s.worker.Add(1)
+	go s.workerLoop()
+	return s, nil
+}
+
+// EmitRow implements the Sink interface.
+func (s *webhookSink) EmitRow(
+	ctx context.Context, _ *sqlbase.TableDescriptor, _, value []byte, _ hlc.Timestamp,
+) error {
+	s.mu.Lock()
+	if s.mu.flushErr != nil {
+		// Fail fast: nothing emitted from now on could be delivered in order.
+		err := s.mu.flushErr
+		s.mu.Unlock()
+		return err
+	}
+	if len(s.mu.batch) == 0 {
+		s.mu.batchStart = timeutil.Now()
+	}
+	// The encoder reuses value, so it has to be copied.
+	s.mu.batch = append(s.mu.batch, append([]byte(nil), value...))
+	s.mu.batchBytes += int64(len(value))
+	s.mu.inflight++
+	full := (s.cfg.batchRows > 0 && len(s.mu.batch) >= s.cfg.batchRows) ||
+		(s.cfg.batchBytes > 0 && s.mu.batchBytes >= s.cfg.batchBytes)
+	if full {
+		s.sealBatchLocked()
+	}
+	inflight := s.mu.inflight
+	s.mu.Unlock()
+
+	if full || s.batchesWhenIdle() {
+		s.wake()
+	}
+	if log.V(2) {
+		log.Infof(ctx, "emitted %d inflight records to webhook", inflight)
+	}
+	return nil
+}
+
+// EmitResolvedTimestamp implements the Sink interface.
+func (s *webhookSink) EmitResolvedTimestamp(
+	ctx context.Context, encoder Encoder, resolved hlc.Timestamp,
+) error {
+	var noTopic string
+	payload, err := encoder.EncodeResolvedTimestamp(ctx, noTopic, resolved)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	if s.mu.flushErr != nil {
+		err := s.mu.flushErr
+		s.mu.Unlock()
+		return err
+	}
+	// The resolved timestamp must not be delivered before the rows emitted
+	// before it, so the current batch is sealed and queued first.
+	s.sealBatchLocked()
+	s.mu.queue = append(s.mu.queue, webhookRequest{
+		body:        append([]byte(nil), payload...),
+		numMessages: 1,
+	})
+	s.mu.inflight++
+	s.mu.Unlock()
+
+	s.wake()
+	return nil
+}
+
+// Flush implements the Sink interface.
+func (s *webhookSink) Flush(ctx context.Context) error {
+	flushCh := make(chan struct{}, 1)
+
+	s.mu.Lock()
+	s.sealBatchLocked()
+	inflight := s.mu.inflight
+	flushErr := s.mu.flushErr
+	s.mu.flushErr = nil
+	immediateFlush := inflight == 0 || flushErr != nil
+	if !immediateFlush {
+		s.mu.flushCh = flushCh
+	}
+	s.mu.Unlock()
+
+	if immediateFlush {
+		return flushErr
+	}
+	s.wake()
+
+	if log.V(1) {
+		log.Infof(ctx, "flush waiting for %d inflight messages", inflight)
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-flushCh:
+		s.mu.Lock()
+		flushErr := s.mu.flushErr
+		s.mu.flushErr = nil
+		s.mu.Unlock()
+		return flushErr
+	}
+}
+
+// Close implements the Sink interface.
+func (s *webhookSink) Close() error {
+	s.cancelWorker()
+	s.worker.Wait()
+	s.client.CloseIdleConnections()
+	return nil
+}
+
+// batchesWhenIdle returns whether no batching trigger is configured, in
+// which case the current batch is sent whenever the worker is idle.
+func (s *webhookSink) batchesWhenIdle() bool {
+	return s.cfg.batchRows == 0 && s.cfg.batchBytes == 0 && s.cfg.batchFrequency == 0
+}
+
+func (s *webhookSink) wake() {
+	select {
+	case s.wakeCh <- struct{}{}:
+	default:
+	}
+}
+
+// sealBatchLocked turns the current batch, if any, into a queued request.
+func (s *webhookSink) sealBatchLocked() {
+	if len(s.mu.batch) == 0 {
+		return
+	}
+	var buf bytes.Buffer
+	buf.WriteString(`{"payload":[`)
+	for i, row := range s.mu.batch {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.Write(row)
+	}
+	fmt.Fprintf(&buf, `],"length":%d}`, len(s.mu.batch))
+	s.mu.queue = append(s.mu.queue, webhookRequest{
+		body:        buf.Bytes(),
+		numMessages: int64(len(s.mu.batch)),
+	})
+	s.mu.batch = nil
+	s.mu.batchBytes = 0
+}
+
+// nextRequest returns the next queued request, sealing the current batch
+// first if it is due. It returns false if there is nothing to send.
+func (s *webhookSink) nextRequest() (webhookRequest, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.mu.queue) == 0 && len(s.mu.batch) > 0 {
+		due := s.batchesWhenIdle() ||
+			(s.cfg.batchFrequency > 0 && timeutil.Since(s.mu.batchStart) >= s.cfg.batchFrequency)
+		if due {
+			s.sealBatchLocked()
+		}
+	}
+	if len(s.mu.queue) == 0 {
+		return webhookRequest{}, false
+	}
+	req := s.mu.queue[0]
+	s.mu.queue = s.mu.queue[1:]
+	return req, true
+}
+
+func (s *webhookSink) workerLoop() {
+	defer s.worker.Done()
+
+	var ticker *time.Ticker
+	var tickCh <-chan time.Time
+	if s.cfg.batchFrequency > 0 {
+		ticker = time.NewTicker(s.cfg.batchFrequency)
+		defer ticker.Stop()
+		tickCh = ticker.C
+	}
+
+	for {
+		select {
+		case <-s.workerCtx.Done():
+			return
+		case <-s.wakeCh:
+		case <-tickCh:
+		}
+
+		for {
+			req, ok := s.nextRequest()
+			if !ok {
+				break
+			}
+			err := s.sendWithRetries(s.workerCtx, req.body)
+			if s.workerCtx.Err() != nil {
+				return
+			}
+
+			s.mu.Lock()
+			if err != nil && s.mu.flushErr == nil {
+				s.mu.flushErr = err
+			}
+			s.mu.inflight -= req.numMessages
+			if err != nil {
+				// Once a request has failed, the requests queued after it are
+				// dropped so that they are not delivered out of order. The
+				// changefeed will be restarted from its last checkpoint.
+				for _, dropped := range s.mu.queue {
+					s.mu.inflight -= dropped.numMessages
+				}
+				s.mu.queue = nil
+				s.mu.inflight -= int64(len(s.mu.batch))
+				s.mu.batch = nil
+				s.mu.batchBytes = 0
+			}
+			if s.mu.inflight == 0 && s.mu.flushCh != nil {
+				s.mu.flushCh <- struct{}{}
+				s.mu.flushCh = nil
+			}
+			s.mu.Unlock()
+		}
+	}
+}
+
+// sendWithRetries POSTs body to the endpoint, retrying with backoff on
+// network errors and on responses that indicate a transient failure.
+func (s *webhookSink) sendWithRetries(ctx context.Context, body []byte) error {
+	var err error
+	attempt := 0
+	for r := retry.StartWithCtx(ctx, s.cfg.retryOpts); r.Next(); attempt++ {
+		var retryable bool
+		if retryable, err = s.send(ctx, body); err == nil || !retryable {
+			return err
+		}
+		if attempt >= s.cfg.maxRetries {
+			break
+		}
+		log.Warningf(ctx, "webhook sink request failed, retrying: %v", err)
+	}
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+	return err
+}
+
+// send POSTs body to the endpoint once. If the request fails, it returns
+// whether it is worth retrying.
+func (s *webhookSink) send(ctx context.Context, body []byte) (retryable bool, _ error) {
+	resp, err := s.client.Post(ctx, s.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return true, errors.Wrap(err, `sending to webhook sink`)
+	}
+	defer resp.Body.Close()
+	// Drain the body so that the connection can be reused, but keep the
+	// beginning of it for the error message.
+	respBody, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1<<10))
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	err = errors.Errorf(`webhook sink returned %s: %s`, resp.Status, bytes.TrimSpace(respBody))
+	retryable = resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests ||
+		resp.StatusCode == http.StatusRequestTimeout
+	return retryable, err
+}
@@ -0,0 +1,386 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package changefeedccl
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/security/securitytest"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/stretchr/testify/require"
+)
+
+// mockWebhookServer is an HTTPS endpoint that records the bodies of the
+// requests it receives. The first `failures` requests are answered with
+// `failureCode`.
+type mockWebhookServer struct {
+	*httptest.Server
+	bodiesCh chan string
+
+	mu struct {
+		syncutil.Mutex
+		failures    int
+		failureCode int
+	}
+}
+
+func makeMockWebhookServer(tlsConfig *tls.Config) *mockWebhookServer {
+	s := &mockWebhookServer{bodiesCh: make(chan string, 100)}
+	s.Server = httptest.NewUnstartedServer(http.HandlerFunc(s.handle))
+	s.TLS = tlsConfig
+	s.StartTLS()
+	return s
+}
+
+func (s *mockWebhookServer) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	fail := s.mu.failures > 0
+	if fail {
+		s.mu.failures--
+	}
+	code := s.mu.failureCode
+	s.mu.Unlock()
+	if fail {
+		http.Error(w, `injected failure`, code)
+		return
+	}
+	if r.Method != http.MethodPost || r.Header.Get(`Content-Type`) != `application/json` {
+		http.Error(w, `unexpected request`, http.StatusBadRequest)
+		return
+	}
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	s.bodiesCh <- string(body)
+}
+
+func (s *mockWebhookServer) failNext(n, code int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.mu.failures = n
+	s.mu.failureCode = code
+}
+
+// sinkURI returns the URI of a webhook sink that trusts the server's
+// certificate, with the given additional query parameters.
+func (s *mockWebhookServer) sinkURI(params url.Values) string {
+	caCert := pem.EncodeToMemory(&pem.Block{Type: `CERTIFICATE`, Bytes: s.Certificate().Raw})
+	if params == nil {
+		params = url.Values{}
+	}
+	params.Set(changefeedbase.SinkParamCACert, base64.StdEncoding.EncodeToString(caCert))
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		panic(err)
+	}
+	u.Scheme = changefeedbase.SinkSchemeWebhookHTTPS
+	u.Path = `/changefeed`
+	u.RawQuery = params.Encode()
+	return u.String()
+}
+
+func (s *mockWebhookServer) next(t *testing.T) string {
+	t.Helper()
+	select {
+	case body := <-s.bodiesCh:
+		return body
+	case <-time.After(testutils.DefaultSucceedsSoonDuration):
+		t.Fatal(`timed out waiting for a webhook request`)
+		return ``
+	}
+}
+
+func (s *mockWebhookServer) requireNoRequest(t *testing.T) {
+	t.Helper()
+	select {
+	case body := <-s.bodiesCh:
+		t.Fatalf(`unexpected webhook request: %s`, body)
+	default:
+	}
+}
+
+var webhookTestOpts = map[string]string{
+	changefeedbase.OptFormat:     string(changefeedbase.OptFormatJSON),
+	changefeedbase.OptEnvelope:   string(changefeedbase.OptEnvelopeWrapped),
+	changefeedbase.OptKeyInValue: ``,
+}
+
+func makeTestWebhookSink(t *testing.T, uri string) Sink {
+	t.Helper()
+	sink, err := getSink(
+		context.Background(), uri, 0 /* nodeID */, webhookTestOpts, nil /* targets */, nil, /* settings */
+		nil /* timestampOracle */, nil, /* makeExternalStorageFromURI */
+	)
+	require.NoError(t, err)
+	return sink
+}
+
+func TestWebhookSink(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	table := &sqlbase.TableDescriptor{Name: `foo`}
+	srv := makeMockWebhookServer(nil /* tlsConfig */)
+	defer srv.Close()
+
+	t.Run("batch rows", func(t *testing.T) {
+		sink := makeTestWebhookSink(t, srv.sinkURI(url.Values{
+			changefeedbase.SinkParamWebhookBatchRows: {`2`},
+		}))
+		defer func() { require.NoError(t, sink.Close()) }()
+
+		for i := 1; i <= 3; i++ {
+			value := []byte(fmt.Sprintf(`{"after":{"a":%d}}`, i))
+			require.NoError(t, sink.EmitRow(ctx, table, nil /* key */, value, zeroTS))
+		}
+		require.Equal(t, `{"payload":[{"after":{"a":1}},{"after":{"a":2}}],"length":2}`, srv.next(t))
+		// The third row waits for the batch to fill up, or for a flush.
+		srv.requireNoRequest(t)
+		require.NoError(t, sink.Flush(ctx))
+		require.Equal(t, `{"payload":[{"after":{"a":3}}],"length":1}`, srv.next(t))
+		require.NoError(t, sink.Flush(ctx))
+		srv.requireNoRequest(t)
+	})
+
+	t.Run("batch bytes", func(t *testing.T) {
+		sink := makeTestWebhookSink(t, srv.sinkURI(url.Values{
+			changefeedbase.SinkParamWebhookBatchBytes: {`10B`},
+		}))
+		defer func() { require.NoError(t, sink.Close()) }()
+
+		require.NoError(t, sink.EmitRow(ctx, table, nil /* key */, []byte(`"12345"`), zeroTS))
+		require.NoError(t, sink.EmitRow(ctx, table, nil /* key */, []byte(`"67890"`), zeroTS))
+		require.Equal(t, `{"payload":["12345","67890"],"length":2}`, srv.next(t))
+		require.NoError(t, sink.Flush(ctx))
+		srv.requireNoRequest(t)
+	})
+
+	t.Run("batch frequency", func(t *testing.T) {
+		sink := makeTestWebhookSink(t, srv.sinkURI(url.Values{
+			changefeedbase.SinkParamWebhookBatchRows: {`100`},
+			changefeedbase.SinkParamWebhookBatchFreq: {`10ms`},
+		}))
+		defer func() { require.NoError(t, sink.Close()) }()
+
+		// The row is sent without a flush.
+		require.NoError(t, sink.EmitRow(ctx, table, nil /* key */, []byte(`1`), zeroTS))
+		require.Equal(t, `{"payload":[1],"length":1}`, srv.next(t))
+	})
+
+	t.Run("resolved", func(t *testing.T) {
+		sink := makeTestWebhookSink(t, srv.sinkURI(url.Values{
+			changefeedbase.SinkParamWebhookBatchRows: {`100`},
+		}))
+		defer func() { require.NoError(t, sink.Close()) }()
+
+		// The resolved timestamp is sent after the rows emitted before it.
+		require.NoError(t, sink.EmitRow(ctx, table, nil /* key */, []byte(`1`), zeroTS))
+		require.NoError(t, sink.EmitResolvedTimestamp(ctx, testEncoder{}, hlc.Timestamp{WallTime: 2}))
+		require.NoError(t, sink.Flush(ctx))
+		require.Equal(t, `{"payload":[1],"length":1}`, srv.next(t))
+		require.Equal(t, `0.000000002,0`, srv.next(t))
+	})
+
+	t.Run("retries", func(t *testing.T) {
+		sink := makeTestWebhookSink(t, srv.sinkURI(url.Values{
+			changefeedbase.SinkParamWebhookRetryBackoff: {`1ms`},
+		}))
+		defer func() { require.NoError(t, sink.Close()) }()
+
+		srv.failNext(2, http.StatusServiceUnavailable)
+		require.NoError(t, sink.EmitRow(ctx, table, nil /* key */, []byte(`1`), zeroTS))
+		require.NoError(t, sink.Flush(ctx))
+		require.Equal(t, `{"payload":[1],"length":1}`, srv.next(t))
+	})
+
+	t.Run("retries exhausted", func(t *testing.T) {
+		sink := makeTestWebhookSink(t, srv.sinkURI(url.Values{
+			changefeedbase.SinkParamWebhookMaxRetries:   {`1`},
+			changefeedbase.SinkParamWebhookRetryBackoff: {`1ms`},
+		}))
+		defer func() { require.NoError(t, sink.Close()) }()
+
+		srv.failNext(2, http.StatusServiceUnavailable)
+		require.NoError(t, sink.EmitRow(ctx, table, nil /* key */, []byte(`1`), zeroTS))
+		require.EqualError(t, sink.Flush(ctx), `webhook sink returned 503 Service Unavailable: injected failure`)
+		srv.requireNoRequest(t)
+
+		// The sink is usable again once the error has been returned.
+		require.NoError(t, sink.EmitRow(ctx, table, nil /* key */, []byte(`2`), zeroTS))
+		require.NoError(t, sink.Flush(ctx))
+		require.Equal(t, `{"payload":[2],"length":1}`, srv.next(t))
+	})
+
+	t.Run("permanent failure", func(t *testing.T) {
+		sink := makeTestWebhookSink(t, srv.sinkURI(url.Values{
+			changefeedbase.SinkParamWebhookRetryBackoff: {`1ms`},
+		}))
+		defer func() { require.NoError(t, sink.Close()) }()
+
+		// Client errors are not retried.
+		srv.failNext(1, http.StatusBadRequest)
+		require.NoError(t, sink.EmitRow(ctx, table, nil /* key */, []byte(`1`), zeroTS))
+		require.EqualError(t, sink.Flush(ctx), `webhook sink returned 400 Bad Request: injected failure`)
+		srv.requireNoRequest(t)
+	})
+
+	t.Run("untrusted server", func(t *testing.T) {
+		u, err := url.Parse(srv.sinkURI(url.Values{
+			changefeedbase.SinkParamWebhookMaxRetries: {`0`},
+		}))
+		require.NoError(t, err)
+		q := u.Query()
+		q.Del(changefeedbase.SinkParamCACert)
+		u.RawQuery = q.Encode()
+		sink := makeTestWebhookSink(t, u.String())
+		defer func() { require.NoError(t, sink.Close()) }()
+
+		require.NoError(t, sink.EmitRow(ctx, table, nil /* key */, []byte(`1`), zeroTS))
+		require.True(t, testutils.IsError(sink.Flush(ctx), `certificate signed by unknown authority`))
+	})
+}
+
+func TestWebhookSinkClientCert(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	srv := makeMockWebhookServer(&tls.Config{ClientAuth: tls.RequireAnyClientCert})
+	defer srv.Close()
+
+	clientCert, err := securitytest.Asset(filepath.Join(security.EmbeddedCertsDir, security.EmbeddedRootCert))
+	require.NoError(t, err)
+	clientKey, err := securitytest.Asset(filepath.Join(security.EmbeddedCertsDir, security.EmbeddedRootKey))
+	require.NoError(t, err)
+
+	sink := makeTestWebhookSink(t, srv.sinkURI(url.Values{
+		changefeedbase.SinkParamClientCert: {base64.StdEncoding.EncodeToString(clientCert)},
+		changefeedbase.SinkParamClientKey:  {base64.StdEncoding.EncodeToString(clientKey)},
+	}))
+	defer func() { require.NoError(t, sink.Close()) }()
+
+	require.NoError(t, sink.EmitRow(ctx, &sqlbase.TableDescriptor{Name: `foo`}, nil /* key */, []byte(`1`), zeroTS))
+	require.NoError(t, sink.Flush(ctx))
+	require.Equal(t, `{"payload":[1],"length":1}`, srv.next(t))
+}
+
+func TestWebhookSinkConfigErrors(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	clientCert := base64.StdEncoding.EncodeToString([]byte(`cert`))
+	for _, tc := range []struct {
+		query    string
+		opts     func(map[string]string)
+		expected string
+	}{
+		{`?foo=bar`, nil, `unknown sink query parameter: foo`},
+		{`?batch_rows=-1`, nil, `param batch_rows must be a non-negative integer: -1`},
+		{`?batch_bytes=lots`, nil, `parsing lots`},
+		{`?batch_frequency=1`, nil, `param batch_frequency must be a non-negative duration: 1`},
+		{`?max_retries=x`, nil, `param max_retries must be a non-negative integer: x`},
+		{`?retry_backoff=0s`, nil, `param retry_backoff must be a positive duration: 0s`},
+		{`?ca_cert=!`, nil, `param ca_cert must be base 64 encoded`},
+		{`?ca_cert=Zm9v`, nil, `invalid ca_cert: no certificates found`},
+		{`?client_cert=` + clientCert, nil, `client_cert requires client_key to be set`},
+		{`?client_key=` + clientCert, nil, `client_key requires client_cert to be set`},
+		{`#avro`, func(opts map[string]string) {
+			opts[changefeedbase.OptFormat] = string(changefeedbase.OptFormatAvro)
+		}, `this sink is incompatible with format=avro`},
+		{`#key_only`, func(opts map[string]string) {
+			opts[changefeedbase.OptEnvelope] = string(changefeedbase.OptEnvelopeKeyOnly)
+		}, `this sink is incompatible with envelope=key_only`},
+		{`#no_key_in_value`, func(opts map[string]string) {
+			delete(opts, changefeedbase.OptKeyInValue)
+		}, `this sink requires the WITH key_in_value option`},
+	} {
+		t.Run(tc.query, func(t *testing.T) {
+			opts := make(map[string]string, len(webhookTestOpts))
+			for k, v := range webhookTestOpts {
+				opts[k] = v
+			}
+			if tc.opts != nil {
+				tc.opts(opts)
+			}
+			_, err := getSink(
+				context.Background(), `webhook-https://localhost/`+tc.query, 0 /* nodeID */, opts,
+				nil /* targets */, nil /* settings */, nil /* timestampOracle */, nil, /* makeExternalStorageFromURI */
+			)
+			if !testutils.IsError(err, tc.expected) {
+				t.Fatalf(`expected %q, got %v`, tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestChangefeedWebhookSink(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	srv := makeMockWebhookServer(nil /* tlsConfig */)
+	defer srv.Close()
+
+	s, db, _ := serverutils.StartServer(t, base.TestServerArgs{UseDatabase: `d`})
+	defer s.Stopper().Stop(ctx)
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.Exec(t, `SET CLUSTER SETTING kv.rangefeed.enabled = true`)
+	sqlDB.Exec(t, `CREATE DATABASE d`)
+	sqlDB.Exec(t, `CREATE TABLE foo (a INT PRIMARY KEY, b STRING)`)
+	sqlDB.Exec(t, `INSERT INTO foo VALUES (1, 'a'), (2, 'b')`)
+
+	var jobID int64
+	sqlDB.QueryRow(t, `CREATE CHANGEFEED FOR foo INTO $1 WITH resolved = '10ms'`,
+		srv.sinkURI(nil /* params */)).Scan(&jobID)
+	defer sqlDB.Exec(t, `CANCEL JOB $1`, jobID)
+
+	// Every row of the initial scan is delivered before the first resolved
+	// timestamp.
+	var rows []string
+	for {
+		body := srv.next(t)
+		if strings.HasPrefix(body, `{"resolved":`) {
+			break
+		}
+		var payload struct {
+			Payload []json.RawMessage `json:"payload"`
+			Length  int               `json:"length"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(body), &payload))
+		require.Len(t, payload.Payload, payload.Length)
+		for _, row := range payload.Payload {
+			rows = append(rows, string(row))
+		}
+	}
+	require.Equal(t, []string{
+		`{"after": {"a": 1, "b": "a"}, "key": [1]}`,
+		`{"after": {"a": 2, "b": "b"}, "key": [2]}`,
+	}, rows)
+}
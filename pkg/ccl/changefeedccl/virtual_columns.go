@@ -0,0 +1,114 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package changefeedccl
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemaexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/transform"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/util/cache"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/errors"
+)
+
+// virtualColumnEvaluator computes the values of the virtual computed columns
+// of changed rows. These columns aren't stored, so the row.Fetcher decodes
+// them as NULL. It is not threadsafe.
+type virtualColumnEvaluator struct {
+	codec   keys.SQLCodec
+	db      *kv.DB
+	evalCtx *tree.EvalContext
+	// exprs caches the computed expressions of the columns of each version of
+	// the table descriptors that have virtual columns. It maps idVersion to
+	// []tree.TypedExpr.
+	exprs     *cache.UnorderedCache
+	container cdcRowContainer
+	alloc     sqlbase.DatumAlloc
+}
+
+func newVirtualColumnEvaluator(
+	codec keys.SQLCodec, db *kv.DB, evalCtx *tree.EvalContext,
+) *virtualColumnEvaluator {
+	e := &virtualColumnEvaluator{
+		codec:   codec,
+		db:      db,
+		evalCtx: evalCtx,
+		exprs:   newIDVersionCache(),
+	}
+	e.container.alloc = &e.alloc
+	return e
+}
+
+// eval sets the values of the virtual columns of a row decoded using the given
+// table descriptor, whose user-defined types must be hydrated. The types
+// referenced by the computed expressions are resolved as of the given
+// timestamp.
+func (e *virtualColumnEvaluator) eval(
+	ctx context.Context,
+	desc *sqlbase.ImmutableTableDescriptor,
+	datums sqlbase.EncDatumRow,
+	ts hlc.Timestamp,
+) error {
+	hasVirtual := false
+	for i := range desc.Columns {
+		if desc.Columns[i].Virtual {
+			hasVirtual = true
+			break
+		}
+	}
+	if !hasVirtual {
+		return nil
+	}
+
+	idVer := idVersion{id: desc.ID, version: desc.Version}
+	var exprs []tree.TypedExpr
+	if cached, ok := e.exprs.Get(idVer); ok {
+		exprs = cached.([]tree.TypedExpr)
+	} else {
+		semaCtx := tree.MakeSemaContext()
+		semaCtx.TypeResolver = &cdcTypeResolver{codec: e.codec, db: e.db, ts: ts}
+		var txCtx transform.ExprTransformContext
+		var err error
+		exprs, err = schemaexpr.MakeComputedExprs(
+			ctx,
+			desc.Columns,
+			desc,
+			tree.NewUnqualifiedTableName(tree.Name(desc.Name)),
+			&txCtx,
+			e.evalCtx,
+			&semaCtx,
+			false, /* addingCols */
+		)
+		if err != nil {
+			return errors.Wrapf(err, "computing virtual columns of table %s version %d",
+				tree.Name(desc.Name), desc.Version)
+		}
+		e.exprs.Add(idVer, exprs)
+	}
+
+	e.container.cols = desc.Columns
+	e.container.row = datums
+	e.evalCtx.PushIVarContainer(&e.container)
+	defer e.evalCtx.PopIVarContainer()
+	for i := range desc.Columns {
+		if col := &desc.Columns[i]; col.Virtual {
+			d, err := exprs[i].Eval(e.evalCtx)
+			if err != nil {
+				return err
+			}
+			datums[i] = sqlbase.DatumToEncDatum(col.Type, d)
+		}
+	}
+	return nil
+}
@@ -145,5 +145,6 @@ var followerReadAwareChoice = replicaoracle.RegisterPolicy(newOracleFactory)
 func init() {
 	sql.ReplicaOraclePolicy = followerReadAwareChoice
 	builtins.EvalFollowerReadOffset = evalFollowerReadOffset
+	builtins.CheckBoundedStalenessEnabled = checkEnterpriseEnabled
 	kvcoord.CanSendToFollower = canSendToFollower
 }
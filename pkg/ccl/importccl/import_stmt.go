@@ -574,6 +574,13 @@ func importPlanHook(
 				return pgerror.New(pgcode.FeatureNotSupported, "Cannot use IMPORT INTO with interleaved tables")
 			}
 
+			// IMPORT INTO ingests data directly, so it cannot run the triggers of
+			// the table.
+			if len(found.Triggers) > 0 {
+				return pgerror.Newf(pgcode.FeatureNotSupported,
+					"cannot IMPORT INTO table %s because it has triggers", found.Name)
+			}
+
 			// Validate target columns.
 			var intoCols []string
 			var isTargetCol = make(map[string]bool)
@@ -2513,6 +2513,24 @@ func TestImportIntoCSV(t *testing.T) {
 			fmt.Sprintf(`IMPORT INTO child (parent_id, child_id) CSV DATA (%s)`, testFiles.files[0]))
 	})
 
+	// IMPORT INTO does not run triggers, so it rejects tables with triggers.
+	t.Run("import-into-rejects-tables-with-triggers", func(t *testing.T) {
+		sqlDB.Exec(t, `CREATE TABLE t (a INT, b STRING)`)
+		defer sqlDB.Exec(t, `DROP TABLE t`)
+		sqlDB.Exec(t, `CREATE TABLE t_log (a INT)`)
+		defer sqlDB.Exec(t, `DROP TABLE t_log`)
+		sqlDB.Exec(t, `CREATE TRIGGER t_insert AFTER INSERT ON t FOR EACH ROW
+			AS 'INSERT INTO t_log VALUES (NEW.a)'`)
+
+		sqlDB.ExpectErr(
+			t, "cannot IMPORT INTO table t because it has triggers",
+			fmt.Sprintf(`IMPORT INTO t (a, b) CSV DATA (%s)`, testFiles.files[0]))
+
+		// Once the trigger is dropped, the table can be imported into.
+		sqlDB.Exec(t, `DROP TRIGGER t_insert ON t`)
+		sqlDB.Exec(t, fmt.Sprintf(`IMPORT INTO t (a, b) CSV DATA (%s)`, testFiles.files[0]))
+	})
+
 	// This tests that consecutive imports from unique data sources into an
 	// existing table without an explicit PK, do not overwrite each other. It
 	// exercises the row_id generation in IMPORT.
@@ -45,6 +45,16 @@ func GenerateSalt() ([]byte, error) {
 	return salt, nil
 }
 
+// GenerateDataKey generates a random 32 byte key, for use when the key is not
+// derived from a passphrase (e.g. when it is instead encrypted using a KMS).
+func GenerateDataKey() ([]byte, error) {
+	key := make([]byte, 32)
+	if _, err := crypto_rand.Read(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
 // GenerateKey generates a key for the supplied passphrase and salt.
 func GenerateKey(passphrase, salt []byte) []byte {
 	return pbkdf2.Key(passphrase, salt, 64000, 32, sha256.New)
@@ -67,6 +67,17 @@ const (
 	VersionAlterColumnTypeGeneral
 	VersionAlterSystemJobsAddCreatedByColumns
 	VersionAddScheduledJobsTable
+	VersionSCRAMAuthentication
+	VersionUserDefinedFunctions
+	VersionMaterializedViews
+	VersionTriggers
+	VersionDeferrableConstraints
+	VersionVirtualComputedColumns
+	VersionCompositeTypes
+	VersionRangeTypes
+	VersionMultiDimensionalArrays
+	VersionNullsOrdering
+	VersionNonVotingReplicas
 
 	// Add new versions here (step one of two).
 )
@@ -511,6 +522,68 @@ var versionsSingleton = keyedVersions([]keyedVersion{
 		Key:     VersionAddScheduledJobsTable,
 		Version: roachpb.Version{Major: 20, Minor: 1, Unstable: 7},
 	},
+	{
+		// VersionSCRAMAuthentication enables the scram-sha-256 authentication
+		// method and the storage of SCRAM verifiers in system.users.
+		Key:     VersionSCRAMAuthentication,
+		Version: roachpb.Version{Major: 20, Minor: 1, Unstable: 8},
+	},
+	{
+		// VersionUserDefinedFunctions enables the creation of user-defined
+		// functions, which are stored in function descriptors.
+		Key:     VersionUserDefinedFunctions,
+		Version: roachpb.Version{Major: 20, Minor: 1, Unstable: 9},
+	},
+	{
+		// VersionMaterializedViews enables the use of materialized views.
+		Key:     VersionMaterializedViews,
+		Version: roachpb.Version{Major: 20, Minor: 1, Unstable: 10},
+	},
+	{
+		// VersionTriggers enables the use of row-level triggers.
+		Key:     VersionTriggers,
+		Version: roachpb.Version{Major: 20, Minor: 1, Unstable: 11},
+	},
+	{
+		// VersionDeferrableConstraints enables the use of deferrable foreign key
+		// constraints.
+		Key:     VersionDeferrableConstraints,
+		Version: roachpb.Version{Major: 20, Minor: 1, Unstable: 12},
+	},
+	{
+		// VersionVirtualComputedColumns enables the use of virtual computed
+		// columns.
+		Key:     VersionVirtualComputedColumns,
+		Version: roachpb.Version{Major: 20, Minor: 1, Unstable: 13},
+	},
+	{
+		// VersionCompositeTypes enables the use of user defined composite types.
+		Key:     VersionCompositeTypes,
+		Version: roachpb.Version{Major: 20, Minor: 1, Unstable: 14},
+	},
+	{
+		// VersionRangeTypes enables the use of the built-in range types.
+		Key:     VersionRangeTypes,
+		Version: roachpb.Version{Major: 20, Minor: 1, Unstable: 15},
+	},
+	{
+		// VersionMultiDimensionalArrays enables the use of multi-dimensional
+		// array column types.
+		Key:     VersionMultiDimensionalArrays,
+		Version: roachpb.Version{Major: 20, Minor: 1, Unstable: 16},
+	},
+	{
+		// VersionNullsOrdering enables index columns with a NULLS FIRST or
+		// NULLS LAST ordering that differs from the default.
+		Key:     VersionNullsOrdering,
+		Version: roachpb.Version{Major: 20, Minor: 1, Unstable: 17},
+	},
+	{
+		// VersionNonVotingReplicas enables the NON_VOTER replica type and the
+		// num_voters and voter_constraints zone config fields.
+		Key:     VersionNonVotingReplicas,
+		Version: roachpb.Version{Major: 20, Minor: 1, Unstable: 18},
+	},
 
 	// Add new versions here (step two of two).
 
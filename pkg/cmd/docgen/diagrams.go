@@ -563,6 +563,7 @@ var specs = []stmtSpec{
 		match:  []*regexp.Regexp{regexp.MustCompile("'CREATE' 'INVERTED'")},
 		inline: []string{"opt_storing", "storing", "opt_unique", "opt_name", "index_params", "index_elem", "opt_asc_desc"},
 	},
+	{name: "create_schedule_for_backup_stmt"},
 	{
 		name:    "create_sequence_stmt",
 		inline:  []string{"opt_sequence_option_list", "sequence_option_list", "sequence_option_elem"},
@@ -670,6 +671,12 @@ var specs = []stmtSpec{
 		inline:  []string{"role_or_group_or_user"},
 		replace: map[string]string{"string_or_placeholder_list": "name"},
 	},
+	{
+		name:    "drop_schedule",
+		stmt:    "drop_schedule_stmt",
+		replace: map[string]string{"a_expr": "schedule_id"},
+		unlink:  []string{"schedule_id"},
+	},
 	{
 		name:   "drop_sequence_stmt",
 		inline: []string{"table_name_list", "opt_drop_behavior"},
@@ -883,10 +890,16 @@ var specs = []stmtSpec{
 	},
 	{
 		name:    "pause_job",
-		stmt:    "pause_stmt",
+		stmt:    "pause_jobs_stmt",
 		replace: map[string]string{"a_expr": "job_id"},
 		unlink:  []string{"job_id"},
 	},
+	{
+		name:    "pause_schedule",
+		stmt:    "pause_schedules_stmt",
+		replace: map[string]string{"a_expr": "schedule_id"},
+		unlink:  []string{"schedule_id"},
+	},
 	{
 		name: "primary_key_column_level",
 		stmt: "stmt_block",
@@ -956,10 +969,16 @@ var specs = []stmtSpec{
 	},
 	{
 		name:    "resume_job",
-		stmt:    "resume_stmt",
+		stmt:    "resume_jobs_stmt",
 		replace: map[string]string{"a_expr": "job_id"},
 		unlink:  []string{"job_id"},
 	},
+	{
+		name:    "resume_schedule",
+		stmt:    "resume_schedules_stmt",
+		replace: map[string]string{"a_expr": "schedule_id"},
+		unlink:  []string{"schedule_id"},
+	},
 	{
 		name:   "revoke_privileges",
 		stmt:   "revoke_stmt",
@@ -1148,6 +1167,19 @@ var specs = []stmtSpec{
 		replace: map[string]string{"string_or_placeholder": "location"},
 		unlink:  []string{"location"},
 	},
+	{
+		name:    "show_backups",
+		stmt:    "show_backup_stmt",
+		match:   []*regexp.Regexp{regexp.MustCompile("'SHOW' 'BACKUPS'")},
+		replace: map[string]string{"string_or_placeholder": "collection"},
+		unlink:  []string{"collection"},
+	},
+	{
+		name:    "show_schedules",
+		stmt:    "show_schedules_stmt",
+		replace: map[string]string{"a_expr": "schedule_id"},
+		unlink:  []string{"schedule_id"},
+	},
 	{
 		name:    "show_jobs",
 		stmt:    "show_jobs_stmt",
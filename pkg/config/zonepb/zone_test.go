@@ -50,6 +50,67 @@ func TestZoneConfigValidate(t *testing.T) {
 			},
 			"at least 3 replicas are required for multi-replica configurations",
 		},
+		{
+			ZoneConfig{
+				NumReplicas:   proto.Int32(2),
+				NumVoters:     proto.Int32(1),
+				RangeMaxBytes: DefaultZoneConfig().RangeMaxBytes,
+				GC:            &GCPolicy{TTLSeconds: 1},
+			},
+			"",
+		},
+		{
+			ZoneConfig{
+				NumReplicas: proto.Int32(5),
+				NumVoters:   proto.Int32(0),
+			},
+			"at least one voting replica is required",
+		},
+		{
+			ZoneConfig{
+				NumReplicas: proto.Int32(5),
+				NumVoters:   proto.Int32(2),
+			},
+			"at least 3 voting replicas are required for multi-replica configurations",
+		},
+		{
+			ZoneConfig{
+				NumReplicas: proto.Int32(3),
+				NumVoters:   proto.Int32(5),
+			},
+			"num_voters \\(5\\) cannot be greater than num_replicas \\(3\\)",
+		},
+		{
+			ZoneConfig{
+				NumReplicas:   proto.Int32(5),
+				NumVoters:     proto.Int32(3),
+				RangeMaxBytes: DefaultZoneConfig().RangeMaxBytes,
+				GC:            &GCPolicy{TTLSeconds: 1},
+				VoterConstraints: []ConstraintsConjunction{
+					{
+						Constraints: []Constraint{{Key: "region", Value: "a", Type: Constraint_REQUIRED}},
+						NumReplicas: 4,
+					},
+				},
+			},
+			"the number of voting replicas specified in constraints \\(4\\) cannot be greater than " +
+				"the number of voting replicas configured for the zone \\(3\\)",
+		},
+		{
+			ZoneConfig{
+				NumReplicas:   proto.Int32(5),
+				NumVoters:     proto.Int32(3),
+				RangeMaxBytes: DefaultZoneConfig().RangeMaxBytes,
+				GC:            &GCPolicy{TTLSeconds: 1},
+				VoterConstraints: []ConstraintsConjunction{
+					{
+						Constraints: []Constraint{{Key: "region", Value: "a", Type: Constraint_REQUIRED}},
+						NumReplicas: 3,
+					},
+				},
+			},
+			"",
+		},
 		{
 			ZoneConfig{
 				NumReplicas:   proto.Int32(1),
@@ -273,6 +334,19 @@ func TestZoneConfigValidateTandemFields(t *testing.T) {
 			},
 			"when per-replica constraints are set, num_replicas must be set as well",
 		},
+		{
+			ZoneConfig{
+				NumVoters: proto.Int32(3),
+			},
+			"when num_voters is set, num_replicas must be set as well",
+		},
+		{
+			ZoneConfig{
+				NumReplicas:                 proto.Int32(3),
+				NullVoterConstraintsIsEmpty: true,
+			},
+			"when voter_constraints are set, num_voters must be set as well",
+		},
 		{
 			ZoneConfig{
 				InheritedConstraints:      true,
@@ -658,6 +732,65 @@ lease_preferences: [[+duck=bar1, +duck=bar2], [-duck=foo]]
 // TestExperimentalLeasePreferencesYAML makes sure that we accept the
 // lease_preferences YAML field both with and without the "experimental_"
 // prefix.
+// TestZoneConfigNonVotersYAML makes sure that the num_voters and
+// voter_constraints fields are marshaled to YAML and back, and that they're
+// omitted when they're not set.
+func TestZoneConfigNonVotersYAML(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	original := ZoneConfig{
+		NumReplicas: proto.Int32(5),
+		NumVoters:   proto.Int32(3),
+		VoterConstraints: []ConstraintsConjunction{
+			{
+				NumReplicas: 3,
+				Constraints: []Constraint{{Type: Constraint_REQUIRED, Key: "region", Value: "us"}},
+			},
+		},
+	}
+	const expected = `range_min_bytes: null
+range_max_bytes: null
+gc: null
+num_replicas: 5
+num_voters: 3
+constraints: []
+voter_constraints: {+region=us: 3}
+lease_preferences: []
+`
+	body, err := yaml.Marshal(original)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(body) != expected {
+		t.Fatalf("yaml.Marshal(%+v)\ngot:\n%s\nwant:\n%s", original, body, expected)
+	}
+	var unmarshaled ZoneConfig
+	if err := yaml.UnmarshalStrict(body, &unmarshaled); err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(&unmarshaled, &original) {
+		t.Errorf("yaml.UnmarshalStrict(%q)\ngot:\n%+v\nwant:\n%+v", body, unmarshaled, original)
+	}
+
+	// Explicitly empty voter constraints are not inherited.
+	var explicit ZoneConfig
+	if err := yaml.UnmarshalStrict([]byte("voter_constraints: []"), &explicit); err != nil {
+		t.Fatal(err)
+	}
+	if explicit.InheritedVoterConstraints() {
+		t.Errorf("expected explicitly empty voter constraints not to be inherited")
+	}
+	child := NewZoneConfig()
+	child.InheritFromParent(&original)
+	if child.InheritedVoterConstraints() || *child.NumVoters != 3 {
+		t.Errorf("expected num_voters and voter_constraints to be inherited, got %+v", child)
+	}
+	explicit.InheritFromParent(&original)
+	if len(explicit.VoterConstraints) != 0 {
+		t.Errorf("expected explicitly empty voter constraints not to be overridden, got %+v", explicit)
+	}
+}
+
 func TestExperimentalLeasePreferencesYAML(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 
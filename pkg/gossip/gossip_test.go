@@ -16,6 +16,7 @@ import (
 	"fmt"
 	"net"
 	"reflect"
+	"sort"
 	"strconv"
 	"testing"
 	"time"
@@ -935,3 +936,28 @@ func TestGossipLoopbackInfoPropagation(t *testing.T) {
 		return nil
 	})
 }
+
+func TestFilterTenantVisibleInfos(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	delta := map[string]*Info{
+		KeyClusterID:              {},
+		KeySentinel:               {},
+		KeyFirstRangeDescriptor:   {},
+		MakeNodeIDKey(1):          {},
+		MakeStoreKey(1):           {},
+		KeySystemConfig:           {},
+		MakeNodeLivenessKey(1):    {},
+		MakeNodeHealthAlertKey(1): {},
+	}
+	var keys []string
+	for key := range filterTenantVisibleInfos(delta) {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	expected := []string{KeyClusterID, KeyFirstRangeDescriptor, MakeNodeIDKey(1), KeySentinel}
+	sort.Strings(expected)
+	if !reflect.DeepEqual(expected, keys) {
+		t.Errorf("expected %v, got %v", expected, keys)
+	}
+}
@@ -110,6 +110,10 @@ func (s *server) Gossip(stream Gossip_GossipServer) error {
 		return errors.Errorf("gossip connection refused from different cluster %s", args.ClusterID)
 	}
 
+	// SQL tenants are permitted to receive the gossip they need in order to
+	// route requests to the KV layer, but they don't participate in gossip.
+	_, isTenant := roachpb.TenantFromContext(stream.Context())
+
 	ctx, cancel := context.WithCancel(s.AnnotateCtx(stream.Context()))
 	defer cancel()
 	syncChan := make(chan struct{}, 1)
@@ -138,7 +142,7 @@ func (s *server) Gossip(stream Gossip_GossipServer) error {
 	// Starting workers in a task prevents data races during shutdown.
 	if err := s.stopper.RunTask(ctx, "gossip.server: receiver", func(ctx context.Context) {
 		s.stopper.RunWorker(ctx, func(ctx context.Context) {
-			errCh <- s.gossipReceiver(ctx, &args, send, stream.Recv)
+			errCh <- s.gossipReceiver(ctx, &args, send, stream.Recv, isTenant)
 		})
 	}); err != nil {
 		return err
@@ -159,6 +163,14 @@ func (s *server) Gossip(stream Gossip_GossipServer) error {
 		if args.HighWaterStamps == nil {
 			args.HighWaterStamps = make(map[roachpb.NodeID]int64)
 		}
+		if isTenant {
+			// Ratchet the high water stamps past the infos that are withheld
+			// from the tenant to avoid considering them again.
+			for _, i := range delta {
+				ratchetHighWaterStamp(args.HighWaterStamps, i.NodeID, i.OrigStamp)
+			}
+			delta = filterTenantVisibleInfos(delta)
+		}
 
 		// Send a response if this is the first response on the connection, or if
 		// there are deltas to send. The first condition is necessary to make sure
@@ -205,6 +217,7 @@ func (s *server) gossipReceiver(
 	argsPtr **Request,
 	senderFn func(*Response) error,
 	receiverFn func() (*Request, error),
+	isTenant bool,
 ) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -220,7 +233,11 @@ func (s *server) gossipReceiver(
 	// server's gossip to the client.
 	for {
 		args := *argsPtr
-		if args.NodeID == 0 {
+		if isTenant {
+			// Let the connection through without admitting the tenant as a
+			// peer. Tenants don't have node IDs of their own.
+			nodeIdentified = true
+		} else if args.NodeID == 0 {
 			// Let the connection through so that the client can get a node ID. Once it
 			// has one, we'll run the logic below to decide whether to keep the
 			// connection to it or to forward it elsewhere.
@@ -305,14 +322,21 @@ func (s *server) gossipReceiver(
 		s.serverMetrics.BytesReceived.Inc(bytesReceived)
 		s.serverMetrics.InfosReceived.Inc(infosReceived)
 
-		freshCount, err := s.mu.is.combine(args.Delta, args.NodeID)
-		if err != nil {
-			log.Warningf(ctx, "failed to fully combine gossip delta from n%d: %s", args.NodeID, err)
-		}
-		if log.V(1) {
-			log.Infof(ctx, "received %s from n%d (%d fresh)", extractKeys(args.Delta), args.NodeID, freshCount)
+		if isTenant {
+			// Tenants are not permitted to gossip infos.
+			if len(args.Delta) > 0 {
+				log.Warningf(ctx, "ignoring %d gossip info(s) from SQL tenant at %s", len(args.Delta), args.Addr)
+			}
+		} else {
+			freshCount, err := s.mu.is.combine(args.Delta, args.NodeID)
+			if err != nil {
+				log.Warningf(ctx, "failed to fully combine gossip delta from n%d: %s", args.NodeID, err)
+			}
+			if log.V(1) {
+				log.Infof(ctx, "received %s from n%d (%d fresh)", extractKeys(args.Delta), args.NodeID, freshCount)
+			}
+			s.maybeTightenLocked()
 		}
-		s.maybeTightenLocked()
 
 		*reply = Response{
 			NodeID:          s.NodeID.Get(),
@@ -320,7 +344,7 @@ func (s *server) gossipReceiver(
 		}
 
 		s.mu.Unlock()
-		err = senderFn(reply)
+		err := senderFn(reply)
 		s.mu.Lock()
 		if err != nil {
 			return err
@@ -346,6 +370,22 @@ func (s *server) gossipReceiver(
 	}
 }
 
+// filterTenantVisibleInfos removes the infos that SQL tenants are not
+// permitted to see from the provided delta. Tenants only need to know about
+// the cluster and the location of the KV nodes and the first range in order to
+// route requests.
+func filterTenantVisibleInfos(delta map[string]*Info) map[string]*Info {
+	for key := range delta {
+		switch {
+		case key == KeyClusterID, key == KeySentinel, key == KeyFirstRangeDescriptor:
+		case IsNodeIDKey(key):
+		default:
+			delete(delta, key)
+		}
+	}
+	return delta
+}
+
 func (s *server) maybeTightenLocked() {
 	select {
 	case s.tighten <- struct{}{}:
@@ -40,7 +40,7 @@ const retryFailedJobAfter = time.Minute
 
 // ExecuteJob implements ScheduledJobExecutor interface.
 func (e *inlineScheduledJobExecutor) ExecuteJob(
-	ctx context.Context, schedule *ScheduledJob, txn *kv.Txn,
+	ctx context.Context, _ *JobExecutionConfig, schedule *ScheduledJob, txn *kv.Txn,
 ) error {
 	sqlArgs := &jobspb.SqlStatementExecutionArg{}
 
@@ -15,12 +15,15 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/settings"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
-	"github.com/cockroachdb/cockroach/pkg/sql/sqlutil"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/errors"
 )
@@ -44,7 +47,9 @@ type prodJobSchedulerEnvImpl struct{}
 
 var prodJobSchedulerEnv jobSchedulerEnv = &prodJobSchedulerEnvImpl{}
 
-const createdByName = "crdb_schedule"
+// CreatedByScheduledJobs identifies the job that was created
+// by scheduled jobs system.
+const CreatedByScheduledJobs = "crdb_schedule"
 
 func (e *prodJobSchedulerEnvImpl) ScheduledJobsTableName() string {
 	return "system.scheduled_jobs"
@@ -65,19 +70,17 @@ func (e *prodJobSchedulerEnvImpl) NowExpr() string {
 // jobScheduler is responsible for finding and starting scheduled
 // jobs that need to be executed.
 type jobScheduler struct {
+	*JobExecutionConfig
 	env jobSchedulerEnv
-	db  *kv.DB
-	ex  sqlutil.InternalExecutor
 }
 
-func newJobScheduler(env jobSchedulerEnv, db *kv.DB, ex sqlutil.InternalExecutor) *jobScheduler {
+func newJobScheduler(cfg *JobExecutionConfig, env jobSchedulerEnv) *jobScheduler {
 	if env == nil {
 		env = prodJobSchedulerEnv
 	}
 	return &jobScheduler{
-		env: env,
-		db:  db,
-		ex:  ex,
+		JobExecutionConfig: cfg,
+		env:                env,
 	}
 }
 
@@ -95,7 +98,7 @@ SELECT
   ) AS num_running, S.*
 FROM %s S
 WHERE next_run < %s
-`, s.env.SystemJobsTableName(), createdByName, s.env.ScheduledJobsTableName(), s.env.NowExpr())
+`, s.env.SystemJobsTableName(), CreatedByScheduledJobs, s.env.ScheduledJobsTableName(), s.env.NowExpr())
 }
 
 // unmarshalScheduledJob is a helper to deserialize a row returned by
@@ -128,13 +131,13 @@ func (s *jobScheduler) processSchedule(
 			// a job.  It would also be nice not to log each event.
 			schedule.SetNextRun(s.env.Now().Add(recheckRunningAfter))
 			schedule.AddScheduleChangeReason("reschedule: %d running", numRunning)
-			return schedule.Update(ctx, s.ex, txn)
+			return schedule.Update(ctx, s.InternalExecutor, txn)
 		case jobspb.ScheduleDetails_SKIP:
 			if err := schedule.ScheduleNextRun(); err != nil {
 				return err
 			}
 			schedule.AddScheduleChangeReason("rescheduled: %d running", numRunning)
-			return schedule.Update(ctx, s.ex, txn)
+			return schedule.Update(ctx, s.InternalExecutor, txn)
 		}
 	}
 
@@ -145,26 +148,26 @@ func (s *jobScheduler) processSchedule(
 		return err
 	}
 
-	if err := schedule.Update(ctx, s.ex, txn); err != nil {
+	if err := schedule.Update(ctx, s.InternalExecutor, txn); err != nil {
 		return err
 	}
 
-	executor, err := NewScheduledJobExecutor(schedule.ExecutorType(), s.ex)
+	executor, err := NewScheduledJobExecutor(schedule.ExecutorType(), s.InternalExecutor)
 	if err != nil {
 		return err
 	}
 
 	// Grab job executor and execute the job.
-	if err := executor.ExecuteJob(ctx, schedule, txn); err != nil {
+	if err := executor.ExecuteJob(ctx, s.JobExecutionConfig, schedule, txn); err != nil {
 		return err
 	}
 
 	// Persist any mutations to the underlying schedule.
-	return schedule.Update(ctx, s.ex, txn)
+	return schedule.Update(ctx, s.InternalExecutor, txn)
 }
 
 func (s *jobScheduler) executeSchedules(ctx context.Context, txn *kv.Txn) error {
-	rows, cols, err := s.ex.QueryWithCols(ctx, "find-scheduled-jobs", nil,
+	rows, cols, err := s.InternalExecutor.QueryWithCols(ctx, "find-scheduled-jobs", txn,
 		sqlbase.InternalExecutorSessionDataOverride{User: security.RootUser},
 		s.getFindJobsStatement(),
 	)
@@ -189,4 +192,70 @@ func (s *jobScheduler) executeSchedules(ctx context.Context, txn *kv.Txn) error
 	return nil
 }
 
-// TODO(yevgeniy): Implement daemon to periodically call executeSchedules
+var schedulerEnabledSetting = settings.RegisterBoolSetting(
+	"jobs.scheduler.enabled",
+	"enable/disable job scheduler",
+	true,
+)
+
+var schedulerPaceSetting = settings.RegisterValidatedDurationSetting(
+	"jobs.scheduler.pace",
+	"how often to scan system.scheduled_jobs table",
+	time.Minute,
+	func(v time.Duration) error {
+		if v <= 0 {
+			return errors.Errorf("jobs.scheduler.pace must be positive, got %s", v)
+		}
+		return nil
+	},
+)
+
+// runDaemon periodically scans system.scheduled_jobs table, executing
+// any schedules whose next run time has passed.
+func (s *jobScheduler) runDaemon(ctx context.Context, stopper *stop.Stopper) {
+	// Changes to the pace setting take effect immediately instead of waiting
+	// for the previously computed wait period to expire.
+	paceChanged := make(chan struct{}, 1)
+	schedulerPaceSetting.SetOnChange(&s.Settings.SV, func() {
+		select {
+		case paceChanged <- struct{}{}:
+		default:
+		}
+	})
+
+	stopper.RunWorker(ctx, func(ctx context.Context) {
+		var timer timeutil.Timer
+		defer timer.Stop()
+
+		for {
+			timer.Reset(schedulerPaceSetting.Get(&s.Settings.SV))
+			select {
+			case <-stopper.ShouldQuiesce():
+				return
+			case <-paceChanged:
+				continue
+			case <-timer.C:
+				timer.Read = true
+			}
+
+			if !schedulerEnabledSetting.Get(&s.Settings.SV) ||
+				!s.Settings.Version.IsActive(ctx, clusterversion.VersionAddScheduledJobsTable) {
+				continue
+			}
+
+			if err := s.DB.Txn(ctx, func(ctx context.Context, txn *kv.Txn) error {
+				return s.executeSchedules(ctx, txn)
+			}); err != nil {
+				log.Errorf(ctx, "error executing schedules: %+v", err)
+			}
+		}
+	})
+}
+
+// StartJobSchedulerDaemon starts a daemon responsible for periodically scanning
+// system.scheduled_jobs table to find and executing eligible scheduled jobs.
+func StartJobSchedulerDaemon(
+	ctx context.Context, stopper *stop.Stopper, cfg *JobExecutionConfig, env jobSchedulerEnv,
+) {
+	newJobScheduler(cfg, env).runDaemon(ctx, stopper)
+}
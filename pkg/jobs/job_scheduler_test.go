@@ -20,7 +20,10 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/security"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/errors"
 	"github.com/gorhill/cronexpr"
 	"github.com/stretchr/testify/require"
 )
@@ -32,7 +35,7 @@ func addFakeJob(t *testing.T, h *testHelper, id int64, status Status, txn *kv.Tx
 		fmt.Sprintf(
 			"INSERT INTO %s (created_by_type, created_by_id, status, payload) VALUES ($1, $2, $3, $4)",
 			h.env.SystemJobsTableName()),
-		createdByName, id, status, payload,
+		CreatedByScheduledJobs, id, status, payload,
 	)
 	require.NoError(t, err)
 	require.Equal(t, 1, n)
@@ -78,7 +81,7 @@ func TestJobSchedulerReschedulesRunning(t *testing.T) {
 
 			// The job should not run -- it should be rescheduled `recheckJobAfter` time in the
 			// future.
-			c := newJobScheduler(h.env, h.kvDB, h.ex)
+			c := newJobScheduler(h.cfg, h.env)
 			require.NoError(t, c.executeSchedules(ctx, nil))
 
 			if wait == jobspb.ScheduleDetails_WAIT {
@@ -118,10 +121,80 @@ func TestJobSchedulerExecutesAndSchedulesNextRun(t *testing.T) {
 	h.env.now = expectedRunTime.Add(time.Second)
 
 	// Execute the job and verify it has the next run scheduled.
-	c := newJobScheduler(h.env, h.kvDB, h.ex)
+	c := newJobScheduler(h.cfg, h.env)
 	require.NoError(t, c.executeSchedules(ctx, nil))
 
 	expectedRunTime = cronexpr.MustParse("@hourly").Next(h.env.now)
 	loaded = h.loadJob(t, j.ScheduleID())
 	require.Equal(t, expectedRunTime, loaded.NextRun())
 }
+
+func TestJobSchedulerDaemonProcessesJobs(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	h, cleanup := newTestHelper(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	// Create few hourly schedules.
+	const numJobs = 5
+	var scheduleIDs []int64
+	for i := 0; i < numJobs; i++ {
+		schedule := h.newScheduledJob(t, "test_job", "SELECT 42")
+		require.NoError(t, schedule.SetSchedule("@hourly"))
+		require.NoError(t, schedule.Create(ctx, h.ex, nil))
+		scheduleIDs = append(scheduleIDs, schedule.ScheduleID())
+	}
+
+	// Move the time past the scheduled run time, then start the daemon and
+	// verify it ran all jobs, scheduling their next run.
+	h.env.now = cronexpr.MustParse("@hourly").Next(h.env.now).Add(time.Second)
+	expectedNextRun := cronexpr.MustParse("@hourly").Next(h.env.now)
+	h.sqlDB.Exec(t, "SET CLUSTER SETTING jobs.scheduler.pace = '10ms'")
+
+	stopper := stop.NewStopper()
+	defer stopper.Stop(ctx)
+	newJobScheduler(h.cfg, h.env).runDaemon(ctx, stopper)
+
+	testutils.SucceedsSoon(t, func() error {
+		for _, id := range scheduleIDs {
+			if nextRun := h.loadJob(t, id).NextRun(); nextRun != expectedNextRun {
+				return errors.Newf("schedule %d has not run yet: next run %s", id, nextRun)
+			}
+		}
+		return nil
+	})
+}
+
+func TestJobSchedulerDaemonHonorsEnabledSetting(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	h, cleanup := newTestHelper(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	schedule := h.newScheduledJob(t, "test_job", "SELECT 42")
+	require.NoError(t, schedule.SetSchedule("@hourly"))
+	require.NoError(t, schedule.Create(ctx, h.ex, nil))
+	scheduledRunTime := cronexpr.MustParse("@hourly").Next(h.env.now)
+	h.env.now = scheduledRunTime.Add(time.Second)
+
+	h.sqlDB.Exec(t, "SET CLUSTER SETTING jobs.scheduler.enabled = false")
+	h.sqlDB.Exec(t, "SET CLUSTER SETTING jobs.scheduler.pace = '10ms'")
+
+	stopper := stop.NewStopper()
+	defer stopper.Stop(ctx)
+	newJobScheduler(h.cfg, h.env).runDaemon(ctx, stopper)
+
+	// Give the daemon a chance to run a few times; the schedule must not run.
+	time.Sleep(100 * time.Millisecond)
+	require.Equal(t, scheduledRunTime, h.loadJob(t, schedule.ScheduleID()).NextRun())
+
+	h.sqlDB.Exec(t, "SET CLUSTER SETTING jobs.scheduler.enabled = true")
+	testutils.SucceedsSoon(t, func() error {
+		if h.loadJob(t, schedule.ScheduleID()).NextRun() == scheduledRunTime {
+			return errors.New("schedule has not run yet")
+		}
+		return nil
+	})
+}
@@ -16,9 +16,11 @@ import (
 	"reflect"
 	"sync/atomic"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
@@ -38,9 +40,10 @@ type Job struct {
 	// Started, etc., have Registry call a setupFn and a workFn as appropriate.
 	registry *Registry
 
-	id  *int64
-	txn *kv.Txn
-	mu  struct {
+	id        *int64
+	createdBy *CreatedByInfo
+	txn       *kv.Txn
+	mu        struct {
 		syncutil.Mutex
 		payload  jobspb.Payload
 		progress jobspb.Progress
@@ -61,6 +64,16 @@ type Record struct {
 	// a version < 20.1, so it can only be used in cases where all nodes having
 	// versions >= 20.1 is guaranteed.
 	NonCancelable bool
+	// CreatedBy, if set, annotates this record with the information on
+	// this job creator.
+	CreatedBy *CreatedByInfo
+}
+
+// CreatedByInfo encapsulates the type and the ID of the system which created
+// this job.
+type CreatedByInfo struct {
+	Name string
+	ID   int64
 }
 
 // StartableJob is a job created with a transaction to be started later.
@@ -468,7 +481,7 @@ func (j *Job) pauseRequested(ctx context.Context, fn onPauseRequestFunc) error {
 			return fmt.Errorf("job with status %s cannot be requested to be paused", md.Status)
 		}
 		if fn != nil {
-			phs, cleanup := j.registry.planFn("pause request", j.Payload().Username)
+			phs, cleanup := j.registry.planFn("pause request", nil /* txn */, j.Payload().Username)
 			defer cleanup()
 			if err := fn(ctx, phs, txn, md.Progress); err != nil {
 				return err
@@ -529,7 +542,7 @@ func (j *Job) canceled(ctx context.Context, fn func(context.Context, *kv.Txn) er
 		ju.UpdateStatus(StatusCanceled)
 		md.Payload.FinishedMicros = timeutil.ToUnixMicros(j.registry.clock.Now().GoTime())
 		ju.UpdatePayload(md.Payload)
-		return nil
+		return j.maybeNotifyScheduledJobTermination(ctx, txn, md, StatusCanceled)
 	})
 }
 
@@ -552,7 +565,7 @@ func (j *Job) failed(
 		md.Payload.Error = err.Error()
 		md.Payload.FinishedMicros = timeutil.ToUnixMicros(j.registry.clock.Now().GoTime())
 		ju.UpdatePayload(md.Payload)
-		return nil
+		return j.maybeNotifyScheduledJobTermination(ctx, txn, md, StatusFailed)
 	})
 }
 
@@ -578,10 +591,71 @@ func (j *Job) succeeded(ctx context.Context, fn func(context.Context, *kv.Txn) e
 			FractionCompleted: 1.0,
 		}
 		ju.UpdateProgress(md.Progress)
-		return nil
+		return j.maybeNotifyScheduledJobTermination(ctx, txn, md, StatusSucceeded)
 	})
 }
 
+// maybeNotifyScheduledJobTermination notifies the schedule which created
+// this job (if any) that the job reached the specified terminal status.
+// The notification is delivered in the same transaction as the job status
+// update, so failures to notify the schedule are returned: the job only
+// reaches its terminal status once the schedule has been notified.
+func (j *Job) maybeNotifyScheduledJobTermination(
+	ctx context.Context, txn *kv.Txn, md JobMetadata, status Status,
+) error {
+	if j.registry.settings == cluster.NoSettings ||
+		!j.registry.settings.Version.IsActive(ctx, clusterversion.VersionAlterSystemJobsAddCreatedByColumns) {
+		return nil
+	}
+
+	scheduleID, err := j.scheduleID(ctx, txn)
+	if err != nil {
+		return errors.Wrapf(err, "job %d: failed to look up the schedule which created it", *j.id)
+	}
+	if scheduleID == 0 {
+		return nil
+	}
+
+	md.Status = status
+	err = NotifyJobTermination(ctx, nil /* env */, &md, scheduleID, j.registry.ex, txn)
+	if errors.Is(err, ErrScheduleNotFound) {
+		// The schedule was dropped while this job was running.
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "job %d: failed to notify schedule %d of its termination",
+			*j.id, scheduleID)
+	}
+	return nil
+}
+
+// scheduleID returns the ID of the schedule which created this job, or 0 if
+// the job was not created by a schedule.
+func (j *Job) scheduleID(ctx context.Context, txn *kv.Txn) (int64, error) {
+	if j.createdBy != nil {
+		if j.createdBy.Name != CreatedByScheduledJobs {
+			return 0, nil
+		}
+		return j.createdBy.ID, nil
+	}
+
+	row, err := j.registry.ex.QueryRowEx(
+		ctx, "job-created-by", txn, sqlbase.InternalExecutorSessionDataOverride{User: security.RootUser},
+		"SELECT created_by_id FROM system.jobs WHERE id = $1 AND created_by_type = $2",
+		*j.id, CreatedByScheduledJobs)
+	if err != nil {
+		return 0, err
+	}
+	if row == nil || row[0] == tree.DNull {
+		return 0, nil
+	}
+	scheduleID, ok := row[0].(*tree.DInt)
+	if !ok {
+		return 0, errors.AssertionFailedf("expected int created_by_id, found %T", row[0])
+	}
+	return int64(*scheduleID), nil
+}
+
 // SetDetails sets the details field of the currently running tracked job.
 func (j *Job) SetDetails(ctx context.Context, details interface{}) error {
 	return j.Update(ctx, func(txn *kv.Txn, md JobMetadata, ju *JobUpdater) error {
@@ -723,6 +797,14 @@ func (j *Job) insert(ctx context.Context, id int64, lease *jobspb.Lease) error {
 			return err
 		}
 
+		if j.createdBy != nil {
+			const stmt = `INSERT INTO system.jobs (id, status, payload, progress, created_by_type, created_by_id)
+VALUES ($1, $2, $3, $4, $5, $6)`
+			_, err = j.registry.ex.Exec(ctx, "job-insert", txn, stmt, id, StatusRunning,
+				payloadBytes, progressBytes, j.createdBy.Name, j.createdBy.ID)
+			return err
+		}
+
 		const stmt = "INSERT INTO system.jobs (id, status, payload, progress) VALUES ($1, $2, $3, $4)"
 		_, err = j.registry.ex.Exec(ctx, "job-insert", txn, stmt, id, StatusRunning, payloadBytes, progressBytes)
 		return err
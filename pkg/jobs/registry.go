@@ -158,7 +158,7 @@ type Registry struct {
 // subpackage like sqlbase is difficult because of the amount of sql-only
 // stuff that PlanHookState exports. One other choice is to merge this package
 // back into the sql package. There's maybe a better way that I'm unaware of.
-type planHookMaker func(opName, user string) (interface{}, func())
+type planHookMaker func(opName string, txn *kv.Txn, user string) (interface{}, func())
 
 // PreventAdoptionFile is the name of the file which, if present in the first
 // on-disk store, will prevent the adoption of background jobs by that node.
@@ -359,6 +359,7 @@ func (r *Registry) NewJob(record Record) *Job {
 		Details:       jobspb.WrapPayloadDetails(record.Details),
 		Noncancelable: record.NonCancelable,
 	}
+	job.createdBy = record.CreatedBy
 	job.mu.progress = jobspb.Progress{
 		Details:       jobspb.WrapProgressDetails(record.Progress),
 		RunningStatus: string(record.RunningStatus),
@@ -935,7 +936,7 @@ func (r *Registry) resume(
 	if err := r.stopper.RunAsyncTask(ctx, taskName, func(ctx context.Context) {
 		// Bookkeeping.
 		payload := job.Payload()
-		phs, cleanup := r.planFn("resume-"+taskName, payload.Username)
+		phs, cleanup := r.planFn("resume-"+taskName, nil /* txn */, payload.Username)
 		defer cleanup()
 		spanName := fmt.Sprintf(`%s-%d`, payload.Type(), *job.ID())
 		var span opentracing.Span
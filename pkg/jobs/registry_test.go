@@ -34,7 +34,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 )
 
-func FakePHS(opName, user string) (interface{}, func()) {
+func FakePHS(opName string, txn *kv.Txn, user string) (interface{}, func()) {
 	return nil, func() {}
 }
 
@@ -59,7 +59,11 @@ type ScheduledJob struct {
 }
 
 // NewScheduledJob creates and initializes ScheduledJob.
+// A nil env means the production environment.
 func NewScheduledJob(env jobSchedulerEnv) *ScheduledJob {
+	if env == nil {
+		env = prodJobSchedulerEnv
+	}
 	return &ScheduledJob{
 		env:   env,
 		dirty: make(map[string]struct{}),
@@ -71,12 +75,28 @@ func (j *ScheduledJob) ScheduleID() int64 {
 	return j.rec.ScheduleID
 }
 
+// ScheduleName returns schedule name.
+func (j *ScheduledJob) ScheduleName() string {
+	return j.rec.ScheduleName
+}
+
 // SetScheduleName updates schedule name.
 func (j *ScheduledJob) SetScheduleName(name string) {
 	j.rec.ScheduleName = name
 	j.markDirty("schedule_name")
 }
 
+// Owner returns schedule owner.
+func (j *ScheduledJob) Owner() string {
+	return j.rec.Owner
+}
+
+// SetOwner updates schedule owner.
+func (j *ScheduledJob) SetOwner(owner string) {
+	j.rec.Owner = owner
+	j.markDirty("owner")
+}
+
 // NextRun returns the next time this schedule supposed to execute.
 // A sentinel value of time.Time{} indicates this schedule is paused.
 func (j *ScheduledJob) NextRun() time.Time {
@@ -98,6 +118,11 @@ func (j *ScheduledJob) ExecutionArgs() *jobspb.ExecutionArguments {
 	return &j.rec.ExecutionArgs
 }
 
+// ScheduleExpr returns the schedule expression for this schedule.
+func (j *ScheduledJob) ScheduleExpr() string {
+	return j.rec.ScheduleExpr
+}
+
 // SetSchedule updates periodicity of this schedule, and updates this schedules
 // next run time.
 func (j *ScheduledJob) SetSchedule(scheduleExpr string) error {
@@ -173,6 +198,44 @@ func (j *ScheduledJob) SetExecutionDetails(executor string, args jobspb.Executio
 	j.markDirty("executor_type", "execution_args")
 }
 
+// ErrScheduleNotFound is returned when the requested schedule does not exist.
+var ErrScheduleNotFound = errors.New("schedule not found")
+
+// LoadScheduledJob loads scheduled job record from the database.
+func LoadScheduledJob(
+	ctx context.Context,
+	env jobSchedulerEnv,
+	id int64,
+	ex sqlutil.InternalExecutor,
+	txn *kv.Txn,
+) (*ScheduledJob, error) {
+	if env == nil {
+		env = prodJobSchedulerEnv
+	}
+
+	rows, cols, err := ex.QueryWithCols(ctx, "lookup-schedule", txn,
+		sqlbase.InternalExecutorSessionDataOverride{User: security.RootUser},
+		fmt.Sprintf("SELECT * FROM %s WHERE schedule_id = %d",
+			env.ScheduledJobsTableName(), id))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) != 1 {
+		return nil, errors.Mark(
+			errors.Newf("expected to find 1 schedule, found %d with schedule_id=%d",
+				len(rows), id),
+			ErrScheduleNotFound)
+	}
+
+	j := NewScheduledJob(env)
+	if err := j.InitFromDatums(rows[0], cols); err != nil {
+		return nil, err
+	}
+	return j, nil
+}
+
 // InitFromDatums initializes this ScheduledJob object based on datums and column names.
 func (j *ScheduledJob) InitFromDatums(datums []tree.Datum, cols []sqlbase.ResultColumn) error {
 	if len(datums) != len(cols) {
@@ -301,6 +364,21 @@ func (j *ScheduledJob) Update(ctx context.Context, ex sqlutil.InternalExecutor,
 	return nil
 }
 
+// Delete removes this schedule.
+// If an error is returned, it is callers responsibility to handle it (e.g. rollback transaction).
+func (j *ScheduledJob) Delete(ctx context.Context, ex sqlutil.InternalExecutor, txn *kv.Txn) error {
+	if j.rec.ScheduleID == 0 {
+		return errors.New("cannot delete schedule: missing schedule id")
+	}
+	_, err := ex.ExecEx(ctx, "sched-delete", txn,
+		sqlbase.InternalExecutorSessionDataOverride{User: security.RootUser},
+		fmt.Sprintf("DELETE FROM %s WHERE schedule_id = %d",
+			j.env.ScheduledJobsTableName(), j.ScheduleID()),
+	)
+
+	return err
+}
+
 // marshalChanges marshals all changes in the in-memory representation and returns
 // the names of the columns and marshaled values.
 // If no error is returned, the job is not considered to be modified anymore.
@@ -16,17 +16,29 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
 	"github.com/cockroachdb/cockroach/pkg/kv"
-	"github.com/cockroachdb/cockroach/pkg/security"
-	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlutil"
 	"github.com/cockroachdb/errors"
 )
 
+// JobExecutionConfig encapsulates external components needed for scheduled job execution.
+type JobExecutionConfig struct {
+	Settings         *cluster.Settings
+	InternalExecutor sqlutil.InternalExecutor
+	DB               *kv.DB
+	// PlanHookMaker is responsible for creating sql.NewInternalPlanner. It returns an
+	// *sql.planner as an interface{} due to package dependency cycles. It should
+	// be cast to that type in the sql package when it is used. Returns a cleanup
+	// function that must be called once the caller is done with the planner.
+	// This is the same mechanism used in jobs.Registry.
+	PlanHookMaker func(opName string, txn *kv.Txn, user string) (interface{}, func())
+}
+
 // ScheduledJobExecutor is an interface describing execution of the scheduled job.
 type ScheduledJobExecutor interface {
 	// Executes scheduled job;  Implementation may use provided transaction.
 	// Modifications to the ScheduledJob object will be persisted.
-	ExecuteJob(ctx context.Context, schedule *ScheduledJob, txn *kv.Txn) error
+	ExecuteJob(ctx context.Context, cfg *JobExecutionConfig, schedule *ScheduledJob, txn *kv.Txn) error
 
 	// Notifies that the system.job started by the ScheduledJob completed.
 	// Implementation may use provided transaction to perform any additional mutations.
@@ -120,26 +132,10 @@ func lookupScheduleAndExecutor(
 	ex sqlutil.InternalExecutor,
 	txn *kv.Txn,
 ) (*ScheduledJob, ScheduledJobExecutor, error) {
-	rows, cols, err := ex.QueryWithCols(ctx, "lookup-schedule", txn,
-		sqlbase.InternalExecutorSessionDataOverride{User: security.RootUser},
-		fmt.Sprintf(
-			"SELECT schedule_id, schedule_details, executor_type FROM %s WHERE schedule_id = %d",
-			env.ScheduledJobsTableName(), scheduleID))
-
+	j, err := LoadScheduledJob(ctx, env, scheduleID, ex, txn)
 	if err != nil {
 		return nil, nil, err
 	}
-
-	if len(rows) != 1 {
-		return nil, nil, errors.Newf(
-			"expected to find 1 schedule, found %d with schedule_id=%d",
-			len(rows), scheduleID)
-	}
-
-	j := NewScheduledJob(env)
-	if err := j.InitFromDatums(rows[0], cols); err != nil {
-		return nil, nil, err
-	}
 	executor, err := NewScheduledJobExecutor(j.ExecutorType(), ex)
 	if err == nil {
 		return j, executor, nil
@@ -14,19 +14,28 @@ import (
 	"context"
 	"testing"
 
+	"github.com/cockroachdb/cockroach/pkg/base"
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
 	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlutil"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/errors"
 	"github.com/stretchr/testify/require"
 )
 
 type statusTrackingExecutor struct {
 	numExec int
 	counts  map[Status]int
+	// notifyErr, if set, is returned by NotifyJobTermination.
+	notifyErr error
 }
 
-func (s *statusTrackingExecutor) ExecuteJob(_ context.Context, _ *ScheduledJob, _ *kv.Txn) error {
+func (s *statusTrackingExecutor) ExecuteJob(
+	_ context.Context, _ *JobExecutionConfig, _ *ScheduledJob, _ *kv.Txn,
+) error {
 	s.numExec++
 	return nil
 }
@@ -34,6 +43,9 @@ func (s *statusTrackingExecutor) ExecuteJob(_ context.Context, _ *ScheduledJob,
 func (s *statusTrackingExecutor) NotifyJobTermination(
 	_ context.Context, md *JobMetadata, _ *ScheduledJob, _ *kv.Txn,
 ) error {
+	if s.notifyErr != nil {
+		return s.notifyErr
+	}
 	s.counts[md.Status]++
 	return nil
 }
@@ -107,3 +119,130 @@ func TestJobTerminationNotification(t *testing.T) {
 	// Verify counts.
 	require.Equal(t, map[Status]int{StatusSucceeded: 1, StatusFailed: 1, StatusCanceled: 1}, ex.counts)
 }
+
+func TestJobTerminationNotifiesCreatingSchedule(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer ResetConstructors()()
+
+	ctx := context.Background()
+	s, _, kvDB := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(ctx)
+	registry := s.JobRegistry().(*Registry)
+	ex := s.InternalExecutor().(sqlutil.InternalExecutor)
+
+	const executorName = "test-executor"
+	tracker, cleanupExecutor := newScopedStatusTrackingExecutor(executorName)
+	defer cleanupExecutor()
+
+	schedule := NewScheduledJob(prodJobSchedulerEnv)
+	schedule.SetScheduleName("notify")
+	schedule.SetExecutionDetails(executorName, jobspb.ExecutionArguments{})
+	require.NoError(t, schedule.SetSchedule("@daily"))
+	require.NoError(t, schedule.Create(ctx, ex, nil))
+
+	failJob := errors.New("boom")
+	for _, expected := range []Status{StatusSucceeded, StatusFailed} {
+		var resumeErr error
+		if expected == StatusFailed {
+			resumeErr = failJob
+		}
+		RegisterConstructor(jobspb.TypeImport, func(_ *Job, _ *cluster.Settings) Resumer {
+			return FakeResumer{
+				OnResume: func(context.Context, chan<- tree.Datums) error {
+					return resumeErr
+				},
+			}
+		})
+
+		_, errCh, err := registry.CreateAndStartJob(ctx, nil, Record{
+			Details:   jobspb.ImportDetails{},
+			Progress:  jobspb.ImportProgress{},
+			CreatedBy: &CreatedByInfo{Name: CreatedByScheduledJobs, ID: schedule.ScheduleID()},
+		})
+		require.NoError(t, err)
+		if err := <-errCh; expected == StatusSucceeded {
+			require.NoError(t, err)
+		} else {
+			require.True(t, errors.Is(err, failJob))
+		}
+	}
+
+	require.Equal(t, map[Status]int{StatusSucceeded: 1, StatusFailed: 1}, tracker.counts)
+
+	// Jobs created by a schedule which no longer exists complete normally.
+	require.NoError(t, kvDB.Txn(ctx, func(ctx context.Context, txn *kv.Txn) error {
+		return schedule.Delete(ctx, ex, txn)
+	}))
+	RegisterConstructor(jobspb.TypeImport, func(_ *Job, _ *cluster.Settings) Resumer {
+		return FakeResumer{}
+	})
+	_, errCh, err := registry.CreateAndStartJob(ctx, nil, Record{
+		Details:   jobspb.ImportDetails{},
+		Progress:  jobspb.ImportProgress{},
+		CreatedBy: &CreatedByInfo{Name: CreatedByScheduledJobs, ID: schedule.ScheduleID()},
+	})
+	require.NoError(t, err)
+	require.NoError(t, <-errCh)
+	require.Equal(t, 1, tracker.counts[StatusSucceeded])
+}
+
+func TestJobTerminationNotificationError(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer ResetConstructors()()
+
+	ctx := context.Background()
+	s, _, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(ctx)
+	registry := s.JobRegistry().(*Registry)
+	ex := s.InternalExecutor().(sqlutil.InternalExecutor)
+
+	const executorName = "test-executor"
+	tracker, cleanupExecutor := newScopedStatusTrackingExecutor(executorName)
+	defer cleanupExecutor()
+
+	schedule := NewScheduledJob(prodJobSchedulerEnv)
+	schedule.SetScheduleName("notify")
+	schedule.SetExecutionDetails(executorName, jobspb.ExecutionArguments{})
+	require.NoError(t, schedule.SetSchedule("@daily"))
+	require.NoError(t, schedule.Create(ctx, ex, nil))
+
+	// The job is marked as succeeded by the test, so it never finishes if it is
+	// adopted by the registry.
+	done := make(chan struct{})
+	defer close(done)
+	RegisterConstructor(jobspb.TypeImport, func(_ *Job, _ *cluster.Settings) Resumer {
+		return FakeResumer{
+			OnResume: func(ctx context.Context, _ chan<- tree.Datums) error {
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-done:
+					return nil
+				}
+			},
+		}
+	})
+
+	j := registry.NewJob(Record{
+		Details:   jobspb.ImportDetails{},
+		Progress:  jobspb.ImportProgress{},
+		CreatedBy: &CreatedByInfo{Name: CreatedByScheduledJobs, ID: schedule.ScheduleID()},
+	})
+	require.NoError(t, j.created(ctx))
+
+	// A failure to notify the schedule aborts the transaction which marks the
+	// job as succeeded.
+	notifyErr := errors.New("boom")
+	tracker.notifyErr = notifyErr
+	require.True(t, errors.Is(j.succeeded(ctx, nil /* fn */), notifyErr))
+	status, err := j.CurrentStatus(ctx)
+	require.NoError(t, err)
+	require.NotEqual(t, StatusSucceeded, status)
+
+	tracker.notifyErr = nil
+	require.NoError(t, j.succeeded(ctx, nil /* fn */))
+	status, err = j.CurrentStatus(ctx)
+	require.NoError(t, err)
+	require.Equal(t, StatusSucceeded, status)
+	require.Equal(t, map[Status]int{StatusSucceeded: 1}, tracker.counts)
+}
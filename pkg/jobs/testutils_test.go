@@ -71,6 +71,7 @@ func (t *testJobSchedulerEnv) getJobsTableSchema() string {
 
 type testHelper struct {
 	env   *testJobSchedulerEnv
+	cfg   *JobExecutionConfig
 	kvDB  *kv.DB
 	sqlDB *sqlutils.SQLRunner
 	ex    sqlutil.InternalExecutor
@@ -96,11 +97,17 @@ func newTestHelper(t *testing.T) (*testHelper, func()) {
 	sqlDB.Exec(t, env.getScheduledJobsTableSchema())
 	sqlDB.Exec(t, env.getJobsTableSchema())
 
+	ex := s.InternalExecutor().(sqlutil.InternalExecutor)
 	return &testHelper{
-			env:   env,
+			env: env,
+			cfg: &JobExecutionConfig{
+				Settings:         s.ClusterSettings(),
+				InternalExecutor: ex,
+				DB:               kvdb,
+			},
 			kvDB:  kvdb,
 			sqlDB: sqlDB,
-			ex:    s.InternalExecutor().(sqlutil.InternalExecutor),
+			ex:    ex,
 		}, func() {
 			if env.scheduledJobsTableName == "defaultdb.scheduled_jobs" {
 				sqlDB.Exec(t, "DROP TABLE "+env.scheduledJobsTableName)
@@ -643,6 +643,19 @@ func MetaReverseScanBounds(key roachpb.RKey) (roachpb.RSpan, error) {
 	return roachpb.RSpan{Key: start, EndKey: end}, nil
 }
 
+// TenantMetaSpan returns the span of the meta2 addressing records of the
+// ranges that make up the keyspace of the provided tenant, given that the
+// keyspace is split at its boundaries. The record at the start key of the
+// keyspace is excluded, since it describes the last range that precedes the
+// keyspace, while the record at its end key is included.
+func TenantMetaSpan(tenID roachpb.TenantID) roachpb.RSpan {
+	prefix := roachpb.RKey(MakeTenantPrefix(tenID))
+	return roachpb.RSpan{
+		Key:    RangeMetaKey(prefix).Next(),
+		EndKey: RangeMetaKey(prefix.PrefixEnd()).Next(),
+	}
+}
+
 // MakeTableIDIndexID returns the key for the table id and index id by appending
 // to the passed key. The key must already contain a tenant id.
 func MakeTableIDIndexID(key []byte, tableID uint32, indexID uint32) []byte {
@@ -264,6 +264,7 @@ func (b *Batch) fillResults(ctx context.Context) {
 			case *roachpb.ImportRequest:
 			case *roachpb.AdminScatterRequest:
 			case *roachpb.AddSSTableRequest:
+			case *roachpb.QueryResolvedTimestampRequest:
 			}
 			// Fill up the resume span.
 			if result.Err == nil && reply != nil && reply.Header().ResumeSpan != nil {
@@ -756,6 +757,28 @@ func (b *Batch) writeBatch(s, e interface{}, data []byte) {
 	b.initResult(1, 0, notRaw, nil)
 }
 
+// queryResolvedTimestamp is only exported on DB.
+func (b *Batch) queryResolvedTimestamp(s, e interface{}) {
+	begin, err := marshalKey(s)
+	if err != nil {
+		b.initResult(0, 0, notRaw, err)
+		return
+	}
+	end, err := marshalKey(e)
+	if err != nil {
+		b.initResult(0, 0, notRaw, err)
+		return
+	}
+	req := &roachpb.QueryResolvedTimestampRequest{
+		RequestHeader: roachpb.RequestHeader{
+			Key:    begin,
+			EndKey: end,
+		},
+	}
+	b.appendReqs(req)
+	b.initResult(1, 0, notRaw, nil)
+}
+
 // addSSTable is only exported on DB.
 func (b *Batch) addSSTable(
 	s, e interface{},
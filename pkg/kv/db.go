@@ -635,10 +635,28 @@ func (db *DB) AddSSTable(
 	ingestAsWrites bool,
 ) error {
 	b := &Batch{}
+	b.Header.AdmissionPriority = roachpb.BulkAdmissionPriority
 	b.addSSTable(begin, end, data, disallowShadowing, stats, ingestAsWrites)
 	return getOneErr(db.Run(ctx, b), b)
 }
 
+// QueryResolvedTimestamp requests the resolved timestamp of the key span
+// [begin, end). The request is served by the nearest replica of each range
+// overlapping the span, which doesn't need to hold the range lease; reads at or
+// below the returned timestamp can be served by those replicas without
+// blocking. A zero timestamp is returned if no such timestamp is known.
+func (db *DB) QueryResolvedTimestamp(
+	ctx context.Context, begin, end interface{},
+) (hlc.Timestamp, error) {
+	b := &Batch{}
+	b.Header.ReadConsistency = roachpb.INCONSISTENT
+	b.queryResolvedTimestamp(begin, end)
+	if err := getOneErr(db.Run(ctx, b), b); err != nil {
+		return hlc.Timestamp{}, err
+	}
+	return b.RawResponse().Responses[0].GetQueryResolvedTimestamp().ResolvedTS, nil
+}
+
 // sendAndFill is a helper which sends the given batch and fills its results,
 // returning the appropriate error which is either from the first failing call,
 // or an "internal" error.
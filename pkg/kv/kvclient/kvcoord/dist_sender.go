@@ -383,6 +383,11 @@ func (ds *DistSender) RangeLookup(
 	// RangeDescriptor is not on the first range we send the lookup too, we'll
 	// still find it when we scan to the next range. This addresses the issue
 	// described in #18032 and #16266, allowing us to support meta2 splits.
+	if tenID := ds.rpcContext.TenantID; tenID != (roachpb.TenantID{}) && tenID != roachpb.SystemTenantID {
+		// SQL tenants may only read the addressing records of their own ranges.
+		return kv.TenantRangeLookup(
+			ctx, ds, tenID, key.AsRawKey(), rc, rangeLookupPrefetchCount, useReverseScan)
+	}
 	return kv.RangeLookup(ctx, ds, key.AsRawKey(), rc, rangeLookupPrefetchCount, useReverseScan)
 }
 
@@ -1612,7 +1617,21 @@ func (ds *DistSender) sendToReplicas(
 	ctx context.Context, ba roachpb.BatchRequest, desc *roachpb.RangeDescriptor, withCommit bool,
 ) (*roachpb.BatchResponse, error) {
 	ba.RangeID = desc.RangeID
-	replicas, err := NewReplicaSlice(ctx, ds.gossip, desc)
+	canFollowerRead := (ds.clusterID != nil) && CanSendToFollower(ds.clusterID.Get(), ds.st, ba)
+	// Batches that ask to be routed to the nearest replica are sent the same way
+	// as follower reads; if that replica can't serve them, they fall back to the
+	// leaseholder through the usual NotLeaseHolderError handling.
+	if ba.RoutingPolicy == roachpb.NEAREST {
+		canFollowerRead = true
+	}
+	// Non-voting replicas can only serve follower reads, so only include them
+	// if this request can be served by one. Resolved timestamps can be queried
+	// from any replica.
+	filter := OnlyPotentialLeaseholders
+	if canFollowerRead || ba.IsSingleQueryResolvedTimestampRequest() {
+		filter = AllExtantReplicas
+	}
+	replicas, err := NewReplicaSlice(ctx, ds.gossip, desc, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -1627,7 +1646,6 @@ func (ds *DistSender) sendToReplicas(
 			cachedLeaseHolder = replicas[i].ReplicaDescriptor
 		}
 	}
-	canFollowerRead := (ds.clusterID != nil) && CanSendToFollower(ds.clusterID.Get(), ds.st, ba)
 	// If this request needs to go to a lease holder and we know who that is, move
 	// it to the front.
 	sendToLeaseholder :=
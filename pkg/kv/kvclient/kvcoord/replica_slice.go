@@ -39,9 +39,23 @@ func (i ReplicaInfo) addr() string {
 // A ReplicaSlice is a slice of ReplicaInfo.
 type ReplicaSlice []ReplicaInfo
 
+// ReplicaSliceFilter controls which kinds of replicas are to be included in
+// a ReplicaSlice.
+type ReplicaSliceFilter int
+
+const (
+	// OnlyPotentialLeaseholders prescribes that the ReplicaSlice include only
+	// the voting replicas, which are the only ones that can hold the lease.
+	OnlyPotentialLeaseholders ReplicaSliceFilter = iota
+	// AllExtantReplicas prescribes that the ReplicaSlice include the voting and
+	// the non-voting replicas, all of which can serve follower reads.
+	AllExtantReplicas
+)
+
 // NewReplicaSlice creates a ReplicaSlice from the replicas listed in the range
 // descriptor and using gossip to lookup node descriptors. Replicas on nodes
-// that are not gossiped are omitted from the result.
+// that are not gossiped are omitted from the result. The filter determines
+// which types of replicas are included.
 //
 // If there's no info in gossip for any of the nodes in the descriptor, a
 // sendError is returned.
@@ -51,13 +65,23 @@ func NewReplicaSlice(
 		GetNodeDescriptor(roachpb.NodeID) (*roachpb.NodeDescriptor, error)
 	},
 	desc *roachpb.RangeDescriptor,
+	filter ReplicaSliceFilter,
 ) (ReplicaSlice, error) {
-	// Learner replicas won't serve reads/writes, so we'll send only to the
-	// `Voters` replicas. This is just an optimization to save a network hop,
-	// everything would still work if we had `All` here.
-	voters := desc.Replicas().Voters()
-	rs := make(ReplicaSlice, 0, len(voters))
-	for _, r := range voters {
+	// Learner replicas won't serve reads/writes, so we'll never send to them.
+	// Non-voters are only useful for requests that can be served by followers.
+	// This is just an optimization to save a network hop, everything would
+	// still work if we had `All` here.
+	var replicas []roachpb.ReplicaDescriptor
+	switch filter {
+	case OnlyPotentialLeaseholders:
+		replicas = desc.Replicas().Voters()
+	case AllExtantReplicas:
+		replicas = desc.Replicas().VotersAndNonVoters()
+	default:
+		log.Fatalf(ctx, "unknown ReplicaSliceFilter %v", filter)
+	}
+	rs := make(ReplicaSlice, 0, len(replicas))
+	for _, r := range replicas {
 		nd, err := gossip.GetNodeDescriptor(r.NodeID)
 		if err != nil {
 			if log.V(1) {
@@ -225,6 +225,54 @@ func TestSequenceNumberAllocationWithStep(t *testing.T) {
 	require.NotNil(t, br)
 }
 
+// TestSequenceNumberAllocationSetReadSeq tests that the read seqnum can be
+// moved back to an earlier sequencing point, but not past the last write.
+func TestSequenceNumberAllocationSetReadSeq(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	ctx := context.Background()
+	s, mockSender := makeMockTxnSeqNumAllocator()
+
+	txn := makeTxnProto()
+	keyA := roachpb.Key("a")
+
+	require.Error(t, s.setReadSeqLocked(0))
+	s.configureSteppingLocked(true /* enabled */)
+	earlierStepSeqNum := s.readSeq
+
+	var ba roachpb.BatchRequest
+	ba.Header = roachpb.Header{Txn: &txn}
+	ba.Add(&roachpb.PutRequest{RequestHeader: roachpb.RequestHeader{Key: keyA}})
+	mockSender.MockSend(func(ba roachpb.BatchRequest) (*roachpb.BatchResponse, *roachpb.Error) {
+		br := ba.CreateReply()
+		br.Txn = ba.Txn
+		return br, nil
+	})
+	br, pErr := s.SendLocked(ctx, ba)
+	require.Nil(t, pErr)
+	require.NotNil(t, br)
+	require.NoError(t, s.stepLocked(ctx))
+	require.Equal(t, earlierStepSeqNum+1, s.readSeq)
+
+	// Reads at the earlier sequencing point ignore the write.
+	require.NoError(t, s.setReadSeqLocked(earlierStepSeqNum))
+	ba.Requests = nil
+	ba.Add(&roachpb.GetRequest{RequestHeader: roachpb.RequestHeader{Key: keyA}})
+	mockSender.MockSend(func(ba roachpb.BatchRequest) (*roachpb.BatchResponse, *roachpb.Error) {
+		require.Len(t, ba.Requests, 1)
+		require.Equal(t, earlierStepSeqNum, ba.Requests[0].GetInner().Header().Sequence)
+
+		br := ba.CreateReply()
+		br.Txn = ba.Txn
+		return br, nil
+	})
+	br, pErr = s.SendLocked(ctx, ba)
+	require.Nil(t, pErr)
+	require.NotNil(t, br)
+
+	require.Error(t, s.setReadSeqLocked(s.writeSeq+1))
+	require.NoError(t, s.setReadSeqLocked(s.writeSeq))
+}
+
 // TestSequenceNumberAllocationTxnRequests tests sequence number allocation's
 // interaction with transaction state requests (HeartbeatTxn and EndTxn). Only
 // EndTxn requests should be assigned unique sequence numbers.
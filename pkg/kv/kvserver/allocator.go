@@ -55,8 +55,12 @@ const (
 	addMissingReplicaPriority               float64 = 10000
 	addDecommissioningReplacementPriority   float64 = 5000
 	removeDeadReplicaPriority               float64 = 1000
+	addMissingNonVoterPriority              float64 = 600
+	removeDeadNonVoterPriority              float64 = 500
 	removeDecommissioningReplicaPriority    float64 = 200
+	removeDecommissioningNonVoterPriority   float64 = 150
 	removeExtraReplicaPriority              float64 = 100
+	removeExtraNonVoterPriority             float64 = 50
 )
 
 // MinLeaseTransferStatsDuration configures the minimum amount of time a
@@ -108,6 +112,10 @@ const (
 	AllocatorConsiderRebalance
 	AllocatorRangeUnavailable
 	AllocatorFinalizeAtomicReplicationChange
+	AllocatorAddNonVoter
+	AllocatorRemoveNonVoter
+	AllocatorRemoveDeadNonVoter
+	AllocatorRemoveDecommissioningNonVoter
 )
 
 var allocatorActionNames = map[AllocatorAction]string{
@@ -122,12 +130,26 @@ var allocatorActionNames = map[AllocatorAction]string{
 	AllocatorConsiderRebalance:               "consider rebalance",
 	AllocatorRangeUnavailable:                "range unavailable",
 	AllocatorFinalizeAtomicReplicationChange: "finalize conf change",
+	AllocatorAddNonVoter:                     "add non-voter",
+	AllocatorRemoveNonVoter:                  "remove non-voter",
+	AllocatorRemoveDeadNonVoter:              "remove dead non-voter",
+	AllocatorRemoveDecommissioningNonVoter:   "remove decommissioning non-voter",
 }
 
 func (a AllocatorAction) String() string {
 	return allocatorActionNames[a]
 }
 
+// targetReplicaType indicates whether an allocation decision concerns a
+// range's voting or non-voting replicas.
+type targetReplicaType int
+
+const (
+	_ targetReplicaType = iota
+	voterTarget
+	nonVoterTarget
+)
+
 type transferDecision int
 
 const (
@@ -296,6 +318,22 @@ func GetNeededReplicas(zoneConfigReplicaCount int32, clusterNodes int) int {
 	return need
 }
 
+// GetNeededNonVoters calculates the number of non-voting replicas a range
+// should have given its zone config, the number of voting replicas it has and
+// the number of nodes available for up-replication. Since no two replicas of a
+// range may share a node, non-voters only get the nodes left over by the
+// voters.
+func GetNeededNonVoters(numVoters, zoneConfigNonVoterCount, clusterNodes int) int {
+	need := zoneConfigNonVoterCount
+	if clusterNodes-numVoters < need {
+		need = clusterNodes - numVoters
+	}
+	if need < 0 {
+		need = 0
+	}
+	return need
+}
+
 // ComputeAction determines the exact operation needed to repair the
 // supplied range, as governed by the supplied zone configuration. It
 // returns the required action that should be taken and a priority.
@@ -354,18 +392,22 @@ func (a *Allocator) ComputeAction(
 		// removeLearnerReplicaPriority as the highest priority.
 		return AllocatorRemoveLearner, removeLearnerReplicaPriority
 	}
-	// computeAction expects to operate only on voters.
-	return a.computeAction(ctx, zone, desc.Replicas().Voters())
+	return a.computeAction(ctx, zone, desc.Replicas().Voters(), desc.Replicas().NonVoters())
 }
 
+// computeAction determines the action to take on the voting replicas of a
+// range and, once those need no repair, on its non-voting replicas.
 func (a *Allocator) computeAction(
-	ctx context.Context, zone *zonepb.ZoneConfig, voterReplicas []roachpb.ReplicaDescriptor,
+	ctx context.Context,
+	zone *zonepb.ZoneConfig,
+	voterReplicas []roachpb.ReplicaDescriptor,
+	nonVoterReplicas []roachpb.ReplicaDescriptor,
 ) (AllocatorAction, float64) {
 	// TODO(mrtracy): Handle non-homogeneous and mismatched attribute sets.
 	have := len(voterReplicas)
 	decommissioningReplicas := a.storePool.decommissioningReplicas(voterReplicas)
 	clusterNodes := a.storePool.ClusterNodeCount()
-	need := GetNeededReplicas(*zone.NumReplicas, clusterNodes)
+	need := GetNeededReplicas(zone.GetNumVoters(), clusterNodes)
 	desiredQuorum := computeQuorum(need)
 	quorum := computeQuorum(have)
 
@@ -449,6 +491,55 @@ func (a *Allocator) computeAction(
 		return action, priority
 	}
 
+	// The voting replicas are in order, so turn to the non-voting ones.
+	return a.computeNonVoterAction(ctx, zone, have, nonVoterReplicas)
+}
+
+// computeNonVoterAction determines the action to take on the non-voting
+// replicas of a range whose voting replicas need no repair. Non-voters don't
+// count towards quorum, so unlike voters, dead or decommissioning non-voters
+// are removed right away and replaced afterwards.
+func (a *Allocator) computeNonVoterAction(
+	ctx context.Context,
+	zone *zonepb.ZoneConfig,
+	numVoters int,
+	nonVoterReplicas []roachpb.ReplicaDescriptor,
+) (AllocatorAction, float64) {
+	have := len(nonVoterReplicas)
+	clusterNodes := a.storePool.ClusterNodeCount()
+	need := GetNeededNonVoters(numVoters, int(zone.GetNumNonVoters()), clusterNodes)
+
+	if _, deadNonVoters := a.storePool.liveAndDeadReplicas(nonVoterReplicas); len(deadNonVoters) > 0 {
+		priority := removeDeadNonVoterPriority
+		action := AllocatorRemoveDeadNonVoter
+		log.VEventf(ctx, 3, "%s - dead=%d, need=%d, have=%d, priority=%.2f",
+			action, len(deadNonVoters), need, have, priority)
+		return action, priority
+	}
+
+	if decommissioning := a.storePool.decommissioningReplicas(nonVoterReplicas); len(decommissioning) > 0 {
+		priority := removeDecommissioningNonVoterPriority
+		action := AllocatorRemoveDecommissioningNonVoter
+		log.VEventf(ctx, 3, "%s - num_decommissioning=%d, need=%d, have=%d, priority=%.2f",
+			action, len(decommissioning), need, have, priority)
+		return action, priority
+	}
+
+	if have < need {
+		priority := addMissingNonVoterPriority
+		action := AllocatorAddNonVoter
+		log.VEventf(ctx, 3, "%s - missing non-voter need=%d, have=%d, priority=%.2f",
+			action, need, have, priority)
+		return action, priority
+	}
+
+	if have > need {
+		priority := removeExtraNonVoterPriority
+		action := AllocatorRemoveNonVoter
+		log.VEventf(ctx, 3, "%s - need=%d, have=%d, priority=%.2f", action, need, have, priority)
+		return action, priority
+	}
+
 	// Nothing needs to be done, but we may want to rebalance.
 	return AllocatorConsiderRebalance, 0
 }
@@ -458,35 +549,92 @@ type decisionDetails struct {
 	Existing string `json:",omitempty"`
 }
 
-// AllocateTarget returns a suitable store for a new allocation with the
-// required attributes. Nodes already accommodating existing replicas are ruled
+// AllocateTarget returns a suitable store for a new voting replica with the
+// required attributes. Nodes already accommodating existing voters are ruled
 // out as targets. The range ID of the replica being allocated for is also
 // passed in to ensure that we don't try to replace an existing dead replica on
 // a store.
 //
+// The store of one of the range's non-voting replicas may be returned, in
+// which case the caller is expected to promote that non-voter rather than add
+// a new replica. Among equally good targets, such stores are preferred.
+//
 // TODO(tbg): AllocateReplacement?
 func (a *Allocator) AllocateTarget(
-	ctx context.Context, zone *zonepb.ZoneConfig, existingReplicas []roachpb.ReplicaDescriptor,
+	ctx context.Context,
+	zone *zonepb.ZoneConfig,
+	existingVoters, existingNonVoters []roachpb.ReplicaDescriptor,
 ) (*roachpb.StoreDescriptor, string, error) {
 	sl, aliveStoreCount, throttled := a.storePool.getStoreList(storeFilterThrottled)
+	// A voter may not share a node with a non-voter other than the one it is
+	// promoting.
+	sl = sl.excludeReplicaNodes(existingNonVoters, true /* keepReplicaStores */)
 
 	target, details := a.allocateTargetFromList(
-		ctx, sl, zone, existingReplicas, a.scorerOptions())
+		ctx, sl, zone, existingVoters, existingNonVoters, a.scorerOptions())
 
 	if target != nil {
 		return target, details, nil
 	}
 
-	// When there are throttled stores that do match, we shouldn't send
-	// the replica to purgatory.
+	constraints := zone.Constraints
+	if len(zone.VoterConstraints) > 0 {
+		constraints = zone.VoterConstraints
+	}
+	return nil, "", newAllocationError(constraints, len(existingVoters), aliveStoreCount, throttled)
+}
+
+// AllocateNonVoter returns a suitable store for a new non-voting replica.
+// Nodes already accommodating any of the range's replicas are ruled out as
+// targets.
+func (a *Allocator) AllocateNonVoter(
+	ctx context.Context,
+	zone *zonepb.ZoneConfig,
+	existingVoters, existingNonVoters []roachpb.ReplicaDescriptor,
+) (*roachpb.StoreDescriptor, string, error) {
+	sl, aliveStoreCount, throttled := a.storePool.getStoreList(storeFilterThrottled)
+
+	existingReplicas := append(append([]roachpb.ReplicaDescriptor(nil), existingVoters...),
+		existingNonVoters...)
+	analyzedConstraints := constraint.AnalyzeConstraints(
+		ctx, a.storePool.getStoreDescriptor, existingReplicas, zone)
+	options := a.scorerOptions()
+	candidates := allocateCandidates(
+		sl, analyzedConstraints, existingReplicas, a.storePool.getLocalities(existingReplicas),
+		options,
+	)
+	log.VEventf(ctx, 3, "allocate non-voter candidates: %s", candidates)
+	if target := candidates.selectGood(a.randGen); target != nil {
+		log.VEventf(ctx, 3, "add non-voter target: %s", target)
+		details := decisionDetails{Target: target.compactString(options)}
+		detailsBytes, err := json.Marshal(details)
+		if err != nil {
+			log.Warningf(ctx, "failed to marshal details for choosing allocate target: %+v", err)
+		}
+		return &target.store, string(detailsBytes), nil
+	}
+
+	return nil, "", newAllocationError(
+		zone.Constraints, len(existingReplicas), aliveStoreCount, throttled)
+}
+
+// newAllocationError returns the error for a failed allocation. When there are
+// throttled stores that do match, we shouldn't send the replica to purgatory,
+// so an allocatorError is only returned if there are none.
+func newAllocationError(
+	constraints []zonepb.ConstraintsConjunction,
+	existingReplicas int,
+	aliveStoreCount int,
+	throttled throttledStoreReasons,
+) error {
 	if len(throttled) > 0 {
-		return nil, "", errors.Errorf(
+		return errors.Errorf(
 			"%d matching stores are currently throttled: %v", len(throttled), throttled,
 		)
 	}
-	return nil, "", &allocatorError{
-		constraints:      zone.Constraints,
-		existingReplicas: len(existingReplicas),
+	return &allocatorError{
+		constraints:      constraints,
+		existingReplicas: existingReplicas,
 		aliveStores:      aliveStoreCount,
 		throttledStores:  len(throttled),
 	}
@@ -497,9 +645,10 @@ func (a *Allocator) allocateTargetFromList(
 	sl StoreList,
 	zone *zonepb.ZoneConfig,
 	candidateReplicas []roachpb.ReplicaDescriptor,
+	existingNonVoters []roachpb.ReplicaDescriptor,
 	options scorerOptions,
 ) (*roachpb.StoreDescriptor, string) {
-	analyzedConstraints := constraint.AnalyzeConstraints(
+	analyzedConstraints := constraint.AnalyzeVoterConstraints(
 		ctx, a.storePool.getStoreDescriptor, candidateReplicas, zone)
 	candidates := allocateCandidates(
 		sl, analyzedConstraints, candidateReplicas, a.storePool.getLocalities(candidateReplicas),
@@ -507,6 +656,16 @@ func (a *Allocator) allocateTargetFromList(
 	)
 	log.VEventf(ctx, 3, "allocate candidates: %s", candidates)
 	if target := candidates.selectGood(a.randGen); target != nil {
+		// Promoting a non-voter doesn't require a snapshot, so prefer it over
+		// any equally good store.
+		if best := candidates.best(); len(existingNonVoters) > 0 {
+			for i := range best {
+				if storeHasReplica(best[i].store.StoreID, existingNonVoters) {
+					target = &best[i]
+					break
+				}
+			}
+		}
 		log.VEventf(ctx, 3, "add target: %s", target)
 		details := decisionDetails{Target: target.compactString(options)}
 		detailsBytes, err := json.Marshal(details)
@@ -524,8 +683,10 @@ func (a Allocator) simulateRemoveTarget(
 	targetStore roachpb.StoreID,
 	zone *zonepb.ZoneConfig,
 	candidates []roachpb.ReplicaDescriptor,
-	existingReplicas []roachpb.ReplicaDescriptor,
+	existingVoters []roachpb.ReplicaDescriptor,
+	existingNonVoters []roachpb.ReplicaDescriptor,
 	rangeUsageInfo RangeUsageInfo,
+	targetType targetReplicaType,
 ) (roachpb.ReplicaDescriptor, string, error) {
 	// Update statistics first
 	// TODO(a-robinson): This could theoretically interfere with decisions made by other goroutines,
@@ -537,19 +698,49 @@ func (a Allocator) simulateRemoveTarget(
 		a.storePool.updateLocalStoreAfterRebalance(targetStore, rangeUsageInfo, roachpb.REMOVE_REPLICA)
 	}()
 	log.VEventf(ctx, 3, "simulating which replica would be removed after adding s%d", targetStore)
-	return a.RemoveTarget(ctx, zone, candidates, existingReplicas)
+	if targetType == nonVoterTarget {
+		return a.RemoveNonVoter(ctx, zone, candidates, existingVoters, existingNonVoters)
+	}
+	return a.RemoveTarget(ctx, zone, candidates, existingVoters)
 }
 
-// RemoveTarget returns a suitable replica to remove from the provided replica
-// set. It first attempts to randomly select a target from the set of stores
-// that have greater than the average number of replicas. Failing that, it
-// falls back to selecting a random target from any of the existing
+// RemoveTarget returns a suitable voting replica to remove from the provided
+// replica set. It first attempts to randomly select a target from the set of
+// stores that have greater than the average number of replicas. Failing that,
+// it falls back to selecting a random target from any of the existing
 // replicas.
 func (a Allocator) RemoveTarget(
 	ctx context.Context,
 	zone *zonepb.ZoneConfig,
 	candidates []roachpb.ReplicaDescriptor,
 	existingReplicas []roachpb.ReplicaDescriptor,
+) (roachpb.ReplicaDescriptor, string, error) {
+	analyzedConstraints := constraint.AnalyzeVoterConstraints(
+		ctx, a.storePool.getStoreDescriptor, existingReplicas, zone)
+	return a.removeTarget(ctx, analyzedConstraints, candidates, existingReplicas)
+}
+
+// RemoveNonVoter is like RemoveTarget, but selects one of the candidate
+// non-voting replicas for removal. The diversity and constraints of the range
+// are judged across all of its replicas.
+func (a Allocator) RemoveNonVoter(
+	ctx context.Context,
+	zone *zonepb.ZoneConfig,
+	candidates []roachpb.ReplicaDescriptor,
+	existingVoters, existingNonVoters []roachpb.ReplicaDescriptor,
+) (roachpb.ReplicaDescriptor, string, error) {
+	existingReplicas := append(append([]roachpb.ReplicaDescriptor(nil), existingVoters...),
+		existingNonVoters...)
+	analyzedConstraints := constraint.AnalyzeConstraints(
+		ctx, a.storePool.getStoreDescriptor, existingReplicas, zone)
+	return a.removeTarget(ctx, analyzedConstraints, candidates, existingReplicas)
+}
+
+func (a Allocator) removeTarget(
+	ctx context.Context,
+	analyzedConstraints constraint.AnalyzedConstraints,
+	candidates []roachpb.ReplicaDescriptor,
+	existingReplicas []roachpb.ReplicaDescriptor,
 ) (roachpb.ReplicaDescriptor, string, error) {
 	if len(candidates) == 0 {
 		return roachpb.ReplicaDescriptor{}, "", errors.Errorf("must supply at least one candidate replica to allocator.RemoveTarget()")
@@ -562,8 +753,6 @@ func (a Allocator) RemoveTarget(
 	}
 	sl, _, _ := a.storePool.getStoreListFromIDs(existingStoreIDs, storeFilterNone)
 
-	analyzedConstraints := constraint.AnalyzeConstraints(
-		ctx, a.storePool.getStoreDescriptor, existingReplicas, zone)
 	options := a.scorerOptions()
 	rankedCandidates := removeCandidates(
 		sl,
@@ -598,9 +787,11 @@ func (a Allocator) RemoveTarget(
 // The supplied parameters are the required attributes for the range and
 // information about the range being considered for rebalancing.
 //
-// The existing replicas modulo any store with dead replicas are candidates for
-// rebalancing. Note that rebalancing is accomplished by first adding a new
-// replica to the range, then removing the most undesirable replica.
+// The existing voting replicas modulo any store with dead replicas are
+// candidates for rebalancing. Note that rebalancing is accomplished by first
+// adding a new replica to the range, then removing the most undesirable
+// replica. Nodes holding one of the range's non-voting replicas are never
+// chosen as targets.
 //
 // Simply ignoring a rebalance opportunity in the event that the target chosen
 // by AllocateTarget() doesn't fit balancing criteria is perfectly fine, as
@@ -619,51 +810,109 @@ func (a Allocator) RebalanceTarget(
 	ctx context.Context,
 	zone *zonepb.ZoneConfig,
 	raftStatus *raft.Status,
-	existingReplicas []roachpb.ReplicaDescriptor,
+	existingVoters []roachpb.ReplicaDescriptor,
+	existingNonVoters []roachpb.ReplicaDescriptor,
+	rangeUsageInfo RangeUsageInfo,
+	filter storeFilter,
+) (add roachpb.ReplicationTarget, remove roachpb.ReplicationTarget, details string, ok bool) {
+	return a.rebalanceTarget(
+		ctx, zone, raftStatus, existingVoters, existingNonVoters, rangeUsageInfo, filter, voterTarget,
+	)
+}
+
+// RebalanceNonVoter is like RebalanceTarget, but considers moving one of the
+// range's non-voting replicas. Nodes holding one of the range's voting
+// replicas are never chosen as targets.
+func (a Allocator) RebalanceNonVoter(
+	ctx context.Context,
+	zone *zonepb.ZoneConfig,
+	raftStatus *raft.Status,
+	existingVoters []roachpb.ReplicaDescriptor,
+	existingNonVoters []roachpb.ReplicaDescriptor,
 	rangeUsageInfo RangeUsageInfo,
 	filter storeFilter,
+) (add roachpb.ReplicationTarget, remove roachpb.ReplicationTarget, details string, ok bool) {
+	return a.rebalanceTarget(
+		ctx, zone, raftStatus, existingVoters, existingNonVoters, rangeUsageInfo, filter, nonVoterTarget,
+	)
+}
+
+func (a Allocator) rebalanceTarget(
+	ctx context.Context,
+	zone *zonepb.ZoneConfig,
+	raftStatus *raft.Status,
+	existingVoters []roachpb.ReplicaDescriptor,
+	existingNonVoters []roachpb.ReplicaDescriptor,
+	rangeUsageInfo RangeUsageInfo,
+	filter storeFilter,
+	targetType targetReplicaType,
 ) (add roachpb.ReplicationTarget, remove roachpb.ReplicationTarget, details string, ok bool) {
 	sl, _, _ := a.storePool.getStoreList(filter)
 
 	zero := roachpb.ReplicationTarget{}
 
-	// We're going to add another replica to the range which will change the
-	// quorum size. Verify that the number of existing live replicas is sufficient
-	// to meet the new quorum. For a range configured for 3 replicas, this will
-	// disable rebalancing if one of the replicas is on a down node. Instead,
-	// we'll have to wait for the down node to be declared dead and go through the
-	// dead-node removal dance: remove dead replica, add new replica.
-	//
-	// NB: The len(replicas) > 1 check allows rebalancing of ranges with only a
-	// single replica. This is a corner case which could happen in practice and
-	// also affects tests.
-	if len(existingReplicas) > 1 {
-		var numLiveReplicas int
-		for _, s := range sl.stores {
-			for _, repl := range existingReplicas {
-				if s.StoreID == repl.StoreID {
-					numLiveReplicas++
-					break
+	var existingReplicas []roachpb.ReplicaDescriptor
+	var analyzedConstraints constraint.AnalyzedConstraints
+	var localities map[roachpb.NodeID]roachpb.Locality
+	switch targetType {
+	case voterTarget:
+		existingReplicas = existingVoters
+		sl = sl.excludeReplicaNodes(existingNonVoters, false /* keepReplicaStores */)
+
+		// We're going to add another replica to the range which will change the
+		// quorum size. Verify that the number of existing live replicas is
+		// sufficient to meet the new quorum. For a range configured for 3
+		// replicas, this will disable rebalancing if one of the replicas is on a
+		// down node. Instead, we'll have to wait for the down node to be declared
+		// dead and go through the dead-node removal dance: remove dead replica,
+		// add new replica.
+		//
+		// NB: The len(replicas) > 1 check allows rebalancing of ranges with only a
+		// single replica. This is a corner case which could happen in practice and
+		// also affects tests.
+		if len(existingReplicas) > 1 {
+			var numLiveReplicas int
+			for _, s := range sl.stores {
+				for _, repl := range existingReplicas {
+					if s.StoreID == repl.StoreID {
+						numLiveReplicas++
+						break
+					}
 				}
 			}
+			newQuorum := computeQuorum(len(existingReplicas) + 1)
+			if numLiveReplicas < newQuorum {
+				// Don't rebalance as we won't be able to make quorum after the rebalance
+				// until the new replica has been caught up.
+				return zero, zero, "", false
+			}
 		}
-		newQuorum := computeQuorum(len(existingReplicas) + 1)
-		if numLiveReplicas < newQuorum {
-			// Don't rebalance as we won't be able to make quorum after the rebalance
-			// until the new replica has been caught up.
-			return zero, zero, "", false
-		}
+
+		analyzedConstraints = constraint.AnalyzeVoterConstraints(
+			ctx, a.storePool.getStoreDescriptor, existingVoters, zone)
+		localities = a.storePool.getLocalities(existingVoters)
+	case nonVoterTarget:
+		// Non-voters don't participate in quorum, so there's no need to check
+		// their liveness. Their constraints and diversity are judged across all
+		// of the range's replicas.
+		existingReplicas = existingNonVoters
+		sl = sl.excludeReplicaNodes(existingVoters, false /* keepReplicaStores */)
+		allReplicas := append(append([]roachpb.ReplicaDescriptor(nil), existingVoters...),
+			existingNonVoters...)
+		analyzedConstraints = constraint.AnalyzeConstraints(
+			ctx, a.storePool.getStoreDescriptor, allReplicas, zone)
+		localities = a.storePool.getLocalities(allReplicas)
+	default:
+		log.Fatalf(ctx, "unexpected target replica type %d", targetType)
 	}
 
-	analyzedConstraints := constraint.AnalyzeConstraints(
-		ctx, a.storePool.getStoreDescriptor, existingReplicas, zone)
 	options := a.scorerOptions()
 	results := rebalanceCandidates(
 		ctx,
 		sl,
 		analyzedConstraints,
 		existingReplicas,
-		a.storePool.getLocalities(existingReplicas),
+		localities,
 		a.storePool.getNodeLocalityString,
 		options,
 	)
@@ -689,7 +938,10 @@ func (a Allocator) RebalanceTarget(
 		newReplica := roachpb.ReplicaDescriptor{
 			NodeID:    target.store.Node.NodeID,
 			StoreID:   target.store.StoreID,
-			ReplicaID: maxReplicaID(existingReplicas) + 1,
+			ReplicaID: maxReplicaID(existingVoters) + 1,
+		}
+		if id := maxReplicaID(existingNonVoters); id >= newReplica.ReplicaID {
+			newReplica.ReplicaID = id + 1
 		}
 		// Deep-copy the Replicas slice since we'll mutate it below.
 		existingPlusOneNew := append([]roachpb.ReplicaDescriptor(nil), existingReplicas...)
@@ -699,7 +951,7 @@ func (a Allocator) RebalanceTarget(
 		// If we can't (e.g. because we're the leaseholder but not the raft leader),
 		// it's better to simulate the removal with the info that we do have than to
 		// assume that the rebalance is ok (#20241).
-		if raftStatus != nil && raftStatus.Progress != nil {
+		if targetType == voterTarget && raftStatus != nil && raftStatus.Progress != nil {
 			replicaCandidates = simulateFilterUnremovableReplicas(
 				ctx, raftStatus, replicaCandidates, newReplica.ReplicaID)
 		}
@@ -710,6 +962,10 @@ func (a Allocator) RebalanceTarget(
 			return zero, zero, "", false
 		}
 
+		simulatedVoters, simulatedNonVoters := existingPlusOneNew, existingNonVoters
+		if targetType == nonVoterTarget {
+			simulatedVoters, simulatedNonVoters = existingVoters, existingPlusOneNew
+		}
 		var removeDetails string
 		var err error
 		removeReplica, removeDetails, err = a.simulateRemoveTarget(
@@ -717,8 +973,10 @@ func (a Allocator) RebalanceTarget(
 			target.store.StoreID,
 			zone,
 			replicaCandidates,
-			existingPlusOneNew,
+			simulatedVoters,
+			simulatedNonVoters,
 			rangeUsageInfo,
+			targetType,
 		)
 		if err != nil {
 			log.Warningf(ctx, "simulating RemoveTarget failed: %+v", err)
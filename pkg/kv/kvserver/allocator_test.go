@@ -392,6 +392,7 @@ func TestAllocatorSimpleRetrieval(t *testing.T) {
 		context.Background(),
 		&simpleZoneConfig,
 		[]roachpb.ReplicaDescriptor{},
+		nil, /* existingNonVoters */
 	)
 	if err != nil {
 		t.Fatalf("Unable to perform allocation: %+v", err)
@@ -410,6 +411,7 @@ func TestAllocatorNoAvailableDisks(t *testing.T) {
 		context.Background(),
 		&simpleZoneConfig,
 		[]roachpb.ReplicaDescriptor{},
+		nil, /* existingNonVoters */
 	)
 	if result != nil {
 		t.Errorf("expected nil result: %+v", result)
@@ -430,6 +432,7 @@ func TestAllocatorTwoDatacenters(t *testing.T) {
 		ctx,
 		&multiDCConfig,
 		[]roachpb.ReplicaDescriptor{},
+		nil, /* existingNonVoters */
 	)
 	if err != nil {
 		t.Fatalf("Unable to perform allocation: %+v", err)
@@ -441,6 +444,7 @@ func TestAllocatorTwoDatacenters(t *testing.T) {
 			NodeID:  result1.Node.NodeID,
 			StoreID: result1.StoreID,
 		}},
+		nil, /* existingNonVoters */
 	)
 	if err != nil {
 		t.Fatalf("Unable to perform allocation: %+v", err)
@@ -464,6 +468,7 @@ func TestAllocatorTwoDatacenters(t *testing.T) {
 				StoreID: result2.StoreID,
 			},
 		},
+		nil, /* existingNonVoters */
 	)
 	if err == nil {
 		t.Errorf("expected error on allocation without available stores: %+v", result3)
@@ -495,6 +500,7 @@ func TestAllocatorExistingReplica(t *testing.T) {
 				StoreID: 2,
 			},
 		},
+		nil, /* existingNonVoters */
 	)
 	if err != nil {
 		t.Fatalf("Unable to perform allocation: %+v", err)
@@ -592,6 +598,7 @@ func TestAllocatorMultipleStoresPerNode(t *testing.T) {
 				context.Background(),
 				zonepb.EmptyCompleteZoneConfig(),
 				tc.existing,
+				nil, /* existingNonVoters */
 			)
 			if e, a := tc.expectTarget, result != nil; e != a {
 				t.Errorf("AllocateTarget(%v) got target %v, err %v; expectTarget=%v",
@@ -606,6 +613,7 @@ func TestAllocatorMultipleStoresPerNode(t *testing.T) {
 				zonepb.EmptyCompleteZoneConfig(),
 				nil, /* raftStatus */
 				tc.existing,
+				nil, /* existingNonVoters */
 				rangeUsageInfo,
 				storeFilterThrottled,
 			)
@@ -679,6 +687,7 @@ func TestAllocatorRebalance(t *testing.T) {
 			zonepb.EmptyCompleteZoneConfig(),
 			nil,
 			[]roachpb.ReplicaDescriptor{{NodeID: 3, StoreID: 3}},
+			nil, /* existingNonVoters */
 			rangeUsageInfo,
 			storeFilterThrottled,
 		)
@@ -829,6 +838,7 @@ func TestAllocatorRebalanceTarget(t *testing.T) {
 			zonepb.EmptyCompleteZoneConfig(),
 			status,
 			replicas,
+			nil, /* existingNonVoters */
 			rangeUsageInfo,
 			storeFilterThrottled,
 		)
@@ -849,6 +859,7 @@ func TestAllocatorRebalanceTarget(t *testing.T) {
 			zonepb.EmptyCompleteZoneConfig(),
 			status,
 			replicas,
+			nil, /* existingNonVoters */
 			rangeUsageInfo,
 			storeFilterThrottled,
 		)
@@ -866,6 +877,7 @@ func TestAllocatorRebalanceTarget(t *testing.T) {
 			zonepb.EmptyCompleteZoneConfig(),
 			status,
 			replicas,
+			nil, /* existingNonVoters */
 			rangeUsageInfo,
 			storeFilterThrottled,
 		)
@@ -945,6 +957,7 @@ func TestAllocatorRebalanceDeadNodes(t *testing.T) {
 				zonepb.EmptyCompleteZoneConfig(),
 				nil,
 				c.existing,
+				nil, /* existingNonVoters */
 				rangeUsageInfo,
 				storeFilterThrottled)
 			if c.expected > 0 {
@@ -1139,6 +1152,7 @@ func TestAllocatorRebalanceByCount(t *testing.T) {
 			zonepb.EmptyCompleteZoneConfig(),
 			nil,
 			[]roachpb.ReplicaDescriptor{{StoreID: stores[0].StoreID}},
+			nil, /* existingNonVoters */
 			rangeUsageInfo,
 			storeFilterThrottled,
 		)
@@ -1420,6 +1434,7 @@ func TestAllocatorRebalanceDifferentLocalitySizes(t *testing.T) {
 			zonepb.EmptyCompleteZoneConfig(),
 			nil, /* raftStatus */
 			tc.existing,
+			nil, /* existingNonVoters */
 			rangeUsageInfo,
 			storeFilterThrottled,
 		)
@@ -1490,6 +1505,7 @@ func TestAllocatorRebalanceDifferentLocalitySizes(t *testing.T) {
 			zonepb.EmptyCompleteZoneConfig(),
 			nil, /* raftStatus */
 			tc.existing,
+			nil, /* existingNonVoters */
 			rangeUsageInfo,
 			storeFilterThrottled,
 		)
@@ -2066,6 +2082,7 @@ func TestAllocatorAllocateTargetLocality(t *testing.T) {
 			context.Background(),
 			zonepb.EmptyCompleteZoneConfig(),
 			existingRepls,
+			nil, /* existingNonVoters */
 		)
 		if err != nil {
 			t.Fatal(err)
@@ -2188,6 +2205,7 @@ func TestAllocatorRebalanceTargetLocality(t *testing.T) {
 			zonepb.EmptyCompleteZoneConfig(),
 			nil,
 			existingRepls,
+			nil, /* existingNonVoters */
 			rangeUsageInfo,
 			storeFilterThrottled,
 		)
@@ -3581,7 +3599,7 @@ func TestRebalanceCandidatesNumReplicasConstraints(t *testing.T) {
 			// Also verify that RebalanceTarget picks out one of the best options as
 			// the final rebalance choice.
 			target, _, details, ok := a.RebalanceTarget(
-				context.Background(), zone, nil, existingRepls, rangeUsageInfo, storeFilterThrottled)
+				context.Background(), zone, nil, existingRepls, nil /* existingNonVoters */, rangeUsageInfo, storeFilterThrottled)
 			var found bool
 			if !ok && len(tc.validTargets) == 0 {
 				found = true
@@ -4795,6 +4813,143 @@ func TestAllocatorRemoveLearner(t *testing.T) {
 	require.Equal(t, AllocatorRemoveLearner, action)
 }
 
+func TestAllocatorComputeActionWithNonVoters(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	zone := zonepb.ZoneConfig{
+		NumReplicas: proto.Int32(5),
+		NumVoters:   proto.Int32(3),
+	}
+	nonVoterType := roachpb.NON_VOTER
+	makeDesc := func(voters, nonVoters []roachpb.StoreID) roachpb.RangeDescriptor {
+		desc := makeDescriptor(append(append([]roachpb.StoreID(nil), voters...), nonVoters...))
+		for i := len(voters); i < len(desc.InternalReplicas); i++ {
+			desc.InternalReplicas[i].Type = &nonVoterType
+		}
+		return desc
+	}
+
+	testCases := []struct {
+		name            string
+		numNodes        int
+		voters          []roachpb.StoreID
+		nonVoters       []roachpb.StoreID
+		live            []roachpb.StoreID
+		dead            []roachpb.StoreID
+		decommissioning []roachpb.StoreID
+		expectedAction  AllocatorAction
+	}{
+		{
+			name:           "missing voter takes precedence over missing non-voters",
+			numNodes:       6,
+			voters:         []roachpb.StoreID{1, 2},
+			live:           []roachpb.StoreID{1, 2, 3, 4, 5},
+			expectedAction: AllocatorAdd,
+		},
+		{
+			name:           "missing non-voter",
+			numNodes:       6,
+			voters:         []roachpb.StoreID{1, 2, 3},
+			nonVoters:      []roachpb.StoreID{4},
+			live:           []roachpb.StoreID{1, 2, 3, 4, 5},
+			expectedAction: AllocatorAddNonVoter,
+		},
+		{
+			name:           "not enough nodes for more non-voters",
+			numNodes:       4,
+			voters:         []roachpb.StoreID{1, 2, 3},
+			nonVoters:      []roachpb.StoreID{4},
+			live:           []roachpb.StoreID{1, 2, 3, 4},
+			expectedAction: AllocatorConsiderRebalance,
+		},
+		{
+			name:           "extra non-voter",
+			numNodes:       6,
+			voters:         []roachpb.StoreID{1, 2, 3},
+			nonVoters:      []roachpb.StoreID{4, 5, 6},
+			live:           []roachpb.StoreID{1, 2, 3, 4, 5, 6},
+			expectedAction: AllocatorRemoveNonVoter,
+		},
+		{
+			name:           "dead non-voter",
+			numNodes:       6,
+			voters:         []roachpb.StoreID{1, 2, 3},
+			nonVoters:      []roachpb.StoreID{4, 5},
+			live:           []roachpb.StoreID{1, 2, 3, 4, 6},
+			dead:           []roachpb.StoreID{5},
+			expectedAction: AllocatorRemoveDeadNonVoter,
+		},
+		{
+			name:            "decommissioning non-voter",
+			numNodes:        6,
+			voters:          []roachpb.StoreID{1, 2, 3},
+			nonVoters:       []roachpb.StoreID{4, 5},
+			live:            []roachpb.StoreID{1, 2, 3, 4, 6},
+			decommissioning: []roachpb.StoreID{5},
+			expectedAction:  AllocatorRemoveDecommissioningNonVoter,
+		},
+		{
+			name:           "dead voter takes precedence over dead non-voter",
+			numNodes:       6,
+			voters:         []roachpb.StoreID{1, 2, 3},
+			nonVoters:      []roachpb.StoreID{4, 5},
+			live:           []roachpb.StoreID{1, 2, 4, 6},
+			dead:           []roachpb.StoreID{3, 5},
+			expectedAction: AllocatorReplaceDead,
+		},
+		{
+			name:           "fully replicated",
+			numNodes:       6,
+			voters:         []roachpb.StoreID{1, 2, 3},
+			nonVoters:      []roachpb.StoreID{4, 5},
+			live:           []roachpb.StoreID{1, 2, 3, 4, 5},
+			expectedAction: AllocatorConsiderRebalance,
+		},
+	}
+
+	ctx := context.Background()
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			stopper, _, sp, a, _ := createTestAllocator(tc.numNodes, false /* deterministic */)
+			defer stopper.Stop(ctx)
+			mockStorePool(sp, tc.live, nil, tc.dead, tc.decommissioning, nil)
+			desc := makeDesc(tc.voters, tc.nonVoters)
+			action, _ := a.ComputeAction(ctx, &zone, &desc)
+			require.Equal(t, tc.expectedAction.String(), action.String())
+		})
+	}
+}
+
+func TestAllocatorAllocateTargetPrefersNonVoters(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	stopper, _, sp, a, _ := createTestAllocator(10, true /* deterministic */)
+	ctx := context.Background()
+	defer stopper.Stop(ctx)
+	mockStorePool(sp, []roachpb.StoreID{1, 2, 3, 4, 5}, nil, nil, nil, nil)
+
+	zone := zonepb.ZoneConfig{
+		NumReplicas: proto.Int32(5),
+		NumVoters:   proto.Int32(3),
+	}
+	voters := []roachpb.ReplicaDescriptor{
+		{NodeID: 1, StoreID: 1, ReplicaID: 1},
+		{NodeID: 2, StoreID: 2, ReplicaID: 2},
+	}
+	nonVoters := []roachpb.ReplicaDescriptor{
+		{NodeID: 4, StoreID: 4, ReplicaID: 4},
+	}
+	// All stores are equally good, so the non-voter should be promoted.
+	target, _, err := a.AllocateTarget(ctx, &zone, voters, nonVoters)
+	require.NoError(t, err)
+	require.Equal(t, roachpb.StoreID(4), target.StoreID)
+
+	// A new non-voter must not be placed on a node that has any replica.
+	target, _, err = a.AllocateNonVoter(ctx, &zone, voters, nonVoters)
+	require.NoError(t, err)
+	require.Contains(t, []roachpb.StoreID{3, 5}, target.StoreID)
+}
+
 func TestAllocatorComputeActionDynamicNumReplicas(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 
@@ -5056,6 +5211,30 @@ func TestAllocatorGetNeededReplicas(t *testing.T) {
 	}
 }
 
+func TestAllocatorGetNeededNonVoters(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	testCases := []struct {
+		numVoters, zoneNonVoters, availNodes int
+		expected                             int
+	}{
+		{3, 0, 5, 0},
+		{3, 2, 5, 2},
+		{3, 2, 4, 1},
+		{3, 2, 3, 0},
+		{3, 2, 2, 0},
+		{1, 4, 10, 4},
+	}
+
+	for _, tc := range testCases {
+		if e, a := tc.expected, GetNeededNonVoters(tc.numVoters, tc.zoneNonVoters, tc.availNodes); e != a {
+			t.Errorf(
+				"GetNeededNonVoters(numVoters=%d, zoneNonVoters=%d, availNodes=%d) got %d; want %d",
+				tc.numVoters, tc.zoneNonVoters, tc.availNodes, a, e)
+		}
+	}
+}
+
 func makeDescriptor(storeList []roachpb.StoreID) roachpb.RangeDescriptor {
 	desc := roachpb.RangeDescriptor{
 		EndKey: roachpb.RKey(keys.SystemPrefix),
@@ -5150,6 +5329,7 @@ func TestAllocatorThrottled(t *testing.T) {
 		ctx,
 		&simpleZoneConfig,
 		[]roachpb.ReplicaDescriptor{},
+		nil, /* existingNonVoters */
 	)
 	if !errors.HasInterface(err, (*purgatoryError)(nil)) {
 		t.Fatalf("expected a purgatory error, got: %+v", err)
@@ -5161,6 +5341,7 @@ func TestAllocatorThrottled(t *testing.T) {
 		ctx,
 		&simpleZoneConfig,
 		[]roachpb.ReplicaDescriptor{},
+		nil, /* existingNonVoters */
 	)
 	if err != nil {
 		t.Fatalf("unable to perform allocation: %+v", err)
@@ -5182,6 +5363,7 @@ func TestAllocatorThrottled(t *testing.T) {
 		ctx,
 		&simpleZoneConfig,
 		[]roachpb.ReplicaDescriptor{},
+		nil, /* existingNonVoters */
 	)
 	if errors.HasInterface(err, (*purgatoryError)(nil)) {
 		t.Fatalf("expected a non purgatory error, got: %+v", err)
@@ -5502,6 +5684,7 @@ func TestAllocatorRebalanceAway(t *testing.T) {
 				&zonepb.ZoneConfig{NumReplicas: proto.Int32(0), Constraints: []zonepb.ConstraintsConjunction{constraints}},
 				nil,
 				existingReplicas,
+				nil, /* existingNonVoters */
 				rangeUsageInfo,
 				storeFilterThrottled,
 			)
@@ -5667,6 +5850,7 @@ func TestAllocatorFullDisks(t *testing.T) {
 						zonepb.EmptyCompleteZoneConfig(),
 						nil,
 						[]roachpb.ReplicaDescriptor{{NodeID: ts.Node.NodeID, StoreID: ts.StoreID}},
+						nil, /* existingNonVoters */
 						rangeUsageInfo,
 						storeFilterThrottled,
 					)
@@ -5795,6 +5979,7 @@ func Example_rebalancing() {
 				zonepb.EmptyCompleteZoneConfig(),
 				nil,
 				[]roachpb.ReplicaDescriptor{{NodeID: ts.Node.NodeID, StoreID: ts.StoreID}},
+				nil, /* existingNonVoters */
 				rangeUsageInfo,
 				storeFilterThrottled,
 			)
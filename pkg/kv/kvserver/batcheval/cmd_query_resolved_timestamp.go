@@ -0,0 +1,73 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package batcheval
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/batcheval/result"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/spanset"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/storage"
+)
+
+func init() {
+	RegisterReadOnlyCommand(roachpb.QueryResolvedTimestamp, declareKeysQueryResolvedTimestamp, QueryResolvedTimestamp)
+}
+
+func declareKeysQueryResolvedTimestamp(
+	_ *roachpb.RangeDescriptor,
+	header roachpb.Header,
+	req roachpb.Request,
+	latchSpans, _ *spanset.SpanSet,
+) {
+	// The closed timestamp is only valid under the lease it was closed for.
+	latchSpans.AddNonMVCC(spanset.SpanReadOnly, roachpb.Span{Key: keys.RangeLeaseKey(header.RangeID)})
+	latchSpans.AddNonMVCC(spanset.SpanReadOnly, req.Header().Span())
+}
+
+// QueryResolvedTimestamp requests the resolved timestamp of the key span it is
+// issued over. The resolved timestamp is the replica's closed timestamp, held
+// back below the timestamp of any intent in the span that has not yet been
+// resolved. Reads at or below it can be served by this replica without
+// blocking.
+func QueryResolvedTimestamp(
+	ctx context.Context, reader storage.Reader, cArgs CommandArgs, resp roachpb.Response,
+) (result.Result, error) {
+	args := cArgs.Args.(*roachpb.QueryResolvedTimestampRequest)
+	reply := resp.(*roachpb.QueryResolvedTimestampResponse)
+
+	closedTS := cArgs.EvalCtx.GetClosedTimestamp(ctx)
+	if closedTS.IsEmpty() {
+		// The range does not close timestamps, so nothing below the present
+		// time is guaranteed to be immutable.
+		return result.Result{}, nil
+	}
+
+	// Intents above the closed timestamp are ignored by the inconsistent scan,
+	// which is fine because they don't affect the resolved timestamp.
+	endKey := args.EndKey
+	if len(endKey) == 0 {
+		endKey = args.Key.Next()
+	}
+	res, err := storage.MVCCScanToBytes(
+		ctx, reader, args.Key, endKey, closedTS, storage.MVCCScanOptions{Inconsistent: true},
+	)
+	if err != nil {
+		return result.Result{}, err
+	}
+	reply.ResolvedTS = closedTS
+	for i := range res.Intents {
+		reply.ResolvedTS.Backward(res.Intents[i].Txn.WriteTimestamp.Prev())
+	}
+	return result.Result{}, nil
+}
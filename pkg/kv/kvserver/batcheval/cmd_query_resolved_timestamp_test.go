@@ -0,0 +1,84 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package batcheval
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/storage"
+	"github.com/cockroachdb/cockroach/pkg/storage/enginepb"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryResolvedTimestamp(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	db := storage.NewDefaultInMem()
+	defer db.Close()
+
+	ts := func(wallTime int64) hlc.Timestamp { return hlc.Timestamp{WallTime: wallTime} }
+	v := roachpb.MakeValueFromString("v")
+	writeIntent := func(k string, wallTime int64) {
+		txn := &roachpb.Transaction{
+			TxnMeta: enginepb.TxnMeta{
+				Key:            roachpb.Key(k),
+				ID:             uuid.MakeV4(),
+				WriteTimestamp: ts(wallTime),
+			},
+			ReadTimestamp: ts(wallTime),
+		}
+		require.NoError(t, storage.MVCCPut(ctx, db, nil, roachpb.Key(k), ts(wallTime), v, txn))
+	}
+	require.NoError(t, storage.MVCCPut(ctx, db, nil, roachpb.Key("a"), ts(5), v, nil))
+	writeIntent("b", 20)
+	writeIntent("c", 12)
+	writeIntent("d", 30)
+
+	testCases := []struct {
+		name     string
+		closedTS hlc.Timestamp
+		key      string
+		endKey   string
+		expected hlc.Timestamp
+	}{
+		{"no closed timestamp", hlc.Timestamp{}, "a", "e", hlc.Timestamp{}},
+		{"no intents", ts(25), "a", "b", ts(25)},
+		{"point with intent", ts(25), "b", "", ts(20).Prev()},
+		{"point without intent", ts(25), "a", "", ts(25)},
+		{"oldest intent", ts(25), "a", "e", ts(12).Prev()},
+		{"intent above closed timestamp", ts(25), "d", "e", ts(25)},
+		{"closed timestamp below intents", ts(10), "a", "e", ts(10)},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			evalCtx := &MockEvalCtx{ClosedTimestamp: tc.closedTS}
+			req := &roachpb.QueryResolvedTimestampRequest{
+				RequestHeader: roachpb.RequestHeader{Key: roachpb.Key(tc.key)},
+			}
+			if tc.endKey != "" {
+				req.EndKey = roachpb.Key(tc.endKey)
+			}
+			var resp roachpb.QueryResolvedTimestampResponse
+			_, err := QueryResolvedTimestamp(ctx, db, CommandArgs{
+				EvalCtx: evalCtx.EvalContext(),
+				Args:    req,
+			}, &resp)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, resp.ResolvedTS)
+		})
+	}
+}
@@ -93,6 +93,11 @@ type EvalContext interface {
 	GetLastReplicaGCTimestamp(context.Context) (hlc.Timestamp, error)
 	GetLease() (roachpb.Lease, roachpb.Lease)
 
+	// GetClosedTimestamp returns the closed timestamp of the range, at or below
+	// which the replica can serve reads without the lease. A zero timestamp is
+	// returned if the range does not close timestamps.
+	GetClosedTimestamp(ctx context.Context) hlc.Timestamp
+
 	GetExternalStorage(ctx context.Context, dest roachpb.ExternalStorage) (cloud.ExternalStorage, error)
 	GetExternalStorageFromURI(ctx context.Context, uri string) (cloud.ExternalStorage, error)
 }
@@ -111,6 +116,7 @@ type MockEvalCtx struct {
 	Term, FirstIndex uint64
 	CanCreateTxn     func() (bool, hlc.Timestamp, roachpb.TransactionAbortedReason)
 	Lease            roachpb.Lease
+	ClosedTimestamp  hlc.Timestamp
 }
 
 // EvalContext returns the MockEvalCtx as an EvalContext. It will reflect future
@@ -202,6 +208,9 @@ func (m *mockEvalCtxImpl) GetLastReplicaGCTimestamp(context.Context) (hlc.Timest
 func (m *mockEvalCtxImpl) GetLease() (roachpb.Lease, roachpb.Lease) {
 	return m.Lease, roachpb.Lease{}
 }
+func (m *mockEvalCtxImpl) GetClosedTimestamp(context.Context) hlc.Timestamp {
+	return m.ClosedTimestamp
+}
 
 func (m *mockEvalCtxImpl) GetExternalStorage(
 	ctx context.Context, dest roachpb.ExternalStorage,
@@ -40,23 +40,55 @@ type AnalyzedConstraints struct {
 // range along with the current replicas for a range, spitting back out
 // information about which constraints are satisfied by which replicas and
 // which replicas satisfy which constraints, aiding in allocation decisions.
+//
+// The zone's constraints apply to all of the range's replicas, voting and
+// non-voting alike. See AnalyzeVoterConstraints for the constraints that apply
+// only to the voting replicas.
 func AnalyzeConstraints(
 	ctx context.Context,
 	getStoreDescFn func(roachpb.StoreID) (roachpb.StoreDescriptor, bool),
 	existing []roachpb.ReplicaDescriptor,
 	zone *zonepb.ZoneConfig,
+) AnalyzedConstraints {
+	return analyzeConstraints(ctx, getStoreDescFn, existing, *zone.NumReplicas, zone.Constraints)
+}
+
+// AnalyzeVoterConstraints is like AnalyzeConstraints, but analyzes the
+// constraints that the supplied voting replicas must satisfy. These are the
+// zone's voter_constraints if it has any, and otherwise its constraints, in
+// which case the per-replica counts are interpreted against the number of
+// voters.
+func AnalyzeVoterConstraints(
+	ctx context.Context,
+	getStoreDescFn func(roachpb.StoreID) (roachpb.StoreDescriptor, bool),
+	existingVoters []roachpb.ReplicaDescriptor,
+	zone *zonepb.ZoneConfig,
+) AnalyzedConstraints {
+	constraints := zone.Constraints
+	if len(zone.VoterConstraints) > 0 {
+		constraints = zone.VoterConstraints
+	}
+	return analyzeConstraints(ctx, getStoreDescFn, existingVoters, zone.GetNumVoters(), constraints)
+}
+
+func analyzeConstraints(
+	ctx context.Context,
+	getStoreDescFn func(roachpb.StoreID) (roachpb.StoreDescriptor, bool),
+	existing []roachpb.ReplicaDescriptor,
+	numReplicas int32,
+	constraints []zonepb.ConstraintsConjunction,
 ) AnalyzedConstraints {
 	result := AnalyzedConstraints{
-		Constraints: zone.Constraints,
+		Constraints: constraints,
 	}
 
-	if len(zone.Constraints) > 0 {
-		result.SatisfiedBy = make([][]roachpb.StoreID, len(zone.Constraints))
+	if len(constraints) > 0 {
+		result.SatisfiedBy = make([][]roachpb.StoreID, len(constraints))
 		result.Satisfies = make(map[roachpb.StoreID][]int)
 	}
 
 	var constrainedReplicas int32
-	for i, subConstraints := range zone.Constraints {
+	for i, subConstraints := range constraints {
 		constrainedReplicas += subConstraints.NumReplicas
 		for _, repl := range existing {
 			// If for some reason we don't have the store descriptor (which shouldn't
@@ -70,7 +102,7 @@ func AnalyzeConstraints(
 			}
 		}
 	}
-	if constrainedReplicas > 0 && constrainedReplicas < *zone.NumReplicas {
+	if constrainedReplicas > 0 && constrainedReplicas < numReplicas {
 		result.UnconstrainedReplicas = true
 	}
 	return result
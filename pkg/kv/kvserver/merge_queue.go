@@ -275,19 +275,26 @@ func (mq *mergeQueue) process(
 	}
 	lhsReplicas, rhsReplicas := lhsDesc.Replicas().All(), rhsDesc.Replicas().All()
 
-	// Defensive sanity check that everything is now a voter.
+	// Defensive sanity check that everything is now a voter or a non-voter.
 	for i := range lhsReplicas {
-		if lhsReplicas[i].GetType() != roachpb.VOTER_FULL {
-			return errors.Errorf(`cannot merge non-voter replicas on lhs: %v`, lhsReplicas)
+		if typ := lhsReplicas[i].GetType(); typ != roachpb.VOTER_FULL && typ != roachpb.NON_VOTER {
+			return errors.Errorf(`cannot merge %s replicas on lhs: %v`, typ, lhsReplicas)
 		}
 	}
 	for i := range rhsReplicas {
-		if rhsReplicas[i].GetType() != roachpb.VOTER_FULL {
-			return errors.Errorf(`cannot merge non-voter replicas on rhs: %v`, rhsReplicas)
+		if typ := rhsReplicas[i].GetType(); typ != roachpb.VOTER_FULL && typ != roachpb.NON_VOTER {
+			return errors.Errorf(`cannot merge %s replicas on rhs: %v`, typ, rhsReplicas)
 		}
 	}
 
 	if !replicaSetsEqual(lhsReplicas, rhsReplicas) {
+		// TODO(aayush): AdminRelocateRange only knows how to place voters. Until
+		// it learns about non-voters, we can't colocate ranges that have them.
+		if len(lhsDesc.Replicas().NonVoters()) > 0 || len(rhsDesc.Replicas().NonVoters()) > 0 {
+			return errors.Errorf(
+				`cannot colocate replicas of ranges with non-voting replicas: lhs=%v rhs=%v`,
+				lhsReplicas, rhsReplicas)
+		}
 		var targets []roachpb.ReplicationTarget
 		for _, lhsReplDesc := range lhsReplicas {
 			targets = append(targets, roachpb.ReplicationTarget{
@@ -112,12 +112,16 @@ func (rq *raftSnapshotQueue) processRaftSnapshot(
 	// A learner replica is either getting a snapshot of type LEARNER by the node
 	// that's adding it or it's been orphaned and it's about to be cleaned up by
 	// the replicate queue. Either way, no point in also sending it a snapshot of
-	// type RAFT.
-	if repDesc.GetType() == roachpb.LEARNER {
+	// type RAFT. Non-voters are sent their initial snapshot the same way, so
+	// the same reasoning applies while that snapshot is in flight; after that,
+	// they're caught up through regular RAFT snapshots like voters.
+	if typ := repDesc.GetType(); typ == roachpb.LEARNER || typ == roachpb.NON_VOTER {
 		if fn := repl.store.cfg.TestingKnobs.ReplicaSkipLearnerSnapshot; fn != nil && fn() {
 			return nil
 		}
-		snapType = SnapshotRequest_LEARNER
+		if typ == roachpb.LEARNER {
+			snapType = SnapshotRequest_LEARNER
+		}
 		if index := repl.getAndGCSnapshotLogTruncationConstraints(timeutil.Now(), repDesc.StoreID); index > 0 {
 			// There is a snapshot being transferred. It's probably a LEARNER snap, so
 			// bail for now and try again later.
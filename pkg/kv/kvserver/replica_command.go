@@ -873,6 +873,16 @@ func IsSnapshotError(err error) bool {
 // The returned RangeDescriptor is the new value of the range's descriptor
 // following the successful commit of the transaction.
 //
+// Non-voting replicas are added and removed through ADD_NON_VOTER and
+// REMOVE_NON_VOTER changes. They are added (and caught up via a snapshot) before
+// step 3 below and removed after it, one at a time. A non-voter is promoted to a
+// voter by pairing an ADD_REPLICA with a REMOVE_NON_VOTER for the same target,
+// and a voter is demoted to a non-voter by pairing a REMOVE_REPLICA with an
+// ADD_NON_VOTER; both are carried out as part of the atomic replication change
+// in step 3, a demoted voter passing through VOTER_DEMOTING_NON_VOTER. When
+// atomic replication changes are disabled, a demotion instead removes the voter
+// and then adds a new non-voter (see unrollReplicationChanges).
+//
 // In general, ChangeReplicas will carry out the following steps.
 //
 // 1. Run a distributed transaction that adds all new replicas as learner replicas.
@@ -964,9 +974,9 @@ func (r *Replica) ChangeReplicas(
 
 	if unroll {
 		// Legacy behavior.
-		for i := range chgs {
+		for _, chgs := range unrollReplicationChanges(chgs) {
 			var err error
-			desc, err = r.changeReplicasImpl(ctx, desc, priority, reason, details, chgs[i:i+1])
+			desc, err = r.changeReplicasImpl(ctx, desc, priority, reason, details, chgs)
 			if err != nil {
 				return nil, err
 			}
@@ -997,8 +1007,11 @@ func (r *Replica) changeReplicasImpl(
 	if err := validateReplicationChanges(desc, chgs); err != nil {
 		return nil, err
 	}
+	promotions, demotions := voterSwaps(chgs)
 
-	if adds := chgs.Additions(); len(adds) > 0 {
+	// Voters that are promoted from non-voters are already caught up and don't
+	// need to go through the learner stage.
+	if adds := excludeTargets(chgs.Additions(), promotions); len(adds) > 0 {
 		// Lock learner snapshots even before we run the ConfChange txn to add them
 		// to prevent a race with the raft snapshot queue trying to send it first.
 		// Note that this lock needs to cover sending the snapshots which happens in
@@ -1024,6 +1037,15 @@ func (r *Replica) changeReplicasImpl(
 		}
 	}
 
+	// Non-voters that are demoted from voters are handled by the atomic
+	// replication change below. All others are added and caught up here.
+	if adds := excludeTargets(chgs.NonVoterAdditions(), demotions); len(adds) > 0 {
+		desc, err = r.addAndInitializeNonVoters(ctx, desc, priority, reason, details, adds)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Catch up any learners, then run the atomic replication change that adds the
 	// final voters and removes any undesirable replicas.
 	desc, err = r.atomicReplicationChange(ctx, desc, priority, reason, details, chgs)
@@ -1038,7 +1060,7 @@ func (r *Replica) changeReplicasImpl(
 		}
 		// Don't leave a learner replica lying around if we didn't succeed in
 		// promoting it to a voter.
-		if targets := chgs.Additions(); len(targets) > 0 {
+		if targets := excludeTargets(chgs.Additions(), promotions); len(targets) > 0 {
 			log.Infof(ctx, "could not promote %v to voter, rolling back: %v", targets, err)
 			for _, target := range targets {
 				r.tryRollBackLearnerReplica(ctx, r.Desc(), target, reason, details)
@@ -1046,9 +1068,61 @@ func (r *Replica) changeReplicasImpl(
 		}
 		return nil, err
 	}
+
+	// Finally, remove the non-voters that weren't promoted. Like learners, they
+	// are removed one at a time.
+	for _, target := range excludeTargets(chgs.NonVoterRemovals(), promotions) {
+		desc, err = execChangeReplicasTxn(
+			ctx, r.store, desc, reason, details,
+			[]internalReplicationChange{{target: target, typ: internalChangeTypeRemove}},
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
 	return desc, err
 }
 
+// addAndInitializeNonVoters adds non-voting replicas to the given targets and
+// sends them their initial snapshot. Any non-voter that can't be initialized
+// is rolled back.
+func (r *Replica) addAndInitializeNonVoters(
+	ctx context.Context,
+	desc *roachpb.RangeDescriptor,
+	priority SnapshotRequest_Priority,
+	reason kvserverpb.RangeLogEventReason,
+	details string,
+	targets []roachpb.ReplicationTarget,
+) (*roachpb.RangeDescriptor, error) {
+	// Non-voters are caught up with a snapshot just like learners, so the same
+	// reasoning about racing with the raft snapshot queue applies here (see
+	// changeReplicasImpl).
+	releaseSnapshotLockFn := r.lockLearnerSnapshot(ctx, targets)
+	defer releaseSnapshotLockFn()
+
+	for _, target := range targets {
+		iChgs := []internalReplicationChange{{target: target, typ: internalChangeTypeAddNonVoter}}
+		var err error
+		desc, err = execChangeReplicasTxn(ctx, r.store, desc, reason, details, iChgs)
+		if err != nil {
+			return nil, err
+		}
+		if fn := r.store.cfg.TestingKnobs.ReplicaSkipLearnerSnapshot; fn != nil && fn() {
+			continue
+		}
+		rDesc, ok := desc.GetReplicaDescriptor(target.StoreID)
+		if !ok {
+			return nil, errors.Errorf("programming error: replica %v not found in %v", target, desc)
+		}
+		if err := r.sendSnapshot(ctx, rDesc, SnapshotRequest_LEARNER, priority); err != nil {
+			log.Infof(ctx, "could not initialize non-voter %v, rolling back: %v", target, err)
+			r.tryRollBackLearnerReplica(ctx, desc, target, reason, details)
+			return nil, err
+		}
+	}
+	return desc, nil
+}
+
 // maybeLeaveAtomicChangeReplicas transitions out of the joint configuration if
 // the descriptor indicates one. This involves running a distributed transaction
 // updating said descriptor, the result of which will be returned. The
@@ -1077,7 +1151,7 @@ func maybeLeaveAtomicChangeReplicas(
 
 // maybeLeaveAtomicChangeReplicasAndRemoveLearners transitions out of the joint
 // config (if there is one), and then removes all learners. After this function
-// returns, all remaining replicas will be of type VOTER_FULL.
+// returns, all remaining replicas will be of type VOTER_FULL or NON_VOTER.
 func maybeLeaveAtomicChangeReplicasAndRemoveLearners(
 	ctx context.Context, store *Store, desc *roachpb.RangeDescriptor,
 ) (*roachpb.RangeDescriptor, error) {
@@ -1120,11 +1194,18 @@ func validateReplicationChanges(
 	desc *roachpb.RangeDescriptor, chgs roachpb.ReplicationChanges,
 ) error {
 	// First make sure that the changes don't self-overlap (i.e. we're not adding
-	// a replica twice, or removing and immediately re-adding it).
+	// a replica twice, or removing and immediately re-adding it). The only
+	// exception are promotions (adding a voter while removing a non-voter) and
+	// demotions (adding a non-voter while removing a voter) on the same store.
 	byNodeID := make(map[roachpb.NodeID]roachpb.ReplicationChange, len(chgs))
+	swaps := make(map[roachpb.NodeID]bool)
 	for _, chg := range chgs {
-		if _, ok := byNodeID[chg.Target.NodeID]; ok {
-			return fmt.Errorf("changes %+v refer to n%d twice", chgs, chg.Target.NodeID)
+		if prev, ok := byNodeID[chg.Target.NodeID]; ok {
+			if !isVoterSwap(prev, chg) || swaps[chg.Target.NodeID] {
+				return fmt.Errorf("changes %+v refer to n%d twice", chgs, chg.Target.NodeID)
+			}
+			swaps[chg.Target.NodeID] = true
+			continue
 		}
 		byNodeID[chg.Target.NodeID] = chg
 	}
@@ -1135,7 +1216,31 @@ func validateReplicationChanges(
 	for _, rDesc := range desc.Replicas().All() {
 		chg, ok := byNodeID[rDesc.NodeID]
 		delete(byNodeID, rDesc.NodeID)
-		if !ok || chg.ChangeType != roachpb.ADD_REPLICA {
+		if !ok {
+			continue
+		}
+		if swaps[rDesc.NodeID] {
+			// A promotion or demotion. The replica being swapped must be present
+			// with the expected type.
+			expTyp := roachpb.NON_VOTER
+			if chg.ChangeType == roachpb.REMOVE_REPLICA || chg.ChangeType == roachpb.ADD_NON_VOTER {
+				expTyp = roachpb.VOTER_FULL
+			}
+			if rDesc.StoreID != chg.Target.StoreID || rDesc.GetType() != expTyp {
+				return errors.Errorf("unable to swap %v; no %s on that store in %s", chg.Target, expTyp, desc)
+			}
+			continue
+		}
+		switch chg.ChangeType {
+		case roachpb.REMOVE_REPLICA:
+			if rDesc.GetType() == roachpb.NON_VOTER {
+				return errors.Errorf("unable to remove non-voting replica %v as a voter in %s", chg.Target, desc)
+			}
+			continue
+		case roachpb.REMOVE_NON_VOTER:
+			if rDesc.GetType() != roachpb.NON_VOTER {
+				return errors.Errorf("unable to remove replica %v as a non-voter in %s", chg.Target, desc)
+			}
 			continue
 		}
 		// We're adding a replica that's already there. This isn't allowed, even
@@ -1153,6 +1258,10 @@ func validateReplicationChanges(
 			return errors.Errorf(
 				"unable to add replica %v which is already present as a learner in %s", chg.Target, desc)
 		}
+		if rDesc.GetType() == roachpb.NON_VOTER {
+			return errors.Errorf(
+				"unable to add replica %v which is already present as a non-voter in %s", chg.Target, desc)
+		}
 
 		// Otherwise, we already had a full voter replica. Can't add another to
 		// this store.
@@ -1160,8 +1269,11 @@ func validateReplicationChanges(
 	}
 
 	// Any removals left in the map now refer to nonexisting replicas, and we refuse them.
-	for _, chg := range byNodeID {
-		if chg.ChangeType != roachpb.REMOVE_REPLICA {
+	for nodeID, chg := range byNodeID {
+		if swaps[nodeID] {
+			return errors.Errorf("unable to swap %v which is not in %s", chg.Target, desc)
+		}
+		if chg.ChangeType != roachpb.REMOVE_REPLICA && chg.ChangeType != roachpb.REMOVE_NON_VOTER {
 			continue
 		}
 		return errors.Errorf("removing %v which is not in %s", chg.Target, desc)
@@ -1169,6 +1281,94 @@ func validateReplicationChanges(
 	return nil
 }
 
+// isVoterSwap returns whether the two changes, which refer to the same node,
+// describe a promotion of a non-voter to a voter or a demotion of a voter to a
+// non-voter on the same store.
+func isVoterSwap(a, b roachpb.ReplicationChange) bool {
+	if a.Target != b.Target {
+		return false
+	}
+	typs := [2]roachpb.ReplicaChangeType{a.ChangeType, b.ChangeType}
+	switch typs {
+	case [2]roachpb.ReplicaChangeType{roachpb.ADD_REPLICA, roachpb.REMOVE_NON_VOTER},
+		[2]roachpb.ReplicaChangeType{roachpb.REMOVE_NON_VOTER, roachpb.ADD_REPLICA},
+		[2]roachpb.ReplicaChangeType{roachpb.REMOVE_REPLICA, roachpb.ADD_NON_VOTER},
+		[2]roachpb.ReplicaChangeType{roachpb.ADD_NON_VOTER, roachpb.REMOVE_REPLICA}:
+		return true
+	}
+	return false
+}
+
+// unrollReplicationChanges splits the given changes into the individual changes
+// that are executed one after the other when atomic replication changes can't
+// be used. A promotion of a non-voter to a voter consists of two changes that
+// can't be separated, but is carried out without a joint configuration. A
+// demotion of a voter to a non-voter, on the other hand, requires a joint
+// configuration, so it is instead unrolled into the removal of the voter
+// followed by the addition of a new non-voter on the same store.
+func unrollReplicationChanges(chgs roachpb.ReplicationChanges) []roachpb.ReplicationChanges {
+	unrolled := make([]roachpb.ReplicationChanges, 0, len(chgs))
+	for i := 0; i < len(chgs); i++ {
+		if i+1 < len(chgs) && isVoterSwap(chgs[i], chgs[i+1]) {
+			rem, add := chgs[i], chgs[i+1]
+			if add.ChangeType == roachpb.REMOVE_REPLICA || add.ChangeType == roachpb.REMOVE_NON_VOTER {
+				rem, add = add, rem
+			}
+			if rem.ChangeType == roachpb.REMOVE_NON_VOTER {
+				unrolled = append(unrolled, chgs[i:i+2])
+			} else {
+				unrolled = append(unrolled, roachpb.ReplicationChanges{rem}, roachpb.ReplicationChanges{add})
+			}
+			i++
+			continue
+		}
+		unrolled = append(unrolled, chgs[i:i+1])
+	}
+	return unrolled
+}
+
+// voterSwaps returns the targets of the changes that promote non-voters to
+// voters and those that demote voters to non-voters.
+func voterSwaps(
+	chgs roachpb.ReplicationChanges,
+) (promotions, demotions map[roachpb.ReplicationTarget]bool) {
+	promotions = make(map[roachpb.ReplicationTarget]bool)
+	demotions = make(map[roachpb.ReplicationTarget]bool)
+	for _, target := range chgs.NonVoterRemovals() {
+		promotions[target] = true
+	}
+	for _, target := range chgs.NonVoterAdditions() {
+		demotions[target] = true
+	}
+	filter := func(m map[roachpb.ReplicationTarget]bool, targets []roachpb.ReplicationTarget) {
+		keep := make(map[roachpb.ReplicationTarget]bool, len(targets))
+		for _, target := range targets {
+			keep[target] = true
+		}
+		for target := range m {
+			if !keep[target] {
+				delete(m, target)
+			}
+		}
+	}
+	filter(promotions, chgs.Additions())
+	filter(demotions, chgs.Removals())
+	return promotions, demotions
+}
+
+// excludeTargets returns the targets that aren't in the given set.
+func excludeTargets(
+	targets []roachpb.ReplicationTarget, exclude map[roachpb.ReplicationTarget]bool,
+) []roachpb.ReplicationTarget {
+	var out []roachpb.ReplicationTarget
+	for _, target := range targets {
+		if !exclude[target] {
+			out = append(out, target)
+		}
+	}
+	return out
+}
+
 // addLearnerReplicas adds learners to the given replication targets.
 func addLearnerReplicas(
 	ctx context.Context,
@@ -1260,8 +1460,14 @@ func (r *Replica) atomicReplicationChange(
 	// both sides.
 
 	iChgs := make([]internalReplicationChange, 0, len(chgs))
+	promotions, demotions := voterSwaps(chgs)
 
 	for _, target := range chgs.Additions() {
+		if promotions[target] {
+			// Non-voters are already caught up and can be promoted right away.
+			iChgs = append(iChgs, internalReplicationChange{target: target, typ: internalChangeTypePromoteNonVoter})
+			continue
+		}
 		iChgs = append(iChgs, internalReplicationChange{target: target, typ: internalChangeTypePromoteLearner})
 		// All adds must be present as learners right now, and we send them
 		// snapshots in anticipation of promoting them to voters.
@@ -1298,7 +1504,7 @@ func (r *Replica) atomicReplicationChange(
 		}
 	}
 
-	if adds := chgs.Additions(); len(adds) > 0 {
+	if adds := excludeTargets(chgs.Additions(), promotions); len(adds) > 0 {
 		if fn := r.store.cfg.TestingKnobs.ReplicaAddStopAfterLearnerSnapshot; fn != nil && fn(adds) {
 			return desc, nil
 		}
@@ -1307,12 +1513,20 @@ func (r *Replica) atomicReplicationChange(
 	canUseDemotion := r.store.ClusterSettings().Version.IsActive(ctx, clusterversion.VersionChangeReplicasDemotion)
 	for _, target := range chgs.Removals() {
 		typ := internalChangeTypeRemove
-		if rDesc, ok := desc.GetReplicaDescriptor(target.StoreID); ok && rDesc.GetType() == roachpb.VOTER_FULL && canUseDemotion {
+		if demotions[target] {
+			typ = internalChangeTypeDemoteVoterToNonVoter
+		} else if rDesc, ok := desc.GetReplicaDescriptor(target.StoreID); ok && rDesc.GetType() == roachpb.VOTER_FULL && canUseDemotion {
 			typ = internalChangeTypeDemote
 		}
 		iChgs = append(iChgs, internalReplicationChange{target: target, typ: typ})
 	}
 
+	if len(iChgs) == 0 {
+		// Only non-voters are being added or removed, which is handled by the
+		// caller.
+		return desc, nil
+	}
+
 	var err error
 	desc, err = execChangeReplicasTxn(ctx, r.store, desc, reason, details, iChgs)
 	if err != nil {
@@ -1328,10 +1542,11 @@ func (r *Replica) atomicReplicationChange(
 	return maybeLeaveAtomicChangeReplicasAndRemoveLearners(ctx, r.store, desc)
 }
 
-// tryRollbackLearnerReplica attempts to remove a learner specified by the
-// target. If no such learner is found in the descriptor (including when it is a
-// voter instead), no action is taken. Otherwise, a single time-limited
-// best-effort attempt at removing the learner is made.
+// tryRollbackLearnerReplica attempts to remove a learner (or a newly added
+// non-voter) specified by the target. If no such learner is found in the
+// descriptor (including when it is a voter instead), no action is taken.
+// Otherwise, a single time-limited best-effort attempt at removing the learner
+// is made.
 func (r *Replica) tryRollBackLearnerReplica(
 	ctx context.Context,
 	desc *roachpb.RangeDescriptor,
@@ -1340,7 +1555,7 @@ func (r *Replica) tryRollBackLearnerReplica(
 	details string,
 ) {
 	repDesc, ok := desc.GetReplicaDescriptor(target.StoreID)
-	if !ok || repDesc.GetType() != roachpb.LEARNER {
+	if !ok || (repDesc.GetType() != roachpb.LEARNER && repDesc.GetType() != roachpb.NON_VOTER) {
 		// There's no learner to roll back.
 		log.Event(ctx, "learner to roll back not found; skipping")
 		return
@@ -1388,6 +1603,17 @@ const (
 	// voter with them), see:
 	// https://github.com/cockroachdb/cockroach/pull/40268
 	internalChangeTypeRemove
+	// internalChangeTypeAddNonVoter adds a non-voting replica. Like learners,
+	// non-voters are added one at a time.
+	internalChangeTypeAddNonVoter
+	// internalChangeTypePromoteNonVoter changes a non-voter to a voter. The
+	// non-voter is already caught up, so unlike learners it isn't sent a
+	// snapshot before being promoted.
+	internalChangeTypePromoteNonVoter
+	// internalChangeTypeDemoteVoterToNonVoter changes a voter to a non-voter.
+	// Like internalChangeTypeDemote, it requires joint consensus and is treated
+	// like a removal.
+	internalChangeTypeDemoteVoterToNonVoter
 )
 
 // internalReplicationChange is a replication target together with an internal
@@ -1405,7 +1631,8 @@ func (c internalReplicationChanges) leaveJoint() bool { return len(c) == 0 }
 func (c internalReplicationChanges) useJoint() bool {
 	// NB: demotions require joint consensus because of limitations in etcd/raft.
 	// These could be lifted, but it doesn't seem worth it.
-	return len(c) > 1 || c[0].typ == internalChangeTypeDemote
+	return len(c) > 1 || c[0].typ == internalChangeTypeDemote ||
+		c[0].typ == internalChangeTypeDemoteVoterToNonVoter
 }
 
 type storeSettings interface {
@@ -1438,14 +1665,21 @@ func prepareChangeReplicasTrigger(
 			case internalChangeTypeAddLearner:
 				added = append(added,
 					updatedDesc.AddReplica(chg.target.NodeID, chg.target.StoreID, roachpb.LEARNER))
-			case internalChangeTypePromoteLearner:
+			case internalChangeTypeAddNonVoter:
+				added = append(added,
+					updatedDesc.AddReplica(chg.target.NodeID, chg.target.StoreID, roachpb.NON_VOTER))
+			case internalChangeTypePromoteLearner, internalChangeTypePromoteNonVoter:
+				expTyp := roachpb.LEARNER
+				if chg.typ == internalChangeTypePromoteNonVoter {
+					expTyp = roachpb.NON_VOTER
+				}
 				typ := roachpb.VOTER_FULL
 				if useJoint {
 					typ = roachpb.VOTER_INCOMING
 				}
 				rDesc, prevTyp, ok := updatedDesc.SetReplicaType(chg.target.NodeID, chg.target.StoreID, typ)
-				if !ok || prevTyp != roachpb.LEARNER {
-					return nil, errors.Errorf("cannot promote target %v which is missing as Learner", chg.target)
+				if !ok || prevTyp != expTyp {
+					return nil, errors.Errorf("cannot promote target %v which is missing as %s", chg.target, expTyp)
 				}
 				added = append(added, rDesc)
 			case internalChangeTypeRemove:
@@ -1454,7 +1688,7 @@ func prepareChangeReplicasTrigger(
 					return nil, errors.Errorf("target %s not found", chg.target)
 				}
 				prevTyp := rDesc.GetType()
-				if !useJoint || prevTyp == roachpb.LEARNER {
+				if !useJoint || prevTyp == roachpb.LEARNER || prevTyp == roachpb.NON_VOTER {
 					rDesc, _ = updatedDesc.RemoveReplica(chg.target.NodeID, chg.target.StoreID)
 				} else if prevTyp != roachpb.VOTER_FULL {
 					// NB: prevTyp is already known to be VOTER_FULL because of
@@ -1465,10 +1699,14 @@ func prepareChangeReplicasTrigger(
 					rDesc, _, _ = updatedDesc.SetReplicaType(chg.target.NodeID, chg.target.StoreID, roachpb.VOTER_OUTGOING)
 				}
 				removed = append(removed, rDesc)
-			case internalChangeTypeDemote:
+			case internalChangeTypeDemote, internalChangeTypeDemoteVoterToNonVoter:
 				// Demotion is similar to removal, except that a demotion
 				// cannot apply to a learner, and that the resulting type is
 				// different when entering a joint config.
+				newTyp := roachpb.VOTER_DEMOTING
+				if chg.typ == internalChangeTypeDemoteVoterToNonVoter {
+					newTyp = roachpb.VOTER_DEMOTING_NON_VOTER
+				}
 				rDesc, ok := updatedDesc.GetReplicaDescriptor(chg.target.StoreID)
 				if !ok {
 					return nil, errors.Errorf("target %s not found", chg.target)
@@ -1479,9 +1717,9 @@ func prepareChangeReplicasTrigger(
 					return nil, errors.Errorf("demotions require joint consensus")
 				}
 				if prevTyp := rDesc.GetType(); prevTyp != roachpb.VOTER_FULL {
-					return nil, errors.Errorf("cannot transition from %s to VOTER_DEMOTING", prevTyp)
+					return nil, errors.Errorf("cannot transition from %s to %s", prevTyp, newTyp)
 				}
-				rDesc, _, _ = updatedDesc.SetReplicaType(chg.target.NodeID, chg.target.StoreID, roachpb.VOTER_DEMOTING)
+				rDesc, _, _ = updatedDesc.SetReplicaType(chg.target.NodeID, chg.target.StoreID, newTyp)
 				removed = append(removed, rDesc)
 			default:
 				return nil, errors.Errorf("unsupported internal change type %d", chg.typ)
@@ -1504,6 +1742,9 @@ func prepareChangeReplicasTrigger(
 			case roachpb.VOTER_DEMOTING:
 				updatedDesc.SetReplicaType(rDesc.NodeID, rDesc.StoreID, roachpb.LEARNER)
 				isJoint = true
+			case roachpb.VOTER_DEMOTING_NON_VOTER:
+				updatedDesc.SetReplicaType(rDesc.NodeID, rDesc.StoreID, roachpb.NON_VOTER)
+				isJoint = true
 			default:
 			}
 		}
@@ -1621,15 +1862,19 @@ func execChangeReplicasTxn(
 
 		// Log replica change into range event log.
 		for _, tup := range []struct {
-			typ      roachpb.ReplicaChangeType
-			repDescs []roachpb.ReplicaDescriptor
+			typ, nonVoterTyp roachpb.ReplicaChangeType
+			repDescs         []roachpb.ReplicaDescriptor
 		}{
-			{roachpb.ADD_REPLICA, crt.Added()},
-			{roachpb.REMOVE_REPLICA, crt.Removed()},
+			{roachpb.ADD_REPLICA, roachpb.ADD_NON_VOTER, crt.Added()},
+			{roachpb.REMOVE_REPLICA, roachpb.REMOVE_NON_VOTER, crt.Removed()},
 		} {
 			for _, repDesc := range tup.repDescs {
+				typ := tup.typ
+				if repDesc.GetType() == roachpb.NON_VOTER {
+					typ = tup.nonVoterTyp
+				}
 				if err := store.logChange(
-					ctx, txn, tup.typ, repDesc, *crt.Desc, reason, details,
+					ctx, txn, typ, repDesc, *crt.Desc, reason, details,
 				); err != nil {
 					return err
 				}
@@ -2240,6 +2485,7 @@ func (s *Store) relocateOne(
 			storeList,
 			zone,
 			rangeReplicas,
+			nil, /* existingNonVoters */
 			s.allocator.scorerOptions())
 		if targetStore == nil {
 			return nil, nil, fmt.Errorf("none of the remaining targets %v are legal additions to %v",
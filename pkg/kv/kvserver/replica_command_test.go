@@ -89,3 +89,32 @@ func TestRangeDescriptorUpdateProtoChangedAcrossVersions(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestUnrollReplicationChanges(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	n1 := roachpb.ReplicationTarget{NodeID: 1, StoreID: 1}
+	n2 := roachpb.ReplicationTarget{NodeID: 2, StoreID: 2}
+	n3 := roachpb.ReplicationTarget{NodeID: 3, StoreID: 3}
+	chg := func(typ roachpb.ReplicaChangeType, target roachpb.ReplicationTarget) roachpb.ReplicationChange {
+		return roachpb.ReplicationChange{ChangeType: typ, Target: target}
+	}
+
+	chgs := roachpb.ReplicationChanges{
+		chg(roachpb.ADD_REPLICA, n1),
+		// Promotion of n2 from a non-voter to a voter.
+		chg(roachpb.ADD_REPLICA, n2),
+		chg(roachpb.REMOVE_NON_VOTER, n2),
+		// Demotion of n3 from a voter to a non-voter.
+		chg(roachpb.ADD_NON_VOTER, n3),
+		chg(roachpb.REMOVE_REPLICA, n3),
+	}
+	// Promotions stay paired, while demotions are unrolled into the removal of
+	// the voter followed by the addition of the non-voter.
+	require.Equal(t, []roachpb.ReplicationChanges{
+		{chg(roachpb.ADD_REPLICA, n1)},
+		{chg(roachpb.ADD_REPLICA, n2), chg(roachpb.REMOVE_NON_VOTER, n2)},
+		{chg(roachpb.REMOVE_REPLICA, n3)},
+		{chg(roachpb.ADD_NON_VOTER, n3)},
+	}, unrollReplicationChanges(chgs))
+}
@@ -46,16 +46,17 @@ func (r *Replica) canServeFollowerRead(
 		(ba.Txn == nil || !ba.Txn.IsLocking()) && // followerreadsccl.txnCanPerformFollowerRead
 		FollowerReadsEnabled.Get(&r.store.cfg.Settings.SV) {
 
-		// There's no known reason that a non-VOTER_FULL replica couldn't serve follower
-		// reads (or RangeFeed), but as of the time of writing, these are expected
-		// to be short-lived, so it's not worth working out the edge-cases. Revisit if
-		// we add long-lived learners or feel that incoming/outgoing voters also need
-		// to be able to serve follower reads.
+		// Full voters and non-voters can serve follower reads. There's no known
+		// reason that other replica types couldn't serve follower reads (or
+		// RangeFeed), but as of the time of writing, these are expected to be
+		// short-lived, so it's not worth working out the edge-cases. Revisit if we
+		// feel that learners or incoming/outgoing voters also need to be able to
+		// serve follower reads.
 		repDesc, err := r.GetReplicaDescriptor()
 		if err != nil {
 			return roachpb.NewError(err)
 		}
-		if typ := repDesc.GetType(); typ != roachpb.VOTER_FULL {
+		if typ := repDesc.GetType(); typ != roachpb.VOTER_FULL && typ != roachpb.NON_VOTER {
 			log.Eventf(ctx, "%s replicas cannot serve follower reads", typ)
 			return pErr
 		}
@@ -98,6 +99,15 @@ func (r *Replica) canServeFollowerRead(
 	return nil
 }
 
+// GetClosedTimestamp returns the maximum closed timestamp for this range, or a
+// zero timestamp if the range uses an expiration-based lease.
+//
+// GetClosedTimestamp is part of the EvalContext interface.
+func (r *Replica) GetClosedTimestamp(ctx context.Context) hlc.Timestamp {
+	maxClosed, _ := r.maxClosed(ctx)
+	return maxClosed
+}
+
 // maxClosed returns the maximum closed timestamp for this range.
 // It is computed as the most recent of the known closed timestamp for the
 // current lease holder for this range as tracked by the closed timestamp
@@ -15,6 +15,7 @@ import (
 	"math/rand"
 	"time"
 
+	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/abortspan"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/kvserverbase"
@@ -308,8 +309,30 @@ func (r *Replica) setDescLockedRaftMuLocked(ctx context.Context, desc *roachpb.R
 		r.mu.lastReplicaAddedTime = time.Time{}
 	}
 
+	r.maybeAcquireTenantLimiterRaftMuLocked(ctx, desc)
 	r.rangeStr.store(r.mu.replicaID, desc)
 	r.connectionClass.set(rpc.ConnectionClassForKey(desc.StartKey))
 	r.concMgr.OnRangeDescUpdated(desc)
 	r.mu.state.Desc = desc
 }
+
+// maybeAcquireTenantLimiterRaftMuLocked acquires a reference to the rate
+// limiter of the tenant whose data the range holds once the replica is
+// initialized. Ranges are split at tenant boundaries, so the tenant is
+// determined by the start key of the range.
+func (r *Replica) maybeAcquireTenantLimiterRaftMuLocked(
+	ctx context.Context, desc *roachpb.RangeDescriptor,
+) {
+	if r.raftMu.tenantLimiter != nil || !desc.IsInitialized() ||
+		r.store.cfg.TenantRateLimiters == nil {
+		return
+	}
+	_, tenantID, err := keys.DecodeTenantPrefix(desc.StartKey.AsRawKey())
+	if err != nil {
+		log.Fatalf(ctx, "failed to decode tenant prefix of %s: %v", desc.StartKey, err)
+	}
+	if tenantID == roachpb.SystemTenantID {
+		return
+	}
+	r.raftMu.tenantLimiter = r.store.cfg.TenantRateLimiters.GetTenant(tenantID)
+}
@@ -1066,7 +1066,7 @@ func (r *Replica) maybeCoalesceHeartbeat(
 		Term:          msg.Term,
 		Commit:        msg.Commit,
 		Quiesce:       quiesce,
-		ToIsLearner:   toReplica.GetType() == roachpb.LEARNER,
+		ToIsLearner:   !toReplica.IsVoter(),
 	}
 	if log.V(4) {
 		log.Infof(ctx, "coalescing beat: %+v", beat)
@@ -1608,7 +1608,7 @@ func (r *Replica) acquireSplitLock(
 	rightReplDesc, _ := split.RightDesc.GetReplicaDescriptor(r.StoreID())
 	rightRepl, _, err := r.store.getOrCreateReplica(ctx, split.RightDesc.RangeID,
 		rightReplDesc.ReplicaID, nil, /* creatingReplica */
-		rightReplDesc.GetType() == roachpb.LEARNER)
+		!rightReplDesc.IsVoter())
 	// If getOrCreateReplica returns RaftGroupDeletedError we know that the RHS
 	// has already been removed. This case is handled properly in splitPostApply.
 	if errors.HasType(err, (*roachpb.RaftGroupDeletedError)(nil)) {
@@ -1647,7 +1647,7 @@ func (r *Replica) acquireMergeLock(
 	rightReplDesc, _ := merge.RightDesc.GetReplicaDescriptor(r.StoreID())
 	rightRepl, _, err := r.store.getOrCreateReplica(ctx, merge.RightDesc.RangeID,
 		rightReplDesc.ReplicaID, nil, /* creatingReplica */
-		rightReplDesc.GetType() == roachpb.LEARNER)
+		!rightReplDesc.IsVoter())
 	if err != nil {
 		return nil, err
 	}
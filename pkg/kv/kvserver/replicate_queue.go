@@ -215,12 +215,14 @@ func (rq *replicateQueue) shouldQueue(
 	action, priority := rq.allocator.ComputeAction(ctx, zone, desc)
 
 	// For simplicity, the first thing the allocator does is remove learners, so
-	// it can do all of its reasoning about only voters. We do the same here so
-	// the executions of the allocator's decisions can be in terms of voters.
+	// it can do all of its reasoning about only voters and non-voters. We do the
+	// same here so the executions of the allocator's decisions can be in terms
+	// of those.
 	if action == AllocatorRemoveLearner {
 		return true, priority
 	}
 	voterReplicas := desc.Replicas().Voters()
+	nonVoterReplicas := desc.Replicas().NonVoters()
 
 	if action == AllocatorNoop {
 		log.VEventf(ctx, 2, "no action to take")
@@ -233,9 +235,17 @@ func (rq *replicateQueue) shouldQueue(
 	if !rq.store.TestingKnobs().DisableReplicaRebalancing {
 		rangeUsageInfo := rangeUsageInfoForRepl(repl)
 		_, _, _, ok := rq.allocator.RebalanceTarget(
-			ctx, zone, repl.RaftStatus(), voterReplicas, rangeUsageInfo, storeFilterThrottled)
+			ctx, zone, repl.RaftStatus(), voterReplicas, nonVoterReplicas, rangeUsageInfo,
+			storeFilterThrottled)
 		if ok {
-			log.VEventf(ctx, 2, "rebalance target found, enqueuing")
+			log.VEventf(ctx, 2, "rebalance target found for voter, enqueuing")
+			return true, 0
+		}
+		_, _, _, ok = rq.allocator.RebalanceNonVoter(
+			ctx, zone, repl.RaftStatus(), voterReplicas, nonVoterReplicas, rangeUsageInfo,
+			storeFilterThrottled)
+		if ok {
+			log.VEventf(ctx, 2, "rebalance target found for non-voter, enqueuing")
 			return true, 0
 		}
 		log.VEventf(ctx, 2, "no rebalance target found, not enqueuing")
@@ -320,6 +330,7 @@ func (rq *replicateQueue) processOneChange(
 	// Avoid taking action if the range has too many dead replicas to make
 	// quorum.
 	voterReplicas := desc.Replicas().Voters()
+	nonVoterReplicas := desc.Replicas().NonVoters()
 	liveVoterReplicas, deadVoterReplicas := rq.allocator.storePool.liveAndDeadReplicas(voterReplicas)
 	{
 		unavailable := !desc.Replicas().CanMakeProgress(func(rDesc roachpb.ReplicaDescriptor) bool {
@@ -343,8 +354,9 @@ func (rq *replicateQueue) processOneChange(
 	log.VEventf(ctx, 1, "next replica action: %s", action)
 
 	// For simplicity, the first thing the allocator does is remove learners, so
-	// it can do all of its reasoning about only voters. We do the same here so
-	// the executions of the allocator's decisions can be in terms of voters.
+	// it can do all of its reasoning about only voters and non-voters. We do the
+	// same here so the executions of the allocator's decisions can be in terms
+	// of those.
 	if action == AllocatorRemoveLearner {
 		return rq.removeLearner(ctx, repl, dryRun)
 	}
@@ -356,9 +368,10 @@ func (rq *replicateQueue) processOneChange(
 		// Let the scanner requeue it again later.
 		return false, nil
 	case AllocatorAdd:
-		return rq.addOrReplace(ctx, repl, voterReplicas, liveVoterReplicas, -1 /* removeIdx */, dryRun)
+		return rq.addOrReplace(
+			ctx, repl, voterReplicas, nonVoterReplicas, liveVoterReplicas, -1 /* removeIdx */, dryRun)
 	case AllocatorRemove:
-		return rq.remove(ctx, repl, voterReplicas, dryRun)
+		return rq.remove(ctx, repl, voterReplicas, nonVoterReplicas, dryRun)
 	case AllocatorReplaceDead:
 		if len(deadVoterReplicas) == 0 {
 			// Nothing to do.
@@ -376,7 +389,8 @@ func (rq *replicateQueue) processOneChange(
 				"dead voter %v unexpectedly not found in %v",
 				deadVoterReplicas[0], voterReplicas)
 		}
-		return rq.addOrReplace(ctx, repl, voterReplicas, liveVoterReplicas, removeIdx, dryRun)
+		return rq.addOrReplace(
+			ctx, repl, voterReplicas, nonVoterReplicas, liveVoterReplicas, removeIdx, dryRun)
 	case AllocatorReplaceDecommissioning:
 		decommissioningReplicas := rq.allocator.storePool.decommissioningReplicas(voterReplicas)
 		if len(decommissioningReplicas) == 0 {
@@ -395,21 +409,32 @@ func (rq *replicateQueue) processOneChange(
 				"decommissioning voter %v unexpectedly not found in %v",
 				decommissioningReplicas[0], voterReplicas)
 		}
-		return rq.addOrReplace(ctx, repl, voterReplicas, liveVoterReplicas, removeIdx, dryRun)
+		return rq.addOrReplace(
+			ctx, repl, voterReplicas, nonVoterReplicas, liveVoterReplicas, removeIdx, dryRun)
 	case AllocatorRemoveDecommissioning:
 		// NB: this path will only be hit when the range is over-replicated and
 		// has decommissioning replicas; in the common case we'll hit
 		// AllocatorReplaceDecommissioning above.
-		return rq.removeDecommissioning(ctx, repl, dryRun)
+		return rq.removeDecommissioning(ctx, repl, voterTarget, dryRun)
 	case AllocatorRemoveDead:
 		// NB: this path will only be hit when the range is over-replicated and
 		// has dead replicas; in the common case we'll hit AllocatorReplaceDead
 		// above.
-		return rq.removeDead(ctx, repl, deadVoterReplicas, dryRun)
+		return rq.removeDead(ctx, repl, deadVoterReplicas, voterTarget, dryRun)
 	case AllocatorRemoveLearner:
 		return rq.removeLearner(ctx, repl, dryRun)
+	case AllocatorAddNonVoter:
+		return rq.addNonVoter(ctx, repl, voterReplicas, nonVoterReplicas, dryRun)
+	case AllocatorRemoveNonVoter:
+		return rq.removeNonVoter(ctx, repl, voterReplicas, nonVoterReplicas, dryRun)
+	case AllocatorRemoveDeadNonVoter:
+		_, deadNonVoterReplicas := rq.allocator.storePool.liveAndDeadReplicas(nonVoterReplicas)
+		return rq.removeDead(ctx, repl, deadNonVoterReplicas, nonVoterTarget, dryRun)
+	case AllocatorRemoveDecommissioningNonVoter:
+		return rq.removeDecommissioning(ctx, repl, nonVoterTarget, dryRun)
 	case AllocatorConsiderRebalance:
-		return rq.considerRebalance(ctx, repl, voterReplicas, canTransferLease, dryRun)
+		return rq.considerRebalance(
+			ctx, repl, voterReplicas, nonVoterReplicas, canTransferLease, dryRun)
 	case AllocatorFinalizeAtomicReplicationChange:
 		_, err := maybeLeaveAtomicChangeReplicasAndRemoveLearners(ctx, repl.store, repl.Desc())
 		// Requeue because either we failed to transition out of a joint state
@@ -420,9 +445,11 @@ func (rq *replicateQueue) processOneChange(
 	}
 }
 
-// addOrReplace adds or replaces a replica. If removeIdx is -1, an addition is
-// carried out. Otherwise, removeIdx must be a valid index into existingReplicas
-// and specifies which replica to replace with a new one.
+// addOrReplace adds or replaces a voting replica. If removeIdx is -1, an
+// addition is carried out. Otherwise, removeIdx must be a valid index into
+// existingReplicas and specifies which replica to replace with a new one. If
+// the allocator picks the store of one of the range's non-voting replicas, that
+// non-voter is promoted instead of adding a new replica.
 //
 // The method preferably issues an atomic replica swap, but may not be able to
 // do this in all cases, such as when atomic replication changes are not
@@ -433,6 +460,7 @@ func (rq *replicateQueue) addOrReplace(
 	ctx context.Context,
 	repl *Replica,
 	existingReplicas []roachpb.ReplicaDescriptor,
+	existingNonVoters []roachpb.ReplicaDescriptor,
 	liveVoterReplicas []roachpb.ReplicaDescriptor,
 	removeIdx int, // -1 for no removal
 	dryRun bool,
@@ -480,6 +508,7 @@ func (rq *replicateQueue) addOrReplace(
 		ctx,
 		zone,
 		remainingLiveReplicas,
+		existingNonVoters,
 	)
 	if err != nil {
 		return false, err
@@ -520,6 +549,7 @@ func (rq *replicateQueue) addOrReplace(
 			ctx,
 			zone,
 			oldPlusNewReplicas,
+			existingNonVoters,
 		)
 		if err != nil {
 			// It does not seem possible to go to the next odd replica state. Note
@@ -530,6 +560,11 @@ func (rq *replicateQueue) addOrReplace(
 	}
 	rq.metrics.AddReplicaCount.Inc(1)
 	ops := roachpb.MakeReplicationChanges(roachpb.ADD_REPLICA, newReplica)
+	if storeHasReplica(newReplica.StoreID, existingNonVoters) {
+		// The new voter takes the place of a non-voter, which is promoted.
+		log.VEventf(ctx, 1, "promoting non-voter on %+v", newReplica)
+		ops = append(ops, roachpb.MakeReplicationChanges(roachpb.REMOVE_NON_VOTER, newReplica)...)
+	}
 	if removeIdx < 0 {
 		log.VEventf(ctx, 1, "adding replica %+v: %s",
 			newReplica, rangeRaftProgress(repl.RaftStatus(), existingReplicas))
@@ -671,8 +706,14 @@ func (rq *replicateQueue) maybeTransferLeaseAway(
 	)
 }
 
+// remove removes a voting replica from an over-replicated range. If the range
+// is missing non-voting replicas, the voter is demoted to a non-voter instead.
 func (rq *replicateQueue) remove(
-	ctx context.Context, repl *Replica, existingReplicas []roachpb.ReplicaDescriptor, dryRun bool,
+	ctx context.Context,
+	repl *Replica,
+	existingReplicas []roachpb.ReplicaDescriptor,
+	existingNonVoters []roachpb.ReplicaDescriptor,
+	dryRun bool,
 ) (requeue bool, _ error) {
 	removeReplica, details, err := rq.findRemoveTarget(ctx, repl, existingReplicas)
 	if err != nil {
@@ -695,11 +736,20 @@ func (rq *replicateQueue) remove(
 		NodeID:  removeReplica.NodeID,
 		StoreID: removeReplica.StoreID,
 	}
-	desc, _ := repl.DescAndZone()
+	desc, zone := repl.DescAndZone()
+	ops := roachpb.MakeReplicationChanges(roachpb.REMOVE_REPLICA, target)
+	clusterNodes := rq.allocator.storePool.ClusterNodeCount()
+	neededNonVoters := GetNeededNonVoters(
+		len(existingReplicas)-1, int(zone.GetNumNonVoters()), clusterNodes)
+	if len(existingNonVoters) < neededNonVoters {
+		// Keep the replica around as a non-voter rather than removing it.
+		log.VEventf(ctx, 1, "demoting replica %+v to a non-voter", removeReplica)
+		ops = append(ops, roachpb.MakeReplicationChanges(roachpb.ADD_NON_VOTER, target)...)
+	}
 	if err := rq.changeReplicas(
 		ctx,
 		repl,
-		roachpb.MakeReplicationChanges(roachpb.REMOVE_REPLICA, target),
+		ops,
 		desc,
 		SnapshotRequest_UNKNOWN, // unused
 		kvserverpb.ReasonRangeOverReplicated,
@@ -712,10 +762,14 @@ func (rq *replicateQueue) remove(
 }
 
 func (rq *replicateQueue) removeDecommissioning(
-	ctx context.Context, repl *Replica, dryRun bool,
+	ctx context.Context, repl *Replica, targetType targetReplicaType, dryRun bool,
 ) (requeue bool, _ error) {
 	desc, _ := repl.DescAndZone()
-	decommissioningReplicas := rq.allocator.storePool.decommissioningReplicas(desc.Replicas().All())
+	replicas, changeType := desc.Replicas().Voters(), roachpb.REMOVE_REPLICA
+	if targetType == nonVoterTarget {
+		replicas, changeType = desc.Replicas().NonVoters(), roachpb.REMOVE_NON_VOTER
+	}
+	decommissioningReplicas := rq.allocator.storePool.decommissioningReplicas(replicas)
 	if len(decommissioningReplicas) == 0 {
 		log.VEventf(ctx, 1, "range of replica %s was identified as having decommissioning replicas, "+
 			"but no decommissioning replicas were found", repl)
@@ -740,7 +794,7 @@ func (rq *replicateQueue) removeDecommissioning(
 	if err := rq.changeReplicas(
 		ctx,
 		repl,
-		roachpb.MakeReplicationChanges(roachpb.REMOVE_REPLICA, target),
+		roachpb.MakeReplicationChanges(changeType, target),
 		desc,
 		SnapshotRequest_UNKNOWN, // unused
 		kvserverpb.ReasonStoreDecommissioning, "", dryRun,
@@ -752,14 +806,22 @@ func (rq *replicateQueue) removeDecommissioning(
 }
 
 func (rq *replicateQueue) removeDead(
-	ctx context.Context, repl *Replica, deadVoterReplicas []roachpb.ReplicaDescriptor, dryRun bool,
+	ctx context.Context,
+	repl *Replica,
+	deadReplicas []roachpb.ReplicaDescriptor,
+	targetType targetReplicaType,
+	dryRun bool,
 ) (requeue bool, _ error) {
 	desc := repl.Desc()
-	if len(deadVoterReplicas) == 0 {
+	if len(deadReplicas) == 0 {
 		log.VEventf(ctx, 1, "range of replica %s was identified as having dead replicas, but no dead replicas were found", repl)
 		return true, nil
 	}
-	deadReplica := deadVoterReplicas[0]
+	changeType := roachpb.REMOVE_REPLICA
+	if targetType == nonVoterTarget {
+		changeType = roachpb.REMOVE_NON_VOTER
+	}
+	deadReplica := deadReplicas[0]
 	rq.metrics.RemoveDeadReplicaCount.Inc(1)
 	log.VEventf(ctx, 1, "removing dead replica %+v from store", deadReplica)
 	target := roachpb.ReplicationTarget{
@@ -772,7 +834,7 @@ func (rq *replicateQueue) removeDead(
 	if err := rq.changeReplicas(
 		ctx,
 		repl,
-		roachpb.MakeReplicationChanges(roachpb.REMOVE_REPLICA, target),
+		roachpb.MakeReplicationChanges(changeType, target),
 		desc,
 		SnapshotRequest_UNKNOWN, // unused
 		kvserverpb.ReasonStoreDead,
@@ -784,6 +846,78 @@ func (rq *replicateQueue) removeDead(
 	return true, nil
 }
 
+// addNonVoter adds a non-voting replica to a range that is missing some.
+func (rq *replicateQueue) addNonVoter(
+	ctx context.Context,
+	repl *Replica,
+	existingVoters, existingNonVoters []roachpb.ReplicaDescriptor,
+	dryRun bool,
+) (requeue bool, _ error) {
+	desc, zone := repl.DescAndZone()
+	newStore, details, err := rq.allocator.AllocateNonVoter(
+		ctx, zone, existingVoters, existingNonVoters)
+	if err != nil {
+		return false, err
+	}
+	target := roachpb.ReplicationTarget{
+		NodeID:  newStore.Node.NodeID,
+		StoreID: newStore.StoreID,
+	}
+	rq.metrics.AddReplicaCount.Inc(1)
+	log.VEventf(ctx, 1, "adding non-voter %+v: %s",
+		target, rangeRaftProgress(repl.RaftStatus(), existingVoters))
+	if err := rq.changeReplicas(
+		ctx,
+		repl,
+		roachpb.MakeReplicationChanges(roachpb.ADD_NON_VOTER, target),
+		desc,
+		SnapshotRequest_RECOVERY,
+		kvserverpb.ReasonRangeUnderReplicated,
+		details,
+		dryRun,
+	); err != nil {
+		return false, err
+	}
+	// Always requeue to see if more work needs to be done.
+	return true, nil
+}
+
+// removeNonVoter removes a non-voting replica from a range that has too many.
+// Non-voters don't participate in quorum, so unlike voters, any of them may be
+// removed regardless of its raft progress.
+func (rq *replicateQueue) removeNonVoter(
+	ctx context.Context,
+	repl *Replica,
+	existingVoters, existingNonVoters []roachpb.ReplicaDescriptor,
+	dryRun bool,
+) (requeue bool, _ error) {
+	desc, zone := repl.DescAndZone()
+	removeReplica, details, err := rq.allocator.RemoveNonVoter(
+		ctx, zone, existingNonVoters, existingVoters, existingNonVoters)
+	if err != nil {
+		return false, err
+	}
+	rq.metrics.RemoveReplicaCount.Inc(1)
+	log.VEventf(ctx, 1, "removing non-voter %+v due to over-replication", removeReplica)
+	target := roachpb.ReplicationTarget{
+		NodeID:  removeReplica.NodeID,
+		StoreID: removeReplica.StoreID,
+	}
+	if err := rq.changeReplicas(
+		ctx,
+		repl,
+		roachpb.MakeReplicationChanges(roachpb.REMOVE_NON_VOTER, target),
+		desc,
+		SnapshotRequest_UNKNOWN, // unused
+		kvserverpb.ReasonRangeOverReplicated,
+		details,
+		dryRun,
+	); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 func (rq *replicateQueue) removeLearner(
 	ctx context.Context, repl *Replica, dryRun bool,
 ) (requeue bool, _ error) {
@@ -823,6 +957,7 @@ func (rq *replicateQueue) considerRebalance(
 	ctx context.Context,
 	repl *Replica,
 	existingReplicas []roachpb.ReplicaDescriptor,
+	existingNonVoters []roachpb.ReplicaDescriptor,
 	canTransferLease func() bool,
 	dryRun bool,
 ) (requeue bool, _ error) {
@@ -832,10 +967,15 @@ func (rq *replicateQueue) considerRebalance(
 	if !rq.store.TestingKnobs().DisableReplicaRebalancing {
 		rangeUsageInfo := rangeUsageInfoForRepl(repl)
 		addTarget, removeTarget, details, ok := rq.allocator.RebalanceTarget(
-			ctx, zone, repl.RaftStatus(), existingReplicas, rangeUsageInfo,
+			ctx, zone, repl.RaftStatus(), existingReplicas, existingNonVoters, rangeUsageInfo,
 			storeFilterThrottled)
 		if !ok {
-			log.VEventf(ctx, 1, "no suitable rebalance target")
+			log.VEventf(ctx, 1, "no suitable rebalance target for voters")
+			if rebalanced, err := rq.rebalanceNonVoter(
+				ctx, repl, existingReplicas, existingNonVoters, dryRun,
+			); err != nil || rebalanced {
+				return rebalanced, err
+			}
 		} else if done, err := rq.maybeTransferLeaseAway(ctx, repl, removeTarget.StoreID, dryRun); err != nil {
 			log.VEventf(ctx, 1, "want to remove self, but failed to transfer lease away: %s", err)
 		} else if done {
@@ -927,6 +1067,46 @@ func (rq *replicateQueue) considerRebalance(
 	return false, nil
 }
 
+// rebalanceNonVoter moves one of the range's non-voting replicas if the
+// allocator finds a better place for it. It returns whether it did.
+func (rq *replicateQueue) rebalanceNonVoter(
+	ctx context.Context,
+	repl *Replica,
+	existingVoters, existingNonVoters []roachpb.ReplicaDescriptor,
+	dryRun bool,
+) (rebalanced bool, _ error) {
+	desc, zone := repl.DescAndZone()
+	rangeUsageInfo := rangeUsageInfoForRepl(repl)
+	addTarget, removeTarget, details, ok := rq.allocator.RebalanceNonVoter(
+		ctx, zone, repl.RaftStatus(), existingVoters, existingNonVoters, rangeUsageInfo,
+		storeFilterThrottled)
+	if !ok {
+		log.VEventf(ctx, 1, "no suitable rebalance target for non-voters")
+		return false, nil
+	}
+	// The leaseholder is always a voter, so unlike in considerRebalance, there's
+	// no need to move the lease before removing the non-voter.
+	chgs := []roachpb.ReplicationChange{
+		{Target: addTarget, ChangeType: roachpb.ADD_NON_VOTER},
+		{Target: removeTarget, ChangeType: roachpb.REMOVE_NON_VOTER},
+	}
+	rq.metrics.RebalanceReplicaCount.Inc(1)
+	log.VEventf(ctx, 1, "rebalancing non-voter %+v to %+v", removeTarget, addTarget)
+	if err := rq.changeReplicas(
+		ctx,
+		repl,
+		chgs,
+		desc,
+		SnapshotRequest_REBALANCE,
+		kvserverpb.ReasonRebalance,
+		details,
+		dryRun,
+	); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 type transferLeaseOptions struct {
 	checkTransferLeaseSource bool
 	checkCandidateFullness   bool
@@ -40,6 +40,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/intentresolver"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/protectedts"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/raftentry"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/tenantrate"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/tscache"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/txnrecovery"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/txnwait"
@@ -718,6 +719,12 @@ type StoreConfig struct {
 	// subsystem. It is queried during the GC process and in the handling of
 	// AdminVerifyProtectedTimestampRequest.
 	ProtectedTimestampCache protectedts.Cache
+
+	// TenantRateLimiters holds the rate limiters of the tenants whose data the
+	// store's replicas hold. Each replica of a tenant's range holds a reference
+	// to the tenant's limiter, so that it is released once the last one is
+	// removed.
+	TenantRateLimiters *tenantrate.LimiterFactory
 }
 
 // ConsistencyTestingKnobs is a BatchEvalTestingKnobs struct used to control the
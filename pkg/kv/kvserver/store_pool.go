@@ -366,11 +366,11 @@ func (sp *StorePool) updateLocalStoreAfterRebalance(
 		return
 	}
 	switch changeType {
-	case roachpb.ADD_REPLICA:
+	case roachpb.ADD_REPLICA, roachpb.ADD_NON_VOTER:
 		detail.desc.Capacity.RangeCount++
 		detail.desc.Capacity.LogicalBytes += rangeUsageInfo.LogicalBytes
 		detail.desc.Capacity.WritesPerSecond += rangeUsageInfo.WritesPerSecond
-	case roachpb.REMOVE_REPLICA:
+	case roachpb.REMOVE_REPLICA, roachpb.REMOVE_NON_VOTER:
 		detail.desc.Capacity.RangeCount--
 		if detail.desc.Capacity.LogicalBytes <= rangeUsageInfo.LogicalBytes {
 			detail.desc.Capacity.LogicalBytes = 0
@@ -621,6 +621,26 @@ func (sl StoreList) filter(constraints []zonepb.ConstraintsConjunction) StoreLis
 	return makeStoreList(filteredDescs)
 }
 
+// excludeReplicaNodes returns a new store list without the stores on the
+// nodes that hold one of the given replicas. If keepReplicaStores is set, the
+// stores holding the replicas themselves are retained. It maintains the
+// original order of the passed in store list.
+func (sl StoreList) excludeReplicaNodes(
+	replicas []roachpb.ReplicaDescriptor, keepReplicaStores bool,
+) StoreList {
+	if len(replicas) == 0 {
+		return sl
+	}
+	var filteredDescs []roachpb.StoreDescriptor
+	for _, store := range sl.stores {
+		if !nodeHasReplica(store.Node.NodeID, replicas) ||
+			(keepReplicaStores && storeHasReplica(store.StoreID, replicas)) {
+			filteredDescs = append(filteredDescs, store)
+		}
+	}
+	return makeStoreList(filteredDescs)
+}
+
 type storeFilter int
 
 const (
@@ -61,7 +61,8 @@ func (s *Store) HandleSnapshot(
 	})
 }
 
-// learnerType exists to avoid allocating on every coalesced beat to a learner.
+// learnerType exists to avoid allocating on every coalesced beat to a learner
+// or non-voter.
 var learnerType = roachpb.LEARNER
 
 func (s *Store) uncoalesceBeats(
@@ -190,7 +191,7 @@ func (s *Store) withReplicaForRequest(
 		req.RangeID,
 		req.ToReplica.ReplicaID,
 		&req.FromReplica,
-		req.ToReplica.GetType() == roachpb.LEARNER,
+		!req.ToReplica.IsVoter(),
 	)
 	if err != nil {
 		return roachpb.NewError(err)
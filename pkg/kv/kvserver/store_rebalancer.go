@@ -500,8 +500,16 @@ func (sr *StoreRebalancer) chooseReplicaToRebalance(
 		log.VEventf(ctx, 3, "considering replica rebalance for r%d with %.2f qps",
 			desc.RangeID, replWithStats.qps)
 
+		// TODO(aayush): AdminRelocateRange only knows how to place voting
+		// replicas, so we leave ranges with non-voting replicas to the
+		// replicate queue.
+		if len(desc.Replicas().NonVoters()) > 0 {
+			log.VEventf(ctx, 3, "not rebalancing r%d because it has non-voting replicas", desc.RangeID)
+			continue
+		}
+
 		clusterNodes := sr.rq.allocator.storePool.ClusterNodeCount()
-		desiredReplicas := GetNeededReplicas(*zone.NumReplicas, clusterNodes)
+		desiredReplicas := GetNeededReplicas(zone.GetNumVoters(), clusterNodes)
 		targets := make([]roachpb.ReplicationTarget, 0, desiredReplicas)
 		targetReplicas := make([]roachpb.ReplicaDescriptor, 0, desiredReplicas)
 		currentReplicas := desc.Replicas().All()
@@ -544,6 +552,7 @@ func (sr *StoreRebalancer) chooseReplicaToRebalance(
 				storeList,
 				zone,
 				targetReplicas,
+				nil, /* existingNonVoters */
 				options,
 			)
 			if target == nil {
@@ -164,6 +164,10 @@ func (s *Store) removeInitializedReplicaRaftMuLocked(
 		log.Fatalf(ctx, "corrupted replicasByKey map: %s and %s overlapped", rep, rep2)
 	}
 	delete(s.mu.replicaPlaceholders, rep.RangeID)
+	if rep.raftMu.tenantLimiter != nil {
+		s.cfg.TenantRateLimiters.Release(rep.raftMu.tenantLimiter)
+		rep.raftMu.tenantLimiter = nil
+	}
 	// TODO(peter): Could release s.mu.Lock() here.
 	s.maybeGossipOnCapacityChange(ctx, rangeRemoveEvent)
 	s.scanner.RemoveReplica(rep)
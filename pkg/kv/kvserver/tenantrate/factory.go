@@ -0,0 +1,119 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tenantrate
+
+import (
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/util/metric"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+)
+
+// LimiterFactory constructs and holds the Limiters of the tenants whose
+// requests a node serves. All of them share the same Config, which is kept up
+// to date with the cluster settings. The Limiters are reference counted: a
+// tenant's Limiter and its metrics are dropped once every reference acquired
+// with GetTenant has been released.
+type LimiterFactory struct {
+	metrics Metrics
+	// registry holds the per-tenant metrics, which carry the tenant's ID as a
+	// label.
+	registry *metric.Registry
+	now      func() time.Time
+
+	mu struct {
+		syncutil.Mutex
+		config  Config
+		tenants map[roachpb.TenantID]*limiter
+	}
+}
+
+// NewLimiterFactory constructs a new LimiterFactory.
+func NewLimiterFactory(st *cluster.Settings) *LimiterFactory {
+	f := &LimiterFactory{
+		metrics:  makeMetrics(),
+		registry: metric.NewRegistry(),
+		now:      timeutil.Now,
+	}
+	f.mu.config = ConfigFromSettings(&st.SV)
+	f.mu.tenants = make(map[roachpb.TenantID]*limiter)
+	for _, setting := range configSettings {
+		setting.SetOnChange(&st.SV, func() {
+			f.UpdateConfig(ConfigFromSettings(&st.SV))
+		})
+	}
+	return f
+}
+
+// GetTenant acquires a reference to the Limiter of the specified tenant,
+// constructing it if it doesn't exist yet. The reference must be released with
+// Release. The system tenant is not rate limited, so it must not be passed.
+func (f *LimiterFactory) GetTenant(tenantID roachpb.TenantID) Limiter {
+	if tenantID == roachpb.SystemTenantID {
+		panic(errors.AssertionFailedf("the system tenant is not rate limited"))
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	l, ok := f.mu.tenants[tenantID]
+	if !ok {
+		l = newLimiter(tenantID, f.mu.config, makeTenantMetrics(tenantID, &f.metrics), f.now)
+		f.mu.tenants[tenantID] = l
+		f.registry.AddMetricStruct(&l.metrics)
+		f.metrics.Tenants.Inc(1)
+	}
+	l.refCount++
+	return l
+}
+
+// Release releases a reference to a Limiter acquired with GetTenant. Once the
+// last reference to a tenant's Limiter is released, the Limiter and its
+// metrics are dropped.
+func (f *LimiterFactory) Release(lim Limiter) {
+	l := lim.(*limiter)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if l.refCount <= 0 {
+		panic(errors.AssertionFailedf("limiter of tenant %v released too many times", l.tenantID))
+	}
+	l.refCount--
+	if l.refCount > 0 {
+		return
+	}
+	delete(f.mu.tenants, l.tenantID)
+	l.metrics.unregister(f.registry)
+	f.metrics.Tenants.Dec(1)
+}
+
+// UpdateConfig updates the configuration of all Limiters.
+func (f *LimiterFactory) UpdateConfig(config Config) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.mu.config = config
+	for _, l := range f.mu.tenants {
+		l.updateConfig(config)
+	}
+}
+
+// Metrics returns the metrics of the LimiterFactory, aggregated over all
+// tenants.
+func (f *LimiterFactory) Metrics() *Metrics {
+	return &f.metrics
+}
+
+// TenantRegistry returns the registry that holds the per-tenant metrics of the
+// LimiterFactory. These metrics are distinguished by a tenant ID label.
+func (f *LimiterFactory) TenantRegistry() *metric.Registry {
+	return f.registry
+}
@@ -0,0 +1,198 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package tenantrate contains logic for rate limiting the requests that SQL
+// tenants send to the KV layer.
+package tenantrate
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+)
+
+// Limiter is used to rate limit the KV requests of a single tenant. A tenant
+// is limited in the number of requests it issues, in the number of bytes it
+// reads and in the number of bytes it writes. Each of these dimensions is
+// governed by a token bucket.
+type Limiter interface {
+	// Wait blocks until the tenant is permitted to issue a request that writes
+	// the specified number of bytes, or until the context is canceled, in
+	// which case the context's error is returned.
+	//
+	// A request that writes more bytes than the write burst limit is admitted
+	// once the bucket is full, and puts the bucket into debt.
+	Wait(ctx context.Context, writeBytes int64) error
+
+	// RecordRead records the number of bytes that a request read once it has
+	// been evaluated. Since this isn't known up front, reads aren't throttled
+	// directly. Instead, the bytes are taken from the read bucket, possibly
+	// putting it into debt, and subsequent requests wait in Wait until the debt
+	// is paid off.
+	RecordRead(ctx context.Context, readBytes int64)
+}
+
+// limiter implements Limiter.
+type limiter struct {
+	tenantID roachpb.TenantID
+	metrics  tenantMetrics
+	now      func() time.Time
+	// refCount is the number of references to the limiter acquired from its
+	// LimiterFactory. It is protected by the factory's mutex.
+	refCount int
+
+	mu struct {
+		syncutil.Mutex
+		requests   tokenBucket
+		readBytes  tokenBucket
+		writeBytes tokenBucket
+	}
+}
+
+var _ Limiter = (*limiter)(nil)
+
+func newLimiter(
+	tenantID roachpb.TenantID, config Config, metrics tenantMetrics, now func() time.Time,
+) *limiter {
+	l := &limiter{tenantID: tenantID, metrics: metrics, now: now}
+	t := now()
+	l.mu.requests = makeTokenBucket(config.Requests, t)
+	l.mu.readBytes = makeTokenBucket(config.ReadBytes, t)
+	l.mu.writeBytes = makeTokenBucket(config.WriteBytes, t)
+	return l
+}
+
+// Wait implements the Limiter interface.
+func (l *limiter) Wait(ctx context.Context, writeBytes int64) error {
+	var timer timeutil.Timer
+	defer timer.Stop()
+	blocked := false
+	for {
+		l.mu.Lock()
+		wait := l.tryAcquireLocked(l.now(), float64(writeBytes))
+		l.mu.Unlock()
+		if wait == 0 {
+			break
+		}
+		if !blocked {
+			blocked = true
+			l.metrics.incCurrentBlocked(1)
+			defer l.metrics.incCurrentBlocked(-1)
+		}
+		timer.Reset(wait)
+		select {
+		case <-timer.C:
+			timer.Read = true
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	l.metrics.incAdmitted(writeBytes)
+	return nil
+}
+
+// RecordRead implements the Limiter interface.
+func (l *limiter) RecordRead(ctx context.Context, readBytes int64) {
+	l.mu.Lock()
+	l.mu.readBytes.update(l.now())
+	l.mu.readBytes.tokens -= float64(readBytes)
+	l.mu.Unlock()
+	l.metrics.incReadBytes(readBytes)
+}
+
+// tryAcquireLocked attempts to take the tokens for a request that writes the
+// specified number of bytes. If the tokens are available in all of the
+// buckets, they are taken and zero is returned. Otherwise, nothing is taken
+// and the duration after which the tokens are expected to be available is
+// returned. A request never takes from the read bucket, but it needs the read
+// bucket to be out of debt.
+func (l *limiter) tryAcquireLocked(now time.Time, writeBytes float64) time.Duration {
+	l.mu.requests.update(now)
+	l.mu.readBytes.update(now)
+	l.mu.writeBytes.update(now)
+	wait := l.mu.requests.waitFor(1)
+	if w := l.mu.readBytes.waitFor(0); w > wait {
+		wait = w
+	}
+	if w := l.mu.writeBytes.waitFor(writeBytes); w > wait {
+		wait = w
+	}
+	if wait > 0 {
+		return wait
+	}
+	l.mu.requests.tokens--
+	l.mu.writeBytes.tokens -= writeBytes
+	return 0
+}
+
+// updateConfig reconfigures the limiter's token buckets.
+func (l *limiter) updateConfig(config Config) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	l.mu.requests.reconfigure(config.Requests, now)
+	l.mu.readBytes.reconfigure(config.ReadBytes, now)
+	l.mu.writeBytes.reconfigure(config.WriteBytes, now)
+}
+
+// tokenBucket is a token bucket that may go into debt, i.e. whose number of
+// tokens may become negative.
+type tokenBucket struct {
+	config      LimitConfig
+	tokens      float64
+	lastUpdated time.Time
+}
+
+// makeTokenBucket returns a full token bucket.
+func makeTokenBucket(config LimitConfig, now time.Time) tokenBucket {
+	return tokenBucket{config: config, tokens: config.Burst, lastUpdated: now}
+}
+
+// update adds the tokens accumulated since the bucket was last updated.
+func (tb *tokenBucket) update(now time.Time) {
+	if since := now.Sub(tb.lastUpdated); since > 0 {
+		tb.tokens += since.Seconds() * tb.config.Rate
+		if tb.tokens > tb.config.Burst {
+			tb.tokens = tb.config.Burst
+		}
+		tb.lastUpdated = now
+	}
+}
+
+// reconfigure updates the rate and the burst of the bucket. Tokens accumulated
+// under the old configuration are retained, up to the new burst.
+func (tb *tokenBucket) reconfigure(config LimitConfig, now time.Time) {
+	tb.update(now)
+	tb.config = config
+	if tb.tokens > tb.config.Burst {
+		tb.tokens = tb.config.Burst
+	}
+}
+
+// waitFor returns the duration after which the bucket will hold the specified
+// number of tokens, or zero if it already does. Requesting more tokens than
+// the burst waits for a full bucket.
+func (tb *tokenBucket) waitFor(n float64) time.Duration {
+	if n > tb.config.Burst {
+		n = tb.config.Burst
+	}
+	if tb.tokens >= n {
+		return 0
+	}
+	wait := time.Duration((n - tb.tokens) / tb.config.Rate * float64(time.Second))
+	if wait <= 0 {
+		// Don't spin on rounding errors.
+		wait = time.Nanosecond
+	}
+	return wait
+}
@@ -0,0 +1,187 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tenantrate
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// manualTime is a time source that only moves when advanced.
+type manualTime struct {
+	t time.Time
+}
+
+func newTestFactory(st *cluster.Settings) (*LimiterFactory, *manualTime) {
+	mt := &manualTime{t: time.Unix(0, 0)}
+	f := NewLimiterFactory(st)
+	f.now = func() time.Time { return mt.t }
+	return f, mt
+}
+
+func (mt *manualTime) advance(d time.Duration) {
+	mt.t = mt.t.Add(d)
+}
+
+// admitted returns whether the limiter admits a request without waiting.
+func admitted(l Limiter, writeBytes int64) bool {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	return l.Wait(ctx, writeBytes) == nil
+}
+
+func TestLimiter(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	f, mt := newTestFactory(cluster.MakeTestingClusterSettings())
+	f.UpdateConfig(Config{
+		Requests:   LimitConfig{Rate: 1, Burst: 2},
+		ReadBytes:  LimitConfig{Rate: 10, Burst: 100},
+		WriteBytes: LimitConfig{Rate: 10, Burst: 100},
+	})
+	l := f.GetTenant(roachpb.MakeTenantID(10))
+
+	// The request bucket starts out full.
+	require.True(t, admitted(l, 0))
+	require.True(t, admitted(l, 0))
+	require.False(t, admitted(l, 0))
+	mt.advance(time.Second)
+	require.True(t, admitted(l, 0))
+
+	// A write larger than the burst is admitted with a full bucket, and puts
+	// the bucket into debt.
+	mt.advance(10 * time.Second)
+	require.True(t, admitted(l, 150))
+	require.False(t, admitted(l, 0))
+	mt.advance(4 * time.Second)
+	require.False(t, admitted(l, 0))
+	mt.advance(time.Second)
+	require.True(t, admitted(l, 0))
+
+	// Reads are accounted for after the fact and block subsequent requests
+	// until the debt is paid off.
+	mt.advance(10 * time.Second)
+	l.RecordRead(context.Background(), 120)
+	require.False(t, admitted(l, 0))
+	mt.advance(2 * time.Second)
+	require.True(t, admitted(l, 0))
+
+	// Raising the limits takes effect immediately, but doesn't add tokens.
+	require.True(t, admitted(l, 0))
+	require.False(t, admitted(l, 0))
+	f.UpdateConfig(Config{
+		Requests:   LimitConfig{Rate: 1000, Burst: 1000},
+		ReadBytes:  LimitConfig{Rate: 1000, Burst: 1000},
+		WriteBytes: LimitConfig{Rate: 1000, Burst: 1000},
+	})
+	require.False(t, admitted(l, 0))
+	mt.advance(time.Millisecond)
+	require.True(t, admitted(l, 0))
+	// Lowering the burst discards excess tokens.
+	mt.advance(time.Hour)
+	f.UpdateConfig(Config{
+		Requests:   LimitConfig{Rate: 1, Burst: 1},
+		ReadBytes:  LimitConfig{Rate: 1, Burst: 1},
+		WriteBytes: LimitConfig{Rate: 1, Burst: 1},
+	})
+	require.True(t, admitted(l, 0))
+	require.False(t, admitted(l, 0))
+}
+
+func TestLimiterWaitCanceled(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	f, _ := newTestFactory(cluster.MakeTestingClusterSettings())
+	f.UpdateConfig(Config{
+		Requests:   LimitConfig{Rate: 1, Burst: 1},
+		ReadBytes:  LimitConfig{Rate: 1, Burst: 1},
+		WriteBytes: LimitConfig{Rate: 1, Burst: 1},
+	})
+	l := f.GetTenant(roachpb.MakeTenantID(10))
+	require.NoError(t, l.Wait(context.Background(), 0))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() { errCh <- l.Wait(ctx, 0) }()
+	testutils.SucceedsSoon(t, func() error {
+		if blocked := f.Metrics().CurrentBlocked.Value(); blocked != 1 {
+			return errors.Errorf("expected 1 blocked request, found %d", blocked)
+		}
+		return nil
+	})
+	cancel()
+	require.Equal(t, context.Canceled, <-errCh)
+	require.Equal(t, int64(0), f.Metrics().CurrentBlocked.Value())
+}
+
+func TestLimiterFactory(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	st := cluster.MakeTestingClusterSettings()
+	f, mt := newTestFactory(st)
+	require.Equal(t, ConfigFromSettings(&st.SV), f.mu.config)
+
+	ten10 := f.GetTenant(roachpb.MakeTenantID(10))
+	require.Equal(t, ten10, f.GetTenant(roachpb.MakeTenantID(10)))
+	ten20 := f.GetTenant(roachpb.MakeTenantID(20))
+	require.NotEqual(t, ten10, ten20)
+	require.Equal(t, int64(2), f.Metrics().Tenants.Value())
+	require.Panics(t, func() { f.GetTenant(roachpb.SystemTenantID) })
+
+	// Changes to the cluster settings are applied to all limiters.
+	requestBurstLimit.Override(&st.SV, 1)
+	require.Equal(t, float64(1), f.mu.config.Requests.Burst)
+	for _, l := range []Limiter{ten10, ten20} {
+		require.True(t, admitted(l, 0))
+		require.False(t, admitted(l, 0))
+	}
+
+	// The metrics are tracked per tenant and in aggregate.
+	mt.advance(time.Hour)
+	require.True(t, admitted(ten10, 5))
+	ten10.RecordRead(context.Background(), 7)
+	m10 := ten10.(*limiter).metrics
+	require.Equal(t, int64(2), m10.RequestsAdmitted.Count())
+	require.Equal(t, int64(5), m10.WriteBytesAdmitted.Count())
+	require.Equal(t, int64(7), m10.ReadBytesAdmitted.Count())
+	require.Equal(t, int64(3), f.Metrics().RequestsAdmitted.Count())
+	require.Equal(t, int64(5), f.Metrics().WriteBytesAdmitted.Count())
+	require.Equal(t, int64(7), f.Metrics().ReadBytesAdmitted.Count())
+
+	labels := m10.RequestsAdmitted.GetLabels()
+	require.Len(t, labels, 1)
+	require.Equal(t, "tenant_id", labels[0].GetName())
+	require.Equal(t, "10", labels[0].GetValue())
+
+	// A limiter is dropped along with its metrics once all the references to
+	// it are released.
+	countTenantMetrics := func() int {
+		var n int
+		f.TenantRegistry().Each(func(string, interface{}) { n++ })
+		return n
+	}
+	require.Equal(t, 8, countTenantMetrics())
+	f.Release(ten10)
+	require.Equal(t, int64(2), f.Metrics().Tenants.Value())
+	f.Release(ten10)
+	require.Equal(t, int64(1), f.Metrics().Tenants.Value())
+	require.Equal(t, 4, countTenantMetrics())
+	require.NotEqual(t, ten10, f.GetTenant(roachpb.MakeTenantID(10)))
+	require.Panics(t, func() { f.Release(ten10) })
+}
@@ -0,0 +1,164 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tenantrate
+
+import (
+	"strconv"
+
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/util/metric"
+)
+
+var (
+	metaTenants = metric.Metadata{
+		Name:        "kv.tenant_rate_limit.num_tenants",
+		Help:        "Number of tenants which are being rate limited",
+		Measurement: "Tenants",
+		Unit:        metric.Unit_COUNT,
+	}
+	metaCurrentBlocked = metric.Metadata{
+		Name:        "kv.tenant_rate_limit.current_blocked",
+		Help:        "Number of requests of a tenant currently blocked by the rate limiter",
+		Measurement: "Requests",
+		Unit:        metric.Unit_COUNT,
+	}
+	metaRequestsAdmitted = metric.Metadata{
+		Name:        "kv.tenant_rate_limit.requests_admitted",
+		Help:        "Number of requests of a tenant admitted by the rate limiter",
+		Measurement: "Requests",
+		Unit:        metric.Unit_COUNT,
+	}
+	metaReadBytesAdmitted = metric.Metadata{
+		Name:        "kv.tenant_rate_limit.read_bytes_admitted",
+		Help:        "Number of read bytes of a tenant admitted by the rate limiter",
+		Measurement: "Bytes",
+		Unit:        metric.Unit_BYTES,
+	}
+	metaWriteBytesAdmitted = metric.Metadata{
+		Name:        "kv.tenant_rate_limit.write_bytes_admitted",
+		Help:        "Number of write bytes of a tenant admitted by the rate limiter",
+		Measurement: "Bytes",
+		Unit:        metric.Unit_BYTES,
+	}
+
+	// The aggregate metrics have names distinct from those of the per-tenant
+	// metrics, so that summing a per-tenant metric over its labels doesn't
+	// count the aggregate as well.
+	metaAllTenantsCurrentBlocked = metric.Metadata{
+		Name:        "kv.tenant_rate_limit.all_tenants.current_blocked",
+		Help:        "Number of requests of all tenants currently blocked by the rate limiter",
+		Measurement: "Requests",
+		Unit:        metric.Unit_COUNT,
+	}
+	metaAllTenantsRequestsAdmitted = metric.Metadata{
+		Name:        "kv.tenant_rate_limit.all_tenants.requests_admitted",
+		Help:        "Number of requests of all tenants admitted by the rate limiter",
+		Measurement: "Requests",
+		Unit:        metric.Unit_COUNT,
+	}
+	metaAllTenantsReadBytesAdmitted = metric.Metadata{
+		Name:        "kv.tenant_rate_limit.all_tenants.read_bytes_admitted",
+		Help:        "Number of read bytes of all tenants admitted by the rate limiter",
+		Measurement: "Bytes",
+		Unit:        metric.Unit_BYTES,
+	}
+	metaAllTenantsWriteBytesAdmitted = metric.Metadata{
+		Name:        "kv.tenant_rate_limit.all_tenants.write_bytes_admitted",
+		Help:        "Number of write bytes of all tenants admitted by the rate limiter",
+		Measurement: "Bytes",
+		Unit:        metric.Unit_BYTES,
+	}
+)
+
+// tenantIDLabel is the name of the label that per-tenant metrics carry.
+const tenantIDLabel = "tenant_id"
+
+// Metrics is a metric.Struct for the rate limiters of a node. Its metrics are
+// aggregated over all tenants.
+type Metrics struct {
+	Tenants            *metric.Gauge
+	CurrentBlocked     *metric.Gauge
+	RequestsAdmitted   *metric.Counter
+	ReadBytesAdmitted  *metric.Counter
+	WriteBytesAdmitted *metric.Counter
+}
+
+var _ metric.Struct = (*Metrics)(nil)
+
+// MetricStruct implements the metric.Struct interface.
+func (*Metrics) MetricStruct() {}
+
+func makeMetrics() Metrics {
+	return Metrics{
+		Tenants:            metric.NewGauge(metaTenants),
+		CurrentBlocked:     metric.NewGauge(metaAllTenantsCurrentBlocked),
+		RequestsAdmitted:   metric.NewCounter(metaAllTenantsRequestsAdmitted),
+		ReadBytesAdmitted:  metric.NewCounter(metaAllTenantsReadBytesAdmitted),
+		WriteBytesAdmitted: metric.NewCounter(metaAllTenantsWriteBytesAdmitted),
+	}
+}
+
+// tenantMetrics is a metric.Struct for the rate limiter of a single tenant.
+// Its metrics carry the tenant's ID as a label. Updates to them are also
+// applied to the aggregate metrics.
+type tenantMetrics struct {
+	CurrentBlocked     *metric.Gauge
+	RequestsAdmitted   *metric.Counter
+	ReadBytesAdmitted  *metric.Counter
+	WriteBytesAdmitted *metric.Counter
+
+	agg *Metrics
+}
+
+var _ metric.Struct = (*tenantMetrics)(nil)
+
+// MetricStruct implements the metric.Struct interface.
+func (*tenantMetrics) MetricStruct() {}
+
+func makeTenantMetrics(tenantID roachpb.TenantID, agg *Metrics) tenantMetrics {
+	withLabel := func(meta metric.Metadata) metric.Metadata {
+		meta.Labels = nil
+		meta.AddLabel(tenantIDLabel, strconv.FormatUint(tenantID.ToUint64(), 10))
+		return meta
+	}
+	return tenantMetrics{
+		CurrentBlocked:     metric.NewGauge(withLabel(metaCurrentBlocked)),
+		RequestsAdmitted:   metric.NewCounter(withLabel(metaRequestsAdmitted)),
+		ReadBytesAdmitted:  metric.NewCounter(withLabel(metaReadBytesAdmitted)),
+		WriteBytesAdmitted: metric.NewCounter(withLabel(metaWriteBytesAdmitted)),
+		agg:                agg,
+	}
+}
+
+// unregister removes the metrics from the registry they were added to.
+func (m *tenantMetrics) unregister(registry *metric.Registry) {
+	registry.RemoveMetric(m.CurrentBlocked)
+	registry.RemoveMetric(m.RequestsAdmitted)
+	registry.RemoveMetric(m.ReadBytesAdmitted)
+	registry.RemoveMetric(m.WriteBytesAdmitted)
+}
+
+func (m *tenantMetrics) incCurrentBlocked(v int64) {
+	m.CurrentBlocked.Inc(v)
+	m.agg.CurrentBlocked.Inc(v)
+}
+
+func (m *tenantMetrics) incAdmitted(writeBytes int64) {
+	m.RequestsAdmitted.Inc(1)
+	m.agg.RequestsAdmitted.Inc(1)
+	m.WriteBytesAdmitted.Inc(writeBytes)
+	m.agg.WriteBytesAdmitted.Inc(writeBytes)
+}
+
+func (m *tenantMetrics) incReadBytes(readBytes int64) {
+	m.ReadBytesAdmitted.Inc(readBytes)
+	m.agg.ReadBytesAdmitted.Inc(readBytes)
+}
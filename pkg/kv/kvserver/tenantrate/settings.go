@@ -0,0 +1,113 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tenantrate
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/errors"
+)
+
+// Config contains the configuration of the rate limiter of a tenant. Each of
+// the dimensions that a tenant is limited in is configured independently.
+type Config struct {
+	Requests   LimitConfig
+	ReadBytes  LimitConfig
+	WriteBytes LimitConfig
+}
+
+// LimitConfig configures the token bucket that limits a tenant in a single
+// dimension.
+type LimitConfig struct {
+	// Rate is the rate at which tokens are added to the bucket, per second.
+	Rate float64
+	// Burst is the maximum number of tokens that the bucket may hold.
+	Burst float64
+}
+
+func validatePositiveFloat(v float64) error {
+	if v <= 0 {
+		return errors.Errorf("cannot set to a non-positive value: %f", v)
+	}
+	return nil
+}
+
+func validatePositiveInt(v int64) error {
+	if v <= 0 {
+		return errors.Errorf("cannot set to a non-positive value: %d", v)
+	}
+	return nil
+}
+
+var (
+	requestRateLimit = settings.RegisterValidatedFloatSetting(
+		"kv.tenant_rate_limiter.requests.rate_limit",
+		"per-tenant rate limit in requests per second",
+		1024,
+		validatePositiveFloat,
+	)
+	requestBurstLimit = settings.RegisterPositiveIntSetting(
+		"kv.tenant_rate_limiter.requests.burst_limit",
+		"per-tenant burst limit in requests",
+		4096,
+	)
+	readRateLimit = settings.RegisterValidatedByteSizeSetting(
+		"kv.tenant_rate_limiter.read_bytes.rate_limit",
+		"per-tenant rate limit in bytes read per second",
+		64<<20, // 64 MiB
+		validatePositiveInt,
+	)
+	readBurstLimit = settings.RegisterValidatedByteSizeSetting(
+		"kv.tenant_rate_limiter.read_bytes.burst_limit",
+		"per-tenant burst limit in bytes read",
+		256<<20, // 256 MiB
+		validatePositiveInt,
+	)
+	writeRateLimit = settings.RegisterValidatedByteSizeSetting(
+		"kv.tenant_rate_limiter.write_bytes.rate_limit",
+		"per-tenant rate limit in bytes written per second",
+		16<<20, // 16 MiB
+		validatePositiveInt,
+	)
+	writeBurstLimit = settings.RegisterValidatedByteSizeSetting(
+		"kv.tenant_rate_limiter.write_bytes.burst_limit",
+		"per-tenant burst limit in bytes written",
+		64<<20, // 64 MiB
+		validatePositiveInt,
+	)
+
+	// configSettings is the list of settings that make up a Config.
+	configSettings = [...]settings.WritableSetting{
+		requestRateLimit,
+		requestBurstLimit,
+		readRateLimit,
+		readBurstLimit,
+		writeRateLimit,
+		writeBurstLimit,
+	}
+)
+
+// ConfigFromSettings constructs a Config from the cluster settings.
+func ConfigFromSettings(sv *settings.Values) Config {
+	return Config{
+		Requests: LimitConfig{
+			Rate:  requestRateLimit.Get(sv),
+			Burst: float64(requestBurstLimit.Get(sv)),
+		},
+		ReadBytes: LimitConfig{
+			Rate:  float64(readRateLimit.Get(sv)),
+			Burst: float64(readBurstLimit.Get(sv)),
+		},
+		WriteBytes: LimitConfig{
+			Rate:  float64(writeRateLimit.Get(sv)),
+			Burst: float64(writeBurstLimit.Get(sv)),
+		},
+	}
+}
@@ -11,6 +11,7 @@
 package kv
 
 import (
+	"bytes"
 	"context"
 	"time"
 
@@ -161,6 +162,51 @@ func RangeLookup(
 	rc roachpb.ReadConsistencyType,
 	prefetchNum int64,
 	prefetchReverse bool,
+) (rs, preRs []roachpb.RangeDescriptor, err error) {
+	return rangeLookup(ctx, sender, key, rc, prefetchNum, prefetchReverse, nil /* metaSpan */)
+}
+
+// TenantRangeLookup is like RangeLookup, but is used by SQL tenants, which are
+// only permitted to read the addressing records of the ranges that make up
+// their own keyspace. The meta2 scans of lookups for keys in the tenant's
+// keyspace are constrained to these records, which means that prefetching
+// stops at the end of the keyspace. Lookups for other keys are left as is, to
+// be rejected by the server.
+func TenantRangeLookup(
+	ctx context.Context,
+	sender Sender,
+	tenID roachpb.TenantID,
+	key roachpb.Key,
+	rc roachpb.ReadConsistencyType,
+	prefetchNum int64,
+	prefetchReverse bool,
+) (rs, preRs []roachpb.RangeDescriptor, err error) {
+	var metaSpan *roachpb.RSpan
+	if rkey, err := addrForDir(prefetchReverse)(key); err == nil {
+		prefix := roachpb.RKey(keys.MakeTenantPrefix(tenID))
+		tenSpan := roachpb.RSpan{Key: prefix, EndKey: prefix.PrefixEnd()}
+		contained := tenSpan.ContainsKey(rkey)
+		if prefetchReverse {
+			contained = tenSpan.ContainsKeyInverted(rkey)
+		}
+		if contained {
+			tenMetaSpan := keys.TenantMetaSpan(tenID)
+			metaSpan = &tenMetaSpan
+		}
+	}
+	return rangeLookup(ctx, sender, key, rc, prefetchNum, prefetchReverse, metaSpan)
+}
+
+// rangeLookup implements RangeLookup. If metaSpan is set, the meta2 scans are
+// constrained to it.
+func rangeLookup(
+	ctx context.Context,
+	sender Sender,
+	key roachpb.Key,
+	rc roachpb.ReadConsistencyType,
+	prefetchNum int64,
+	prefetchReverse bool,
+	metaSpan *roachpb.RSpan,
 ) (rs, preRs []roachpb.RangeDescriptor, err error) {
 	// RangeLookup scans can span multiple ranges, as discussed above.
 	// Traditionally, in order to see a fully-consistent snapshot of multiple
@@ -194,13 +240,14 @@ func RangeLookup(
 			return nil, nil, err
 		}
 
-		descs, intentDescs, err := lookupRangeFwdScan(ctx, sender, rkey, rc, prefetchNum, prefetchReverse)
+		descs, intentDescs, err := lookupRangeFwdScan(
+			ctx, sender, rkey, rc, prefetchNum, prefetchReverse, metaSpan)
 		if err != nil {
 			return nil, nil, err
 		}
 		if prefetchReverse {
 			descs, intentDescs, err = lookupRangeRevScan(ctx, sender, rkey, rc, prefetchNum,
-				prefetchReverse, descs, intentDescs)
+				prefetchReverse, metaSpan, descs, intentDescs)
 			if err != nil {
 				return nil, nil, err
 			}
@@ -258,6 +305,7 @@ func lookupRangeFwdScan(
 	rc roachpb.ReadConsistencyType,
 	prefetchNum int64,
 	prefetchReverse bool,
+	metaSpan *roachpb.RSpan,
 ) (rs, preRs []roachpb.RangeDescriptor, err error) {
 	if skipFwd := prefetchReverse && key.Equal(roachpb.KeyMax); skipFwd {
 		// Don't attempt a forward scan because it's neither defined nor required.
@@ -274,6 +322,13 @@ func lookupRangeFwdScan(
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "could not create scan bounds for range lookup")
 	}
+	bounds, ok := constrainMetaScanBounds(bounds, metaSpan)
+	if !ok {
+		// The scan would only find addressing records beyond the end of the
+		// permitted span. This is the case in the forward scan of a reverse
+		// lookup of the end key of a tenant's keyspace, which isn't needed.
+		return nil, nil, nil
+	}
 
 	ba := roachpb.BatchRequest{}
 	ba.ReadConsistency = rc
@@ -346,6 +401,7 @@ func lookupRangeRevScan(
 	rc roachpb.ReadConsistencyType,
 	prefetchNum int64,
 	prefetchReverse bool,
+	metaSpan *roachpb.RSpan,
 	fwdDescs, fwdIntentDescs []roachpb.RangeDescriptor,
 ) (rs, preRs []roachpb.RangeDescriptor, err error) {
 	// If the forward scan already found the desired descriptor, subtract from
@@ -367,6 +423,10 @@ func lookupRangeRevScan(
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "could not create scan bounds for reverse range lookup")
 	}
+	revBounds, ok := constrainMetaScanBounds(revBounds, metaSpan)
+	if !ok {
+		return fwdDescs, fwdIntentDescs, nil
+	}
 
 	ba := roachpb.BatchRequest{}
 	ba.ReadConsistency = rc
@@ -395,6 +455,24 @@ func lookupRangeRevScan(
 	return append(fwdDescs, revDescs...), append(fwdIntentDescs, revIntentDescs...), nil
 }
 
+// constrainMetaScanBounds constrains the bounds of a meta2 scan to the
+// provided span, if set. Returns false if the constrained bounds are empty.
+// Meta1 scans are not constrained.
+func constrainMetaScanBounds(
+	bounds roachpb.RSpan, metaSpan *roachpb.RSpan,
+) (roachpb.RSpan, bool) {
+	if metaSpan == nil || !bytes.HasPrefix(bounds.Key, keys.Meta2Prefix) {
+		return bounds, true
+	}
+	if bounds.Key.Less(metaSpan.Key) {
+		bounds.Key = metaSpan.Key
+	}
+	if metaSpan.EndKey.Less(bounds.EndKey) {
+		bounds.EndKey = metaSpan.EndKey
+	}
+	return bounds, bounds.Key.Less(bounds.EndKey)
+}
+
 // addrForDir determines the key addressing function to use for a RangeLookup
 // scan in the given direction. With either addressing function, the result is
 // the identity fn if the key is not a local key. However, for local keys, we
@@ -173,6 +173,63 @@ func TestRangeLookupRaceSplits(t *testing.T) {
 	})
 }
 
+// TestTenantRangeLookup tests that the meta2 scans of the range lookups of SQL
+// tenants don't extend beyond the addressing records of the tenant's ranges.
+func TestTenantRangeLookup(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	tenID := roachpb.MakeTenantID(10)
+	prefix := roachpb.RKey(keys.MakeTenantPrefix(tenID))
+	desc := roachpb.RangeDescriptor{
+		RangeID:  1,
+		StartKey: prefix,
+		EndKey:   prefix.PrefixEnd(),
+	}
+	metaSpan := keys.TenantMetaSpan(tenID)
+	lookupKey := append(prefix[:len(prefix):len(prefix)], 'k')
+	metaKey := keys.RangeMetaKey(lookupKey)
+
+	testutils.RunTrueAndFalse(t, "reverse", func(t *testing.T, reverse bool) {
+		var spans []roachpb.Span
+		sender := SenderFunc(func(_ context.Context, ba roachpb.BatchRequest) (*roachpb.BatchResponse, *roachpb.Error) {
+			req := ba.Requests[0].GetInner()
+			spans = append(spans, req.Header().Span())
+			if _, ok := req.(*roachpb.ReverseScanRequest); ok {
+				br := &roachpb.BatchResponse{}
+				br.Add(&roachpb.ReverseScanResponse{})
+				return br, nil
+			}
+			return newScanRespFromRangeDescriptors(&desc), nil
+		})
+
+		rs, _, err := TenantRangeLookup(
+			context.Background(),
+			sender,
+			tenID,
+			lookupKey.AsRawKey(),
+			roachpb.READ_UNCOMMITTED,
+			8, /* prefetchNum */
+			reverse,
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expRs := []roachpb.RangeDescriptor{desc}; !reflect.DeepEqual(expRs, rs) {
+			t.Fatalf("expected RangeLookup to return %v, found %v", expRs, rs)
+		}
+		expSpans := []roachpb.Span{
+			{Key: metaKey.Next().AsRawKey(), EndKey: metaSpan.EndKey.AsRawKey()},
+		}
+		if reverse {
+			expSpans = append(expSpans,
+				roachpb.Span{Key: metaSpan.Key.AsRawKey(), EndKey: metaKey.Next().AsRawKey()})
+		}
+		if !reflect.DeepEqual(expSpans, spans) {
+			t.Fatalf("expected range lookup scans %v, found %v", expSpans, spans)
+		}
+	})
+}
+
 func newScanRespFromRangeDescriptors(descs ...*roachpb.RangeDescriptor) *roachpb.BatchResponse {
 	br := &roachpb.BatchResponse{}
 	r := &roachpb.ScanResponse{}
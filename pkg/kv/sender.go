@@ -290,6 +290,17 @@ type TxnSender interface {
 	// GetSteppingMode accompanies ConfigureStepping. It is provided
 	// for use in tests and assertion checks.
 	GetSteppingMode(ctx context.Context) (curMode SteppingMode)
+
+	// GetReadSeqNum returns the sequence number at which read-only
+	// operations currently observe the transaction's own writes, i.e.
+	// the sequencing point established by the last call to Step().
+	GetReadSeqNum() enginepb.TxnSeq
+
+	// SetReadSeqNum moves the sequencing point to the given sequence
+	// number, which must have been obtained from GetReadSeqNum() since
+	// the last epoch bump. It allows a reader that was suspended to
+	// continue observing the snapshot it started with.
+	SetReadSeqNum(seq enginepb.TxnSeq) error
 }
 
 // SteppingMode is the argument type to ConfigureStepping.
@@ -72,6 +72,10 @@ type Txn struct {
 		// The txn has to be committed by this deadline. A nil value indicates no
 		// deadline.
 		deadline *hlc.Timestamp
+
+		// routingPolicy is attached to all requests sent through this
+		// transaction and instructs the DistSender how to route them.
+		routingPolicy roachpb.RoutingPolicy
 	}
 }
 
@@ -904,6 +908,9 @@ func (txn *Txn) Send(
 	txn.mu.Lock()
 	requestTxnID := txn.mu.ID
 	sender := txn.mu.sender
+	if txn.mu.routingPolicy != roachpb.LEASEHOLDER {
+		ba.Header.RoutingPolicy = txn.mu.routingPolicy
+	}
 	txn.mu.Unlock()
 	br, pErr := txn.db.sendUsingSender(ctx, ba, sender)
 	if pErr == nil {
@@ -1215,6 +1222,28 @@ func (txn *Txn) deadline() *hlc.Timestamp {
 	return txn.mu.deadline
 }
 
+// Deadline returns the deadline by which the transaction has to be committed,
+// or false if the transaction doesn't have one.
+func (txn *Txn) Deadline() (hlc.Timestamp, bool) {
+	if d := txn.deadline(); d != nil {
+		return *d, true
+	}
+	return hlc.Timestamp{}, false
+}
+
+// SetRoutingPolicy sets the policy that the DistSender uses to route the
+// requests sent through this transaction to the replicas of their ranges. It
+// should only be set to NEAREST for read-only transactions whose fixed
+// timestamp is known to be closed on the nearest replicas.
+func (txn *Txn) SetRoutingPolicy(policy roachpb.RoutingPolicy) {
+	if txn.typ != RootTxn {
+		panic(errors.AssertionFailedf("SetRoutingPolicy() called on leaf txn"))
+	}
+	txn.mu.Lock()
+	defer txn.mu.Unlock()
+	txn.mu.routingPolicy = policy
+}
+
 // Active returns true iff some commands have been performed with
 // this txn already.
 //
@@ -1256,6 +1285,28 @@ func (txn *Txn) ConfigureStepping(ctx context.Context, mode SteppingMode) (prevM
 	return txn.mu.sender.ConfigureStepping(ctx, mode)
 }
 
+// GetReadSeqNum returns the sequence number at which the reads of the
+// transaction currently observe its writes. See SetReadSeqNum.
+func (txn *Txn) GetReadSeqNum() enginepb.TxnSeq {
+	txn.mu.Lock()
+	defer txn.mu.Unlock()
+	return txn.mu.sender.GetReadSeqNum()
+}
+
+// SetReadSeqNum sets the sequence number at which the reads of the
+// transaction observe its writes. It can be used to resume a read that
+// started at an earlier sequencing point, ignoring the writes performed
+// since. Step-wise execution must be already enabled.
+func (txn *Txn) SetReadSeqNum(ctx context.Context, seq enginepb.TxnSeq) error {
+	if txn.typ != RootTxn {
+		return errors.WithContextTags(
+			errors.AssertionFailedf("txn.SetReadSeqNum() only allowed in RootTxn"), ctx)
+	}
+	txn.mu.Lock()
+	defer txn.mu.Unlock()
+	return txn.mu.sender.SetReadSeqNum(seq)
+}
+
 // CreateSavepoint establishes a savepoint.
 // This method is only valid when called on RootTxns.
 func (txn *Txn) CreateSavepoint(ctx context.Context) (SavepointToken, error) {
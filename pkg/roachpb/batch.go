@@ -197,6 +197,16 @@ func (ba *BatchRequest) IsSingleSubsumeRequest() bool {
 	return false
 }
 
+// IsSingleQueryResolvedTimestampRequest returns true iff the batch contains a
+// single request, and that request is a QueryResolvedTimestampRequest.
+func (ba *BatchRequest) IsSingleQueryResolvedTimestampRequest() bool {
+	if ba.IsSingleRequest() {
+		_, ok := ba.Requests[0].GetInner().(*QueryResolvedTimestampRequest)
+		return ok
+	}
+	return false
+}
+
 // IsSingleComputeChecksumRequest returns true iff the batch contains a single
 // request, and that request is a ComputeChecksumRequest.
 func (ba *BatchRequest) IsSingleComputeChecksumRequest() bool {
@@ -1478,13 +1478,14 @@ func confChangeImpl(
 			if err := checkExists(rDesc); err != nil {
 				return nil, err
 			}
-		case VOTER_DEMOTING:
+		case VOTER_DEMOTING, VOTER_DEMOTING_NON_VOTER:
 			// If a voter is demoted through joint consensus, it will
 			// be turned into a demoting voter first.
 			if err := checkExists(rDesc); err != nil {
 				return nil, err
 			}
-			// It's being re-added as a learner, not only removed.
+			// It's being re-added as a learner (or non-voter, which raft also
+			// considers a learner), not only removed.
 			sl = append(sl, raftpb.ConfChangeSingle{
 				Type:   raftpb.ConfChangeAddLearnerNode,
 				NodeID: uint64(rDesc.ReplicaID),
@@ -1499,6 +1500,11 @@ func confChangeImpl(
 			if err := checkNotExists(rDesc); err != nil {
 				return nil, err
 			}
+		case NON_VOTER:
+			// Non-voters are removed outright.
+			if err := checkNotExists(rDesc); err != nil {
+				return nil, err
+			}
 		case VOTER_FULL:
 			// A voter can't be in the descriptor if it's being removed.
 			if err := checkNotExists(rDesc); err != nil {
@@ -1533,6 +1539,11 @@ func confChangeImpl(
 			// Demotions (i.e. transitioning from voter to learner) are not
 			// represented in `added`; they're handled in `removed` above.
 			changeType = raftpb.ConfChangeAddLearnerNode
+		case NON_VOTER:
+			// We're adding a non-voter, which is a learner as far as raft is
+			// concerned. As with learners, demotions of voters to non-voters
+			// are handled in `removed` above.
+			changeType = raftpb.ConfChangeAddLearnerNode
 		default:
 			// A voter that is demoting was just removed and re-added in the
 			// `removals` handler. We should not see it again here.
@@ -1552,7 +1563,7 @@ func confChangeImpl(
 	var enteringJoint bool
 	for _, rDesc := range replicas {
 		switch rDesc.GetType() {
-		case VOTER_INCOMING, VOTER_OUTGOING, VOTER_DEMOTING:
+		case VOTER_INCOMING, VOTER_OUTGOING, VOTER_DEMOTING, VOTER_DEMOTING_NON_VOTER:
 			enteringJoint = true
 		default:
 		}
@@ -1743,6 +1743,7 @@ func TestChangeReplicasTrigger_ConfChange(t *testing.T) {
 	vo1 := sl(VOTER_OUTGOING, 1)
 	vi1 := sl(VOTER_INCOMING, 1)
 	vl1 := sl(LEARNER, 1)
+	nv1 := sl(NON_VOTER, 1)
 
 	testCases := []struct {
 		crt mockCRT
@@ -1775,6 +1776,12 @@ func TestChangeReplicasTrigger_ConfChange(t *testing.T) {
 			NodeID: 1,
 		}},
 
+		// Adding a non-voter via the V1 path.
+		{crt: mk(in{add: nv1, repls: nv1}), exp: raftpb.ConfChange{
+			Type:   raftpb.ConfChangeAddLearnerNode,
+			NodeID: 1,
+		}},
+
 		// Removing a voter or learner via the V1 path but falsely the replica is still in the descriptor.
 		{crt: mk(in{del: vf1, repls: vf1}), err: "(n3,s2):1 must no longer be present in descriptor"},
 		{crt: mk(in{del: vl1, repls: vl1}), err: "(n3,s2):1LEARNER must no longer be present in descriptor"},
@@ -1787,6 +1794,11 @@ func TestChangeReplicasTrigger_ConfChange(t *testing.T) {
 			Type:   raftpb.ConfChangeRemoveNode,
 			NodeID: 1,
 		}},
+		{crt: mk(in{del: nv1}), exp: raftpb.ConfChange{
+			Type:   raftpb.ConfChangeRemoveNode,
+			NodeID: 1,
+		}},
+		{crt: mk(in{del: nv1, repls: nv1}), err: "(n3,s2):1NON_VOTER must no longer be present in descriptor"},
 		// Adding a voter via the V2 path but without joint consensus.
 		{crt: mk(in{v2: true, add: vf1, repls: vf1}), exp: raftpb.ConfChangeV2{
 			Transition: raftpb.ConfChangeTransitionAuto,
@@ -1874,6 +1886,25 @@ func TestChangeReplicasTrigger_ConfChange(t *testing.T) {
 				}},
 		},
 
+		// Swap a voter and a non-voter: the non-voter is promoted while the voter
+		// is demoted to a non-voter.
+		{crt: mk(in{
+			add: sl(VOTER_INCOMING, 2),
+			del: sl(VOTER_DEMOTING_NON_VOTER, 3),
+			repls: sl(
+				VOTER_FULL, 1,
+				VOTER_INCOMING, 2, // promoted
+				VOTER_DEMOTING_NON_VOTER, 3, // demoted
+			)}),
+			exp: raftpb.ConfChangeV2{
+				Transition: raftpb.ConfChangeTransitionJointExplicit,
+				Changes: []raftpb.ConfChangeSingle{
+					{NodeID: 3, Type: raftpb.ConfChangeRemoveNode},
+					{NodeID: 3, Type: raftpb.ConfChangeAddLearnerNode},
+					{NodeID: 2, Type: raftpb.ConfChangeAddNode},
+				}},
+		},
+
 		// Leave a joint config.
 		{
 			crt: mk(in{repls: sl(VOTER_FULL, 1)}),
@@ -371,6 +371,14 @@ func (r ReplicaDescriptor) GetType() ReplicaType {
 	return *r.Type
 }
 
+// IsVoter returns whether the replica is a voter in the incoming or outgoing
+// configuration of the range's Raft group. LEARNER and NON_VOTER replicas are
+// both Raft learners and are not voters.
+func (r ReplicaDescriptor) IsVoter() bool {
+	typ := r.GetType()
+	return typ != LEARNER && typ != NON_VOTER
+}
+
 // SafeValue implements the redact.SafeValue interface.
 func (r ReplicaType) SafeValue() {}
 
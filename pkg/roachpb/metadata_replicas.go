@@ -52,6 +52,20 @@ func ReplicaTypeLearner() *ReplicaType {
 	return &t
 }
 
+// ReplicaTypeNonVoter returns a NON_VOTER pointer suitable for use in
+// a nullable proto field.
+func ReplicaTypeNonVoter() *ReplicaType {
+	t := NON_VOTER
+	return &t
+}
+
+// ReplicaTypeVoterDemotingNonVoter returns a VOTER_DEMOTING_NON_VOTER pointer
+// suitable for use in a nullable proto field.
+func ReplicaTypeVoterDemotingNonVoter() *ReplicaType {
+	t := VOTER_DEMOTING_NON_VOTER
+	return &t
+}
+
 // ReplicaDescriptors is a set of replicas, usually the nodes/stores on which
 // replicas of a range are stored.
 type ReplicaDescriptors struct {
@@ -86,8 +100,8 @@ func (d ReplicaDescriptors) String() string {
 	return buf.String()
 }
 
-// All returns every replica in the set, including both voter replicas and
-// learner replicas. Voter replicas are ordered first in the returned slice.
+// All returns every replica in the set, including voter, non-voter and learner
+// replicas. Voter replicas are ordered first in the returned slice.
 func (d ReplicaDescriptors) All() []ReplicaDescriptor {
 	return d.wrapped
 }
@@ -105,6 +119,14 @@ func predLearner(rDesc ReplicaDescriptor) bool {
 	return rDesc.GetType() == LEARNER
 }
 
+func predNonVoter(rDesc ReplicaDescriptor) bool {
+	return rDesc.GetType() == NON_VOTER
+}
+
+func predVoterOrNonVoter(rDesc ReplicaDescriptor) bool {
+	return predVoterFullOrIncoming(rDesc) || predNonVoter(rDesc)
+}
+
 // Voters returns the current and future voter replicas in the set. This means
 // that during an atomic replication change, only the replicas that will be
 // voters once the change completes will be returned; "outgoing" voters will not
@@ -122,6 +144,28 @@ func (d ReplicaDescriptors) Voters() []ReplicaDescriptor {
 	return d.Filter(predVoterFullOrIncoming)
 }
 
+// NonVoters returns the non-voting replicas in the set. This may allocate, but
+// it also may return the underlying slice as a performance optimization, so
+// it's not safe to modify the returned value.
+//
+// Non-voters are raft learners that are a stable part of the range's
+// configuration: they are placed by the allocator according to the zone
+// config's num_replicas and num_voters, receive the raft log, and serve
+// follower reads, but they don't count towards quorum and are never
+// considered for the lease. Voters that are in the process of being demoted
+// to non-voters are not included.
+func (d ReplicaDescriptors) NonVoters() []ReplicaDescriptor {
+	return d.Filter(predNonVoter)
+}
+
+// VotersAndNonVoters returns the current and future voter replicas along with
+// the non-voting replicas in the set, i.e. all the replicas that are expected
+// to stay part of the range once any ongoing replication change completes,
+// excluding learners. It is not safe to modify the returned value.
+func (d ReplicaDescriptors) VotersAndNonVoters() []ReplicaDescriptor {
+	return d.Filter(predVoterOrNonVoter)
+}
+
 // Learners returns the learner replicas in the set. This may allocate, but it
 // also may return the underlying slice as a performance optimization, so it's
 // not safe to modify the returned value.
@@ -282,9 +326,9 @@ func (d *ReplicaDescriptors) RemoveReplica(
 func (d ReplicaDescriptors) InAtomicReplicationChange() bool {
 	for _, rDesc := range d.wrapped {
 		switch rDesc.GetType() {
-		case VOTER_INCOMING, VOTER_OUTGOING, VOTER_DEMOTING:
+		case VOTER_INCOMING, VOTER_OUTGOING, VOTER_DEMOTING, VOTER_DEMOTING_NON_VOTER:
 			return true
-		case VOTER_FULL, LEARNER:
+		case VOTER_FULL, LEARNER, NON_VOTER:
 		default:
 			panic(fmt.Sprintf("unknown replica type %d", rDesc.GetType()))
 		}
@@ -312,10 +356,10 @@ func (d ReplicaDescriptors) ConfState() raftpb.ConfState {
 			cs.Voters = append(cs.Voters, id)
 		case VOTER_OUTGOING:
 			cs.VotersOutgoing = append(cs.VotersOutgoing, id)
-		case VOTER_DEMOTING:
+		case VOTER_DEMOTING, VOTER_DEMOTING_NON_VOTER:
 			cs.VotersOutgoing = append(cs.VotersOutgoing, id)
 			cs.LearnersNext = append(cs.LearnersNext, id)
-		case LEARNER:
+		case LEARNER, NON_VOTER:
 			cs.Learners = append(cs.Learners, id)
 		default:
 			panic(fmt.Sprintf("unknown ReplicaType %d", typ))
@@ -330,7 +374,7 @@ func (d ReplicaDescriptors) ConfState() raftpb.ConfState {
 func (d ReplicaDescriptors) CanMakeProgress(liveFunc func(descriptor ReplicaDescriptor) bool) bool {
 	isVoterOldConfig := func(rDesc ReplicaDescriptor) bool {
 		switch rDesc.GetType() {
-		case VOTER_FULL, VOTER_OUTGOING, VOTER_DEMOTING:
+		case VOTER_FULL, VOTER_OUTGOING, VOTER_DEMOTING, VOTER_DEMOTING_NON_VOTER:
 			return true
 		default:
 			return false
@@ -100,6 +100,26 @@ func TestRangeDescriptorSafeMessage(t *testing.T) {
 	}
 }
 
+func TestReplicaDescriptorIsVoter(t *testing.T) {
+	if !(ReplicaDescriptor{}).IsVoter() {
+		t.Errorf("expected a replica without a type to be a voter")
+	}
+	for typ, exp := range map[ReplicaType]bool{
+		VOTER_FULL:               true,
+		VOTER_INCOMING:           true,
+		VOTER_OUTGOING:           true,
+		VOTER_DEMOTING:           true,
+		VOTER_DEMOTING_NON_VOTER: true,
+		LEARNER:                  false,
+		NON_VOTER:                false,
+	} {
+		typ := typ
+		if act := (ReplicaDescriptor{Type: &typ}).IsVoter(); act != exp {
+			t.Errorf("%s: expected IsVoter() = %t, got %t", typ, exp, act)
+		}
+	}
+}
+
 func TestRangeDescriptorMissingReplica(t *testing.T) {
 	desc := RangeDescriptor{}
 	r, ok := desc.GetReplicaDescriptor(0)
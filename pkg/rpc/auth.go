@@ -0,0 +1,409 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"strconv"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/util/grpcutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+var errTLSInfoMissing = authError("internal authentication error: TLSInfo is not available in request context")
+
+func authError(msg string) error {
+	return status.Error(codes.Unauthenticated, msg)
+}
+
+func authErrorf(format string, a ...interface{}) error {
+	return status.Errorf(codes.Unauthenticated, format, a...)
+}
+
+// kvAuth is the authentication and authorization policy of the RPCs served by
+// a KV node. Clients presenting a node or root client certificate may invoke
+// any RPC. Clients presenting a tenant client certificate are SQL tenants,
+// which may only invoke the RPCs they need, and only on their own keyspace.
+type kvAuth struct {
+	// clientCAs returns the CA certificates that node and root client
+	// certificates, and tenant client certificates, must be verified with.
+	clientCAs func() (clientCAs, tenantCAs []*x509.Certificate, _ error)
+	tenant    tenantAuthorizer
+}
+
+func (a kvAuth) unaryInterceptor(
+	ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler,
+) (interface{}, error) {
+	tenID, err := a.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if tenID != (roachpb.TenantID{}) {
+		if err := a.tenant.authorize(tenID, info.FullMethod, req); err != nil {
+			return nil, err
+		}
+		ctx = roachpb.NewContextForTenant(ctx, tenID)
+	}
+	return handler(ctx, req)
+}
+
+func (a kvAuth) streamInterceptor(
+	srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler,
+) error {
+	tenID, err := a.authenticate(ss.Context())
+	if err != nil {
+		return err
+	}
+	if tenID != (roachpb.TenantID{}) {
+		if err := a.tenant.authorizeMethod(info.FullMethod); err != nil {
+			return err
+		}
+		ss = &tenantServerStream{
+			ServerStream: ss,
+			ctx:          roachpb.NewContextForTenant(ss.Context(), tenID),
+			authorize: func(m interface{}) error {
+				return a.tenant.authorize(tenID, info.FullMethod, m)
+			},
+		}
+	}
+	return handler(srv, ss)
+}
+
+// authenticate verifies the client certificate of an incoming RPC. It returns
+// the ID of the tenant that the RPC originates from, or the zero TenantID if
+// the RPC originates from a KV node or a root client.
+func (a kvAuth) authenticate(ctx context.Context) (roachpb.TenantID, error) {
+	if grpcutil.IsLocalRequestContext(ctx) {
+		// This is an in-process request. Bypass authentication check.
+		return roachpb.TenantID{}, nil
+	}
+
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return roachpb.TenantID{}, errTLSInfoMissing
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok {
+		return roachpb.TenantID{}, errTLSInfoMissing
+	}
+	if len(tlsInfo.State.PeerCertificates) == 0 {
+		return roachpb.TenantID{}, authError("no client certificates in request")
+	}
+
+	// The certificate chain was verified during the TLS handshake, so the
+	// client certificate is known to be signed by one of the client CAs. The
+	// server trusts both the client CA and the tenant CA though, so check that
+	// the certificate was verified by the CA that may issue its identity.
+	clientCAs, tenantCAs, err := a.clientCAs()
+	if err != nil {
+		return roachpb.TenantID{}, err
+	}
+	clientCert := tlsInfo.State.PeerCertificates[0]
+	if security.IsTenantCert(clientCert) {
+		if !security.VerifiedByCA(tlsInfo.State.VerifiedChains, tenantCAs) {
+			return roachpb.TenantID{}, authError("tenant client certificate is not signed by the tenant CA")
+		}
+		return tenantFromCommonName(clientCert.Subject.CommonName)
+	}
+	if !security.VerifiedByCA(tlsInfo.State.VerifiedChains, clientCAs) {
+		return roachpb.TenantID{}, authError("client certificate is not signed by the client CA")
+	}
+
+	// TODO(benesch): the vast majority of RPCs should be limited to just
+	// NodeUser. This is not a security concern, as RootUser has access to
+	// read and write all data, merely good hygiene. For example, there is
+	// no reason to permit the root user to send raw Raft RPCs.
+	certUsers, err := security.GetCertificateUsers(&tlsInfo.State)
+	if err != nil {
+		return roachpb.TenantID{}, err
+	}
+	if !security.ContainsUser(security.NodeUser, certUsers) &&
+		!security.ContainsUser(security.RootUser, certUsers) {
+		return roachpb.TenantID{}, authErrorf("user %s is not allowed to perform this RPC", certUsers)
+	}
+	return roachpb.TenantID{}, nil
+}
+
+// tenantFromCommonName parses the ID of the tenant that a tenant client
+// certificate was issued to from the certificate's common name.
+func tenantFromCommonName(commonName string) (roachpb.TenantID, error) {
+	tenID, err := strconv.ParseUint(commonName, 10, 64)
+	if err != nil {
+		return roachpb.TenantID{}, authErrorf("could not parse tenant ID from Common Name (CN): %s", err)
+	}
+	if tenID < roachpb.MinTenantID.ToUint64() || tenID > roachpb.MaxTenantID.ToUint64() {
+		return roachpb.TenantID{}, authErrorf("invalid tenant ID %d in Common Name (CN)", tenID)
+	}
+	return roachpb.MakeTenantID(tenID), nil
+}
+
+// tenantServerStream wraps the server stream of a streaming RPC invoked by a
+// tenant. It attaches the tenant to the stream's context and authorizes every
+// message received from the tenant.
+type tenantServerStream struct {
+	grpc.ServerStream
+	ctx       context.Context
+	authorize func(interface{}) error
+}
+
+// Context overrides the nested grpc.ServerStream.Context().
+func (ss *tenantServerStream) Context() context.Context {
+	return ss.ctx
+}
+
+// RecvMsg overrides the nested grpc.ServerStream.RecvMsg().
+func (ss *tenantServerStream) RecvMsg(m interface{}) error {
+	if err := ss.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	// m is now populated and contains the request from the tenant.
+	return ss.authorize(m)
+}
+
+// tenantAuthorizer authorizes RPCs sent by tenants to a KV node.
+type tenantAuthorizer struct{}
+
+const (
+	batchMethod     = "/cockroach.roachpb.Internal/Batch"
+	rangeFeedMethod = "/cockroach.roachpb.Internal/RangeFeed"
+	gossipMethod    = "/cockroach.gossip.Gossip/Gossip"
+	pingMethod      = "/cockroach.rpc.Heartbeat/Ping"
+)
+
+// authorizeMethod authorizes the provided tenant to invoke the RPC with the
+// provided method name.
+func (a tenantAuthorizer) authorizeMethod(fullMethod string) error {
+	switch fullMethod {
+	case batchMethod, rangeFeedMethod, gossipMethod, pingMethod:
+		return nil
+	default:
+		return authErrorf("unknown method %q", fullMethod)
+	}
+}
+
+// authorize authorizes the provided tenant to invoke the RPC with the provided
+// method name and request.
+func (a tenantAuthorizer) authorize(tenID roachpb.TenantID, fullMethod string, req interface{}) error {
+	switch fullMethod {
+	case batchMethod:
+		return a.authBatch(tenID, req.(*roachpb.BatchRequest))
+	case rangeFeedMethod:
+		return a.authRangeFeed(tenID, req.(*roachpb.RangeFeedRequest))
+	case gossipMethod:
+		// The gossip server restricts what tenants are allowed to see and
+		// ignores the infos they send, see gossip.server.
+		return nil
+	case pingMethod:
+		// No restriction on the usage of this endpoint by tenants.
+		return nil
+	default:
+		return authErrorf("unknown method %q", fullMethod)
+	}
+}
+
+// authBatch authorizes the provided tenant to invoke the Batch RPC with the
+// provided args.
+func (a tenantAuthorizer) authBatch(tenID roachpb.TenantID, args *roachpb.BatchRequest) error {
+	tenSpan := tenantPrefix(tenID)
+	if isRangeLookup(args) {
+		return a.authRangeLookup(tenID, tenSpan, args)
+	}
+
+	// Consult reqAllowed to determine whether each request in the batch is
+	// permitted. If not, reject the entire batch.
+	for _, ru := range args.Requests {
+		req := ru.GetInner()
+		if !reqAllowed(req) {
+			return authErrorf("request [%s] not permitted", args.Summary())
+		}
+		if et, ok := req.(*roachpb.EndTxnRequest); ok {
+			if err := authEndTxn(tenSpan, et); err != nil {
+				return err
+			}
+		}
+	}
+
+	// All keys in the request must reside within the tenant's keyspace.
+	rSpan, err := keys.Range(args.Requests)
+	if err != nil {
+		return authError(err.Error())
+	}
+	if !tenSpan.ContainsKeyRange(rSpan.Key, rSpan.EndKey) {
+		return authErrorf("requested key span %s not fully contained in tenant keyspace %s", rSpan, tenSpan)
+	}
+	return nil
+}
+
+// reqMethodAllowlist contains the requests that tenants are permitted to send.
+// Administrative requests and requests that are only sent by the KV layer to
+// itself are not permitted.
+var reqMethodAllowlist = [...]bool{
+	roachpb.Get:                    true,
+	roachpb.Put:                    true,
+	roachpb.ConditionalPut:         true,
+	roachpb.Increment:              true,
+	roachpb.Delete:                 true,
+	roachpb.DeleteRange:            true,
+	roachpb.ClearRange:             true,
+	roachpb.RevertRange:            true,
+	roachpb.Scan:                   true,
+	roachpb.ReverseScan:            true,
+	roachpb.EndTxn:                 true,
+	roachpb.HeartbeatTxn:           true,
+	roachpb.QueryTxn:               true,
+	roachpb.QueryIntent:            true,
+	roachpb.InitPut:                true,
+	roachpb.Refresh:                true,
+	roachpb.RefreshRange:           true,
+	roachpb.QueryResolvedTimestamp: true,
+}
+
+func reqAllowed(r roachpb.Request) bool {
+	m := int(r.Method())
+	return m < len(reqMethodAllowlist) && reqMethodAllowlist[m]
+}
+
+// authEndTxn authorizes the provided EndTxn request, the header of which is
+// checked along with the rest of the batch. The locks it resolves must reside
+// within the tenant's keyspace and it must not carry a commit trigger, which
+// only the KV layer itself is permitted to use.
+func authEndTxn(tenSpan roachpb.RSpan, et *roachpb.EndTxnRequest) error {
+	if et.InternalCommitTrigger != nil {
+		return authError("EndTxn requests with a commit trigger are not permitted")
+	}
+	for _, sp := range et.LockSpans {
+		if err := authSpan(tenSpan, sp); err != nil {
+			return err
+		}
+	}
+	for _, w := range et.InFlightWrites {
+		if err := authSpan(tenSpan, roachpb.Span{Key: w.Key}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isRangeLookup returns whether the batch consists exclusively of scans over
+// the range addressing records, which is how range descriptors are looked up.
+// See kv.RangeLookup.
+func isRangeLookup(args *roachpb.BatchRequest) bool {
+	if len(args.Requests) == 0 {
+		return false
+	}
+	for _, ru := range args.Requests {
+		switch ru.GetInner().(type) {
+		case *roachpb.ScanRequest, *roachpb.ReverseScanRequest:
+		default:
+			return false
+		}
+		h := ru.GetInner().Header()
+		if !bytes.HasPrefix(h.Key, keys.Meta1Prefix) && !bytes.HasPrefix(h.Key, keys.Meta2Prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+// maxTenantRangeLookupKeys is the maximum number of addressing records that a
+// tenant may read with a single range lookup. It accommodates the descriptors
+// that the DistSender prefetches, see kvcoord.rangeLookupPrefetchCount.
+const maxTenantRangeLookupKeys = 16
+
+// authRangeLookup authorizes the provided tenant to look up range descriptors
+// with the provided args. Tenants may only look up the descriptors of the
+// ranges that contain their own keyspace. The meta2 scans of their lookups
+// must not extend beyond the addressing records of these ranges, see
+// kv.TenantRangeLookup, and all lookups are limited in the number of records
+// that they read.
+func (a tenantAuthorizer) authRangeLookup(
+	tenID roachpb.TenantID, tenSpan roachpb.RSpan, args *roachpb.BatchRequest,
+) error {
+	metaSpan := keys.TenantMetaSpan(tenID)
+	for _, ru := range args.Requests {
+		req := ru.GetInner()
+		h := req.Header()
+		if bytes.Compare(h.Key, keys.MetaMin) < 0 || bytes.Compare(h.EndKey, keys.MetaMax) > 0 {
+			return authErrorf("requested key span %s not fully contained in range addressing keyspace", h.Span())
+		}
+		// Range lookups scan forward from just after the addressing record of
+		// the key being looked up, or in reverse from just after it. See
+		// keys.MetaScanBounds and keys.MetaReverseScanBounds.
+		_, reverse := req.(*roachpb.ReverseScanRequest)
+		metaKey := h.Key
+		if reverse {
+			metaKey = h.EndKey
+		}
+		if len(metaKey) == 0 || metaKey[len(metaKey)-1] != 0 {
+			return authErrorf("request [%s] not permitted", args.Summary())
+		}
+		key := roachpb.RKey(metaKey[:len(metaKey)-1])
+		for bytes.HasPrefix(key, keys.Meta1Prefix) || bytes.HasPrefix(key, keys.Meta2Prefix) {
+			key = keys.UserKey(key)
+		}
+		contained := tenSpan.ContainsKey(key)
+		if reverse {
+			contained = tenSpan.ContainsKeyInverted(key)
+		}
+		if !contained {
+			return authErrorf("requested range lookup for key %s not contained in tenant keyspace %s", key, tenSpan)
+		}
+		if bytes.HasPrefix(h.Key, keys.Meta2Prefix) &&
+			!metaSpan.ContainsKeyRange(roachpb.RKey(h.Key), roachpb.RKey(h.EndKey)) {
+			return authErrorf("requested key span %s not fully contained in tenant range addressing keyspace %s",
+				h.Span(), metaSpan)
+		}
+	}
+	if args.MaxSpanRequestKeys <= 0 || args.MaxSpanRequestKeys > maxTenantRangeLookupKeys {
+		return authErrorf("range lookups must be limited to at most %d keys", maxTenantRangeLookupKeys)
+	}
+	return nil
+}
+
+// authRangeFeed authorizes the provided tenant to invoke the RangeFeed RPC with
+// the provided args.
+func (a tenantAuthorizer) authRangeFeed(tenID roachpb.TenantID, args *roachpb.RangeFeedRequest) error {
+	return authSpan(tenantPrefix(tenID), args.Span)
+}
+
+// authSpan returns an error if the provided span does not reside within the
+// provided tenant keyspace.
+func authSpan(tenSpan roachpb.RSpan, sp roachpb.Span) error {
+	var rSpan roachpb.RSpan
+	var err error
+	if rSpan.Key, err = keys.Addr(sp.Key); err != nil {
+		return authError(err.Error())
+	}
+	if len(sp.EndKey) == 0 {
+		rSpan.EndKey = rSpan.Key.Next()
+	} else if rSpan.EndKey, err = keys.AddrUpperBound(sp.EndKey); err != nil {
+		return authError(err.Error())
+	}
+	if !tenSpan.ContainsKeyRange(rSpan.Key, rSpan.EndKey) {
+		return authErrorf("requested key span %s not fully contained in tenant keyspace %s", rSpan, tenSpan)
+	}
+	return nil
+}
+
+// tenantPrefix returns the keyspace of the provided tenant.
+func tenantPrefix(tenID roachpb.TenantID) roachpb.RSpan {
+	prefix := roachpb.RKey(keys.MakeTenantPrefix(tenID))
+	return roachpb.RSpan{Key: prefix, EndKey: prefix.PrefixEnd()}
+}
@@ -0,0 +1,277 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package rpc
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+func TestAuthenticateTenant(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	clientCA := &x509.Certificate{Raw: []byte("client CA")}
+	tenantCA := &x509.Certificate{Raw: []byte("tenant CA")}
+	auth := kvAuth{
+		clientCAs: func() (clientCAs, tenantCAs []*x509.Certificate, _ error) {
+			return []*x509.Certificate{clientCA}, []*x509.Certificate{tenantCA}, nil
+		},
+	}
+	makeCtx := func(subject pkix.Name, ca *x509.Certificate) context.Context {
+		cert := &x509.Certificate{Subject: subject}
+		return peer.NewContext(context.Background(), &peer.Peer{
+			AuthInfo: credentials.TLSInfo{
+				State: tls.ConnectionState{
+					PeerCertificates: []*x509.Certificate{cert},
+					VerifiedChains:   [][]*x509.Certificate{{cert, ca}},
+				},
+			},
+		})
+	}
+	tenantsOU := []string{security.TenantsOU}
+
+	testCases := []struct {
+		subject pkix.Name
+		ca      *x509.Certificate
+		expTen  roachpb.TenantID
+		expErr  string
+	}{
+		{subject: pkix.Name{CommonName: security.NodeUser}, ca: clientCA},
+		{subject: pkix.Name{CommonName: security.RootUser}, ca: clientCA},
+		{subject: pkix.Name{CommonName: "foo"}, ca: clientCA, expErr: `user \[foo\] is not allowed to perform this RPC`},
+		{subject: pkix.Name{CommonName: "10", OrganizationalUnit: tenantsOU}, ca: tenantCA, expTen: roachpb.MakeTenantID(10)},
+		{subject: pkix.Name{CommonName: "1", OrganizationalUnit: tenantsOU}, ca: tenantCA, expErr: `invalid tenant ID 1 in Common Name \(CN\)`},
+		{subject: pkix.Name{CommonName: "0", OrganizationalUnit: tenantsOU}, ca: tenantCA, expErr: `invalid tenant ID 0 in Common Name \(CN\)`},
+		{subject: pkix.Name{CommonName: "root", OrganizationalUnit: tenantsOU}, ca: tenantCA, expErr: `could not parse tenant ID from Common Name \(CN\)`},
+		{subject: pkix.Name{CommonName: "10", OrganizationalUnit: []string{"Nodes"}}, ca: clientCA, expErr: `user \[10\] is not allowed to perform this RPC`},
+		// The tenant CA may not issue certificates for anyone but tenants.
+		{subject: pkix.Name{CommonName: security.NodeUser}, ca: tenantCA, expErr: `client certificate is not signed by the client CA`},
+		{subject: pkix.Name{CommonName: security.RootUser}, ca: tenantCA, expErr: `client certificate is not signed by the client CA`},
+		// Nor may the client CA issue certificates for tenants.
+		{subject: pkix.Name{CommonName: "10", OrganizationalUnit: tenantsOU}, ca: clientCA, expErr: `tenant client certificate is not signed by the tenant CA`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.subject.String(), func(t *testing.T) {
+			tenID, err := auth.authenticate(makeCtx(tc.subject, tc.ca))
+			if tc.expErr == "" {
+				require.NoError(t, err)
+				require.Equal(t, tc.expTen, tenID)
+			} else {
+				require.Regexp(t, tc.expErr, err)
+			}
+		})
+	}
+
+	t.Run("no TLS info", func(t *testing.T) {
+		_, err := auth.authenticate(context.Background())
+		require.Equal(t, errTLSInfoMissing, err)
+	})
+}
+
+func TestTenantAuthRequest(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	tenID := roachpb.MakeTenantID(10)
+	prefix := func(tenID uint64, key string) string {
+		tenPrefix := keys.MakeTenantPrefix(roachpb.MakeTenantID(tenID))
+		return string(append(tenPrefix, []byte(key)...))
+	}
+	makeSpan := func(key string, endKey ...string) roachpb.Span {
+		s := roachpb.Span{Key: roachpb.Key(key)}
+		if len(endKey) > 0 {
+			s.EndKey = roachpb.Key(endKey[0])
+		}
+		return s
+	}
+	makeReq := func(req roachpb.Request) *roachpb.BatchRequest {
+		ba := &roachpb.BatchRequest{}
+		ba.Add(req)
+		return ba
+	}
+	makeGet := func(key string) *roachpb.BatchRequest {
+		return makeReq(&roachpb.GetRequest{RequestHeader: roachpb.RequestHeaderFromSpan(makeSpan(key))})
+	}
+	makeScan := func(key, endKey string) *roachpb.BatchRequest {
+		return makeReq(&roachpb.ScanRequest{RequestHeader: roachpb.RequestHeaderFromSpan(makeSpan(key, endKey))})
+	}
+	// makeLookup constructs a range lookup for the provided key like
+	// kv.TenantRangeLookup does, with meta2 scans that are constrained to the
+	// addressing records of the tenant's ranges.
+	makeLookup := func(key string, reverse bool) *roachpb.BatchRequest {
+		metaKey := keys.RangeMetaKey(roachpb.RKey(key))
+		var bounds roachpb.RSpan
+		var err error
+		var req roachpb.Request
+		if reverse {
+			bounds, err = keys.MetaReverseScanBounds(metaKey)
+			req = &roachpb.ReverseScanRequest{}
+		} else {
+			bounds, err = keys.MetaScanBounds(metaKey)
+			req = &roachpb.ScanRequest{}
+		}
+		require.NoError(t, err)
+		tenSpan := tenantPrefix(tenID)
+		if (!reverse && tenSpan.ContainsKey(roachpb.RKey(key))) ||
+			(reverse && tenSpan.ContainsKeyInverted(roachpb.RKey(key))) {
+			metaSpan := keys.TenantMetaSpan(tenID)
+			if bounds.Key.Less(metaSpan.Key) {
+				bounds.Key = metaSpan.Key
+			}
+			if metaSpan.EndKey.Less(bounds.EndKey) {
+				bounds.EndKey = metaSpan.EndKey
+			}
+		}
+		req.SetHeader(roachpb.RequestHeaderFromSpan(bounds.AsRawSpanWithNoLocals()))
+		ba := makeReq(req)
+		ba.MaxSpanRequestKeys = 9
+		return ba
+	}
+	// makeUnboundedLookup constructs a range lookup for the provided key like
+	// kv.RangeLookup does, the meta2 scans of which may extend to the
+	// addressing records of other tenants.
+	makeUnboundedLookup := func(key string, reverse bool) *roachpb.BatchRequest {
+		metaKey := keys.RangeMetaKey(roachpb.RKey(key))
+		var ba *roachpb.BatchRequest
+		if reverse {
+			bounds, err := keys.MetaReverseScanBounds(metaKey)
+			require.NoError(t, err)
+			ba = makeReq(&roachpb.ReverseScanRequest{
+				RequestHeader: roachpb.RequestHeaderFromSpan(bounds.AsRawSpanWithNoLocals()),
+			})
+		} else {
+			bounds, err := keys.MetaScanBounds(metaKey)
+			require.NoError(t, err)
+			ba = makeReq(&roachpb.ScanRequest{
+				RequestHeader: roachpb.RequestHeaderFromSpan(bounds.AsRawSpanWithNoLocals()),
+			})
+		}
+		ba.MaxSpanRequestKeys = 9
+		return ba
+	}
+	makeMetaLookup := func(key string) *roachpb.BatchRequest {
+		// A lookup of the meta2 range containing the addressing record of key.
+		metaKey := keys.RangeMetaKey(keys.RangeMetaKey(roachpb.RKey(key)))
+		bounds, err := keys.MetaScanBounds(metaKey)
+		require.NoError(t, err)
+		ba := makeReq(&roachpb.ScanRequest{
+			RequestHeader: roachpb.RequestHeaderFromSpan(bounds.AsRawSpanWithNoLocals()),
+		})
+		ba.MaxSpanRequestKeys = 9
+		return ba
+	}
+	withMaxKeys := func(ba *roachpb.BatchRequest, maxKeys int64) *roachpb.BatchRequest {
+		ba.MaxSpanRequestKeys = maxKeys
+		return ba
+	}
+	// crossTenantLookup is a lookup for a key of the tenant, the scan of which
+	// extends into the addressing records of another tenant.
+	crossTenantLookup := makeReq(&roachpb.ScanRequest{
+		RequestHeader: roachpb.RequestHeaderFromSpan(roachpb.Span{
+			Key:    keys.RangeMetaKey(roachpb.RKey(prefix(10, "a"))).Next().AsRawKey(),
+			EndKey: keys.RangeMetaKey(roachpb.RKey(prefix(20, "a"))).AsRawKey(),
+		}),
+	})
+	crossTenantLookup.MaxSpanRequestKeys = 9
+	makeEndTxn := func(key string, lockSpans ...roachpb.Span) *roachpb.BatchRequest {
+		return makeReq(&roachpb.EndTxnRequest{
+			RequestHeader: roachpb.RequestHeaderFromSpan(makeSpan(key)),
+			Commit:        true,
+			LockSpans:     lockSpans,
+		})
+	}
+	tenantEnd := string(roachpb.Key(keys.MakeTenantPrefix(tenID)).PrefixEnd())
+
+	const noError = ""
+	for method, tests := range map[string][]struct {
+		req    interface{}
+		expErr string
+	}{
+		batchMethod: {
+			{req: &roachpb.BatchRequest{}, expErr: `requested key span /Max not fully contained in tenant keyspace /Tenant/1{0-1}`},
+			{req: makeGet(prefix(10, "a")), expErr: noError},
+			{req: makeGet(prefix(5, "a")), expErr: `requested key span /Tenant/5"a.* not fully contained in tenant keyspace /Tenant/1{0-1}`},
+			{req: makeGet("a"), expErr: `requested key span a.* not fully contained in tenant keyspace /Tenant/1{0-1}`},
+			{req: makeScan(prefix(10, "a"), prefix(10, "b")), expErr: noError},
+			{req: makeScan(prefix(10, "a"), prefix(20, "b")), expErr: `requested key span /Tenant/{10"a"-20"b"} not fully contained in tenant keyspace /Tenant/1{0-1}`},
+			{req: makeScan("a", prefix(10, "b")), expErr: `requested key span {a-/Tenant/10"b"} not fully contained in tenant keyspace /Tenant/1{0-1}`},
+			{req: makeReq(&roachpb.AdminSplitRequest{
+				RequestHeader: roachpb.RequestHeaderFromSpan(makeSpan(prefix(10, "a"))),
+			}), expErr: `request \[1 AdmSplit\] not permitted`},
+			{req: makeReq(&roachpb.GCRequest{
+				RequestHeader: roachpb.RequestHeaderFromSpan(makeSpan(prefix(10, "a"), prefix(10, "b"))),
+			}), expErr: `request \[1 Gc\] not permitted`},
+			{req: makeEndTxn(prefix(10, "a"), makeSpan(prefix(10, "b"), prefix(10, "c"))), expErr: noError},
+			{req: makeEndTxn(prefix(10, "a"), makeSpan(prefix(20, "b"))), expErr: `requested key span /Tenant/20"b.* not fully contained in tenant keyspace /Tenant/1{0-1}`},
+			{req: makeReq(&roachpb.EndTxnRequest{
+				RequestHeader:         roachpb.RequestHeaderFromSpan(makeSpan(prefix(10, "a"))),
+				Commit:                true,
+				InternalCommitTrigger: &roachpb.InternalCommitTrigger{},
+			}), expErr: `EndTxn requests with a commit trigger are not permitted`},
+			{req: makeLookup(prefix(10, "a"), false /* reverse */), expErr: noError},
+			{req: makeLookup(prefix(10, ""), false /* reverse */), expErr: noError},
+			{req: makeLookup(prefix(10, "a"), true /* reverse */), expErr: noError},
+			{req: makeLookup(tenantEnd, true /* reverse */), expErr: noError},
+			{req: makeMetaLookup(prefix(10, "a")), expErr: noError},
+			{req: makeLookup(prefix(20, "a"), false /* reverse */), expErr: `requested range lookup for key /Tenant/20"a" not contained in tenant keyspace /Tenant/1{0-1}`},
+			{req: makeLookup("a", true /* reverse */), expErr: `requested range lookup for key "a" not contained in tenant keyspace /Tenant/1{0-1}`},
+			{req: makeMetaLookup("a"), expErr: `requested range lookup for key "a" not contained in tenant keyspace /Tenant/1{0-1}`},
+			{req: makeScan(string(keys.Meta2Prefix), string(keys.MetaMax)), expErr: `request \[1 Scan\] not permitted`},
+			{req: makeUnboundedLookup(prefix(10, "a"), false /* reverse */), expErr: `requested key span .* not fully contained in tenant range addressing keyspace`},
+			{req: makeUnboundedLookup(prefix(10, "a"), true /* reverse */), expErr: `requested key span .* not fully contained in tenant range addressing keyspace`},
+			{req: crossTenantLookup, expErr: `requested key span .* not fully contained in tenant range addressing keyspace`},
+			{req: withMaxKeys(makeLookup(prefix(10, "a"), false /* reverse */), 0), expErr: `range lookups must be limited to at most 16 keys`},
+			{req: withMaxKeys(makeLookup(prefix(10, "a"), false /* reverse */), 1000), expErr: `range lookups must be limited to at most 16 keys`},
+			{req: withMaxKeys(makeMetaLookup(prefix(10, "a")), 0), expErr: `range lookups must be limited to at most 16 keys`},
+		},
+		rangeFeedMethod: {
+			{req: &roachpb.RangeFeedRequest{Span: makeSpan(prefix(10, "a"), prefix(10, "b"))}, expErr: noError},
+			{req: &roachpb.RangeFeedRequest{Span: makeSpan(prefix(10, "a"), prefix(20, "b"))}, expErr: `requested key span /Tenant/{10"a"-20"b"} not fully contained in tenant keyspace /Tenant/1{0-1}`},
+			{req: &roachpb.RangeFeedRequest{Span: makeSpan("a", "b")}, expErr: `requested key span {a-b} not fully contained in tenant keyspace /Tenant/1{0-1}`},
+		},
+		gossipMethod: {
+			{req: nil, expErr: noError},
+		},
+		pingMethod: {
+			{req: &PingRequest{}, expErr: noError},
+		},
+		"/cockroach.roachpb.Internal/Foo": {
+			{req: nil, expErr: `unknown method "/cockroach.roachpb.Internal/Foo"`},
+		},
+		"/cockroach.server.serverpb.Admin/Databases": {
+			{req: nil, expErr: `unknown method "/cockroach.server.serverpb.Admin/Databases"`},
+		},
+	} {
+		t.Run(method, func(t *testing.T) {
+			for _, tc := range tests {
+				t.Run("", func(t *testing.T) {
+					err := tenantAuthorizer{}.authorize(tenID, method, tc.req)
+					if tc.expErr == noError {
+						require.NoError(t, err)
+					} else {
+						require.True(t, testutils.IsError(err, tc.expErr), "expected %q, got %v", tc.expErr, err)
+					}
+				})
+			}
+		})
+	}
+}
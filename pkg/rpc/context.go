@@ -13,6 +13,7 @@ package rpc
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/binary"
 	"fmt"
 	"io"
@@ -25,7 +26,6 @@ import (
 	circuit "github.com/cockroachdb/circuitbreaker"
 	"github.com/cockroachdb/cockroach/pkg/base"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
-	"github.com/cockroachdb/cockroach/pkg/security"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/util/contextutil"
 	"github.com/cockroachdb/cockroach/pkg/util/envutil"
@@ -48,7 +48,6 @@ import (
 	"google.golang.org/grpc/encoding"
 	encodingproto "google.golang.org/grpc/encoding/proto"
 	"google.golang.org/grpc/metadata"
-	"google.golang.org/grpc/peer"
 )
 
 func init() {
@@ -113,34 +112,6 @@ func spanInclusionFuncForClient(
 	return parentSpanCtx != nil && !tracing.IsNoopContext(parentSpanCtx)
 }
 
-func requireSuperUser(ctx context.Context) error {
-	// TODO(marc): grpc's authentication model (which gives credential access in
-	// the request handler) doesn't really fit with the current design of the
-	// security package (which assumes that TLS state is only given at connection
-	// time) - that should be fixed.
-	if grpcutil.IsLocalRequestContext(ctx) {
-		// This is an in-process request. Bypass authentication check.
-	} else if peer, ok := peer.FromContext(ctx); ok {
-		if tlsInfo, ok := peer.AuthInfo.(credentials.TLSInfo); ok {
-			certUsers, err := security.GetCertificateUsers(&tlsInfo.State)
-			if err != nil {
-				return err
-			}
-			// TODO(benesch): the vast majority of RPCs should be limited to just
-			// NodeUser. This is not a security concern, as RootUser has access to
-			// read and write all data, merely good hygiene. For example, there is
-			// no reason to permit the root user to send raw Raft RPCs.
-			if !security.ContainsUser(security.NodeUser, certUsers) &&
-				!security.ContainsUser(security.RootUser, certUsers) {
-				return errors.Errorf("user %s is not allowed to perform this RPC", certUsers)
-			}
-		}
-	} else {
-		return errors.New("internal authentication error: TLSInfo is not available in request context")
-	}
-	return nil
-}
-
 // NewServer is a thin wrapper around grpc.NewServer that registers a heartbeat
 // service.
 func NewServer(ctx *Context) *grpc.Server {
@@ -244,29 +215,34 @@ func NewServerWithInterceptor(
 	}
 
 	if !ctx.Insecure {
+		// The authentication interceptors authorize the requests of SQL tenants
+		// and attach the tenant to the context of the handler.
+		cm, err := ctx.GetCertificateManager()
+		if err != nil {
+			panic(err)
+		}
+		a := kvAuth{clientCAs: cm.ClientCACerts}
 		prevUnaryInterceptor := unaryInterceptor
 		unaryInterceptor = func(
 			ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler,
 		) (interface{}, error) {
-			if err := requireSuperUser(ctx); err != nil {
-				return nil, err
-			}
 			if prevUnaryInterceptor != nil {
-				return prevUnaryInterceptor(ctx, req, info, handler)
+				return a.unaryInterceptor(ctx, req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+					return prevUnaryInterceptor(ctx, req, info, handler)
+				})
 			}
-			return handler(ctx, req)
+			return a.unaryInterceptor(ctx, req, info, handler)
 		}
 		prevStreamInterceptor := streamInterceptor
 		streamInterceptor = func(
 			srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler,
 		) error {
-			if err := requireSuperUser(stream.Context()); err != nil {
-				return err
-			}
 			if prevStreamInterceptor != nil {
-				return prevStreamInterceptor(srv, stream, info, handler)
+				return a.streamInterceptor(srv, stream, info, func(srv interface{}, stream grpc.ServerStream) error {
+					return prevStreamInterceptor(srv, stream, info, handler)
+				})
 			}
-			return handler(srv, stream)
+			return a.streamInterceptor(srv, stream, info, handler)
 		}
 	}
 
@@ -407,6 +383,13 @@ type Context struct {
 
 	// For testing. See the comment on the same field in HeartbeatService.
 	TestingAllowNamedRPCToAnonymousServer bool
+
+	// TenantID is the ID of the SQL tenant that this process runs on behalf
+	// of, if it is a SQL tenant server. If it is set and a client certificate
+	// for the tenant is present, that certificate is presented to the KV
+	// nodes instead of the node certificate, which restricts the RPCs the
+	// process may issue to those permitted for the tenant.
+	TenantID roachpb.TenantID
 }
 
 // connKey is used as key in the Context.conns map.
@@ -656,6 +639,28 @@ func (ctx *Context) GRPCDialOptions() ([]grpc.DialOption, error) {
 	return ctx.grpcDialOptions("", DefaultClass)
 }
 
+// getClientTLSConfig returns the client TLS config used to dial other nodes.
+// SQL tenant servers must use their tenant client certificate.
+func (ctx *Context) getClientTLSConfig() (*tls.Config, error) {
+	if ctx.TenantID == (roachpb.TenantID{}) || ctx.TenantID == roachpb.SystemTenantID {
+		return ctx.GetClientTLSConfig()
+	}
+	cm, err := ctx.GetCertificateManager()
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig, ok, err := cm.GetTenantClientTLSConfig(ctx.TenantID.String())
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		// Falling back to the node certificate would let the tenant
+		// authenticate to KV as a node, bypassing tenant authorization.
+		return nil, errors.Errorf("no client certificate found for tenant %s", ctx.TenantID)
+	}
+	return tlsConfig, nil
+}
+
 // grpcDialOptions extends GRPCDialOptions to support a connection class for use
 // with TestingKnobs.
 func (ctx *Context) grpcDialOptions(
@@ -665,7 +670,7 @@ func (ctx *Context) grpcDialOptions(
 	if ctx.Insecure {
 		dialOpts = append(dialOpts, grpc.WithInsecure())
 	} else {
-		tlsConfig, err := ctx.GetClientTLSConfig()
+		tlsConfig, err := ctx.getClientTLSConfig()
 		if err != nil {
 			return nil, err
 		}
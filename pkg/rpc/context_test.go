@@ -12,6 +12,7 @@ package rpc
 
 import (
 	"context"
+	"crypto/x509"
 	"fmt"
 	"math"
 	"net"
@@ -1713,6 +1714,35 @@ func TestRunHeartbeatSetsHeartbeatStateWhenExitingBeforeFirstHeartbeat(t *testin
 	require.NoError(t, c.grpcConn.Close())
 }
 
+// TestTenantClientTLSConfig verifies that SQL tenant servers dial KV nodes
+// with their tenant client certificate and never fall back to the node
+// certificate.
+func TestTenantClientTLSConfig(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	stopper := stop.NewStopper()
+	defer stopper.Stop(context.Background())
+
+	clock := hlc.NewClock(hlc.UnixNano, time.Nanosecond)
+	ctx := newTestContext(uuid.MakeV4(), clock, stopper)
+
+	// The embedded test certificates include a client certificate for tenant
+	// 10 only.
+	ctx.TenantID = roachpb.MakeTenantID(10)
+	tlsConfig, err := ctx.getClientTLSConfig()
+	require.NoError(t, err)
+	require.Len(t, tlsConfig.Certificates, 1)
+	leaf, err := x509.ParseCertificate(tlsConfig.Certificates[0].Certificate[0])
+	require.NoError(t, err)
+	require.Equal(t, "10", leaf.Subject.CommonName)
+
+	ctx.TenantID = roachpb.MakeTenantID(11)
+	_, err = ctx.getClientTLSConfig()
+	require.EqualError(t, err, "no client certificate found for tenant 11")
+	_, err = ctx.GRPCDialOptions()
+	require.EqualError(t, err, "no client certificate found for tenant 11")
+}
+
 func BenchmarkGRPCDial(b *testing.B) {
 	if testing.Short() {
 		b.Skip("TODO: fix benchmark")
@@ -13,6 +13,7 @@ package security
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -84,6 +85,9 @@ var (
 // - client.<user>.crt  client certificate for 'user'. Verified using 'ca.crt', or 'ca-client.crt'.
 // - client.node.crt    client certificate for the 'node' user. If it does not exist,
 //                      fall back on 'node.crt'.
+// - ca-tenant.crt      optional CA certificate to verify tenant client certificates.
+// - tenant.<id>.crt    client certificate for the SQL tenant with ID 'id'. Verified
+//                      using 'ca-tenant.crt'.
 type CertificateManager struct {
 	// Certificate directory is not modified after initialization.
 	certsDir string
@@ -105,6 +109,10 @@ type CertificateManager struct {
 	nodeClientCert *CertInfo // optional: client certificate for 'node' user. Also included in 'clientCerts'
 	uiCert         *CertInfo // optional: server certificate for the admin UI.
 	clientCerts    map[string]*CertInfo
+	// optional: certificate to verify tenant client certificates.
+	tenantCACert *CertInfo
+	// optional: client certificates for SQL tenants, keyed by tenant ID.
+	tenantClientCerts map[string]*CertInfo
 
 	// TLS configs. Initialized lazily. Wiped on every successful Load().
 	// Server-side config.
@@ -333,6 +341,30 @@ func (cm *CertificateManager) NodeCert() *CertInfo {
 	return cm.nodeCert
 }
 
+// TenantCACert returns the CA cert used to verify tenant client certificates.
+// May be nil. Callers should check for an internal Error field.
+func (cm *CertificateManager) TenantCACert() *CertInfo {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+	return cm.tenantCACert
+}
+
+// ClientCACerts returns the CA certificates used to verify client
+// certificates: those of the client CA, or of the general CA if there is no
+// client CA, and those of the tenant CA, if there is one.
+func (cm *CertificateManager) ClientCACerts() (clientCAs, tenantCAs []*x509.Certificate, _ error) {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+	clientCA, err := cm.getClientCACertLocked()
+	if err != nil {
+		return nil, nil, err
+	}
+	if cm.tenantCACert != nil {
+		tenantCAs = cm.tenantCACert.ParsedCertificates
+	}
+	return clientCA.ParsedCertificates, tenantCAs, nil
+}
+
 // ClientCerts returns the Client certs.
 // Callers should check for internal Error fields.
 func (cm *CertificateManager) ClientCerts() map[string]*CertInfo {
@@ -372,8 +404,9 @@ func (cm *CertificateManager) LoadCertificates() error {
 		return makeErrorf(err, "problem loading certs directory %s", cm.certsDir)
 	}
 
-	var caCert, clientCACert, uiCACert, nodeCert, uiCert, nodeClientCert *CertInfo
+	var caCert, clientCACert, uiCACert, nodeCert, uiCert, nodeClientCert, tenantCACert *CertInfo
 	clientCerts := make(map[string]*CertInfo)
+	tenantClientCerts := make(map[string]*CertInfo)
 	for _, ci := range cl.Certificates() {
 		switch ci.FileUsage {
 		case CAPem:
@@ -391,6 +424,10 @@ func (cm *CertificateManager) LoadCertificates() error {
 			if ci.Name == NodeUser {
 				nodeClientCert = ci
 			}
+		case TenantCAPem:
+			tenantCACert = ci
+		case TenantClientPem:
+			tenantClientCerts[ci.Name] = ci
 		}
 	}
 
@@ -416,6 +453,9 @@ func (cm *CertificateManager) LoadCertificates() error {
 		if err := checkCertIsValid(uiCert); checkCertIsValid(cm.uiCert) == nil && err != nil {
 			return makeError(err, "reload would lose valid UI certificate")
 		}
+		if err := checkCertIsValid(tenantCACert); checkCertIsValid(cm.tenantCACert) == nil && err != nil {
+			return makeError(err, "reload would lose valid CA certificate for tenant verification")
+		}
 	}
 
 	if nodeClientCert == nil && nodeCert != nil {
@@ -435,6 +475,8 @@ func (cm *CertificateManager) LoadCertificates() error {
 	cm.nodeClientCert = nodeClientCert
 	cm.uiCert = uiCert
 	cm.clientCerts = clientCerts
+	cm.tenantCACert = tenantCACert
+	cm.tenantClientCerts = tenantClientCerts
 
 	cm.initialized = true
 
@@ -524,19 +566,56 @@ func (cm *CertificateManager) getEmbeddedServerTLSConfig(
 		return nil, err
 	}
 
+	clientCAs := [][]byte{clientCA.FileContents}
+	// Tenant client certificates are signed by the tenant CA, if there is one.
+	if cm.tenantCACert != nil {
+		if err := checkCertIsValid(cm.tenantCACert); err != nil {
+			return nil, makeError(err, "problem with tenant CA certificate")
+		}
+		clientCAs = append(clientCAs, cm.tenantCACert.FileContents)
+	}
+
 	cfg, err := newServerTLSConfig(
 		nodeCert.FileContents,
 		nodeCert.KeyFileContents,
 		ca.FileContents,
-		clientCA.FileContents)
+		clientCAs...)
 	if err != nil {
 		return nil, err
 	}
+	if cm.tenantCACert != nil {
+		cfg.VerifyPeerCertificate = verifyClientCertIssuer(
+			clientCA.ParsedCertificates, cm.tenantCACert.ParsedCertificates)
+	}
 
 	cm.serverConfig = cfg
 	return cfg, nil
 }
 
+// verifyClientCertIssuer returns a tls.Config.VerifyPeerCertificate callback
+// for a server that trusts both the tenant CA and the client CA to verify
+// client certificates. It only accepts tenant client certificates verified by
+// the tenant CA, and other client certificates verified by the client CA.
+// Otherwise, the tenant CA could issue certificates for any user, like root.
+func verifyClientCertIssuer(
+	clientCAs, tenantCAs []*x509.Certificate,
+) func([][]byte, [][]*x509.Certificate) error {
+	return func(_ [][]byte, verifiedChains [][]*x509.Certificate) error {
+		if len(verifiedChains) == 0 || len(verifiedChains[0]) == 0 {
+			// The client did not present a certificate.
+			return nil
+		}
+		if IsTenantCert(verifiedChains[0][0]) {
+			if !VerifiedByCA(verifiedChains, tenantCAs) {
+				return errors.New("tenant client certificate is not signed by the tenant CA")
+			}
+		} else if !VerifiedByCA(verifiedChains, clientCAs) {
+			return errors.New("client certificate is not signed by the client CA")
+		}
+		return nil
+	}
+}
+
 // GetUIServerTLSConfig returns a server TLS config for the Admin UI with a
 // callback to fetch the latest TLS config. We still attempt to get the config to make sure
 // the initial call has a valid config loaded.
@@ -722,6 +801,34 @@ func (cm *CertificateManager) GetClientTLSConfig(user string) (*tls.Config, erro
 	return cfg, nil
 }
 
+// GetTenantClientTLSConfig returns the most up-to-date client tls.Config for
+// the SQL tenant with the given ID. The second return value is false if there
+// is no client certificate for the tenant.
+func (cm *CertificateManager) GetTenantClientTLSConfig(tenantID string) (*tls.Config, bool, error) {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+
+	ci, ok := cm.tenantClientCerts[tenantID]
+	if !ok {
+		return nil, false, nil
+	}
+	if err := checkCertIsValid(ci); err != nil {
+		return nil, false, makeErrorf(err, "problem with client cert for tenant %s", tenantID)
+	}
+
+	// The KV nodes present certificates signed by the general CA.
+	ca, err := cm.getCACertLocked()
+	if err != nil {
+		return nil, false, err
+	}
+
+	cfg, err := newClientTLSConfig(ci.FileContents, ci.KeyFileContents, ca.FileContents)
+	if err != nil {
+		return nil, false, err
+	}
+	return cfg, true, nil
+}
+
 // GetUIClientTLSConfig returns the most up-to-date client tls.Config for Admin UI clients.
 // It does not include a client certificate and uses the UI CA certificate if present.
 func (cm *CertificateManager) GetUIClientTLSConfig() (*tls.Config, error) {
@@ -486,7 +486,7 @@ func CreateTenantClientPair(
 		return nil, errors.Errorf("could not generate new tenant key: %v", err)
 	}
 
-	clientCert, err := GenerateClientCert(
+	clientCert, err := GenerateTenantClientCert(
 		caCert, caPrivateKey, clientKey.Public(), lifetime, tenantIdentifier,
 	)
 	if err != nil {
@@ -11,6 +11,10 @@
 package security_test
 
 import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"io/ioutil"
 	"net"
@@ -106,7 +110,8 @@ func TestTenantCertificates(t *testing.T) {
 		Addr:      ln.Addr().String(),
 		TLSConfig: serverTLSConfig,
 		Handler: http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
-			fmt.Fprint(w, "hello, tenant ", req.TLS.PeerCertificates[0].Subject.CommonName)
+			subject := req.TLS.PeerCertificates[0].Subject
+			fmt.Fprint(w, "hello, tenant ", subject.CommonName, " ", subject.OrganizationalUnit)
 		}),
 	}
 	defer func() { _ = httpServer.Close() }()
@@ -124,5 +129,103 @@ func TestTenantCertificates(t *testing.T) {
 	defer resp.Body.Close()
 	b, err := ioutil.ReadAll(resp.Body)
 	require.NoError(t, err)
-	require.Equal(t, "hello, tenant "+tenant, string(b))
+	require.Equal(t, "hello, tenant "+tenant+" ["+security.TenantsOU+"]", string(b))
+}
+
+// TestTenantCASignedClientCertificates verifies that a server trusting both
+// the client CA and the tenant CA accepts tenant client certificates signed by
+// the tenant CA, but rejects other client certificates signed by it.
+func TestTenantCASignedClientCertificates(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	// Don't mock assets in this test, we're creating our own one-off certs.
+	security.ResetAssetLoader()
+	defer ResetTest()
+
+	certsDir, cleanup := tempDir(t)
+	defer cleanup()
+
+	caKeyPath := filepath.Join(certsDir, "ca.key")
+	require.NoError(t, security.CreateCAPair(
+		certsDir, caKeyPath, testKeySize, 1000*time.Hour, false, false,
+	))
+	require.NoError(t, security.CreateNodePair(
+		certsDir, caKeyPath, testKeySize, 500*time.Hour, false, []string{"127.0.0.1"}))
+	tenantCAKeyPath := filepath.Join(certsDir, "ca-tenant.key")
+	require.NoError(t, security.CreateTenantCAPair(
+		certsDir, tenantCAKeyPath, testKeySize, 1000*time.Hour, false, false,
+	))
+
+	tenantCA, err := tls.LoadX509KeyPair(
+		filepath.Join(certsDir, security.TenantCACertFilename()), tenantCAKeyPath)
+	require.NoError(t, err)
+	tenantCACert, err := x509.ParseCertificate(tenantCA.Certificate[0])
+	require.NoError(t, err)
+	caPEM, err := ioutil.ReadFile(filepath.Join(certsDir, security.CACertFilename()))
+	require.NoError(t, err)
+	caPool := x509.NewCertPool()
+	require.True(t, caPool.AppendCertsFromPEM(caPEM))
+
+	cm, err := security.NewCertificateManager(certsDir)
+	require.NoError(t, err)
+	serverTLSConfig, err := cm.GetServerTLSConfig()
+	require.NoError(t, err)
+
+	// handshake presents a client certificate signed by the tenant CA to the
+	// server and returns the error of the server side of the handshake.
+	handshake := func(
+		generate func(*x509.Certificate, *rsa.PrivateKey, *rsa.PublicKey) ([]byte, error),
+	) error {
+		clientKey, err := rsa.GenerateKey(rand.Reader, testKeySize)
+		require.NoError(t, err)
+		clientCert, err := generate(tenantCACert, tenantCA.PrivateKey.(*rsa.PrivateKey), &clientKey.PublicKey)
+		require.NoError(t, err)
+		clientTLSConfig := &tls.Config{
+			Certificates: []tls.Certificate{{
+				Certificate: [][]byte{clientCert},
+				PrivateKey:  clientKey,
+			}},
+			RootCAs:    caPool,
+			ServerName: "127.0.0.1",
+		}
+
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer func() { _ = ln.Close() }()
+		serverErrC := make(chan error, 1)
+		go func() {
+			conn, err := ln.Accept()
+			if err != nil {
+				serverErrC <- err
+				return
+			}
+			defer func() { _ = conn.Close() }()
+			serverErrC <- tls.Server(conn, serverTLSConfig).Handshake()
+		}()
+
+		conn, err := tls.Dial("tcp", ln.Addr().String(), clientTLSConfig)
+		if err == nil {
+			// Wait for the server to verify the client certificate.
+			_, _ = conn.Read(make([]byte, 1))
+			_ = conn.Close()
+		}
+		return <-serverErrC
+	}
+
+	t.Run("tenant", func(t *testing.T) {
+		require.NoError(t, handshake(func(
+			ca *x509.Certificate, caKey *rsa.PrivateKey, pub *rsa.PublicKey,
+		) ([]byte, error) {
+			return security.GenerateTenantClientCert(ca, caKey, pub, 48*time.Hour, "10")
+		}))
+	})
+
+	t.Run("root", func(t *testing.T) {
+		err := handshake(func(
+			ca *x509.Certificate, caKey *rsa.PrivateKey, pub *rsa.PublicKey,
+		) ([]byte, error) {
+			return security.GenerateClientCert(ca, caKey, pub, 48*time.Hour, security.RootUser)
+		})
+		require.Regexp(t, "client certificate is not signed by the client CA", err)
+	})
 }
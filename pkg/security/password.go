@@ -50,8 +50,11 @@ func appendEmptySha256(password string) []byte {
 
 // CompareHashAndPassword tests that the provided bytes are equivalent to the
 // hash of the supplied password. If they are not equivalent, returns an
-// error.
+// error. The hash can be either a bcrypt hash or a SCRAM verifier.
 func CompareHashAndPassword(hashedPassword []byte, password string) error {
+	if IsSCRAMHash(hashedPassword) {
+		return compareSCRAMHashAndPassword(hashedPassword, password)
+	}
 	return bcrypt.CompareHashAndPassword(hashedPassword, appendEmptySha256(password))
 }
 
@@ -60,6 +63,31 @@ func HashPassword(password string) ([]byte, error) {
 	return bcrypt.GenerateFromPassword(appendEmptySha256(password), BcryptCost)
 }
 
+// HashMethod identifies the method used to hash passwords stored in
+// system.users.
+type HashMethod int
+
+const (
+	// HashBCrypt hashes passwords using bcrypt. Passwords stored this way
+	// can only be checked if the client sends them in cleartext.
+	HashBCrypt HashMethod = iota
+	// HashSCRAMSHA256 stores SCRAM-SHA-256 verifiers, which allow
+	// authenticating clients without them sending the password.
+	HashSCRAMSHA256
+)
+
+// HashPasswordWithMethod hashes a raw password using the given method.
+func HashPasswordWithMethod(method HashMethod, password string) ([]byte, error) {
+	switch method {
+	case HashBCrypt:
+		return HashPassword(password)
+	case HashSCRAMSHA256:
+		return HashPasswordSCRAM(password)
+	default:
+		return nil, errors.AssertionFailedf("unknown hash method: %d", method)
+	}
+}
+
 // PromptForPassword prompts for a password.
 // This is meant to be used when using a password.
 func PromptForPassword() (string, error) {
@@ -0,0 +1,290 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package security
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// This file implements the server side of the SCRAM-SHA-256 SASL
+// authentication mechanism (RFC 5802 and RFC 7677), as used by the
+// PostgreSQL wire protocol.
+//
+// The password is never sent over the wire. Instead, the server stores a
+// SCRAM verifier, which is derived from the password but cannot be used to
+// impersonate the user, and the client proves that it knows the password by
+// completing a challenge-response exchange.
+//
+// Verifiers are stored in system.users, in the same column as bcrypt hashes,
+// using the same textual format as PostgreSQL:
+//
+//   SCRAM-SHA-256$<iterations>:<salt>$<StoredKey>:<ServerKey>
+//
+// where salt, StoredKey and ServerKey are base64-encoded.
+
+// SCRAMMechanism is the name of the only SASL mechanism supported by the
+// server.
+const SCRAMMechanism = "SCRAM-SHA-256"
+
+// scramHashPrefix is the prefix of the stored form of a SCRAM verifier.
+const scramHashPrefix = SCRAMMechanism + "$"
+
+// SCRAMCost is the number of PBKDF2 iterations to use when computing new
+// SCRAM verifiers. It is exposed for testing.
+var SCRAMCost = 4096
+
+const (
+	scramSaltLen  = 16
+	scramNonceLen = 18
+)
+
+// SCRAMVerifier is the decoded form of a SCRAM-SHA-256 verifier.
+type SCRAMVerifier struct {
+	Iterations int
+	Salt       []byte
+	StoredKey  []byte
+	ServerKey  []byte
+}
+
+// IsSCRAMHash returns true if the given stored password hash is a SCRAM
+// verifier, as opposed to a bcrypt hash.
+func IsSCRAMHash(hashedPassword []byte) bool {
+	return bytes.HasPrefix(hashedPassword, []byte(scramHashPrefix))
+}
+
+// HashPasswordSCRAM computes the SCRAM verifier of a password, in its stored
+// form.
+func HashPasswordSCRAM(password string) ([]byte, error) {
+	salt := make([]byte, scramSaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, errors.Wrap(err, "generating salt")
+	}
+	return makeSCRAMVerifier(password, salt, SCRAMCost).encode(), nil
+}
+
+func makeSCRAMVerifier(password string, salt []byte, iterations int) SCRAMVerifier {
+	saltedPassword := pbkdf2.Key([]byte(password), salt, iterations, sha256.Size, sha256.New)
+	clientKey := scramHMAC(saltedPassword, "Client Key")
+	storedKey := sha256.Sum256(clientKey)
+	return SCRAMVerifier{
+		Iterations: iterations,
+		Salt:       salt,
+		StoredKey:  storedKey[:],
+		ServerKey:  scramHMAC(saltedPassword, "Server Key"),
+	}
+}
+
+func (v SCRAMVerifier) encode() []byte {
+	enc := base64.StdEncoding.EncodeToString
+	return []byte(scramHashPrefix + strconv.Itoa(v.Iterations) + ":" + enc(v.Salt) +
+		"$" + enc(v.StoredKey) + ":" + enc(v.ServerKey))
+}
+
+// ParseSCRAMVerifier decodes the stored form of a SCRAM verifier.
+func ParseSCRAMVerifier(hashedPassword []byte) (SCRAMVerifier, error) {
+	var v SCRAMVerifier
+	if !IsSCRAMHash(hashedPassword) {
+		return v, errors.New("not a SCRAM verifier")
+	}
+	parts := strings.Split(string(hashedPassword[len(scramHashPrefix):]), "$")
+	if len(parts) != 2 {
+		return v, errors.New("malformed SCRAM verifier")
+	}
+	iterSalt := strings.Split(parts[0], ":")
+	keys := strings.Split(parts[1], ":")
+	if len(iterSalt) != 2 || len(keys) != 2 {
+		return v, errors.New("malformed SCRAM verifier")
+	}
+	var err error
+	if v.Iterations, err = strconv.Atoi(iterSalt[0]); err != nil || v.Iterations <= 0 {
+		return v, errors.New("malformed SCRAM verifier: invalid iteration count")
+	}
+	dec := base64.StdEncoding.DecodeString
+	if v.Salt, err = dec(iterSalt[1]); err != nil {
+		return v, errors.Wrap(err, "malformed SCRAM verifier: invalid salt")
+	}
+	if v.StoredKey, err = dec(keys[0]); err != nil || len(v.StoredKey) != sha256.Size {
+		return v, errors.New("malformed SCRAM verifier: invalid stored key")
+	}
+	if v.ServerKey, err = dec(keys[1]); err != nil || len(v.ServerKey) != sha256.Size {
+		return v, errors.New("malformed SCRAM verifier: invalid server key")
+	}
+	return v, nil
+}
+
+// compareSCRAMHashAndPassword checks a cleartext password against a stored
+// SCRAM verifier.
+func compareSCRAMHashAndPassword(hashedPassword []byte, password string) error {
+	v, err := ParseSCRAMVerifier(hashedPassword)
+	if err != nil {
+		return err
+	}
+	computed := makeSCRAMVerifier(password, v.Salt, v.Iterations)
+	if subtle.ConstantTimeCompare(computed.StoredKey, v.StoredKey) != 1 ||
+		subtle.ConstantTimeCompare(computed.ServerKey, v.ServerKey) != 1 {
+		return errors.New("password does not match SCRAM verifier")
+	}
+	return nil
+}
+
+// MakeMockSCRAMVerifier returns a verifier that no password matches. It is
+// used to carry on with the exchange when the user has no SCRAM verifier, so
+// that the exchange fails the same way as with a wrong password.
+func MakeMockSCRAMVerifier() (SCRAMVerifier, error) {
+	salt := make([]byte, scramSaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return SCRAMVerifier{}, errors.Wrap(err, "generating salt")
+	}
+	return SCRAMVerifier{
+		Iterations: SCRAMCost,
+		Salt:       salt,
+		// No client key hashes to all zeroes, so the client proof never
+		// validates.
+		StoredKey: make([]byte, sha256.Size),
+		ServerKey: make([]byte, sha256.Size),
+	}, nil
+}
+
+// SCRAMExchange holds the server-side state of a single SCRAM-SHA-256
+// exchange. The exchange consists of two rounds: ServerFirst processes the
+// client-first-message and produces the server-first-message, and
+// ServerFinal validates the client-final-message and produces the
+// server-final-message.
+type SCRAMExchange struct {
+	verifier SCRAMVerifier
+	// serverNonce is the server's part of the nonce, overridden in tests.
+	serverNonce string
+
+	clientFirstBare string
+	serverFirst     string
+	nonce           string
+	gs2Header       string
+}
+
+// NewSCRAMExchange initializes a SCRAM exchange for the user with the given
+// verifier.
+func NewSCRAMExchange(verifier SCRAMVerifier) (*SCRAMExchange, error) {
+	nonce := make([]byte, scramNonceLen)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, errors.Wrap(err, "generating nonce")
+	}
+	return &SCRAMExchange{
+		verifier:    verifier,
+		serverNonce: base64.StdEncoding.EncodeToString(nonce),
+	}, nil
+}
+
+// ServerFirst processes the client-first-message and returns the
+// server-first-message.
+func (e *SCRAMExchange) ServerFirst(clientFirst []byte) ([]byte, error) {
+	// client-first-message = gs2-header client-first-message-bare
+	// gs2-header = gs2-cbind-flag "," [ authzid ] ","
+	msg := string(clientFirst)
+	var cbindFlag string
+	cbindFlag, msg = scramNextField(msg)
+	switch {
+	case cbindFlag == "n" || cbindFlag == "y":
+		// The client does not support channel binding, or does support it
+		// but thinks the server does not.
+	case strings.HasPrefix(cbindFlag, "p="):
+		return nil, errors.New("SCRAM channel binding is not supported")
+	default:
+		return nil, errors.Newf("malformed SCRAM message: unexpected channel binding flag %q", cbindFlag)
+	}
+	var authzid string
+	authzid, msg = scramNextField(msg)
+	if authzid != "" {
+		return nil, errors.New("SCRAM authorization identities are not supported")
+	}
+	e.gs2Header = cbindFlag + "," + authzid + ","
+	e.clientFirstBare = msg
+
+	// client-first-message-bare = [reserved-mext ","] username "," nonce
+	// The username is ignored: like in PostgreSQL, the user is the one
+	// given in the startup message.
+	var field string
+	if field, msg = scramNextField(msg); !strings.HasPrefix(field, "n=") {
+		return nil, errors.New("malformed SCRAM message: expected username")
+	}
+	if field, _ = scramNextField(msg); !strings.HasPrefix(field, "r=") || len(field) == 2 {
+		return nil, errors.New("malformed SCRAM message: expected nonce")
+	}
+	e.nonce = field[2:] + e.serverNonce
+
+	e.serverFirst = "r=" + e.nonce +
+		",s=" + base64.StdEncoding.EncodeToString(e.verifier.Salt) +
+		",i=" + strconv.Itoa(e.verifier.Iterations)
+	return []byte(e.serverFirst), nil
+}
+
+// ServerFinal validates the client proof in the client-final-message and
+// returns the server-final-message. An error is returned if the proof is
+// invalid, i.e. if the client does not know the password.
+func (e *SCRAMExchange) ServerFinal(clientFinal []byte) ([]byte, error) {
+	// client-final-message = channel-binding "," nonce "," proof
+	msg := string(clientFinal)
+	var field string
+	field, msg = scramNextField(msg)
+	if field != "c="+base64.StdEncoding.EncodeToString([]byte(e.gs2Header)) {
+		return nil, errors.New("malformed SCRAM message: unexpected channel binding")
+	}
+	field, msg = scramNextField(msg)
+	if field != "r="+e.nonce {
+		return nil, errors.New("malformed SCRAM message: nonce mismatch")
+	}
+	clientFinalWithoutProof := string(clientFinal[:len(clientFinal)-len(msg)-1])
+	field, _ = scramNextField(msg)
+	if !strings.HasPrefix(field, "p=") {
+		return nil, errors.New("malformed SCRAM message: expected proof")
+	}
+	proof, err := base64.StdEncoding.DecodeString(field[2:])
+	if err != nil || len(proof) != sha256.Size {
+		return nil, errors.New("malformed SCRAM message: invalid proof")
+	}
+
+	authMessage := e.clientFirstBare + "," + e.serverFirst + "," + clientFinalWithoutProof
+	clientSignature := scramHMAC(e.verifier.StoredKey, authMessage)
+	clientKey := make([]byte, sha256.Size)
+	for i := range clientKey {
+		clientKey[i] = proof[i] ^ clientSignature[i]
+	}
+	storedKey := sha256.Sum256(clientKey)
+	if subtle.ConstantTimeCompare(storedKey[:], e.verifier.StoredKey) != 1 {
+		return nil, errors.New("invalid SCRAM client proof")
+	}
+
+	serverSignature := scramHMAC(e.verifier.ServerKey, authMessage)
+	return []byte("v=" + base64.StdEncoding.EncodeToString(serverSignature)), nil
+}
+
+// scramNextField splits the next comma-separated field off a SCRAM message.
+func scramNextField(msg string) (field, rest string) {
+	if i := strings.IndexByte(msg, ','); i >= 0 {
+		return msg[:i], msg[i+1:]
+	}
+	return msg, ""
+}
+
+func scramHMAC(key []byte, msg string) []byte {
+	h := hmac.New(sha256.New, key)
+	_, _ = h.Write([]byte(msg))
+	return h.Sum(nil)
+}
@@ -0,0 +1,133 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package security_test
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/lib/pq/scram"
+	"github.com/stretchr/testify/require"
+)
+
+// runSCRAMExchange runs a SCRAM exchange between the lib/pq client and our
+// server implementation, and returns the error reported by either side.
+func runSCRAMExchange(t *testing.T, verifier security.SCRAMVerifier, password string) error {
+	client := scram.NewClient(sha256.New, "ignored", password)
+	exchange, err := security.NewSCRAMExchange(verifier)
+	require.NoError(t, err)
+
+	// Step returns false while the exchange is not over.
+	require.False(t, client.Step(nil))
+	serverFirst, err := exchange.ServerFirst(client.Out())
+	require.NoError(t, err)
+	require.False(t, client.Step(serverFirst))
+	serverFinal, err := exchange.ServerFinal(client.Out())
+	if err != nil {
+		return err
+	}
+	// The client checks the server signature.
+	client.Step(serverFinal)
+	return client.Err()
+}
+
+func TestSCRAMExchange(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	// lib/pq rejects iteration counts below 1000.
+	defer func(prev int) { security.SCRAMCost = prev }(security.SCRAMCost)
+	security.SCRAMCost = 1000
+
+	hashedPassword, err := security.HashPasswordSCRAM("hunter2")
+	require.NoError(t, err)
+	require.True(t, security.IsSCRAMHash(hashedPassword))
+	verifier, err := security.ParseSCRAMVerifier(hashedPassword)
+	require.NoError(t, err)
+	require.Equal(t, 1000, verifier.Iterations)
+
+	t.Run("correct password", func(t *testing.T) {
+		require.NoError(t, runSCRAMExchange(t, verifier, "hunter2"))
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		require.EqualError(t, runSCRAMExchange(t, verifier, "hunter3"), "invalid SCRAM client proof")
+	})
+
+	t.Run("mock verifier", func(t *testing.T) {
+		mock, err := security.MakeMockSCRAMVerifier()
+		require.NoError(t, err)
+		require.EqualError(t, runSCRAMExchange(t, mock, ""), "invalid SCRAM client proof")
+	})
+
+	t.Run("cleartext", func(t *testing.T) {
+		require.NoError(t, security.CompareHashAndPassword(hashedPassword, "hunter2"))
+		require.Error(t, security.CompareHashAndPassword(hashedPassword, "hunter3"))
+	})
+}
+
+func TestSCRAMProtocolErrors(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	mock, err := security.MakeMockSCRAMVerifier()
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		clientFirst string
+		expected    string
+	}{
+		{"p=tls-server-end-point,,n=,r=abc", "SCRAM channel binding is not supported"},
+		{"x,,n=,r=abc", `malformed SCRAM message: unexpected channel binding flag "x"`},
+		{"n,a=admin,n=,r=abc", "SCRAM authorization identities are not supported"},
+		{"n,,r=abc", "malformed SCRAM message: expected username"},
+		{"n,,n=,r=", "malformed SCRAM message: expected nonce"},
+	} {
+		t.Run(tc.clientFirst, func(t *testing.T) {
+			exchange, err := security.NewSCRAMExchange(mock)
+			require.NoError(t, err)
+			_, err = exchange.ServerFirst([]byte(tc.clientFirst))
+			require.EqualError(t, err, tc.expected)
+		})
+	}
+
+	exchange, err := security.NewSCRAMExchange(mock)
+	require.NoError(t, err)
+	_, err = exchange.ServerFirst([]byte("n,,n=,r=abc"))
+	require.NoError(t, err)
+	_, err = exchange.ServerFinal([]byte("c=biws,r=abc,p=xyz"))
+	require.EqualError(t, err, "malformed SCRAM message: nonce mismatch")
+}
+
+func TestParseSCRAMVerifier(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	// A verifier for the password "abc", in the format used by PostgreSQL,
+	// computed independently with Python's hashlib.
+	const verifier = "SCRAM-SHA-256$4096:f3QbIgrX3MKI9DCHx4PXRg==$" +
+		"wtzFhTVe2wRNWuV00X7jaVmpBXXxIi8+vm6QU8iV9eI=:" +
+		"HN7/bZJSNPFvVHGzGyQlejvcKXhUoREN9DpAk0qsAMY="
+	v, err := security.ParseSCRAMVerifier([]byte(verifier))
+	require.NoError(t, err)
+	require.Equal(t, 4096, v.Iterations)
+	require.Len(t, v.Salt, 16)
+	require.NoError(t, security.CompareHashAndPassword([]byte(verifier), "abc"))
+
+	for _, bad := range []string{
+		"$2a$10$abcdefghijklmnopqrstuv",
+		"SCRAM-SHA-256$4096:c2FsdA==",
+		"SCRAM-SHA-256$x:c2FsdA==$AAAA:AAAA",
+		"SCRAM-SHA-256$4096:c2FsdA==$AAAA:AAAA",
+	} {
+		_, err := security.ParseSCRAMVerifier([]byte(bad))
+		require.Error(t, err, bad)
+	}
+}
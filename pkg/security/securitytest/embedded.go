@@ -1,5 +1,7 @@
 // Code generated by go-bindata. DO NOT EDIT.
 // sources:
+// test_certs/ca-tenant.crt (1.155kB)
+// test_certs/ca-tenant.key (1.675kB)
 // test_certs/ca.crt (1.111kB)
 // test_certs/ca.key (1.675kB)
 // test_certs/client.root.crt (1.103kB)
@@ -8,6 +10,8 @@
 // test_certs/client.testuser.key (1.679kB)
 // test_certs/node.crt (1.192kB)
 // test_certs/node.key (1.679kB)
+// test_certs/tenant.10.crt (1.172kB)
+// test_certs/tenant.10.key (1.679kB)
 
 package securitytest
 
@@ -76,6 +80,46 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
+var _test_certsCaTenantCrt = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6d\x94\xc9\xce\xab\x38\x10\x85\xf7\x3c\x45\xef\xa3\xab\x00\x21\x09\x2c\xee\xc2\xc6\x66\x9e\xcc\x10\x02\x3b\x08\x09\x43\x18\x32\x81\x09\x4f\xdf\xfc\xb7\x37\x2d\x75\x97\x64\x95\x74\x54\x25\x55\xe9\x7c\xae\x5f\xbf\xd6\x80\x58\xd5\x9d\xbf\x64\xec\x87\xba\xa2\xcb\x20\xc4\x3f\xe2\x2f\xc6\xd6\x75\x64\x2c\xb2\x0c\xca\x4d\x09\xa8\x0e\x41\xa9\x93\x9b\x66\x6c\xb6\x86\x60\x36\xc5\xe0\x35\xb6\xeb\x6f\x5e\xd5\xe6\x82\x80\x03\xcb\xfb\xb3\xba\xd7\xaa\x44\x59\x08\xc8\x5b\x01\x08\xbc\x18\xdb\xd7\x29\x06\x09\x3a\x11\x62\x62\xda\xa2\x9c\x77\x5e\x97\x4e\xaa\x12\xbe\x9c\x95\x10\x84\xb0\x74\x4e\x10\xd8\x21\xc2\xce\x94\xf0\x9f\x6f\xce\x2b\x4d\x26\x43\x44\x42\x50\x2a\x94\xfd\x32\x36\x02\x82\x8d\x30\x5d\xf3\xfa\x60\xa6\x02\x71\xef\x86\xf7\xfd\x1f\x2d\x04\xff\xe8\xca\x40\xcd\x05\x07\x36\x04\x2a\xe0\x22\x2c\x57\xb6\x41\x78\xa9\xc9\x76\xc6\xc4\x24\xb1\x33\xd8\x7e\x44\x31\xfd\x33\xc5\xda\x36\xff\x7b\x8a\x92\xb0\x98\x96\x25\xae\x6d\xc0\xaa\x72\xf0\x54\x03\x3d\xdf\x21\x82\xd7\x15\x22\x00\x04\x1d\x32\x88\x82\x9f\x02\x13\x0c\xeb\xfe\x04\x39\x43\x21\xcc\x1c\xc0\xd2\x22\x9d\x7a\x15\x6f\x53\x92\xf5\xaa\x9b\x61\xaf\xb5\x23\x0a\xeb\x28\xfa\xf6\x5c\xf1\xb5\x17\x1d\xe5\xd1\x12\x25\x4d\xc9\x24\x85\xe7\xec\x44\x4d\x6f\xdf\x7a\xeb\x57\x86\x5f\x4d\x1b\xc9\xca\x79\xe1\x73\x0c\x0f\x0a\x7c\x28\x06\x7a\x09\xbb\x4a\xba\xd3\xb7\x32\xe6\x5a\x84\x8d\xbc\x78\xcc\x15\x6b\x09\xf6\xed\xf1\x7e\xbd\x1b\x06\x57\xb7\x0b\xe6\xe9\x45\x6f\xa6\xfb\xd8\x60\xb7\x4c\xbc\x89\xf6\x71\xad\x76\x72\x45\x3d\xbb\x2b\x47\xd7\x20\xd9\x50\x18\xc0\x56\x2d\x09\x07\x5f\x60\x1d\x4f\x64\x24\x0d\xbd\x74\xae\x89\x46\x86\x8c\xea\x70\xd0\x73\x8f\x1f\x3a\xb2\xd9\x3f\xa9\x8a\xa8\x6a\xd6\x58\xf4\xd1\x33\x13\x59\x03\x26\xa9\xb5\xe9\x5d\x1f\xdd\x50\xfe\x25\xc5\xbc\x4d\x8b\x6b\x7c\x0e\xa6\xba\xb9\xec\xcc\x40\xba\x73\x4c\x73\x0c\xc2\x0b\x0f\x9f\x7a\x70\x2e\xf6\xcd\xae\x3d\x61\xdf\xc8\xcf\x76\xcc\x51\x75\x2e\x8c\x80\x7e\xf6\xa4\xec\x72\x04\xed\xd2\x08\xfb\x6b\x62\xd5\x1b\x16\xec\xac\x34\x57\x52\xe5\x08\xa2\x63\xce\xf8\x45\x5a\x9b\x56\xc5\x15\xaa\x7a\xdf\xfa\x1a\xf2\xa6\xb2\x95\xb3\x7b\x97\x0b\xca\x94\x48\xdb\x98\x83\xe3\x0c\xca\xd5\x40\xa0\x36\x7e\x08\x91\x0d\x84\x1f\x2b\x0b\x44\x31\xdc\x52\x82\x99\x95\x3e\x79\x8f\x40\xf0\x03\x8c\xe6\xaf\x85\x37\x11\xcb\x68\xf5\x9b\x68\x5b\x50\x62\x68\x43\xf6\x9f\x86\x92\xc4\x10\x12\xfe\x1a\xb5\xa4\x88\x6b\xc9\x14\x17\x58\x7d\x07\x99\x01\x93\x52\x84\x12\xbe\x6d\xff\x0f\x55\xe0\xca\x80\x60\xd0\xfb\xe7\xe9\xfc\x38\x4c\x95\x33\xcd\x92\xfa\xc9\x85\xca\xfc\xbc\x75\x27\x31\x1e\x44\x67\x22\xd0\xdd\x7c\x7b\x54\xfa\xfa\x34\x87\x0b\xf5\xc5\xd1\x74\x2d\xb1\x15\xed\x6b\x28\xb5\x90\x88\xd7\xed\x67\xdf\xef\x44\x97\xd6\xc5\xf8\xd8\xbb\xfb\x27\xdb\xcc\xd6\x25\x0d\xbd\xe4\x78\xd0\x53\xd8\x32\x20\xff\x64\x1b\x7e\x0a\x31\x1a\x02\xeb\xa8\x14\xb9\x56\xf2\x34\xd3\x1c\xf9\x10\xec\xac\xaf\x78\x96\x82\xc5\xca\xaf\xa5\x1b\x5d\x3f\xf2\x51\xbb\xb8\xb6\xe9\x72\x47\xeb\xd4\x58\xf9\x52\x65\xb2\xae\x31\x5b\x5e\xc2\xf6\x56\x1b\x2c\x56\xca\x79\x5e\x80\xf5\xd3\x7f\xc7\xd6\x7c\xa8\xd4\x89\x17\x57\x1e\x82\xe6\xd4\x9f\x39\xde\x2b\xc8\x55\x51\xde\xde\x79\x08\x0f\xe8\x13\x5a\xda\x23\x05\x63\x4b\x50\xc3\xb0\x7e\x7c\xb2\x28\xcd\xda\xba\xdb\x9b\x52\x3d\x2d\xa6\x7e\xef\xd4\xd8\x21\xd1\xfa\xe7\x82\x82\xcf\x1e\x3d\x77\xb2\xc6\x4d\x39\xef\x40\x93\x81\x9a\xb7\xb8\x41\x4b\x95\x41\xe0\x5b\xc0\xdf\x75\x8b\x59\xe2\xf2\x28\x40\xf6\x99\xee\xd1\x51\x32\x0e\x96\x1b\x2f\x5e\x50\x74\x2f\xcb\xa3\xea\xca\xe4\x37\xb8\x3a\xaa\x58\xcd\xc6\x98\x0d\x8b\x99\x76\xca\x4b\xf7\xc7\x4d\xcf\x09\x1c\xf8\xfd\x9b\xf9\x73\x52\xb0\x83\xfe\x7b\x66\xfe\x06\x82\xa5\x4b\x93\x83\x04\x00\x00")
+
+func test_certsCaTenantCrtBytes() ([]byte, error) {
+	return bindataRead(
+		_test_certsCaTenantCrt,
+		"test_certs/ca-tenant.crt",
+	)
+}
+
+func test_certsCaTenantCrt() (*asset, error) {
+	bytes, err := test_certsCaTenantCrtBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "test_certs/ca-tenant.crt", size: 1155, mode: os.FileMode(0600), modTime: time.Unix(1400000000, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x1, 0x58, 0xba, 0x6e, 0x1f, 0xbf, 0x19, 0xf6, 0x83, 0x88, 0x1c, 0x2a, 0xf9, 0x13, 0x51, 0x99, 0x23, 0x99, 0x11, 0x34, 0xfb, 0x81, 0x86, 0x11, 0xbe, 0xc8, 0xe4, 0xe5, 0xd1, 0x9f, 0x2e, 0xc5}}
+	return a, nil
+}
+
+var _test_certsCaTenantKey = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6d\x95\xb7\xb2\xa3\x5a\x14\x44\x73\xbe\x62\x72\x6a\x0a\x01\x02\x44\x88\xf7\xe6\xe0\x21\xc3\x3b\x61\x24\x3c\x5f\xff\xee\x9d\xf8\x75\xba\x93\x55\xbd\xbb\xab\xff\xfe\xfd\x11\x2b\x48\x8a\xf9\xc7\x71\x99\x3f\xb6\xa3\x04\x8c\x27\xfc\xd1\x84\xf8\xf7\xf0\x17\x32\x14\x45\x98\x0e\x85\x65\x18\x8d\x63\x80\xc0\xdc\xa9\x5c\x1a\x05\x60\xed\x1c\x09\x92\xb3\xb1\x31\x5f\x9a\xcf\x6e\x68\x78\xc2\x13\x0d\x26\x7e\x8b\xc6\x4c\x47\x79\x67\x5c\x0c\x86\x1a\xa8\xa4\x40\x4f\x49\xf6\xee\x02\x61\x2f\x35\xe3\x54\x3f\x76\xfd\x38\x43\x76\x17\xa3\x37\xbd\x7c\xc0\x0d\x48\x1d\x17\x90\xb0\xf9\xac\x12\x43\x67\xb3\xe1\x44\x59\x2e\xc4\xd3\x3c\x2e\x38\x98\x97\xc9\x4c\xa3\x20\xe5\x54\x22\x9c\x35\x17\x99\x53\x28\x42\x9f\x4e\x7e\x92\x78\xea\xa5\xa2\x53\x33\xd7\x53\xce\xdf\xea\xa4\x77\xad\x2f\x7d\x64\x17\xf0\x6c\x8b\x38\xc2\x52\x73\x30\xea\xeb\xbd\xf2\x92\xd5\xae\xad\x21\xaa\xd7\x9b\x8f\xd5\x4a\x37\xfd\x51\x7b\x7b\x4b\x17\xb6\x7e\xb1\xed\xd4\xd8\x02\x20\x87\xdd\xe4\x40\x28\xb9\xc8\x7c\xf7\x00\x3f\xb0\x57\x2f\xe7\x15\x16\x45\xef\xf7\xe3\xfb\x9c\x70\xf3\xea\xf7\x04\x32\x63\xf8\xd1\xe3\x66\x9d\xb6\xc2\x1b\x2f\x63\x9a\x08\x1c\xc1\x0d\xd1\x5b\x5c\x73\x76\x89\x5c\x7f\xd1\x4b\x5f\x51\x87\xe3\xf0\x14\xce\x27\xf0\x81\x7b\xee\x2b\x63\x5e\x58\x78\x86\x4e\x79\x88\x16\x84\x3d\xa2\x30\x6e\xdb\x67\x11\x39\xcd\x6c\x3f\x9c\xe3\xa6\x9e\x2a\x18\x66\x75\x80\xd9\x0c\xb3\x2b\x74\x05\xd9\x02\x14\x9e\x01\x0c\xcb\x4c\x3f\x66\x73\xf6\x4e\x24\x5a\x7f\x30\xbc\x06\x7b\x0b\x06\x1d\x87\xb4\x0f\xc9\xd0\xb7\x99\x9e\x13\x64\x01\x72\x62\x73\xc5\x02\x0f\xfa\x98\x1d\x5e\x96\xc5\x8d\x26\x25\x71\xf5\xd6\x78\xcb\xab\x10\x8b\x49\xb9\xe4\x5d\x13\x92\x8e\xb9\xbd\xac\x1d\x78\x2f\x85\xa2\xf8\x8b\xe1\x11\x66\x93\x82\x34\x78\xb9\x9e\xcb\x53\x8f\x35\xfc\x77\x1b\xbb\x68\x59\x51\x73\x7b\x4e\xed\x97\x0b\xcc\x3b\x7d\xca\xa7\x41\x89\x97\xbc\xc0\xfb\x97\xbe\xf6\xb6\x9a\x07\x5e\x3f\x15\x48\x2b\x92\xf3\x33\xa1\x29\x5a\x61\xf1\xe9\xb8\x8a\xf9\x3a\x45\xf8\xb1\x2e\x51\xed\xaa\x47\xff\x4a\xab\x87\xac\x69\xb6\xc6\x26\x0c\x7e\x58\xb1\xee\x8f\xaa\x2f\xd9\x4a\x57\xbe\x44\x8b\x07\xce\x50\x43\xd1\x2a\x69\x7c\x74\xe6\x08\x25\xc1\x35\x5a\xa1\x75\xd7\x13\x14\x20\x63\xd6\xe2\x6b\x75\xf7\x15\x02\x7e\xe9\xc5\x8b\x6d\xc4\xb6\xba\x97\xda\x39\x5a\xa4\xa7\xb7\x28\x5a\x3a\x60\x66\x9c\xdb\xf5\x10\x9e\xef\xc6\x2d\xc3\xb8\x26\xc5\x3e\x8c\xb5\xeb\x30\x88\xa9\xf1\xe4\x0d\x32\x75\xf4\xb9\xb3\xb1\xe9\x00\x63\xf1\xd4\xbe\xd6\x74\x6f\x42\x9f\x90\x67\xc5\xaf\x61\x73\xd2\xe5\x96\xf2\x5b\x71\x0d\xd0\xc0\x86\xe4\xac\xe4\x5c\x1d\x0b\x0c\x6e\xa8\x6f\x4f\xde\xb7\xa6\x98\xc9\x86\xf4\xeb\x42\x20\xde\xd1\x19\x57\x22\xfa\xdc\xd4\xef\x71\x65\xbb\xa6\xbd\xad\xad\x02\x4e\x71\x15\x43\xab\x78\x6a\x53\x41\x4c\xfc\xf9\x48\xa3\x25\x45\xf4\xda\xe6\x4a\x7f\x65\x24\x62\x18\x9c\x8b\xd5\xc1\x89\x4e\x49\x31\x52\xae\x91\xc0\xdf\xa4\x7f\x08\x6d\x0f\xd0\x53\xe0\x86\x8f\xee\x1b\x32\xfd\x9d\xce\xca\xa2\x98\x1d\xc2\xa2\x15\x9e\x78\xb3\x64\x0a\x62\xb3\x37\xd9\x15\xb4\x00\x15\xc9\x65\xd7\x18\xb8\x59\xe5\x34\x52\x4d\xf7\xdb\xd8\xc1\x4f\x5e\x97\x65\xa2\x2c\xb1\x41\x1a\x9a\x18\x5f\xff\x88\xa9\x31\x50\x21\xd0\x64\xb0\xd9\x5e\xcd\x0b\xbb\x03\x8a\x37\x32\xa9\xbe\x62\xfd\xa3\xa0\x0c\x15\xc9\xd2\xdd\xf0\x72\x95\x4b\x0b\xd2\x3a\xec\x8f\xcb\xf4\x29\x3b\x11\x4a\xbd\x11\x72\x12\x9a\x7b\x7b\x74\xe8\x80\x41\xec\xcd\x62\xb1\xe4\x0b\x07\x56\xec\x6f\xe4\x7c\x88\xf8\x34\x7f\x61\xbf\xd1\x0b\xdf\xa1\x79\xce\xd2\xfa\xe7\xec\xbd\x31\xcc\xe6\x07\x73\x36\x36\x59\x1a\x91\xa5\xe4\xc4\x05\xe9\xd4\x04\x21\x79\x09\x3a\xc4\x6f\x3b\x83\x12\x9b\xcc\x25\xdb\x69\x65\xc7\xb7\xeb\xbd\x30\x68\x70\xc3\xd6\x15\x2a\x86\x67\xac\x7b\x72\xe6\xbf\xc8\x4c\xde\xf7\x8e\xc5\xd1\x60\x96\x96\x9f\xaa\xcf\x75\xbb\x86\x5f\x11\xf2\xb2\xb0\xcf\xe6\x49\x6a\x42\x9e\x18\x8f\xe4\xd1\x53\x0a\x8c\x49\x87\xc4\x5d\xe8\x74\x4b\xe5\x9e\x86\xde\x36\xe0\xbd\xb5\xed\x20\x9e\x94\x3e\xee\xb0\x38\xa3\x80\x43\x10\x09\xac\xa3\x12\xb9\x40\x2f\xb1\x76\x33\xfd\xd6\x6b\x7e\x75\x1d\xc5\x63\x71\xf1\x40\xba\x68\x9f\xd7\xe0\x81\xc2\x03\x16\xd2\x5d\xc6\x03\x57\xdb\xdf\xf7\x31\xd7\x8f\xcb\xda\xf7\xd5\x1d\x37\xe3\x6c\x5b\xa7\x19\x4c\xb8\x85\x5e\x9f\x75\xb2\x61\x95\x8d\x02\x0a\xf9\x58\xea\x3b\xd6\xe5\x74\x07\x1a\x5b\x03\xee\x29\xbf\x18\x33\x60\x36\x38\x07\xcc\xa2\xb4\x52\x1e\x49\xa3\x8f\xa2\x17\x79\x86\x0f\x8e\x17\x09\x33\xec\xeb\x18\xc2\x2f\x91\xc5\x2b\x0e\x9e\x49\xb8\x95\x4f\x1b\x47\x38\x5d\x50\xd6\xd2\x77\xf8\x51\x4f\x26\xfc\x76\xae\xb3\x6e\x1b\x04\xc9\x4b\x7d\xe5\xe5\x68\x1c\x36\xce\xac\x6c\x9d\x1e\x0d\x27\x37\x1d\xd9\x81\x18\x43\x34\xbc\xa8\x7a\xfb\xe6\x9a\xca\x08\x16\xea\x5a\x0b\x7f\x32\xee\xab\xae\xf8\x4f\x13\x86\x91\x8d\x93\x7b\x47\x6f\x42\xa6\x92\xb3\xbd\x48\xbe\x41\xa9\x67\x96\x58\x0e\xff\x06\xdf\x91\x9b\x48\x08\x3f\xe9\x25\xfd\x45\x96\x99\x46\x2c\xaf\x44\x73\x3c\xae\xbf\x35\xfb\x42\xf5\xcd\xba\xc9\x1d\xc9\xf1\xcc\x56\x65\x36\x58\x46\x03\xe0\xe2\xb5\xe9\x6a\x88\x20\x5d\xc8\x0f\xd7\x14\xbb\xa0\x80\x00\x75\x36\xe5\xc8\x89\xcd\x9c\xe0\xc8\x67\x26\xf5\x71\xa5\x8e\xa6\x8b\x39\xb7\xa8\xea\xea\xe7\x8b\xf9\xbd\x79\x82\xde\xb3\x76\xba\x20\xc0\x6f\xf0\xc3\x1e\x4d\xd8\x5c\xb0\x47\x4c\x83\xc4\xfc\x40\xf1\x1d\x66\xcb\x49\x28\x95\xde\xd1\x32\xbd\x9c\x34\xc5\x6c\x5d\xe7\x31\x27\x2b\x09\xb1\x6d\xa2\x84\xc3\x46\x9b\x7c\xde\x53\x5f\x92\x81\x54\x9a\x5a\xbf\x0e\xd0\xbf\x49\x11\x4c\xfe\xff\xa7\xe6\x3f\xe3\x70\x1e\x27\x8b\x06\x00\x00")
+
+func test_certsCaTenantKeyBytes() ([]byte, error) {
+	return bindataRead(
+		_test_certsCaTenantKey,
+		"test_certs/ca-tenant.key",
+	)
+}
+
+func test_certsCaTenantKey() (*asset, error) {
+	bytes, err := test_certsCaTenantKeyBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "test_certs/ca-tenant.key", size: 1675, mode: os.FileMode(0600), modTime: time.Unix(1400000000, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x7b, 0x14, 0x26, 0x26, 0xe, 0x99, 0xe8, 0x58, 0xfe, 0x20, 0x56, 0x9, 0xa4, 0x40, 0x9c, 0x99, 0x6c, 0x5a, 0x66, 0x54, 0x48, 0x44, 0xb6, 0x24, 0x16, 0x61, 0xde, 0x6c, 0x59, 0x4e, 0x7b, 0xe3}}
+	return a, nil
+}
+
 var _test_certsCaCrt = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x64\x94\xc9\xd2\xb2\x38\x14\x40\xf7\x3c\x45\xef\xa9\x2e\x34\x88\xc2\x32\x21\x01\x99\x82\x4c\x22\xee\x40\x24\x0c\x8a\xca\x60\x94\xa7\xef\xfa\xbe\xde\x74\xf5\x7f\x97\xb7\xee\xe2\x54\x9d\x5b\xe7\xef\x9f\x41\xc4\xb4\xe8\x5f\x3a\x09\x63\xcb\xb0\x74\x18\x93\xdf\xad\xe0\x59\x16\xd6\xb1\xae\xc3\x4a\x67\x90\x5b\x08\x32\x2b\x88\xe4\x77\xd3\x6a\xdb\x2d\x98\x1b\x1d\x9e\x9f\xb3\xf4\x15\xef\x22\x86\x14\xb1\xee\x55\x77\x8d\xa9\xf1\x15\x82\xc1\x68\x40\x0c\x07\xc1\x0b\x2d\x4e\x60\x86\x8f\x41\xe0\x10\x7e\xc3\x05\xa0\xc3\xe5\xae\xd5\x19\x60\x1f\x23\x86\x31\x62\xf4\x88\xa0\x17\x63\x42\xdf\x19\x98\xbe\x05\x30\xda\x5c\x47\x38\x88\x21\x33\xf8\xea\x23\xd0\x05\x2e\x5e\xdb\x7d\xe8\x12\xc8\x14\x87\xb9\x09\x55\xc5\x8f\x3b\xc5\xc3\x84\x7b\x31\xe4\x1e\x86\xdc\x33\x1e\xdc\x59\x48\xe4\x21\x68\xc2\x75\x42\xf4\xda\xb3\x03\xa0\xb5\xb9\x6c\xbf\x85\x2c\xa5\x0f\x2f\x4c\x38\xe1\xbf\x14\x98\xf0\xcf\x7f\x29\x58\xb0\x22\x9c\x31\xd2\x78\x70\x65\xea\xd1\xcb\x8c\xac\x42\xc6\x01\x41\x30\x48\x20\xdc\x58\x48\xc0\x1c\xfe\x1c\x38\xf0\x61\x21\x18\xe0\x2a\xdc\x1c\x2b\x0b\x56\xa0\xd5\xce\x9e\x5d\x8e\x31\x53\xdf\x7e\x39\x2e\x3b\xb7\x8e\xd8\x71\xf1\x1c\xd9\x5b\xe2\xf3\xb8\xbb\x87\x69\xec\x07\x52\x79\x16\x66\xf1\xf1\x1d\x71\xa1\x7f\x87\x35\x1a\x23\x62\x24\x4c\x0e\x64\xb4\xa8\x7c\xf0\x0b\x67\x5d\x42\x6f\xb3\xa2\x1e\xb9\x26\x37\xc7\xdf\x1f\x13\x71\x94\xe6\x2b\xbf\x4d\x13\x1a\xdc\x8d\xd4\xf3\xfd\x24\x80\x8d\x9f\x1e\x1e\xec\x1e\xd8\x9b\x1c\x95\xec\x81\xc3\x8b\x6a\xa7\x64\x88\x2a\xda\x96\xaf\x6c\x34\x3a\x32\x42\x15\x2a\x57\x6d\xdb\x3e\xe2\xfa\x73\x2e\xfa\x42\x6d\xc0\xfb\x95\x68\x13\xe8\xde\xba\xc0\xa2\x6f\xf3\x71\x92\xc7\x3d\x26\xf9\x59\x51\x8e\x8a\x94\xec\xcb\xf8\x72\xcb\x73\x97\xee\x9f\x20\x25\x59\x93\x9e\xbe\x71\x90\x14\x7a\x7e\x57\x76\xda\x8e\x6f\x69\x44\x8f\xdd\x92\xe4\xd8\xbe\x1d\x57\xc2\x13\x10\x39\x59\x7a\x7e\xb2\xb4\xb6\xdb\xf5\xbb\x8e\x9c\xdd\x77\xe8\x77\xd5\xfc\x7a\x81\x5a\x3e\xa9\xb1\x78\x0b\x77\xc0\x2d\x12\xe3\xa0\x00\xa9\x2b\x6d\x89\x3e\x0f\x26\x6e\x24\x37\x1d\xc9\xdd\x11\xe4\xdb\xd7\x33\xe2\x25\x7d\x3e\x8b\xbe\xd0\xc7\xf3\x7c\x9e\xee\x57\xfd\x8e\x5f\x27\x33\xdb\xee\x50\xa2\x31\x2e\x43\xe6\x21\x08\xcd\xd6\x6e\x61\xe7\xc1\xcd\x8f\xca\x12\x73\x82\x24\x1e\x10\x01\x72\x4b\x57\x30\x8c\x7e\x1e\x66\x1f\x7a\x08\x56\x2a\xd1\x31\x34\x61\xb0\x97\x20\x23\xe8\xff\xfa\x74\xfe\xaf\x3e\x18\xa0\xb9\x03\xb7\x83\xb0\x2f\xa5\x3d\x6b\x87\x81\x5e\x1a\x90\x24\xf4\x64\x22\x3a\xcb\xc3\xc1\xb7\x57\x9a\xcf\x9e\xe1\x7a\x06\x59\xe6\x2f\xf6\x2d\x71\xa9\xab\xaf\xf6\x80\xdb\xb9\xb9\xba\xbd\x1b\x45\x94\xc2\x47\x33\x5d\x84\x97\x7c\x49\xab\x25\xce\xed\x83\x16\x5a\x27\xc7\x0c\xdc\xce\x3a\x38\xe8\x9a\x6f\x97\xe8\x30\xce\x4a\xd6\x2b\xac\x12\x53\x51\xfb\xc8\x47\xe2\x9c\x49\xd6\xc4\xb9\xbe\x3b\x89\xc5\x46\xb2\x55\xe3\x65\x0a\xfd\x70\x6e\xbf\x15\x29\x27\x89\xd4\xeb\xeb\x48\xa8\x89\x72\x11\xcd\x0d\x9a\xb2\x7e\xf3\x75\x4b\x40\xe0\xd3\x45\x91\xf8\xc8\x8e\x2b\x5d\xdc\x16\x1b\x6d\xbf\xa5\x7b\xaf\xdf\xf5\x52\x68\xc4\xa3\x2a\x34\x49\xe3\xbc\x62\x63\xb3\x4e\xc9\x50\xba\x85\x81\xb7\x72\xa1\x92\xd9\xf5\xaf\x5f\xe7\xd4\x13\x33\x44\xd3\x25\x41\x45\x4b\x9f\x15\x63\x4a\x53\x57\x20\xfd\xf4\x87\xe6\x12\xdf\xdf\xf6\xad\x4d\x76\x82\xb7\xd9\x82\x5d\x62\x3f\xa5\xbb\xce\x7c\x6b\x41\x92\x49\x40\x28\x0e\xb2\x53\x5f\x70\xb9\x3e\x89\x0b\xe0\x99\x0c\xab\xbe\x8e\xcf\xe9\xa1\x56\x6b\xbf\xbc\x72\x7f\x7f\x9f\xad\x6f\xbd\x3a\x54\x91\x2b\x54\x2a\x7d\x7e\xd2\x08\xcc\x5c\x9f\x4a\xac\x4e\xc2\x6f\x26\x08\xc5\x7f\xa6\xe3\x9f\x00\x00\x00\xff\xff\x14\x79\x4e\xe2\x57\x04\x00\x00")
 
 func test_certsCaCrtBytes() ([]byte, error) {
@@ -236,6 +280,46 @@ func test_certsNodeKey() (*asset, error) {
 	return a, nil
 }
 
+var _test_certsTenant10Crt = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x65\x54\x39\xd3\xa3\x4a\x0c\xcc\xf9\x15\x2f\xa7\xb6\x8c\xf1\x49\xb0\xc1\x0c\x33\x1c\x86\xe1\x3e\x0c\x19\x87\x39\x8d\x31\xb6\x61\x80\x5f\xbf\xfe\x76\xc3\xa7\x2a\x25\x2d\x95\xa4\x52\x77\xf5\xaf\x5f\xdf\x80\x58\x56\x8d\xff\x44\xec\x78\xaa\xa4\x8a\xc0\xc3\x3f\xe0\x2f\x86\xa8\x2a\x22\xab\x28\x82\x6a\x2c\x01\x55\x21\x28\x55\x07\x18\x03\x1f\xe1\xa7\x5a\xab\x79\xa0\x73\x17\xbf\x14\x55\x89\xf4\x2d\x45\x76\x74\xd1\xfa\x58\xad\xa6\xcc\x00\x36\xd6\xa1\x0d\x28\xa3\xad\xd8\x25\x10\xc8\x60\xeb\x63\xb1\x22\x17\x9b\x17\x9a\x64\x77\x99\xa2\xd0\xe8\x89\xe3\x53\x4c\x23\x14\xd8\x36\xc2\x74\x46\x29\x6f\xbc\xb2\x4e\xa8\x22\xbe\x2c\x6d\x0e\x7f\xd7\x65\x06\x43\x1a\x40\x4d\x04\x66\x82\x00\xfd\xc9\xb0\x2a\x2d\xd3\x6b\x0f\xa6\xf7\x83\x61\xfa\x0f\x87\x09\x41\x64\xc6\x0d\xb0\x61\x69\x04\x10\xf4\x9e\xe8\x1b\x53\xc4\x7f\x16\x26\xe5\xa5\x26\x41\xd8\x26\x60\xff\xef\x8a\x99\x28\x81\x1c\x8c\x51\x78\xe0\xb2\x15\xeb\x04\xb4\x7f\xf1\xef\x38\x91\x78\x80\x96\x25\xae\x09\xe0\x64\xd1\x1d\x64\x57\x4d\x77\x0c\xb2\x31\x04\xb6\x0f\xc0\x5e\x85\x88\xfe\x6d\xd0\x40\xff\xfd\x85\x2d\x3e\xbd\x31\x99\x01\xdb\x1f\x87\xdd\x4d\xb3\x37\xc7\x6d\x2b\xdf\x37\xdb\x50\x8a\xef\xc9\x52\xca\x9b\x1d\x3c\x2a\xf7\x94\x89\x0a\x55\x70\x0b\x14\x7f\x6c\x3a\x38\x81\xc9\x37\xb6\xd7\xef\xcb\x8c\x87\x59\x77\x97\x8f\x49\x6b\xa4\x9d\xe3\x7b\x74\x51\x3e\x8d\xb1\x3f\x8b\xfc\x21\x68\xb5\x8f\xd9\xc0\x6b\xe8\xdf\xfd\x77\xfa\x64\x90\x30\x86\x57\x77\xcb\xe7\x75\xfe\xce\x39\xcf\xf8\x28\x5d\xfe\x7c\xb5\x6e\x65\x6d\x2a\xbd\x3e\x6a\xdd\xcc\xf7\x53\x3e\xa1\xb7\x79\xe3\xcf\x70\x76\xac\x9d\x23\xe5\x88\x0d\x94\xf1\x52\x75\xf3\x94\x30\x33\x92\x5a\xc1\x37\xa4\xb3\xb3\x72\x03\x9b\x86\x17\xdb\xb9\x3e\x46\xaf\xf7\x36\xc3\x81\xe7\x3a\xaa\x2a\xdc\x18\xd0\xbd\x2e\x1e\xac\x3a\x0c\x83\x38\x4e\xc7\x6c\x5b\x9c\x48\xea\xba\x73\x7d\x75\x04\x46\x94\x2d\xc5\xbc\x87\x1a\x47\xcd\x68\xee\x96\x71\x45\x9d\x73\x54\xf7\x9f\xc7\x64\xe6\xfb\xbb\xbe\xaa\x51\x53\xe5\xe3\xc2\x6e\x8c\x2c\x64\x0d\xf1\x4c\x6f\x3b\xc1\xcd\xb9\x30\x92\xf0\x07\x9e\xbc\x89\x21\xb7\x4b\x6f\xd9\xbd\x71\x63\x1f\xe8\x8a\xf2\x41\x13\xf4\x81\xc8\xdc\xbe\x48\xac\x14\xd8\xf5\x4e\x37\xef\x34\x50\x5f\x16\x9b\x24\xfd\xf5\xb4\x8f\x41\xf9\x55\x0d\x90\x1b\x17\x41\xf9\x87\x39\xe6\x4b\x51\x8e\x28\x86\x1b\x6a\xe3\xaf\x12\xa5\x1e\x01\xef\x87\x6b\xc5\xf5\x31\x42\x40\x83\x65\xf9\x82\x25\x96\xa0\x9d\x21\xd0\x80\xe2\x5f\x8d\x60\x19\x81\xb0\x84\x36\xcf\xdc\xfc\xbb\x9d\x87\xb5\xa0\x9d\x57\x58\x2d\xbd\x08\x26\x29\xf7\x04\x5c\x6c\x10\x30\x60\xd9\x0e\x55\x5b\xcb\x02\xe5\xbe\xb4\xbe\x25\x00\x4c\xf1\x2b\x61\x30\x26\x93\xb1\x77\xe3\x67\x61\x69\x98\x81\xf4\xc1\x72\xe7\x83\x27\x39\x87\xc8\xc9\x36\xf9\x1a\x9e\x15\x54\x71\xf3\x19\x6c\x06\x55\x2b\xde\xa1\xdf\x0a\x8d\xdf\xfb\x7a\x2b\x65\x4e\x3c\x67\xbb\xca\xa1\xf5\x64\x1c\x3a\xe7\xd1\x37\xb2\x44\x18\xf5\x7a\x8f\x73\x2c\x38\xb4\x6b\x9f\x65\xf4\x69\xab\x40\x3f\x49\x68\x20\xfe\x6c\x4d\x4e\x4f\x24\x9d\xf8\x44\x16\xa0\x8c\x02\xe4\x2c\xce\x9a\x6c\x4c\xf3\x69\x9e\x91\xbe\x26\x66\xfa\x82\xc1\xc0\x28\x0f\x5d\x03\x9f\x98\x4d\x6f\x20\xdb\x5e\x01\xee\x0f\x2b\x3a\xb5\x86\xd7\x0b\x74\x30\x63\x21\xb8\xf9\xd6\xea\x65\x4e\x86\x5f\x1a\xd0\xcf\xd3\x56\xc8\x82\x3e\xab\x25\x74\xf2\x59\xf5\x6e\xb6\x0f\xc6\xb4\x82\x57\xeb\x5f\x5e\xe7\xc5\xd8\x29\x5d\x55\xa0\x22\x1e\xd7\x2c\xff\xe4\xf2\x83\x5f\xc1\x81\xea\x4e\x7d\xea\xc1\xde\x1a\x55\x27\xd7\x96\x75\x8a\xaa\xab\x92\x5e\xf0\x29\xba\x1e\x50\xbe\x7b\x9b\xcc\x6e\x95\x2e\xd0\x3d\xd0\x5d\x76\x71\xa7\x26\xa3\xc7\x5b\xff\x20\x4b\xc1\x72\xe2\xf6\x4c\xfc\xeb\x7c\xab\x62\xfe\xe2\x82\xa4\xb8\x3f\x4c\x9d\xad\x7c\xa4\x6d\x27\x20\x58\x33\xee\x50\xfd\x16\xbd\x91\x19\x97\x63\x56\xb3\xc7\xdb\x52\xfe\xfe\xcd\xfc\xf5\x18\x6c\xa0\xff\xfb\xce\x1f\x40\x57\xfc\xe6\x94\x04\x00\x00")
+
+func test_certsTenant10CrtBytes() ([]byte, error) {
+	return bindataRead(
+		_test_certsTenant10Crt,
+		"test_certs/tenant.10.crt",
+	)
+}
+
+func test_certsTenant10Crt() (*asset, error) {
+	bytes, err := test_certsTenant10CrtBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "test_certs/tenant.10.crt", size: 1172, mode: os.FileMode(0600), modTime: time.Unix(1400000000, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x7, 0x9, 0xfc, 0x15, 0x5c, 0x1f, 0x85, 0xac, 0x81, 0x14, 0x1e, 0xc2, 0xca, 0x8d, 0x1c, 0x45, 0xa7, 0x5b, 0xf9, 0xc5, 0xcf, 0x5d, 0x93, 0x6b, 0xec, 0x3f, 0xd4, 0xfb, 0x8b, 0x85, 0xef, 0xa}}
+	return a, nil
+}
+
+var _test_certsTenant10Key = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x04\xc0\x47\xd6\x6b\x50\x00\x00\xe0\xb9\x55\xfc\x73\xc7\x89\x96\x90\xc1\x1b\x5c\xdc\x20\x44\xef\x33\xbd\xb7\xa8\xb1\xfa\xf7\x61\x18\x86\x61\x1c\x14\x65\xed\xcf\xb2\xc1\x9f\x61\xc9\x1e\x70\xe0\x9f\x02\x43\x0c\xc3\x30\x0c\xf9\xc8\x32\x9c\x80\xcc\x01\xa0\xf0\xc0\x84\x60\x76\x99\x7e\x31\x8d\x59\x9f\x57\xaa\x6e\x0d\x74\x8d\xb8\xa9\x78\x7a\x95\x1f\xf9\xcb\xc8\xed\xcf\x23\xaf\xee\x3e\x29\xfd\x0c\x77\x38\xc8\xc4\xfd\x20\x4a\xeb\x39\xeb\x88\xc3\x87\x2e\x4b\x5a\x19\xbc\x27\x6b\xeb\x27\xc1\xbf\xb7\x1e\x64\x3f\x15\x13\x5e\xb9\x01\xd6\xcd\x8b\xf8\xaf\x33\x1e\x1e\xd1\xbd\x3d\x55\x7c\x98\xb7\xa4\xeb\xf0\x35\x1b\xc2\x01\x49\xa4\x0c\x5e\x89\x75\x1f\xe2\xf8\x0e\x47\xe7\x46\xdb\xf4\x56\x4f\x4b\x36\xab\x54\x72\x30\xc2\xb0\xee\x20\x75\x9d\x27\x6e\x05\xe6\xad\xb3\x98\x3a\x8c\x96\x84\x5c\xcc\x33\x32\x3c\xc1\x2a\x60\x8a\x3c\x95\xbd\x27\x6a\x17\x7a\x77\xa6\x7d\xc0\xdb\x63\xcb\xd6\xf7\xc2\x73\x4f\xb5\x4b\x75\xfe\xd8\x1c\xba\xeb\x3a\xdf\xf7\x99\x41\x0b\xd0\x4b\xc4\xdb\x25\xec\xf0\xc2\xac\x9a\xf3\x9a\xbc\x7a\xfd\x08\x48\xff\x89\x96\xef\x72\xdc\xdb\xbc\xd6\xd5\x88\xb9\x86\xfc\x6d\xb3\x3f\x51\xa6\x83\x64\xd9\xf7\x2b\x78\xed\x8d\xb9\x7f\xa4\xf5\xe6\x0e\xd9\xab\xe7\x2c\xd5\xcc\x71\xe6\x92\xea\x58\xc0\x15\x21\xd8\x27\xe4\x20\x0e\x2a\xae\x77\xfb\xd1\x70\xab\x2e\x91\x0d\x79\x40\x79\xfd\x5d\x53\xfa\xb2\x95\xeb\xd6\xf7\xf3\x0b\x45\x45\x53\x16\x80\x09\x38\x30\xca\x1c\x80\xb2\x06\xc3\x5d\x29\xbf\xee\x17\x5f\x44\x1e\x39\x5e\x93\x0f\xca\x59\xee\xf4\x5c\x5b\x44\xee\xd0\x7f\xd2\xa7\xb9\xe7\x8f\xd6\x08\x8b\x37\xa7\x3a\x60\x78\x11\x56\x20\x8c\x28\xf7\x2d\x87\xeb\x61\xce\x52\xce\x52\x44\x9d\xfd\x8e\x6b\xda\xd8\xfe\x42\xee\x95\x0c\x8e\xec\x47\x97\x85\x94\x53\x96\x9b\x93\x65\xd5\x40\x48\xde\x9d\x70\xd4\x2d\x0d\x65\xf6\x78\x2e\xa6\xf3\xa4\xac\x58\xc8\x51\x56\xe2\xfc\x17\xa8\xc6\x07\x1a\x3a\xf8\xe9\xb3\x7e\x1a\x21\x3e\x65\xef\x83\x6e\x4b\xdd\xcb\x53\xcc\x76\xf8\xfa\x5c\xaa\x8a\x0f\xcf\xd8\x38\xed\x3c\x71\xb8\x10\x7c\xeb\x29\xa8\x33\x47\x57\x4a\xa8\x37\xd4\xd8\x5d\xf6\xaa\x8d\x2f\xab\x8a\x6f\x8a\xbb\x19\x2b\xe2\xd4\x0a\xde\xd4\x5c\x6d\x87\xba\xec\x1d\x53\x91\x53\xbd\x74\xa8\x67\xd2\xe9\xe9\x2f\xf2\x1c\xb0\xde\x6e\x65\xfe\xa6\xf6\x65\x77\xf8\xa7\xec\x3e\xfd\x3a\x56\x99\xe9\x39\xbe\xb7\xb1\xad\x47\x3c\x43\xd2\x90\xe6\xaf\x40\xb2\xd0\x5a\xcc\xdd\x2e\x25\x9f\xe8\x81\xa7\x41\x36\xc2\xc3\xd9\xf7\x4f\x0e\xb4\xe7\x58\xcd\xb0\xb9\x09\x64\x61\xad\xac\x1f\x4f\x28\x78\x08\xe2\xcf\x15\xa0\xa2\x14\x85\xde\x23\xe2\xcc\x0d\xaf\x47\xca\x97\x21\x04\x17\x05\x28\xb3\x31\x80\x6c\xb8\xf7\x6c\xe4\x8b\x10\xde\xed\x5a\x7e\xa9\xb2\xfc\x7a\x8e\xa7\xae\xa0\x14\x3f\x6a\xec\x70\x35\xc1\xca\x4d\x95\x58\x52\x13\x6e\x20\x9e\x22\x52\xf7\x32\x63\xce\xc3\x21\x58\xfc\xdb\x30\xb6\xa7\x77\xe6\x57\x59\x09\xc5\x5a\x7f\x8d\xec\x64\xbc\xca\xcf\x27\x7a\x2a\xe7\x7e\x29\xbd\x5c\x26\x24\xa9\xcd\x50\x4f\x7f\x9e\x51\x49\x6e\x84\x84\xe4\x33\xf6\x9a\x48\xf4\x36\xfa\xd8\xa8\xb3\x16\x7c\xfb\x27\x5a\x9f\x04\x68\x74\xa3\x08\x2e\x47\x10\xd1\xd6\xde\xac\xbe\xc2\x6b\x19\x8e\x51\xf4\x96\x9a\xf3\x83\xb2\x7c\x19\x42\x80\x1b\xa2\x8a\x4c\x52\x7f\x73\x58\x46\xa3\x02\x6b\xbd\xee\xdd\xa8\xec\x2c\xb7\x04\xd6\xfd\xcd\x5a\xf6\x3e\x39\x3f\x32\xa7\xad\xcf\x78\x8e\x59\x6b\xe3\xbe\x6a\x35\x54\xc1\xe1\x94\xa4\x33\x64\x18\x9c\xf6\xa1\xbf\x90\xd6\xd0\x92\x97\xea\x4b\x5f\xad\xd1\xa1\xf0\xc8\x75\x92\xf4\x67\x96\x52\x93\x72\x3c\xfc\x13\xee\x52\xbf\x66\x9e\x66\x32\xaa\x00\x02\xb1\x8f\x0d\x6d\x21\x3e\x21\xaa\x4c\x53\x74\x64\xd2\x7e\x03\x48\x48\xcb\x7c\x18\xeb\x5e\xbf\xd9\xcb\x53\x8b\xb7\x95\x4f\xcf\x3d\x4f\x5f\x09\x29\x56\x6a\xa5\x13\x46\x31\x4b\xdc\x90\xf2\x65\x08\xc1\x22\x1e\x1d\x7b\xd2\xd0\xa3\x35\x3a\x48\xbe\x3c\x1e\x6f\x2a\xf2\xa9\x45\xb7\x08\x99\x96\x8b\x81\x14\x56\xc1\xc7\x67\xa2\x34\xa3\x03\xfd\x1b\x37\x4b\x39\x57\x59\x4a\x7f\x72\x6a\x98\x63\xda\x4c\x56\xe1\x00\xc7\xe0\xdf\x0d\xdb\x62\xfb\x26\xcc\x2b\x74\x78\xcc\x08\xa3\x69\x75\x13\x3f\xbf\xa2\x1f\x90\x13\x73\x03\x32\x59\xec\xf4\xf2\x76\xca\x34\x91\x76\x18\x08\xee\xad\xd2\xcb\xbe\xac\xa7\xb9\x9a\xd6\xfc\x7b\xb6\x71\x0c\xf3\x5a\xdd\xc7\x7e\x55\x4e\xc2\xcb\x90\x3d\x14\x18\x6e\x89\x9b\x55\x4e\xad\x92\xba\x39\x5f\x6d\x0d\x72\x1d\xe7\xcb\x10\x08\xab\xe3\x97\xea\xe4\x4c\xf5\x9c\x90\x5d\xd5\x85\xe7\xc7\x1d\xb2\x1a\x17\x2b\xde\xcf\xcd\x72\xae\x28\xfb\xd2\x91\xd7\x75\x3f\xe8\x46\x65\x83\x96\xe2\x80\x33\x83\x6c\x51\x17\x79\xf2\x9b\x8b\x46\xf7\x91\xeb\x8a\x85\x97\xf2\xc9\x09\x15\x45\x50\x25\xd6\xb4\x25\x9c\xfa\xc8\x3c\x55\x14\xab\x59\xbb\x93\xfd\xfe\x22\xcc\x27\x70\xf5\x71\xdf\xac\x0c\x5c\x6f\xf5\x92\x68\x9b\x91\xc9\x9d\xca\xd6\xe0\xc1\x5f\x1e\xb4\x96\x9d\x5a\xf1\x87\xe5\x81\xae\x48\x22\xf1\x36\x18\x72\x62\xa7\x5b\x68\xff\x36\x92\x75\xa6\xf4\x8e\xfc\xbc\x2c\x86\x87\xc2\x95\x26\x4f\x18\x66\xb8\xf7\xc4\xb3\x75\x81\x59\x53\x7c\x1b\x05\x72\x28\x3f\x5d\xdb\x7c\x41\x34\xe7\xbe\xb3\x1a\x71\xa6\xe0\x94\x21\xee\xd8\xfb\xa9\x77\x72\x94\x5d\x91\x85\x84\x9c\x68\xa2\x1e\xc5\x16\x30\x10\xf4\x57\x9b\x2c\xab\x74\xe5\xe6\x17\xbd\x11\xfa\xf1\x12\x98\xab\x16\x12\xc2\xa8\x92\xda\x6f\x48\xa1\xd9\xec\xa0\x9d\x7a\x22\xb5\xc9\xb2\xd5\x4b\x87\xd5\x53\x02\xc9\xdd\x22\x7a\x53\x52\xb9\x95\xfe\xf3\xe3\xa2\x59\x27\x6d\xde\x04\xfb\x9f\x01\xe7\x82\xa9\xe9\x87\xf6\xe8\x5b\xb4\xaa\x4b\xf1\xdb\x02\x47\xeb\x32\x4a\x2d\x5a\x0e\xfc\xfb\x87\x60\x18\x86\x61\x50\x13\xfe\x2c\x1b\xfc\x19\x96\xec\x01\x07\xfe\x29\x30\xc4\x30\x0c\xc3\x90\xff\x03\x00\xbe\x17\xda\xc1\x8f\x06\x00\x00")
+
+func test_certsTenant10KeyBytes() ([]byte, error) {
+	return bindataRead(
+		_test_certsTenant10Key,
+		"test_certs/tenant.10.key",
+	)
+}
+
+func test_certsTenant10Key() (*asset, error) {
+	bytes, err := test_certsTenant10KeyBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "test_certs/tenant.10.key", size: 1679, mode: os.FileMode(0600), modTime: time.Unix(1400000000, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x2f, 0xd7, 0x88, 0x48, 0x74, 0xb3, 0x7c, 0xc5, 0x6e, 0x76, 0x50, 0x5b, 0xd8, 0xa1, 0x79, 0xf, 0x51, 0x20, 0x8b, 0x7f, 0x5f, 0xc4, 0xee, 0x6c, 0xa9, 0x7, 0x95, 0x8, 0x6, 0x29, 0xb3, 0xc6}}
+	return a, nil
+}
+
 // Asset loads and returns the asset for the given name.
 // It returns an error if the asset could not be found or
 // could not be loaded.
@@ -327,6 +411,10 @@ func AssetNames() []string {
 
 // _bindata is a table, holding each asset generator, mapped to its name.
 var _bindata = map[string]func() (*asset, error){
+	"test_certs/ca-tenant.crt": test_certsCaTenantCrt,
+
+	"test_certs/ca-tenant.key": test_certsCaTenantKey,
+
 	"test_certs/ca.crt": test_certsCaCrt,
 
 	"test_certs/ca.key": test_certsCaKey,
@@ -342,6 +430,10 @@ var _bindata = map[string]func() (*asset, error){
 	"test_certs/node.crt": test_certsNodeCrt,
 
 	"test_certs/node.key": test_certsNodeKey,
+
+	"test_certs/tenant.10.crt": test_certsTenant10Crt,
+
+	"test_certs/tenant.10.key": test_certsTenant10Key,
 }
 
 // AssetDir returns the file names below a certain
@@ -386,6 +478,8 @@ type bintree struct {
 
 var _bintree = &bintree{nil, map[string]*bintree{
 	"test_certs": {nil, map[string]*bintree{
+		"ca-tenant.crt":       {test_certsCaTenantCrt, map[string]*bintree{}},
+		"ca-tenant.key":       {test_certsCaTenantKey, map[string]*bintree{}},
 		"ca.crt":              {test_certsCaCrt, map[string]*bintree{}},
 		"ca.key":              {test_certsCaKey, map[string]*bintree{}},
 		"client.root.crt":     {test_certsClientRootCrt, map[string]*bintree{}},
@@ -394,6 +488,8 @@ var _bintree = &bintree{nil, map[string]*bintree{
 		"client.testuser.key": {test_certsClientTestuserKey, map[string]*bintree{}},
 		"node.crt":            {test_certsNodeCrt, map[string]*bintree{}},
 		"node.key":            {test_certsNodeKey, map[string]*bintree{}},
+		"tenant.10.crt":       {test_certsTenant10Crt, map[string]*bintree{}},
+		"tenant.10.key":       {test_certsTenant10Key, map[string]*bintree{}},
 	}},
 }}
 
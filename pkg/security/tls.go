@@ -67,21 +67,23 @@ func LoadServerTLSConfig(sslCA, sslClientCA, sslCert, sslCertKey string) (*tls.C
 // - the certificate of this node (should be signed by the CA),
 // - the private key of this node.
 // - the certificate of the cluster CA, used to verify other server certificates
-// - the certificate of the client CA, used to verify client certificates
+// - the certificates of the client CAs, used to verify client certificates
 //
-// caClientPEM can be equal to caPEM (shared CA) or nil (use system CA pool).
-func newServerTLSConfig(certPEM, keyPEM, caPEM, caClientPEM []byte) (*tls.Config, error) {
+// caClientPEMs can contain caPEM (shared CA) or be empty (use system CA pool).
+func newServerTLSConfig(certPEM, keyPEM, caPEM []byte, caClientPEMs ...[]byte) (*tls.Config, error) {
 	cfg, err := newBaseTLSConfigWithCertificate(certPEM, keyPEM, caPEM)
 	if err != nil {
 		return nil, err
 	}
 	cfg.ClientAuth = tls.VerifyClientCertIfGiven
 
-	if caClientPEM != nil {
+	if len(caClientPEMs) != 0 {
 		certPool := x509.NewCertPool()
 
-		if !certPool.AppendCertsFromPEM(caClientPEM) {
-			return nil, errors.Errorf("failed to parse client CA PEM data to pool")
+		for _, pem := range caClientPEMs {
+			if !certPool.AppendCertsFromPEM(pem) {
+				return nil, errors.Errorf("failed to parse client CA PEM data to pool")
+			}
 		}
 		cfg.ClientCAs = certPool
 	}
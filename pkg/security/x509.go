@@ -186,9 +186,6 @@ func GenerateUIServerCert(
 // GenerateClientCert generates a client certificate and returns the cert bytes.
 // Takes in the CA cert and private key, the client public key, the certificate lifetime,
 // and the username.
-//
-// This is used both for vanilla CockroachDB user client certs as well as for the
-// multi-tenancy KV auth broker (in which case the user is a SQL tenant).
 func GenerateClientCert(
 	caCert *x509.Certificate,
 	caPrivateKey crypto.PrivateKey,
@@ -224,3 +221,75 @@ func GenerateClientCert(
 
 	return certBytes, nil
 }
+
+// TenantsOU is the OrganizationalUnit that determines a client certificate
+// should be treated as a tenant client certificate (as opposed to a KV
+// node client certificate).
+const TenantsOU = "Tenants"
+
+// IsTenantCert returns whether the certificate is a tenant client certificate.
+func IsTenantCert(cert *x509.Certificate) bool {
+	for _, ou := range cert.Subject.OrganizationalUnit {
+		if ou == TenantsOU {
+			return true
+		}
+	}
+	return false
+}
+
+// VerifiedByCA returns whether one of the chains that a peer certificate was
+// verified with ends at one of the given CA certificates.
+func VerifiedByCA(verifiedChains [][]*x509.Certificate, cas []*x509.Certificate) bool {
+	for _, chain := range verifiedChains {
+		if len(chain) == 0 {
+			continue
+		}
+		root := chain[len(chain)-1]
+		for _, ca := range cas {
+			if root.Equal(ca) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// GenerateTenantClientCert generates a tenant client certificate and returns
+// the cert bytes. Takes in the CA cert and private key, the client public key,
+// the certificate lifetime, and the tenant identifier, which is used as the
+// certificate's common name.
+//
+// These certificates are used by the multi-tenancy KV auth broker to
+// authenticate SQL tenants against the KV layer.
+func GenerateTenantClientCert(
+	caCert *x509.Certificate,
+	caPrivateKey crypto.PrivateKey,
+	clientPublicKey crypto.PublicKey,
+	lifetime time.Duration,
+	tenantIdentifier string,
+) ([]byte, error) {
+	if len(tenantIdentifier) == 0 {
+		return nil, errors.Errorf("tenant identifier cannot be empty")
+	}
+
+	template, err := newTemplate(tenantIdentifier, lifetime)
+	if err != nil {
+		return nil, err
+	}
+	template.Subject.OrganizationalUnit = []string{TenantsOU}
+
+	// Don't issue certificates that outlast the CA cert.
+	if err := checkLifetimeAgainstCA(template, caCert); err != nil {
+		return nil, err
+	}
+
+	// Client authentication only.
+	template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+
+	certBytes, err := x509.CreateCertificate(rand.Reader, template, caCert, clientPublicKey, caPrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return certBytes, nil
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/kvcoord"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/tenantrate"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/server/status"
 	"github.com/cockroachdb/cockroach/pkg/settings"
@@ -35,6 +36,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
 	"github.com/cockroachdb/cockroach/pkg/storage"
 	"github.com/cockroachdb/cockroach/pkg/util"
+	"github.com/cockroachdb/cockroach/pkg/util/admission"
 	"github.com/cockroachdb/cockroach/pkg/util/growstack"
 	"github.com/cockroachdb/cockroach/pkg/util/grpcutil"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
@@ -159,6 +161,13 @@ type Node struct {
 	initialBoot bool // True if this is the first time this node has started.
 	txnMetrics  kvcoord.TxnMetrics
 
+	// tenantLimiters rate limits the requests of SQL tenants.
+	tenantLimiters *tenantrate.LimiterFactory
+
+	// admissionController performs admission control for the requests that
+	// the node serves.
+	admissionController *admission.Controller
+
 	perReplicaServer kvserver.Server
 }
 
@@ -299,7 +308,14 @@ func NewNode(
 		txnMetrics:  txnMetrics,
 		eventLogger: eventLogger,
 		clusterID:   clusterID,
+
+		tenantLimiters: tenantrate.NewLimiterFactory(cfg.Settings),
 	}
+	n.storeCfg.TenantRateLimiters = n.tenantLimiters
+	reg.AddMetricStruct(n.tenantLimiters.Metrics())
+	n.admissionController = admission.NewController(
+		cfg.Settings, cfg.HistogramWindowInterval, n.storeL0Metrics)
+	reg.AddMetricStruct(n.admissionController.Metrics())
 	n.perReplicaServer = kvserver.MakeServer(&n.Descriptor, n.stores)
 	return n
 }
@@ -455,6 +471,7 @@ func (n *Node) start(
 	}
 
 	n.startComputePeriodicMetrics(n.stopper, base.DefaultMetricsSampleInterval)
+	n.admissionController.Start(n.AnnotateCtx(context.Background()), n.stopper)
 
 	// Be careful about moving this line above `startStores`; store migrations rely
 	// on the fact that the cluster version has not been updated via Gossip (we
@@ -636,6 +653,25 @@ func (n *Node) gossipStores(ctx context.Context) {
 	}
 }
 
+// storeL0Metrics returns the L0 metrics of the node's stores, which drive the
+// admission of writes.
+func (n *Node) storeL0Metrics() []admission.StoreL0Metrics {
+	var metrics []admission.StoreL0Metrics
+	_ = n.stores.VisitStores(func(s *kvserver.Store) error {
+		stats, err := s.Engine().GetStats()
+		if err != nil {
+			log.Warningf(context.TODO(), "%s: unable to read engine stats: %v", s, err)
+			return nil
+		}
+		metrics = append(metrics, admission.StoreL0Metrics{
+			FileCount:     stats.L0FileCount,
+			SublevelCount: stats.L0SublevelCount,
+		})
+		return nil
+	})
+	return metrics
+}
+
 // startComputePeriodicMetrics starts a loop which periodically instructs each
 // store to compute the value of metrics which cannot be incrementally
 // maintained.
@@ -841,6 +877,22 @@ func (n *Node) batchInternal(
 			log.Eventf(ctx, "node received request: %s", args.Summary())
 		}
 
+		// Requests from SQL tenants are subject to the tenant's rate limit.
+		var limiter tenantrate.Limiter
+		if tenID, ok := roachpb.TenantFromContext(ctx); ok && tenID != roachpb.SystemTenantID {
+			limiter = n.tenantLimiters.GetTenant(tenID)
+			defer n.tenantLimiters.Release(limiter)
+			if err := limiter.Wait(ctx, writeBytesFromBatch(args)); err != nil {
+				return err
+			}
+		}
+
+		if enabled, err := n.admissionController.Admit(ctx, admissionInfo(args)); err != nil {
+			return err
+		} else if enabled {
+			defer n.admissionController.AdmittedWorkDone()
+		}
+
 		tStart := timeutil.Now()
 		var pErr *roachpb.Error
 		br, pErr = n.stores.Send(ctx, *args)
@@ -852,6 +904,9 @@ func (n *Node) batchInternal(
 			panic(roachpb.ErrorUnexpectedlySet(n.stores, br))
 		}
 		n.metrics.callComplete(timeutil.Since(tStart), pErr)
+		if limiter != nil {
+			limiter.RecordRead(ctx, int64(br.Size()))
+		}
 		br.Error = pErr
 		return nil
 	}); err != nil {
@@ -860,6 +915,52 @@ func (n *Node) batchInternal(
 	return br, nil
 }
 
+// admissionInfo determines how a batch is treated by admission control.
+// Batches that are marked as bulk work by their sender, like the AddSSTable
+// requests of IMPORT and the batches of index and column backfills, are
+// deprioritized. Requests to the system keyspace, like node liveness
+// heartbeats, are not subject to admission control. Neither are the requests
+// that finish or push transactions and resolve their intents: admitted work
+// may be waiting on conflicting transactions, so making those requests wait
+// for admission could deadlock once all slots are taken.
+func admissionInfo(ba *roachpb.BatchRequest) admission.WorkInfo {
+	info := admission.WorkInfo{Priority: admission.UserPri, IsWrite: ba.IsWrite()}
+	if rs, err := keys.Range(ba.Requests); err == nil &&
+		!roachpb.RKey(keys.UserTableDataMin).Less(rs.EndKey) {
+		info.Priority = admission.SystemPri
+	} else if hasTxnProgressRequest(ba) {
+		info.Priority = admission.SystemPri
+	} else if ba.AdmissionPriority == roachpb.BulkAdmissionPriority {
+		info.Priority = admission.BulkPri
+	}
+	return info
+}
+
+// hasTxnProgressRequest returns whether the batch contains a request that
+// finishes or pushes a transaction, or resolves its intents.
+func hasTxnProgressRequest(ba *roachpb.BatchRequest) bool {
+	for _, ru := range ba.Requests {
+		switch ru.GetInner().Method() {
+		case roachpb.PushTxn, roachpb.QueryTxn, roachpb.ResolveIntent,
+			roachpb.ResolveIntentRange, roachpb.HeartbeatTxn, roachpb.EndTxn:
+			return true
+		}
+	}
+	return false
+}
+
+// writeBytesFromBatch returns the number of bytes that a batch writes, which
+// is approximated by the size of its write requests.
+func writeBytesFromBatch(ba *roachpb.BatchRequest) int64 {
+	var writeBytes int64
+	for _, ru := range ba.Requests {
+		if req := ru.GetInner(); !roachpb.IsReadOnly(req) {
+			writeBytes += int64(req.Size())
+		}
+	}
+	return writeBytes
+}
+
 // Batch implements the roachpb.InternalServer interface.
 func (n *Node) Batch(
 	ctx context.Context, args *roachpb.BatchRequest,
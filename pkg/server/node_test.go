@@ -17,6 +17,7 @@ import (
 	"reflect"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/base"
 	"github.com/cockroachdb/cockroach/pkg/clusterversion"
@@ -25,11 +26,13 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/gossip"
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/tenantrate"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/server/status/statuspb"
 	"github.com/cockroachdb/cockroach/pkg/storage"
 	"github.com/cockroachdb/cockroach/pkg/testutils"
 	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/util/admission"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
 	"github.com/cockroachdb/cockroach/pkg/util/uuid"
@@ -630,3 +633,156 @@ func TestNodeSendUnknownBatchRequest(t *testing.T) {
 		t.Fatalf("expected unsupported request, not %v", br.Error)
 	}
 }
+
+func TestNodeBatchTenantRateLimit(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	s, _, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(ctx)
+	ts := s.(*TestServer)
+	n := ts.node
+	metrics := n.tenantLimiters.Metrics()
+
+	tenID := roachpb.MakeTenantID(10)
+	tenCtx := roachpb.NewContextForTenant(ctx, tenID)
+	key := roachpb.Key(keys.MakeTenantPrefix(tenID))
+	// The replica of the tenant's range holds a reference to its limiter.
+	leftDesc, desc, err := ts.SplitRange(key)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), metrics.Tenants.Value())
+	var ba roachpb.BatchRequest
+	ba.RangeID = desc.RangeID
+	ba.Replica = desc.InternalReplicas[0]
+	ba.Add(roachpb.NewPut(key, roachpb.MakeValueFromString("v")))
+	br, err := n.Batch(tenCtx, &ba)
+	require.NoError(t, err)
+	require.Nil(t, br.Error)
+	require.Equal(t, int64(1), metrics.RequestsAdmitted.Count())
+	require.Equal(t, writeBytesFromBatch(&ba), metrics.WriteBytesAdmitted.Count())
+	require.NotZero(t, metrics.ReadBytesAdmitted.Count())
+
+	// Requests that don't originate from a tenant are not rate limited.
+	br, err = n.Batch(ctx, &ba)
+	require.NoError(t, err)
+	require.Nil(t, br.Error)
+	require.Equal(t, int64(1), metrics.RequestsAdmitted.Count())
+
+	// The per-tenant metrics are exported to prometheus.
+	body, err := getText(s, s.AdminURL()+statusPrefix+"vars")
+	require.NoError(t, err)
+	require.Contains(t, string(body), `kv_tenant_rate_limit_requests_admitted{tenant_id="10"} 1`)
+	require.Equal(t, int64(1), metrics.Tenants.Value())
+
+	// Waiting on the rate limiter can be canceled.
+	n.tenantLimiters.UpdateConfig(tenantrate.Config{
+		Requests:   tenantrate.LimitConfig{Rate: 1e-3, Burst: 1},
+		ReadBytes:  tenantrate.LimitConfig{Rate: 1e-3, Burst: 1},
+		WriteBytes: tenantrate.LimitConfig{Rate: 1e-3, Burst: 1},
+	})
+	cancelCtx, cancel := context.WithCancel(tenCtx)
+	cancel()
+	br, err = n.Batch(cancelCtx, &ba)
+	require.NoError(t, err)
+	require.True(t, testutils.IsPError(br.Error, context.Canceled.Error()), "%v", br.Error)
+
+	// The limiter is dropped once the tenant's last replica is removed.
+	require.NoError(t, ts.DB().AdminMerge(ctx, leftDesc.StartKey.AsRawKey()))
+	testutils.SucceedsSoon(t, func() error {
+		if n := metrics.Tenants.Value(); n != 0 {
+			return errors.Errorf("%d tenants are still rate limited", n)
+		}
+		return nil
+	})
+}
+
+func TestAdmissionInfo(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	userKey := roachpb.Key(keys.SystemSQLCodec.TablePrefix(keys.MinUserDescID))
+	makeBatch := func(key roachpb.Key, pri roachpb.AdmissionPriority) *roachpb.BatchRequest {
+		var ba roachpb.BatchRequest
+		ba.AdmissionPriority = pri
+		ba.Add(roachpb.NewPut(key, roachpb.MakeValueFromString("v")))
+		return &ba
+	}
+
+	testCases := []struct {
+		ba  *roachpb.BatchRequest
+		exp admission.WorkPriority
+	}{
+		{makeBatch(userKey, roachpb.NormalAdmissionPriority), admission.UserPri},
+		// Backfills write with ordinary requests, but mark their batches as
+		// bulk work.
+		{makeBatch(userKey, roachpb.BulkAdmissionPriority), admission.BulkPri},
+		{makeBatch(keys.NodeLivenessKey(1), roachpb.NormalAdmissionPriority), admission.SystemPri},
+		{makeBatch(keys.NodeLivenessKey(1), roachpb.BulkAdmissionPriority), admission.SystemPri},
+	}
+	for _, tc := range testCases {
+		info := admissionInfo(tc.ba)
+		require.Equal(t, tc.exp, info.Priority, "%s", tc.ba)
+		require.True(t, info.IsWrite)
+	}
+
+	// Requests that finish or push transactions and resolve their intents are
+	// not subject to admission control, even on user keys.
+	txn := roachpb.MakeTransaction("test", userKey, 0, hlc.Timestamp{WallTime: 1}, 0)
+	for _, req := range []roachpb.Request{
+		&roachpb.PushTxnRequest{RequestHeader: roachpb.RequestHeader{Key: userKey}},
+		&roachpb.QueryTxnRequest{RequestHeader: roachpb.RequestHeader{Key: userKey}},
+		&roachpb.ResolveIntentRequest{RequestHeader: roachpb.RequestHeader{Key: userKey}},
+		&roachpb.ResolveIntentRangeRequest{
+			RequestHeader: roachpb.RequestHeader{Key: userKey, EndKey: userKey.PrefixEnd()},
+		},
+		&roachpb.HeartbeatTxnRequest{RequestHeader: roachpb.RequestHeader{Key: userKey}},
+		&roachpb.EndTxnRequest{RequestHeader: roachpb.RequestHeader{Key: userKey}, Commit: true},
+	} {
+		ba := makeBatch(userKey, roachpb.NormalAdmissionPriority)
+		ba.Txn = &txn
+		ba.Add(req)
+		require.Equal(t, admission.SystemPri, admissionInfo(ba).Priority, "%s", ba)
+	}
+}
+
+// TestNodeBatchAdmissionTxnProgress verifies that a transaction can commit,
+// and a conflicting read can push it and resolve its intent, while the read
+// holds the only admission slot.
+func TestNodeBatchAdmissionTxnProgress(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	s, _, kvDB := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(ctx)
+	ts := s.(*TestServer)
+	admission.Enabled.Override(&ts.ClusterSettings().SV, true)
+	ts.node.admissionController.PinSlotsForTesting(1)
+	metrics := ts.node.admissionController.Metrics()
+
+	key := roachpb.Key(keys.SystemSQLCodec.TablePrefix(keys.MinUserDescID))
+	txn := kvDB.NewTxn(ctx, "writer")
+	require.NoError(t, txn.Put(ctx, key, "v"))
+
+	// The read waits on the writer's intent while it holds the only slot.
+	readCh := make(chan error, 1)
+	go func() {
+		kv, err := kvDB.Get(ctx, key)
+		if err == nil && string(kv.ValueBytes()) != "v" {
+			err = errors.Errorf("expected v, found %q", kv.ValueBytes())
+		}
+		readCh <- err
+	}()
+	testutils.SucceedsSoon(t, func() error {
+		if n := metrics.UsedSlots.Value(); n != 1 {
+			return errors.Errorf("expected 1 used slot, found %d", n)
+		}
+		return nil
+	})
+
+	require.NoError(t, txn.Commit(ctx))
+	select {
+	case err := <-readCh:
+		require.NoError(t, err)
+	case <-time.After(testutils.DefaultSucceedsSoonDuration):
+		t.Fatal("read did not complete")
+	}
+}
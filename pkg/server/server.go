@@ -1401,6 +1401,7 @@ func (s *Server) Start(ctx context.Context) error {
 
 	// We can now add the node registry.
 	s.recorder.AddNode(s.registry, s.node.Descriptor, s.node.startedAt, s.cfg.AdvertiseAddr, s.cfg.HTTPAdvertiseAddr, s.cfg.SQLAdvertiseAddr)
+	s.recorder.AddTenantRegistry(s.node.tenantLimiters.TenantRegistry())
 
 	// Begin recording runtime statistics.
 	if err := s.startSampleEnvironment(ctx, base.DefaultMetricsSampleInterval); err != nil {
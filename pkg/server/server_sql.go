@@ -193,10 +193,10 @@ func newSQLServer(ctx context.Context, cfg sqlServerArgs) (*sqlServer, error) {
 			cfg.nodeIDContainer,
 			cfg.Settings,
 			cfg.HistogramWindowInterval(),
-			func(opName, user string) (interface{}, func()) {
+			func(opName string, txn *kv.Txn, user string) (interface{}, func()) {
 				// This is a hack to get around a Go package dependency cycle. See comment
 				// in sql/jobs/registry.go on planHookMaker.
-				return sql.NewInternalPlanner(opName, nil, user, &sql.MemoryMetrics{}, execCfg)
+				return sql.NewInternalPlanner(opName, txn, user, &sql.MemoryMetrics{}, execCfg)
 			},
 			cfg.jobAdoptionStopFile,
 		)
@@ -393,6 +393,7 @@ func newSQLServer(ctx context.Context, cfg sqlServerArgs) (*sqlServer, error) {
 		LeaseHolderCache:        cfg.distSender.LeaseHolderCache(),
 		RoleMemberCache:         &sql.MembershipCache{},
 		TestingKnobs:            sqlExecutorTestingKnobs,
+		ExternalIODirConfig:     cfg.ExternalIODirConfig,
 
 		DistSQLPlanner: sql.NewDistSQLPlanner(
 			ctx,
@@ -644,6 +645,24 @@ func (s *sqlServer) start(
 
 	log.Infof(ctx, "done ensuring all necessary migrations have run")
 
+	// Start the daemon responsible for executing scheduled jobs (e.g. scheduled
+	// backups); this depends on the migrations creating system.scheduled_jobs.
+	jobs.StartJobSchedulerDaemon(
+		ctx,
+		stopper,
+		&jobs.JobExecutionConfig{
+			Settings:         s.execCfg.Settings,
+			InternalExecutor: s.internalExecutor,
+			DB:               s.execCfg.DB,
+			PlanHookMaker: func(opName string, txn *kv.Txn, user string) (interface{}, func()) {
+				// This is a hack to get around a Go package dependency cycle. See comment
+				// in sql/jobs/registry.go on planHookMaker.
+				return sql.NewInternalPlanner(opName, txn, user, &sql.MemoryMetrics{}, s.execCfg)
+			},
+		},
+		nil, /* env */
+	)
+
 	// Start serving SQL clients.
 	if err := s.startServeSQL(ctx, stopper, connManager, pgL, socketFile); err != nil {
 		return err
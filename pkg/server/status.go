@@ -50,6 +50,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirecancel"
 	"github.com/cockroachdb/cockroach/pkg/storage"
 	"github.com/cockroachdb/cockroach/pkg/util/contextutil"
 	"github.com/cockroachdb/cockroach/pkg/util/httputil"
@@ -1835,7 +1836,13 @@ func (s *statusServer) CancelQuery(
 	}
 
 	output := &serverpb.CancelQueryResponse{}
-	canceled, err := s.sessionRegistry.CancelQuery(req.QueryID, req.Username)
+	var canceled bool
+	if req.BackendKeyData != 0 {
+		canceled, err = s.sessionRegistry.CancelQueryByKey(
+			pgwirecancel.BackendKeyData(req.BackendKeyData), req.Username)
+	} else {
+		canceled, err = s.sessionRegistry.CancelQuery(req.QueryID, req.Username)
+	}
 
 	if err != nil {
 		output.Error = err.Error()
@@ -120,6 +120,11 @@ type MetricsRecorder struct {
 		// independent.
 		storeRegistries map[roachpb.StoreID]*metric.Registry
 		stores          map[roachpb.StoreID]storeMetrics
+
+		// tenantRegistry contains the metrics that carry a tenant ID label. Since
+		// there may be many tenants, these are only exported to prometheus and
+		// are not recorded as time series.
+		tenantRegistry *metric.Registry
 	}
 	// PrometheusExporter is not thread-safe even for operations that are
 	// logically read-only, but we don't want to block using it just because
@@ -205,6 +210,14 @@ func (mr *MetricsRecorder) AddStore(store storeMetrics) {
 	mr.mu.stores[storeID] = store
 }
 
+// AddTenantRegistry adds a Registry of per-tenant metrics to this recorder.
+// Its metrics are only exported to prometheus.
+func (mr *MetricsRecorder) AddTenantRegistry(reg *metric.Registry) {
+	mr.mu.Lock()
+	defer mr.mu.Unlock()
+	mr.mu.tenantRegistry = reg
+}
+
 // MarshalJSON returns an appropriate JSON representation of the current values
 // of the metrics being tracked by this recorder.
 func (mr *MetricsRecorder) MarshalJSON() ([]byte, error) {
@@ -252,6 +265,9 @@ func (mr *MetricsRecorder) scrapeIntoPrometheus(pm *metric.PrometheusExporter) {
 	for _, reg := range mr.mu.storeRegistries {
 		pm.ScrapeRegistry(reg)
 	}
+	if mr.mu.tenantRegistry != nil {
+		pm.ScrapeRegistry(mr.mu.tenantRegistry)
+	}
 }
 
 // PrintAsText writes the current metrics values as plain-text to the writer.
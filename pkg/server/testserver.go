@@ -461,6 +461,9 @@ func makeSQLServerArgs(
 		st,
 		rpcTestingKnobs,
 	)
+	// The tenant authenticates itself to the KV nodes using its tenant client
+	// certificate, which limits its access to its own keyspace.
+	rpcContext.TenantID = sqlCfg.TenantID
 
 	// TODO(tbg): expose this registry via prometheus. See:
 	// https://github.com/cockroachdb/cockroach/issues/47905
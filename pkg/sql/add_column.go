@@ -11,6 +11,7 @@
 package sql
 
 import (
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/schemaexpr"
@@ -64,6 +65,12 @@ func (p *planner) addColumnImpl(
 	d = newDef
 	incTelemetryForNewColumn(d)
 
+	if d.IsComputed() && d.Computed.Virtual &&
+		!params.ExecCfg().Settings.Version.IsActive(params.ctx, clusterversion.VersionVirtualComputedColumns) {
+		return pgerror.New(pgcode.FeatureNotSupported,
+			"virtual computed columns are not supported until version upgrade is finalized")
+	}
+
 	col, idx, expr, err := sqlbase.MakeColumnDefDescs(params.ctx, d, &params.p.semaCtx, params.EvalContext())
 	if err != nil {
 		return err
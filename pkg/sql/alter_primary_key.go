@@ -88,6 +88,10 @@ func (p *planner) AlterPrimaryKey(
 		if col.Nullable {
 			return pgerror.Newf(pgcode.InvalidSchemaDefinition, "cannot use nullable column %q in primary key", col.Name)
 		}
+		if col.Virtual {
+			return pgerror.Newf(pgcode.InvalidTableDefinition,
+				"virtual column %q cannot be part of the primary key", col.Name)
+		}
 	}
 
 	// Disable primary key changes on tables that are interleaved parents.
@@ -172,6 +176,11 @@ func (p *planner) AlterPrimaryKey(
 	if err := newPrimaryIndexDesc.FillColumns(alterPKNode.Columns); err != nil {
 		return err
 	}
+	if err := checkNullsOrderingSupported(
+		p.ExecCfg().Settings.Version.ActiveVersionOrEmpty(ctx), newPrimaryIndexDesc,
+	); err != nil {
+		return err
+	}
 	if err := tableDesc.AddIndexMutation(newPrimaryIndexDesc, sqlbase.DescriptorMutation_ADD); err != nil {
 		return err
 	}
@@ -185,6 +194,10 @@ func (p *planner) AlterPrimaryKey(
 	// to consider the indexed columns to be newPrimaryIndexDesc.ColumnIDs.
 	newPrimaryIndexDesc.StoreColumnNames, newPrimaryIndexDesc.StoreColumnIDs = nil, nil
 	for _, col := range tableDesc.Columns {
+		if col.Virtual {
+			// Virtual columns are not stored.
+			continue
+		}
 		containsCol := false
 		for _, colID := range newPrimaryIndexDesc.ColumnIDs {
 			if colID == col.ID {
@@ -123,7 +123,8 @@ func (n *alterRoleNode) startExec(params runParams) error {
 	}
 
 	if n.roleOptions.Contains(roleoption.PASSWORD) {
-		hashedPassword, err := n.roleOptions.GetHashedPassword()
+		hashedPassword, err := n.roleOptions.GetHashedPassword(
+			GetConfiguredPasswordHashMethod(params.ctx, params.EvalContext().Settings))
 		if err != nil {
 			return err
 		}
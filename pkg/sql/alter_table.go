@@ -183,6 +183,11 @@ func (n *alterTableNode) startExec(params runParams) error {
 				if err := idx.FillColumns(d.Columns); err != nil {
 					return err
 				}
+				if err := checkNullsOrderingSupported(
+					params.ExecCfg().Settings.Version.ActiveVersionOrEmpty(params.ctx), &idx,
+				); err != nil {
+					return err
+				}
 				if d.PartitionBy != nil {
 					partitioning, err := CreatePartitioning(
 						params.ctx, params.p.ExecCfg().Settings,
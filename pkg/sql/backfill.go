@@ -38,6 +38,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/ctxgroup"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/errors"
 )
@@ -160,6 +161,7 @@ func (sc *SchemaChanger) runBackfill(ctx context.Context) error {
 	var constraintsToDrop []sqlbase.ConstraintToUpdate
 	var constraintsToAddBeforeValidation []sqlbase.ConstraintToUpdate
 	var constraintsToValidate []sqlbase.ConstraintToUpdate
+	var viewToRefresh *sqlbase.MaterializedViewRefresh
 
 	tableDesc, err := sc.updateJobRunningStatus(ctx, RunningStatusBackfill)
 	if err != nil {
@@ -208,6 +210,8 @@ func (sc *SchemaChanger) runBackfill(ctx context.Context) error {
 				}
 			case *sqlbase.DescriptorMutation_PrimaryKeySwap, *sqlbase.DescriptorMutation_ComputedColumnSwap:
 				// The backfiller doesn't need to do anything here.
+			case *sqlbase.DescriptorMutation_MaterializedViewRefresh:
+				viewToRefresh = t.MaterializedViewRefresh
 			default:
 				return errors.AssertionFailedf(
 					"unsupported mutation: %+v", m)
@@ -223,7 +227,8 @@ func (sc *SchemaChanger) runBackfill(ctx context.Context) error {
 				}
 			case *sqlbase.DescriptorMutation_Constraint:
 				constraintsToDrop = append(constraintsToDrop, *t.Constraint)
-			case *sqlbase.DescriptorMutation_PrimaryKeySwap, *sqlbase.DescriptorMutation_ComputedColumnSwap:
+			case *sqlbase.DescriptorMutation_PrimaryKeySwap, *sqlbase.DescriptorMutation_ComputedColumnSwap,
+				*sqlbase.DescriptorMutation_MaterializedViewRefresh:
 				// The backfiller doesn't need to do anything here.
 			default:
 				return errors.AssertionFailedf(
@@ -266,6 +271,14 @@ func (sc *SchemaChanger) runBackfill(ctx context.Context) error {
 		}
 	}
 
+	// Refresh the materialized view by writing the result of its query into
+	// the new set of indexes.
+	if viewToRefresh != nil {
+		if err := sc.refreshMaterializedView(ctx, tableDesc, viewToRefresh); err != nil {
+			return err
+		}
+	}
+
 	// Add check and foreign key constraints, publish the new version of the table descriptor,
 	// and wait until the entire cluster is on the new version. This is basically
 	// a state transition for the schema change, which must happen after the
@@ -1258,6 +1271,21 @@ func (sc *SchemaChanger) validateForwardIndexes(
 	return grp.Wait()
 }
 
+// refreshMaterializedView writes the result of the query of a materialized
+// view into the new indexes of the given refresh. The new indexes are swapped
+// in once the schema change completes.
+func (sc *SchemaChanger) refreshMaterializedView(
+	ctx context.Context, table *sqlbase.TableDescriptor, refresh *sqlbase.MaterializedViewRefresh,
+) error {
+	// The data of the materialized view is written under the new indexes, so
+	// create a copy of the view descriptor that uses them for the backfill.
+	tableToRefresh := protoutil.Clone(table).(*sqlbase.TableDescriptor)
+	tableToRefresh.PrimaryIndex = refresh.NewPrimaryIndex
+	tableToRefresh.Indexes = refresh.NewIndexes
+	tableToRefresh.CreateAsOfTime = refresh.AsOf
+	return sc.backfillQueryIntoTable(ctx, tableToRefresh, table.ViewQuery, refresh.AsOf, "refreshView")
+}
+
 // backfillIndexes fills the missing columns in the indexes of the
 // leased tables.
 //
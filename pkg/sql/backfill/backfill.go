@@ -240,6 +240,8 @@ func (cb *ColumnBackfiller) RunColumnBackfillChunk(
 	oldValues := make(tree.Datums, len(ru.FetchCols))
 	updateValues := make(tree.Datums, len(cb.updateExprs))
 	b := txn.NewBatch()
+	// Backfills are bulk work, which is admitted after foreground traffic.
+	b.Header.AdmissionPriority = roachpb.BulkAdmissionPriority
 	rowLength := 0
 	iv := &sqlbase.RowIndexedVarContainer{
 		Cols:    append(tableDesc.Columns, cb.added...),
@@ -327,6 +329,13 @@ type IndexBackfiller struct {
 	types   []*types.T
 	rowVals tree.Datums
 	evalCtx *tree.EvalContext
+
+	// virtualCols contains the indices of the virtual computed columns which
+	// are part of the added indexes. Their values are not stored in the primary
+	// index, so they are computed using computedExprs.
+	virtualCols   []int
+	computedExprs []tree.TypedExpr
+	ivarContainer sqlbase.RowIndexedVarContainer
 }
 
 // ContainsInvertedIndex returns true if backfilling an inverted index.
@@ -377,25 +386,68 @@ func (ib *IndexBackfiller) Init(
 		}
 	}
 
+	// The virtual computed columns of the added indexes are computed from the
+	// stored columns, so all of the stored columns are needed.
+	for i := range cols {
+		if cols[i].Virtual && valNeededForCol.Contains(i) {
+			ib.virtualCols = append(ib.virtualCols, i)
+		}
+	}
+	if len(ib.virtualCols) > 0 {
+		for i := range cols {
+			if cols[i].Virtual {
+				valNeededForCol.Remove(i)
+			} else {
+				valNeededForCol.Add(i)
+			}
+		}
+	}
+
 	ib.types = make([]*types.T, len(cols))
 	for i := range cols {
 		ib.types[i] = cols[i].Type
 	}
 
+	// Set up a closure to hydrate the types and preprocess the computed
+	// expressions needed for the backfill.
+	hydrateTypes := func(ctx context.Context, evalCtx *tree.EvalContext) error {
+		if err := execinfrapb.HydrateTypeSlice(evalCtx, ib.types); err != nil {
+			return err
+		}
+		if len(ib.virtualCols) == 0 {
+			return nil
+		}
+		semaCtx := tree.MakeSemaContext()
+		semaCtx.TypeResolver = evalCtx.TypeResolver
+		var txCtx transform.ExprTransformContext
+		var err error
+		ib.computedExprs, err = schemaexpr.MakeComputedExprs(
+			ctx,
+			cols,
+			desc,
+			tree.NewUnqualifiedTableName(tree.Name(desc.Name)),
+			&txCtx,
+			evalCtx,
+			&semaCtx,
+			false, /* addingCols */
+		)
+		return err
+	}
+
 	// Hydrate types used by the backfiller.
 	// TODO (rohany): As part of #49261, this needs to use cached enum data.
 	if evalCtx.Txn != nil {
 		// If the evalCtx has a transaction (if the schema change is running on a
 		// new table within a transaction), then use that.
-		if err := execinfrapb.HydrateTypeSlice(evalCtx, ib.types); err != nil {
+		if err := hydrateTypes(evalCtx.Context, evalCtx); err != nil {
 			return err
 		}
 	} else {
 		// Otherwise, make a new transaction. This case will happen when we are
 		// performing a distributed schema change outside of a transaction.
-		if err := ib.evalCtx.DB.Txn(evalCtx.Context, func(_ context.Context, txn *kv.Txn) error {
+		if err := ib.evalCtx.DB.Txn(evalCtx.Context, func(ctx context.Context, txn *kv.Txn) error {
 			evalCtx.Txn = txn
-			return execinfrapb.HydrateTypeSlice(evalCtx, ib.types)
+			return hydrateTypes(ctx, evalCtx)
 		}); err != nil {
 			return err
 		}
@@ -405,6 +457,10 @@ func (ib *IndexBackfiller) Init(
 	for i := range cols {
 		ib.colIdxMap[cols[i].ID] = i
 	}
+	ib.ivarContainer = sqlbase.RowIndexedVarContainer{
+		Cols:    desc.Columns,
+		Mapping: ib.colIdxMap,
+	}
 
 	tableArgs := row.FetcherTableArgs{
 		Desc:            desc,
@@ -469,6 +525,17 @@ func (ib *IndexBackfiller) BuildIndexEntriesChunk(
 		if err := sqlbase.EncDatumRowToDatums(ib.types, ib.rowVals, encRow, &ib.alloc); err != nil {
 			return nil, nil, err
 		}
+		if len(ib.virtualCols) > 0 {
+			ib.ivarContainer.CurSourceRow = ib.rowVals
+			ib.evalCtx.IVarContainer = &ib.ivarContainer
+			for _, i := range ib.virtualCols {
+				val, err := ib.computedExprs[i].Eval(ib.evalCtx)
+				if err != nil {
+					return nil, nil, err
+				}
+				ib.rowVals[i] = val
+			}
+		}
 
 		// We're resetting the length of this slice for variable length indexes such as inverted
 		// indexes which can append entries to the end of the slice. If we don't do this, then everything
@@ -509,6 +576,7 @@ func (ib *IndexBackfiller) RunIndexBackfillChunk(
 		return nil, err
 	}
 	batch := txn.NewBatch()
+	batch.Header.AdmissionPriority = roachpb.BulkAdmissionPriority
 
 	for _, entry := range entries {
 		if traceKV {
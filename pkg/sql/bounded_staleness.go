@@ -0,0 +1,153 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+)
+
+// boundedStalenessRead holds the state of a bounded staleness read, i.e. of a
+// statement whose AS OF SYSTEM TIME clause uses with_min_timestamp or
+// with_max_staleness. Such a statement is planned at its maximum timestamp
+// bound, which is the time at which it started. Once the plan is known, the
+// statement's read timestamp is negotiated to be the newest timestamp within
+// the bound that the nearest replicas of the data it reads can serve, so that
+// the read never has to wait on a remote leaseholder.
+type boundedStalenessRead struct {
+	// minTimestamp is the oldest timestamp that the statement may read at.
+	minTimestamp hlc.Timestamp
+	// readTimestamp is the negotiated timestamp that the statement reads at.
+	// It is set after planning.
+	readTimestamp hlc.Timestamp
+	// allowLeaseholder is set if the statement may be served by the
+	// leaseholder when the nearest replica can't serve it within the staleness
+	// bound. If it isn't, the statement fails in that case.
+	allowLeaseholder bool
+	// nearest is set if readTimestamp can be served by the nearest replica. If
+	// it isn't, the statement reads at the oldest timestamp it may read at from
+	// the leaseholder.
+	nearest bool
+}
+
+func newBoundedStalenessRead(
+	minTimestamp hlc.Timestamp, allowLeaseholder bool,
+) *boundedStalenessRead {
+	return &boundedStalenessRead{minTimestamp: minTimestamp, allowLeaseholder: allowLeaseholder}
+}
+
+var errBoundedStalenessPlan = unimplemented.Newf("bounded staleness",
+	"bounded staleness reads are only supported for point lookups that touch a single range")
+
+// negotiateBoundedStalenessTimestamp picks the read timestamp of the current
+// bounded staleness statement and fixes the transaction to it. It must be
+// called after planning and before execution.
+//
+// Only plans that consist of a single point lookup are supported, which means
+// that the read touches a single range. The resolved timestamp of the span of
+// the lookup is queried from the nearest replica of that range, and the read is
+// performed at that timestamp if it falls within the staleness bound.
+// Otherwise, an error is returned, unless the statement allowed the read to be
+// served by the leaseholder, in which case it is performed at the minimum
+// timestamp bound.
+//
+// Since the statement was planned with the descriptors that were current at
+// its maximum timestamp bound, it is never read at a timestamp that precedes
+// the modification time of the table it scans.
+func (p *planner) negotiateBoundedStalenessTimestamp(ctx context.Context) error {
+	bs := p.boundedStaleness
+	scan, err := boundedStalenessScan(&p.curPlan.planComponents)
+	if err != nil {
+		return err
+	}
+	span := roachpb.Span{Key: scan.spans[0].Key, EndKey: scan.spans[len(scan.spans)-1].EndKey}
+	if len(span.EndKey) == 0 {
+		span.EndKey = span.Key.Next()
+	}
+	resolvedTS, err := p.ExecCfg().DB.QueryResolvedTimestamp(ctx, span.Key, span.EndKey)
+	if err != nil {
+		return err
+	}
+
+	// The read can't be performed in the future, nor at or after the
+	// expiration of the leases on the descriptors that the statement was
+	// planned with.
+	readTS := resolvedTS
+	readTS.Backward(p.execCfg.Clock.Now())
+	if deadline, ok := p.txn.Deadline(); ok {
+		readTS.Backward(deadline.Prev())
+	}
+	minTS := bs.minTimestamp
+	minTS.Forward(scan.desc.ModificationTime)
+	bs.nearest = minTS.LessEq(readTS)
+	if !bs.nearest {
+		if !bs.allowLeaseholder {
+			err := pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+				"bounded staleness read with minimum timestamp %s cannot be served by the "+
+					"nearest replica, which can only serve reads up to %s",
+				minTS.AsOfSystemTime(), readTS.AsOfSystemTime())
+			return errors.WithHint(err,
+				"use a larger staleness bound, or pass allow_leaseholder = true to the "+
+					"bounded staleness function to fall back to the leaseholder")
+		}
+		readTS = minTS
+	}
+	bs.readTimestamp = readTS
+	log.VEventf(ctx, 2, "bounded staleness read negotiated timestamp %s (resolved %s, nearest %t)",
+		readTS, resolvedTS, bs.nearest)
+
+	p.txn.SetFixedTimestamp(ctx, readTS)
+	if bs.nearest {
+		p.txn.SetRoutingPolicy(roachpb.NEAREST)
+	}
+	return nil
+}
+
+// boundedStalenessScan returns the scan performed by a plan, provided that the
+// plan can be used for a bounded staleness read. It returns an error otherwise.
+func boundedStalenessScan(plan *planComponents) (*scanNode, error) {
+	if len(plan.subqueryPlans) > 0 || len(plan.cascades) > 0 || len(plan.checkPlans) > 0 {
+		return nil, errBoundedStalenessPlan
+	}
+	n := plan.main.planNode
+	for {
+		switch t := n.(type) {
+		case *explainPlanNode:
+			return boundedStalenessScan(&t.plan)
+		case *explainDistSQLNode:
+			return boundedStalenessScan(&t.plan)
+		case *explainVecNode:
+			n = t.plan.planNode
+		case *renderNode:
+			n = t.source.plan
+		case *filterNode:
+			n = t.source.plan
+		case *limitNode:
+			n = t.plan
+		case *scanNode:
+			if t.maxResults != 1 || len(t.spans) == 0 ||
+				t.lockingStrength != sqlbase.ScanLockingStrength_FOR_NONE {
+				return nil, errBoundedStalenessPlan
+			}
+			return t, nil
+		default:
+			return nil, errBoundedStalenessPlan
+		}
+	}
+}
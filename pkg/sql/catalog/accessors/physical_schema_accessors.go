@@ -120,9 +120,9 @@ func (a *CachedPhysicalAccessor) GetObjectDesc(
 	flags tree.ObjectLookupFlags,
 ) (catalog.Descriptor, error) {
 	switch flags.DesiredObjectKind {
-	case tree.TypeObject:
-		// TypeObjects are not caches so fall through to the underlying physical
-		// Accessor.
+	case tree.TypeObject, tree.FunctionObject:
+		// TypeObjects and FunctionObjects are not cached so fall through to the
+		// underlying physical Accessor.
 		return a.Accessor.GetObjectDesc(ctx, txn, settings, codec, db, schema, object, flags)
 	case tree.TableObject:
 		a.tn = tree.MakeTableNameWithSchema(tree.Name(db), tree.Name(schema), tree.Name(object))
@@ -104,7 +104,7 @@ func unwrapDescriptor(
 ) (catalog.Descriptor, error) {
 	// TODO(ajwerner): Fill in the ModificationTime field for the descriptor.
 	desc.MaybeSetModificationTimeFromMVCCTimestamp(ctx, ts)
-	table, database, typ, schema := desc.Table(hlc.Timestamp{}), desc.GetDatabase(), desc.GetType(), desc.GetSchema()
+	table, database, typ, schema, fn := desc.Table(hlc.Timestamp{}), desc.GetDatabase(), desc.GetType(), desc.GetSchema(), desc.GetFunction()
 	switch {
 	case table != nil:
 		if err := table.MaybeFillInDescriptor(ctx, txn, codec); err != nil {
@@ -124,6 +124,8 @@ func unwrapDescriptor(
 		return sqlbase.NewImmutableTypeDescriptor(*typ), nil
 	case schema != nil:
 		return sqlbase.NewImmutableSchemaDescriptor(*schema), nil
+	case fn != nil:
+		return sqlbase.NewImmutableFunctionDescriptor(*fn), nil
 	default:
 		return nil, nil
 	}
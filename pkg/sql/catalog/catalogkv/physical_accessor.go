@@ -249,6 +249,11 @@ func (a UncachedPhysicalAccessor) GetObjectDesc(
 			return sqlbase.NewMutableExistingTypeDescriptor(*desc.TypeDesc()), nil
 		}
 		return desc, nil
+	case *sqlbase.ImmutableFunctionDescriptor:
+		if flags.RequireMutable {
+			return sqlbase.NewMutableExistingFunctionDescriptor(*desc.FunctionDesc()), nil
+		}
+		return desc, nil
 	}
 	return nil, nil
 }
@@ -155,6 +155,14 @@ func ResolveExistingObject(
 			return obj.(*sqlbase.MutableTypeDescriptor), prefix, nil
 		}
 		return obj.(*sqlbase.ImmutableTypeDescriptor), prefix, nil
+	case tree.FunctionObject:
+		if obj.FunctionDesc() == nil {
+			return nil, prefix, sqlbase.NewUndefinedFunctionError(&resolvedTn)
+		}
+		if lookupFlags.RequireMutable {
+			return obj.(*sqlbase.MutableFunctionDescriptor), prefix, nil
+		}
+		return obj.(*sqlbase.ImmutableFunctionDescriptor), prefix, nil
 	case tree.TableObject:
 		if obj.TableDesc() == nil {
 			return nil, prefix, sqlbase.NewUndefinedRelationError(&resolvedTn)
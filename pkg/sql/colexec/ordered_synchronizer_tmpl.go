@@ -247,6 +247,12 @@ func (o *OrderedSynchronizer) compareRow(batchIdx1 int, batchIdx2 int) int {
 		info := o.ordering[i]
 		res := o.comparators[i].compare(batchIdx1, batchIdx2, valIdx1, valIdx2)
 		if res != 0 {
+			if info.NullsReversed &&
+				o.comparators[i].nullAt(batchIdx1, valIdx1) != o.comparators[i].nullAt(batchIdx2, valIdx2) {
+				// Exactly one of the values is null, so it must be ordered on the
+				// other side of the non-null value.
+				res = -res
+			}
 			switch d := info.Direction; d {
 			case encoding.Ascending:
 				return res
@@ -321,7 +321,10 @@ func (p *sortOp) sort(ctx context.Context) {
 
 	for i := range p.orderingCols {
 		inputVec := p.input.getValues(int(p.orderingCols[i].ColIdx))
-		p.sorters[i] = newSingleSorter(p.inputTypes[p.orderingCols[i].ColIdx], p.orderingCols[i].Direction, inputVec.MaybeHasNulls())
+		p.sorters[i] = newSingleSorter(
+			p.inputTypes[p.orderingCols[i].ColIdx], p.orderingCols[i].Direction,
+			inputVec.MaybeHasNulls(), p.orderingCols[i].NullsReversed,
+		)
 		p.sorters[i].init(inputVec, p.order)
 	}
 
@@ -84,7 +84,7 @@ func isSorterSupported(t *types.T, dir execinfrapb.Ordering_Column_Direction) bo
 }
 
 func newSingleSorter(
-	t *types.T, dir execinfrapb.Ordering_Column_Direction, hasNulls bool,
+	t *types.T, dir execinfrapb.Ordering_Column_Direction, hasNulls bool, nullsReversed bool,
 ) colSorter {
 	switch hasNulls {
 	// {{range .}}
@@ -100,7 +100,7 @@ func newSingleSorter(
 				switch t.Width() {
 				// {{range .WidthOverloads}}
 				case _TYPE_WIDTH:
-					return &sort_TYPE_DIR_HANDLES_NULLSOp{}
+					return &sort_TYPE_DIR_HANDLES_NULLSOp{nullsReversed: nullsReversed}
 					// {{end}}
 				}
 				// {{end}}
@@ -126,6 +126,9 @@ type sort_TYPE_DIR_HANDLES_NULLSOp struct {
 	nulls         *coldata.Nulls
 	order         []int
 	cancelChecker CancelChecker
+	// nullsReversed indicates that nulls sort last if ascending and first if
+	// descending.
+	nullsReversed bool
 }
 
 func (s *sort_TYPE_DIR_HANDLES_NULLSOp) init(col coldata.Vec, order []int) {
@@ -162,22 +165,24 @@ func (s *sort_TYPE_DIR_HANDLES_NULLSOp) Less(i, j int) bool {
 	n1 := s.nulls.MaybeHasNulls() && s.nulls.NullAt(s.order[i])
 	n2 := s.nulls.MaybeHasNulls() && s.nulls.NullAt(s.order[j])
 	// {{if eq $dir "Asc"}}
-	// If ascending, nulls always sort first, so we encode that logic here.
+	// If ascending, nulls sort first unless the nulls ordering is reversed, so
+	// we encode that logic here.
 	if n1 && n2 {
 		return false
 	} else if n1 {
-		return true
+		return !s.nullsReversed
 	} else if n2 {
-		return false
+		return s.nullsReversed
 	}
 	// {{else if eq $dir "Desc"}}
-	// If descending, nulls always sort last, so we encode that logic here.
+	// If descending, nulls sort last unless the nulls ordering is reversed, so
+	// we encode that logic here.
 	if n1 && n2 {
 		return false
 	} else if n1 {
-		return false
+		return s.nullsReversed
 	} else if n2 {
-		return true
+		return !s.nullsReversed
 	}
 	// {{end}}
 	// {{end}}
@@ -243,6 +243,12 @@ func (t *topKSorter) compareRow(vecIdx1, vecIdx2 int, rowIdx1, rowIdx2 int) int
 		info := t.orderingCols[i]
 		res := t.comparators[info.ColIdx].compare(vecIdx1, vecIdx2, rowIdx1, rowIdx2)
 		if res != 0 {
+			if info.NullsReversed &&
+				t.comparators[info.ColIdx].nullAt(vecIdx1, rowIdx1) != t.comparators[info.ColIdx].nullAt(vecIdx2, rowIdx2) {
+				// Exactly one of the values is null, so it must be ordered on the
+				// other side of the non-null value.
+				res = -res
+			}
 			switch d := info.Direction; d {
 			case execinfrapb.Ordering_Column_ASC:
 				return res
@@ -420,6 +420,14 @@ func getDatumToPhysicalFn(ct *types.T) func(tree.Datum) (interface{}, error) {
 			}
 			return d, nil
 		}
+	case types.RangeFamily:
+		return func(datum tree.Datum) (interface{}, error) {
+			d, ok := datum.(*tree.DRange)
+			if !ok {
+				return nil, errors.Errorf("expected *tree.DRange, found %s", reflect.TypeOf(datum))
+			}
+			return d, nil
+		}
 	}
 	colexecerror.InternalError(fmt.Sprintf("unexpectedly unhandled type %s", ct.DebugString()))
 	// This code is unreachable, but the compiler cannot infer that.
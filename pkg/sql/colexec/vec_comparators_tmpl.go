@@ -59,6 +59,10 @@ type vecComparator interface {
 	// 0, or 1.
 	compare(vecIdx1, vecIdx2 int, valIdx1, valIdx2 int) int
 
+	// nullAt returns whether the value at index valIdx of the vector at vecIdx
+	// is null.
+	nullAt(vecIdx int, valIdx int) bool
+
 	// set sets the value of the vector at dstVecIdx at index dstValIdx to the value
 	// at the vector at srcVecIdx at index srcValIdx.
 	// NOTE: whenever set is used, the caller is responsible for updating the
@@ -77,8 +81,8 @@ type _TYPEVecComparator struct {
 }
 
 func (c *_TYPEVecComparator) compare(vecIdx1, vecIdx2 int, valIdx1, valIdx2 int) int {
-	n1 := c.nulls[vecIdx1].MaybeHasNulls() && c.nulls[vecIdx1].NullAt(valIdx1)
-	n2 := c.nulls[vecIdx2].MaybeHasNulls() && c.nulls[vecIdx2].NullAt(valIdx2)
+	n1 := c.nullAt(vecIdx1, valIdx1)
+	n2 := c.nullAt(vecIdx2, valIdx2)
 	if n1 && n2 {
 		return 0
 	} else if n1 {
@@ -93,6 +97,10 @@ func (c *_TYPEVecComparator) compare(vecIdx1, vecIdx2 int, valIdx1, valIdx2 int)
 	return cmp
 }
 
+func (c *_TYPEVecComparator) nullAt(vecIdx int, valIdx int) bool {
+	return c.nulls[vecIdx].MaybeHasNulls() && c.nulls[vecIdx].NullAt(valIdx)
+}
+
 func (c *_TYPEVecComparator) setVec(idx int, vec coldata.Vec) {
 	c.vecs[idx] = vec._TYPE()
 	c.nulls[idx] = vec.Nulls()
@@ -31,6 +31,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirecancel"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
@@ -430,6 +431,7 @@ func (s *Server) SetupConn(
 		ctx, sd, args.SessionDefaults, stmtBuf, clientComm, memMetrics, &s.Metrics,
 		s.sqlStats.getStatsForApplication(sd.ApplicationName),
 	)
+	ex.backendKeyData = args.BackendKeyData
 	return ConnectionHandler{ex}, nil
 }
 
@@ -637,6 +639,7 @@ func (s *Server) newConnExecutor(
 	ex.extraTxnState.descCollection = descs.MakeCollection(s.cfg.LeaseManager,
 		s.cfg.Settings, s.dbCache.getDatabaseCache(), s.dbCache)
 	ex.extraTxnState.txnRewindPos = -1
+	ex.extraTxnState.deferredFKChecks.memAcc = ex.sessionMon.MakeBoundAccount()
 	ex.mu.ActiveQueries = make(map[ClusterWideID]*queryMeta)
 	ex.machine = fsm.MakeMachine(TxnStateTransitions, stateNoTxn{}, &ex.state)
 
@@ -986,6 +989,15 @@ type connExecutor struct {
 		// processing the command at position txnRewindPos. When rewinding, we're
 		// going to restore this snapshot.
 		savepointsAtTxnRewindPos savepointStack
+
+		// sqlCursors contains the cursors declared with DECLARE in the current
+		// transaction. Like portals, they are all closed once the transaction
+		// finishes or restarts.
+		sqlCursors sqlCursors
+
+		// deferredFKChecks contains the foreign key checks which are deferred
+		// until the transaction commits, and the modes set by SET CONSTRAINTS.
+		deferredFKChecks deferredFKChecks
 	}
 
 	// sessionData contains the user-configurable connection variables.
@@ -1047,6 +1059,10 @@ type connExecutor struct {
 
 	sessionID ClusterWideID
 
+	// backendKeyData is the key with which a pgwire client can cancel the
+	// session's queries out-of-band. It is zero for internal sessions.
+	backendKeyData pgwirecancel.BackendKeyData
+
 	// activated determines whether activate() was called already.
 	// When this is set, close() must be called to release resources.
 	activated bool
@@ -1150,6 +1166,10 @@ func (ex *connExecutor) resetExtraTxnState(
 ) error {
 	ex.extraTxnState.jobs = nil
 
+	// Close all cursors. This is done before releasing the descriptors, which
+	// the plans of their queries refer to.
+	ex.extraTxnState.sqlCursors.closeAll(ctx)
+
 	ex.extraTxnState.descCollection.ReleaseAll(ctx)
 
 	ex.extraTxnState.descCollection.ResetDatabaseCache(dbCacheHolder.getDatabaseCache())
@@ -1160,6 +1180,8 @@ func (ex *connExecutor) resetExtraTxnState(
 		delete(ex.extraTxnState.prepStmtsNamespace.portals, name)
 	}
 
+	ex.extraTxnState.deferredFKChecks.reset(ctx)
+
 	switch ev {
 	case txnCommit, txnRollback:
 		ex.extraTxnState.savepoints.clear()
@@ -1271,9 +1293,9 @@ func (ex *connExecutor) run(
 	ex.onCancelSession = onCancel
 
 	ex.sessionID = ex.generateID()
-	ex.server.cfg.SessionRegistry.register(ex.sessionID, ex)
+	ex.server.cfg.SessionRegistry.register(ex.sessionID, ex.backendKeyData, ex)
 	ex.planner.extendedEvalCtx.setSessionID(ex.sessionID)
-	defer ex.server.cfg.SessionRegistry.deregister(ex.sessionID)
+	defer ex.server.cfg.SessionRegistry.deregister(ex.sessionID, ex.backendKeyData)
 
 	for {
 		ex.curStmt = nil
@@ -1797,6 +1819,11 @@ func (ex *connExecutor) execCopyIn(
 // stmtHasNoData returns true if describing a result of the input statement
 // type should return NoData.
 func stmtHasNoData(stmt tree.Statement) bool {
+	if _, ok := stmt.(*tree.CopyTo); ok {
+		// Like in Postgres, the rows of COPY TO are not described; they are sent
+		// using the Copy-out subprotocol instead.
+		return true
+	}
 	return stmt == nil || stmt.StatementType() != tree.Rows
 }
 
@@ -2045,6 +2072,13 @@ func (ex *connExecutor) initPlanner(ctx context.Context, p *planner) {
 	p.sessionDataMutator = ex.dataMutator
 	p.noticeSender = nil
 	p.preparedStatements = ex.getPrepStmtsAccessor()
+	p.sqlCursors = &ex.extraTxnState.sqlCursors
+	p.deferredFKChecks = nil
+	if ex.executorType == executorTypeExec {
+		// Internal executors can run statements in the transaction of a session;
+		// they must not defer checks that would never run at commit time.
+		p.deferredFKChecks = &ex.extraTxnState.deferredFKChecks
+	}
 
 	p.queryCacheSession.Init()
 	p.optPlanningCtx.init(p)
@@ -2064,12 +2098,14 @@ func (ex *connExecutor) resetPlanner(
 	p.semaCtx.AsOfTimestamp = nil
 	p.semaCtx.Annotations = nil
 	p.semaCtx.TypeResolver = p
+	p.semaCtx.FunctionResolver = p
 
 	ex.resetEvalCtx(&p.extendedEvalCtx, txn, stmtTS)
 
 	p.autoCommit = false
 	p.isPreparing = false
 	p.avoidCachedDescriptors = false
+	p.boundedStaleness = nil
 	p.discardRows = false
 	p.collectBundle = false
 }
@@ -2219,6 +2255,18 @@ func (ex *connExecutor) cancelQuery(queryID ClusterWideID) bool {
 	return false
 }
 
+// cancelCurrentQueries is part of the registrySession interface.
+func (ex *connExecutor) cancelCurrentQueries() bool {
+	ex.mu.Lock()
+	defer ex.mu.Unlock()
+	canceled := false
+	for _, queryMeta := range ex.mu.ActiveQueries {
+		queryMeta.cancel()
+		canceled = true
+	}
+	return canceled
+}
+
 // cancelSession is part of the registrySession interface.
 func (ex *connExecutor) cancelSession() {
 	if ex.onCancelSession == nil {
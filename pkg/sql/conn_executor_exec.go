@@ -286,6 +286,9 @@ func (ex *connExecutor) execStmtInOpenState(
 		return ev, payload, nil
 	}
 
+	// cursor is set if the statement declares a cursor.
+	var cursor *sqlCursor
+
 	switch s := stmt.AST.(type) {
 	case *tree.BeginTransaction:
 		// BEGIN is always an error when in the Open state. It's legitimate only in
@@ -387,6 +390,32 @@ func (ex *connExecutor) execStmtInOpenState(
 		if s.DiscardRows {
 			p.discardRows = true
 		}
+
+	case *tree.DeclareCursor:
+		// Replace the `DECLARE foo CURSOR FOR ...` statement with its query, and
+		// continue setting it up below with the cursor's planner. The query is
+		// planned, but only run once rows are fetched from the cursor.
+		if os.ImplicitTxn.Get() {
+			err := pgerror.New(pgcode.NoActiveSQLTransaction,
+				"DECLARE CURSOR can only be used in transaction blocks")
+			return makeErrEvent(err)
+		}
+		c, err := ex.declareCursor(ctx, s, stmt.SQL, stmtTS)
+		if err != nil {
+			return makeErrEvent(err)
+		}
+		defer func() {
+			if retErr == nil && retEv == nil && res.Err() == nil {
+				ex.extraTxnState.sqlCursors.add(c)
+			} else {
+				c.close(ctx)
+			}
+		}()
+
+		stmt.AST = s.Select
+		stmt.ExpectedTypes = nil
+		p = c.planner
+		cursor = c
 	}
 
 	p.semaCtx.Annotations = tree.MakeAnnotations(stmt.NumAnnotations)
@@ -395,32 +424,40 @@ func (ex *connExecutor) execStmtInOpenState(
 	// don't return any event unless an error happens.
 
 	if os.ImplicitTxn.Get() {
-		asOfTs, err := p.isAsOf(ctx, stmt.AST)
+		asOf, err := p.isAsOf(ctx, stmt.AST, true /* allowBoundedStaleness */)
 		if err != nil {
 			return makeErrEvent(err)
 		}
-		if asOfTs != nil {
-			p.semaCtx.AsOfTimestamp = asOfTs
-			p.extendedEvalCtx.SetTxnTimestamp(asOfTs.GoTime())
-			ex.state.setHistoricalTimestamp(ctx, *asOfTs)
+		if asOf != nil {
+			ts := asOf.Timestamp
+			if asOf.BoundedStaleness {
+				// Bounded staleness reads are planned at the maximum timestamp
+				// bound, i.e. now, and their read timestamp is negotiated once
+				// the plan is known.
+				p.boundedStaleness = newBoundedStalenessRead(asOf.Timestamp, asOf.AllowLeaseholder)
+				ts = ex.server.cfg.Clock.Now()
+			}
+			p.semaCtx.AsOfTimestamp = &asOf.Timestamp
+			p.extendedEvalCtx.SetTxnTimestamp(ts.GoTime())
+			ex.state.setHistoricalTimestamp(ctx, ts)
 		}
 	} else {
 		// If we're in an explicit txn, we allow AOST but only if it matches with
 		// the transaction's timestamp. This is useful for running AOST statements
 		// using the InternalExecutor inside an external transaction; one might want
 		// to do that to force p.avoidCachedDescriptors to be set below.
-		ts, err := p.isAsOf(ctx, stmt.AST)
+		asOf, err := p.isAsOf(ctx, stmt.AST, false /* allowBoundedStaleness */)
 		if err != nil {
 			return makeErrEvent(err)
 		}
-		if ts != nil {
-			if readTs := ex.state.getReadTimestamp(); *ts != readTs {
+		if asOf != nil {
+			if readTs := ex.state.getReadTimestamp(); asOf.Timestamp != readTs {
 				err = pgerror.Newf(pgcode.Syntax,
 					"inconsistent AS OF SYSTEM TIME timestamp; expected: %s", readTs)
 				err = errors.WithHint(err, "try SET TRANSACTION AS OF SYSTEM TIME")
 				return makeErrEvent(err)
 			}
-			p.semaCtx.AsOfTimestamp = ts
+			p.semaCtx.AsOfTimestamp = &asOf.Timestamp
 		}
 	}
 
@@ -485,6 +522,12 @@ func (ex *connExecutor) execStmtInOpenState(
 	p.stmt = &stmt
 	p.cancelChecker = sqlbase.NewCancelChecker(ctx)
 	p.autoCommit = os.ImplicitTxn.Get() && !ex.server.cfg.TestingKnobs.DisableAutoCommit
+	if cursor != nil {
+		if err := ex.openCursor(ctx, cursor); err != nil {
+			return makeErrEvent(err)
+		}
+		return nil, nil, nil
+	}
 	if err := ex.dispatchToExecutionEngine(ctx, p, res); err != nil {
 		return nil, nil, err
 	}
@@ -648,6 +691,14 @@ func (ex *connExecutor) commitSQLTransaction(
 func (ex *connExecutor) commitSQLTransactionInternal(
 	ctx context.Context, stmt tree.Statement,
 ) error {
+	// Run the foreign key checks which were deferred until the end of the
+	// transaction.
+	if err := ex.extraTxnState.deferredFKChecks.run(
+		ctx, ex.server.cfg.InternalExecutor, ex.state.mu.txn, nil, /* filter */
+	); err != nil {
+		return err
+	}
+
 	if err := validatePrimaryKeys(&ex.extraTxnState.descCollection); err != nil {
 		return err
 	}
@@ -747,6 +798,13 @@ func (ex *connExecutor) dispatchToExecutionEngine(
 		return nil
 	}
 
+	if planner.boundedStaleness != nil {
+		if err := planner.negotiateBoundedStalenessTimestamp(ctx); err != nil {
+			res.SetError(err)
+			return nil
+		}
+	}
+
 	var cols sqlbase.ResultColumns
 	if stmt.AST.StatementType() == tree.Rows {
 		cols = planner.curPlan.main.planColumns()
@@ -896,6 +954,11 @@ func (ex *connExecutor) execWithDistSQLEngine(
 		) {
 			return recv.bytesRead, recv.rowsRead, recv.commErr
 		}
+		if !ex.server.cfg.DistSQLPlanner.PlanAndRunBeforeCascades(
+			ctx, planner, evalCtxFactory, &planner.curPlan.planComponents, recv, distribute,
+		) {
+			return recv.bytesRead, recv.rowsRead, recv.commErr
+		}
 	}
 	recv.discardRows = planner.discardRows
 	// We pass in whether or not we wanted to distribute this plan, which tells
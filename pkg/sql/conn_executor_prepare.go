@@ -218,13 +218,17 @@ func (ex *connExecutor) populatePrepared(
 	}
 	p.extendedEvalCtx.PrepareOnly = true
 
-	protoTS, err := p.isAsOf(ctx, stmt.AST)
+	asOf, err := p.isAsOf(ctx, stmt.AST, true /* allowBoundedStaleness */)
 	if err != nil {
 		return 0, err
 	}
-	if protoTS != nil {
-		p.semaCtx.AsOfTimestamp = protoTS
-		txn.SetFixedTimestamp(ctx, *protoTS)
+	if asOf != nil {
+		p.semaCtx.AsOfTimestamp = &asOf.Timestamp
+		// Bounded staleness reads are prepared at the current time, just like
+		// they are planned at it when executed.
+		if !asOf.BoundedStaleness {
+			txn.SetFixedTimestamp(ctx, asOf.Timestamp)
+		}
 	}
 
 	// PREPARE has a limited subset of statements it can be run with. Postgres
@@ -115,6 +115,7 @@ func (ex *connExecutor) execSavepointInOpenState(
 		commitOnRelease: commitOnRelease,
 		kvToken:         token,
 		numDDL:          ex.extraTxnState.numDDL,
+		cursorSeq:       ex.extraTxnState.sqlCursors.seq,
 	}
 	savepoints.push(sp)
 
@@ -212,6 +213,7 @@ func (ex *connExecutor) execRollbackToSavepointInOpenState(
 	}
 
 	ex.extraTxnState.savepoints.popToIdx(idx)
+	ex.extraTxnState.sqlCursors.closeSince(ctx, entry.cursorSeq)
 
 	if entry.kvToken.Initial() {
 		return eventTxnRestart{}, nil
@@ -286,6 +288,7 @@ func (ex *connExecutor) execRollbackToSavepointInAbortedState(
 	}
 
 	ex.extraTxnState.savepoints.popToIdx(idx)
+	ex.extraTxnState.sqlCursors.closeSince(ctx, entry.cursorSeq)
 
 	// Special case for mixed-cluster versions, where regular savepoints
 	// are not yet enabled but we still support cockroach_restart. In
@@ -348,6 +351,11 @@ type savepoint struct {
 	// more DDL statements were executed since the savepoint's creation.
 	// TODO(knz): support partial DDL cancellation in pending txns.
 	numDDL int
+
+	// The sequence number of the next cursor to be declared in the transaction
+	// (at the time the savepoint was created). Cursors declared after the
+	// savepoint are closed when rolling back to it.
+	cursorSeq int
 }
 
 type savepointStack []savepoint
@@ -0,0 +1,98 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/errors"
+)
+
+type controlSchedulesNode struct {
+	rows    planNode
+	command tree.ScheduleCommand
+	numRows int
+}
+
+// FastPathResults implements the planNodeFastPath interface.
+func (n *controlSchedulesNode) FastPathResults() (int, bool) {
+	return n.numRows, true
+}
+
+// startExec implements planNode interface.
+func (n *controlSchedulesNode) startExec(params runParams) error {
+	ex := params.ExecCfg().InternalExecutor
+	for {
+		ok, err := n.rows.Next(params)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			break
+		}
+
+		scheduleIDDatum := n.rows.Values()[0]
+		if scheduleIDDatum == tree.DNull {
+			continue
+		}
+
+		scheduleID, ok := tree.AsDInt(scheduleIDDatum)
+		if !ok {
+			return errors.AssertionFailedf("%q: expected *DInt, found %T", scheduleIDDatum, scheduleIDDatum)
+		}
+
+		schedule, err := jobs.LoadScheduledJob(
+			params.ctx, nil /* env */, int64(scheduleID), ex, params.p.txn)
+		if err != nil {
+			if errors.Is(err, jobs.ErrScheduleNotFound) {
+				return pgerror.Newf(pgcode.UndefinedObject, "schedule %d does not exist", scheduleID)
+			}
+			return err
+		}
+
+		switch n.command {
+		case tree.PauseSchedule:
+			schedule.Pause("operator paused schedule")
+			err = schedule.Update(params.ctx, ex, params.p.txn)
+		case tree.ResumeSchedule:
+			err = schedule.Unpause("operator resumed schedule")
+			if err == nil {
+				err = schedule.Update(params.ctx, ex, params.p.txn)
+			}
+		case tree.DropSchedule:
+			err = schedule.Delete(params.ctx, ex, params.p.txn)
+		default:
+			err = errors.AssertionFailedf("unhandled command %s", n.command)
+		}
+
+		if err != nil {
+			return err
+		}
+		n.numRows++
+	}
+
+	return nil
+}
+
+// Next implements planNode interface.
+func (*controlSchedulesNode) Next(runParams) (bool, error) { return false, nil }
+
+// Values implements planNode interface.
+func (*controlSchedulesNode) Values() tree.Datums { return nil }
+
+// Close implements planNode interface.
+func (n *controlSchedulesNode) Close(ctx context.Context) {
+	n.rows.Close(ctx)
+}
@@ -513,3 +513,31 @@ var decodeMap = map[byte]byte{
 	'v':  '\v',
 	'\\': '\\',
 }
+
+// encodeMap is the inverse of decodeMap.
+var encodeMap = map[byte]byte{
+	'\b': 'b',
+	'\f': 'f',
+	'\n': 'n',
+	'\r': 'r',
+	'\t': 't',
+	'\v': 'v',
+	'\\': '\\',
+}
+
+// EncodeCopy escapes a single COPY field in the text format and appends it to
+// buf. This is the inverse of decodeCopy. The delimiter is escaped as well.
+//
+// See: https://www.postgresql.org/docs/9.5/static/sql-copy.html#AEN74432
+func EncodeCopy(buf []byte, in []byte, delimiter byte) []byte {
+	for _, ch := range in {
+		if encoded, ok := encodeMap[ch]; ok {
+			buf = append(buf, '\\', encoded)
+		} else if ch == delimiter {
+			buf = append(buf, '\\', ch)
+		} else {
+			buf = append(buf, ch)
+		}
+	}
+	return buf
+}
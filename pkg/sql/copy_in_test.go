@@ -488,3 +488,55 @@ func TestCopyFKCheck(t *testing.T) {
 		t.Fatalf("expected FK error, got: %v", err)
 	}
 }
+
+// TestCopyTriggers verifies that COPY FROM fires the BEFORE and AFTER triggers
+// of the table.
+func TestCopyTriggers(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	params, _ := tests.CreateTestServerParams()
+	s, db, _ := serverutils.StartServer(t, params)
+	defer s.Stopper().Stop(context.Background())
+
+	db.SetMaxOpenConns(1)
+	r := sqlutils.MakeSQLRunner(db)
+	r.Exec(t, `
+		CREATE DATABASE d;
+		SET DATABASE = d;
+		CREATE TABLE t (k INT PRIMARY KEY);
+		CREATE TABLE t_log (k INT, n INT, after BOOL);
+		CREATE TRIGGER t_before BEFORE INSERT ON t FOR EACH ROW
+		  AS 'INSERT INTO t_log SELECT NEW.k, count(*), false FROM t';
+		CREATE TRIGGER t_after AFTER INSERT ON t FOR EACH ROW
+		  AS 'INSERT INTO t_log SELECT NEW.k, count(*), true FROM t';
+	`)
+
+	txn, err := db.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = txn.Rollback() }()
+
+	stmt, err := txn.Prepare(pq.CopyInSchema("d", "t", "k"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, k := range []int{1, 2} {
+		if _, err := stmt.Exec(k); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := stmt.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := txn.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	r.CheckQueryResults(t, `SELECT k, n, after FROM t_log ORDER BY after, k`, [][]string{
+		{"1", "0", "false"},
+		{"2", "0", "false"},
+		{"1", "2", "true"},
+		{"2", "2", "true"},
+	})
+}
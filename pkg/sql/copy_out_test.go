@@ -0,0 +1,152 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"net/url"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/sql/tests"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/jackc/pgx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopyOut(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	params, _ := tests.CreateTestServerParams()
+	s, db, _ := serverutils.StartServer(t, params)
+	defer s.Stopper().Stop(context.Background())
+
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.Exec(t, `
+		CREATE TABLE t (i INT PRIMARY KEY, s STRING, b BYTES, f FLOAT);
+		INSERT INTO t VALUES
+			(1, 'tab	and
+newline', 'ab', 1.5),
+			(2, 'comma, "quote"', NULL, NULL),
+			(3, '', '', -2);
+	`)
+
+	pgURL, cleanup := sqlutils.PGUrl(t, s.ServingSQLAddr(), "TestCopyOut", url.User(security.RootUser))
+	defer cleanup()
+	pgxConfig, err := pgx.ParseConnectionString(pgURL.String())
+	require.NoError(t, err)
+	conn, err := pgx.Connect(pgxConfig)
+	require.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+
+	copyOut := func(t *testing.T, query string) (string, string) {
+		var buf bytes.Buffer
+		tag, err := conn.CopyToWriter(&buf, query)
+		require.NoError(t, err)
+		return buf.String(), string(tag)
+	}
+
+	for _, tc := range []struct {
+		query    string
+		expected string
+	}{
+		{
+			query: `COPY t TO STDOUT`,
+			expected: "1\ttab\\tand\\nnewline\t\\\\x6162\t1.5\n" +
+				"2\tcomma, \"quote\"\t\\N\t\\N\n" +
+				"3\t\t\\\\x\t-2\n",
+		},
+		{
+			query:    `COPY t (f, i) TO STDOUT WITH NULL 'null'`,
+			expected: "1.5\t1\nnull\t2\n-2\t3\n",
+		},
+		{
+			query:    `COPY (SELECT i, i * 2 AS double FROM t ORDER BY i DESC) TO STDOUT WITH DELIMITER '|'`,
+			expected: "3|6\n2|4\n1|2\n",
+		},
+		{
+			// Values are formatted like EXPORT does. Empty strings are quoted to
+			// distinguish them from NULL.
+			query: `COPY t TO STDOUT WITH CSV HEADER`,
+			expected: "i,s,b,f\n" +
+				"1,\"tab\tand\nnewline\",\\x6162,1.5\n" +
+				"2,\"comma, \"\"quote\"\"\",,\n" +
+				"3,\"\",\\x,-2.0\n",
+		},
+		{
+			query:    `COPY (VALUES (1, NULL), (NULL, 'x;y')) TO STDOUT (FORMAT csv, DELIMITER ';', NULL 'NULL')`,
+			expected: "1;NULL\nNULL;\"x;y\"\n",
+		},
+		{
+			// Values that match the NULL string are quoted.
+			query:    `COPY (VALUES ('', NULL), ('NULL', '')) TO STDOUT (FORMAT csv, NULL 'NULL')`,
+			expected: "\"\",NULL\n\"NULL\",\n",
+		},
+	} {
+		t.Run(tc.query, func(t *testing.T) {
+			out, tag := copyOut(t, tc.query)
+			require.Equal(t, tc.expected, out)
+			require.Equal(t, "COPY", tag[:4])
+		})
+	}
+
+	t.Run("binary", func(t *testing.T) {
+		out, tag := copyOut(t, `COPY (SELECT i, s FROM t WHERE i = 2) TO STDOUT WITH BINARY`)
+		require.Equal(t, "COPY 1", tag)
+		var expected bytes.Buffer
+		expected.WriteString("PGCOPY\n\377\r\n\000")
+		_ = binary.Write(&expected, binary.BigEndian, []int32{0, 0})
+		_ = binary.Write(&expected, binary.BigEndian, int16(2))
+		_ = binary.Write(&expected, binary.BigEndian, []int32{8, 0, 2})
+		_ = binary.Write(&expected, binary.BigEndian, int32(len(`comma, "quote"`)))
+		expected.WriteString(`comma, "quote"`)
+		_ = binary.Write(&expected, binary.BigEndian, int16(-1))
+		require.Equal(t, expected.String(), out)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for _, tc := range []struct {
+			query    string
+			expected string
+		}{
+			{`COPY t TO STDOUT WITH HEADER`, "COPY HEADER available only in CSV mode"},
+			{`COPY t TO STDOUT WITH BINARY NULL 'x'`, "cannot specify NULL in BINARY mode"},
+			{`COPY t TO STDOUT WITH DELIMITER 'ab'`, "COPY delimiter must be a single one-byte character"},
+			{`COPY t (x) TO STDOUT`, `column "x" does not exist`},
+			{`COPY (SELECT 1/0) TO STDOUT`, "division by zero"},
+		} {
+			var buf bytes.Buffer
+			_, err := conn.CopyToWriter(&buf, tc.query)
+			if !testutils.IsError(err, tc.expected) {
+				t.Errorf("%s: expected %q, got %v", tc.query, tc.expected, err)
+			}
+		}
+		// The connection is still usable after an error.
+		out, _ := copyOut(t, `COPY (SELECT 1) TO STDOUT`)
+		require.Equal(t, "1\n", out)
+	})
+
+	t.Run("txn", func(t *testing.T) {
+		tx, err := conn.Begin()
+		require.NoError(t, err)
+		_, err = tx.Exec(`INSERT INTO t VALUES (4, 'four', NULL, NULL)`)
+		require.NoError(t, err)
+		out, tag := copyOut(t, `COPY (SELECT s FROM t WHERE i = 4) TO STDOUT`)
+		require.Equal(t, "four\n", out)
+		require.Equal(t, "COPY 1", tag)
+		require.NoError(t, tx.Rollback())
+	})
+}
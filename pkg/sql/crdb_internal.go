@@ -1383,38 +1383,59 @@ CREATE TABLE crdb_internal.create_type_statements (
 `,
 	populate: func(ctx context.Context, p *planner, db *sqlbase.ImmutableDatabaseDescriptor, addRow func(...tree.Datum) error) error {
 		return forEachTypeDesc(ctx, p, db, func(db *sqlbase.ImmutableDatabaseDescriptor, sc string, typeDesc *sqlbase.ImmutableTypeDescriptor) error {
+			name, err := tree.NewUnresolvedObjectName(3, [3]string{typeDesc.GetName(), sc, db.GetName()}, 0)
+			if err != nil {
+				return err
+			}
+			var node *tree.CreateType
 			switch typeDesc.Kind {
 			case sqlbase.TypeDescriptor_ENUM:
 				var enumLabels []string
 				for i := range typeDesc.EnumMembers {
 					enumLabels = append(enumLabels, typeDesc.EnumMembers[i].LogicalRepresentation)
 				}
-				name, err := tree.NewUnresolvedObjectName(3, [3]string{typeDesc.GetName(), sc, db.GetName()}, 0)
-				if err != nil {
-					return err
-				}
-				node := &tree.CreateType{
+				node = &tree.CreateType{
 					Variety:    tree.Enum,
 					TypeName:   name,
 					EnumLabels: enumLabels,
 				}
-				if err := addRow(
-					tree.NewDInt(tree.DInt(db.GetID())),       // database_id
-					tree.NewDString(db.GetName()),             // database_name
-					tree.NewDString(sc),                       // schema_name
-					tree.NewDInt(tree.DInt(typeDesc.GetID())), // descriptor_id
-					tree.NewDString(typeDesc.GetName()),       // descriptor_name
-					tree.NewDString(tree.AsString(node)),      // create_statement
-				); err != nil {
+			case sqlbase.TypeDescriptor_COMPOSITE:
+				// Hydrate the type so that user defined field types can be
+				// formatted with their names.
+				typ, err := typeDesc.MakeTypesT(
+					tree.NewUnqualifiedTypeName(tree.Name(typeDesc.GetName())),
+					p.makeTypeLookupFn(ctx),
+				)
+				if err != nil {
 					return err
 				}
+				fields := make([]tree.CompositeTypeElem, len(typ.TupleContents()))
+				for i := range fields {
+					fields[i] = tree.CompositeTypeElem{
+						Label: tree.Name(typ.TupleLabels()[i]),
+						Type:  typ.TupleContents()[i],
+					}
+				}
+				node = &tree.CreateType{
+					Variety:           tree.Composite,
+					TypeName:          name,
+					CompositeTypeList: fields,
+				}
 			case sqlbase.TypeDescriptor_ALIAS:
-			// Alias types are created implicitly, so we don't have create
-			// statements for them.
+				// Alias types are created implicitly, so we don't have create
+				// statements for them.
+				return nil
 			default:
 				return errors.AssertionFailedf("unknown type descriptor kind %s", typeDesc.Kind.String())
 			}
-			return nil
+			return addRow(
+				tree.NewDInt(tree.DInt(db.GetID())),       // database_id
+				tree.NewDString(db.GetName()),             // database_name
+				tree.NewDString(sc),                       // schema_name
+				tree.NewDInt(tree.DInt(typeDesc.GetID())), // descriptor_id
+				tree.NewDString(typeDesc.GetName()),       // descriptor_name
+				tree.NewDString(tree.AsString(node)),      // create_statement
+			)
 		})
 	},
 }
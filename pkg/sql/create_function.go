@@ -0,0 +1,213 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catalogkv"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+type createFunctionNode struct {
+	n      *tree.CreateFunction
+	dbDesc *sqlbase.ImmutableDatabaseDescriptor
+	// body is the body of the function, with fully qualified data sources.
+	body string
+}
+
+// createFunctionNode implements planNode. We set n here to satisfy the linter.
+var _ planNode = &createFunctionNode{n: nil}
+
+// ReadingOwnWrites implements the planNodeReadingOwnWrites interface.
+// This is because CREATE FUNCTION performs multiple KV operations on
+// descriptors and expects to see its own writes.
+func (n *createFunctionNode) ReadingOwnWrites() {}
+
+func (n *createFunctionNode) startExec(params runParams) error {
+	// Make sure that all nodes in the cluster are able to recognize function
+	// descriptors.
+	if !params.p.ExecCfg().Settings.Version.IsActive(
+		params.ctx, clusterversion.VersionUserDefinedFunctions,
+	) {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"not all nodes are the correct version for CREATE FUNCTION")
+	}
+
+	telemetry.Inc(sqltelemetry.SchemaChangeCreateCounter("function"))
+
+	// Disallow function creation in the system database.
+	if n.dbDesc.GetID() == keys.SystemDatabaseID {
+		return errors.New("cannot create a function in the system database")
+	}
+
+	overload, err := n.makeOverload(params)
+	if err != nil {
+		return err
+	}
+	paramTypes := make([]*types.T, len(overload.Params))
+	for i := range overload.Params {
+		paramTypes[i] = overload.Params[i].Type
+	}
+
+	name := n.n.FuncName.Object()
+	fnName := tree.MakeTableNameWithSchema(
+		tree.Name(n.dbDesc.GetName()), tree.PublicSchemaName, tree.Name(name),
+	)
+	// As of now, we can only create functions in the public schema.
+	exists, id, err := sqlbase.LookupObjectID(
+		params.ctx, params.p.txn, params.ExecCfg().Codec,
+		n.dbDesc.GetID(), keys.PublicSchemaID, name,
+	)
+	if err != nil {
+		return err
+	}
+
+	if exists {
+		// Add the overload to the existing function, or replace an existing
+		// overload with the same parameter types.
+		desc, err := catalogkv.GetDescriptorByID(params.ctx, params.p.txn, params.ExecCfg().Codec, id)
+		if err != nil {
+			return err
+		}
+		fnDesc, ok := desc.(*sqlbase.ImmutableFunctionDescriptor)
+		if !ok {
+			return makeObjectAlreadyExistsError(desc.DescriptorProto(), fnName.String())
+		}
+		mutDesc := sqlbase.NewMutableExistingFunctionDescriptor(*fnDesc.FunctionDesc())
+		if i := mutDesc.FindOverload(paramTypes); i >= 0 {
+			if !n.n.OrReplace {
+				return pgerror.Newf(pgcode.DuplicateFunction,
+					"function %s already exists with same argument types",
+					funcSignature(&fnName, paramTypes))
+			}
+			if !mutDesc.Overloads[i].ReturnType.Equivalent(overload.ReturnType) {
+				return pgerror.Newf(pgcode.InvalidFunctionDefinition,
+					"cannot change return type of existing function %s",
+					funcSignature(&fnName, paramTypes))
+			}
+			mutDesc.Overloads[i] = overload
+		} else {
+			mutDesc.Overloads = append(mutDesc.Overloads, overload)
+		}
+		return params.p.writeFunctionDesc(params.ctx, mutDesc)
+	}
+
+	id, err = catalogkv.GenerateUniqueDescID(params.ctx, params.ExecCfg().DB, params.ExecCfg().Codec)
+	if err != nil {
+		return err
+	}
+	fnDesc := sqlbase.NewMutableCreatedFunctionDescriptor(sqlbase.FunctionDescriptor{
+		Name:           name,
+		ID:             id,
+		Version:        1,
+		ParentID:       n.dbDesc.GetID(),
+		ParentSchemaID: keys.PublicSchemaID,
+		Overloads:      []sqlbase.FunctionDescriptor_Overload{overload},
+	})
+	fnKey := sqlbase.MakePublicTableNameKey(
+		params.ctx, params.ExecCfg().Settings, n.dbDesc.GetID(), name,
+	)
+	return params.p.createDescriptorWithID(
+		params.ctx,
+		fnKey.Key(params.ExecCfg().Codec),
+		id,
+		fnDesc,
+		params.EvalContext().Settings,
+		tree.AsStringWithFQNames(n.n, params.Ann()),
+	)
+}
+
+// makeOverload returns the descriptor of the overload defined by the
+// CREATE FUNCTION statement.
+func (n *createFunctionNode) makeOverload(
+	params runParams,
+) (sqlbase.FunctionDescriptor_Overload, error) {
+	typeResolver := params.p.semaCtx.GetTypeResolver()
+	overload := sqlbase.FunctionDescriptor_Overload{
+		Params: make([]sqlbase.FunctionDescriptor_Param, len(n.n.Params)),
+		Strict: n.n.Options.Strict(),
+		Body:   n.body,
+	}
+	for i := range n.n.Params {
+		typ, err := tree.ResolveType(params.ctx, n.n.Params[i].Type, typeResolver)
+		if err != nil {
+			return overload, err
+		}
+		overload.Params[i] = sqlbase.FunctionDescriptor_Param{
+			Name: string(n.n.Params[i].Name),
+			Type: typ,
+		}
+	}
+	retType, err := tree.ResolveType(params.ctx, n.n.ReturnType, typeResolver)
+	if err != nil {
+		return overload, err
+	}
+	overload.ReturnType = retType
+	// Functions are volatile unless declared otherwise.
+	volatility := n.n.Options.Volatility
+	if volatility == 0 {
+		volatility = tree.VolatilityVolatile
+	}
+	overload.Volatility = sqlbase.MakeFunctionDescriptorVolatility(volatility)
+	return overload, nil
+}
+
+// writeFunctionDesc writes a modified function descriptor. Function
+// descriptors are not leased, so the new version is visible to all
+// transactions that start after this one commits.
+func (p *planner) writeFunctionDesc(
+	ctx context.Context, desc *sqlbase.MutableFunctionDescriptor,
+) error {
+	desc.Version++
+	b := p.txn.NewBatch()
+	if err := catalogkv.WriteDescToBatch(
+		ctx,
+		p.ExtendedEvalContext().Tracing.KVTracingEnabled(),
+		p.ExecCfg().Settings,
+		b,
+		p.ExecCfg().Codec,
+		desc.GetID(),
+		desc,
+	); err != nil {
+		return err
+	}
+	return p.txn.Run(ctx, b)
+}
+
+// funcSignature formats the name and parameter types of a function overload.
+func funcSignature(name *tree.TableName, paramTypes []*types.T) string {
+	var buf strings.Builder
+	buf.WriteString(name.String())
+	buf.WriteByte('(')
+	for i, typ := range paramTypes {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(typ.SQLString())
+	}
+	buf.WriteByte(')')
+	return buf.String()
+}
+
+func (n *createFunctionNode) Next(runParams) (bool, error) { return false, nil }
+func (n *createFunctionNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *createFunctionNode) Close(context.Context)        {}
@@ -221,9 +221,35 @@ func MakeIndexDescriptor(
 	if err := indexDesc.FillColumns(n.Columns); err != nil {
 		return nil, err
 	}
+	if err := checkNullsOrderingSupported(
+		params.ExecCfg().Settings.Version.ActiveVersionOrEmpty(params.ctx), &indexDesc,
+	); err != nil {
+		return nil, err
+	}
 	return &indexDesc, nil
 }
 
+// checkNullsOrderingSupported returns an error if the index has a column with
+// a non-default NULLS ordering and either the index is inverted or the cluster
+// version does not yet support encoding it.
+func checkNullsOrderingSupported(
+	version clusterversion.ClusterVersion, idx *sqlbase.IndexDescriptor,
+) error {
+	if !idx.HasReversedNulls() {
+		return nil
+	}
+	if idx.Type == sqlbase.IndexDescriptor_INVERTED {
+		return pgerror.New(pgcode.FeatureNotSupported,
+			"inverted indexes do not support NULLS FIRST or NULLS LAST")
+	}
+	if version == (clusterversion.ClusterVersion{}) ||
+		!version.IsActive(clusterversion.VersionNullsOrdering) {
+		return pgerror.New(pgcode.FeatureNotSupported,
+			"NULLS FIRST and NULLS LAST index columns are not supported until version upgrade is finalized")
+	}
+	return nil
+}
+
 // validateIndexColumnsExists validates that the columns for an index exist
 // in the table and are not being dropped prior to attempting to add the index.
 func validateIndexColumnsExist(
@@ -112,7 +112,8 @@ func (n *CreateRoleNode) startExec(params runParams) error {
 
 	var hashedPassword []byte
 	if n.roleOptions.Contains(roleoption.PASSWORD) {
-		hashedPassword, err = n.roleOptions.GetHashedPassword()
+		hashedPassword, err = n.roleOptions.GetHashedPassword(
+			GetConfiguredPasswordHashMethod(params.ctx, params.EvalContext().Settings))
 		if err != nil {
 			return err
 		}
@@ -206,6 +206,10 @@ func (n *createStatsNode) makeJobRecord(ctx context.Context) (*jobs.Record, erro
 
 		columnIDs := make([]sqlbase.ColumnID, len(columns))
 		for i := range columns {
+			if columns[i].Virtual {
+				return nil, pgerror.Newf(pgcode.InvalidColumnReference,
+					"cannot create statistics on virtual column %q", columns[i].Name)
+			}
 			columnIDs[i] = columns[i].ID
 		}
 		colStats = []jobspb.CreateStatsDetails_ColStat{{
@@ -316,17 +320,22 @@ func createStatsDefaultColumns(
 
 			colIDs := desc.Indexes[i].ColumnIDs[: j+1 : j+1]
 
+			col, err := desc.FindColumnByID(colIDs[j])
+			if err != nil {
+				return nil, err
+			}
+			// Statistics are collected by scanning the primary index, which does
+			// not contain virtual columns.
+			if col.Virtual {
+				break
+			}
+
 			// Check for existing stats and remember the requested stats.
 			key := makeColStatKey(colIDs)
 			if _, ok := requestedStats[key]; ok {
 				continue
 			}
 			requestedStats[key] = struct{}{}
-
-			col, err := desc.FindColumnByID(colIDs[j])
-			if err != nil {
-				return nil, err
-			}
 			colStats = append(colStats, jobspb.CreateStatsDetails_ColStat{
 				ColumnIDs:    colIDs,
 				HasHistogram: j == 0 && canHistogramType(col.Type),
@@ -338,6 +347,9 @@ func createStatsDefaultColumns(
 	nonIdxCols := 0
 	for i := 0; i < len(desc.Columns) && nonIdxCols < maxNonIndexCols; i++ {
 		col := &desc.Columns[i]
+		if col.Virtual {
+			continue
+		}
 		colList := []sqlbase.ColumnID{col.ID}
 		key := makeColStatKey(colList)
 		if _, ok := requestedStats[key]; !ok {
@@ -120,6 +120,7 @@ var minimumTypeUsageVersions = map[types.Family]clusterversion.VersionKey{
 	types.TimeTZFamily:    clusterversion.VersionTimeTZType,
 	types.GeographyFamily: clusterversion.VersionGeospatialType,
 	types.GeometryFamily:  clusterversion.VersionGeospatialType,
+	types.RangeFamily:     clusterversion.VersionRangeTypes,
 }
 
 // isTypeSupportedInVersion returns whether a given type is supported in the given version.
@@ -138,6 +139,11 @@ func isTypeSupportedInVersion(v clusterversion.ClusterVersion, t *types.T) (bool
 			!v.IsActive(clusterversion.VersionTimePrecision) {
 			return false, nil
 		}
+	case types.ArrayFamily:
+		if t.ArrayContents().Family() == types.ArrayFamily &&
+			!v.IsActive(clusterversion.VersionMultiDimensionalArrays) {
+			return false, nil
+		}
 	}
 	minVersion, ok := minimumTypeUsageVersions[t.Family()]
 	if !ok {
@@ -809,6 +815,12 @@ func ResolveFK(
 		}
 	}
 
+	if d.Deferrable != tree.NotDeferrable &&
+		!evalCtx.Settings.Version.IsActive(ctx, clusterversion.VersionDeferrableConstraints) {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"not all nodes are the correct version for deferrable foreign key constraints")
+	}
+
 	ref := sqlbase.ForeignKeyConstraint{
 		OriginTableID:         tbl.ID,
 		OriginColumnIDs:       originColumnIDs,
@@ -821,6 +833,8 @@ func ResolveFK(
 		Match:                 sqlbase.CompositeKeyMatchMethodValue[d.Match],
 		LegacyOriginIndex:     legacyOriginIndexID,
 		LegacyReferencedIndex: legacyReferencedIndexID,
+		Deferrable:            d.Deferrable != tree.NotDeferrable,
+		InitiallyDeferred:     d.Deferrable == tree.DeferrableInitiallyDeferred,
 	}
 
 	if ts == NewTable {
@@ -959,7 +973,8 @@ func addInterleave(
 				strings.Join(index.ColumnNames, ", "),
 			)
 		}
-		if !col.Type.Identical(targetCol.Type) || index.ColumnDirections[i] != parentIndex.ColumnDirections[i] {
+		if !col.Type.Identical(targetCol.Type) || index.ColumnDirections[i] != parentIndex.ColumnDirections[i] ||
+			index.ColumnNullsReversedAt(i) != parentIndex.ColumnNullsReversedAt(i) {
 			return pgerror.Newf(
 				pgcode.InvalidSchemaDefinition,
 				"declared interleaved columns (%s) must match type and sort direction of the parent's primary index (%s)",
@@ -1247,6 +1262,12 @@ func MakeTableDesc(
 					defType.SQLString(),
 				)
 			}
+			if d.IsComputed() && d.Computed.Virtual &&
+				(version == (clusterversion.ClusterVersion{}) ||
+					!version.IsActive(clusterversion.VersionVirtualComputedColumns)) {
+				return desc, pgerror.New(pgcode.FeatureNotSupported,
+					"virtual computed columns are not supported until version upgrade is finalized")
+			}
 			if d.PrimaryKey.Sharded {
 				// This function can sometimes be called when `st` is nil,
 				// and also before the version has been initialized. We only
@@ -1402,6 +1423,9 @@ func MakeTableDesc(
 			if err := idx.FillColumns(d.Columns); err != nil {
 				return desc, err
 			}
+			if err := checkNullsOrderingSupported(version, &idx); err != nil {
+				return desc, err
+			}
 			if d.Inverted {
 				columnDesc, _, err := desc.FindColumnByName(tree.Name(idx.ColumnNames[0]))
 				if err != nil {
@@ -1460,6 +1484,9 @@ func MakeTableDesc(
 			if err := idx.FillColumns(d.Columns); err != nil {
 				return desc, err
 			}
+			if err := checkNullsOrderingSupported(version, &idx); err != nil {
+				return desc, err
+			}
 			if d.PartitionBy != nil {
 				partitioning, err := CreatePartitioning(ctx, st, evalCtx, &desc, &idx, d.PartitionBy)
 				if err != nil {
@@ -1941,6 +1968,7 @@ func replaceLikeTableOpts(n *tree.CreateTable, params runParams) (tree.TableDefs
 					if idx.ColumnDirections[i] == sqlbase.IndexDescriptor_DESC {
 						elem.Direction = tree.Descending
 					}
+					elem.NullsOrder = idx.ColumnNullsOrder(i)
 					indexDef.Columns = append(indexDef.Columns, elem)
 				}
 				for _, name := range idx.StoreColumnNames {
@@ -1972,6 +2000,8 @@ func makeObjectAlreadyExistsError(collidingObject *sqlbase.Descriptor, name stri
 		return sqlbase.NewRelationAlreadyExistsError(name)
 	case *sqlbase.Descriptor_Type:
 		return sqlbase.NewTypeAlreadyExistsError(name)
+	case *sqlbase.Descriptor_Function:
+		return sqlbase.NewFunctionAlreadyExistsError(name)
 	case *sqlbase.Descriptor_Database:
 		return sqlbase.NewDatabaseAlreadyExistsError(name)
 	case *sqlbase.Descriptor_Schema:
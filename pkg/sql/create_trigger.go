@@ -0,0 +1,153 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"sort"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/resolver"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+)
+
+type createTriggerNode struct {
+	n         *tree.CreateTrigger
+	tableDesc *sqlbase.MutableTableDescriptor
+}
+
+// Use to satisfy the linter.
+var _ planNode = &createTriggerNode{n: nil}
+
+// CreateTrigger creates a row-level trigger on a table.
+// Privileges: CREATE on table.
+//   notes: postgres requires TRIGGER on the table.
+func (p *planner) CreateTrigger(ctx context.Context, n *tree.CreateTrigger) (planNode, error) {
+	tn := n.Table.ToTableName()
+	tableDesc, err := p.ResolveMutableTableDescriptor(
+		ctx, &tn, true /* required */, resolver.ResolveRequireTableDesc,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.CheckPrivilege(ctx, tableDesc, privilege.CREATE); err != nil {
+		return nil, err
+	}
+
+	if err := validateTriggerBody(n.Body); err != nil {
+		return nil, err
+	}
+
+	return &createTriggerNode{n: n, tableDesc: tableDesc}, nil
+}
+
+// validateTriggerBody checks that the body of a trigger consists of data
+// modification statements which do not return rows. The results of the
+// statements of a trigger are discarded.
+func validateTriggerBody(body string) error {
+	stmts, err := parser.Parse(body)
+	if err != nil {
+		return pgerror.Wrap(err, pgcode.InvalidFunctionDefinition, "invalid trigger body")
+	}
+	if len(stmts) == 0 {
+		return pgerror.New(pgcode.InvalidFunctionDefinition, "trigger body cannot be empty")
+	}
+	for i := range stmts {
+		var returning tree.ReturningClause
+		switch t := stmts[i].AST.(type) {
+		case *tree.Insert:
+			returning = t.Returning
+		case *tree.Update:
+			returning = t.Returning
+		case *tree.Delete:
+			returning = t.Returning
+		default:
+			return pgerror.Newf(pgcode.InvalidFunctionDefinition,
+				"%s statements are not supported in triggers", stmts[i].AST.StatementTag())
+		}
+		if _, ok := returning.(*tree.ReturningExprs); ok {
+			return pgerror.New(pgcode.InvalidFunctionDefinition,
+				"RETURNING is not supported in triggers")
+		}
+	}
+	return nil
+}
+
+// ReadingOwnWrites implements the planNodeReadingOwnWrites interface.
+// This is because CREATE TRIGGER modifies the table descriptor and expects
+// to see its own writes.
+func (n *createTriggerNode) ReadingOwnWrites() {}
+
+func (n *createTriggerNode) startExec(params runParams) error {
+	// Make sure that all nodes in the cluster are able to run triggers.
+	if !params.p.ExecCfg().Settings.Version.IsActive(
+		params.ctx, clusterversion.VersionTriggers,
+	) {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"not all nodes are the correct version for CREATE TRIGGER")
+	}
+
+	telemetry.Inc(sqltelemetry.SchemaChangeCreateCounter("trigger"))
+
+	trigger := sqlbase.TableDescriptor_Trigger{
+		Name:       string(n.n.Name),
+		ActionTime: sqlbase.TableDescriptor_Trigger_BEFORE,
+		Body:       n.n.Body,
+	}
+	if n.n.ActionTime == tree.TriggerAfter {
+		trigger.ActionTime = sqlbase.TableDescriptor_Trigger_AFTER
+	}
+	for _, ev := range n.n.Events {
+		switch ev {
+		case tree.TriggerInsert:
+			trigger.OnInsert = true
+		case tree.TriggerUpdate:
+			trigger.OnUpdate = true
+		case tree.TriggerDelete:
+			trigger.OnDelete = true
+		}
+	}
+
+	// Triggers are kept sorted by name.
+	triggers := n.tableDesc.Triggers
+	i := sort.Search(len(triggers), func(i int) bool {
+		return triggers[i].Name >= trigger.Name
+	})
+	if i < len(triggers) && triggers[i].Name == trigger.Name {
+		return pgerror.Newf(pgcode.DuplicateObject,
+			"trigger %q for relation %q already exists", trigger.Name, n.tableDesc.Name)
+	}
+	triggers = append(triggers, sqlbase.TableDescriptor_Trigger{})
+	copy(triggers[i+1:], triggers[i:])
+	triggers[i] = trigger
+	n.tableDesc.Triggers = triggers
+
+	if err := n.tableDesc.Validate(params.ctx, params.p.txn, params.ExecCfg().Codec); err != nil {
+		return err
+	}
+
+	return params.p.writeSchemaChange(
+		params.ctx, n.tableDesc, sqlbase.InvalidMutationID,
+		tree.AsStringWithFQNames(n.n, params.Ann()),
+	)
+}
+
+func (n *createTriggerNode) Next(runParams) (bool, error) { return false, nil }
+func (n *createTriggerNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *createTriggerNode) Close(context.Context)        {}
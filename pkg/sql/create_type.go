@@ -43,6 +43,8 @@ func (n *createTypeNode) startExec(params runParams) error {
 	switch n.n.Variety {
 	case tree.Enum:
 		return params.p.createEnum(params, n.n)
+	case tree.Composite:
+		return params.p.createComposite(params, n.n)
 	default:
 		return unimplemented.NewWithIssue(25123, "CREATE TYPE")
 	}
@@ -159,6 +161,14 @@ func (p *planner) createArrayType(
 	switch t := typDesc.Kind; t {
 	case sqlbase.TypeDescriptor_ENUM:
 		elemTyp = types.MakeEnum(uint32(typDesc.GetID()), uint32(id))
+	case sqlbase.TypeDescriptor_COMPOSITE:
+		contents := make([]*types.T, len(typDesc.CompositeFields))
+		labels := make([]string, len(typDesc.CompositeFields))
+		for i := range typDesc.CompositeFields {
+			contents[i] = typDesc.CompositeFields[i].Type
+			labels[i] = typDesc.CompositeFields[i].Name
+		}
+		elemTyp = types.MakeComposite(uint32(typDesc.GetID()), uint32(id), contents, labels)
 	default:
 		return 0, errors.AssertionFailedf("cannot make array type for kind %s", t.String())
 	}
@@ -269,6 +279,77 @@ func (p *planner) createEnum(params runParams, n *tree.CreateType) error {
 	)
 }
 
+func (p *planner) createComposite(params runParams, n *tree.CreateType) error {
+	// Make sure that all nodes in the cluster are able to recognize composite
+	// types.
+	if !p.ExecCfg().Settings.Version.IsActive(params.ctx, clusterversion.VersionCompositeTypes) {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"composite types are not supported until version upgrade is finalized")
+	}
+
+	// Resolve the types of the attributes, ensuring there are no duplicate
+	// attribute names.
+	seenNames := make(map[tree.Name]struct{})
+	fields := make([]sqlbase.TypeDescriptor_CompositeField, len(n.CompositeTypeList))
+	for i := range n.CompositeTypeList {
+		elem := &n.CompositeTypeList[i]
+		if _, ok := seenNames[elem.Label]; ok {
+			return pgerror.Newf(pgcode.DuplicateColumn,
+				"column %q specified more than once", elem.Label)
+		}
+		seenNames[elem.Label] = struct{}{}
+		typ, err := tree.ResolveType(params.ctx, elem.Type, p.semaCtx.GetTypeResolver())
+		if err != nil {
+			return err
+		}
+		if err := sqlbase.ValidateColumnDefType(typ); err != nil {
+			return err
+		}
+		fields[i] = sqlbase.TypeDescriptor_CompositeField{
+			Name: string(elem.Label),
+			Type: typ,
+		}
+	}
+
+	// Resolve the desired new type name.
+	typeName, db, err := resolveNewTypeName(params, n.TypeName)
+	if err != nil {
+		return err
+	}
+	n.TypeName.SetAnnotation(&p.semaCtx.Annotations, typeName)
+
+	// Generate a key in the namespace table and a new id for this type.
+	typeKey, id, err := getCreateTypeParams(params, typeName, db)
+	if err != nil {
+		return err
+	}
+
+	typeDesc := sqlbase.NewMutableCreatedTypeDescriptor(sqlbase.TypeDescriptor{
+		Name:            typeName.Type(),
+		ID:              id,
+		ParentID:        db.GetID(),
+		ParentSchemaID:  keys.PublicSchemaID,
+		Kind:            sqlbase.TypeDescriptor_COMPOSITE,
+		CompositeFields: fields,
+	})
+
+	// Create the implicit array type for this type before finishing the type.
+	arrayTypeID, err := p.createArrayType(params, n, typeName, typeDesc, db)
+	if err != nil {
+		return err
+	}
+	typeDesc.ArrayTypeID = arrayTypeID
+
+	return p.createDescriptorWithID(
+		params.ctx,
+		typeKey.Key(params.ExecCfg().Codec),
+		id,
+		typeDesc,
+		params.EvalContext().Settings,
+		tree.AsStringWithFQNames(n, params.Ann()),
+	)
+}
+
 func (n *createTypeNode) Next(params runParams) (bool, error) { return false, nil }
 func (n *createTypeNode) Values() tree.Datums                 { return tree.Datums{} }
 func (n *createTypeNode) Close(ctx context.Context)           {}
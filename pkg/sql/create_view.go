@@ -14,6 +14,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catalogkv"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
@@ -36,8 +37,11 @@ type createViewNode struct {
 	ifNotExists bool
 	replace     bool
 	temporary   bool
-	dbDesc      *sqlbase.ImmutableDatabaseDescriptor
-	columns     sqlbase.ResultColumns
+	// materialized is set if the view is a materialized view, in which case
+	// the view's results are stored in the view's own primary index.
+	materialized bool
+	dbDesc       *sqlbase.ImmutableDatabaseDescriptor
+	columns      sqlbase.ResultColumns
 
 	// planDeps tracks which tables and views the view being created
 	// depends on. This is collected during the construction of
@@ -51,7 +55,15 @@ type createViewNode struct {
 func (n *createViewNode) ReadingOwnWrites() {}
 
 func (n *createViewNode) startExec(params runParams) error {
-	telemetry.Inc(sqltelemetry.SchemaChangeCreateCounter("view"))
+	if n.materialized {
+		if !params.p.ExecCfg().Settings.Version.IsActive(params.ctx, clusterversion.VersionMaterializedViews) {
+			return pgerror.New(pgcode.FeatureNotSupported,
+				"not all nodes are the correct version for materialized views")
+		}
+		telemetry.Inc(sqltelemetry.SchemaChangeCreateCounter("materialized_view"))
+	} else {
+		telemetry.Inc(sqltelemetry.SchemaChangeCreateCounter("view"))
+	}
 
 	viewName := string(n.viewName)
 	isTemporary := n.temporary
@@ -102,6 +114,10 @@ func (n *createViewNode) startExec(params runParams) error {
 			if !desc.IsView() {
 				return pgerror.Newf(pgcode.WrongObjectType, `%q is not a view`, viewName)
 			}
+			if desc.MaterializedView() {
+				return pgerror.Newf(pgcode.WrongObjectType,
+					`%q is a materialized view and cannot be replaced`, viewName)
+			}
 			replacingDesc = desc
 		default:
 			return err
@@ -146,11 +162,19 @@ func (n *createViewNode) startExec(params runParams) error {
 			&params.p.semaCtx,
 			params.p.EvalContext(),
 			isTemporary,
+			n.materialized,
 		)
 		if err != nil {
 			return err
 		}
 
+		// Materialized views are populated by the schema changer once the
+		// creating transaction commits, so the view stays in the ADD state
+		// until its backfill completes.
+		if n.materialized {
+			desc.State = sqlbase.TableDescriptor_ADD
+		}
+
 		// Collect all the tables/views this view depends on.
 		for backrefID := range n.planDeps {
 			desc.DependsOn = append(desc.DependsOn, backrefID)
@@ -232,7 +256,8 @@ func (n *createViewNode) Close(ctx context.Context)  {}
 // the ADDING state because back-references are added to the view's
 // dependencies in the same transaction that the view is created and it
 // doesn't matter if reads/writes use a cached descriptor that doesn't
-// include the back-references.
+// include the back-references. Materialized views are the exception: the
+// caller moves them to the ADDING state so that they can be backfilled.
 func makeViewTableDesc(
 	ctx context.Context,
 	viewName string,
@@ -246,6 +271,7 @@ func makeViewTableDesc(
 	semaCtx *tree.SemaContext,
 	evalCtx *tree.EvalContext,
 	temporary bool,
+	materialized bool,
 ) (sqlbase.MutableTableDescriptor, error) {
 	desc := sqlbase.InitTableDescriptor(
 		id,
@@ -257,6 +283,9 @@ func makeViewTableDesc(
 		temporary,
 	)
 	desc.ViewQuery = viewQuery
+	// Materialized views need to be marked before the columns are added so
+	// that AllocateIDs gives them a primary index.
+	desc.IsMaterializedView = materialized
 	if err := addResultColumns(ctx, semaCtx, evalCtx, &desc, resultColumns); err != nil {
 		return sqlbase.MutableTableDescriptor{}, err
 	}
@@ -0,0 +1,400 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/sql/lex"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowcontainer"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/cockroachdb/errors"
+)
+
+// deferredFKCheckBatchSize is the maximum number of key values that are
+// checked by a single query when the deferred checks of a foreign key
+// constraint are run.
+const deferredFKCheckBatchSize = 100
+
+// deferredFKCheck describes a foreign key check which can be deferred until
+// the end of the transaction. Deferred checks are re-run against the state of
+// the database at commit time: a key value violates the constraint if a row of
+// the origin table still references it and the referenced table has no row
+// with that value. This works the same way for checks of new values in the
+// origin table and for checks of values removed from the referenced table.
+type deferredFKCheck struct {
+	name              string
+	initiallyDeferred bool
+
+	originTableID sqlbase.ID
+	refTableID    sqlbase.ID
+	// originColIDs and refColIDs contain the IDs of the FK columns of the origin
+	// and referenced tables.
+	originColIDs []sqlbase.ColumnID
+	refColIDs    []sqlbase.ColumnID
+
+	// outbound is true if the check verifies values of the origin table; it
+	// determines the error message of a violation.
+	outbound bool
+
+	// The following fields are used for error messages.
+	originTableName string
+	refTableName    string
+	originColNames  []string
+	refColNames     []string
+}
+
+func makeDeferredFKCheck(c *exec.DeferrableFKCheck) *deferredFKCheck {
+	fk := c.FK
+	n := fk.ColumnCount()
+	check := &deferredFKCheck{
+		name:              fk.Name(),
+		initiallyDeferred: fk.Deferrability() == tree.DeferrableInitiallyDeferred,
+		originTableID:     sqlbase.ID(c.Origin.ID()),
+		refTableID:        sqlbase.ID(c.Referenced.ID()),
+		originColIDs:      make([]sqlbase.ColumnID, n),
+		refColIDs:         make([]sqlbase.ColumnID, n),
+		outbound:          c.Outbound,
+		originTableName:   string(c.Origin.Name()),
+		refTableName:      string(c.Referenced.Name()),
+		originColNames:    make([]string, n),
+		refColNames:       make([]string, n),
+	}
+	for i := 0; i < n; i++ {
+		originCol := c.Origin.Column(fk.OriginColumnOrdinal(c.Origin, i))
+		check.originColIDs[i] = sqlbase.ColumnID(originCol.ColID())
+		check.originColNames[i] = string(originCol.ColName())
+		refCol := c.Referenced.Column(fk.ReferencedColumnOrdinal(c.Referenced, i))
+		check.refColIDs[i] = sqlbase.ColumnID(refCol.ColID())
+		check.refColNames[i] = string(refCol.ColName())
+	}
+	return check
+}
+
+// deferredFKCheckKey identifies the pending checks of a foreign key constraint
+// in one direction.
+type deferredFKCheckKey struct {
+	originTableID sqlbase.ID
+	name          string
+	outbound      bool
+}
+
+func (c *deferredFKCheck) key() deferredFKCheckKey {
+	return deferredFKCheckKey{originTableID: c.originTableID, name: c.name, outbound: c.outbound}
+}
+
+// pendingFKCheck contains the key values which must be checked for a foreign
+// key constraint before the transaction commits.
+type pendingFKCheck struct {
+	check *deferredFKCheck
+	keys  []tree.Datums
+	// seen is used to deduplicate the key values.
+	seen map[string]struct{}
+	// memUsage is the memory accounted for keys and seen.
+	memUsage int64
+}
+
+// deferredFKChecks contains the state of the deferred foreign key checks of a
+// transaction.
+type deferredFKChecks struct {
+	// allModeSet and allDeferred are set by SET CONSTRAINTS ALL. If allModeSet
+	// is false, the checks of each constraint use the default mode of the
+	// constraint.
+	allModeSet  bool
+	allDeferred bool
+	// named contains the modes set by SET CONSTRAINTS for individual
+	// constraints; the value is true if the checks are deferred.
+	named map[string]bool
+
+	pending map[deferredFKCheckKey]*pendingFKCheck
+
+	// memAcc accounts for the memory used by the pending key values. It is
+	// drawn from the session monitor, the parent of the transaction monitor,
+	// because the latter is stopped before the state is reset.
+	memAcc mon.BoundAccount
+}
+
+// reset clears the state at the end of the transaction.
+func (s *deferredFKChecks) reset(ctx context.Context) {
+	memAcc := s.memAcc
+	memAcc.Clear(ctx)
+	*s = deferredFKChecks{memAcc: memAcc}
+}
+
+// isDeferred returns whether the given check is currently deferred.
+func (s *deferredFKChecks) isDeferred(c *deferredFKCheck) bool {
+	if deferred, ok := s.named[c.name]; ok {
+		return deferred
+	}
+	if s.allModeSet {
+		return s.allDeferred
+	}
+	return c.initiallyDeferred
+}
+
+// setMode implements SET CONSTRAINTS. If names is empty, the mode is set for
+// all constraints.
+func (s *deferredFKChecks) setMode(names tree.NameList, deferred bool) {
+	if len(names) == 0 {
+		s.allModeSet, s.allDeferred = true, deferred
+		s.named = nil
+		return
+	}
+	if s.named == nil {
+		s.named = make(map[string]bool)
+	}
+	for _, name := range names {
+		s.named[string(name)] = deferred
+	}
+}
+
+// add records a key value which must be checked before the transaction
+// commits. It returns an error if the memory budget is exceeded.
+func (s *deferredFKChecks) add(
+	ctx context.Context, c *deferredFKCheck, keyVals tree.Datums,
+) error {
+	for _, d := range keyVals {
+		if d == tree.DNull {
+			// A key containing a NULL can never violate the constraint.
+			return nil
+		}
+	}
+	if s.pending == nil {
+		s.pending = make(map[deferredFKCheckKey]*pendingFKCheck)
+	}
+	p, ok := s.pending[c.key()]
+	if !ok {
+		p = &pendingFKCheck{check: c, seen: make(map[string]struct{})}
+		s.pending[c.key()] = p
+	}
+	encoded := tree.AsString(&tree.DTuple{D: keyVals})
+	if _, ok := p.seen[encoded]; ok {
+		return nil
+	}
+	// The key values are stored both as datums and in their encoded form.
+	sz := int64(len(encoded)) + rowcontainer.SizeOfDatums
+	for _, d := range keyVals {
+		sz += rowcontainer.SizeOfDatum + int64(d.Size())
+	}
+	if err := s.memAcc.Grow(ctx, sz); err != nil {
+		return err
+	}
+	p.memUsage += sz
+	p.seen[encoded] = struct{}{}
+	p.keys = append(p.keys, keyVals)
+	return nil
+}
+
+// run runs the pending checks for which the filter returns true (or all
+// pending checks if filter is nil), and removes them.
+func (s *deferredFKChecks) run(
+	ctx context.Context, ie *InternalExecutor, txn *kv.Txn, filter func(*deferredFKCheck) bool,
+) error {
+	for k, p := range s.pending {
+		if filter != nil && !filter(p.check) {
+			continue
+		}
+		if err := p.run(ctx, ie, txn); err != nil {
+			return err
+		}
+		s.memAcc.Shrink(ctx, p.memUsage)
+		delete(s.pending, k)
+	}
+	return nil
+}
+
+// run checks the pending key values of a constraint and returns an error for
+// the first value that violates it.
+func (p *pendingFKCheck) run(ctx context.Context, ie *InternalExecutor, txn *kv.Txn) error {
+	c := p.check
+	n := len(c.originColIDs)
+	keyCols := make([]string, n)
+	originCond := make([]string, n)
+	refCond := make([]string, n)
+	for i := 0; i < n; i++ {
+		keyCols[i] = fmt.Sprintf("k%d", i+1)
+		originCond[i] = fmt.Sprintf("o.k%[1]d = v.k%[1]d", i+1)
+		refCond[i] = fmt.Sprintf("r.k%[1]d = v.k%[1]d", i+1)
+	}
+	for len(p.keys) > 0 {
+		batch := p.keys
+		if len(batch) > deferredFKCheckBatchSize {
+			batch = batch[:deferredFKCheckBatchSize]
+		}
+		var values bytes.Buffer
+		args := make([]interface{}, 0, len(batch)*n)
+		for i, keyVals := range batch {
+			if i > 0 {
+				values.WriteString(", ")
+			}
+			values.WriteByte('(')
+			for j, d := range keyVals {
+				if j > 0 {
+					values.WriteString(", ")
+				}
+				args = append(args, d)
+				fmt.Fprintf(&values, "$%d", len(args))
+			}
+			values.WriteByte(')')
+		}
+		query := fmt.Sprintf(
+			`SELECT v.* FROM (VALUES %[1]s) AS v (%[2]s)
+			 WHERE EXISTS (SELECT 1 FROM [%[3]d(%[4]s) AS o (%[2]s)] WHERE %[5]s)
+			   AND NOT EXISTS (SELECT 1 FROM [%[6]d(%[7]s) AS r (%[2]s)] WHERE %[8]s)
+			 LIMIT 1`,
+			values.String(),                   // 1
+			strings.Join(keyCols, ", "),       // 2
+			c.originTableID,                   // 3
+			colIDsToString(c.originColIDs),    // 4
+			strings.Join(originCond, " AND "), // 5
+			c.refTableID,                      // 6
+			colIDsToString(c.refColIDs),       // 7
+			strings.Join(refCond, " AND "),    // 8
+		)
+		row, err := ie.QueryRow(ctx, "deferred-fk-check", txn, query, args...)
+		if err != nil {
+			return err
+		}
+		if row != nil {
+			return c.violationErr(row)
+		}
+		p.keys = p.keys[len(batch):]
+	}
+	return nil
+}
+
+func colIDsToString(ids []sqlbase.ColumnID) string {
+	strs := make([]string, len(ids))
+	for i, id := range ids {
+		strs[i] = fmt.Sprintf("%d", id)
+	}
+	return strings.Join(strs, ", ")
+}
+
+// violationErr returns the error for a key value which violates the
+// constraint at the end of the transaction.
+func (c *deferredFKCheck) violationErr(keyVals tree.Datums) error {
+	var msg, details bytes.Buffer
+	vals := make([]string, len(keyVals))
+	for i, d := range keyVals {
+		vals[i] = d.String()
+	}
+	if c.outbound {
+		// Generate an error of the form:
+		//   ERROR:  insert or update on table "child" violates foreign key
+		//           constraint "foo"
+		//   DETAIL: Key (child_p)=(2) is not present in table "parent".
+		msg.WriteString("insert or update on table ")
+		lex.EncodeEscapedSQLIdent(&msg, c.originTableName)
+		msg.WriteString(" violates foreign key constraint ")
+		lex.EncodeEscapedSQLIdent(&msg, c.name)
+		fmt.Fprintf(&details, "Key (%s)=(%s) is not present in table ",
+			strings.Join(c.originColNames, ", "), strings.Join(vals, ", "))
+		lex.EncodeEscapedSQLIdent(&details, c.refTableName)
+	} else {
+		// Generate an error of the form:
+		//   ERROR:  update or delete on table "parent" violates foreign key
+		//           constraint "child_child_p_fkey" on table "child"
+		//   DETAIL: Key (p)=(1) is still referenced from table "child".
+		msg.WriteString("update or delete on table ")
+		lex.EncodeEscapedSQLIdent(&msg, c.refTableName)
+		msg.WriteString(" violates foreign key constraint ")
+		lex.EncodeEscapedSQLIdent(&msg, c.name)
+		msg.WriteString(" on table ")
+		lex.EncodeEscapedSQLIdent(&msg, c.originTableName)
+		fmt.Fprintf(&details, "Key (%s)=(%s) is still referenced from table ",
+			strings.Join(c.refColNames, ", "), strings.Join(vals, ", "))
+		lex.EncodeEscapedSQLIdent(&details, c.originTableName)
+	}
+	details.WriteByte('.')
+	return errors.WithDetail(
+		pgerror.Newf(pgcode.ForeignKeyViolation, "%s", msg.String()),
+		details.String(),
+	)
+}
+
+// deferrableFKCheckNode wraps the query of a foreign key check which can be
+// deferred until the end of the transaction. If the check is not deferred, it
+// behaves like errorIfRowsNode. Otherwise, it records the key values of the
+// rows returned by the query; they are checked again before the transaction
+// commits.
+//
+// Checks are never deferred in implicit transactions, for which the end of
+// the statement is the end of the transaction.
+type deferrableFKCheckNode struct {
+	plan  planNode
+	check *deferredFKCheck
+
+	// mkErr creates the error message, given the values of the first row
+	// produced.
+	mkErr func(values tree.Datums) error
+
+	keyCols []exec.NodeColumnOrdinal
+
+	nexted bool
+}
+
+func (n *deferrableFKCheckNode) startExec(params runParams) error {
+	return nil
+}
+
+func (n *deferrableFKCheckNode) Next(params runParams) (bool, error) {
+	if n.nexted {
+		return false, nil
+	}
+	n.nexted = true
+
+	state := params.p.deferredFKChecks
+	deferred := state != nil && !params.p.EvalContext().TxnImplicit && state.isDeferred(n.check)
+	for {
+		ok, err := n.plan.Next(params)
+		if err != nil || !ok {
+			return false, err
+		}
+		row := n.plan.Values()
+		if !deferred {
+			return false, n.mkErr(row)
+		}
+		keyVals := make(tree.Datums, len(n.keyCols))
+		for i, ord := range n.keyCols {
+			keyVals[i] = row[ord]
+		}
+		if n.check.outbound {
+			for _, d := range keyVals {
+				if d == tree.DNull {
+					// This can only be a MATCH FULL violation, which can't be fixed by
+					// later statements without modifying this row.
+					return false, n.mkErr(row)
+				}
+			}
+		}
+		if err := state.add(params.ctx, n.check, keyVals); err != nil {
+			return false, err
+		}
+	}
+}
+
+func (n *deferrableFKCheckNode) Values() tree.Datums {
+	return nil
+}
+
+func (n *deferrableFKCheckNode) Close(ctx context.Context) {
+	n.plan.Close(ctx)
+}
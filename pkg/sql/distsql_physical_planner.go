@@ -965,6 +965,7 @@ func (dsp *DistSQLPlanner) convertOrdering(
 			dir = execinfrapb.Ordering_Column_DESC
 		}
 		result.Columns[i].Direction = dir
+		result.Columns[i].NullsReversed = o.NullsReversed
 	}
 	return result
 }
@@ -1628,6 +1629,7 @@ func (dsp *DistSQLPlanner) addAggregators(
 			} else {
 				ordCols[i].Direction = execinfrapb.Ordering_Column_ASC
 			}
+			ordCols[i].NullsReversed = o.NullsReversed
 		}
 
 		localAggsSpec := execinfrapb.AggregatorSpec{
@@ -325,6 +325,7 @@ func distsqlOrdering(
 			dir = execinfrapb.Ordering_Column_DESC
 		}
 		ord.Columns[i].Direction = dir
+		ord.Columns[i].NullsReversed = c.NullsReversed
 	}
 
 	return ord
@@ -123,7 +123,8 @@ func (s *windowPlanState) createWindowFnSpec(
 			ColIdx: uint32(column.ColIdx),
 			// We need this -1 because encoding.Direction has extra value "_"
 			// as zeroth "entry" which its proto equivalent doesn't have.
-			Direction: execinfrapb.Ordering_Column_Direction(column.Direction - 1),
+			Direction:     execinfrapb.Ordering_Column_Direction(column.Direction - 1),
+			NullsReversed: column.NullsReversed,
 		})
 	}
 	funcInProgressSpec := execinfrapb.WindowerSpec_WindowFn{
@@ -1035,12 +1035,26 @@ func (dsp *DistSQLPlanner) PlanAndRunCascadesAndChecks(
 		// TODO(radu): this requires keeping all previous plans "alive" until the
 		// very end. We may want to make copies of the buffer nodes and clean up
 		// everything else.
+		if plan.cascades[i].Before {
+			// BEFORE triggers were run by PlanAndRunBeforeCascades.
+			continue
+		}
 		buf := plan.cascades[i].Buffer.(*bufferNode)
 		if buf.bufferedRows.Len() == 0 {
 			// No rows were actually modified.
 			continue
 		}
 
+		if plan.cascades[i].PlanRowFn != nil {
+			if err := dsp.planAndRunRowCascade(
+				ctx, planner, evalCtxFactory, plan, i, recv, maybeDistribute,
+			); err != nil {
+				recv.SetError(err)
+				return false
+			}
+			continue
+		}
+
 		log.VEventf(ctx, 1, "executing cascade for constraint %s", plan.cascades[i].FKName)
 
 		// We place a sequence point before every cascade, so
@@ -1142,6 +1156,121 @@ func (dsp *DistSQLPlanner) PlanAndRunCascadesAndChecks(
 	return true
 }
 
+// PlanAndRunBeforeCascades runs the cascades which must run before the main
+// query (i.e. BEFORE triggers). The input of the main query is buffered by a
+// subquery, so this method must be called after PlanAndRunSubqueries.
+//
+// Any cascades and checks generated by these cascades are appended to
+// plan.cascades and plan.checkPlans, and are run by
+// PlanAndRunCascadesAndChecks.
+//
+// Returns false if an error was encountered and sets that error in the provided
+// receiver.
+func (dsp *DistSQLPlanner) PlanAndRunBeforeCascades(
+	ctx context.Context,
+	planner *planner,
+	evalCtxFactory func() *extendedEvalContext,
+	plan *planComponents,
+	recv *DistSQLReceiver,
+	maybeDistribute bool,
+) bool {
+	prevSteppingMode := planner.Txn().ConfigureStepping(ctx, kv.SteppingEnabled)
+	defer func() { _ = planner.Txn().ConfigureStepping(ctx, prevSteppingMode) }()
+
+	for i, n := 0, len(plan.cascades); i < n; i++ {
+		if !plan.cascades[i].Before {
+			continue
+		}
+		if err := dsp.planAndRunRowCascade(
+			ctx, planner, evalCtxFactory, plan, i, recv, maybeDistribute,
+		); err != nil {
+			recv.SetError(err)
+			return false
+		}
+	}
+	return true
+}
+
+// planAndRunRowCascade runs the ith cascade of the plan, which is planned for
+// each buffered row using PlanRowFn (i.e. a trigger). The queries of the
+// cascade are run for each row in order; any cascades and checks generated by
+// them are appended to plan.cascades and plan.checkPlans.
+func (dsp *DistSQLPlanner) planAndRunRowCascade(
+	ctx context.Context,
+	planner *planner,
+	evalCtxFactory func() *extendedEvalContext,
+	plan *planComponents,
+	i int,
+	recv *DistSQLReceiver,
+	maybeDistribute bool,
+) error {
+	buf := plan.cascades[i].Buffer.(*bufferNode)
+	log.VEventf(ctx, 1, "executing trigger %s for %d rows",
+		plan.cascades[i].FKName, buf.bufferedRows.Len())
+
+	for rowIdx, n := 0, buf.bufferedRows.Len(); rowIdx < n; rowIdx++ {
+		row := buf.bufferedRows.At(rowIdx)
+		for queryIdx := 0; ; queryIdx++ {
+			// We place a sequence point before every query, so that it observes
+			// the writes of the previous ones.
+			_ = planner.Txn().ConfigureStepping(ctx, kv.SteppingEnabled)
+			if err := planner.Txn().Step(ctx); err != nil {
+				return err
+			}
+
+			evalCtx := evalCtxFactory()
+			execFactory := newExecFactory(planner)
+			// There can always be more rows or queries to run.
+			execFactory.disableAutoCommit()
+			queryPlan, err := plan.cascades[i].PlanRowFn(
+				ctx, &planner.semaCtx, &evalCtx.EvalContext, execFactory, row, queryIdx,
+			)
+			if err != nil {
+				return err
+			}
+			if queryPlan == nil {
+				// No more queries to run for this row.
+				break
+			}
+			qp := queryPlan.(*planTop)
+			plan.cascades[i].rowPlans = append(plan.cascades[i].rowPlans, qp.main)
+			// The BEFORE triggers of a statement must run between its subqueries
+			// and its main query, which is not possible for the queries of a
+			// trigger.
+			for j := range qp.cascades {
+				if qp.cascades[j].Before {
+					return unimplemented.NewWithIssuef(28296,
+						"trigger %s: statements on tables with BEFORE triggers are not supported in triggers",
+						plan.cascades[i].FKName)
+				}
+			}
+			if len(qp.subqueryPlans) > 0 {
+				return unimplemented.NewWithIssuef(28296,
+					"trigger %s: statements with subqueries are not supported in triggers",
+					plan.cascades[i].FKName)
+			}
+
+			// Queue any new cascades and collect any new checks.
+			plan.cascades = append(plan.cascades, qp.cascades...)
+			plan.checkPlans = append(plan.checkPlans, qp.checkPlans...)
+
+			// Triggers can fire other triggers, so we enforce the same limit as for
+			// cascades.
+			if limit := evalCtx.SessionData.OptimizerFKCascadesLimit; len(plan.cascades) > limit {
+				telemetry.Inc(sqltelemetry.CascadesLimitReached)
+				return pgerror.Newf(pgcode.TriggeredActionException, "cascades limit (%d) reached", limit)
+			}
+
+			if err := dsp.planAndRunPostquery(
+				ctx, qp.main, planner, evalCtx, recv, maybeDistribute,
+			); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // planAndRunPostquery runs a cascade or check query.
 func (dsp *DistSQLPlanner) planAndRunPostquery(
 	ctx context.Context,
@@ -36,6 +36,7 @@ type dropDatabaseNode struct {
 	dbDesc          *sqlbase.ImmutableDatabaseDescriptor
 	td              []toDelete
 	schemasToDelete []string
+	fnsToDelete     []*sqlbase.MutableFunctionDescriptor
 }
 
 // DropDatabase drops a database.
@@ -104,6 +105,7 @@ func (p *planner) DropDatabase(ctx context.Context, n *tree.DropDatabase) (planN
 	}
 
 	td := make([]toDelete, 0, len(tbNames))
+	var fnsToDelete []*sqlbase.MutableFunctionDescriptor
 	for i, tbName := range tbNames {
 		found, desc, err := p.LookupObject(
 			ctx,
@@ -120,6 +122,15 @@ func (p *planner) DropDatabase(ctx context.Context, n *tree.DropDatabase) (planN
 			return nil, err
 		}
 		if !found {
+			// The name may refer to a function, which is dropped along with the
+			// database.
+			fnDesc, err := p.lookupMutableFunctionForDrop(ctx, &tbName)
+			if err != nil {
+				return nil, err
+			}
+			if fnDesc != nil {
+				fnsToDelete = append(fnsToDelete, fnDesc)
+			}
 			continue
 		}
 		tbDesc, ok := desc.(*sqlbase.MutableTableDescriptor)
@@ -154,7 +165,13 @@ func (p *planner) DropDatabase(ctx context.Context, n *tree.DropDatabase) (planN
 		return nil, err
 	}
 
-	return &dropDatabaseNode{n: n, dbDesc: dbDesc, td: td, schemasToDelete: schemasToDelete}, nil
+	return &dropDatabaseNode{
+		n:               n,
+		dbDesc:          dbDesc,
+		td:              td,
+		schemasToDelete: schemasToDelete,
+		fnsToDelete:     fnsToDelete,
+	}, nil
 }
 
 func (n *dropDatabaseNode) startExec(params runParams) error {
@@ -201,6 +218,12 @@ func (n *dropDatabaseNode) startExec(params runParams) error {
 		tbNameStrings = append(tbNameStrings, toDel.tn.FQString())
 	}
 
+	for _, fnDesc := range n.fnsToDelete {
+		if err := p.deleteFunctionDesc(ctx, fnDesc); err != nil {
+			return err
+		}
+	}
+
 	descKey := sqlbase.MakeDescMetadataKey(p.ExecCfg().Codec, n.dbDesc.GetID())
 
 	b := &kv.Batch{}
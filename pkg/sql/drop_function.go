@@ -0,0 +1,171 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/resolver"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+)
+
+type dropFunctionNode struct {
+	n *tree.DropFunction
+}
+
+// Use to satisfy the linter.
+var _ planNode = &dropFunctionNode{n: nil}
+
+// DropFunction drops overloads of user-defined functions.
+// Privileges: CREATE on the database of the function.
+func (p *planner) DropFunction(ctx context.Context, n *tree.DropFunction) (planNode, error) {
+	return &dropFunctionNode{n: n}, nil
+}
+
+// ReadingOwnWrites implements the planNodeReadingOwnWrites interface.
+// This is because DROP FUNCTION can drop several overloads of the same
+// function, and needs to see the effects of the previous ones.
+func (n *dropFunctionNode) ReadingOwnWrites() {}
+
+func (n *dropFunctionNode) startExec(params runParams) error {
+	telemetry.Inc(sqltelemetry.SchemaChangeDropCounter("function"))
+
+	for i := range n.n.Functions {
+		if err := n.dropFunction(params, &n.n.Functions[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dropFunction drops the overload of a function identified by fn. If the
+// function has no other overloads, its descriptor is removed as well.
+func (n *dropFunctionNode) dropFunction(params runParams, fn *tree.FuncObj) error {
+	p := params.p
+	lookupFlags := tree.ObjectLookupFlags{
+		CommonLookupFlags: tree.CommonLookupFlags{Required: !n.n.IfExists},
+		RequireMutable:    true,
+		DesiredObjectKind: tree.FunctionObject,
+	}
+	desc, prefix, err := resolver.ResolveExistingObject(
+		params.ctx, p, fn.FuncName, lookupFlags, resolver.ResolveAnyDescType,
+	)
+	if err != nil {
+		if n.n.IfExists && pgerror.GetPGCode(err) == pgcode.UndefinedFunction {
+			return nil
+		}
+		return err
+	}
+	if desc == nil {
+		// IfExists was specified and the function was not found.
+		return nil
+	}
+	fnDesc := desc.(*sqlbase.MutableFunctionDescriptor)
+	fnName := tree.MakeTableNameWithSchema(prefix.CatalogName, prefix.SchemaName, tree.Name(fnDesc.Name))
+
+	dbDesc, err := p.ResolveUncachedDatabaseByName(params.ctx, prefix.Catalog(), true /* required */)
+	if err != nil {
+		return err
+	}
+	if err := p.CheckPrivilege(params.ctx, dbDesc, privilege.CREATE); err != nil {
+		return err
+	}
+
+	idx := 0
+	if fn.ParamsSpecified {
+		paramTypes := make([]*types.T, len(fn.Params))
+		for i := range fn.Params {
+			typ, err := tree.ResolveType(params.ctx, fn.Params[i].Type, p.semaCtx.GetTypeResolver())
+			if err != nil {
+				return err
+			}
+			paramTypes[i] = typ
+		}
+		if err := sqlbase.HydrateTypesInFunctionDescriptor(
+			fnDesc.FunctionDesc(), p.makeTypeLookupFn(params.ctx),
+		); err != nil {
+			return err
+		}
+		idx = fnDesc.FindOverload(paramTypes)
+		if idx < 0 {
+			if n.n.IfExists {
+				return nil
+			}
+			return pgerror.Newf(pgcode.UndefinedFunction,
+				"function %s does not exist", funcSignature(&fnName, paramTypes))
+		}
+	} else if len(fnDesc.Overloads) > 1 {
+		return errors.WithHint(
+			pgerror.Newf(pgcode.AmbiguousFunction,
+				"function name %q is not unique", tree.ErrString(&fnName)),
+			"Specify the argument list to select the function unambiguously.")
+	}
+
+	fnDesc.Overloads = append(fnDesc.Overloads[:idx], fnDesc.Overloads[idx+1:]...)
+	if len(fnDesc.Overloads) == 0 {
+		return p.deleteFunctionDesc(params.ctx, fnDesc)
+	}
+	return p.writeFunctionDesc(params.ctx, fnDesc)
+}
+
+// lookupMutableFunctionForDrop returns the descriptor of the function with
+// the given name, or nil if the name does not refer to a function.
+func (p *planner) lookupMutableFunctionForDrop(
+	ctx context.Context, name *tree.TableName,
+) (*sqlbase.MutableFunctionDescriptor, error) {
+	found, desc, err := p.LookupObject(
+		ctx,
+		tree.ObjectLookupFlags{
+			RequireMutable:    true,
+			DesiredObjectKind: tree.FunctionObject,
+		},
+		name.Catalog(),
+		name.Schema(),
+		name.Object(),
+	)
+	if err != nil || !found {
+		return nil, err
+	}
+	fnDesc, _ := desc.(*sqlbase.MutableFunctionDescriptor)
+	return fnDesc, nil
+}
+
+// deleteFunctionDesc removes the descriptor of a function and its entry in
+// the namespace table. Since function descriptors are not leased, there is no
+// need to wait for old versions of the descriptor to be released.
+func (p *planner) deleteFunctionDesc(
+	ctx context.Context, desc *sqlbase.MutableFunctionDescriptor,
+) error {
+	kvTrace := p.ExtendedEvalContext().Tracing.KVTracingEnabled()
+	if err := sqlbase.RemoveObjectNamespaceEntry(
+		ctx, p.txn, p.ExecCfg().Codec, desc.ParentID, desc.ParentSchemaID, desc.Name, kvTrace,
+	); err != nil {
+		return err
+	}
+	descKey := sqlbase.MakeDescMetadataKey(p.ExecCfg().Codec, desc.ID)
+	if kvTrace {
+		log.VEventf(ctx, 2, "Del %s", descKey)
+	}
+	return p.txn.Del(ctx, descKey)
+}
+
+func (n *dropFunctionNode) Next(params runParams) (bool, error) { return false, nil }
+func (n *dropFunctionNode) Values() tree.Datums                 { return tree.Datums{} }
+func (n *dropFunctionNode) Close(ctx context.Context)           {}
@@ -350,7 +350,7 @@ func (p *planner) initiateDropTable(
 	//
 	// TODO(bram): If interleaved and ON DELETE CASCADE, we will be able to use
 	// this faster mechanism.
-	if tableDesc.IsTable() && !tableDesc.IsInterleaved() {
+	if (tableDesc.IsTable() || tableDesc.MaterializedView()) && !tableDesc.IsInterleaved() {
 		tableDesc.DropTime = timeutil.Now().UnixNano()
 	}
 
@@ -15,6 +15,8 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/resolver"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
@@ -44,6 +46,9 @@ func (p *planner) DropView(ctx context.Context, n *tree.DropView) (planNode, err
 			// IfExists specified and the view did not exist.
 			continue
 		}
+		if err := checkViewMatchesMaterialized(droppedDesc, n.IsMaterialized); err != nil {
+			return nil, err
+		}
 
 		td = append(td, toDelete{tn, droppedDesc})
 	}
@@ -71,6 +76,21 @@ func (p *planner) DropView(ctx context.Context, n *tree.DropView) (planNode, err
 	return &dropViewNode{n: n, td: td}, nil
 }
 
+// checkViewMatchesMaterialized ensures that DROP VIEW is only used on regular
+// views and DROP MATERIALIZED VIEW is only used on materialized views.
+func checkViewMatchesMaterialized(desc *sqlbase.MutableTableDescriptor, materialized bool) error {
+	if desc.MaterializedView() && !materialized {
+		return errors.WithHint(
+			pgerror.Newf(pgcode.WrongObjectType, "%q is a materialized view", desc.Name),
+			"use DROP MATERIALIZED VIEW to remove a materialized view",
+		)
+	}
+	if !desc.MaterializedView() && materialized {
+		return pgerror.Newf(pgcode.WrongObjectType, "%q is not a materialized view", desc.Name)
+	}
+	return nil
+}
+
 // ReadingOwnWrites implements the planNodeReadingOwnWrites interface.
 // This is because DROP VIEW performs multiple KV operations on descriptors
 // and expects to see its own writes.
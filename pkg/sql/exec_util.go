@@ -55,6 +55,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirecancel"
 	"github.com/cockroachdb/cockroach/pkg/sql/querycache"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
@@ -63,7 +64,6 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/bitarray"
 	"github.com/cockroachdb/cockroach/pkg/util/duration"
-	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/metric"
@@ -678,6 +678,10 @@ type ExecutorConfig struct {
 
 	// StmtDiagnosticsRecorder deals with recording statement diagnostics.
 	StmtDiagnosticsRecorder *stmtdiagnostics.Registry
+
+	// ExternalIODirConfig configures access to external services, such as the
+	// KMS used to encrypt backups.
+	ExternalIODirConfig base.ExternalIODirConfig
 }
 
 // Organization returns the value of cluster.organization.
@@ -1040,16 +1044,8 @@ func checkResultType(typ *types.T) error {
 	case types.OidFamily:
 	case types.TupleFamily:
 	case types.EnumFamily:
+	case types.RangeFamily:
 	case types.ArrayFamily:
-		if typ.ArrayContents().Family() == types.ArrayFamily {
-			// Technically we could probably return arrays of arrays to a
-			// client (the encoding exists) but we don't want to give
-			// mixed signals -- that nested arrays appear to be supported
-			// in this case, and not in other cases (eg. CREATE). So we
-			// reject them in every case instead.
-			return unimplemented.NewWithIssueDetail(32552,
-				"result", "arrays cannot have arrays as element type")
-		}
 	case types.AnyFamily:
 		// Placeholder case.
 		return errors.Errorf("could not determine data type of %s", typ)
@@ -1064,15 +1060,24 @@ func checkResultType(typ *types.T) error {
 func (p *planner) EvalAsOfTimestamp(
 	ctx context.Context, asOf tree.AsOfClause,
 ) (_ hlc.Timestamp, err error) {
-	ts, err := tree.EvalAsOfTimestamp(ctx, asOf, &p.semaCtx, p.EvalContext())
+	asOfSystemTime, err := p.evalAsOf(ctx, asOf, false /* allowBoundedStaleness */)
+	return asOfSystemTime.Timestamp, err
+}
+
+// evalAsOf evaluates an AS OF SYSTEM TIME clause. If allowBoundedStaleness is
+// set, the clause may specify a bounded staleness read.
+func (p *planner) evalAsOf(
+	ctx context.Context, asOf tree.AsOfClause, allowBoundedStaleness bool,
+) (tree.AsOfSystemTime, error) {
+	asOfSystemTime, err := tree.EvalAsOf(ctx, asOf, &p.semaCtx, p.EvalContext(), allowBoundedStaleness)
 	if err != nil {
-		return hlc.Timestamp{}, err
+		return tree.AsOfSystemTime{}, err
 	}
-	if now := p.execCfg.Clock.Now(); now.Less(ts) {
-		return hlc.Timestamp{}, errors.Errorf(
+	if now, ts := p.execCfg.Clock.Now(), asOfSystemTime.Timestamp; now.Less(ts) {
+		return tree.AsOfSystemTime{}, errors.Errorf(
 			"AS OF SYSTEM TIME: cannot specify timestamp in the future (%s > %s)", ts, now)
 	}
-	return ts, nil
+	return asOfSystemTime, nil
 }
 
 // ParseHLC parses a string representation of an `hlc.Timestamp`.
@@ -1098,10 +1103,16 @@ func ParseHLC(s string) (hlc.Timestamp, error) {
 
 // isAsOf analyzes a statement to bypass the logic in newPlan(), since
 // that requires the transaction to be started already. If the returned
-// timestamp is not nil, it is the timestamp to which a transaction
+// value is not nil, its timestamp is the timestamp to which a transaction
 // should be set. The statements that will be checked are Select,
 // ShowTrace (of a Select statement), Scrub, Export, and CreateStats.
-func (p *planner) isAsOf(ctx context.Context, stmt tree.Statement) (*hlc.Timestamp, error) {
+//
+// If allowBoundedStaleness is set, a Select statement may specify a bounded
+// staleness read, in which case the returned timestamp is only the minimum
+// timestamp bound of the read.
+func (p *planner) isAsOf(
+	ctx context.Context, stmt tree.Statement, allowBoundedStaleness bool,
+) (*tree.AsOfSystemTime, error) {
 	var asOf tree.AsOfClause
 	switch s := stmt.(type) {
 	case *tree.Select:
@@ -1126,20 +1137,22 @@ func (p *planner) isAsOf(ctx context.Context, stmt tree.Statement) (*hlc.Timesta
 			return nil, nil
 		}
 		asOf = s.AsOf
+		allowBoundedStaleness = false
 	case *tree.Export:
-		return p.isAsOf(ctx, s.Query)
+		return p.isAsOf(ctx, s.Query, false /* allowBoundedStaleness */)
 	case *tree.CreateStats:
 		if s.Options.AsOf.Expr == nil {
 			return nil, nil
 		}
 		asOf = s.Options.AsOf
+		allowBoundedStaleness = false
 	case *tree.Explain:
-		return p.isAsOf(ctx, s.Statement)
+		return p.isAsOf(ctx, s.Statement, allowBoundedStaleness)
 	default:
 		return nil, nil
 	}
-	ts, err := p.EvalAsOfTimestamp(ctx, asOf)
-	return &ts, err
+	asOfSystemTime, err := p.evalAsOf(ctx, asOf, allowBoundedStaleness)
+	return &asOfSystemTime, err
 }
 
 // isSavepoint returns true if stmt is a SAVEPOINT statement.
@@ -1227,36 +1240,55 @@ type SessionArgs struct {
 	// client.
 	RemoteAddr            net.Addr
 	ConnResultsBufferSize int64
+	// BackendKeyData is the key with which the client can cancel the
+	// session's queries with a pgwire CancelRequest. It is zero for
+	// sessions that cannot be canceled this way.
+	BackendKeyData pgwirecancel.BackendKeyData
 }
 
 // SessionRegistry stores a set of all sessions on this node.
 // Use register() and deregister() to modify this registry.
 type SessionRegistry struct {
 	syncutil.Mutex
-	sessions map[ClusterWideID]registrySession
+	sessions            map[ClusterWideID]registrySession
+	sessionsByCancelKey map[pgwirecancel.BackendKeyData]registrySession
 }
 
 // NewSessionRegistry creates a new SessionRegistry with an empty set
 // of sessions.
 func NewSessionRegistry() *SessionRegistry {
-	return &SessionRegistry{sessions: make(map[ClusterWideID]registrySession)}
+	return &SessionRegistry{
+		sessions:            make(map[ClusterWideID]registrySession),
+		sessionsByCancelKey: make(map[pgwirecancel.BackendKeyData]registrySession),
+	}
 }
 
-func (r *SessionRegistry) register(id ClusterWideID, s registrySession) {
+func (r *SessionRegistry) register(
+	id ClusterWideID, cancelKey pgwirecancel.BackendKeyData, s registrySession,
+) {
 	r.Lock()
 	r.sessions[id] = s
+	if cancelKey != 0 {
+		r.sessionsByCancelKey[cancelKey] = s
+	}
 	r.Unlock()
 }
 
-func (r *SessionRegistry) deregister(id ClusterWideID) {
+func (r *SessionRegistry) deregister(id ClusterWideID, cancelKey pgwirecancel.BackendKeyData) {
 	r.Lock()
 	delete(r.sessions, id)
+	if cancelKey != 0 {
+		delete(r.sessionsByCancelKey, cancelKey)
+	}
 	r.Unlock()
 }
 
 type registrySession interface {
 	user() string
 	cancelQuery(queryID ClusterWideID) bool
+	// cancelCurrentQueries cancels all the queries currently running in the
+	// session and returns whether there were any.
+	cancelCurrentQueries() bool
 	cancelSession()
 	// serialize serializes a Session into a serverpb.Session
 	// that can be served over RPC.
@@ -1287,6 +1319,22 @@ func (r *SessionRegistry) CancelQuery(queryIDStr string, username string) (bool,
 	return false, fmt.Errorf("query ID %s not found", queryID)
 }
 
+// CancelQueryByKey looks up the session with the given BackendKeyData in the
+// session registry and cancels the queries currently running in it.
+func (r *SessionRegistry) CancelQueryByKey(
+	cancelKey pgwirecancel.BackendKeyData, username string,
+) (bool, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	session, ok := r.sessionsByCancelKey[cancelKey]
+	if !ok || !(username == security.RootUser || username == session.user()) {
+		// The key is a secret, so it is not included in the error.
+		return false, fmt.Errorf("session for cancel request not found")
+	}
+	return session.cancelCurrentQueries(), nil
+}
+
 // CancelSession looks up the specified session in the session registry and cancels it.
 func (r *SessionRegistry) CancelSession(sessionIDBytes []byte, username string) (bool, error) {
 	sessionID := BytesToClusterWideID(sessionIDBytes)
@@ -39,6 +39,7 @@ func ConvertToColumnOrdering(specOrdering Ordering) sqlbase.ColumnOrdering {
 		} else {
 			ordering[i].Direction = encoding.Descending
 		}
+		ordering[i].NullsReversed = c.NullsReversed
 	}
 	return ordering
 }
@@ -71,6 +72,7 @@ func ConvertToMappedSpecOrdering(
 		} else {
 			specOrdering.Columns[i].Direction = Ordering_Column_DESC
 		}
+		specOrdering.Columns[i].NullsReversed = c.NullsReversed
 	}
 	return specOrdering
 }
@@ -47,6 +47,13 @@ func (ord *Ordering) diagramString() string {
 		} else {
 			buf.WriteByte('+')
 		}
+		if c.NullsReversed {
+			if c.Direction == Ordering_Column_DESC {
+				buf.WriteString("(nulls-first)")
+			} else {
+				buf.WriteString("(nulls-last)")
+			}
+		}
 	}
 	return buf.String()
 }
@@ -99,11 +99,6 @@ func (n *explainDistSQLNode) startExec(params runParams) error {
 	planCtx.planner = params.p
 	planCtx.stmtType = n.stmtType
 
-	if n.analyze && len(n.plan.cascades) > 0 {
-		return errors.New("running EXPLAIN ANALYZE (DISTSQL) on this query is " +
-			"unsupported because of the presence of cascades")
-	}
-
 	// In EXPLAIN ANALYZE mode, we need subqueries to be evaluated as normal.
 	// In EXPLAIN mode, we don't evaluate subqueries, and instead display their
 	// original text in the plan.
@@ -146,6 +141,21 @@ func (n *explainDistSQLNode) startExec(params runParams) error {
 			}
 			return recv.commErr
 		}
+		// BEFORE triggers must run before the main query, as they do when the
+		// statement is executed without EXPLAIN.
+		if !distSQLPlanner.PlanAndRunBeforeCascades(
+			planCtx.ctx,
+			params.p,
+			params.extendedEvalCtx.copy,
+			&n.plan,
+			recv,
+			willDistribute,
+		) {
+			if err := rw.Err(); err != nil {
+				return err
+			}
+			return recv.commErr
+		}
 	}
 
 	physPlan, err := newPhysPlanForExplainPurposes(planCtx, distSQLPlanner, n.plan.main)
@@ -237,7 +247,7 @@ func (n *explainDistSQLNode) startExec(params runParams) error {
 		}
 	}
 
-	if n.analyze && len(n.plan.checkPlans) > 0 {
+	if n.analyze && (len(n.plan.cascades) > 0 || len(n.plan.checkPlans) > 0) {
 		outerChecks := planCtx.planner.curPlan.checkPlans
 		defer func() {
 			planCtx.planner.curPlan.checkPlans = outerChecks
@@ -260,6 +260,20 @@ func populateExplain(
 	if err := emitRow("", 0, "", "vectorized", fmt.Sprintf("%t", willVectorize), "", ""); err != nil {
 		return err
 	}
+	// For bounded staleness reads, also emit the negotiated read timestamp.
+	if bs := params.p.boundedStaleness; bs != nil {
+		if err := emitRow("", 0, "", "min timestamp", bs.minTimestamp.AsOfSystemTime(), "", ""); err != nil {
+			return err
+		}
+		servedBy := "leaseholder"
+		if bs.nearest {
+			servedBy = "nearest replica"
+		}
+		readTS := fmt.Sprintf("%s (%s)", bs.readTimestamp.AsOfSystemTime(), servedBy)
+		if err := emitRow("", 0, "", "read timestamp", readTS, "", ""); err != nil {
+			return err
+		}
+	}
 
 	e.populateEntries(params.ctx, plan, explainSubqueryFmtFlags)
 	return e.emitRows(emitRow)
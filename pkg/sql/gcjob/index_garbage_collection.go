@@ -91,6 +91,7 @@ func clearIndex(
 	// ClearRange cannot be run in a transaction, so create a
 	// non-transactional batch to send the request.
 	b := &kv.Batch{}
+	b.Header.AdmissionPriority = roachpb.BulkAdmissionPriority
 	b.AddRawRequest(&roachpb.ClearRangeRequest{
 		RequestHeader: roachpb.RequestHeader{
 			Key:    sp.Key,
@@ -135,6 +135,7 @@ func clearTableData(
 				endKey = tableSpan.EndKey
 			}
 			var b kv.Batch
+			b.Header.AdmissionPriority = roachpb.BulkAdmissionPriority
 			b.AddRawRequest(&roachpb.ClearRangeRequest{
 				RequestHeader: roachpb.RequestHeader{
 					Key:    lastKey.AsRawKey(),
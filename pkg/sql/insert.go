@@ -132,30 +132,28 @@ func (r *insertRun) initRowContainer(
 	}
 }
 
-// processSourceRow processes one row from the source for insertion and, if
-// result rows are needed, saves it in the result row container.
-func (r *insertRun) processSourceRow(params runParams, rowVals tree.Datums) error {
-	if err := enforceLocalColumnConstraints(rowVals, r.insertCols); err != nil {
-		return err
-	}
-
-	// Create a set of index IDs to not write to. Indexes should not be written
-	// to when they are partial indexes and the row does not satisfy the
-	// predicate. This set is passed as a parameter to tableInserter.row below.
+// partialIndexIgnoreSet returns the set of IDs of the partial indexes of the
+// given table that a row should not be written to, because it does not satisfy
+// their predicates. predVals contains the results of evaluating the predicate
+// of each partial index of the table, in index order. If predVals is shorter
+// than the number of partial indexes, then the remaining indexes are not
+// ignored.
+func partialIndexIgnoreSet(
+	desc *sqlbase.ImmutableTableDescriptor, predVals tree.Datums,
+) (util.FastIntSet, error) {
 	var ignoreIndexes util.FastIntSet
-	indexPredicateVals := rowVals[len(r.insertCols)+r.checkOrds.Len():]
 	colIdx := 0
-	indexes := r.ti.tableDesc().Indexes
+	indexes := desc.Indexes
 	for i := range indexes {
-		if colIdx >= len(indexPredicateVals) {
+		if colIdx >= len(predVals) {
 			break
 		}
 
 		index := indexes[i]
 		if index.IsPartial() {
-			val, err := tree.GetBool(indexPredicateVals[colIdx])
+			val, err := tree.GetBool(predVals[colIdx])
 			if err != nil {
-				return err
+				return util.FastIntSet{}, err
 			}
 			if !val {
 				// If the value of the column for the index predicate expression
@@ -165,6 +163,24 @@ func (r *insertRun) processSourceRow(params runParams, rowVals tree.Datums) erro
 			colIdx++
 		}
 	}
+	return ignoreIndexes, nil
+}
+
+// processSourceRow processes one row from the source for insertion and, if
+// result rows are needed, saves it in the result row container.
+func (r *insertRun) processSourceRow(params runParams, rowVals tree.Datums) error {
+	if err := enforceLocalColumnConstraints(rowVals, r.insertCols); err != nil {
+		return err
+	}
+
+	// Create a set of index IDs to not write to. Indexes should not be written
+	// to when they are partial indexes and the row does not satisfy the
+	// predicate. This set is passed as a parameter to tableInserter.row below.
+	indexPredicateVals := rowVals[len(r.insertCols)+r.checkOrds.Len():]
+	ignoreIndexes, err := partialIndexIgnoreSet(r.ti.tableDesc(), indexPredicateVals)
+	if err != nil {
+		return err
+	}
 
 	// Truncate rowVals so that it no longer includes partial index predicate
 	// values.
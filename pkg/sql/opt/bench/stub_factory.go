@@ -272,6 +272,8 @@ func (f *stubFactory) ConstructUpdate(
 	returnCols exec.TableColumnOrdinalSet,
 	checks exec.CheckOrdinalSet,
 	passthrough sqlbase.ResultColumns,
+	partialIndexPutCols int,
+	partialIndexDelCols int,
 	allowAutoCommit bool,
 	skipFKChecks bool,
 ) (exec.Node, error) {
@@ -287,6 +289,8 @@ func (f *stubFactory) ConstructUpsert(
 	updateCols exec.TableColumnOrdinalSet,
 	returnCols exec.TableColumnOrdinalSet,
 	checks exec.CheckOrdinalSet,
+	partialIndexPutCols int,
+	partialIndexDelCols int,
 	allowAutoCommit bool,
 	skipFKChecks bool,
 ) (exec.Node, error) {
@@ -337,6 +341,12 @@ func (f *stubFactory) ConstructErrorIfRows(
 	return struct{}{}, nil
 }
 
+func (f *stubFactory) ConstructDeferrableFKCheck(
+	input exec.Node, check *exec.DeferrableFKCheck, mkErr func(tree.Datums) error,
+) (exec.Node, error) {
+	return struct{}{}, nil
+}
+
 func (f *stubFactory) ConstructOpaque(metadata opt.OpaqueMetadata) (exec.Node, error) {
 	return struct{}{}, nil
 }
@@ -383,6 +393,12 @@ func (f *stubFactory) ConstructControlJobs(
 	return struct{}{}, nil
 }
 
+func (f *stubFactory) ConstructControlSchedules(
+	command tree.ScheduleCommand, input exec.Node,
+) (exec.Node, error) {
+	return struct{}{}, nil
+}
+
 func (f *stubFactory) ConstructCancelQueries(input exec.Node, ifExists bool) (exec.Node, error) {
 	return struct{}{}, nil
 }
@@ -397,6 +413,7 @@ func (f *stubFactory) ConstructCreateView(
 	ifNotExists bool,
 	replace bool,
 	temporary bool,
+	materialized bool,
 	viewQuery string,
 	columns sqlbase.ResultColumns,
 	deps opt.ViewDeps,
@@ -404,6 +421,12 @@ func (f *stubFactory) ConstructCreateView(
 	return struct{}{}, nil
 }
 
+func (f *stubFactory) ConstructCreateFunction(
+	schema cat.Schema, cf *tree.CreateFunction, body string,
+) (exec.Node, error) {
+	return struct{}{}, nil
+}
+
 func (f *stubFactory) ConstructExport(
 	input exec.Node, fileName tree.TypedExpr, fileFormat string, options []exec.KVOption,
 ) (exec.Node, error) {
@@ -80,6 +80,11 @@ type Column interface {
 	// computed columns, but they can depend on all other columns, including
 	// columns with default values.
 	ComputedExprStr() string
+
+	// IsVirtualComputed returns true if the column is a computed column which
+	// is not stored. Its value is computed from ComputedExprStr when the table
+	// is read.
+	IsVirtualComputed() bool
 }
 
 // IsMutationColumn is a convenience function that returns true if the column at
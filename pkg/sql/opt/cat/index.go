@@ -228,6 +228,11 @@ type IndexColumn struct {
 	// Descending is true if the index is ordered from greatest to least on
 	// this column, rather than least to greatest.
 	Descending bool
+
+	// NullsReversed is true if NULLs are ordered opposite to the default for
+	// the direction of this column: after all other values if the column is
+	// ascending, and before them if it is descending.
+	NullsReversed bool
 }
 
 // IsMutationIndex is a convenience function that returns true if the index at
@@ -41,6 +41,11 @@ type Table interface {
 	// information_schema tables.
 	IsVirtualTable() bool
 
+	// IsMaterializedView returns true if this table is actually a materialized
+	// view. Materialized views are the same as tables in all aspects, other
+	// than that they cannot be mutated.
+	IsMaterializedView() bool
+
 	// ColumnCount returns the number of public columns in the table. Public
 	// columns are not currently being added or dropped from the table. This
 	// method should be used when mutation columns can be ignored (the common
@@ -132,6 +137,14 @@ type Table interface {
 
 	// InboundForeignKey returns the ith inbound foreign key reference.
 	InboundForeignKey(i int) ForeignKeyConstraint
+
+	// TriggerCount returns the number of row-level triggers defined on the
+	// table.
+	TriggerCount() int
+
+	// Trigger returns the ith trigger, where i < TriggerCount. Triggers are
+	// returned in the order in which they are run.
+	Trigger(i int) Trigger
 }
 
 // CheckConstraint contains the SQL text and the validity status for a check
@@ -146,6 +159,27 @@ type CheckConstraint struct {
 	Validated  bool
 }
 
+// Trigger contains the definition of a row-level trigger on a table. For
+// example, this trigger inserts a row into an audit table for every row that
+// is inserted into table a:
+//
+//   CREATE TRIGGER t AFTER INSERT ON a FOR EACH ROW
+//     AS 'INSERT INTO audit VALUES (NEW.a)'
+//
+type Trigger struct {
+	Name string
+	// Before is true if the trigger runs before rows are modified, and false if
+	// it runs after.
+	Before bool
+	// OnInsert, OnUpdate and OnDelete indicate the events which fire the
+	// trigger.
+	OnInsert bool
+	OnUpdate bool
+	OnDelete bool
+	// Body contains the SQL statements of the trigger, separated by semicolons.
+	Body string
+}
+
 // TableStatistic is an interface to a table statistic. Each statistic is
 // associated with a set of columns.
 type TableStatistic interface {
@@ -245,4 +279,9 @@ type ForeignKeyConstraint interface {
 	// UpdateReferenceAction returns the action to be performed if the foreign key
 	// constraint would be violated by an update.
 	UpdateReferenceAction() tree.ReferenceAction
+
+	// Deferrability returns whether the checks of the constraint can be
+	// deferred until the end of the transaction (see SET CONSTRAINTS), and
+	// whether they are deferred by default.
+	Deferrability() tree.ConstraintDeferrability
 }
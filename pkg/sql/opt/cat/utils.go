@@ -227,6 +227,13 @@ func formatCatalogIndex(tab Table, ord int, tp treeprinter.Node) {
 		if idxCol.Descending {
 			fmt.Fprintf(&buf, " desc")
 		}
+		if idxCol.NullsReversed {
+			if idxCol.Descending {
+				fmt.Fprintf(&buf, " nulls first")
+			} else {
+				fmt.Fprintf(&buf, " nulls last")
+			}
+		}
 
 		if i >= idx.LaxKeyColumnCount() {
 			fmt.Fprintf(&buf, " (storing)")
@@ -149,7 +149,7 @@ func makeCascadeBuilder(b *Builder, mutationWithID opt.WithID) (*cascadeBuilder,
 
 // setupCascade fills in an exec.Cascade struct for the given cascade.
 func (cb *cascadeBuilder) setupCascade(cascade *memo.FKCascade) exec.Cascade {
-	return exec.Cascade{
+	res := exec.Cascade{
 		FKName: cascade.FKName,
 		Buffer: cb.mutationBuffer,
 		PlanFn: func(
@@ -162,7 +162,87 @@ func (cb *cascadeBuilder) setupCascade(cascade *memo.FKCascade) exec.Cascade {
 		) (exec.Plan, error) {
 			return cb.planCascade(ctx, semaCtx, evalCtx, execFactory, cascade, bufferRef, numBufferedRows)
 		},
+		Before: cascade.Before,
 	}
+	if rb, ok := cascade.Builder.(memo.RowCascadeBuilder); ok {
+		res.PlanRowFn = func(
+			ctx context.Context,
+			semaCtx *tree.SemaContext,
+			evalCtx *tree.EvalContext,
+			execFactory exec.Factory,
+			row tree.Datums,
+			i int,
+		) (exec.Plan, error) {
+			return cb.planRowCascade(ctx, semaCtx, evalCtx, execFactory, cascade, rb, row, i)
+		}
+	}
+	return res
+}
+
+// planRowCascade is used to plan the ith query of a cascade built by a
+// memo.RowCascadeBuilder for the given buffered row. Like planCascade, it is
+// run by the execution logic after the buffer is populated. It returns nil if
+// there are no more queries to run for the row.
+func (cb *cascadeBuilder) planRowCascade(
+	ctx context.Context,
+	semaCtx *tree.SemaContext,
+	evalCtx *tree.EvalContext,
+	execFactory exec.Factory,
+	cascade *memo.FKCascade,
+	rb memo.RowCascadeBuilder,
+	row tree.Datums,
+	i int,
+) (exec.Plan, error) {
+	// Extract the values of the cascade columns from the buffered row.
+	rowValues := func(cols opt.ColList) (tree.Datums, error) {
+		if len(cols) == 0 {
+			return nil, nil
+		}
+		res := make(tree.Datums, len(cols))
+		for j, col := range cols {
+			ordinal, ok := cb.mutationBufferCols.Get(int(col))
+			if !ok {
+				return nil, errors.AssertionFailedf("column %d not in mutation buffer", col)
+			}
+			res[j] = row[ordinal]
+		}
+		return res, nil
+	}
+	oldVals, err := rowValues(cascade.OldValues)
+	if err != nil {
+		return nil, err
+	}
+	newVals, err := rowValues(cascade.NewValues)
+	if err != nil {
+		return nil, err
+	}
+
+	var o xform.Optimizer
+	o.Init(evalCtx, cb.b.catalog)
+	factory := o.Factory()
+
+	relExpr, err := rb.BuildRow(
+		ctx, semaCtx, evalCtx, cb.b.catalog, factory, oldVals, newVals, i,
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while building trigger %s", cascade.FKName)
+	}
+	if relExpr == nil {
+		return nil, nil
+	}
+
+	o.Memo().SetRoot(relExpr, &physical.Required{})
+	optimizedExpr, err := o.Optimize()
+	if err != nil {
+		return nil, errors.Wrapf(err, "while optimizing trigger %s", cascade.FKName)
+	}
+
+	eb := New(execFactory, factory.Memo(), cb.b.catalog, optimizedExpr, evalCtx)
+	plan, err := eb.Build()
+	if err != nil {
+		return nil, errors.Wrapf(err, "while building plan for trigger %s", cascade.FKName)
+	}
+	return plan, nil
 }
 
 // planCascade is used to plan a cascade query. It is NOT run while
@@ -68,6 +68,22 @@ func (b *Builder) buildMutationInput(
 
 		b.addBuiltWithExpr(p.WithID, input.outputCols, bufferNode)
 		input.root = bufferNode
+
+		if p.FKCascades.HasBefore() {
+			// BEFORE triggers must run after the input is buffered but before the
+			// mutation, so the buffer is populated by a subquery (like a With
+			// binding) and the mutation reads from it.
+			name := tree.Name(label)
+			b.subqueries = append(b.subqueries, exec.Subquery{
+				ExprNode: &name,
+				Mode:     exec.SubqueryAllRows,
+				Root:     bufferNode,
+			})
+			input.root, err = b.factory.ConstructScanBuffer(bufferNode, label)
+			if err != nil {
+				return execPlan{}, err
+			}
+		}
 	}
 	return input, nil
 }
@@ -116,6 +132,10 @@ func (b *Builder) buildInsert(ins *memo.InsertExpr) (execPlan, error) {
 		return execPlan{}, err
 	}
 
+	if err := b.buildFKCascades(ins.WithID, ins.FKCascades); err != nil {
+		return execPlan{}, err
+	}
+
 	return ep, nil
 }
 
@@ -128,6 +148,11 @@ func (b *Builder) tryBuildFastPathInsert(ins *memo.InsertExpr) (_ execPlan, ok b
 		return execPlan{}, false, nil
 	}
 
+	// Triggers are planned as cascades, which the fast path does not support.
+	if len(ins.FKCascades) > 0 {
+		return execPlan{}, false, nil
+	}
+
 	// Conditions from ConstructFastPathInsert:
 	//
 	//  - there are no other mutations in the statement, and the output of the
@@ -157,6 +182,10 @@ func (b *Builder) tryBuildFastPathInsert(ins *memo.InsertExpr) (_ execPlan, ok b
 			return execPlan{}, false, nil
 		}
 		fk := tab.OutboundForeignKey(c.FKOrdinal)
+		if fk.Deferrability() != tree.NotDeferrable {
+			// The check might be deferred until the end of the transaction.
+			return execPlan{}, false, nil
+		}
 		lookupJoin, isLookupJoin := c.Check.(*memo.LookupJoinExpr)
 		if !isLookupJoin || lookupJoin.JoinType != opt.AntiJoinOp {
 			// Not a lookup anti-join.
@@ -279,7 +308,8 @@ func (b *Builder) buildUpdate(upd *memo.UpdateExpr) (execPlan, error) {
 	//
 	// TODO(andyk): Using ensureColumns here can result in an extra Render.
 	// Upgrade execution engine to not require this.
-	cnt := len(upd.FetchCols) + len(upd.UpdateCols) + len(upd.PassthroughCols) + len(upd.CheckCols)
+	cnt := len(upd.FetchCols) + len(upd.UpdateCols) + len(upd.PassthroughCols) +
+		len(upd.CheckCols) + len(upd.IndexPredicateCols) + len(upd.IndexPredicateDelCols)
 	colList := make(opt.ColList, 0, cnt)
 	colList = appendColsWhenPresent(colList, upd.FetchCols)
 	colList = appendColsWhenPresent(colList, upd.UpdateCols)
@@ -291,6 +321,8 @@ func (b *Builder) buildUpdate(upd *memo.UpdateExpr) (execPlan, error) {
 		colList = appendColsWhenPresent(colList, upd.PassthroughCols)
 	}
 	colList = appendColsWhenPresent(colList, upd.CheckCols)
+	colList = appendColsWhenPresent(colList, upd.IndexPredicateCols)
+	colList = appendColsWhenPresent(colList, upd.IndexPredicateDelCols)
 
 	input, err := b.buildMutationInput(upd, upd.Input, colList, &upd.MutationPrivate)
 	if err != nil {
@@ -323,6 +355,8 @@ func (b *Builder) buildUpdate(upd *memo.UpdateExpr) (execPlan, error) {
 		returnColOrds,
 		checkOrds,
 		passthroughCols,
+		len(upd.IndexPredicateCols),
+		len(upd.IndexPredicateDelCols),
 		b.allowAutoCommit && len(upd.Checks) == 0 && len(upd.FKCascades) == 0,
 		disableExecFKs,
 	)
@@ -365,7 +399,8 @@ func (b *Builder) buildUpsert(ups *memo.UpsertExpr) (execPlan, error) {
 	//
 	// TODO(andyk): Using ensureColumns here can result in an extra Render.
 	// Upgrade execution engine to not require this.
-	cnt := len(ups.InsertCols) + len(ups.FetchCols) + len(ups.UpdateCols) + len(ups.CheckCols) + 1
+	cnt := len(ups.InsertCols) + len(ups.FetchCols) + len(ups.UpdateCols) + len(ups.CheckCols) +
+		len(ups.IndexPredicateCols) + len(ups.IndexPredicateDelCols) + 1
 	colList := make(opt.ColList, 0, cnt)
 	colList = appendColsWhenPresent(colList, ups.InsertCols)
 	colList = appendColsWhenPresent(colList, ups.FetchCols)
@@ -374,6 +409,8 @@ func (b *Builder) buildUpsert(ups *memo.UpsertExpr) (execPlan, error) {
 		colList = append(colList, ups.CanaryCol)
 	}
 	colList = appendColsWhenPresent(colList, ups.CheckCols)
+	colList = appendColsWhenPresent(colList, ups.IndexPredicateCols)
+	colList = appendColsWhenPresent(colList, ups.IndexPredicateDelCols)
 
 	input, err := b.buildMutationInput(ups, ups.Input, colList, &ups.MutationPrivate)
 	if err != nil {
@@ -402,6 +439,8 @@ func (b *Builder) buildUpsert(ups *memo.UpsertExpr) (execPlan, error) {
 		updateColOrds,
 		returnColOrds,
 		checkOrds,
+		len(ups.IndexPredicateCols),
+		len(ups.IndexPredicateDelCols),
 		b.allowAutoCommit && len(ups.Checks) == 0 && len(ups.FKCascades) == 0,
 		disableExecFKs,
 	)
@@ -504,6 +543,11 @@ func (b *Builder) tryBuildDeleteRange(del *memo.DeleteExpr) (_ execPlan, ok bool
 		return execPlan{}, false, nil
 	}
 
+	if tab.TriggerCount() > 0 {
+		// Triggers need the values of the deleted rows.
+		return execPlan{}, false, nil
+	}
+
 	primaryIdx := tab.Index(cat.PrimaryIndex)
 
 	// If the table is interleaved in another table, we cannot use the fast path.
@@ -556,7 +600,7 @@ func (b *Builder) tryBuildDeleteRangeOnInterleaving(
 	for queuePos := 0; queuePos < len(queue); queuePos++ {
 		currTab := queue[queuePos]
 
-		if currTab.DeletableIndexCount() > 1 {
+		if currTab.DeletableIndexCount() > 1 || currTab.TriggerCount() > 0 {
 			return execPlan{}, false, nil
 		}
 
@@ -746,7 +790,12 @@ func (b *Builder) buildFKChecks(checks memo.FKChecksExpr) error {
 			}
 			return mkFKCheckErr(md, c, keyVals)
 		}
-		node, err := b.factory.ConstructErrorIfRows(query.root, mkErr)
+		var node exec.Node
+		if check, ok := b.makeDeferrableFKCheck(c, &query); ok {
+			node, err = b.factory.ConstructDeferrableFKCheck(query.root, check, mkErr)
+		} else {
+			node, err = b.factory.ConstructErrorIfRows(query.root, mkErr)
+		}
 		if err != nil {
 			return err
 		}
@@ -755,6 +804,45 @@ func (b *Builder) buildFKChecks(checks memo.FKChecksExpr) error {
 	return nil
 }
 
+// makeDeferrableFKCheck returns the description of the given FK check if the
+// check can be deferred until the end of the transaction. Checks which verify
+// that removed values are not referenced are only deferrable if the action of
+// the constraint is NO ACTION.
+func (b *Builder) makeDeferrableFKCheck(
+	c *memo.FKChecksItem, query *execPlan,
+) (_ *exec.DeferrableFKCheck, ok bool) {
+	md := b.mem.Metadata()
+	origin := md.Table(c.OriginTable)
+	referenced := md.Table(c.ReferencedTable)
+	var fk cat.ForeignKeyConstraint
+	if c.FKOutbound {
+		fk = origin.OutboundForeignKey(c.FKOrdinal)
+	} else {
+		fk = referenced.InboundForeignKey(c.FKOrdinal)
+		action := fk.UpdateReferenceAction()
+		if c.OpName == "delete" {
+			action = fk.DeleteReferenceAction()
+		}
+		if action != tree.NoAction {
+			return nil, false
+		}
+	}
+	if fk.Deferrability() == tree.NotDeferrable {
+		return nil, false
+	}
+	keyCols := make([]exec.NodeColumnOrdinal, len(c.KeyCols))
+	for i, col := range c.KeyCols {
+		keyCols[i] = query.getNodeColumnOrdinal(col)
+	}
+	return &exec.DeferrableFKCheck{
+		Origin:     origin,
+		Referenced: referenced,
+		FK:         fk,
+		Outbound:   c.FKOutbound,
+		KeyCols:    keyCols,
+	}, true
+}
+
 // mkFKCheckErr generates a user-friendly error describing a foreign key
 // violation. The keyVals are the values that correspond to the
 // cat.ForeignKeyConstraint columns.
@@ -133,6 +133,7 @@ func (ep *execPlan) sqlOrdering(ordering opt.Ordering) sqlbase.ColumnOrdering {
 		} else {
 			colOrder[i].Direction = encoding.Ascending
 		}
+		colOrder[i].NullsReversed = ordering[i].NullsReversed()
 	}
 
 	return colOrder
@@ -257,6 +258,9 @@ func (b *Builder) buildRelational(e memo.RelExpr) (execPlan, error) {
 	case *memo.CreateViewExpr:
 		ep, err = b.buildCreateView(t)
 
+	case *memo.CreateFunctionExpr:
+		ep, err = b.buildCreateFunction(t)
+
 	case *memo.WithExpr:
 		ep, err = b.buildWith(t)
 
@@ -290,6 +294,9 @@ func (b *Builder) buildRelational(e memo.RelExpr) (execPlan, error) {
 	case *memo.ControlJobsExpr:
 		ep, err = b.buildControlJobs(t)
 
+	case *memo.ControlSchedulesExpr:
+		ep, err = b.buildControlSchedules(t)
+
 	case *memo.CancelQueriesExpr:
 		ep, err = b.buildCancelQueries(t)
 
@@ -1834,13 +1841,20 @@ func (b *Builder) buildWindow(w *memo.WindowExpr) (execPlan, error) {
 
 	orderingExprs := make(tree.OrderBy, len(ord))
 	for i, c := range ord {
-		direction := tree.Ascending
+		direction, nullsOrder := tree.Ascending, tree.DefaultNullsOrder
 		if c.Descending() {
 			direction = tree.Descending
 		}
+		if c.NullsReversed() {
+			nullsOrder = tree.NullsLast
+			if c.Descending() {
+				nullsOrder = tree.NullsFirst
+			}
+		}
 		orderingExprs[i] = &tree.Order{
-			Expr:      b.indexedVar(&ctx, b.mem.Metadata(), c.ID()),
-			Direction: direction,
+			Expr:       b.indexedVar(&ctx, b.mem.Metadata(), c.ID()),
+			Direction:  direction,
+			NullsOrder: nullsOrder,
 		}
 	}
 
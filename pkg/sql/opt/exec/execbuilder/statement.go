@@ -67,6 +67,7 @@ func (b *Builder) buildCreateView(cv *memo.CreateViewExpr) (execPlan, error) {
 		cv.IfNotExists,
 		cv.Replace,
 		cv.Temporary,
+		cv.Materialized,
 		cv.ViewQuery,
 		cols,
 		cv.Deps,
@@ -74,6 +75,12 @@ func (b *Builder) buildCreateView(cv *memo.CreateViewExpr) (execPlan, error) {
 	return execPlan{root: root}, err
 }
 
+func (b *Builder) buildCreateFunction(cf *memo.CreateFunctionExpr) (execPlan, error) {
+	schema := b.mem.Metadata().Schema(cf.Schema)
+	root, err := b.factory.ConstructCreateFunction(schema, cf.Syntax, cf.Body)
+	return execPlan{root: root}, err
+}
+
 func (b *Builder) buildExplain(explain *memo.ExplainExpr) (execPlan, error) {
 	var node exec.Node
 
@@ -265,6 +272,22 @@ func (b *Builder) buildControlJobs(ctl *memo.ControlJobsExpr) (execPlan, error)
 	return execPlan{root: node}, nil
 }
 
+func (b *Builder) buildControlSchedules(ctl *memo.ControlSchedulesExpr) (execPlan, error) {
+	input, err := b.buildRelational(ctl.Input)
+	if err != nil {
+		return execPlan{}, err
+	}
+	node, err := b.factory.ConstructControlSchedules(
+		ctl.Command,
+		input.root,
+	)
+	if err != nil {
+		return execPlan{}, err
+	}
+	// ControlSchedules returns no columns.
+	return execPlan{root: node}, nil
+}
+
 func (b *Builder) buildCancelQueries(cancel *memo.CancelQueriesExpr) (execPlan, error) {
 	input, err := b.buildRelational(cancel.Input)
 	if err != nil {
@@ -390,6 +390,11 @@ type Factory interface {
 	// the input). The pass through columns are used to return any column from the
 	// FROM tables that are referenced in the RETURNING clause.
 	//
+	// The check columns are followed by partialIndexPutCols columns that contain
+	// the results of evaluating the predicates of the partial indexes of the
+	// table on the updated row, and then by partialIndexDelCols columns that
+	// contain the results of evaluating them on the existing row.
+	//
 	// If allowAutoCommit is set, the operator is allowed to commit the
 	// transaction (if appropriate, i.e. if it is in an implicit transaction).
 	// This is false if there are multiple mutations in a statement, or the output
@@ -406,6 +411,8 @@ type Factory interface {
 		returnCols TableColumnOrdinalSet,
 		checks CheckOrdinalSet,
 		passthrough sqlbase.ResultColumns,
+		partialIndexPutCols int,
+		partialIndexDelCols int,
 		allowAutoCommit bool,
 		skipFKChecks bool,
 	) (Node, error)
@@ -434,6 +441,11 @@ type Factory interface {
 	// values of columns {0, 1, 2} of the table. The last column contains the
 	// new value for column {1} of the table.
 	//
+	// The check columns are followed by partialIndexPutCols columns that contain
+	// the results of evaluating the predicates of the partial indexes of the
+	// table on the upserted row, and then by partialIndexDelCols columns that
+	// contain the results of evaluating them on the existing row.
+	//
 	// If allowAutoCommit is set, the operator is allowed to commit the
 	// transaction (if appropriate, i.e. if it is in an implicit transaction).
 	// This is false if there are multiple mutations in a statement, or the output
@@ -452,6 +464,8 @@ type Factory interface {
 		updateCols TableColumnOrdinalSet,
 		returnCols TableColumnOrdinalSet,
 		checks CheckOrdinalSet,
+		partialIndexPutCols int,
+		partialIndexDelCols int,
 		allowAutoCommit bool,
 		skipFKChecks bool,
 	) (Node, error)
@@ -519,11 +533,16 @@ type Factory interface {
 		ifNotExists bool,
 		replace bool,
 		temporary bool,
+		materialized bool,
 		viewQuery string,
 		columns sqlbase.ResultColumns,
 		deps opt.ViewDeps,
 	) (Node, error)
 
+	// ConstructCreateFunction returns a node that implements a CREATE FUNCTION
+	// statement.
+	ConstructCreateFunction(schema cat.Schema, cf *tree.CreateFunction, body string) (Node, error)
+
 	// ConstructSequenceSelect creates a node that implements a scan of a sequence
 	// as a data source.
 	ConstructSequenceSelect(sequence cat.Sequence) (Node, error)
@@ -537,6 +556,15 @@ type Factory interface {
 	// is used to create the error.
 	ConstructErrorIfRows(input Node, mkErr func(tree.Datums) error) (Node, error)
 
+	// ConstructDeferrableFKCheck is like ConstructErrorIfRows, but for a foreign
+	// key check that can be deferred until the end of the transaction. If the
+	// check is deferred when the node runs, the key values of the rows returned
+	// by the input are recorded so that they can be checked again at commit
+	// time; otherwise mkErr is used to create an error for the first row.
+	ConstructDeferrableFKCheck(
+		input Node, check *DeferrableFKCheck, mkErr func(tree.Datums) error,
+	) (Node, error)
+
 	// ConstructOpaque creates a node for an opaque operator.
 	ConstructOpaque(metadata opt.OpaqueMetadata) (Node, error)
 
@@ -580,6 +608,10 @@ type Factory interface {
 	// JOBS.
 	ConstructControlJobs(command tree.JobCommand, input Node) (Node, error)
 
+	// ConstructControlSchedules creates a node that implements PAUSE/RESUME/DROP
+	// SCHEDULES commands.
+	ConstructControlSchedules(command tree.ScheduleCommand, input Node) (Node, error)
+
 	// ConstructCancelQueries creates a node that implements CANCEL QUERIES.
 	ConstructCancelQueries(input Node, ifExists bool) (Node, error)
 
@@ -767,12 +799,47 @@ type Cascade struct {
 		bufferRef BufferNode,
 		numBufferedRows int,
 	) (Plan, error)
+
+	// PlanRowFn is set for row-level triggers, which run separate queries for
+	// each row of the mutation input; in that case, it is used instead of
+	// PlanFn. It creates the plan for the ith query to run for the given
+	// buffered row, or returns nil if there are no more queries for the row.
+	PlanRowFn func(
+		ctx context.Context,
+		semaCtx *tree.SemaContext,
+		evalCtx *tree.EvalContext,
+		execFactory Factory,
+		row tree.Datums,
+		i int,
+	) (Plan, error)
+
+	// Before is set if the cascading query must run before the main query.
+	// The buffer is then populated by a subquery.
+	Before bool
 }
 
 // InsertFastPathMaxRows is the maximum number of rows for which we can use the
 // insert fast path.
 const InsertFastPathMaxRows = 10000
 
+// DeferrableFKCheck contains information about a foreign key check which can be
+// deferred until the end of the transaction (see ConstructDeferrableFKCheck).
+type DeferrableFKCheck struct {
+	Origin     cat.Table
+	Referenced cat.Table
+	FK         cat.ForeignKeyConstraint
+
+	// Outbound is true if the check verifies that new values in the origin
+	// table have a valid reference, and false if it verifies that values
+	// removed from the referenced table are not referenced from the origin
+	// table.
+	Outbound bool
+
+	// KeyCols contains, for each column of the FK, the input column which
+	// contains the key value.
+	KeyCols []NodeColumnOrdinal
+}
+
 // InsertFastPathFKCheck contains information about a foreign key check to be
 // performed by the insert fast-path (see ConstructInsertFastPath). It
 // identifies the index into which we can perform the lookup.
@@ -910,7 +910,10 @@ func (c *indexConstraintCtx) makeInvertedIndexSpansForJSONExpr(
 func (c *indexConstraintCtx) makeInvertedIndexSpansForArrayExpr(
 	arr *tree.DArray, constraints []*constraint.Constraint, allPaths bool,
 ) (bool, []*constraint.Constraint) {
-	if len(arr.Array) == 0 {
+	// Multi-dimensional arrays are indexed and compared by their innermost
+	// elements.
+	elements := arr.Flatten()
+	if len(elements) == 0 {
 		// Arrays always contain the empty array.
 		out := &constraint.Constraint{}
 		c.unconstrained(0 /* offset */, out)
@@ -928,19 +931,15 @@ func (c *indexConstraintCtx) makeInvertedIndexSpansForArrayExpr(
 
 	// We're going to make one span to search for every value inside of the
 	// array datum.
-	for i := range arr.Array {
+	for i := range elements {
 		out := &constraint.Constraint{}
-		if arr.Array[i] == tree.DNull {
-			// This shouldn't ever happen - it means that arr.HasNulls was
-			// incorrectly set to false... but we'll be good citizens and make a
-			// contradiction here anyway, just in case.
+		if elements[i] == tree.DNull {
+			// The innermost elements of a multi-dimensional array can be NULL
+			// even though arr.HasNulls is false.
 			c.contradiction(0 /* offset */, out)
 			return false, append(constraints, out)
 		}
-		array := tree.NewDArray(arr.ParamTyp)
-		array.Array = make(tree.Datums, 1)
-		array.Array[0] = arr.Array[i]
-		c.eqSpan(0 /* offset */, array, out)
+		c.eqSpan(0 /* offset */, makeSingletonArray(arr.ParamTyp, elements[i]), out)
 
 		constraints = append(constraints, out)
 
@@ -949,7 +948,18 @@ func (c *indexConstraintCtx) makeInvertedIndexSpansForArrayExpr(
 			break
 		}
 	}
-	return len(arr.Array) == 1, constraints
+	return len(elements) == 1, constraints
+}
+
+// makeSingletonArray returns an array with elements of type typ that has the
+// given innermost element in each of its dimensions.
+func makeSingletonArray(typ *types.T, d tree.Datum) *tree.DArray {
+	if typ.Family() == types.ArrayFamily {
+		d = makeSingletonArray(typ.ArrayContents(), d)
+	}
+	array := tree.NewDArray(typ)
+	array.Array = tree.Datums{d}
+	return array
 }
 
 // makeInvertedIndexSpansForExpr is analogous to makeSpansForExpr, but it is
@@ -780,10 +780,24 @@ func OutputColumnIsAlwaysNull(e RelExpr, col opt.ColumnID) bool {
 // FKCascades stores metadata necessary for building cascading queries.
 type FKCascades []FKCascade
 
+// HasBefore returns true if any of the cascading queries must run before the
+// original query.
+func (c FKCascades) HasBefore() bool {
+	for i := range c {
+		if c[i].Before {
+			return true
+		}
+	}
+	return false
+}
+
 // FKCascade stores metadata necessary for building a cascading query.
 // Cascading queries are built as needed, after the original query is executed.
+//
+// Row-level triggers are also planned as cascades; in that case the builder is
+// a RowCascadeBuilder.
 type FKCascade struct {
-	// FKName is the name of the FK constraint.
+	// FKName is the name of the FK constraint, or the name of the trigger.
 	FKName string
 
 	// Builder is an object that can be used as the "optbuilder" for the cascading
@@ -803,6 +817,10 @@ type FKCascade struct {
 	// new values of the modified rows. The list maps 1-to-1 to foreign key columns.
 	// It is empty if the mutation is a deletion.
 	NewValues opt.ColList
+
+	// Before is set if the cascading query must run before the original query
+	// (after its input is buffered). It is only set for BEFORE triggers.
+	Before bool
 }
 
 // CascadeBuilder is an interface used to construct a cascading query for a
@@ -835,3 +853,27 @@ type CascadeBuilder interface {
 		oldValues, newValues opt.ColList,
 	) (RelExpr, error)
 }
+
+// RowCascadeBuilder is implemented by the CascadeBuilders of row-level
+// triggers, which build separate queries for each row of the mutation input.
+// Execution uses BuildRow instead of CascadeBuilder.Build for these builders.
+type RowCascadeBuilder interface {
+	CascadeBuilder
+
+	// BuildRow constructs the ith query to run for a row of the mutation input.
+	// The oldValues and newValues datums contain the values of the OldValues
+	// and NewValues columns of the FKCascade in that row. It returns nil if
+	// there are no more queries to run for the row.
+	//
+	// Like Build, the method does not mutate any captured state, and factory
+	// is always *norm.Factory.
+	BuildRow(
+		ctx context.Context,
+		semaCtx *tree.SemaContext,
+		evalCtx *tree.EvalContext,
+		catalog cat.Catalog,
+		factory interface{},
+		oldValues, newValues tree.Datums,
+		i int,
+	) (RelExpr, error)
+}
@@ -206,8 +206,9 @@ func (f *ExprFmtCtx) formatRelational(e RelExpr, tp treeprinter.Node) {
 		*InsertExpr, *UpdateExpr, *UpsertExpr, *DeleteExpr, *SequenceSelectExpr,
 		*WindowExpr, *OpaqueRelExpr, *OpaqueMutationExpr, *OpaqueDDLExpr,
 		*AlterTableSplitExpr, *AlterTableUnsplitExpr, *AlterTableUnsplitAllExpr,
-		*AlterTableRelocateExpr, *ControlJobsExpr, *CancelQueriesExpr,
-		*CancelSessionsExpr, *CreateViewExpr, *ExportExpr:
+		*AlterTableRelocateExpr, *ControlJobsExpr, *ControlSchedulesExpr,
+		*CancelQueriesExpr, *CancelSessionsExpr, *CreateViewExpr, *CreateFunctionExpr,
+		*ExportExpr:
 		fmt.Fprintf(f.Buffer, "%v", e.Op())
 		FormatPrivate(f, e.Private(), required)
 
@@ -510,6 +511,8 @@ func (f *ExprFmtCtx) formatRelational(e RelExpr, tp treeprinter.Node) {
 				f.formatMutationCols(e, tp, "upsert-mapping:", t.InsertCols, t.Table)
 			}
 			f.formatColList(e, tp, "check columns:", t.CheckCols)
+			f.formatColList(e, tp, "partial index pred columns:", t.IndexPredicateCols)
+			f.formatColList(e, tp, "partial index del pred columns:", t.IndexPredicateDelCols)
 			f.formatMutationCommon(tp, &t.MutationPrivate)
 		}
 
@@ -547,6 +550,9 @@ func (f *ExprFmtCtx) formatRelational(e RelExpr, tp treeprinter.Node) {
 	case *CreateTableExpr:
 		tp.Child(t.Syntax.String())
 
+	case *CreateFunctionExpr:
+		tp.Child(t.Body)
+
 	case *CreateViewExpr:
 		tp.Child(t.ViewQuery)
 
@@ -1309,6 +1315,9 @@ func FormatPrivate(f *ExprFmtCtx, private interface{}, physProps *physical.Requi
 	case *ControlJobsPrivate:
 		fmt.Fprintf(f.Buffer, " (%s)", tree.JobCommandToStatement[t.Command])
 
+	case *ControlSchedulesPrivate:
+		fmt.Fprintf(f.Buffer, " (%s)", t.Command)
+
 	case *CancelPrivate:
 		if t.IfExists {
 			f.Buffer.WriteString(" [if-exists]")
@@ -1318,6 +1327,10 @@ func FormatPrivate(f *ExprFmtCtx, private interface{}, physProps *physical.Requi
 		schema := f.Memo.Metadata().Schema(t.Schema)
 		fmt.Fprintf(f.Buffer, " %s.%s", schema.Name(), t.ViewName)
 
+	case *CreateFunctionPrivate:
+		schema := f.Memo.Metadata().Schema(t.Schema)
+		fmt.Fprintf(f.Buffer, " %s.%s", schema.Name(), t.Syntax.FuncName.Object())
+
 	case *JoinPrivate:
 		// Nothing to show; flags are shown separately.
 
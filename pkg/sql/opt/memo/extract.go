@@ -13,6 +13,7 @@ package memo
 import (
 	"github.com/cockroachdb/cockroach/pkg/sql/opt"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/errors"
 )
 
@@ -44,6 +45,12 @@ func CanExtractConstDatum(e opt.Expr) bool {
 	}
 
 	if arr, ok := e.(*ArrayExpr); ok {
+		if arr.Typ.ArrayContents().Family() == types.ArrayFamily {
+			// The sub-arrays of a multi-dimensional array might have mismatched
+			// dimensions, in which case the array must be evaluated to raise an
+			// error. Valid constant arrays are folded by the FoldArray rule.
+			return false
+		}
 		for _, elem := range arr.Elems {
 			if !CanExtractConstDatum(elem) {
 				return false
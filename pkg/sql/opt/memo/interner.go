@@ -449,6 +449,7 @@ func (h *hasher) HashOrderingChoice(val physical.OrderingChoice) {
 		choice := &val.Columns[i]
 		h.HashColSet(choice.Group)
 		h.HashBool(choice.Descending)
+		h.HashBool(choice.NullsReversed)
 	}
 }
 
@@ -517,6 +518,10 @@ func (h *hasher) HashJobCommand(val tree.JobCommand) {
 	h.HashInt(int(val))
 }
 
+func (h *hasher) HashScheduleCommand(val tree.ScheduleCommand) {
+	h.HashInt(int(val))
+}
+
 func (h *hasher) HashIndexOrdinal(val cat.IndexOrdinal) {
 	h.HashInt(val)
 }
@@ -873,6 +878,10 @@ func (h *hasher) IsJobCommandEqual(l, r tree.JobCommand) bool {
 	return l == r
 }
 
+func (h *hasher) IsScheduleCommandEqual(l, r tree.ScheduleCommand) bool {
+	return l == r
+}
+
 func (h *hasher) IsIndexOrdinalEqual(l, r cat.IndexOrdinal) bool {
 	return l == r
 }
@@ -51,10 +51,21 @@ func (c *CustomFuncs) IsListOfConstants(elems memo.ScalarListExpr) bool {
 }
 
 // FoldArray evaluates an Array expression with constant inputs. It returns the
-// array as a Const datum with type TArray.
+// array as a Const datum with type TArray, or nil if the elements do not form a
+// valid array.
 func (c *CustomFuncs) FoldArray(elems memo.ScalarListExpr, typ *types.T) opt.ScalarExpr {
 	elemType := typ.ArrayContents()
 	a := tree.NewDArray(elemType)
+	if elemType.Family() == types.ArrayFamily {
+		// Sub-arrays of a multi-dimensional array must all have the same
+		// dimensions, which Append verifies.
+		for i := range elems {
+			if err := a.Append(memo.ExtractConstDatum(elems[i])); err != nil {
+				return nil
+			}
+		}
+		return c.f.ConstructConst(a, typ)
+	}
 	a.Array = make(tree.Datums, len(elems))
 	for i := range a.Array {
 		a.Array[i] = memo.ExtractConstDatum(elems[i])
@@ -388,7 +399,12 @@ func (c *CustomFuncs) FoldColumnAccess(input opt.ScalarExpr, idx memo.TupleOrdin
 		return tup.Elems[idx]
 	}
 
-	// Case 2: The input is a constant DTuple.
+	// Case 2: The input is NULL, such as a NULL value of a composite type.
+	if input.Op() == opt.NullOp {
+		return c.f.ConstructNull(input.DataType().TupleContents()[idx])
+	}
+
+	// Case 3: The input is a constant DTuple.
 	if memo.CanExtractConstDatum(input) {
 		datum := memo.ExtractConstDatum(input)
 
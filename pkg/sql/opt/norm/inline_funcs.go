@@ -193,6 +193,36 @@ func (c *CustomFuncs) CanInlineProjections(projections memo.ProjectionsExpr) boo
 	return true
 }
 
+// CanInlineVirtualColumnProjections returns true if at least one of the given
+// projections computes a virtual computed column, and all other projections
+// are inlinable (see CanInline).
+func (c *CustomFuncs) CanInlineVirtualColumnProjections(projections memo.ProjectionsExpr) bool {
+	foundVirtual := false
+	for i := range projections {
+		if c.IsVirtualColumnProjection(&projections[i]) {
+			foundVirtual = true
+		} else if !c.CanInline(projections[i].Element) {
+			return false
+		}
+	}
+	return foundVirtual
+}
+
+// IsVirtualColumnProjection returns true if the given projection computes a
+// virtual computed column of a table using its computed column expression.
+func (c *CustomFuncs) IsVirtualColumnProjection(item *memo.ProjectionsItem) bool {
+	md := c.mem.Metadata()
+	tabID := md.ColumnMeta(item.Col).Table
+	if tabID == 0 {
+		return false
+	}
+	tabMeta := md.TableMeta(tabID)
+	if !tabMeta.Table.Column(tabID.ColumnOrdinal(item.Col)).IsVirtualComputed() {
+		return false
+	}
+	return tabMeta.ComputedCols[item.Col] == item.Element
+}
+
 // CanInline returns true if the given expression consists only of "simple"
 // operators like Variable, Const, Eq, and Plus. These operators are assumed to
 // be relatively inexpensive to evaluate, and therefore potentially evaluating
@@ -61,11 +61,16 @@ func (c *CustomFuncs) NeededMutationCols(
 	addCols(private.UpdateCols)
 	addCols(private.CheckCols)
 	addCols(private.IndexPredicateCols)
+	addCols(private.IndexPredicateDelCols)
 	addCols(private.ReturnCols)
 	addCols(private.PassthroughCols)
 	if private.CanaryCol != 0 {
 		cols.Add(private.CanaryCol)
 	}
+	for i := range private.FKCascades {
+		addCols(private.FKCascades[i].OldValues)
+		addCols(private.FKCascades[i].NewValues)
+	}
 
 	if private.WithID != 0 {
 		for i := range checks {
@@ -136,6 +141,19 @@ func neededMutationFetchCols(
 		}
 	}
 
+	// Retain any FetchCols that are used by cascades. Row-level triggers use the
+	// values of all columns.
+	var cascadeCols opt.ColSet
+	for i := range private.FKCascades {
+		cascadeCols.UnionWith(private.FKCascades[i].OldValues.ToSet())
+		cascadeCols.UnionWith(private.FKCascades[i].NewValues.ToSet())
+	}
+	for ord, col := range private.FetchCols {
+		if col != 0 && cascadeCols.Contains(col) {
+			cols.Add(tabMeta.MetaID.ColumnID(ord))
+		}
+	}
+
 	switch op {
 	case opt.UpdateOp, opt.UpsertOp:
 		// Determine set of target table columns that need to be updated.
@@ -149,8 +167,11 @@ func neededMutationFetchCols(
 		// Make sure to consider indexes that are being added or dropped.
 		for i, n := 0, tabMeta.Table.DeletableIndexCount(); i < n; i++ {
 			indexCols := tabMeta.IndexColumns(i)
-			if !indexCols.Intersects(updateCols) {
-				// This index is not being updated.
+			_, isPartial := tabMeta.Table.Index(i).Predicate()
+			if !indexCols.Intersects(updateCols) && (!isPartial || updateCols.Empty()) {
+				// This index is not being updated. Partial indexes are always
+				// considered updated, since the updated columns may change
+				// whether the row satisfies the predicate.
 				continue
 			}
 
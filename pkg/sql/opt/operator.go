@@ -139,6 +139,7 @@ var ComparisonOpReverseMap = map[Operator]tree.ComparisonOperator{
 	JsonSomeExistsOp: tree.JSONSomeExists,
 	JsonAllExistsOp:  tree.JSONAllExists,
 	OverlapsOp:       tree.Overlaps,
+	AdjacentOp:       tree.Adjacent,
 }
 
 // BinaryOpReverseMap maps from an optimizer operator type to a semantic tree
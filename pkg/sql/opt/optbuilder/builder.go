@@ -106,6 +106,10 @@ type Builder struct {
 	// are disabled and certain statements (like mutations) are disallowed.
 	insideViewDef bool
 
+	// If set, we are processing the input query of a CREATE TABLE AS statement,
+	// which is stored in the table descriptor and run again asynchronously.
+	insideCreateTableAs bool
+
 	// If set, we are collecting view dependencies in viewDeps. This can only
 	// happen inside view definitions.
 	//
@@ -125,6 +129,14 @@ type Builder struct {
 	// isCorrelated is set to true if we already reported to telemetry that the
 	// query contains a correlated subquery.
 	isCorrelated bool
+
+	// udfDepth is the number of user-defined function bodies that are currently
+	// being inlined.
+	udfDepth int
+
+	// triggerRow is set when building a statement of a row-level trigger. It
+	// provides the values of the NEW and OLD rows.
+	triggerRow *triggerRow
 }
 
 // New creates a new Builder structure initialized with the given
@@ -235,7 +247,7 @@ func (b *Builder) buildStmt(
 		switch stmt := stmt.(type) {
 		case *tree.Delete, *tree.Insert, *tree.Update, *tree.CreateTable, *tree.CreateView,
 			*tree.Split, *tree.Unsplit, *tree.Relocate,
-			*tree.ControlJobs, *tree.CancelQueries, *tree.CancelSessions:
+			*tree.ControlJobs, *tree.ControlSchedules, *tree.CancelQueries, *tree.CancelSessions:
 			panic(pgerror.Newf(
 				pgcode.Syntax, "%s cannot be used inside a view definition", stmt.StatementTag(),
 			))
@@ -270,6 +282,9 @@ func (b *Builder) buildStmt(
 	case *tree.CreateView:
 		return b.buildCreateView(stmt, inScope)
 
+	case *tree.CreateFunction:
+		return b.buildCreateFunction(stmt, inScope)
+
 	case *tree.Explain:
 		return b.buildExplain(stmt, inScope)
 
@@ -288,6 +303,9 @@ func (b *Builder) buildStmt(
 	case *tree.ControlJobs:
 		return b.buildControlJobs(stmt, inScope)
 
+	case *tree.ControlSchedules:
+		return b.buildControlSchedules(stmt, inScope)
+
 	case *tree.CancelQueries:
 		return b.buildCancelQueries(stmt, inScope)
 
@@ -297,6 +315,9 @@ func (b *Builder) buildStmt(
 	case *tree.Export:
 		return b.buildExport(stmt, inScope)
 
+	case *tree.CopyTo:
+		return b.buildCopyTo(stmt, inScope)
+
 	case *tree.ExplainAnalyzeDebug:
 		// This statement should have been handled by the executor.
 		panic(errors.Errorf("%s can only be used as a top-level statement", stmt.StatementTag()))
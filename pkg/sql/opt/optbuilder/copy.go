@@ -0,0 +1,84 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// buildCopyTo builds a COPY ... TO STDOUT statement. The query (or the scan of
+// the table) is built like a regular statement; its rows are encoded in the
+// requested COPY format by the connection as they are produced.
+func (b *Builder) buildCopyTo(copyTo *tree.CopyTo, inScope *scope) (outScope *scope) {
+	if err := checkCopyOptions(&copyTo.Options); err != nil {
+		panic(err)
+	}
+	stmt := copyTo.Statement
+	if stmt == nil {
+		exprs := tree.SelectExprs{tree.StarSelectExpr()}
+		if len(copyTo.Columns) > 0 {
+			exprs = make(tree.SelectExprs, len(copyTo.Columns))
+			for i := range copyTo.Columns {
+				exprs[i].Expr = tree.NewUnresolvedName(string(copyTo.Columns[i]))
+			}
+		}
+		stmt = &tree.Select{
+			Select: &tree.SelectClause{
+				Exprs: exprs,
+				From:  tree.From{Tables: tree.TableExprs{&copyTo.Table}},
+			},
+		}
+	}
+	return b.buildStmt(stmt, nil /* desiredTypes */, inScope)
+}
+
+// checkCopyOptions verifies that the options of a COPY statement are
+// consistent with each other. The checks and messages follow PostgreSQL.
+func checkCopyOptions(o *tree.CopyOptions) error {
+	if o.CopyFormat == tree.CopyFormatBinary {
+		if o.Delimiter != nil {
+			return pgerror.New(pgcode.Syntax, "cannot specify DELIMITER in BINARY mode")
+		}
+		if o.Null != nil {
+			return pgerror.New(pgcode.Syntax, "cannot specify NULL in BINARY mode")
+		}
+	}
+	if o.Header && o.CopyFormat != tree.CopyFormatCSV {
+		return pgerror.New(pgcode.FeatureNotSupported, "COPY HEADER available only in CSV mode")
+	}
+	if o.Delimiter != nil {
+		delim := o.Delimiter.RawString()
+		if len(delim) != 1 {
+			return pgerror.New(pgcode.FeatureNotSupported,
+				"COPY delimiter must be a single one-byte character")
+		}
+		if delim == "\n" || delim == "\r" {
+			return pgerror.New(pgcode.InvalidParameterValue,
+				"COPY delimiter cannot be newline or carriage return")
+		}
+		if o.CopyFormat == tree.CopyFormatText &&
+			strings.Contains(`\.abcdefghijklmnopqrstuvwxyz0123456789`, delim) {
+			return pgerror.Newf(pgcode.FeatureNotSupported, "COPY delimiter cannot be %q", delim)
+		}
+		if o.CopyFormat == tree.CopyFormatCSV && delim == `"` {
+			return pgerror.New(pgcode.FeatureNotSupported, "COPY delimiter and quote must be different")
+		}
+	}
+	if o.Null != nil && strings.ContainsAny(o.Null.RawString(), "\r\n") {
+		return pgerror.New(pgcode.InvalidParameterValue,
+			"COPY null representation cannot use newline or carriage return")
+	}
+	return nil
+}
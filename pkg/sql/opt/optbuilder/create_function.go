@@ -0,0 +1,138 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/props"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/errors"
+)
+
+func (b *Builder) buildCreateFunction(cf *tree.CreateFunction, inScope *scope) (outScope *scope) {
+	b.DisableMemoReuse = true
+	tn := cf.FuncName.ToTableName()
+	sch, _ := b.resolveSchemaForCreate(&tn)
+	schID := b.factory.Metadata().AddSchema(sch)
+
+	if lang := strings.ToLower(cf.Options.Language); lang != "" && lang != "sql" {
+		panic(unimplemented.NewWithIssueDetailf(17511, lang,
+			"functions with LANGUAGE %s are not supported", lang))
+	}
+	if cf.Options.Body == nil {
+		panic(pgerror.New(pgcode.InvalidFunctionDefinition, "no function body specified"))
+	}
+
+	// Resolve the types of the parameters and of the return value. The
+	// parameters are represented by synthesized columns while the body is
+	// checked.
+	argScope := b.allocScope()
+	params := make(tree.ArgTypes, len(cf.Params))
+	for i := range cf.Params {
+		name := string(cf.Params[i].Name)
+		for j := 0; j < i; j++ {
+			if name != "" && params[j].Name == name {
+				panic(pgerror.Newf(pgcode.InvalidFunctionDefinition,
+					"parameter name %q used more than once", name))
+			}
+		}
+		typ, err := tree.ResolveType(b.ctx, cf.Params[i].Type, b.semaCtx.GetTypeResolver())
+		if err != nil {
+			panic(err)
+		}
+		params[i].Name = name
+		params[i].Typ = typ
+		b.synthesizeColumn(argScope, name, typ, nil /* expr */, nil /* scalar */)
+	}
+	retType, err := tree.ResolveType(b.ctx, cf.ReturnType, b.semaCtx.GetTypeResolver())
+	if err != nil {
+		panic(err)
+	}
+	args := make([]tree.TypedExpr, len(argScope.cols))
+	for i := range argScope.cols {
+		args[i] = &argScope.cols[i]
+	}
+
+	stmt, err := parser.ParseOne(*cf.Options.Body)
+	if err != nil {
+		panic(errors.Wrap(err, "invalid function body"))
+	}
+	bodyExpr, err := funcBodyExpr(stmt.AST)
+	if err != nil {
+		panic(err)
+	}
+
+	// We build the body of the function to:
+	//  - check it semantically,
+	//  - compute its volatility, and
+	//  - get the fully resolved names into the AST.
+	// Substituting the parameters only copies the nodes on the path to the
+	// references to them, so the data source names are qualified in stmt as
+	// well.
+	b.qualifyDataSourceNamesInAST = true
+	defer func() {
+		b.qualifyDataSourceNamesInAST = false
+	}()
+	body, err := substituteFuncParams(bodyExpr, params, args, make([]int, len(args)))
+	if err != nil {
+		panic(err)
+	}
+
+	defer b.semaCtx.Properties.Restore(b.semaCtx.Properties)
+	b.semaCtx.Properties.Require("function body", tree.RejectSpecial)
+	texpr := argScope.resolveType(body, retType)
+	if typ := texpr.ResolvedType(); !typ.Equivalent(retType) && typ.Family() != types.UnknownFamily {
+		panic(errors.WithDetailf(
+			pgerror.Newf(pgcode.InvalidFunctionDefinition,
+				"return type mismatch in function declared to return %s", retType.SQLString()),
+			"Actual return type is %s.", typ.SQLString()))
+	}
+	scalar := b.buildScalar(texpr, argScope, nil /* outScope */, nil /* outCol */, nil /* colRefs */)
+
+	// The body of the function cannot be more volatile than the function.
+	var shared props.Shared
+	memo.BuildSharedProps(scalar, &shared)
+	volatility := cf.Options.Volatility
+	if volatility == 0 {
+		volatility = tree.VolatilityVolatile
+	}
+	bodyVolatility := tree.VolatilityImmutable
+	if shared.VolatilitySet.HasVolatile() {
+		bodyVolatility = tree.VolatilityVolatile
+	} else if shared.VolatilitySet.HasStable() || shared.HasSubquery {
+		// Subqueries read from tables, whose contents can change between
+		// statements.
+		bodyVolatility = tree.VolatilityStable
+	}
+	if bodyVolatility > volatility {
+		panic(errors.WithHintf(
+			pgerror.Newf(pgcode.InvalidFunctionDefinition,
+				"function declared %s cannot have a %s body", volatility, bodyVolatility),
+			"declare the function as %s", strings.ToUpper(bodyVolatility.String())))
+	}
+
+	outScope = b.allocScope()
+	outScope.expr = b.factory.ConstructCreateFunction(
+		&memo.CreateFunctionPrivate{
+			Schema: schID,
+			Syntax: cf,
+			Body:   tree.AsStringWithFlags(stmt.AST, tree.FmtParsable),
+		},
+	)
+	return outScope
+}
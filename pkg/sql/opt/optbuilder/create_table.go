@@ -57,8 +57,10 @@ func (b *Builder) buildCreateTable(ct *tree.CreateTable, inScope *scope) (outSco
 		// TODO(radu): this interaction is pretty hacky, investigate moving the
 		// generation of the string to the optimizer.
 		b.qualifyDataSourceNamesInAST = true
+		b.insideCreateTableAs = true
 		defer func() {
 			b.qualifyDataSourceNamesInAST = false
+			b.insideCreateTableAs = false
 		}()
 
 		b.pushWithFrame()
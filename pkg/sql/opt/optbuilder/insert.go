@@ -17,6 +17,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/opt"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
@@ -288,8 +289,7 @@ func (b *Builder) buildInsert(ins *tree.Insert, inScope *scope) (outScope *scope
 		// Wrap the input in one LEFT OUTER JOIN per UNIQUE index, and filter out
 		// rows that have conflicts. See the buildInputForDoNothing comment for
 		// more details.
-		conflictOrds := mb.mapColumnNamesToOrdinals(ins.OnConflict.Columns)
-		mb.buildInputForDoNothing(inScope, conflictOrds)
+		mb.buildInputForDoNothing(inScope, ins.OnConflict)
 
 		// Since buildInputForDoNothing filters out rows with conflicts, always
 		// insert rows that are not filtered.
@@ -306,8 +306,7 @@ func (b *Builder) buildInsert(ins *tree.Insert, inScope *scope) (outScope *scope
 		if mb.needExistingRows() {
 			// Left-join each input row to the target table, using conflict columns
 			// derived from the primary index as the join condition.
-			primaryOrds := getIndexLaxKeyOrdinals(mb.tab.Index(cat.PrimaryIndex))
-			mb.buildInputForUpsert(inScope, primaryOrds, nil /* whereClause */)
+			mb.buildInputForUpsert(inScope, cat.PrimaryIndex, nil /* whereClause */)
 
 			// Add additional columns for computed expressions that may depend on any
 			// updated columns, as well as mutation columns with default values.
@@ -319,10 +318,10 @@ func (b *Builder) buildInsert(ins *tree.Insert, inScope *scope) (outScope *scope
 
 	// Case 4: INSERT..ON CONFLICT..DO UPDATE statement.
 	default:
-		// Left-join each input row to the target table, using the conflict columns
-		// as the join condition.
-		conflictOrds := mb.mapColumnNamesToOrdinals(ins.OnConflict.Columns)
-		mb.buildInputForUpsert(inScope, conflictOrds, ins.OnConflict.Where)
+		// Left-join each input row to the target table, using the columns of the
+		// arbiter index as the join condition.
+		arbiter := mb.arbiterIndex(ins.OnConflict)
+		mb.buildInputForUpsert(inScope, arbiter, ins.OnConflict.Where)
 
 		// Derive the columns that will be updated from the SET expressions.
 		mb.addTargetColsForUpdate(ins.OnConflict.Exprs)
@@ -347,6 +346,8 @@ func (b *Builder) buildInsert(ins *tree.Insert, inScope *scope) (outScope *scope
 //   2. All non-key columns (including mutation columns) have insert and update
 //      values specified for them.
 //   3. Each update value is the same as the corresponding insert value.
+//   4. There are no triggers. Triggers need to know whether each row is
+//      inserted or updated, and the values of the updated rows.
 //
 // TODO(radu): once FKs no longer require indexes, this function will have to
 // take FKs into account explicitly.
@@ -357,7 +358,7 @@ func (b *Builder) buildInsert(ins *tree.Insert, inScope *scope) (outScope *scope
 // of edge cases (that caused real correctness bugs #13437 #13962). As a result,
 // this support was removed and needs to re-enabled. See #14482.
 func (mb *mutationBuilder) needExistingRows() bool {
-	if mb.tab.DeletableIndexCount() > 1 {
+	if mb.tab.DeletableIndexCount() > 1 || mb.tab.TriggerCount() > 0 {
 		return true
 	}
 
@@ -644,6 +645,8 @@ func (mb *mutationBuilder) buildInsert(returning tree.ReturningExprs) {
 
 	mb.buildFKChecksForInsert()
 
+	mb.buildTriggers(opt.InsertOp)
+
 	private := mb.makeMutationPrivate(returning != nil)
 	mb.outScope.expr = mb.b.factory.ConstructInsert(mb.outScope.expr, mb.checks, private)
 
@@ -655,15 +658,19 @@ func (mb *mutationBuilder) buildInsert(returning tree.ReturningExprs) {
 // filter that discards rows that have a conflict (by checking a not-null table
 // column to see if it was null-extended by the left join). See the comment
 // header for Builder.buildInsert for an example.
-func (mb *mutationBuilder) buildInputForDoNothing(inScope *scope, conflictOrds util.FastIntSet) {
-	// DO NOTHING clause does not require ON CONFLICT columns.
-	var conflictIndex cat.Index
-	if !conflictOrds.Empty() {
-		// Check that the ON CONFLICT columns reference at most one target row by
-		// ensuring they match columns of a UNIQUE index. Using LEFT OUTER JOIN
-		// to detect conflicts relies upon this being true (otherwise result
-		// cardinality could increase). This is also a Postgres requirement.
-		conflictIndex = mb.ensureUniqueConflictCols(conflictOrds)
+//
+// If a unique index is a partial index, then only rows that satisfy its
+// predicate can conflict, so the predicate is added to the join condition for
+// both the inserted row and the existing row.
+func (mb *mutationBuilder) buildInputForDoNothing(inScope *scope, onConflict *tree.OnConflict) {
+	// DO NOTHING clause does not require a conflict target.
+	conflictIndex := -1
+	if len(onConflict.Columns) != 0 || onConflict.Constraint != "" {
+		// Check that the conflict target references at most one target row by
+		// ensuring it matches a UNIQUE index. Using LEFT OUTER JOIN to detect
+		// conflicts relies upon this being true (otherwise result cardinality
+		// could increase). This is also a Postgres requirement.
+		conflictIndex = mb.arbiterIndex(onConflict)
 	}
 
 	insertColSet := mb.outScope.expr.Relational().OutputCols
@@ -680,9 +687,9 @@ func (mb *mutationBuilder) buildInputForDoNothing(inScope *scope, conflictOrds u
 			continue
 		}
 
-		// If conflict columns were explicitly specified, then only check for a
+		// If a conflict target was explicitly specified, then only check for a
 		// conflict on a single index. Otherwise, check on all indexes.
-		if conflictIndex != nil && conflictIndex != index {
+		if conflictIndex != -1 && conflictIndex != idx {
 			continue
 		}
 
@@ -720,6 +727,7 @@ func (mb *mutationBuilder) buildInputForDoNothing(inScope *scope, conflictOrds u
 			)
 			on = append(on, mb.b.factory.ConstructFiltersItem(condition))
 		}
+		on = mb.addPartialIndexPredicateFilters(on, index, scanScope)
 
 		// Construct the left join + filter.
 		// TODO(andyk): Convert this to use anti-join once we have support for
@@ -746,16 +754,8 @@ func (mb *mutationBuilder) buildInputForDoNothing(inScope *scope, conflictOrds u
 		// Add an UpsertDistinctOn operator to ensure there are no duplicate input
 		// rows for this unique index. Duplicate rows can trigger conflict errors
 		// at runtime, which DO NOTHING is not supposed to do. See issue #37880.
-		var conflictCols opt.ColSet
-		for i, n := 0, index.LaxKeyColumnCount(); i < n; i++ {
-			indexCol := index.Column(i)
-			conflictCols.Add(mb.outScope.cols[mb.insertOrds[indexCol.Ordinal]].id)
-		}
-
-		// Treat NULL values as distinct from one another. And if duplicates are
-		// detected, remove them rather than raising an error.
-		mb.outScope = mb.b.buildDistinctOn(
-			conflictCols, mb.outScope, true /* nullsAreDistinct */, "" /* errorOnDup */)
+		// If duplicates are detected, remove them rather than raising an error.
+		mb.buildDistinctOnForArbiter(index, "" /* errorOnDup */)
 	}
 
 	mb.targetColList = make(opt.ColList, 0, mb.tab.DeletableColumnCount())
@@ -768,14 +768,17 @@ func (mb *mutationBuilder) buildInputForDoNothing(inScope *scope, conflictOrds u
 // columns to be a "canary column" that can be tested to determine whether a
 // given insert row conflicts with an existing row in the table. If it is null,
 // then there is no conflict.
+//
+// The conflict columns are the lax key columns of the given arbiter index,
+// which must be a UNIQUE index. Using LEFT OUTER JOIN to detect conflicts
+// relies upon this (otherwise result cardinality could increase). If the
+// arbiter is a partial index, then its predicate is added to the join
+// condition, since only rows in the index can conflict.
 func (mb *mutationBuilder) buildInputForUpsert(
-	inScope *scope, conflictOrds util.FastIntSet, whereClause *tree.Where,
+	inScope *scope, arbiter cat.IndexOrdinal, whereClause *tree.Where,
 ) {
-	// Check that the ON CONFLICT columns reference at most one target row.
-	// Using LEFT OUTER JOIN to detect conflicts relies upon this being true
-	// (otherwise result cardinality could increase). This is also a Postgres
-	// requirement.
-	mb.ensureUniqueConflictCols(conflictOrds)
+	index := mb.tab.Index(arbiter)
+	conflictOrds := getIndexLaxKeyOrdinals(index)
 
 	// Ensure that input is distinct on the conflict columns. Otherwise, the
 	// Upsert could affect the same row more than once, which can lead to index
@@ -785,13 +788,12 @@ func (mb *mutationBuilder) buildInputForUpsert(
 	// EnsureUpsertDistinctOn operator does not allow multiple rows in distinct
 	// groupings, the internal ordering is meaningless (and can trigger a
 	// misleading error in buildDistinctOn if present).
-	var conflictCols opt.ColSet
-	for ord, ok := conflictOrds.Next(0); ok; ord, ok = conflictOrds.Next(ord + 1) {
-		conflictCols.Add(mb.outScope.cols[mb.insertOrds[ord]].id)
-	}
 	mb.outScope.ordering = nil
-	mb.outScope = mb.b.buildDistinctOn(
-		conflictCols, mb.outScope, true /* nullsAreDistinct */, duplicateUpsertErrText)
+	mb.buildDistinctOnForArbiter(index, duplicateUpsertErrText)
+
+	// Build the predicate of a partial arbiter index over the insert columns
+	// before they are joined with the fetch columns of the same name.
+	insertPred := mb.buildPartialIndexPredicate(index, mb.outScope)
 
 	// Re-alias all INSERT columns so that they are accessible as if they were
 	// part of a special data source named "crdb_internal.excluded".
@@ -847,6 +849,12 @@ func (mb *mutationBuilder) buildInputForUpsert(
 			on = append(on, mb.b.factory.ConstructFiltersItem(condition))
 		}
 	}
+	if insertPred != nil {
+		on = append(on,
+			mb.b.factory.ConstructFiltersItem(insertPred),
+			mb.b.factory.ConstructFiltersItem(mb.buildPartialIndexPredicate(index, fetchScope)),
+		)
+	}
 
 	// Construct the left join.
 	mb.outScope.expr = mb.b.factory.ConstructLeftJoin(
@@ -935,8 +943,17 @@ func (mb *mutationBuilder) buildUpsert(returning tree.ReturningExprs) {
 	// Add any check constraint boolean columns to the input.
 	mb.addCheckConstraintCols()
 
+	// Add the partial index predicate columns for the upserted rows and, if
+	// existing rows are fetched, for the existing rows.
+	mb.addPartialIndexPredicateCols()
+	if mb.canaryColID != 0 {
+		mb.addPartialIndexDelPredicateCols()
+	}
+
 	mb.buildFKChecksForUpsert()
 
+	mb.buildTriggers(opt.UpsertOp)
+
 	private := mb.makeMutationPrivate(returning != nil)
 	mb.outScope.expr = mb.b.factory.ConstructUpsert(mb.outScope.expr, mb.checks, private)
 
@@ -1022,11 +1039,38 @@ func (mb *mutationBuilder) projectUpsertColumns() {
 	mb.outScope = projectionsScope
 }
 
-// ensureUniqueConflictCols tries to prove that the given set of column ordinals
-// correspond to the columns of at least one UNIQUE index on the target table.
-// If true, then ensureUniqueConflictCols returns the matching index. Otherwise,
-// it reports an error.
-func (mb *mutationBuilder) ensureUniqueConflictCols(conflictOrds util.FastIntSet) cat.Index {
+// arbiterIndex returns the ordinal of the UNIQUE index that is used to detect
+// conflicts for the given ON CONFLICT clause. The index is inferred in one of
+// two ways, following Postgres:
+//
+//   1. ON CONFLICT ON CONSTRAINT name: the unique index with the given name.
+//
+//   2. ON CONFLICT (cols) [WHERE pred]: a unique index whose lax key columns
+//      are exactly the conflict columns. A partial unique index is only a
+//      candidate if its predicate is implied by the arbiter predicate (see
+//      arbiterPredicateImplies). A non-partial index is preferred over a
+//      partial index.
+//
+// If no index can be inferred, or if the inference is ambiguous because there
+// are several matching partial indexes, then arbiterIndex reports an error.
+func (mb *mutationBuilder) arbiterIndex(onConflict *tree.OnConflict) cat.IndexOrdinal {
+	if onConflict.Constraint != "" {
+		for idx, idxCount := 0, mb.tab.IndexCount(); idx < idxCount; idx++ {
+			index := mb.tab.Index(idx)
+			if index.Name() != onConflict.Constraint {
+				continue
+			}
+			if !index.IsUnique() {
+				break
+			}
+			return idx
+		}
+		panic(pgerror.Newf(pgcode.UndefinedObject,
+			"constraint %q for table %q does not exist", onConflict.Constraint, mb.tab.Name()))
+	}
+
+	conflictOrds := mb.mapColumnNamesToOrdinals(onConflict.Columns)
+	partialIndex := -1
 	for idx, idxCount := 0, mb.tab.IndexCount(); idx < idxCount; idx++ {
 		index := mb.tab.Index(idx)
 
@@ -1040,14 +1084,162 @@ func (mb *mutationBuilder) ensureUniqueConflictCols(conflictOrds util.FastIntSet
 
 		// Determine whether the conflict columns match the columns in the lax key.
 		indexOrds := getIndexLaxKeyOrdinals(index)
-		if indexOrds.Equals(conflictOrds) {
-			return index
+		if !indexOrds.Equals(conflictOrds) {
+			continue
 		}
+
+		if _, isPartial := index.Predicate(); !isPartial {
+			return idx
+		}
+		if onConflict.ArbiterPredicate == nil || !mb.arbiterPredicateImplies(onConflict.ArbiterPredicate, index) {
+			continue
+		}
+		if partialIndex != -1 {
+			panic(errors.WithDetailf(pgerror.Newf(pgcode.InvalidColumnReference,
+				"there is no unique or exclusion constraint matching the ON CONFLICT specification"),
+				"partial unique indexes %q and %q both match the ON CONFLICT specification",
+				mb.tab.Index(partialIndex).Name(), index.Name()))
+		}
+		partialIndex = idx
+	}
+	if partialIndex != -1 {
+		return partialIndex
 	}
 	panic(pgerror.Newf(pgcode.InvalidColumnReference,
 		"there is no unique or exclusion constraint matching the ON CONFLICT specification"))
 }
 
+// arbiterPredicateImplies returns true if the given ON CONFLICT arbiter
+// predicate implies the predicate of the given partial index. Both predicates
+// are normalized and split into conjuncts; the implication is proven if every
+// conjunct of the index predicate is also a conjunct of the arbiter predicate.
+// This is conservative: some predicates that do imply the index predicate are
+// not recognized.
+func (mb *mutationBuilder) arbiterPredicateImplies(arbiterPred tree.Expr, index cat.Index) bool {
+	tableScope := mb.b.allocScope()
+	tableScope.appendColumnsFromTable(mb.md.TableMeta(mb.tabID), &mb.alias)
+
+	arbiter := mb.b.resolveAndBuildScalar(
+		arbiterPred, types.Bool, exprKindWhere, tree.RejectSpecial, tableScope,
+	)
+	var arbiterConjuncts []opt.ScalarExpr
+	arbiterConjuncts = appendConjuncts(arbiterConjuncts, arbiter)
+
+	var indexConjuncts []opt.ScalarExpr
+	indexConjuncts = appendConjuncts(indexConjuncts, mb.buildPartialIndexPredicate(index, tableScope))
+	for _, c := range indexConjuncts {
+		found := false
+		for _, a := range arbiterConjuncts {
+			if c == a {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// appendConjuncts appends the conjuncts of the given scalar expression to the
+// given list. A True expression has no conjuncts.
+func appendConjuncts(conjuncts []opt.ScalarExpr, e opt.ScalarExpr) []opt.ScalarExpr {
+	switch t := e.(type) {
+	case *memo.AndExpr:
+		return appendConjuncts(appendConjuncts(conjuncts, t.Left), t.Right)
+	case *memo.TrueExpr:
+		return conjuncts
+	}
+	return append(conjuncts, e)
+}
+
+// buildPartialIndexPredicate builds the predicate of the given index as a
+// scalar expression over the columns of the given scope, which must contain a
+// column named after each table column referenced by the predicate. It returns
+// nil if the index is not a partial index.
+func (mb *mutationBuilder) buildPartialIndexPredicate(index cat.Index, predScope *scope) opt.ScalarExpr {
+	predicate, ok := index.Predicate()
+	if !ok {
+		return nil
+	}
+
+	expr, err := parser.ParseExpr(predicate)
+	if err != nil {
+		panic(err)
+	}
+
+	texpr := predScope.resolveAndRequireType(expr, types.Bool)
+	return mb.b.buildScalar(texpr, predScope, nil, nil, nil)
+}
+
+// addPartialIndexPredicateFilters appends filters to the given join condition
+// that require both the inserted row and the existing row in scanScope to
+// satisfy the predicate of the given index, if it is a partial index. Rows
+// that are not in a partial index cannot conflict on that index.
+func (mb *mutationBuilder) addPartialIndexPredicateFilters(
+	on memo.FiltersExpr, index cat.Index, scanScope *scope,
+) memo.FiltersExpr {
+	insertPred := mb.buildPartialIndexPredicate(index, mb.outScope)
+	if insertPred == nil {
+		return on
+	}
+	return append(on,
+		mb.b.factory.ConstructFiltersItem(insertPred),
+		mb.b.factory.ConstructFiltersItem(mb.buildPartialIndexPredicate(index, scanScope)),
+	)
+}
+
+// buildDistinctOnForArbiter wraps the input in an UpsertDistinctOn operator
+// that ensures there are no duplicate input rows for the lax key columns of the
+// given arbiter index. NULL values are treated as distinct from one another. If
+// errorOnDup is non-empty, then duplicates raise an error with that text;
+// otherwise they are removed.
+//
+// If the arbiter is a partial index, then only rows that satisfy its predicate
+// can conflict with one another. In that case, a column that is true for those
+// rows and NULL for all others is added to the distinct columns, so that rows
+// outside of the index are never treated as duplicates.
+func (mb *mutationBuilder) buildDistinctOnForArbiter(index cat.Index, errorOnDup string) {
+	var conflictCols opt.ColSet
+	for i, n := 0, index.LaxKeyColumnCount(); i < n; i++ {
+		conflictCols.Add(mb.insertColID(index.Column(i).Ordinal))
+	}
+
+	pred := mb.buildPartialIndexPredicate(index, mb.outScope)
+	if pred == nil {
+		mb.outScope = mb.b.buildDistinctOn(
+			conflictCols, mb.outScope, true /* nullsAreDistinct */, errorOnDup)
+		return
+	}
+
+	projectionsScope := mb.outScope.replace()
+	projectionsScope.appendColumnsFromScope(mb.outScope)
+	predCol := mb.b.synthesizeColumn(
+		projectionsScope,
+		"arbiter_pred",
+		types.Bool,
+		nil, /* expr */
+		mb.b.factory.ConstructCase(
+			memo.TrueSingleton,
+			memo.ScalarListExpr{mb.b.factory.ConstructWhen(pred, memo.TrueSingleton)},
+			mb.b.factory.ConstructNull(types.Bool),
+		),
+	)
+	conflictCols.Add(predCol.id)
+	mb.b.constructProjectForScope(mb.outScope, projectionsScope)
+
+	distinctScope := mb.b.buildDistinctOn(
+		conflictCols, projectionsScope, true /* nullsAreDistinct */, errorOnDup)
+
+	// Remove the predicate column, which is always the last column.
+	outScope := distinctScope.replace()
+	outScope.appendColumnsFromScope(distinctScope)
+	outScope.cols = outScope.cols[:len(outScope.cols)-1]
+	mb.b.constructProjectForScope(distinctScope, outScope)
+	mb.outScope = outScope
+}
+
 // mapColumnNamesToOrdinals returns the set of ordinal positions within the
 // target table that correspond to the given names.
 func (mb *mutationBuilder) mapColumnNamesToOrdinals(names tree.NameList) util.FastIntSet {
@@ -114,12 +114,15 @@ type mutationBuilder struct {
 	// of evaluating partial index predicate expressions defined on the indexes
 	// of the target table. Its length is always equal to the number of partial
 	// indexes on the table.
-	// TODO(mgartner): We will likely need two sets of columns to support
-	// updates for partial indexes; one set to indicate current rows that need
-	// to be removed from the index, and the other to indicate updated rows that
-	// need to be added to the index.
 	indexPredicateOrds []scopeOrdinal
 
+	// indexPredicateDelOrds lists the outScope columns storing the boolean
+	// results of evaluating partial index predicate expressions on the existing
+	// values of rows fetched from the target table. They indicate existing rows
+	// that need to be removed from each partial index. Its length is always
+	// equal to the number of partial indexes on the table.
+	indexPredicateDelOrds []scopeOrdinal
+
 	// canaryColID is the ID of the column that is used to decide whether to
 	// insert or update each row. If the canary column's value is null, then it's
 	// an insert; otherwise it's an update.
@@ -167,7 +170,8 @@ func (mb *mutationBuilder) init(b *Builder, opName string, tab cat.Table, alias
 
 	// Allocate segmented array of scope column ordinals.
 	n := tab.DeletableColumnCount()
-	scopeOrds := make([]scopeOrdinal, n*4+tab.CheckCount()+partialIndexCount(tab))
+	numPartial := partialIndexCount(tab)
+	scopeOrds := make([]scopeOrdinal, n*4+tab.CheckCount()+numPartial*2)
 	for i := range scopeOrds {
 		scopeOrds[i] = -1
 	}
@@ -176,7 +180,8 @@ func (mb *mutationBuilder) init(b *Builder, opName string, tab cat.Table, alias
 	mb.updateOrds = scopeOrds[n*2 : n*3]
 	mb.upsertOrds = scopeOrds[n*3 : n*4]
 	mb.checkOrds = scopeOrds[n*4 : n*4+tab.CheckCount()]
-	mb.indexPredicateOrds = scopeOrds[n*4+tab.CheckCount():]
+	mb.indexPredicateOrds = scopeOrds[n*4+tab.CheckCount() : n*4+tab.CheckCount()+numPartial]
+	mb.indexPredicateDelOrds = scopeOrds[n*4+tab.CheckCount()+numPartial:]
 
 	// Add the table and its columns (including mutation columns) to metadata.
 	mb.tabID = mb.md.AddTable(tab, &mb.alias)
@@ -652,17 +657,15 @@ func (mb *mutationBuilder) roundDecimalValues(scopeOrds []scopeOrdinal, roundCom
 }
 
 // findRoundingFunction returns the builtin function overload needed to round
-// input values. This is only necessary for DECIMAL or DECIMAL[] types that have
-// limited precision, such as:
+// input values. This is only necessary for DECIMAL or DECIMAL array types that
+// have limited precision, such as:
 //
 //   DECIMAL(15, 1)
 //   DECIMAL(10, 3)[]
+//   DECIMAL(10, 3)[][]
 //
 // If an input decimal value has more than the required number of fractional
 // digits, it must be rounded before being inserted into these types.
-//
-// NOTE: CRDB does not allow nested array storage types, so only one level of
-// array nesting needs to be checked.
 func findRoundingFunction(typ *types.T, precision int) (*tree.FunctionProperties, *tree.Overload) {
 	if precision == 0 {
 		// Unlimited precision decimal target type never needs rounding.
@@ -677,6 +680,15 @@ func findRoundingFunction(typ *types.T, precision int) (*tree.FunctionProperties
 	if typ.Equivalent(types.DecimalArray) {
 		return props, &overloads[1]
 	}
+	if typ.Family() == types.ArrayFamily && typ.ArrayContents().Family() == types.ArrayFamily {
+		contents := typ.ArrayContents()
+		for contents.Family() == types.ArrayFamily {
+			contents = contents.ArrayContents()
+		}
+		if contents.Equivalent(types.Decimal) {
+			return props, &overloads[2]
+		}
+	}
 
 	// Not DECIMAL or DECIMAL[].
 	return nil, nil
@@ -716,6 +728,34 @@ func (mb *mutationBuilder) addCheckConstraintCols() {
 // booleans to determine whether or not to insert or delete a row in the partial
 // index.
 func (mb *mutationBuilder) addPartialIndexPredicateCols() {
+	mb.projectPartialIndexPredicateCols(mb.outScope, "indexpred", mb.indexPredicateOrds)
+}
+
+// addPartialIndexDelPredicateCols synthesizes a boolean output column for each
+// partial index defined on the target table, which evaluates the predicate on
+// the fetched values of the existing row. The execution code uses these
+// booleans to determine whether or not an existing row has an entry in the
+// partial index that must be deleted.
+func (mb *mutationBuilder) addPartialIndexDelPredicateCols() {
+	// Build a scope in which the table column names refer to the fetch columns.
+	fetchScope := mb.b.allocScope()
+	for i, n := 0, mb.tab.ColumnCount(); i < n; i++ {
+		if mb.fetchOrds[i] == -1 {
+			continue
+		}
+		col := mb.outScope.cols[mb.fetchOrds[i]]
+		col.name = mb.tab.Column(i).ColName()
+		fetchScope.cols = append(fetchScope.cols, col)
+	}
+	mb.projectPartialIndexPredicateCols(fetchScope, "indexdelpred", mb.indexPredicateDelOrds)
+}
+
+// projectPartialIndexPredicateCols projects a boolean column for each partial
+// index defined on the target table, evaluating its predicate on the columns of
+// predScope. The scope ordinals of the new columns are stored in predOrds.
+func (mb *mutationBuilder) projectPartialIndexPredicateCols(
+	predScope *scope, aliasPrefix string, predOrds []scopeOrdinal,
+) {
 	projectionsScope := mb.outScope.replace()
 	projectionsScope.appendColumnsFromScope(mb.outScope)
 
@@ -732,12 +772,12 @@ func (mb *mutationBuilder) addPartialIndexPredicateCols() {
 			panic(err)
 		}
 
-		alias := fmt.Sprintf("indexpred%d", ord+1)
-		texpr := mb.outScope.resolveAndRequireType(expr, types.Bool)
+		alias := fmt.Sprintf("%s%d", aliasPrefix, ord+1)
+		texpr := predScope.resolveAndRequireType(expr, types.Bool)
 		scopeCol := mb.b.addColumn(projectionsScope, alias, texpr)
 
-		mb.b.buildScalar(texpr, mb.outScope, projectionsScope, scopeCol, nil)
-		mb.indexPredicateOrds[ord] = scopeOrdinal(len(projectionsScope.cols) - 1)
+		mb.b.buildScalar(texpr, predScope, projectionsScope, scopeCol, nil)
+		predOrds[ord] = scopeOrdinal(len(projectionsScope.cols) - 1)
 
 		ord++
 	}
@@ -785,15 +825,16 @@ func (mb *mutationBuilder) makeMutationPrivate(needResults bool) *memo.MutationP
 	}
 
 	private := &memo.MutationPrivate{
-		Table:              mb.tabID,
-		InsertCols:         makeColList(mb.insertOrds),
-		FetchCols:          makeColList(mb.fetchOrds),
-		UpdateCols:         makeColList(mb.updateOrds),
-		CanaryCol:          mb.canaryColID,
-		CheckCols:          makeColList(mb.checkOrds),
-		IndexPredicateCols: makeColList(mb.indexPredicateOrds),
-		FKCascades:         mb.cascades,
-		FKFallback:         mb.fkFallback,
+		Table:                 mb.tabID,
+		InsertCols:            makeColList(mb.insertOrds),
+		FetchCols:             makeColList(mb.fetchOrds),
+		UpdateCols:            makeColList(mb.updateOrds),
+		CanaryCol:             mb.canaryColID,
+		CheckCols:             makeColList(mb.checkOrds),
+		IndexPredicateCols:    makeColList(mb.indexPredicateOrds),
+		IndexPredicateDelCols: makeColList(mb.indexPredicateDelOrds),
+		FKCascades:            mb.cascades,
+		FKFallback:            mb.fkFallback,
 	}
 
 	// If we didn't actually plan any checks or cascades, don't buffer the input.
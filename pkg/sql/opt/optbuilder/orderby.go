@@ -148,6 +148,7 @@ func (b *Builder) analyzeOrderByIndex(
 		expr := inScope.resolveType(colItem, types.Any)
 		outCol := b.addColumn(orderByScope, "" /* alias */, expr)
 		outCol.descending = desc
+		outCol.nullsReversed = col.NullsReversed
 	}
 }
 
@@ -168,6 +169,7 @@ func (b *Builder) analyzeOrderByArg(
 	for i := start; i < len(orderByScope.cols); i++ {
 		col := &orderByScope.cols[i]
 		col.descending = order.Direction == tree.Descending
+		col.nullsReversed = order.NullsOrder.IsReversed(order.Direction)
 	}
 }
 
@@ -182,7 +184,7 @@ func (b *Builder) buildOrderByArg(
 
 	// Add the new column to the ordering.
 	orderByScope.ordering = append(orderByScope.ordering,
-		opt.MakeOrderingColumn(orderByCol.id, orderByCol.descending),
+		opt.MakeOrderingColumnWithNulls(orderByCol.id, orderByCol.descending, orderByCol.nullsReversed),
 	)
 }
 
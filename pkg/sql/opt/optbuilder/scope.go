@@ -894,6 +894,11 @@ func (s *scope) VisitPre(expr tree.Expr) (recurse bool, newExpr tree.Expr) {
 		return s.VisitPre(vn)
 
 	case *tree.ColumnItem:
+		if s.builder.triggerRow != nil {
+			if val := s.builder.triggerRow.resolveColumn(t); val != nil {
+				return false, val
+			}
+		}
 		colI, err := t.Resolve(s.builder.ctx, s)
 		if err != nil {
 			panic(err)
@@ -901,7 +906,7 @@ func (s *scope) VisitPre(expr tree.Expr) (recurse bool, newExpr tree.Expr) {
 		return false, colI.(*scopeColumn)
 
 	case *tree.FuncExpr:
-		def, err := t.Func.Resolve(s.builder.semaCtx.SearchPath)
+		def, err := s.builder.resolveFunction(&t.Func)
 		if err != nil {
 			panic(err)
 		}
@@ -1301,6 +1306,10 @@ func analyzeWindowFrame(s *scope, windowDef *tree.WindowDef) error {
 				return pgerror.Newf(pgcode.Windowing,
 					"RANGE with offset PRECEDING/FOLLOWING requires exactly one ORDER BY column")
 			}
+			if o := windowDef.OrderBy[0]; o.NullsOrder.IsReversed(o.Direction) {
+				return pgerror.Newf(pgcode.FeatureNotSupported,
+					"RANGE with offset PRECEDING/FOLLOWING is not supported with %s", o.NullsOrder)
+			}
 			requiredType = windowDef.OrderBy[0].Expr.(tree.TypedExpr).ResolvedType()
 			if !types.IsAdditiveType(requiredType) {
 				return pgerror.Newf(pgcode.Windowing,
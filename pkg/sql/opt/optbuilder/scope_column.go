@@ -47,6 +47,11 @@ type scopeColumn struct {
 	// This field is only used for ordering columns.
 	descending bool
 
+	// nullsReversed indicates whether NULLs sort after non-NULL values in
+	// ascending order (or before them in descending order). This field is only
+	// used for ordering columns.
+	nullsReversed bool
+
 	// scalar is the scalar expression associated with this column. If it is nil,
 	// then the column is a passthrough from an inner scope or a table column.
 	scalar opt.ScalarExpr
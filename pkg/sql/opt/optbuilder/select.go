@@ -527,7 +527,28 @@ func (b *Builder) buildScan(
 		b.addComputedColsForTable(tabMeta)
 		b.addPartialIndexPredicatesForTable(tabMeta)
 
+		// Virtual computed columns are not stored, so the scan produces the
+		// columns they depend on instead, and a projection on top of the scan
+		// computes them.
+		var virtualProjections memo.ProjectionsExpr
+		var passthrough opt.ColSet
+		for i := range outScope.cols {
+			ord := getOrdinal(i)
+			colID := outScope.cols[i].id
+			if !tab.Column(ord).IsVirtualComputed() {
+				passthrough.Add(colID)
+				continue
+			}
+			item := b.factory.ConstructProjectionsItem(b.buildVirtualColExpr(tabMeta, ord), colID)
+			private.Cols.Remove(colID)
+			private.Cols.UnionWith(item.ScalarProps().OuterCols)
+			virtualProjections = append(virtualProjections, item)
+		}
+
 		outScope.expr = b.factory.ConstructScan(&private)
+		if len(virtualProjections) > 0 {
+			outScope.expr = b.factory.ConstructProject(outScope.expr, virtualProjections, passthrough)
+		}
 
 		if b.trackViewDeps {
 			dep := opt.ViewDep{DataSource: tab}
@@ -635,6 +656,24 @@ func (b *Builder) addComputedColsForTable(tabMeta *opt.TableMeta) {
 	}
 }
 
+// buildVirtualColExpr returns the scalar expression which computes the virtual
+// computed column with the given ordinal. Public columns reuse the expression
+// built by addComputedColsForTable, whereas mutation columns are built here.
+func (b *Builder) buildVirtualColExpr(tabMeta *opt.TableMeta, ord int) opt.ScalarExpr {
+	colID := tabMeta.MetaID.ColumnID(ord)
+	if expr, ok := tabMeta.ComputedCols[colID]; ok {
+		return expr
+	}
+	expr, err := parser.ParseExpr(tabMeta.Table.Column(ord).ComputedExprStr())
+	if err != nil {
+		panic(err)
+	}
+	tableScope := b.allocScope()
+	tableScope.appendColumnsFromTable(tabMeta, &tabMeta.Alias)
+	texpr := tableScope.resolveAndRequireType(expr, types.Any)
+	return b.buildScalar(texpr, tableScope, nil, nil, nil)
+}
+
 // addPartialIndexPredicatesForTable finds all partial indexes in the table and
 // adds their predicates to the table metadata (see
 // TableMeta.PartialIndexPredicates). The predicates are converted from strings
@@ -1249,10 +1288,15 @@ func (b *Builder) buildFromWithLateral(
 // validateAsOf ensures that any AS OF SYSTEM TIME timestamp is consistent with
 // that of the root statement.
 func (b *Builder) validateAsOf(asOf tree.AsOfClause) {
-	ts, err := tree.EvalAsOfTimestamp(b.ctx, asOf, b.semaCtx, b.evalCtx)
+	// Bounded staleness reads have been validated by the executor; here they
+	// are only checked against their minimum timestamp bound.
+	asOfSystemTime, err := tree.EvalAsOf(
+		b.ctx, asOf, b.semaCtx, b.evalCtx, true, /* allowBoundedStaleness */
+	)
 	if err != nil {
 		panic(err)
 	}
+	ts := asOfSystemTime.Timestamp
 
 	if b.semaCtx.AsOfTimestamp == nil {
 		panic(pgerror.Newf(pgcode.Syntax,
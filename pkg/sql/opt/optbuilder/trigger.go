@@ -0,0 +1,273 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/norm"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/props"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/errors"
+)
+
+// buildTriggers adds a cascade to mb.cascades for every row-level trigger on
+// the target table that can be fired by the mutation. The op is the type of
+// the mutation.
+//
+// The statements of the triggers are not built here: the execution engine
+// uses triggerBuilder to build and run them for each row of the buffered
+// mutation input, either after the mutation (like FK cascades) or, for BEFORE
+// triggers, before it.
+//
+// The cascade columns contain the values of the public columns of the table:
+//  - insert: NewValues contains the inserted values.
+//  - update: OldValues contains the fetched values and NewValues contains the
+//    updated values.
+//  - delete: OldValues contains the fetched values.
+//  - upsert: OldValues contains the fetched values followed by the canary
+//    column, and NewValues contains the values that are inserted followed by
+//    the values that are used if the row is updated.
+func (mb *mutationBuilder) buildTriggers(op opt.Operator) {
+	if mb.tab.TriggerCount() == 0 {
+		return
+	}
+	if mb.fkFallback {
+		panic(unimplemented.NewWithIssuef(28296,
+			"triggers are not supported with legacy foreign key checks and cascades"))
+	}
+
+	n := mb.tab.ColumnCount()
+	makeCols := func(ords ...[]scopeOrdinal) opt.ColList {
+		cols := make(opt.ColList, 0, n*len(ords))
+		for _, o := range ords {
+			for i := 0; i < n; i++ {
+				cols = append(cols, mb.scopeOrdToColID(o[i]))
+			}
+		}
+		return cols
+	}
+
+	// updateOrds contains the new values of updated rows; columns which are not
+	// updated keep their fetched values.
+	updateOrds := make([]scopeOrdinal, n)
+	if op == opt.UpdateOp || op == opt.UpsertOp {
+		for i := range updateOrds {
+			updateOrds[i] = mb.updateOrds[i]
+			if updateOrds[i] == -1 {
+				updateOrds[i] = mb.fetchOrds[i]
+			}
+		}
+	}
+
+	var oldCols, newCols opt.ColList
+	switch op {
+	case opt.InsertOp:
+		newCols = makeCols(mb.insertOrds)
+	case opt.UpdateOp:
+		oldCols = makeCols(mb.fetchOrds)
+		newCols = makeCols(updateOrds)
+	case opt.DeleteOp:
+		oldCols = makeCols(mb.fetchOrds)
+	case opt.UpsertOp:
+		// The update columns of an upsert are only used if the row exists, so
+		// they contain the new values of updated rows.
+		oldCols = append(makeCols(mb.fetchOrds), mb.canaryColID)
+		newCols = makeCols(mb.insertOrds, updateOrds)
+	default:
+		panic(errors.AssertionFailedf("unexpected mutation operator %s", op))
+	}
+
+	for i, cnt := 0, mb.tab.TriggerCount(); i < cnt; i++ {
+		trigger := mb.tab.Trigger(i)
+		var fires bool
+		switch op {
+		case opt.InsertOp:
+			fires = trigger.OnInsert
+		case opt.UpdateOp:
+			fires = trigger.OnUpdate
+		case opt.DeleteOp:
+			fires = trigger.OnDelete
+		case opt.UpsertOp:
+			fires = trigger.OnInsert || trigger.OnUpdate
+		}
+		if !fires {
+			continue
+		}
+		stmts, err := parser.Parse(trigger.Body)
+		if err != nil {
+			panic(errors.Wrapf(err, "invalid body for trigger %s", trigger.Name))
+		}
+		if mb.withID == 0 {
+			mb.withID = mb.b.factory.Memo().NextWithID()
+		}
+		mb.cascades = append(mb.cascades, memo.FKCascade{
+			FKName:    trigger.Name,
+			Builder:   newTriggerBuilder(mb.tab, trigger, op, stmts),
+			WithID:    mb.withID,
+			OldValues: oldCols,
+			NewValues: newCols,
+			Before:    trigger.Before,
+		})
+	}
+}
+
+// triggerBuilder is a memo.RowCascadeBuilder implementation for row-level
+// triggers. For each row of the mutation input, it builds the statements of
+// the trigger, in which the references to the columns of the NEW and OLD rows
+// are replaced with the values of the row. For example, with the trigger:
+//
+//   CREATE TRIGGER tr AFTER UPDATE ON t FOR EACH ROW
+//     AS 'INSERT INTO audit VALUES (OLD.k, OLD.v, NEW.v)'
+//
+// the statement
+//
+//   UPDATE t SET v = 10 WHERE k IN (1, 2)
+//
+// builds and runs the following statements after the rows are updated:
+//
+//   INSERT INTO audit VALUES (1, <old value of v for k=1>, 10)
+//   INSERT INTO audit VALUES (2, <old value of v for k=2>, 10)
+//
+type triggerBuilder struct {
+	tab     cat.Table
+	trigger cat.Trigger
+	// op is the type of the mutation which fires the trigger.
+	op    opt.Operator
+	stmts parser.Statements
+}
+
+var _ memo.RowCascadeBuilder = &triggerBuilder{}
+
+func newTriggerBuilder(
+	tab cat.Table, trigger cat.Trigger, op opt.Operator, stmts parser.Statements,
+) *triggerBuilder {
+	return &triggerBuilder{
+		tab:     tab,
+		trigger: trigger,
+		op:      op,
+		stmts:   stmts,
+	}
+}
+
+// Build is part of the memo.CascadeBuilder interface. Triggers are only built
+// for individual rows, using BuildRow.
+func (tb *triggerBuilder) Build(
+	ctx context.Context,
+	semaCtx *tree.SemaContext,
+	evalCtx *tree.EvalContext,
+	catalog cat.Catalog,
+	factoryI interface{},
+	binding opt.WithID,
+	bindingProps *props.Relational,
+	oldValues, newValues opt.ColList,
+) (memo.RelExpr, error) {
+	return nil, errors.AssertionFailedf("trigger %s must be built for each row", tb.trigger.Name)
+}
+
+// BuildRow is part of the memo.RowCascadeBuilder interface.
+func (tb *triggerBuilder) BuildRow(
+	ctx context.Context,
+	semaCtx *tree.SemaContext,
+	evalCtx *tree.EvalContext,
+	catalog cat.Catalog,
+	factoryI interface{},
+	oldValues, newValues tree.Datums,
+	i int,
+) (_ memo.RelExpr, err error) {
+	row := &triggerRow{tab: tb.tab, old: oldValues, new: newValues}
+	if tb.op == opt.UpsertOp {
+		// The canary column is NULL if the row is inserted.
+		n := tb.tab.ColumnCount()
+		if oldValues[n] == tree.DNull {
+			if !tb.trigger.OnInsert {
+				return nil, nil
+			}
+			row.old, row.new = nil, newValues[:n]
+		} else {
+			if !tb.trigger.OnUpdate {
+				return nil, nil
+			}
+			row.old, row.new = oldValues[:n], newValues[n:]
+		}
+	}
+	if i >= len(tb.stmts) {
+		return nil, nil
+	}
+
+	factory := factoryI.(*norm.Factory)
+	stmt := tb.stmts[i].AST
+	b := New(ctx, semaCtx, evalCtx, catalog, factory, stmt)
+	b.triggerRow = row
+
+	// Enact panic handling similar to Builder.Build().
+	defer func() {
+		if r := recover(); r != nil {
+			if ok, e := errorutil.ShouldCatch(r); ok {
+				err = e
+			} else {
+				panic(r)
+			}
+		}
+	}()
+
+	b.pushWithFrame()
+	outScope := b.buildStmtAtRoot(stmt, nil /* desiredTypes */, b.allocScope())
+	b.popWithFrame(outScope)
+	return outScope.expr, nil
+}
+
+// triggerRow contains the values of the row for which the statements of a
+// trigger are built.
+type triggerRow struct {
+	tab cat.Table
+	// old and new contain the values of the public columns of the table before
+	// and after the modification of the row. They are nil if the row did not
+	// exist before (insert) or after (delete) the modification.
+	old, new tree.Datums
+}
+
+// resolveColumn returns the value of a NEW.<col> or OLD.<col> column
+// reference, or nil if the given column does not refer to the NEW or OLD row.
+func (r *triggerRow) resolveColumn(c *tree.ColumnItem) tree.Expr {
+	if c.TableName == nil || c.TableName.NumParts != 1 {
+		return nil
+	}
+	var vals tree.Datums
+	switch rowName := c.TableName.Parts[0]; rowName {
+	case "new":
+		vals = r.new
+	case "old":
+		vals = r.old
+	default:
+		return nil
+	}
+	for i, n := 0, r.tab.ColumnCount(); i < n; i++ {
+		col := r.tab.Column(i)
+		if col.ColName() != c.ColumnName {
+			continue
+		}
+		if vals == nil || vals[i] == tree.DNull {
+			return &tree.CastExpr{Expr: tree.DNull, Type: col.DatumType(), SyntaxMode: tree.CastShort}
+		}
+		return vals[i]
+	}
+	panic(pgerror.Newf(pgcode.UndefinedColumn,
+		"record %q has no field %q", c.TableName.Parts[0], tree.ErrString(&c.ColumnName)))
+}
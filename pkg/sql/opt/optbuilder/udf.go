@@ -0,0 +1,247 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/errors"
+)
+
+// maxUDFInlineDepth is the maximum number of user-defined function calls that
+// can be nested inside each other's bodies. It prevents recursive functions
+// from being inlined forever.
+const maxUDFInlineDepth = 32
+
+// resolveFunction resolves the name of the given function reference to a
+// builtin or to a user-defined function.
+func (b *Builder) resolveFunction(
+	fn *tree.ResolvableFunctionReference,
+) (*tree.FunctionDefinition, error) {
+	return fn.ResolveWithResolver(b.ctx, b.semaCtx.SearchPath, b.semaCtx.FunctionResolver)
+}
+
+// buildUDF builds a call to a user-defined function by inlining its body.
+// Only function bodies of the form "SELECT <expr>" are supported. The
+// references to the parameters in the body are replaced with the arguments of
+// the call, and the resulting expression is built in place of the call.
+//
+// Arguments that are volatile cannot be duplicated, so each of them must be
+// referenced exactly once. If the function is strict, the body is wrapped in
+// a CASE expression that returns NULL if any of the arguments is NULL.
+//
+// See Builder.buildStmt for a description of the remaining input and
+// return values.
+func (b *Builder) buildUDF(
+	f *tree.FuncExpr,
+	def *tree.FunctionDefinition,
+	inScope, outScope *scope,
+	outCol *scopeColumn,
+	colRefs *opt.ColSet,
+) (out opt.ScalarExpr) {
+	// The body of the function can change at any time, so the memo cannot be
+	// reused.
+	b.DisableMemoReuse = true
+
+	// Views do not track their dependencies on functions, so a function could
+	// be dropped while a view still refers to it.
+	if b.insideViewDef {
+		panic(pgerror.Newf(pgcode.FeatureNotSupported,
+			"user-defined function %s cannot be used in a view definition", def.Name))
+	}
+
+	// The query of a CREATE TABLE AS statement is run again by the schema
+	// changer, which does not resolve function names the same way.
+	if b.insideCreateTableAs {
+		panic(pgerror.Newf(pgcode.FeatureNotSupported,
+			"user-defined function %s cannot be used in CREATE TABLE AS", def.Name))
+	}
+
+	if b.udfDepth >= maxUDFInlineDepth {
+		panic(pgerror.Newf(pgcode.ProgramLimitExceeded,
+			"cannot inline function %s: too many nested function calls", def.Name))
+	}
+	b.udfDepth++
+	defer func() { b.udfDepth-- }()
+
+	o := f.ResolvedOverload()
+	retType := f.ResolvedType()
+	args := make([]tree.TypedExpr, len(f.Exprs))
+	for i := range f.Exprs {
+		args[i] = f.Exprs[i].(tree.TypedExpr)
+		if o.Strict && args[i] == tree.DNull {
+			// A strict function returns NULL if any of its arguments is NULL.
+			out = b.factory.ConstructNull(retType)
+			return b.finishBuildScalar(f, out, inScope, outScope, outCol)
+		}
+	}
+
+	stmt, err := parser.ParseOne(o.Body)
+	if err != nil {
+		panic(err)
+	}
+	bodyExpr, err := funcBodyExpr(stmt.AST)
+	if err != nil {
+		panic(errors.NewAssertionErrorWithWrappedErrf(err, "invalid body for function %s", def.Name))
+	}
+	uses := make([]int, len(args))
+	body, err := substituteFuncParams(bodyExpr, o.Types.(tree.ArgTypes), args, uses)
+	if err != nil {
+		panic(err)
+	}
+
+	var nullCond tree.Expr
+	for i, arg := range args {
+		needsNullCheck := false
+		if o.Strict {
+			_, isDatum := arg.(tree.Datum)
+			needsNullCheck = !isDatum
+		}
+		if needsNullCheck {
+			uses[i]++
+			isNull := &tree.ComparisonExpr{
+				Operator: tree.IsNotDistinctFrom, Left: arg, Right: tree.DNull,
+			}
+			if nullCond == nil {
+				nullCond = isNull
+			} else {
+				nullCond = &tree.OrExpr{Left: nullCond, Right: isNull}
+			}
+		}
+		if uses[i] > 1 && isVolatileExpr(arg) {
+			panic(pgerror.Newf(pgcode.FeatureNotSupported,
+				"cannot inline function %s: volatile argument %d is referenced more than once",
+				def.Name, i+1))
+		}
+	}
+	if nullCond != nil {
+		body = &tree.CaseExpr{
+			Whens: []*tree.When{{Cond: nullCond, Val: tree.DNull}},
+			Else:  body,
+		}
+	}
+
+	texpr := inScope.resolveType(body, retType)
+	if !texpr.ResolvedType().Identical(retType) {
+		texpr = tree.NewTypedCastExpr(texpr, retType)
+	}
+	out = b.buildScalar(texpr, inScope, nil, nil, colRefs)
+	return b.finishBuildScalar(f, out, inScope, outScope, outCol)
+}
+
+// funcBodyExpr returns the expression of a function body of the form
+// "SELECT <expr>", or an error if the body has any other form.
+func funcBodyExpr(stmt tree.Statement) (tree.Expr, error) {
+	errBody := pgerror.New(pgcode.FeatureNotSupported,
+		"function body must be a SELECT statement with a single expression and no FROM clause")
+	errBody = errors.WithHint(errBody,
+		"use a scalar subquery to access tables, as in SELECT (SELECT ... FROM ...)")
+
+	sel, ok := stmt.(*tree.Select)
+	if !ok || sel.With != nil || sel.OrderBy != nil || sel.Limit != nil || sel.Locking != nil {
+		return nil, errBody
+	}
+	for {
+		paren, ok := sel.Select.(*tree.ParenSelect)
+		if !ok {
+			break
+		}
+		sel = paren.Select
+		if sel.With != nil || sel.OrderBy != nil || sel.Limit != nil || sel.Locking != nil {
+			return nil, errBody
+		}
+	}
+	clause, ok := sel.Select.(*tree.SelectClause)
+	if !ok || len(clause.Exprs) != 1 || clause.Distinct || clause.DistinctOn != nil ||
+		len(clause.From.Tables) != 0 || clause.Where != nil || clause.GroupBy != nil ||
+		clause.Having != nil || clause.Window != nil || clause.TableSelect {
+		return nil, errBody
+	}
+	switch t := clause.Exprs[0].Expr.(type) {
+	case *tree.UnresolvedName:
+		if t.Star {
+			return nil, errBody
+		}
+	case *tree.AllColumnsSelector:
+		return nil, errBody
+	}
+	return clause.Exprs[0].Expr, nil
+}
+
+// substituteFuncParams replaces the references to the parameters of a
+// function in expr with the corresponding arguments. Parameters can be
+// referenced by name or by position ($1, $2, ...). Note that parameter names
+// take precedence over the names of columns inside subqueries in the body.
+//
+// The number of references to each parameter is added to uses.
+func substituteFuncParams(
+	expr tree.Expr, params tree.ArgTypes, args []tree.TypedExpr, uses []int,
+) (tree.Expr, error) {
+	return tree.SimpleVisit(expr, func(expr tree.Expr) (recurse bool, newExpr tree.Expr, err error) {
+		switch t := expr.(type) {
+		case *tree.UnresolvedName:
+			if t.NumParts != 1 || t.Star {
+				break
+			}
+			for i := range params {
+				if params[i].Name != "" && params[i].Name == t.Parts[0] {
+					uses[i]++
+					return false, args[i], nil
+				}
+			}
+
+		case *tree.Placeholder:
+			i := int(t.Idx)
+			if i >= len(args) {
+				return false, nil, pgerror.Newf(pgcode.UndefinedParameter,
+					"there is no parameter %s", t)
+			}
+			uses[i]++
+			return false, args[i], nil
+		}
+		return true, expr, nil
+	})
+}
+
+// isVolatileExpr returns true if evaluating the given expression more than
+// once could produce different results. Subqueries are conservatively treated
+// as volatile.
+func isVolatileExpr(expr tree.TypedExpr) bool {
+	v := volatileExprVisitor{}
+	tree.WalkExprConst(&v, expr)
+	return v.volatile
+}
+
+type volatileExprVisitor struct {
+	volatile bool
+}
+
+var _ tree.Visitor = &volatileExprVisitor{}
+
+func (v *volatileExprVisitor) VisitPre(expr tree.Expr) (recurse bool, newExpr tree.Expr) {
+	if v.volatile {
+		return false, expr
+	}
+	switch t := expr.(type) {
+	case *tree.FuncExpr:
+		if o := t.ResolvedOverload(); o == nil || o.Volatility == tree.VolatilityVolatile {
+			v.volatile = true
+		}
+	case *tree.Subquery, *subquery:
+		v.volatile = true
+	}
+	return !v.volatile, expr
+}
+
+func (*volatileExprVisitor) VisitPost(expr tree.Expr) tree.Expr { return expr }
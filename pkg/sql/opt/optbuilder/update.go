@@ -327,8 +327,15 @@ func (mb *mutationBuilder) buildUpdate(returning tree.ReturningExprs) {
 
 	mb.addCheckConstraintCols()
 
+	// Add the partial index predicate columns for the updated rows and for the
+	// existing rows.
+	mb.addPartialIndexPredicateCols()
+	mb.addPartialIndexDelPredicateCols()
+
 	mb.buildFKChecksForUpdate()
 
+	mb.buildTriggers(opt.UpdateOp)
+
 	private := mb.makeMutationPrivate(returning != nil)
 	for _, col := range mb.extraAccessibleCols {
 		if col.id != 0 {
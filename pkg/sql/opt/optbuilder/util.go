@@ -536,6 +536,11 @@ func (b *Builder) resolveTableForMutation(
 			"%q does not resolve to a table", tree.ErrString(n)))
 	}
 
+	// We can't mutate materialized views.
+	if tab.IsMaterializedView() {
+		panic(pgerror.Newf(pgcode.WrongObjectType, "cannot mutate materialized view %q", tab.Name()))
+	}
+
 	if outerAlias != nil {
 		alias = *outerAlias
 	}
@@ -409,7 +409,9 @@ func (b *Builder) buildWindowOrdering(
 					b.buildScalar(e, inScope, nil, nil, nil),
 				)
 			}
-			ord = append(ord, opt.MakeOrderingColumn(col.id, t.Direction == tree.Descending))
+			ord = append(ord, opt.MakeOrderingColumnWithNulls(
+				col.id, t.Direction == tree.Descending, t.NullsOrder.IsReversed(t.Direction),
+			))
 		}
 	}
 	return ord
@@ -217,6 +217,7 @@ func newMetadata(compiled *lang.CompiledExpr, pkg string) *metadata {
 		"Statement":           {fullName: "tree.Statement", isInterface: true},
 		"Subquery":            {fullName: "tree.Subquery", isPointer: true, usePointerIntern: true},
 		"CreateTable":         {fullName: "tree.CreateTable", isPointer: true, usePointerIntern: true},
+		"CreateFunction":      {fullName: "tree.CreateFunction", isPointer: true, usePointerIntern: true},
 		"Constraint":          {fullName: "constraint.Constraint", isPointer: true, usePointerIntern: true},
 		"FuncProps":           {fullName: "tree.FunctionProperties", isPointer: true, usePointerIntern: true},
 		"FuncOverload":        {fullName: "tree.Overload", isPointer: true, usePointerIntern: true},
@@ -229,6 +230,7 @@ func newMetadata(compiled *lang.CompiledExpr, pkg string) *metadata {
 		"JoinMultiplicity":    {fullName: "props.JoinMultiplicity"},
 		"OpaqueMetadata":      {fullName: "opt.OpaqueMetadata", isInterface: true},
 		"JobCommand":          {fullName: "tree.JobCommand", passByVal: true},
+		"ScheduleCommand":     {fullName: "tree.ScheduleCommand", passByVal: true},
 		"IndexOrdinal":        {fullName: "cat.IndexOrdinal", passByVal: true},
 		"ViewDeps":            {fullName: "opt.ViewDeps", passByVal: true},
 		"LockingItem":         {fullName: "tree.LockingItem", isPointer: true},
@@ -19,24 +19,46 @@ import (
 
 // OrderingColumn is the ColumnID for a column that is part of an ordering,
 // except that it can be negated to indicate a descending ordering on that
-// column.
+// column. The nullsReversedFlag bit is set if NULLs are ordered opposite to the
+// default for the column's direction.
 type OrderingColumn int32
 
+// nullsReversedFlag is set on the absolute value of an OrderingColumn when
+// NULLs sort after all other values in an ascending ordering, or before all
+// other values in a descending ordering. By default, NULLs sort before all
+// other values.
+const nullsReversedFlag = 1 << 30
+
 // MakeOrderingColumn initializes an ordering column with a ColumnID and a flag
-// indicating whether the direction is descending.
+// indicating whether the direction is descending. NULLs are ordered in the
+// default way.
 func MakeOrderingColumn(id ColumnID, descending bool) OrderingColumn {
+	return MakeOrderingColumnWithNulls(id, descending, false /* nullsReversed */)
+}
+
+// MakeOrderingColumnWithNulls initializes an ordering column with a ColumnID, a
+// flag indicating whether the direction is descending, and a flag indicating
+// whether NULLs are ordered opposite to the default (i.e. last for ascending
+// orderings and first for descending orderings).
+func MakeOrderingColumnWithNulls(
+	id ColumnID, descending bool, nullsReversed bool,
+) OrderingColumn {
+	c := OrderingColumn(id)
+	if nullsReversed {
+		c |= nullsReversedFlag
+	}
 	if descending {
-		return OrderingColumn(-id)
+		return -c
 	}
-	return OrderingColumn(id)
+	return c
 }
 
 // ID returns the ColumnID for this OrderingColumn.
 func (c OrderingColumn) ID() ColumnID {
 	if c < 0 {
-		return ColumnID(-c)
+		c = -c
 	}
-	return ColumnID(c)
+	return ColumnID(c &^ nullsReversedFlag)
 }
 
 // Ascending returns true if the ordering on this column is ascending.
@@ -49,6 +71,16 @@ func (c OrderingColumn) Descending() bool {
 	return c < 0
 }
 
+// NullsReversed returns true if NULLs are ordered opposite to the default for
+// this column; that is, NULLs sort last if the ordering is ascending, and first
+// if it is descending.
+func (c OrderingColumn) NullsReversed() bool {
+	if c < 0 {
+		c = -c
+	}
+	return c&nullsReversedFlag != 0
+}
+
 func (c OrderingColumn) String() string {
 	var buf bytes.Buffer
 	c.Format(&buf)
@@ -63,6 +95,21 @@ func (c OrderingColumn) Format(buf *bytes.Buffer) {
 		buf.WriteByte('+')
 	}
 	fmt.Fprintf(buf, "%d", c.ID())
+	FormatNullsReversed(buf, c.Descending(), c.NullsReversed())
+}
+
+// FormatNullsReversed writes a suffix describing the NULL ordering of an
+// ordering column to the buffer, if it is not the default for the given
+// direction.
+func FormatNullsReversed(buf *bytes.Buffer, descending, nullsReversed bool) {
+	if !nullsReversed {
+		return
+	}
+	if descending {
+		buf.WriteString("(nulls-first)")
+	} else {
+		buf.WriteString("(nulls-last)")
+	}
 }
 
 // Ordering defines the order of rows provided or required by an operator. A
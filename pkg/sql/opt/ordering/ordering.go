@@ -222,6 +222,11 @@ func init() {
 		buildChildReqOrdering: controlJobsBuildChildReqOrdering,
 		buildProvidedOrdering: noProvidedOrdering,
 	}
+	funcMap[opt.ControlSchedulesOp] = funcs{
+		canProvideOrdering:    canNeverProvideOrdering,
+		buildChildReqOrdering: controlSchedulesBuildChildReqOrdering,
+		buildProvidedOrdering: noProvidedOrdering,
+	}
 	funcMap[opt.CancelQueriesOp] = funcs{
 		canProvideOrdering:    canNeverProvideOrdering,
 		buildChildReqOrdering: cancelQueriesBuildChildReqOrdering,
@@ -295,8 +300,8 @@ func remapProvided(provided opt.Ordering, fds *props.FuncDepSet, outCols opt.Col
 				result = make(opt.Ordering, i, len(provided))
 				copy(result, provided)
 			}
-			result = append(result, opt.MakeOrderingColumn(
-				remappedCol, provided[i].Descending(),
+			result = append(result, opt.MakeOrderingColumnWithNulls(
+				remappedCol, provided[i].Descending(), provided[i].NullsReversed(),
 			))
 		}
 		closure.Add(col)
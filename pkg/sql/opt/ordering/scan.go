@@ -91,7 +91,7 @@ func ScanPrivateCanProvide(
 			continue
 		}
 		reqCol := &required.Columns[right]
-		if !reqCol.Group.Contains(indexColID) {
+		if !reqCol.Group.Contains(indexColID) || indexCol.NullsReversed != reqCol.NullsReversed {
 			return false, false
 		}
 		// The directions of the index column and the required column impose either
@@ -140,7 +140,7 @@ func scanBuildProvided(expr memo.RelExpr, required *physical.OrderingChoice) opt
 			continue
 		}
 		direction := (indexCol.Descending != reverse) // != is bool XOR
-		provided = append(provided, opt.MakeOrderingColumn(colID, direction))
+		provided = append(provided, opt.MakeOrderingColumnWithNulls(colID, direction, indexCol.NullsReversed))
 	}
 
 	return trimProvided(provided, required, fds)
@@ -57,6 +57,15 @@ func controlJobsBuildChildReqOrdering(
 	return parent.(*memo.ControlJobsExpr).Props.Ordering
 }
 
+func controlSchedulesBuildChildReqOrdering(
+	parent memo.RelExpr, required *physical.OrderingChoice, childIdx int,
+) physical.OrderingChoice {
+	if childIdx != 0 {
+		return physical.OrderingChoice{}
+	}
+	return parent.(*memo.ControlSchedulesExpr).Props.Ordering
+}
+
 func cancelQueriesBuildChildReqOrdering(
 	parent memo.RelExpr, required *physical.OrderingChoice, childIdx int,
 ) physical.OrderingChoice {
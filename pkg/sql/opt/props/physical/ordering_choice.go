@@ -34,13 +34,16 @@ import (
 //   +(1|2)              ORDER BY a        | ORDER BY b
 //   +(1|2),+3           ORDER BY a,c      | ORDER BY b, c
 //   -(3|4),+5 opt(1,2)  ORDER BY c DESC,e | ORDER BY a,d DESC,b DESC,e | ...
+//   +1(nulls-last)      ORDER BY a NULLS LAST
 //
 // Each column in the ordering sequence forms the corresponding column of the
 // sort key, from most significant to least significant. Each column has a sort
 // direction, either ascending or descending. The relation is ordered by the
 // first column; rows that have the same value are then ordered by the second
 // column; rows that still have the same value are ordered by the third column,
-// and so on.
+// and so on. By default, NULL values sort before all other values in an
+// ascending column and after them in a descending column; a column can also
+// specify the reverse.
 //
 // Sometimes multiple columns in the relation have equivalent values. The
 // OrderingChoiceColumn stores these columns in a group; any of the columns in
@@ -76,7 +79,7 @@ type OrderingChoice struct {
 
 // OrderingColumnChoice specifies the set of columns which can form one of the
 // columns in the sort key, as well as the direction of that column (ascending
-// or descending).
+// or descending) and the position of NULLs.
 type OrderingColumnChoice struct {
 	// Group is a set of equivalent columns, any of which can be used to form a
 	// column in the sort key. After initial construction, Group is immutable.
@@ -86,11 +89,17 @@ type OrderingColumnChoice struct {
 	// Descending is true if the sort key column is ordered from highest to
 	// lowest. Otherwise, it's ordered from lowest to highest.
 	Descending bool
+
+	// NullsReversed is true if NULLs are ordered opposite to the default for the
+	// direction: after all other values if the column is ascending, and before
+	// them if it is descending.
+	NullsReversed bool
 }
 
 const (
 	colChoiceRegexStr = `(?:\((\d+(?:\|\d+)*)\))`
-	ordColRegexStr    = `^(?:(?:\+|\-)(?:(\d+)|` + colChoiceRegexStr + `))$`
+	nullsRegexStr     = `(\(nulls-(?:first|last)\))?`
+	ordColRegexStr    = `^(?:(?:\+|\-)(?:(\d+)|` + colChoiceRegexStr + `))` + nullsRegexStr + `$`
 	colListRegexStr   = `(\d+(?:,\d+)*)`
 	optRegexStr       = `^\s*([\S]+)?\s*(?:opt\(` + colListRegexStr + `\))?\s*$`
 )
@@ -104,6 +113,7 @@ var optRegex, ordColRegex *regexp.Regexp
 //   +1
 //   -(1|2),+3
 //   +(1|2),+3 opt(5,6)
+//   +1(nulls-last),-2(nulls-first)
 //
 // The input string is expected to be valid; ParseOrderingChoice will panic if
 // it is not.
@@ -142,11 +152,17 @@ func ParseOrderingChoice(s string) OrderingChoice {
 		//   +3:
 		//     matches[1]: 3
 		//     matches[2]: <empty>
+		//
+		//   +3(nulls-last):
+		//     matches[1]: 3
+		//     matches[2]: <empty>
+		//     matches[3]: (nulls-last)
 		ordColMatches := ordColRegex.FindStringSubmatch(ordColStr)
 
 		// First character is the direction indicator.
 		var colChoice OrderingColumnChoice
 		colChoice.Descending = strings.HasPrefix(ordColStr, "-")
+		colChoice.NullsReversed = len(ordColMatches[3]) != 0
 
 		if len(ordColMatches[1]) != 0 {
 			// Single column in equivalence group.
@@ -209,6 +225,7 @@ func (oc *OrderingChoice) FromOrdering(ord opt.Ordering) {
 	for i := range ord {
 		oc.Columns[i].Group.Add(ord[i].ID())
 		oc.Columns[i].Descending = ord[i].Descending()
+		oc.Columns[i].NullsReversed = ord[i].NullsReversed()
 	}
 }
 
@@ -221,8 +238,9 @@ func (oc *OrderingChoice) FromOrderingWithOptCols(ord opt.Ordering, optCols opt.
 	for i := range ord {
 		if !oc.Optional.Contains(ord[i].ID()) {
 			oc.Columns = append(oc.Columns, OrderingColumnChoice{
-				Group:      opt.MakeColSet(ord[i].ID()),
-				Descending: ord[i].Descending(),
+				Group:         opt.MakeColSet(ord[i].ID()),
+				Descending:    ord[i].Descending(),
+				NullsReversed: ord[i].NullsReversed(),
 			})
 		}
 	}
@@ -235,7 +253,7 @@ func (oc *OrderingChoice) ToOrdering() opt.Ordering {
 	ordering := make(opt.Ordering, len(oc.Columns))
 	for i := range oc.Columns {
 		col := &oc.Columns[i]
-		ordering[i] = opt.MakeOrderingColumn(col.AnyID(), col.Descending)
+		ordering[i] = opt.MakeOrderingColumnWithNulls(col.AnyID(), col.Descending, col.NullsReversed)
 	}
 	return ordering
 }
@@ -274,6 +292,7 @@ func (oc *OrderingChoice) ColSet() opt.ColSet {
 //
 //   <empty>           !implies +1
 //   +1                !implies -1            (direction mismatch)
+//   +1                !implies +1(nulls-last) (nulls order mismatch)
 //   +1                !implies +1,-2         (prefix matching not commutative)
 //   +1 opt(2)         !implies +1            (extra optional cols not allowed)
 //   +1 opt(2)         !implies +1 opt(3)
@@ -296,7 +315,7 @@ func (oc *OrderingChoice) Implies(other *OrderingChoice) bool {
 		leftCol, rightCol := &oc.Columns[left], &other.Columns[right]
 
 		switch {
-		case leftCol.Descending == rightCol.Descending && leftCol.Group.SubsetOf(rightCol.Group):
+		case leftCol.SameDirection(rightCol) && leftCol.Group.SubsetOf(rightCol.Group):
 			// The columns match.
 			left, right = left+1, right+1
 
@@ -317,7 +336,7 @@ func (oc *OrderingChoice) Intersects(other *OrderingChoice) bool {
 	for left, right := 0, 0; left < len(oc.Columns) && right < len(other.Columns); {
 		leftCol, rightCol := &oc.Columns[left], &other.Columns[right]
 		switch {
-		case leftCol.Descending == rightCol.Descending && leftCol.Group.Intersects(rightCol.Group):
+		case leftCol.SameDirection(rightCol) && leftCol.Group.Intersects(rightCol.Group):
 			// The columns match.
 			left, right = left+1, right+1
 
@@ -371,27 +390,30 @@ func (oc *OrderingChoice) Intersection(other *OrderingChoice) OrderingChoice {
 		leftCol, rightCol := &oc.Columns[left], &other.Columns[right]
 
 		switch {
-		case leftCol.Descending == rightCol.Descending && leftCol.Group.Intersects(rightCol.Group):
+		case leftCol.SameDirection(rightCol) && leftCol.Group.Intersects(rightCol.Group):
 			// The columns match.
 			result = append(result, OrderingColumnChoice{
-				Group:      leftCol.Group.Intersection(rightCol.Group),
-				Descending: leftCol.Descending,
+				Group:         leftCol.Group.Intersection(rightCol.Group),
+				Descending:    leftCol.Descending,
+				NullsReversed: leftCol.NullsReversed,
 			})
 			left, right = left+1, right+1
 
 		case leftCol.Group.Intersects(other.Optional):
 			// Left column is optional in the right set.
 			result = append(result, OrderingColumnChoice{
-				Group:      leftCol.Group.Intersection(other.Optional),
-				Descending: leftCol.Descending,
+				Group:         leftCol.Group.Intersection(other.Optional),
+				Descending:    leftCol.Descending,
+				NullsReversed: leftCol.NullsReversed,
 			})
 			left++
 
 		case rightCol.Group.Intersects(oc.Optional):
 			// Right column is optional in the left set.
 			result = append(result, OrderingColumnChoice{
-				Group:      rightCol.Group.Intersection(oc.Optional),
-				Descending: rightCol.Descending,
+				Group:         rightCol.Group.Intersection(oc.Optional),
+				Descending:    rightCol.Descending,
+				NullsReversed: rightCol.NullsReversed,
 			})
 			right++
 
@@ -451,13 +473,13 @@ func (oc *OrderingChoice) CanProjectCols(cs opt.ColSet) bool {
 
 // MatchesAt returns true if the ordering column at the given index in this
 // instance matches the given column. The column matches if its id is part of
-// the equivalence group and if it has the same direction.
+// the equivalence group and if it has the same direction and NULL ordering.
 func (oc *OrderingChoice) MatchesAt(index int, col opt.OrderingColumn) bool {
 	if oc.Optional.Contains(col.ID()) {
 		return true
 	}
 	choice := &oc.Columns[index]
-	if choice.Descending != col.Descending() {
+	if choice.Descending != col.Descending() || choice.NullsReversed != col.NullsReversed() {
 		return false
 	}
 	if !choice.Group.Contains(col.ID()) {
@@ -468,9 +490,15 @@ func (oc *OrderingChoice) MatchesAt(index int, col opt.OrderingColumn) bool {
 
 // AppendCol adds a new column to the end of the sequence of ordering columns
 // maintained by this instance. The new column has the given ID and direction as
-// the only ordering choice.
+// the only ordering choice, and orders NULLs in the default way.
 func (oc *OrderingChoice) AppendCol(id opt.ColumnID, descending bool) {
-	ordCol := OrderingColumnChoice{Descending: descending}
+	oc.AppendColWithNulls(id, descending, false /* nullsReversed */)
+}
+
+// AppendColWithNulls is like AppendCol, but also takes a flag indicating
+// whether NULLs are ordered opposite to the default for the direction.
+func (oc *OrderingChoice) AppendColWithNulls(id opt.ColumnID, descending, nullsReversed bool) {
+	ordCol := OrderingColumnChoice{Descending: descending, NullsReversed: nullsReversed}
 	ordCol.Group.Add(id)
 	oc.Optional.Remove(id)
 	oc.Columns = append(oc.Columns, ordCol)
@@ -672,7 +700,7 @@ func (oc OrderingChoice) PrefixIntersection(
 			return result, true
 		case prefix.Empty() && len(oc.Columns) > 0 && len(suffix) > 0 &&
 			oc.Columns[0].Group.Intersects(suffix[0].Group) &&
-			oc.Columns[0].Descending == suffix[0].Descending:
+			oc.Columns[0].SameDirection(&suffix[0]):
 			// <prefix> is empty, and <suffix> and <oc> agree on the first column, so
 			// emit that column, remove it from both, and loop.
 			newCol := oc.Columns[0]
@@ -709,7 +737,7 @@ func (oc *OrderingChoice) Equals(rhs *OrderingChoice) bool {
 		left := &oc.Columns[i]
 		y := &rhs.Columns[i]
 
-		if left.Descending != y.Descending {
+		if !left.SameDirection(y) {
 			return false
 		}
 		if !left.Group.Equals(y.Group) {
@@ -733,6 +761,7 @@ func (oc OrderingChoice) String() string {
 //   +(1|2)
 //   +(1|2),+3
 //   -(3|4),+5 opt(1,2)
+//   +1(nulls-last)
 //
 func (oc OrderingChoice) Format(buf *bytes.Buffer) {
 	for g := range oc.Columns {
@@ -761,6 +790,7 @@ func (oc OrderingChoice) Format(buf *bytes.Buffer) {
 		if count > 1 {
 			buf.WriteByte(')')
 		}
+		opt.FormatNullsReversed(buf, group.Descending, group.NullsReversed)
 
 		if g+1 != len(oc.Columns) {
 			buf.WriteByte(',')
@@ -785,3 +815,9 @@ func (oc *OrderingColumnChoice) AnyID() opt.ColumnID {
 	}
 	return id
 }
+
+// SameDirection returns true if the two column choices have the same direction
+// and order NULLs in the same way.
+func (oc *OrderingColumnChoice) SameDirection(other *OrderingColumnChoice) bool {
+	return oc.Descending == other.Descending && oc.NullsReversed == other.NullsReversed
+}
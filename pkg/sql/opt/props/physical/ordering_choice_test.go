@@ -11,6 +11,7 @@
 package physical_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/opt"
@@ -40,10 +41,20 @@ func TestOrderingChoice_ToOrdering(t *testing.T) {
 		{s: "+1", o: opt.Ordering{1}},
 		{s: "-1,+(2|3) opt(4,5)", o: opt.Ordering{-1, 2}},
 		{s: "+(1|2),-(3|4),+5", o: opt.Ordering{1, -3, 5}},
+		{
+			s: "+1(nulls-last),-(2|3)(nulls-first)",
+			o: opt.Ordering{
+				opt.MakeOrderingColumnWithNulls(1, false /* descending */, true /* nullsReversed */),
+				opt.MakeOrderingColumnWithNulls(2, true /* descending */, true /* nullsReversed */),
+			},
+		},
 	}
 
 	for _, tc := range testcases {
 		choice := physical.ParseOrderingChoice(tc.s)
+		if actual := choice.String(); actual != strings.TrimSpace(tc.s) {
+			t.Errorf("expected %s to round-trip, actual: %s", tc.s, actual)
+		}
 		ordering := choice.ToOrdering()
 		if len(ordering) != len(tc.o) {
 			t.Errorf("%s: expected %s, actual: %s", tc.s, tc.o, ordering)
@@ -94,6 +105,8 @@ func TestOrderingChoice_Implies(t *testing.T) {
 		{left: "+(1|2)", right: "+(1|2|3)", expected: true},
 		{left: "+(1|2),-4", right: "+(1|2|3),-(4|5)", expected: true},
 		{left: "+(1|2) opt(4)", right: "+(1|2|3) opt(4)", expected: true},
+		{left: "+1(nulls-last),-2", right: "+1(nulls-last)", expected: true},
+		{left: "-(1|2)(nulls-first)", right: "-(1|2|3)(nulls-first)", expected: true},
 
 		{left: "", right: "+1", expected: false},
 		{left: "+1", right: "-1", expected: false},
@@ -108,6 +121,9 @@ func TestOrderingChoice_Implies(t *testing.T) {
 		{left: "+(1|2),-(3|4)", right: "+(1|2),-(3|4),+5", expected: false},
 		{left: "+1", right: "+3 opt(1,2)", expected: false},
 		{left: "+3 opt(1,2)", right: "+1", expected: false},
+		{left: "+1", right: "+1(nulls-last)", expected: false},
+		{left: "-1(nulls-first)", right: "-1", expected: false},
+		{left: "+1(nulls-last)", right: "-1(nulls-first)", expected: false},
 	}
 
 	for _, tc := range testcases {
@@ -488,6 +504,7 @@ func TestOrderingChoice_Equals(t *testing.T) {
 		{left: "+1", right: "+2", expected: false},
 		{left: "+1,+2", right: "+2,+1", expected: false},
 		{left: "+1 opt(2)", right: "+1 opt(2,3)", expected: false},
+		{left: "+1(nulls-last)", right: "+1", expected: false},
 	}
 
 	for _, tc := range testcases {
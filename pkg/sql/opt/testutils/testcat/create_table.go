@@ -371,6 +371,7 @@ func (tc *Catalog) resolveFK(tab *Table, d *tree.ForeignKeyConstraintTableDef) {
 		matchMethod:              d.Match,
 		deleteAction:             d.Actions.Delete,
 		updateAction:             d.Actions.Update,
+		deferrability:            d.Deferrable,
 	}
 	tab.outboundFKs = append(tab.outboundFKs, fk)
 	targetTable.inboundFKs = append(targetTable.inboundFKs, fk)
@@ -402,6 +403,7 @@ func (tt *Table) addColumn(def *tree.ColumnTableDef) {
 	if def.Computed.Expr != nil {
 		s := serializeTableDefExpr(def.Computed.Expr)
 		col.ComputedExpr = &s
+		col.Virtual = def.Computed.Virtual
 	}
 
 	tt.Columns = append(tt.Columns, col)
@@ -430,6 +432,9 @@ func (tt *Table) addIndex(def *tree.IndexTableDef, typ indexType) *Index {
 	notNullIndex := true
 	for _, colDef := range def.Columns {
 		col := idx.addColumn(tt, string(colDef.Column), colDef.Direction, keyCol)
+		if colDef.NullsOrder.IsReversed(colDef.Direction) {
+			idx.Columns[len(idx.Columns)-1].NullsReversed = true
+		}
 
 		if typ == primaryIndex {
 			col.Nullable = false
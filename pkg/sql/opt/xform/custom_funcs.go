@@ -189,7 +189,7 @@ func (c *CustomFuncs) GenerateIndexScans(grp memo.RelExpr, scanPrivate *memo.Sca
 // GenerateConstrainedScans will further constrain the enumerated index scans
 // by trying to use the check constraints and computed columns that apply to the
 // table being scanned, as well as the partitioning defined for the index. See
-// comments above checkColumnFilters, computedColFilters, and
+// comments above checkColumnFilters, computedColFilters, virtualColFilters, and
 // partitionValuesFilters for more detail.
 func (c *CustomFuncs) GenerateConstrainedScans(
 	grp memo.RelExpr, scanPrivate *memo.ScanPrivate, explicitFilters memo.FiltersExpr,
@@ -202,6 +202,7 @@ func (c *CustomFuncs) GenerateConstrainedScans(
 	optionalFilters := c.checkConstraintFilters(scanPrivate.Table)
 	computedColFilters := c.computedColFilters(scanPrivate.Table, explicitFilters, optionalFilters)
 	optionalFilters = append(optionalFilters, computedColFilters...)
+	optionalFilters = append(optionalFilters, c.virtualColFilters(scanPrivate.Table, explicitFilters)...)
 
 	filterColumns := c.FilterOuterCols(explicitFilters)
 	filterColumns.UnionWith(c.FilterOuterCols(optionalFilters))
@@ -593,6 +594,65 @@ func (c *CustomFuncs) tryFoldComputedCol(
 	return false
 }
 
+// virtualColFilters generates filters which reference the virtual computed
+// columns of the given table, by replacing the computed column expressions
+// found in the given filters with references to the columns. Virtual columns
+// are not produced by scans of the primary index, so filters on them are
+// expressed in terms of their computed expressions (see
+// PushSelectIntoVirtualColumnProject). Consider the following example:
+//
+//   CREATE TABLE t (
+//     k INT PRIMARY KEY,
+//     j JSON,
+//     v STRING AS (j->>'a') VIRTUAL,
+//     INDEX (v)
+//   )
+//
+//   SELECT k FROM t WHERE (j->>'a') = 'foo'
+//
+// The filter v = 'foo' is generated, which allows the index on v to be
+// constrained. As with computedColFilters, the generated filters are only used
+// to constrain indexes; the original filters are still applied.
+func (c *CustomFuncs) virtualColFilters(
+	tabID opt.TableID, filters memo.FiltersExpr,
+) memo.FiltersExpr {
+	tabMeta := c.e.mem.Metadata().TableMeta(tabID)
+	var virtualCols map[opt.ScalarExpr]opt.ColumnID
+	for colID, expr := range tabMeta.ComputedCols {
+		if !tabMeta.Table.Column(tabID.ColumnOrdinal(colID)).IsVirtualComputed() {
+			continue
+		}
+		if virtualCols == nil {
+			virtualCols = make(map[opt.ScalarExpr]opt.ColumnID)
+		}
+		virtualCols[expr] = colID
+	}
+	if virtualCols == nil {
+		return nil
+	}
+
+	// Scalar expressions are interned, so a computed column expression found in
+	// a filter is the same expression as the one stored in the table metadata.
+	var replace func(e opt.Expr) opt.Expr
+	replace = func(e opt.Expr) opt.Expr {
+		if scalar, ok := e.(opt.ScalarExpr); ok {
+			if colID, ok := virtualCols[scalar]; ok {
+				return c.e.f.ConstructVariable(colID)
+			}
+		}
+		return c.e.f.Replace(e, replace)
+	}
+
+	var virtualColFilters memo.FiltersExpr
+	for i := range filters {
+		replaced := replace(filters[i].Condition).(opt.ScalarExpr)
+		if replaced != filters[i].Condition {
+			virtualColFilters = append(virtualColFilters, c.e.f.ConstructFiltersItem(replaced))
+		}
+	}
+	return virtualColFilters
+}
+
 // inBetweenFilters returns a set of filters that are required to cover all the
 // in-between spans given a set of partition values. This is required for
 // correctness reasons; although values are unlikely to exist between defined
@@ -901,12 +961,17 @@ func (c *CustomFuncs) initIdxConstraintForIndex(
 	// slice cannot be reused, as Instance.Init can use it in the constraint.
 	md := c.e.mem.Metadata()
 	index := md.Table(tabID).Index(indexOrd)
-	columns := make([]opt.OrderingColumn, index.LaxKeyColumnCount())
+	columns := make([]opt.OrderingColumn, 0, index.LaxKeyColumnCount())
 	var notNullCols opt.ColSet
-	for i := range columns {
+	for i := 0; i < index.LaxKeyColumnCount(); i++ {
 		col := index.Column(i)
+		if col.NullsReversed {
+			// Constraint spans assume that NULLs sort before all other values, so
+			// we can't constrain this column or any column after it.
+			break
+		}
 		colID := tabID.ColumnID(col.Ordinal)
-		columns[i] = opt.MakeOrderingColumn(colID, col.Descending)
+		columns = append(columns, opt.MakeOrderingColumn(colID, col.Descending))
 		if !col.IsNullable() {
 			notNullCols.Add(colID)
 		}
@@ -981,11 +1046,17 @@ func (c *CustomFuncs) allInvIndexConstraints(
 //   2. The constraints are not tight (see props.Scalar.TightConstraints).
 //   3. Any of the filter's constraints start with the first index column.
 //
+// The index can never be constrained if NULLs in its first column are ordered
+// opposite to the default, since constraint spans assume that NULLs sort before
+// all other values.
 func (c *CustomFuncs) canMaybeConstrainIndex(
 	filters memo.FiltersExpr, tabID opt.TableID, indexOrd int,
 ) bool {
 	md := c.e.mem.Metadata()
 	index := md.Table(tabID).Index(indexOrd)
+	if index.Column(0).NullsReversed {
+		return false
+	}
 
 	for i := range filters {
 		filterProps := filters[i].ScalarProps()
@@ -1244,6 +1315,10 @@ func (c *CustomFuncs) GenerateMergeJoins(
 			// sort. This would not useful now since we don't support streaming sorts.
 			continue
 		}
+		if orderingHasReversedNulls(o[:n]) {
+			// The merge joiner requires NULLs to be ordered in the default way.
+			continue
+		}
 
 		if remainingFilters == nil {
 			remainingFilters = memo.ExtractRemainingJoinFilters(on, leftEq, rightEq)
@@ -1273,6 +1348,17 @@ func (c *CustomFuncs) GenerateMergeJoins(
 	}
 }
 
+// orderingHasReversedNulls returns true if any column in the ordering orders
+// NULLs opposite to the default for its direction.
+func orderingHasReversedNulls(o opt.Ordering) bool {
+	for i := range o {
+		if o[i].NullsReversed() {
+			return true
+		}
+	}
+	return false
+}
+
 // GenerateLookupJoins looks at the possible indexes and creates lookup join
 // expressions in the current group. A lookup join can be created when the ON
 // condition has equality constraints on a prefix of the index columns.
@@ -2353,7 +2439,8 @@ func (c *CustomFuncs) GenerateStreamingGroupBy(
 
 			if intraIdx < len(intraOrd.Columns) &&
 				intraOrd.Columns[intraIdx].Group.Contains(oCol) &&
-				intraOrd.Columns[intraIdx].Descending == o[oIdx].Descending() {
+				intraOrd.Columns[intraIdx].Descending == o[oIdx].Descending() &&
+				intraOrd.Columns[intraIdx].NullsReversed == o[oIdx].NullsReversed() {
 				// Column matches the one in the ordering.
 				intraIdx++
 				continue
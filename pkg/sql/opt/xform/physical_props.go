@@ -69,6 +69,8 @@ func BuildChildPhysicalProps(
 		childProps.Presentation = parent.(*memo.AlterTableRelocateExpr).Props.Presentation
 	case opt.ControlJobsOp:
 		childProps.Presentation = parent.(*memo.ControlJobsExpr).Props.Presentation
+	case opt.ControlSchedulesOp:
+		childProps.Presentation = parent.(*memo.ControlSchedulesExpr).Props.Presentation
 	case opt.CancelQueriesOp:
 		childProps.Presentation = parent.(*memo.CancelQueriesExpr).Props.Presentation
 	case opt.CancelSessionsOp:
@@ -303,8 +303,9 @@ func (oc *optCatalog) dataSourceForDesc(
 	desc *sqlbase.ImmutableTableDescriptor,
 	name *cat.DataSourceName,
 ) (cat.DataSource, error) {
-	if desc.IsTable() {
-		// Tables require invalidation logic for cached wrappers.
+	if desc.IsTable() || desc.MaterializedView() {
+		// Tables require invalidation logic for cached wrappers. Materialized
+		// views store their results like tables and are scanned like tables.
 		return oc.dataSourceForTable(ctx, flags, desc, name)
 	}
 
@@ -602,6 +603,7 @@ func newOptTable(
 			match:             fk.Match,
 			deleteAction:      fk.OnDelete,
 			updateAction:      fk.OnUpdate,
+			deferrability:     fkDeferrability(fk),
 		})
 	}
 	for i := range ot.desc.InboundFKs {
@@ -616,6 +618,7 @@ func newOptTable(
 			match:             fk.Match,
 			deleteAction:      fk.OnDelete,
 			updateAction:      fk.OnUpdate,
+			deferrability:     fkDeferrability(fk),
 		})
 	}
 
@@ -725,6 +728,11 @@ func (ot *optTable) IsVirtualTable() bool {
 	return false
 }
 
+// IsMaterializedView is part of the cat.Table interface.
+func (ot *optTable) IsMaterializedView() bool {
+	return ot.desc.MaterializedView()
+}
+
 // ColumnCount is part of the cat.Table interface.
 func (ot *optTable) ColumnCount() int {
 	return len(ot.desc.Columns)
@@ -825,6 +833,24 @@ func (ot *optTable) InboundForeignKey(i int) cat.ForeignKeyConstraint {
 	return &ot.inboundFKs[i]
 }
 
+// TriggerCount is part of the cat.Table interface.
+func (ot *optTable) TriggerCount() int {
+	return len(ot.desc.Triggers)
+}
+
+// Trigger is part of the cat.Table interface.
+func (ot *optTable) Trigger(i int) cat.Trigger {
+	t := &ot.desc.Triggers[i]
+	return cat.Trigger{
+		Name:     t.Name,
+		Before:   t.ActionTime == sqlbase.TableDescriptor_Trigger_BEFORE,
+		OnInsert: t.OnInsert,
+		OnUpdate: t.OnUpdate,
+		OnDelete: t.OnDelete,
+		Body:     t.Body,
+	}
+}
+
 // lookupColumnOrdinal returns the ordinal of the column with the given ID. A
 // cache makes the lookup O(1).
 func (ot *optTable) lookupColumnOrdinal(colID sqlbase.ColumnID) (int, error) {
@@ -964,9 +990,10 @@ func (oi *optIndex) Column(i int) cat.IndexColumn {
 	if i < length {
 		ord, _ := oi.tab.lookupColumnOrdinal(oi.desc.ColumnIDs[i])
 		return cat.IndexColumn{
-			Column:     oi.tab.Column(ord),
-			Ordinal:    ord,
-			Descending: oi.desc.ColumnDirections[i] == sqlbase.IndexDescriptor_DESC,
+			Column:        oi.tab.Column(ord),
+			Ordinal:       ord,
+			Descending:    oi.desc.ColumnDirections[i] == sqlbase.IndexDescriptor_DESC,
+			NullsReversed: oi.desc.ColumnNullsReversedAt(i),
 		}
 	}
 
@@ -1190,6 +1217,8 @@ type optForeignKeyConstraint struct {
 	match        sqlbase.ForeignKeyReference_Match
 	deleteAction sqlbase.ForeignKeyReference_Action
 	updateAction sqlbase.ForeignKeyReference_Action
+
+	deferrability tree.ConstraintDeferrability
 }
 
 var _ cat.ForeignKeyConstraint = &optForeignKeyConstraint{}
@@ -1261,6 +1290,24 @@ func (fk *optForeignKeyConstraint) UpdateReferenceAction() tree.ReferenceAction
 	return sqlbase.ForeignKeyReferenceActionType[fk.updateAction]
 }
 
+// Deferrability is part of the cat.ForeignKeyConstraint interface.
+func (fk *optForeignKeyConstraint) Deferrability() tree.ConstraintDeferrability {
+	return fk.deferrability
+}
+
+// fkDeferrability returns whether the checks of the given foreign key
+// constraint can be deferred, and whether they are deferred by default.
+func fkDeferrability(fk *sqlbase.ForeignKeyConstraint) tree.ConstraintDeferrability {
+	switch {
+	case fk.InitiallyDeferred:
+		return tree.DeferrableInitiallyDeferred
+	case fk.Deferrable:
+		return tree.DeferrableInitiallyImmediate
+	default:
+		return tree.NotDeferrable
+	}
+}
+
 // optVirtualTable is similar to optTable but is used with virtual tables.
 type optVirtualTable struct {
 	desc *sqlbase.ImmutableTableDescriptor
@@ -1416,6 +1463,11 @@ func (ot *optVirtualTable) IsVirtualTable() bool {
 	return true
 }
 
+// IsMaterializedView is part of the cat.Table interface.
+func (ot *optVirtualTable) IsMaterializedView() bool {
+	return false
+}
+
 // ColumnCount is part of the cat.Table interface.
 func (ot *optVirtualTable) ColumnCount() int {
 	// Virtual tables expose an extra (bogus) PK column.
@@ -1518,6 +1570,16 @@ func (ot *optVirtualTable) InboundForeignKey(i int) cat.ForeignKeyConstraint {
 	panic("no FKs")
 }
 
+// TriggerCount is part of the cat.Table interface.
+func (ot *optVirtualTable) TriggerCount() int {
+	return 0
+}
+
+// Trigger is part of the cat.Table interface.
+func (ot *optVirtualTable) Trigger(i int) cat.Trigger {
+	panic("no triggers")
+}
+
 type optDummyVirtualPKColumn struct{}
 
 var _ cat.Column = optDummyVirtualPKColumn{}
@@ -1582,6 +1644,11 @@ func (optDummyVirtualPKColumn) ComputedExprStr() string {
 	return ""
 }
 
+// IsVirtualComputed is part of the cat.Column interface.
+func (optDummyVirtualPKColumn) IsVirtualComputed() bool {
+	return false
+}
+
 // optVirtualIndex is a dummy implementation of cat.Index for the indexes
 // reported by a virtual table. The index assumes that table column 0 is a dummy
 // PK column.
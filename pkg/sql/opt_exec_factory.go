@@ -452,6 +452,7 @@ func (ef *execFactory) ConstructMergeJoin(
 		// 0,1,2,3..
 		node.mergeJoinOrdering[i].ColIdx = i
 		node.mergeJoinOrdering[i].Direction = leftOrdering[i].Direction
+		node.mergeJoinOrdering[i].NullsReversed = leftOrdering[i].NullsReversed
 	}
 
 	// Set up node.props, which tells the distsql planner to maintain the
@@ -1406,6 +1407,8 @@ func (ef *execFactory) ConstructUpdate(
 	returnColOrdSet exec.TableColumnOrdinalSet,
 	checks exec.CheckOrdinalSet,
 	passthrough sqlbase.ResultColumns,
+	partialIndexPutCols int,
+	partialIndexDelCols int,
 	allowAutoCommit bool,
 	skipFKChecks bool,
 ) (exec.Node, error) {
@@ -1482,10 +1485,12 @@ func (ef *execFactory) ConstructUpdate(
 				Cols:         ru.FetchCols,
 				Mapping:      ru.FetchColIDtoRowIndex,
 			},
-			sourceSlots:    sourceSlots,
-			updateValues:   make(tree.Datums, len(ru.UpdateCols)),
-			updateColsIdx:  updateColsIdx,
-			numPassthrough: len(passthrough),
+			sourceSlots:         sourceSlots,
+			updateValues:        make(tree.Datums, len(ru.UpdateCols)),
+			updateColsIdx:       updateColsIdx,
+			numPassthrough:      len(passthrough),
+			partialIndexPutCols: partialIndexPutCols,
+			partialIndexDelCols: partialIndexDelCols,
 		},
 	}
 
@@ -1561,6 +1566,8 @@ func (ef *execFactory) ConstructUpsert(
 	updateColOrdSet exec.TableColumnOrdinalSet,
 	returnColOrdSet exec.TableColumnOrdinalSet,
 	checks exec.CheckOrdinalSet,
+	partialIndexPutCols int,
+	partialIndexDelCols int,
 	allowAutoCommit bool,
 	skipFKChecks bool,
 ) (exec.Node, error) {
@@ -1640,8 +1647,10 @@ func (ef *execFactory) ConstructUpsert(
 	*ups = upsertNode{
 		source: input.(planNode),
 		run: upsertRun{
-			checkOrds:  checks,
-			insertCols: ri.InsertCols,
+			checkOrds:           checks,
+			partialIndexPutCols: partialIndexPutCols,
+			partialIndexDelCols: partialIndexDelCols,
+			insertCols:          ri.InsertCols,
 			tw: optTableUpserter{
 				ri:            ri,
 				alloc:         ef.planner.alloc,
@@ -1848,6 +1857,7 @@ func (ef *execFactory) ConstructCreateView(
 	ifNotExists bool,
 	replace bool,
 	temporary bool,
+	materialized bool,
 	viewQuery string,
 	columns sqlbase.ResultColumns,
 	deps opt.ViewDeps,
@@ -1877,17 +1887,25 @@ func (ef *execFactory) ConstructCreateView(
 	}
 
 	return &createViewNode{
-		viewName:    tree.Name(viewName),
-		ifNotExists: ifNotExists,
-		replace:     replace,
-		temporary:   temporary,
-		viewQuery:   viewQuery,
-		dbDesc:      schema.(*optSchema).desc,
-		columns:     columns,
-		planDeps:    planDeps,
+		viewName:     tree.Name(viewName),
+		ifNotExists:  ifNotExists,
+		replace:      replace,
+		temporary:    temporary,
+		materialized: materialized,
+		viewQuery:    viewQuery,
+		dbDesc:       schema.(*optSchema).desc,
+		columns:      columns,
+		planDeps:     planDeps,
 	}, nil
 }
 
+// ConstructCreateFunction is part of the exec.Factory interface.
+func (ef *execFactory) ConstructCreateFunction(
+	schema cat.Schema, cf *tree.CreateFunction, body string,
+) (exec.Node, error) {
+	return &createFunctionNode{n: cf, dbDesc: schema.(*optSchema).desc, body: body}, nil
+}
+
 // ConstructSequenceSelect is part of the exec.Factory interface.
 func (ef *execFactory) ConstructSequenceSelect(sequence cat.Sequence) (exec.Node, error) {
 	return ef.planner.SequenceSelectNode(sequence.(*optSequence).desc)
@@ -1910,6 +1928,18 @@ func (ef *execFactory) ConstructErrorIfRows(
 	}, nil
 }
 
+// ConstructDeferrableFKCheck is part of the exec.Factory interface.
+func (ef *execFactory) ConstructDeferrableFKCheck(
+	input exec.Node, check *exec.DeferrableFKCheck, mkErr func(tree.Datums) error,
+) (exec.Node, error) {
+	return &deferrableFKCheckNode{
+		plan:    input.(planNode),
+		check:   makeDeferredFKCheck(check),
+		mkErr:   mkErr,
+		keyCols: check.KeyCols,
+	}, nil
+}
+
 // ConstructOpaque is part of the exec.Factory interface.
 func (ef *execFactory) ConstructOpaque(metadata opt.OpaqueMetadata) (exec.Node, error) {
 	o, ok := metadata.(*opaqueMetadata)
@@ -1977,6 +2007,16 @@ func (ef *execFactory) ConstructControlJobs(
 	}, nil
 }
 
+// ConstructControlSchedules is part of the exec.Factory interface.
+func (ef *execFactory) ConstructControlSchedules(
+	command tree.ScheduleCommand, input exec.Node,
+) (exec.Node, error) {
+	return &controlSchedulesNode{
+		rows:    input.(planNode),
+		command: command,
+	}, nil
+}
+
 // ConstructCancelQueries is part of the exec.Factory interface.
 func (ef *execFactory) ConstructCancelQueries(input exec.Node, ifExists bool) (exec.Node, error) {
 	return &cancelQueriesNode{
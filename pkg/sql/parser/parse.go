@@ -355,17 +355,22 @@ func newDecimal(prec, scale int32) (*types.T, error) {
 }
 
 // arrayOf creates a type alias for an array of the given element type and fixed
-// bounds. The bounds are currently ignored.
+// bounds. Each bound adds a dimension to the array; the sizes of the bounds are
+// currently ignored.
 func arrayOf(
 	ref tree.ResolvableTypeReference, bounds []int32,
 ) (tree.ResolvableTypeReference, error) {
-	// If the reference is a statically known type, then return an array type,
-	// rather than an array type reference.
-	if typ, ok := tree.GetStaticallyKnownType(ref); ok {
-		if err := types.CheckArrayElementType(typ); err != nil {
-			return nil, err
+	for range bounds {
+		// If the reference is a statically known type, then return an array
+		// type, rather than an array type reference.
+		if typ, ok := tree.GetStaticallyKnownType(ref); ok {
+			if err := types.CheckArrayElementType(typ); err != nil {
+				return nil, err
+			}
+			ref = types.MakeArray(typ)
+		} else {
+			ref = &tree.ArrayTypeReference{ElementType: ref}
 		}
-		return types.MakeArray(typ), nil
 	}
-	return &tree.ArrayTypeReference{ElementType: ref}, nil
+	return ref, nil
 }
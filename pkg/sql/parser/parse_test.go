@@ -91,6 +91,7 @@ func TestParse(t *testing.T) {
 		{`CREATE INDEX ON a (b) INTERLEAVE IN PARENT c.d (e)`},
 		{`CREATE INDEX ON a (b ASC, c DESC)`},
 		{`CREATE INDEX ON a (b NULLS FIRST, c ASC NULLS FIRST, d DESC NULLS LAST)`},
+		{`CREATE INDEX ON a (b NULLS LAST, c ASC NULLS LAST, d DESC NULLS FIRST)`},
 		{`CREATE INDEX IF NOT EXISTS i ON a (b) WHERE c > 3`},
 		{`CREATE UNIQUE INDEX a ON b (c)`},
 		{`CREATE UNIQUE INDEX a ON b (c) STORING (d)`},
@@ -184,6 +185,10 @@ func TestParse(t *testing.T) {
 		{`CREATE TABLE a (b INT8, c STRING, FOREIGN KEY (b, c) REFERENCES other (x, y) MATCH FULL)`},
 		{`CREATE TABLE a (b INT8, c STRING, FOREIGN KEY (b, c) REFERENCES other)`},
 		{`CREATE TABLE a (b INT8, c STRING, FOREIGN KEY (b, c) REFERENCES other (x, y))`},
+		{`CREATE TABLE a (b INT8, FOREIGN KEY (b) REFERENCES other DEFERRABLE)`},
+		{`CREATE TABLE a (b INT8, FOREIGN KEY (b) REFERENCES other (x) ON DELETE CASCADE DEFERRABLE INITIALLY DEFERRED)`},
+		{`CREATE TABLE a (b INT8 REFERENCES other (x) DEFERRABLE)`},
+		{`CREATE TABLE a (b INT8 REFERENCES other (x) DEFERRABLE INITIALLY DEFERRED)`},
 		{`CREATE TABLE a (b INT8, c STRING, CONSTRAINT s FOREIGN KEY (b, c) REFERENCES other (x, y))`},
 		{`CREATE TABLE a (b INT8, c STRING, CONSTRAINT s FOREIGN KEY (b, c) REFERENCES other (x, y) MATCH FULL)`},
 		{`CREATE TABLE a (b INT8, c STRING, CONSTRAINT s FOREIGN KEY (b, c) REFERENCES other (x, y) MATCH FULL ON UPDATE SET NULL)`},
@@ -235,6 +240,7 @@ func TestParse(t *testing.T) {
 		{`CREATE TABLE a.b (b INT8)`},
 		{`CREATE TABLE IF NOT EXISTS a (b INT8)`},
 		{`CREATE TABLE a (b INT8 AS (a + b) STORED)`},
+		{`CREATE TABLE a (b INT8 AS (a + b) VIRTUAL)`},
 		{`CREATE TABLE view (view INT8)`},
 
 		{`CREATE TABLE a (b INT8 CONSTRAINT c PRIMARY KEY)`},
@@ -311,6 +317,11 @@ func TestParse(t *testing.T) {
 		{`CREATE VIEW a (x, y) AS VALUES (1, 'one'), (2, 'two')`},
 		{`CREATE VIEW a AS TABLE b`},
 		{`CREATE TEMPORARY VIEW a AS SELECT b`},
+		{`CREATE MATERIALIZED VIEW a AS SELECT * FROM b`},
+		{`CREATE MATERIALIZED VIEW IF NOT EXISTS a AS SELECT * FROM b`},
+		{`CREATE MATERIALIZED VIEW a (x, y) AS SELECT c, d FROM b`},
+		{`REFRESH MATERIALIZED VIEW a.b`},
+		{`REFRESH MATERIALIZED VIEW CONCURRENTLY a.b`},
 
 		{`CREATE SEQUENCE a`},
 		{`EXPLAIN CREATE SEQUENCE a`},
@@ -349,11 +360,30 @@ func TestParse(t *testing.T) {
 		{`ANALYZE t`},
 		{`ANALYZE db.sc.t`},
 
+		{`CREATE FUNCTION f() RETURNS INT8 AS 'SELECT 1'`},
+		{`CREATE FUNCTION a.b.f(INT8, s STRING) RETURNS STRING LANGUAGE sql IMMUTABLE STRICT AS 'SELECT s'`},
+		{`CREATE OR REPLACE FUNCTION f(x INT8) RETURNS INT8 STABLE CALLED ON NULL INPUT AS 'SELECT x + 1'`},
+		{`CREATE FUNCTION f(x INT8) RETURNS INT8 VOLATILE RETURNS NULL ON NULL INPUT AS 'SELECT x'`},
+		{`CREATE FUNCTION f(x my.typ) RETURNS my.typ AS 'SELECT x'`},
+		{`DROP FUNCTION f`},
+		{`DROP FUNCTION f()`},
+		{`DROP FUNCTION IF EXISTS a.f(INT8, x STRING), g CASCADE`},
+		{`DROP FUNCTION f(INT8) RESTRICT`},
+
+		{`CREATE TRIGGER tr AFTER INSERT ON t FOR EACH ROW AS 'INSERT INTO audit VALUES (new.a)'`},
+		{`CREATE TRIGGER tr BEFORE UPDATE OR DELETE ON a.b.t FOR EACH ROW AS 'SELECT old.a; SELECT new.a'`},
+		{`CREATE TRIGGER tr AFTER INSERT OR UPDATE OR DELETE ON t FOR EACH ROW AS ''`},
+		{`DROP TRIGGER tr ON t`},
+		{`DROP TRIGGER IF EXISTS tr ON a.t CASCADE`},
+		{`DROP TRIGGER tr ON t RESTRICT`},
 		{`CREATE TYPE a AS ENUM ()`},
 		{`CREATE TYPE a AS ENUM ('a')`},
 		{`CREATE TYPE a AS ENUM ('a', 'b', 'c')`},
 		{`CREATE TYPE a.b AS ENUM ('a', 'b', 'c')`},
 		{`CREATE TYPE a.b.c AS ENUM ('a', 'b', 'c')`},
+		{`CREATE TYPE a AS ()`},
+		{`CREATE TYPE a AS (b INT8)`},
+		{`CREATE TYPE a.b AS (c INT8, d STRING[], e b.f)`},
 
 		{`DROP TYPE a`},
 		{`DROP TYPE a, b, c`},
@@ -413,6 +443,9 @@ func TestParse(t *testing.T) {
 		{`DROP VIEW IF EXISTS a, b RESTRICT`},
 		{`DROP VIEW a.b CASCADE`},
 		{`DROP VIEW a, b CASCADE`},
+		{`DROP MATERIALIZED VIEW a, b`},
+		{`DROP MATERIALIZED VIEW IF EXISTS a`},
+		{`DROP MATERIALIZED VIEW a.b CASCADE`},
 		{`DROP SEQUENCE a`},
 		{`EXPLAIN DROP SEQUENCE a`},
 		{`DROP SEQUENCE a.b`},
@@ -622,6 +655,25 @@ func TestParse(t *testing.T) {
 		{`DEALLOCATE a`},
 		{`DEALLOCATE ALL`},
 
+		{`DECLARE a CURSOR FOR SELECT 1`},
+		{`DECLARE a BINARY CURSOR FOR SELECT 1`},
+		{`DECLARE a INSENSITIVE NO SCROLL CURSOR WITH HOLD FOR SELECT * FROM t ORDER BY x`},
+		{`DECLARE a ASENSITIVE SCROLL CURSOR FOR SELECT 1`},
+		{`FETCH 1 a`},
+		{`FETCH 10 a`},
+		{`FETCH -3 a`},
+		{`FETCH ALL a`},
+		{`FETCH BACKWARD ALL a`},
+		{`FETCH ABSOLUTE 5 a`},
+		{`FETCH RELATIVE -2 a`},
+		{`FETCH FIRST a`},
+		{`FETCH LAST a`},
+		{`MOVE 1 a`},
+		{`MOVE ALL a`},
+		{`MOVE ABSOLUTE 2 a`},
+		{`CLOSE a`},
+		{`CLOSE ALL`},
+
 		// Tables are the default, but can also be specified with
 		// GRANT x ON TABLE y. However, the stringer does not output TABLE.
 		{`GRANT SELECT ON TABLE foo TO root`},
@@ -682,6 +734,10 @@ func TestParse(t *testing.T) {
 		{`INSERT INTO a VALUES (1) ON CONFLICT (a, b) DO UPDATE SET a = 1`},
 		{`INSERT INTO a VALUES (1) ON CONFLICT (a) DO UPDATE SET a = 1, b = excluded.a`},
 		{`INSERT INTO a VALUES (1) ON CONFLICT (a) DO UPDATE SET a = 1 WHERE b > 2`},
+		{`INSERT INTO a VALUES (1) ON CONFLICT (a) WHERE b > 2 DO NOTHING`},
+		{`INSERT INTO a VALUES (1) ON CONFLICT (a) WHERE b > 2 DO UPDATE SET a = 1 WHERE b < 4`},
+		{`INSERT INTO a VALUES (1) ON CONFLICT ON CONSTRAINT a_key DO NOTHING`},
+		{`INSERT INTO a VALUES (1) ON CONFLICT ON CONSTRAINT a_key DO UPDATE SET a = 1`},
 		{`INSERT INTO a VALUES (1) ON CONFLICT (a) DO UPDATE SET a = DEFAULT`},
 		{`INSERT INTO a VALUES (1) ON CONFLICT (a) DO UPDATE SET (a, b) = (SELECT 1, 2)`},
 		{`INSERT INTO a VALUES (1) ON CONFLICT (a) DO UPDATE SET (a, b) = (SELECT 1, 2) RETURNING a, b`},
@@ -724,6 +780,7 @@ func TestParse(t *testing.T) {
 		{`SELECT 'Deutsch' COLLATE de`},
 		{`SELECT a @> b`},
 		{`SELECT a <@ b`},
+		{`SELECT a -|- b`},
 		{`SELECT a ? b`},
 		{`SELECT a ?| b`},
 		{`SELECT a ?& b`},
@@ -958,6 +1015,9 @@ func TestParse(t *testing.T) {
 		{`SELECT a FROM t ORDER BY a NULLS FIRST`},
 		{`SELECT a FROM t ORDER BY a ASC NULLS FIRST`},
 		{`SELECT a FROM t ORDER BY a DESC NULLS LAST`},
+		{`SELECT a FROM t ORDER BY a NULLS LAST`},
+		{`SELECT a FROM t ORDER BY a ASC NULLS LAST`},
+		{`SELECT a FROM t ORDER BY a DESC NULLS FIRST`},
 
 		{`SELECT 1 FROM t GROUP BY a`},
 		{`SELECT 1 FROM t GROUP BY a, b`},
@@ -1115,6 +1175,10 @@ func TestParse(t *testing.T) {
 		{`SET TRANSACTION PRIORITY HIGH`},
 		{`SET TRANSACTION ISOLATION LEVEL SERIALIZABLE, PRIORITY HIGH`},
 
+		{`SET CONSTRAINTS ALL DEFERRED`},
+		{`SET CONSTRAINTS ALL IMMEDIATE`},
+		{`SET CONSTRAINTS a, b DEFERRED`},
+
 		{`SET TRACING = off`},
 		{`EXPLAIN SET TRACING = off`},
 		{`SET TRACING = 'cluster', 'kv'`},
@@ -1271,6 +1335,11 @@ func TestParse(t *testing.T) {
 		{`COPY t FROM STDIN`},
 		{`COPY t (a, b, c) FROM STDIN`},
 		{`COPY crdb_internal.file_upload FROM STDIN WITH destination = 'filename'`},
+		{`COPY t TO STDOUT`},
+		{`COPY t (a, b, c) TO STDOUT`},
+		{`COPY (SELECT a, b FROM t ORDER BY a) TO STDOUT`},
+		{`COPY (VALUES (1, 'a')) TO STDOUT WITH BINARY`},
+		{`COPY t TO STDOUT WITH CSV HEADER DELIMITER '|' NULL 'n/a'`},
 
 		{`ALTER TABLE a SPLIT AT VALUES (1)`},
 		{`EXPLAIN ALTER TABLE a SPLIT AT VALUES (1)`},
@@ -1452,6 +1521,15 @@ func TestParse(t *testing.T) {
 		{`BACKUP DATABASE foo TO ($1, $2)`},
 		{`BACKUP DATABASE foo TO ($1, $2) INCREMENTAL FROM 'baz'`},
 
+		{`BACKUP TABLE foo INTO 'bar'`},
+		{`BACKUP DATABASE foo INTO 'bar' AS OF SYSTEM TIME '1' WITH revision_history`},
+		{`BACKUP DATABASE foo INTO ($1, $2)`},
+		{`BACKUP TABLE foo INTO LATEST IN 'bar'`},
+		{`BACKUP DATABASE foo INTO LATEST IN ($1, $2) WITH revision_history`},
+		{`BACKUP TABLE foo INTO 'subdir' IN 'bar'`},
+		{`BACKUP TABLE foo INTO $1 IN $2`},
+		{`EXPLAIN BACKUP TABLE foo INTO LATEST IN 'bar'`},
+
 		{`RESTORE TABLE foo FROM 'bar'`},
 		{`EXPLAIN RESTORE TABLE foo FROM 'bar'`},
 		{`RESTORE TABLE foo FROM $1`},
@@ -1470,8 +1548,37 @@ func TestParse(t *testing.T) {
 		{`RESTORE DATABASE foo FROM ($1, $2), ($3, $4)`},
 		{`RESTORE DATABASE foo FROM ($1, $2), ($3, $4) AS OF SYSTEM TIME '1'`},
 
+		{`RESTORE TABLE foo FROM 'subdir' IN 'bar'`},
+		{`RESTORE DATABASE foo FROM $1 IN ($2, $3) AS OF SYSTEM TIME '1'`},
+		{`RESTORE TABLE foo FROM 'LATEST' IN 'bar' WITH into_db = 'baz'`},
+		{`SHOW BACKUPS IN 'bar'`},
+		{`SHOW BACKUPS IN $1`},
+		{`SHOW BACKUP 'subdir' IN 'bar'`},
+		{`SHOW BACKUP $1 IN $2 WITH foo = 'bar'`},
+		{`EXPLAIN SHOW BACKUPS IN 'bar'`},
+
 		{`BACKUP TABLE foo TO 'bar' WITH key1, key2 = 'value'`},
 		{`RESTORE TABLE foo FROM 'bar' WITH key1, key2 = 'value'`},
+		{`BACKUP TABLE foo TO 'bar' WITH kms = ('aws:///a', $1)`},
+		{`RESTORE TABLE foo FROM 'bar' WITH kms = 'aws:///a'`},
+		{`SHOW BACKUP 'bar' WITH kms = ('aws:///a', 'aws:///b')`},
+		{`CREATE SCHEDULE FOR BACKUP INTO 'bar' WITH kms = ('aws:///a', $1) RECURRING '@daily'`},
+
+		{`CREATE SCHEDULE FOR BACKUP INTO 'bar' RECURRING '@daily'`},
+		{`CREATE SCHEDULE 'my schedule' FOR BACKUP INTO 'bar' RECURRING '@daily'`},
+		{`CREATE SCHEDULE FOR BACKUP TABLE foo INTO 'bar' RECURRING '@hourly' FULL BACKUP '@daily'`},
+		{`CREATE SCHEDULE FOR BACKUP DATABASE foo, baz INTO ($1, $2) WITH revision_history RECURRING $3 FULL BACKUP ALWAYS`},
+		{`CREATE SCHEDULE FOR BACKUP INTO 'bar' RECURRING '@daily' WITH SCHEDULE OPTIONS first_run = 'now', on_previous_running = 'skip'`},
+		{`EXPLAIN CREATE SCHEDULE FOR BACKUP INTO 'bar' RECURRING '@daily'`},
+
+		{`PAUSE SCHEDULES SELECT a`},
+		{`RESUME SCHEDULES SELECT a`},
+		{`DROP SCHEDULES SELECT a`},
+		{`EXPLAIN PAUSE SCHEDULES SELECT a`},
+		{`SHOW SCHEDULES`},
+		{`SHOW SCHEDULE 123`},
+		{`SHOW SCHEDULE $1`},
+		{`EXPLAIN SHOW SCHEDULES`},
 
 		{`IMPORT TABLE foo CREATE USING 'nodelocal://0/some/file' CSV DATA ('path/to/some/file', $1) WITH temp = 'path/to/temp'`},
 		{`EXPLAIN IMPORT TABLE foo CREATE USING 'nodelocal://0/some/file' CSV DATA ('path/to/some/file', $1) WITH temp = 'path/to/temp'`},
@@ -1501,6 +1608,9 @@ func TestParse(t *testing.T) {
 		// {`CREATE CHANGEFEED FOR TABLE foo PARTITION bar, baz INTO 'sink'`},
 		// {`CREATE CHANGEFEED FOR DATABASE foo INTO 'sink'`},
 		{`CREATE CHANGEFEED FOR TABLE foo INTO 'sink' WITH bar = 'baz'`},
+		{`CREATE CHANGEFEED AS SELECT * FROM foo`},
+		{`CREATE CHANGEFEED INTO 'sink' AS SELECT a, b + 1 AS c FROM db.foo WHERE b > 0`},
+		{`CREATE CHANGEFEED INTO 'sink' WITH resolved AS SELECT f.a FROM foo AS f WHERE f.s = 'shipped'`},
 
 		// Regression for #15926
 		{`SELECT * FROM ((t1 NATURAL JOIN t2 WITH ORDINALITY AS o1)) WITH ORDINALITY AS o2`},
@@ -1540,6 +1650,12 @@ func TestParse2(t *testing.T) {
 			`CREATE DATABASE a ENCODING = 'foo'`},
 		{`CREATE DATABASE a TEMPLATE = template0`,
 			`CREATE DATABASE a TEMPLATE = 'template0'`},
+		{`CREATE TABLE a (b INT8, FOREIGN KEY (b) REFERENCES other INITIALLY DEFERRED)`,
+			`CREATE TABLE a (b INT8, FOREIGN KEY (b) REFERENCES other DEFERRABLE INITIALLY DEFERRED)`},
+		{`CREATE TABLE a (b INT8, FOREIGN KEY (b) REFERENCES other DEFERRABLE INITIALLY IMMEDIATE)`,
+			`CREATE TABLE a (b INT8, FOREIGN KEY (b) REFERENCES other DEFERRABLE)`},
+		{`CREATE TABLE a (b INT8, FOREIGN KEY (b) REFERENCES other INITIALLY IMMEDIATE)`,
+			`CREATE TABLE a (b INT8, FOREIGN KEY (b) REFERENCES other)`},
 		{`CREATE DATABASE a TEMPLATE = invalid`,
 			`CREATE DATABASE a TEMPLATE = 'invalid'`},
 		{`CREATE TABLE a (b INT) WITH (fillfactor=100)`,
@@ -1553,6 +1669,10 @@ func TestParse2(t *testing.T) {
 		{`CREATE TABLE a (UNIQUE INDEX (b) PARTITION BY LIST (c) (PARTITION d VALUES IN (1)))`,
 			`CREATE TABLE a (UNIQUE (b) PARTITION BY LIST (c) (PARTITION d VALUES IN (1)))`},
 		{`CREATE INDEX ON a (b) COVERING (c)`, `CREATE INDEX ON a (b) STORING (c)`},
+		{`CREATE FUNCTION f(x int, double precision) RETURNS int LANGUAGE SQL AS $$SELECT x$$`,
+			`CREATE FUNCTION f(x INT8, FLOAT8) RETURNS INT8 LANGUAGE sql AS 'SELECT x'`},
+		{`CREATE FUNCTION f() RETURNS int AS 'SELECT 1' LANGUAGE 'sql' IMMUTABLE`,
+			`CREATE FUNCTION f() RETURNS INT8 LANGUAGE sql IMMUTABLE AS 'SELECT 1'`},
 		{`CREATE INDEX ON a (b) INCLUDE (c)`, `CREATE INDEX ON a (b) STORING (c)`},
 
 		{`CREATE INDEX a ON b USING GIN (c)`,
@@ -1595,6 +1715,9 @@ func TestParse2(t *testing.T) {
 		{`SELECT CAST(1 AS "timestamp")`, `SELECT CAST(1 AS TIMESTAMP)`},
 		{`SELECT CAST(1 AS _int8)`, `SELECT CAST(1 AS INT8[])`},
 		{`SELECT CAST(1 AS "_int8")`, `SELECT CAST(1 AS INT8[])`},
+		{`CREATE TABLE a (b INT[][], c INT[1][2], d INT ARRAY[1][2], e STRING[][][])`,
+			`CREATE TABLE a (b INT8[][], c INT8[][], d INT8[][], e STRING[][][])`},
+		{`SELECT '{{1}}'::INT[][]`, `SELECT '{{1}}'::INT8[][]`},
 		{`SELECT SERIAL8 'foo', 'foo'::SERIAL8`, `SELECT INT8 'foo', 'foo'::INT8`},
 
 		{`SELECT 'a'::TIMESTAMP(3)`, `SELECT 'a'::TIMESTAMP(3)`},
@@ -2000,6 +2123,22 @@ $function$`,
 		{`DEALLOCATE PREPARE ALL`,
 			`DEALLOCATE ALL`},
 
+		{`DECLARE a NO SCROLL CURSOR WITHOUT HOLD FOR SELECT 1`,
+			`DECLARE a NO SCROLL CURSOR FOR SELECT 1`},
+		{`FETCH a`, `FETCH 1 a`},
+		{`FETCH FROM a`, `FETCH 1 a`},
+		{`FETCH IN a`, `FETCH 1 a`},
+		{`FETCH NEXT a`, `FETCH 1 a`},
+		{`FETCH NEXT FROM a`, `FETCH 1 a`},
+		{`FETCH PRIOR IN a`, `FETCH -1 a`},
+		{`FETCH FORWARD a`, `FETCH 1 a`},
+		{`FETCH FORWARD 5 FROM a`, `FETCH 5 a`},
+		{`FETCH BACKWARD 5 a`, `FETCH -5 a`},
+		{`FETCH FORWARD ALL IN a`, `FETCH ALL a`},
+		{`FETCH FIRST FROM a`, `FETCH FIRST a`},
+		{`MOVE NEXT a`, `MOVE 1 a`},
+		{`MOVE FORWARD ALL FROM a`, `MOVE ALL a`},
+
 		{`CANCEL JOB a`, `CANCEL JOBS VALUES (a)`},
 		{`EXPLAIN CANCEL JOB a`, `EXPLAIN CANCEL JOBS VALUES (a)`},
 		{`RESUME JOB a`, `RESUME JOBS VALUES (a)`},
@@ -2022,6 +2161,8 @@ $function$`,
 		{`RESTORE DATABASE foo FROM bar`,
 			`RESTORE DATABASE foo FROM 'bar'`},
 		{`BACKUP DATABASE foo TO ($1)`, `BACKUP DATABASE foo TO $1`},
+		{`RESTORE TABLE foo FROM LATEST IN 'bar'`, `RESTORE TABLE foo FROM 'LATEST' IN 'bar'`},
+		{`SHOW BACKUP LATEST IN 'bar'`, `SHOW BACKUP 'LATEST' IN 'bar'`},
 
 		{`RESTORE DATABASE foo FROM ($1)`, `RESTORE DATABASE foo FROM $1`},
 		{`RESTORE DATABASE foo FROM ($1), ($2)`, `RESTORE DATABASE foo FROM $1, $2`},
@@ -2105,6 +2246,14 @@ $function$`,
 			`ALTER INDEX t@i CONFIGURE ZONE USING "foo.bar" = yay`},
 		{`ALTER INDEX i CONFIGURE ZONE USING foo.bar = yay`,
 			`ALTER INDEX i CONFIGURE ZONE USING "foo.bar" = yay`},
+		{`COPY t TO STDOUT CSV`, `COPY t TO STDOUT WITH CSV`},
+		{`COPY t TO STDOUT (FORMAT csv, HEADER, DELIMITER ';')`,
+			`COPY t TO STDOUT WITH CSV HEADER DELIMITER ';'`},
+		{`COPY t TO STDOUT WITH (FORMAT text, HEADER false, NULL '')`,
+			`COPY t TO STDOUT WITH NULL ''`},
+		{`COPY (TABLE t) TO STDOUT WITH (FORMAT binary)`,
+			`COPY (TABLE t) TO STDOUT WITH BINARY`},
+
 		{`ALTER INDEX i CONFIGURE ZONE USING foo = COPY FROM PARENT`,
 			`ALTER INDEX i CONFIGURE ZONE USING foo = COPY FROM PARENT`},
 
@@ -2140,9 +2289,17 @@ $function$`,
 			`BACKUP TABLE foo TO 'bar' WITH key1, key2 = 'value'`},
 		{`RESTORE foo FROM 'bar' WITH key1, key2 = 'value'`,
 			`RESTORE TABLE foo FROM 'bar' WITH key1, key2 = 'value'`},
+		{`BACKUP foo TO 'bar' WITH kms = ('aws:///a')`,
+			`BACKUP TABLE foo TO 'bar' WITH kms = 'aws:///a'`},
 
 		{`CREATE CHANGEFEED FOR foo INTO 'sink'`, `CREATE CHANGEFEED FOR TABLE foo INTO 'sink'`},
 
+		{`CREATE SCHEDULE foo FOR BACKUP bar INTO 'baz' WITH OPTIONS (detached) RECURRING '@daily' WITH SCHEDULE OPTIONS (first_run = 'now')`,
+			`CREATE SCHEDULE 'foo' FOR BACKUP TABLE bar INTO 'baz' WITH detached RECURRING '@daily' WITH SCHEDULE OPTIONS first_run = 'now'`},
+		{`PAUSE SCHEDULE 123`, `PAUSE SCHEDULES VALUES (123)`},
+		{`RESUME SCHEDULE 123`, `RESUME SCHEDULES VALUES (123)`},
+		{`DROP SCHEDULE 123`, `DROP SCHEDULES VALUES (123)`},
+
 		{`GRANT SELECT ON foo TO root`,
 			`GRANT SELECT ON TABLE foo TO root`},
 		{`GRANT SELECT, DELETE, UPDATE ON foo, db.foo TO root, bar`,
@@ -2337,6 +2494,7 @@ $function$`,
 			`CREATE TABLE a (b INT8, c STRING, FOREIGN KEY (b, c) REFERENCES other (x, y) ON DELETE CASCADE ON UPDATE SET NULL)`,
 		},
 		{`CREATE TABLE a (b INT8 GENERATED ALWAYS AS (a + b) STORED)`, `CREATE TABLE a (b INT8 AS (a + b) STORED)`},
+		{`CREATE TABLE a (b INT8 GENERATED ALWAYS AS (a + b) VIRTUAL)`, `CREATE TABLE a (b INT8 AS (a + b) VIRTUAL)`},
 
 		{`ALTER TABLE a ALTER b DROP STORED`, `ALTER TABLE a ALTER COLUMN b DROP STORED`},
 		{`ALTER TABLE a ADD b INT8`, `ALTER TABLE a ADD COLUMN b INT8`},
@@ -2479,6 +2637,11 @@ DETAIL: source SQL:
 SELECT 1 FROM (t)
                 ^
 HINT: try \h <SOURCE>`},
+		{`COPY t TO STDOUT WITH CSV BINARY`,
+			`at or near "binary": syntax error: conflicting or redundant options: format
+DETAIL: source SQL:
+COPY t TO STDOUT WITH CSV BINARY
+                          ^`},
 		{`SET TIME ZONE INTERVAL 'foobar'`,
 			`at or near "EOF": syntax error: could not parse "foobar" as type interval: interval: missing unit at position 0: "foobar"
 DETAIL: source SQL:
@@ -2489,6 +2652,16 @@ SET TIME ZONE INTERVAL 'foobar'
 DETAIL: source SQL:
 SELECT INTERVAL 'foo'
                      ^`},
+		{`CREATE FUNCTION f() RETURNS INT IMMUTABLE STABLE AS 'SELECT 1'`,
+			`at or near "stable": syntax error: conflicting or redundant options
+DETAIL: source SQL:
+CREATE FUNCTION f() RETURNS INT IMMUTABLE STABLE AS 'SELECT 1'
+                                          ^`},
+		{`COPY t FROM STDIN WITH a = ('b')`,
+			`at or near "(": syntax error
+DETAIL: source SQL:
+COPY t FROM STDIN WITH a = ('b')
+                           ^`},
 		{`SELECT 1 /* hello`,
 			`lexical error: unterminated comment
 DETAIL: source SQL:
@@ -3236,17 +3409,17 @@ func TestUnimplementedSyntax(t *testing.T) {
 		{`CREATE EXTENSION a`, 0, `create extension a`, ``},
 		{`CREATE FOREIGN DATA WRAPPER a`, 0, `create fdw`, ``},
 		{`CREATE FOREIGN TABLE a`, 0, `create foreign table`, ``},
-		{`CREATE FUNCTION a`, 17511, `create`, ``},
-		{`CREATE OR REPLACE FUNCTION a`, 17511, `create`, ``},
 		{`CREATE LANGUAGE a`, 17511, `create language a`, ``},
-		{`CREATE MATERIALIZED VIEW a`, 41649, ``, ``},
 		{`CREATE OPERATOR a`, 0, `create operator`, ``},
 		{`CREATE PUBLICATION a`, 0, `create publication`, ``},
 		{`CREATE RULE a`, 0, `create rule`, ``},
 		{`CREATE SERVER a`, 0, `create server`, ``},
 		{`CREATE SUBSCRIPTION a`, 0, `create subscription`, ``},
 		{`CREATE TEXT SEARCH a`, 7821, `create text`, ``},
-		{`CREATE TRIGGER a`, 28296, `create`, ``},
+		{`CREATE TRIGGER a AFTER INSERT ON t FOR EACH ROW EXECUTE FUNCTION f()`, 28296, `execute function`, ``},
+		{`CREATE TRIGGER a AFTER INSERT ON t FOR EACH STATEMENT AS 'SELECT 1'`, 28296, `for each statement`, ``},
+		{`CREATE TRIGGER a AFTER UPDATE OF b ON t FOR EACH ROW AS 'SELECT 1'`, 28296, `update of`, ``},
+		{`CREATE TRIGGER a AFTER TRUNCATE ON t FOR EACH ROW AS 'SELECT 1'`, 28296, `truncate`, ``},
 
 		{`DROP AGGREGATE a`, 0, `drop aggregate`, ``},
 		{`DROP CAST a`, 0, `drop cast`, ``},
@@ -3256,7 +3429,6 @@ func TestUnimplementedSyntax(t *testing.T) {
 		{`DROP EXTENSION a`, 0, `drop extension a`, ``},
 		{`DROP FOREIGN TABLE a`, 0, `drop foreign table`, ``},
 		{`DROP FOREIGN DATA WRAPPER a`, 0, `drop fdw`, ``},
-		{`DROP FUNCTION a`, 17511, `drop `, ``},
 		{`DROP LANGUAGE a`, 17511, `drop language a`, ``},
 		{`DROP OPERATOR a`, 0, `drop operator`, ``},
 		{`DROP PUBLICATION a`, 0, `drop publication`, ``},
@@ -3265,38 +3437,26 @@ func TestUnimplementedSyntax(t *testing.T) {
 		{`DROP SERVER a`, 0, `drop server`, ``},
 		{`DROP SUBSCRIPTION a`, 0, `drop subscription`, ``},
 		{`DROP TEXT SEARCH a`, 7821, `drop text`, ``},
-		{`DROP TRIGGER a`, 28296, `drop`, ``},
 
 		{`DISCARD PLANS`, 0, `discard plans`, ``},
 		{`DISCARD SEQUENCES`, 0, `discard sequences`, ``},
 		{`DISCARD TEMP`, 0, `discard temp`, ``},
 		{`DISCARD TEMPORARY`, 0, `discard temp`, ``},
 
-		{`SET CONSTRAINTS foo`, 0, `set constraints`, ``},
 		{`SET LOCAL foo = bar`, 32562, ``, ``},
 		{`SET foo FROM CURRENT`, 0, `set from current`, ``},
 
 		{`CREATE UNLOGGED TABLE a(b INT8)`, 0, `create unlogged`, ``},
 
-		{`CREATE TABLE a(x INT[][])`, 32552, ``, ``},
-		{`CREATE TABLE a(x INT[1][2])`, 32552, ``, ``},
-		{`CREATE TABLE a(x INT ARRAY[1][2])`, 32552, ``, ``},
-
 		{`CREATE TABLE a(b INT8) WITH OIDS`, 0, `create table with oids`, ``},
 
 		{`CREATE TABLE a AS SELECT b WITH NO DATA`, 0, `create table as with no data`, ``},
 
-		{`CREATE TABLE a(b INT8 AS (123) VIRTUAL)`, 0, `virtual computed columns`, ``},
 		{`CREATE TABLE a(b INT8 REFERENCES c(x) MATCH PARTIAL`, 20305, `match partial`, ``},
 		{`CREATE TABLE a(b INT8, FOREIGN KEY (b) REFERENCES c(x) MATCH PARTIAL)`, 20305, `match partial`, ``},
 
-		{`CREATE TABLE a(b INT8, FOREIGN KEY (b) REFERENCES c(x) DEFERRABLE)`, 31632, `deferrable`, ``},
-		{`CREATE TABLE a(b INT8, FOREIGN KEY (b) REFERENCES c(x) INITIALLY DEFERRED)`, 31632, `initially deferred`, ``},
-		{`CREATE TABLE a(b INT8, FOREIGN KEY (b) REFERENCES c(x) INITIALLY IMMEDIATE)`, 31632, `initially immediate`, ``},
-		{`CREATE TABLE a(b INT8, FOREIGN KEY (b) REFERENCES c(x) DEFERRABLE INITIALLY DEFERRED)`, 31632, `initially deferred`, ``},
-		{`CREATE TABLE a(b INT8, FOREIGN KEY (b) REFERENCES c(x) DEFERRABLE INITIALLY IMMEDIATE)`, 31632, `initially immediate`, ``},
-		{`CREATE TABLE a(b INT8, UNIQUE (b) DEFERRABLE)`, 31632, `deferrable`, ``},
-		{`CREATE TABLE a(b INT8, CHECK (b > 0) DEFERRABLE)`, 31632, `deferrable`, ``},
+		{`CREATE TABLE a(b INT8, UNIQUE (b) DEFERRABLE)`, 31632, `deferrable unique`, ``},
+		{`CREATE TABLE a(b INT8, CHECK (b > 0) DEFERRABLE)`, 31632, `deferrable check`, ``},
 
 		{`CREATE TABLE a (LIKE b INCLUDING COMMENTS)`, 47071, `like table`, ``},
 		{`CREATE TABLE a (LIKE b INCLUDING IDENTITY)`, 47071, `like table`, ``},
@@ -3316,7 +3476,6 @@ func TestUnimplementedSyntax(t *testing.T) {
 
 		{`CREATE RECURSIVE VIEW a AS SELECT b`, 0, `create recursive view`, ``},
 
-		{`CREATE TYPE a AS (b)`, 27792, ``, ``},
 		{`CREATE TYPE a AS RANGE b`, 27791, ``, ``},
 		{`CREATE TYPE a (b)`, 27793, `base`, ``},
 		{`CREATE TYPE a`, 27793, `shell`, ``},
@@ -3347,12 +3506,8 @@ func TestUnimplementedSyntax(t *testing.T) {
 		{`CREATE INDEX a ON b(c + d)`, 9682, ``, ``},
 		{`CREATE INDEX a ON b(c[d])`, 9682, ``, ``},
 		{`CREATE INDEX a ON b(foo(c))`, 9682, ``, ``},
-		{`CREATE INDEX a ON b(a NULLS LAST)`, 6224, ``, ``},
-		{`CREATE INDEX a ON b(a ASC NULLS LAST)`, 6224, ``, ``},
-		{`CREATE INDEX a ON b(a DESC NULLS FIRST)`, 6224, ``, ``},
 
 		{`INSERT INTO foo(a, a.b) VALUES (1,2)`, 27792, ``, ``},
-		{`INSERT INTO foo VALUES (1,2) ON CONFLICT ON CONSTRAINT a DO NOTHING`, 28161, ``, ``},
 
 		{`SELECT * FROM ROWS FROM (a(b) AS (d))`, 0, `ROWS FROM with col_def_list`, ``},
 
@@ -3364,10 +3519,6 @@ func TestUnimplementedSyntax(t *testing.T) {
 		{`SELECT a(b, c, VARIADIC b)`, 0, `variadic`, ``},
 		{`SELECT TREAT (a AS INT8)`, 0, `treat`, ``},
 
-		{`SELECT a FROM t ORDER BY a NULLS LAST`, 6224, ``, ``},
-		{`SELECT a FROM t ORDER BY a ASC NULLS LAST`, 6224, ``, ``},
-		{`SELECT a FROM t ORDER BY a DESC NULLS FIRST`, 6224, ``, ``},
-
 		{`CREATE TABLE a(b BOX)`, 21286, `box`, ``},
 		{`CREATE TABLE a(b CIDR)`, 18846, `cidr`, ``},
 		{`CREATE TABLE a(b CIRCLE)`, 21286, `circle`, ``},
@@ -3385,8 +3536,6 @@ func TestUnimplementedSyntax(t *testing.T) {
 		{`CREATE TABLE a(b TXID_SNAPSHOT)`, 0, `txid_snapshot`, ``},
 		{`CREATE TABLE a(b XML)`, 0, `xml`, ``},
 
-		{`INSERT INTO a VALUES (1) ON CONFLICT (x) WHERE x > 3 DO NOTHING`, 32557, ``, ``},
-
 		{`UPDATE foo SET (a, a.b) = (1, 2)`, 27792, ``, ``},
 		{`UPDATE foo SET a.b = 1`, 27792, ``, ``},
 		{`UPDATE Foo SET x.y = z`, 27792, ``, ``},
@@ -362,6 +362,12 @@ func (s *scanner) scan(lval *sqlSymType) {
 			s.pos++
 			lval.id = FETCHVAL
 			return
+		case '|': // -|-
+			if s.peekN(1) == '-' {
+				s.pos += 2
+				lval.id = ADJACENT
+				return
+			}
 		}
 		return
 
@@ -55,6 +55,8 @@ func TestScanner(t *testing.T) {
 		{`;`, []int{';'}},
 		{`+`, []int{'+'}},
 		{`-`, []int{'-'}},
+		{`-|-`, []int{ADJACENT}},
+		{`-|/`, []int{'-', SQRT}},
 		{`*`, []int{'*'}},
 		{`/`, []int{'/'}},
 		{`//`, []int{FLOORDIV}},
@@ -421,6 +421,42 @@ func (u *sqlSymUnion) idxElems() tree.IndexElemList {
 func (u *sqlSymUnion) dropBehavior() tree.DropBehavior {
     return u.val.(tree.DropBehavior)
 }
+func (u *sqlSymUnion) funcParam() tree.FuncParam {
+    return u.val.(tree.FuncParam)
+}
+func (u *sqlSymUnion) funcParams() tree.FuncParams {
+    return u.val.(tree.FuncParams)
+}
+func (u *sqlSymUnion) compositeTypeList() []tree.CompositeTypeElem {
+    return u.val.([]tree.CompositeTypeElem)
+}
+func (u *sqlSymUnion) funcOptions() tree.FuncOptions {
+    return u.val.(tree.FuncOptions)
+}
+func (u *sqlSymUnion) funcObj() tree.FuncObj {
+    return u.val.(tree.FuncObj)
+}
+func (u *sqlSymUnion) funcObjs() []tree.FuncObj {
+    return u.val.([]tree.FuncObj)
+}
+func (u *sqlSymUnion) cursorStmt() tree.CursorStmt {
+    return u.val.(tree.CursorStmt)
+}
+func (u *sqlSymUnion) cursorSensitivity() tree.CursorSensitivity {
+    return u.val.(tree.CursorSensitivity)
+}
+func (u *sqlSymUnion) cursorScrollOption() tree.CursorScrollOption {
+    return u.val.(tree.CursorScrollOption)
+}
+func (u *sqlSymUnion) triggerActionTime() tree.TriggerActionTime {
+    return u.val.(tree.TriggerActionTime)
+}
+func (u *sqlSymUnion) triggerEvent() tree.TriggerEvent {
+    return u.val.(tree.TriggerEvent)
+}
+func (u *sqlSymUnion) triggerEvents() tree.TriggerEvents {
+    return u.val.(tree.TriggerEvents)
+}
 func (u *sqlSymUnion) validationBehavior() tree.ValidationBehavior {
     return u.val.(tree.ValidationBehavior)
 }
@@ -478,6 +514,9 @@ func (u *sqlSymUnion) kvOptions() []tree.KVOption {
     }
     return nil
 }
+func (u *sqlSymUnion) copyOptions() *tree.CopyOptions {
+    return u.val.(*tree.CopyOptions)
+}
 func (u *sqlSymUnion) transactionModes() tree.TransactionModes {
     return u.val.(tree.TransactionModes)
 }
@@ -490,6 +529,9 @@ func (u *sqlSymUnion) referenceAction() tree.ReferenceAction {
 func (u *sqlSymUnion) referenceActions() tree.ReferenceActions {
     return u.val.(tree.ReferenceActions)
 }
+func (u *sqlSymUnion) constraintDeferrability() tree.ConstraintDeferrability {
+    return u.val.(tree.ConstraintDeferrability)
+}
 func (u *sqlSymUnion) createStatsOptions() *tree.CreateStatsOptions {
     return u.val.(*tree.CreateStatsOptions)
 }
@@ -511,6 +553,9 @@ func (u *sqlSymUnion) partitionedBackup() tree.PartitionedBackup {
 func (u *sqlSymUnion) partitionedBackups() []tree.PartitionedBackup {
     return u.val.([]tree.PartitionedBackup)
 }
+func (u *sqlSymUnion) fullBackupClause() *tree.FullBackupClause {
+    return u.val.(*tree.FullBackupClause)
+}
 func (u *sqlSymUnion) geoFigure() geopb.Shape {
   return u.val.(geopb.Shape)
 }
@@ -545,28 +590,28 @@ func (u *sqlSymUnion) alterTypeAddValuePlacement() *tree.AlterTypeAddValuePlacem
 // below; search this file for "Keyword category lists".
 
 // Ordinary key words in alphabetical order.
-%token <str> ABORT ACTION ADD ADMIN AFTER AGGREGATE
+%token <str> ABORT ABSOLUTE ACTION ADD ADJACENT ADMIN AFTER AGGREGATE
 %token <str> ALL ALTER ALWAYS ANALYSE ANALYZE AND AND_AND ANY ANNOTATE_TYPE ARRAY AS ASC
-%token <str> ASYMMETRIC AT ATTRIBUTE AUTHORIZATION AUTOMATIC
+%token <str> ASENSITIVE ASYMMETRIC AT ATTRIBUTE AUTHORIZATION AUTOMATIC
 
-%token <str> BACKUP BEFORE BEGIN BETWEEN BIGINT BIGSERIAL BIT
+%token <str> BACKUP BACKUPS BACKWARD BEFORE BEGIN BETWEEN BIGINT BIGSERIAL BINARY BIT
 %token <str> BUCKET_COUNT
 %token <str> BOOLEAN BOTH BUNDLE BY
 
-%token <str> CACHE CANCEL CASCADE CASE CAST CBRT CHANGEFEED CHAR
+%token <str> CACHE CALLED CANCEL CASCADE CASE CAST CBRT CHANGEFEED CHAR
 %token <str> CHARACTER CHARACTERISTICS CHECK CLOSE
 %token <str> CLUSTER COALESCE COLLATE COLLATION COLUMN COLUMNS COMMENT COMMENTS COMMIT
 %token <str> COMMITTED COMPACT COMPLETE CONCAT CONCURRENTLY CONFIGURATION CONFIGURATIONS CONFIGURE
 %token <str> CONFLICT CONSTRAINT CONSTRAINTS CONTAINS CONVERSION COPY COVERING CREATE CREATEROLE
-%token <str> CROSS CUBE CURRENT CURRENT_CATALOG CURRENT_DATE CURRENT_SCHEMA
+%token <str> CROSS CSV CUBE CURRENT CURRENT_CATALOG CURRENT_DATE CURRENT_SCHEMA
 %token <str> CURRENT_ROLE CURRENT_TIME CURRENT_TIMESTAMP
-%token <str> CURRENT_USER CYCLE
+%token <str> CURRENT_USER CURSOR CYCLE
 
 %token <str> DATA DATABASE DATABASES DATE DAY DEC DECIMAL DEFAULT DEFAULTS
-%token <str> DEALLOCATE DECLARE DEFERRABLE DEFERRED DELETE DESC
+%token <str> DEALLOCATE DECLARE DEFERRABLE DEFERRED DELETE DELIMITER DESC
 %token <str> DISCARD DISTINCT DO DOMAIN DOUBLE DROP
 
-%token <str> ELSE ENCODING END ENUM ESCAPE EXCEPT EXCLUDE EXCLUDING
+%token <str> EACH ELSE ENCODING END ENUM ESCAPE EXCEPT EXCLUDE EXCLUDING
 %token <str> EXISTS EXECUTE EXPERIMENTAL
 %token <str> EXPERIMENTAL_FINGERPRINTS EXPERIMENTAL_REPLICA
 %token <str> EXPERIMENTAL_AUDIT
@@ -574,29 +619,29 @@ func (u *sqlSymUnion) alterTypeAddValuePlacement() *tree.AlterTypeAddValuePlacem
 
 %token <str> FALSE FAMILY FETCH FETCHVAL FETCHTEXT FETCHVAL_PATH FETCHTEXT_PATH
 %token <str> FILES FILTER
-%token <str> FIRST FLOAT FLOAT4 FLOAT8 FLOORDIV FOLLOWING FOR FORCE_INDEX FOREIGN FROM FULL FUNCTION
+%token <str> FIRST FLOAT FLOAT4 FLOAT8 FLOORDIV FOLLOWING FOR FORCE_INDEX FOREIGN FORMAT FORWARD FROM FULL FUNCTION
 
 %token <str> GENERATED GEOGRAPHY GEOMETRY GEOMETRYCOLLECTION
 %token <str> GLOBAL GRANT GRANTS GREATEST GROUP GROUPING GROUPS
 
-%token <str> HAVING HASH HIGH HISTOGRAM HOUR
+%token <str> HAVING HASH HEADER HIGH HISTOGRAM HOLD HOUR
 
 %token <str> IDENTITY
-%token <str> IF IFERROR IFNULL IGNORE_FOREIGN_KEYS ILIKE IMMEDIATE IMPORT IN INCLUDE INCLUDING INCREMENT INCREMENTAL
+%token <str> IF IFERROR IFNULL IGNORE_FOREIGN_KEYS ILIKE IMMEDIATE IMMUTABLE IMPORT IN INCLUDE INCLUDING INCREMENT INCREMENTAL
 %token <str> INET INET_CONTAINED_BY_OR_EQUALS
 %token <str> INET_CONTAINS_OR_EQUALS INDEX INDEXES INJECT INTERLEAVE INITIALLY
-%token <str> INNER INSERT INT INTEGER
+%token <str> INNER INPUT INSENSITIVE INSERT INT INTEGER
 %token <str> INTERSECT INTERVAL INTO INVERTED IS ISERROR ISNULL ISOLATION
 
 %token <str> JOB JOBS JOIN JSON JSONB JSON_SOME_EXISTS JSON_ALL_EXISTS
 
 %token <str> KEY KEYS KV
 
-%token <str> LANGUAGE LAST LATERAL LC_CTYPE LC_COLLATE
+%token <str> LANGUAGE LAST LATEST LATERAL LC_CTYPE LC_COLLATE
 %token <str> LEADING LEASE LEAST LEFT LESS LEVEL LIKE LIMIT LINESTRING LIST LOCAL
 %token <str> LOCALTIME LOCALTIMESTAMP LOCKED LOGIN LOOKUP LOW LSHIFT
 
-%token <str> MATCH MATERIALIZED MERGE MINVALUE MAXVALUE MINUTE MONTH
+%token <str> MATCH MATERIALIZED MERGE MINVALUE MAXVALUE MINUTE MONTH MOVE
 %token <str> MULTILINESTRING MULTIPOINT MULTIPOLYGON
 
 %token <str> NAN NAME NAMES NATURAL NEXT NO NOCREATEROLE NOLOGIN NO_INDEX_JOIN
@@ -606,22 +651,22 @@ func (u *sqlSymUnion) alterTypeAddValuePlacement() *tree.AlterTypeAddValuePlacem
 %token <str> ORDER ORDINALITY OTHERS OUT OUTER OVER OVERLAPS OVERLAY OWNED OWNER OPERATOR
 
 %token <str> PARENT PARTIAL PARTITION PARTITIONS PASSWORD PAUSE PHYSICAL PLACING
-%token <str> PLAN PLANS POINT POLYGON POSITION PRECEDING PRECISION PREPARE PRESERVE PRIMARY PRIORITY
+%token <str> PLAN PLANS POINT POLYGON POSITION PRECEDING PRECISION PREPARE PRESERVE PRIMARY PRIOR PRIORITY
 %token <str> PROCEDURAL PUBLIC PUBLICATION
 
 %token <str> QUERIES QUERY
 
-%token <str> RANGE RANGES READ REAL RECURSIVE REF REFERENCES
+%token <str> RANGE RANGES READ REAL RECURRING RECURSIVE REF REFERENCES REFRESH
 %token <str> REGCLASS REGPROC REGPROCEDURE REGNAMESPACE REGTYPE REINDEX
 %token <str> REMOVE_PATH RENAME REPEATABLE REPLACE
-%token <str> RELEASE RESET RESTORE RESTRICT RESUME RETURNING REVOKE RIGHT
+%token <str> RELATIVE RELEASE RESET RESTORE RESTRICT RESUME RETURNING RETURNS REVOKE RIGHT
 %token <str> ROLE ROLES ROLLBACK ROLLUP ROW ROWS RSHIFT RULE
 
-%token <str> SAVEPOINT SCATTER SCHEMA SCHEMAS SCRUB SEARCH SECOND SELECT SEQUENCE SEQUENCES
+%token <str> SAVEPOINT SCATTER SCHEDULE SCHEDULES SCHEMA SCHEMAS SCROLL SCRUB SEARCH SECOND SELECT SEQUENCE SEQUENCES
 %token <str> SERIALIZABLE SERVER SESSION SESSIONS SESSION_USER SET SETTING SETTINGS
 %token <str> SHARE SHOW SIMILAR SIMPLE SKIP SMALLINT SMALLSERIAL SNAPSHOT SOME SPLIT SQL
 
-%token <str> START STATISTICS STATUS STDIN STRICT STRING STORAGE STORE STORED STORING SUBSTRING
+%token <str> STABLE START STATEMENT STATISTICS STATUS STDIN STDOUT STRICT STRING STORAGE STORE STORED STORING SUBSTRING
 %token <str> SYMMETRIC SYNTAX SYSTEM SQRT SUBSCRIPTION
 
 %token <str> TABLE TABLES TEMP TEMPLATE TEMPORARY TESTING_RELOCATE EXPERIMENTAL_RELOCATE TEXT THEN
@@ -632,7 +677,7 @@ func (u *sqlSymUnion) alterTypeAddValuePlacement() *tree.AlterTypeAddValuePlacem
 %token <str> UNBOUNDED UNCOMMITTED UNION UNIQUE UNKNOWN UNLOGGED UNSPLIT
 %token <str> UPDATE UPSERT UNTIL USE USER USERS USING UUID
 
-%token <str> VALID VALIDATE VALUE VALUES VARBIT VARCHAR VARIADIC VIEW VARYING VIRTUAL
+%token <str> VALID VALIDATE VALUE VALUES VARBIT VARCHAR VARIADIC VIEW VARYING VIRTUAL VOLATILE
 
 %token <str> WHEN WHERE WINDOW WITH WITHIN WITHOUT WORK WRITE
 
@@ -730,6 +775,9 @@ func (u *sqlSymUnion) alterTypeAddValuePlacement() *tree.AlterTypeAddValuePlacem
 %type <tree.Statement> comment_stmt
 %type <tree.Statement> commit_stmt
 %type <tree.Statement> copy_from_stmt
+%type <tree.Statement> copy_to_stmt
+%type <*tree.CopyOptions> opt_with_copy_options copy_options copy_options_list
+%type <*tree.CopyOptions> copy_generic_options copy_generic_options_list
 
 %type <tree.Statement> create_stmt
 %type <tree.Statement> create_changefeed_stmt
@@ -748,6 +796,8 @@ func (u *sqlSymUnion) alterTypeAddValuePlacement() *tree.AlterTypeAddValuePlacem
 %type <*tree.CreateStatsOptions> create_stats_option_list
 %type <*tree.CreateStatsOptions> create_stats_option
 
+%type <tree.Statement> create_function_stmt
+%type <tree.Statement> create_trigger_stmt
 %type <tree.Statement> create_type_stmt
 %type <tree.Statement> delete_stmt
 %type <tree.Statement> discard_stmt
@@ -758,6 +808,8 @@ func (u *sqlSymUnion) alterTypeAddValuePlacement() *tree.AlterTypeAddValuePlacem
 %type <tree.Statement> drop_index_stmt
 %type <tree.Statement> drop_role_stmt
 %type <tree.Statement> drop_table_stmt
+%type <tree.Statement> drop_function_stmt
+%type <tree.Statement> drop_trigger_stmt
 %type <tree.Statement> drop_type_stmt
 %type <tree.Statement> drop_view_stmt
 %type <tree.Statement> drop_sequence_stmt
@@ -773,11 +825,13 @@ func (u *sqlSymUnion) alterTypeAddValuePlacement() *tree.AlterTypeAddValuePlacem
 %type <tree.Statement> grant_stmt
 %type <tree.Statement> insert_stmt
 %type <tree.Statement> import_stmt
-%type <tree.Statement> pause_stmt
+%type <tree.Statement> pause_stmt pause_jobs_stmt pause_schedules_stmt
 %type <tree.Statement> release_stmt
 %type <tree.Statement> reset_stmt reset_session_stmt reset_csetting_stmt
-%type <tree.Statement> resume_stmt
+%type <tree.Statement> resume_stmt resume_jobs_stmt resume_schedules_stmt
 %type <tree.Statement> restore_stmt
+%type <tree.Statement> create_schedule_for_backup_stmt
+%type <tree.Statement> drop_schedule_stmt
 %type <tree.PartitionedBackup> partitioned_backup
 %type <[]tree.PartitionedBackup> partitioned_backup_list
 %type <tree.Statement> revoke_stmt
@@ -790,6 +844,7 @@ func (u *sqlSymUnion) alterTypeAddValuePlacement() *tree.AlterTypeAddValuePlacem
 %type <tree.Statement> set_session_stmt
 %type <tree.Statement> set_csetting_stmt
 %type <tree.Statement> set_transaction_stmt
+%type <tree.Statement> set_constraints_stmt
 %type <tree.Statement> set_exprs_internal
 %type <tree.Statement> generic_set
 %type <tree.Statement> set_rest_more
@@ -808,6 +863,7 @@ func (u *sqlSymUnion) alterTypeAddValuePlacement() *tree.AlterTypeAddValuePlacem
 %type <tree.Statement> show_indexes_stmt
 %type <tree.Statement> show_partitions_stmt
 %type <tree.Statement> show_jobs_stmt
+%type <tree.Statement> show_schedules_stmt
 %type <tree.Statement> show_queries_stmt
 %type <tree.Statement> show_ranges_stmt
 %type <tree.Statement> show_range_for_row_stmt
@@ -836,11 +892,23 @@ func (u *sqlSymUnion) alterTypeAddValuePlacement() *tree.AlterTypeAddValuePlacem
 
 %type <tree.Statement> close_cursor_stmt
 %type <tree.Statement> declare_cursor_stmt
+%type <tree.Statement> fetch_cursor_stmt
+%type <tree.Statement> move_cursor_stmt
+%type <tree.CursorStmt> cursor_movement_specifier
+%type <bool> opt_hold opt_binary set_constraints_mode
+%type <tree.CursorSensitivity> opt_sensitivity
+%type <tree.CursorScrollOption> opt_scroll
+%type <tree.TriggerActionTime> trigger_action_time
+%type <tree.TriggerEvent> trigger_event
+%type <tree.TriggerEvents> trigger_event_list
+%type <int64> opt_forward_backward forward_backward next_prior
 %type <tree.Statement> reindex_stmt
+%type <tree.Statement> refresh_stmt
 
 %type <[]string> opt_incremental
-%type <tree.KVOption> kv_option
+%type <tree.KVOption> kv_option backup_kv_option
 %type <[]tree.KVOption> kv_option_list opt_with_options var_set_list
+%type <[]tree.KVOption> backup_kv_option_list opt_with_backup_options
 %type <str> import_format
 %type <tree.StorageParam> storage_parameter
 %type <[]tree.StorageParam> storage_parameter_list opt_table_with
@@ -888,7 +956,7 @@ func (u *sqlSymUnion) alterTypeAddValuePlacement() *tree.AlterTypeAddValuePlacem
 %type <str> schema_name
 %type <*tree.UnresolvedName> table_pattern complex_table_pattern
 %type <*tree.UnresolvedName> column_path prefixed_column_path column_path_with_star
-%type <tree.TableExpr> insert_target create_stats_target analyze_target
+%type <tree.TableExpr> insert_target create_stats_target analyze_target changefeed_target_expr
 
 %type <*tree.TableIndexName> table_index_name
 %type <tree.TableIndexNames> table_index_name_list
@@ -911,6 +979,12 @@ func (u *sqlSymUnion) alterTypeAddValuePlacement() *tree.AlterTypeAddValuePlacem
 %type <[]tree.RangePartition> range_partitions
 %type <empty> opt_all_clause
 %type <bool> distinct_clause
+%type <bool> opt_or_replace
+%type <tree.FuncParam> func_param
+%type <tree.FuncParams> func_param_list opt_func_param_list
+%type <tree.FuncOptions> create_func_opt_list create_func_opt_item
+%type <tree.FuncObj> func_obj
+%type <[]tree.FuncObj> func_obj_list
 %type <tree.DistinctOn> distinct_on_clause
 %type <tree.NameList> opt_column_list insert_column_list opt_stats_columns
 %type <tree.OrderBy> sort_clause single_sort_clause opt_sort_clause
@@ -963,8 +1037,8 @@ func (u *sqlSymUnion) alterTypeAddValuePlacement() *tree.AlterTypeAddValuePlacem
 %type <empty> first_or_next
 
 %type <tree.Statement> insert_rest
-%type <tree.NameList> opt_conf_expr opt_col_def_list
-%type <*tree.OnConflict> on_conflict
+%type <tree.NameList> opt_col_def_list
+%type <*tree.OnConflict> on_conflict opt_conf_expr
 
 %type <tree.Statement> begin_transaction
 %type <tree.TransactionModes> transaction_mode_list transaction_mode
@@ -1010,6 +1084,7 @@ func (u *sqlSymUnion) alterTypeAddValuePlacement() *tree.AlterTypeAddValuePlacem
 
 %type <str> explain_option_name
 %type <[]string> explain_option_list opt_enum_val_list enum_val_list
+%type <[]tree.CompositeTypeElem> opt_composite_type_list composite_type_list
 
 %type <tree.ResolvableTypeReference> typename simple_typename cast_target
 %type <*types.T> const_typename
@@ -1040,6 +1115,11 @@ func (u *sqlSymUnion) alterTypeAddValuePlacement() *tree.AlterTypeAddValuePlacem
 %type <tree.Expr> zone_value
 %type <tree.Expr> string_or_placeholder
 %type <tree.Expr> string_or_placeholder_list
+%type <tree.Expr> opt_description
+%type <tree.Expr> cron_expr sconst_or_placeholder restore_subdir
+%type <*tree.FullBackupClause> opt_full_backup_clause
+%type <[]tree.KVOption> opt_with_schedule_options
+%type <*tree.TargetList> opt_backup_targets
 
 %type <str> unreserved_keyword type_func_name_keyword type_func_name_no_crdb_extra_keyword type_func_name_crdb_extra_keyword
 %type <str> col_name_keyword reserved_keyword cockroachdb_extra_reserved_keyword extra_var_value
@@ -1054,6 +1134,7 @@ func (u *sqlSymUnion) alterTypeAddValuePlacement() *tree.AlterTypeAddValuePlacem
 %type <tree.NamedColumnQualification> col_qualification create_as_col_qualification
 %type <tree.ColumnQualification> col_qualification_elem create_as_col_qualification_elem
 %type <tree.CompositeKeyMatchMethod> key_match
+%type <tree.ConstraintDeferrability> opt_deferrable
 %type <tree.ReferenceActions> reference_actions
 %type <tree.ReferenceAction> reference_action reference_on_delete reference_on_update
 
@@ -1139,7 +1220,7 @@ func (u *sqlSymUnion) alterTypeAddValuePlacement() *tree.AlterTypeAddValuePlacem
 %left      '|'
 %left      '#'
 %left      '&'
-%left      LSHIFT RSHIFT INET_CONTAINS_OR_EQUALS INET_CONTAINED_BY_OR_EQUALS AND_AND SQRT CBRT
+%left      LSHIFT RSHIFT INET_CONTAINS_OR_EQUALS INET_CONTAINED_BY_OR_EQUALS AND_AND ADJACENT SQRT CBRT
 %left      '+' '-'
 %left      '*' '/' FLOORDIV '%'
 %left      '^'
@@ -1173,6 +1254,7 @@ stmt:
 | preparable_stmt   // help texts in sub-rule
 | analyze_stmt      // EXTEND WITH HELP: ANALYZE
 | copy_from_stmt
+| copy_to_stmt
 | comment_stmt
 | execute_stmt      // EXTEND WITH HELP: EXECUTE
 | deallocate_stmt   // EXTEND WITH HELP: DEALLOCATE
@@ -1184,8 +1266,10 @@ stmt:
 | release_stmt      // EXTEND WITH HELP: RELEASE
 | nonpreparable_set_stmt // help texts in sub-rule
 | transaction_stmt  // help texts in sub-rule
-| close_cursor_stmt
-| declare_cursor_stmt
+| close_cursor_stmt // EXTEND WITH HELP: CLOSE
+| declare_cursor_stmt // EXTEND WITH HELP: DECLARE
+| fetch_cursor_stmt // EXTEND WITH HELP: FETCH
+| move_cursor_stmt // EXTEND WITH HELP: MOVE
 | reindex_stmt
 | /* EMPTY */
   {
@@ -2005,6 +2089,10 @@ alter_attribute_action:
 //        [ INCREMENTAL FROM <location...> ]
 //        [ WITH <option> [= <value>] [, ...] ]
 //
+// BACKUP <targets...> INTO [<subdir> IN | LATEST IN] <collection...>
+//        [ AS OF SYSTEM TIME <expr> ]
+//        [ WITH <option> [= <value>] [, ...] ]
+//
 // Targets:
 //    TABLE <pattern> [, ...]
 //    DATABASE <databasename> [, ...]
@@ -2012,22 +2100,206 @@ alter_attribute_action:
 // Location:
 //    "[scheme]://[host]/[path to backup]?[parameters]"
 //
+// Collection:
+//    "[scheme]://[host]/[path to collection]?[parameters]"
+//    BACKUP INTO creates a new full backup in a dated subdirectory of the
+//    collection. BACKUP INTO LATEST IN appends an incremental backup to the
+//    most recent full backup in the collection.
+//
 // Options:
-//    INTO_DB
-//    SKIP_MISSING_FOREIGN_KEYS
+//    REVISION_HISTORY
+//    DETACHED
+//    ENCRYPTION_PASSPHRASE = <passphrase>
+//    KMS = <kms_uri> | ( <kms_uri> [, ...] )
 //
 // %SeeAlso: RESTORE, WEBDOCS/backup.html
 backup_stmt:
-  BACKUP TO partitioned_backup opt_as_of_clause opt_incremental opt_with_options
+  BACKUP TO partitioned_backup opt_as_of_clause opt_incremental opt_with_backup_options
   {
     $$.val = &tree.Backup{DescriptorCoverage: tree.AllDescriptors, To: $3.partitionedBackup(), IncrementalFrom: $5.exprs(), AsOf: $4.asOfClause(), Options: $6.kvOptions()}
   }
-| BACKUP targets TO partitioned_backup opt_as_of_clause opt_incremental opt_with_options
+| BACKUP targets TO partitioned_backup opt_as_of_clause opt_incremental opt_with_backup_options
   {
     $$.val = &tree.Backup{Targets: $2.targetList(), To: $4.partitionedBackup(), IncrementalFrom: $6.exprs(), AsOf: $5.asOfClause(), Options: $7.kvOptions()}
   }
+| BACKUP INTO partitioned_backup opt_as_of_clause opt_with_backup_options
+  {
+    $$.val = &tree.Backup{DescriptorCoverage: tree.AllDescriptors, Nested: true, To: $3.partitionedBackup(), AsOf: $4.asOfClause(), Options: $5.kvOptions()}
+  }
+| BACKUP targets INTO partitioned_backup opt_as_of_clause opt_with_backup_options
+  {
+    $$.val = &tree.Backup{Targets: $2.targetList(), Nested: true, To: $4.partitionedBackup(), AsOf: $5.asOfClause(), Options: $6.kvOptions()}
+  }
+| BACKUP INTO sconst_or_placeholder IN partitioned_backup opt_as_of_clause opt_with_backup_options
+  {
+    $$.val = &tree.Backup{DescriptorCoverage: tree.AllDescriptors, Nested: true, Subdir: $3.expr(), To: $5.partitionedBackup(), AsOf: $6.asOfClause(), Options: $7.kvOptions()}
+  }
+| BACKUP targets INTO sconst_or_placeholder IN partitioned_backup opt_as_of_clause opt_with_backup_options
+  {
+    $$.val = &tree.Backup{Targets: $2.targetList(), Nested: true, Subdir: $4.expr(), To: $6.partitionedBackup(), AsOf: $7.asOfClause(), Options: $8.kvOptions()}
+  }
+| BACKUP INTO LATEST IN partitioned_backup opt_as_of_clause opt_with_backup_options
+  {
+    $$.val = &tree.Backup{DescriptorCoverage: tree.AllDescriptors, Nested: true, AppendToLatest: true, To: $5.partitionedBackup(), AsOf: $6.asOfClause(), Options: $7.kvOptions()}
+  }
+| BACKUP targets INTO LATEST IN partitioned_backup opt_as_of_clause opt_with_backup_options
+  {
+    $$.val = &tree.Backup{Targets: $2.targetList(), Nested: true, AppendToLatest: true, To: $6.partitionedBackup(), AsOf: $7.asOfClause(), Options: $8.kvOptions()}
+  }
 | BACKUP error // SHOW HELP: BACKUP
 
+// %Help: CREATE SCHEDULE FOR BACKUP - backup data periodically
+// %Category: CCL
+// %Text:
+// CREATE SCHEDULE [<description>]
+// FOR BACKUP [<targets>] INTO <location...>
+// [WITH <backup_option>[=<value>] [, ...]]
+// RECURRING [crontab]
+// [FULL BACKUP <crontab|ALWAYS>]
+// [WITH SCHEDULE OPTIONS <schedule_option>[= <value>] [, ...] ]
+//
+// All backups run in UTC timezone.
+//
+// Description:
+//   Optional description (or name) for this schedule
+//
+// Targets:
+//   empty targets: Backup entire cluster
+//   DATABASE <pattern> [, ...]: comma separated list of databases to backup.
+//   TABLE <pattern> [, ...]: comma separated list of tables to backup.
+//
+// Location:
+//   "[scheme]://[host]/[path prefix to backup]?[parameters]"
+//   Backup schedule will create subdirectories under this location to store
+//   full and periodic backups.
+//
+// WITH <options>:
+//   Options specific to BACKUP: See BACKUP options
+//
+// RECURRING <crontab>:
+//   The RECURRING expression specifies when we backup.  By default these are incremental
+//   backups that capture changes since the last backup, writing to a new dated
+//   subdirectory under the full backup they build upon.
+//
+//   Schedule specified as a string in crontab format.
+//   All times in UTC.
+//     "5 0 * * *": run schedule 5 minutes past midnight.
+//     "@daily": run daily, at midnight
+//   See https://en.wikipedia.org/wiki/Cron
+//
+// FULL BACKUP <crontab|ALWAYS>:
+//   The optional FULL BACKUP '<cron expr>' clause specifies when we'll start a new full backup,
+//   which becomes the base for any subsequent incremental backups.
+//   If FULL BACKUP ALWAYS is specified, then the backups triggered by the RECURRING clause will
+//   always be full backups.
+//
+//   If the FULL BACKUP clause is omitted, we will select a reasonable default:
+//      * RECURRING <= 1 hour: we default to FULL BACKUP '@daily';
+//      * RECURRING <= 1 day:  we default to FULL BACKUP '@weekly';
+//      * Otherwise: we default to FULL BACKUP ALWAYS.
+//
+// SCHEDULE OPTIONS:
+//   The schedule can be modified by specifying the following options (which are optional):
+//
+//   * first_run=TIMESTAMPTZ:
+//     execute the schedule at the specified time. If not specified, the first full
+//     backup starts right away so that incremental backups have a base to build upon.
+//   * on_execution_failure='[retry|reschedule|pause]':
+//     If an error occurs during the execution, handle the error based as:
+//     * retry: retry execution right away
+//     * reschedule: retry execution by rescheduling it based on its RECURRING expression.
+//       This is the default.
+//     * pause: pause this schedule.  Requires manual intervention to unpause.
+//   * on_previous_running='[start|skip|wait]':
+//     If the previous backup started by this schedule still running, handle this as:
+//     * start: start this execution anyway, even if the previous one still running.
+//     * skip: skip this execution, reschedule it based on RECURRING expression.
+//     * wait: wait for the previous execution to complete.  This is the default.
+//
+// %SeeAlso: BACKUP
+create_schedule_for_backup_stmt:
+  CREATE SCHEDULE /*$3=*/opt_description FOR BACKUP /*$6=*/opt_backup_targets INTO
+  /*$8=*/partitioned_backup /*$9=*/opt_with_backup_options
+  /*$10=*/cron_expr /*$11=*/opt_full_backup_clause /*$12=*/opt_with_schedule_options
+  {
+    $$.val = &tree.ScheduledBackup{
+      ScheduleLabel:   $3.expr(),
+      Recurrence:      $10.expr(),
+      FullBackup:      $11.fullBackupClause(),
+      To:              $8.partitionedBackup(),
+      Targets:         $6.targetListPtr(),
+      BackupOptions:   $9.kvOptions(),
+      ScheduleOptions: $12.kvOptions(),
+    }
+  }
+| CREATE SCHEDULE error  // SHOW HELP: CREATE SCHEDULE FOR BACKUP
+
+opt_description:
+  string_or_placeholder
+| /* EMPTY */
+  {
+     $$.val = nil
+  }
+
+// sconst_or_placeholder matches a simple string, or a placeholder.
+sconst_or_placeholder:
+  SCONST
+  {
+    $$.val = tree.NewStrVal($1)
+  }
+| PLACEHOLDER
+  {
+    p := $1.placeholder()
+    sqllex.(*lexer).UpdateNumPlaceholders(p)
+    $$.val = p
+  }
+
+cron_expr:
+  RECURRING sconst_or_placeholder
+  {
+    $$.val = $2.expr()
+  }
+
+opt_full_backup_clause:
+  FULL BACKUP sconst_or_placeholder
+  {
+    $$.val = &tree.FullBackupClause{Recurrence: $3.expr()}
+  }
+| FULL BACKUP ALWAYS
+  {
+    $$.val = &tree.FullBackupClause{AlwaysFull: true}
+  }
+| /* EMPTY */
+  {
+    var clause *tree.FullBackupClause = nil
+    $$.val = clause
+  }
+
+opt_with_schedule_options:
+  WITH SCHEDULE OPTIONS kv_option_list
+  {
+    $$.val = $4.kvOptions()
+  }
+| WITH SCHEDULE OPTIONS '(' kv_option_list ')'
+  {
+    $$.val = $5.kvOptions()
+  }
+| /* EMPTY */
+  {
+    $$.val = nil
+  }
+
+opt_backup_targets:
+  /* EMPTY -- full cluster */
+  {
+    $$.val = (*tree.TargetList)(nil)
+  }
+| targets
+  {
+    t := $1.targetList()
+    $$.val = &t
+  }
+
 // %Help: RESTORE - restore data from external storage
 // %Category: CCL
 // %Text:
@@ -2035,6 +2307,10 @@ backup_stmt:
 //         [ AS OF SYSTEM TIME <expr> ]
 //         [ WITH <option> [= <value>] [, ...] ]
 //
+// RESTORE <targets...> FROM {<subdir> | LATEST} IN <collection...>
+//         [ AS OF SYSTEM TIME <expr> ]
+//         [ WITH <option> [= <value>] [, ...] ]
+//
 // Targets:
 //    TABLE <pattern> [, ...]
 //    DATABASE <databasename> [, ...]
@@ -2045,19 +2321,38 @@ backup_stmt:
 // Options:
 //    INTO_DB
 //    SKIP_MISSING_FOREIGN_KEYS
+//    ENCRYPTION_PASSPHRASE = <passphrase>
+//    KMS = <kms_uri> | ( <kms_uri> [, ...] )
 //
 // %SeeAlso: BACKUP, WEBDOCS/restore.html
 restore_stmt:
-  RESTORE FROM partitioned_backup_list opt_as_of_clause opt_with_options
+  RESTORE FROM partitioned_backup_list opt_as_of_clause opt_with_backup_options
   {
     $$.val = &tree.Restore{DescriptorCoverage: tree.AllDescriptors, From: $3.partitionedBackups(), AsOf: $4.asOfClause(), Options: $5.kvOptions()}
   }
-| RESTORE targets FROM partitioned_backup_list opt_as_of_clause opt_with_options
+| RESTORE targets FROM partitioned_backup_list opt_as_of_clause opt_with_backup_options
   {
     $$.val = &tree.Restore{Targets: $2.targetList(), From: $4.partitionedBackups(), AsOf: $5.asOfClause(), Options: $6.kvOptions()}
   }
+| RESTORE FROM restore_subdir IN partitioned_backup opt_as_of_clause opt_with_backup_options
+  {
+    $$.val = &tree.Restore{DescriptorCoverage: tree.AllDescriptors, Subdir: $3.expr(), From: []tree.PartitionedBackup{$5.partitionedBackup()}, AsOf: $6.asOfClause(), Options: $7.kvOptions()}
+  }
+| RESTORE targets FROM restore_subdir IN partitioned_backup opt_as_of_clause opt_with_backup_options
+  {
+    $$.val = &tree.Restore{Targets: $2.targetList(), Subdir: $4.expr(), From: []tree.PartitionedBackup{$6.partitionedBackup()}, AsOf: $7.asOfClause(), Options: $8.kvOptions()}
+  }
 | RESTORE error // SHOW HELP: RESTORE
 
+// restore_subdir is the subdirectory of a backup collection to restore from;
+// LATEST refers to the most recent backup in the collection.
+restore_subdir:
+  sconst_or_placeholder
+| LATEST
+  {
+    $$.val = tree.NewDString(tree.LatestBackupSubdir)
+  }
+
 partitioned_backup:
   string_or_placeholder
   {
@@ -2237,6 +2532,44 @@ kv_option_list:
     $$.val = append($1.kvOptions(), $3.kvOption())
   }
 
+// backup_kv_option extends kv_option with options taking a list of values,
+// such as the KMS URIs used to encrypt a backup.
+backup_kv_option:
+  kv_option
+| name '=' '(' string_or_placeholder_list ')'
+  {
+    exprs := $4.exprs()
+    if len(exprs) == 1 {
+      $$.val = tree.KVOption{Key: tree.Name($1), Value: exprs[0]}
+    } else {
+      $$.val = tree.KVOption{Key: tree.Name($1), Value: &tree.Tuple{Exprs: exprs}}
+    }
+  }
+
+backup_kv_option_list:
+  backup_kv_option
+  {
+    $$.val = []tree.KVOption{$1.kvOption()}
+  }
+|  backup_kv_option_list ',' backup_kv_option
+  {
+    $$.val = append($1.kvOptions(), $3.kvOption())
+  }
+
+opt_with_backup_options:
+  WITH backup_kv_option_list
+  {
+    $$.val = $2.kvOptions()
+  }
+| WITH OPTIONS '(' backup_kv_option_list ')'
+  {
+    $$.val = $4.kvOptions()
+  }
+| /* EMPTY */
+  {
+    $$.val = nil
+  }
+
 opt_with_options:
   WITH kv_option_list
   {
@@ -2263,6 +2596,120 @@ copy_from_stmt:
     }
   }
 
+copy_to_stmt:
+  COPY table_name opt_column_list TO STDOUT opt_with_copy_options
+  {
+    name := $2.unresolvedObjectName().ToTableName()
+    $$.val = &tree.CopyTo{
+      Table: name,
+      Columns: $3.nameList(),
+      Options: *$6.copyOptions(),
+    }
+  }
+| COPY '(' select_stmt ')' TO STDOUT opt_with_copy_options
+  {
+    $$.val = &tree.CopyTo{
+      Statement: $3.slct(),
+      Options: *$7.copyOptions(),
+    }
+  }
+
+// opt_with_copy_options accepts both the current PostgreSQL option syntax,
+// e.g. WITH (FORMAT csv, HEADER), and the older one, e.g. WITH CSV HEADER.
+opt_with_copy_options:
+  opt_with copy_options_list
+  {
+    $$.val = $2.copyOptions()
+  }
+| opt_with '(' copy_generic_options_list ')'
+  {
+    $$.val = $3.copyOptions()
+  }
+| /* EMPTY */
+  {
+    $$.val = &tree.CopyOptions{}
+  }
+
+copy_options_list:
+  copy_options
+  {
+    $$.val = $1.copyOptions()
+  }
+| copy_options_list copy_options
+  {
+    if err := $1.copyOptions().CombineWith($2.copyOptions()); err != nil {
+      return setErr(sqllex, err)
+    }
+  }
+
+copy_options:
+  BINARY
+  {
+    $$.val = &tree.CopyOptions{CopyFormat: tree.CopyFormatBinary}
+  }
+| CSV
+  {
+    $$.val = &tree.CopyOptions{CopyFormat: tree.CopyFormatCSV}
+  }
+| HEADER
+  {
+    $$.val = &tree.CopyOptions{Header: true}
+  }
+| DELIMITER SCONST
+  {
+    $$.val = &tree.CopyOptions{Delimiter: tree.NewStrVal($2)}
+  }
+| NULL SCONST
+  {
+    $$.val = &tree.CopyOptions{Null: tree.NewStrVal($2)}
+  }
+
+copy_generic_options_list:
+  copy_generic_options
+  {
+    $$.val = $1.copyOptions()
+  }
+| copy_generic_options_list ',' copy_generic_options
+  {
+    if err := $1.copyOptions().CombineWith($3.copyOptions()); err != nil {
+      return setErr(sqllex, err)
+    }
+  }
+
+copy_generic_options:
+  FORMAT TEXT
+  {
+    $$.val = &tree.CopyOptions{}
+  }
+| FORMAT BINARY
+  {
+    $$.val = &tree.CopyOptions{CopyFormat: tree.CopyFormatBinary}
+  }
+| FORMAT CSV
+  {
+    $$.val = &tree.CopyOptions{CopyFormat: tree.CopyFormatCSV}
+  }
+| HEADER
+  {
+    $$.val = &tree.CopyOptions{Header: true}
+  }
+| HEADER TRUE
+  {
+    $$.val = &tree.CopyOptions{Header: true}
+  }
+| HEADER FALSE
+  {
+    $$.val = &tree.CopyOptions{}
+  }
+| DELIMITER SCONST
+  {
+    $$.val = &tree.CopyOptions{Delimiter: tree.NewStrVal($2)}
+  }
+| NULL SCONST
+  {
+    $$.val = &tree.CopyOptions{Null: tree.NewStrVal($2)}
+  }
+
 // %Help: CANCEL
 // %Category: Group
 // %Text: CANCEL JOBS, CANCEL QUERIES, CANCEL SESSIONS
@@ -2411,11 +2858,12 @@ comment_text:
 // %Text:
 // CREATE DATABASE, CREATE TABLE, CREATE INDEX, CREATE TABLE AS,
 // CREATE USER, CREATE VIEW, CREATE SEQUENCE, CREATE STATISTICS,
-// CREATE ROLE, CREATE TYPE
+// CREATE ROLE, CREATE TYPE, CREATE SCHEDULE FOR BACKUP
 create_stmt:
   create_role_stmt     // EXTEND WITH HELP: CREATE ROLE
 | create_ddl_stmt      // help texts in sub-rule
 | create_stats_stmt    // EXTEND WITH HELP: CREATE STATISTICS
+| create_schedule_for_backup_stmt   // EXTEND WITH HELP: CREATE SCHEDULE FOR BACKUP
 | create_unsupported   {}
 | CREATE error         // SHOW HELP: CREATE
 
@@ -2429,21 +2877,23 @@ create_unsupported:
 | CREATE EXTENSION name error { return unimplemented(sqllex, "create extension " + $3) }
 | CREATE FOREIGN TABLE error { return unimplemented(sqllex, "create foreign table") }
 | CREATE FOREIGN DATA error { return unimplemented(sqllex, "create fdw") }
-| CREATE FUNCTION error { return unimplementedWithIssueDetail(sqllex, 17511, "create function") }
-| CREATE OR REPLACE FUNCTION error { return unimplementedWithIssueDetail(sqllex, 17511, "create function") }
 | CREATE opt_or_replace opt_trusted opt_procedural LANGUAGE name error { return unimplementedWithIssueDetail(sqllex, 17511, "create language " + $6) }
-| CREATE MATERIALIZED VIEW error { return unimplementedWithIssue(sqllex, 41649) }
 | CREATE OPERATOR error { return unimplemented(sqllex, "create operator") }
 | CREATE PUBLICATION error { return unimplemented(sqllex, "create publication") }
 | CREATE opt_or_replace RULE error { return unimplemented(sqllex, "create rule") }
 | CREATE SERVER error { return unimplemented(sqllex, "create server") }
 | CREATE SUBSCRIPTION error { return unimplemented(sqllex, "create subscription") }
 | CREATE TEXT error { return unimplementedWithIssueDetail(sqllex, 7821, "create text") }
-| CREATE TRIGGER error { return unimplementedWithIssueDetail(sqllex, 28296, "create") }
 
 opt_or_replace:
-  OR REPLACE {}
-| /* EMPTY */ {}
+  OR REPLACE
+  {
+    $$.val = true
+  }
+| /* EMPTY */
+  {
+    $$.val = false
+  }
 
 opt_trusted:
   TRUSTED {}
@@ -2463,7 +2913,6 @@ drop_unsupported:
 | DROP EXTENSION name error { return unimplemented(sqllex, "drop extension " + $3) }
 | DROP FOREIGN TABLE error { return unimplemented(sqllex, "drop foreign table") }
 | DROP FOREIGN DATA error { return unimplemented(sqllex, "drop fdw") }
-| DROP FUNCTION error { return unimplementedWithIssueDetail(sqllex, 17511, "drop function") }
 | DROP opt_procedural LANGUAGE name error { return unimplementedWithIssueDetail(sqllex, 17511, "drop language " + $4) }
 | DROP OPERATOR error { return unimplemented(sqllex, "drop operator") }
 | DROP PUBLICATION error { return unimplemented(sqllex, "drop publication") }
@@ -2472,7 +2921,6 @@ drop_unsupported:
 | DROP SERVER error { return unimplemented(sqllex, "drop server") }
 | DROP SUBSCRIPTION error { return unimplemented(sqllex, "drop subscription") }
 | DROP TEXT error { return unimplementedWithIssueDetail(sqllex, 7821, "drop text") }
-| DROP TRIGGER error { return unimplementedWithIssueDetail(sqllex, 28296, "drop") }
 
 create_ddl_stmt:
   create_changefeed_stmt
@@ -2481,6 +2929,8 @@ create_ddl_stmt:
 | create_schema_stmt   // EXTEND WITH HELP: CREATE SCHEMA
 | create_table_stmt    // EXTEND WITH HELP: CREATE TABLE
 | create_table_as_stmt // EXTEND WITH HELP: CREATE TABLE
+| create_function_stmt // EXTEND WITH HELP: CREATE FUNCTION
+| create_trigger_stmt  // EXTEND WITH HELP: CREATE TRIGGER
 // Error case for both CREATE TABLE and CREATE TABLE ... AS in one
 | CREATE opt_temp_create_table TABLE error   // SHOW HELP: CREATE TABLE
 | create_type_stmt     // EXTEND WITH HELP: CREATE TYPE
@@ -2599,6 +3049,21 @@ create_changefeed_stmt:
       Options: $5.kvOptions(),
     }
   }
+| CREATE CHANGEFEED opt_changefeed_sink opt_with_options AS SELECT target_list FROM changefeed_target_expr opt_where_clause
+  {
+    target := $9.tblExpr().(*tree.AliasedTableExpr)
+    name := target.Expr.(*tree.TableName)
+    $$.val = &tree.CreateChangefeed{
+      Targets: tree.TargetList{Tables: tree.TablePatterns{name}},
+      SinkURI: $3.expr(),
+      Options: $4.kvOptions(),
+      Select: &tree.SelectClause{
+        Exprs: $7.selExprs(),
+        From:  tree.From{Tables: tree.TableExprs{target}},
+        Where: tree.NewWhere(tree.AstWhere, $10.expr()),
+      },
+    }
+  }
 
 changefeed_targets:
   single_table_pattern_list
@@ -2610,6 +3075,18 @@ changefeed_targets:
     $$.val = tree.TargetList{Tables: $2.tablePatterns()}
   }
 
+changefeed_target_expr:
+  table_name
+  {
+    name := $1.unresolvedObjectName().ToTableName()
+    $$.val = &tree.AliasedTableExpr{Expr: &name}
+  }
+| table_name AS table_alias_name
+  {
+    name := $1.unresolvedObjectName().ToTableName()
+    $$.val = &tree.AliasedTableExpr{Expr: &name, As: tree.AliasClause{Alias: tree.Name($3)}}
+  }
+
 single_table_pattern_list:
   table_name
   {
@@ -2676,24 +3153,54 @@ discard_stmt:
 // %Category: Group
 // %Text:
 // DROP DATABASE, DROP INDEX, DROP TABLE, DROP VIEW, DROP SEQUENCE,
-// DROP USER, DROP ROLE, DROP TYPE
+// DROP USER, DROP ROLE, DROP TYPE, DROP SCHEDULES
 drop_stmt:
   drop_ddl_stmt      // help texts in sub-rule
 | drop_role_stmt     // EXTEND WITH HELP: DROP ROLE
+| drop_schedule_stmt // EXTEND WITH HELP: DROP SCHEDULES
 | drop_unsupported   {}
 | DROP error         // SHOW HELP: DROP
 
 drop_ddl_stmt:
   drop_database_stmt // EXTEND WITH HELP: DROP DATABASE
+| drop_function_stmt // EXTEND WITH HELP: DROP FUNCTION
 | drop_index_stmt    // EXTEND WITH HELP: DROP INDEX
 | drop_table_stmt    // EXTEND WITH HELP: DROP TABLE
 | drop_view_stmt     // EXTEND WITH HELP: DROP VIEW
 | drop_sequence_stmt // EXTEND WITH HELP: DROP SEQUENCE
+| drop_trigger_stmt  // EXTEND WITH HELP: DROP TRIGGER
 | drop_type_stmt     // EXTEND WITH HELP: DROP TYPE
 
+// %Help: DROP SCHEDULES - destroy specified schedules
+// %Category: Misc
+// %Text:
+// DROP SCHEDULES <selectclause>
+//   select clause: select statement returning schedule id to drop.
+// DROP SCHEDULE <scheduleID>
+// %SeeAlso: PAUSE SCHEDULES, RESUME SCHEDULES, SHOW SCHEDULES
+drop_schedule_stmt:
+  DROP SCHEDULE a_expr
+  {
+    $$.val = &tree.ControlSchedules{
+      Schedules: &tree.Select{
+        Select: &tree.ValuesClause{Rows: []tree.Exprs{tree.Exprs{$3.expr()}}},
+      },
+      Command: tree.DropSchedule,
+    }
+  }
+| DROP SCHEDULE error // SHOW HELP: DROP SCHEDULES
+| DROP SCHEDULES select_stmt
+  {
+    $$.val = &tree.ControlSchedules{
+      Schedules: $3.slct(),
+      Command: tree.DropSchedule,
+    }
+  }
+| DROP SCHEDULES error // SHOW HELP: DROP SCHEDULES
+
 // %Help: DROP VIEW - remove a view
 // %Category: DDL
-// %Text: DROP VIEW [IF EXISTS] <tablename> [, ...] [CASCADE | RESTRICT]
+// %Text: DROP [MATERIALIZED] VIEW [IF EXISTS] <tablename> [, ...] [CASCADE | RESTRICT]
 // %SeeAlso: WEBDOCS/drop-index.html
 drop_view_stmt:
   DROP VIEW table_name_list opt_drop_behavior
@@ -2704,7 +3211,26 @@ drop_view_stmt:
   {
     $$.val = &tree.DropView{Names: $5.tableNames(), IfExists: true, DropBehavior: $6.dropBehavior()}
   }
+| DROP MATERIALIZED VIEW table_name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropView{
+      Names: $4.tableNames(),
+      IfExists: false,
+      DropBehavior: $5.dropBehavior(),
+      IsMaterialized: true,
+    }
+  }
+| DROP MATERIALIZED VIEW IF EXISTS table_name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropView{
+      Names: $6.tableNames(),
+      IfExists: true,
+      DropBehavior: $7.dropBehavior(),
+      IsMaterialized: true,
+    }
+  }
 | DROP VIEW error // SHOW HELP: DROP VIEW
+| DROP MATERIALIZED VIEW error // SHOW HELP: DROP VIEW
 
 // %Help: DROP SEQUENCE - remove a sequence
 // %Category: DDL
@@ -2784,6 +3310,80 @@ drop_database_stmt:
   }
 | DROP DATABASE error // SHOW HELP: DROP DATABASE
 
+// %Help: DROP FUNCTION - remove a function
+// %Category: DDL
+// %Text:
+// DROP FUNCTION [IF EXISTS] <name> [ ( [ [<argname>] <argtype> [, ...] ] ) ] [, ...]
+//   [CASCADE | RESTRICT]
+// %SeeAlso: CREATE FUNCTION, WEBDOCS/drop-function.html
+drop_function_stmt:
+  DROP FUNCTION func_obj_list opt_drop_behavior
+  {
+    $$.val = &tree.DropFunction{
+      Functions: $3.funcObjs(),
+      IfExists: false,
+      DropBehavior: $4.dropBehavior(),
+    }
+  }
+| DROP FUNCTION IF EXISTS func_obj_list opt_drop_behavior
+  {
+    $$.val = &tree.DropFunction{
+      Functions: $5.funcObjs(),
+      IfExists: true,
+      DropBehavior: $6.dropBehavior(),
+    }
+  }
+| DROP FUNCTION error // SHOW HELP: DROP FUNCTION
+
+// %Help: DROP TRIGGER - remove a trigger
+// %Category: DDL
+// %Text: DROP TRIGGER [IF EXISTS] <name> ON <tablename> [CASCADE | RESTRICT]
+// %SeeAlso: CREATE TRIGGER
+drop_trigger_stmt:
+  DROP TRIGGER name ON table_name opt_drop_behavior
+  {
+    $$.val = &tree.DropTrigger{
+      Name: tree.Name($3),
+      Table: $5.unresolvedObjectName(),
+      IfExists: false,
+      DropBehavior: $6.dropBehavior(),
+    }
+  }
+| DROP TRIGGER IF EXISTS name ON table_name opt_drop_behavior
+  {
+    $$.val = &tree.DropTrigger{
+      Name: tree.Name($5),
+      Table: $7.unresolvedObjectName(),
+      IfExists: true,
+      DropBehavior: $8.dropBehavior(),
+    }
+  }
+| DROP TRIGGER error // SHOW HELP: DROP TRIGGER
+
+func_obj_list:
+  func_obj
+  {
+    $$.val = []tree.FuncObj{$1.funcObj()}
+  }
+| func_obj_list ',' func_obj
+  {
+    $$.val = append($1.funcObjs(), $3.funcObj())
+  }
+
+func_obj:
+  db_object_name
+  {
+    $$.val = tree.FuncObj{FuncName: $1.unresolvedObjectName()}
+  }
+| db_object_name '(' opt_func_param_list ')'
+  {
+    $$.val = tree.FuncObj{
+      FuncName: $1.unresolvedObjectName(),
+      Params: $3.funcParams(),
+      ParamsSpecified: true,
+    }
+  }
+
 // %Help: DROP TYPE - remove a type
 // %Category: DDL
 // %Text: DROP TYPE [IF EXISTS] <type_name> [, ...] [CASCASE | RESTRICT]
@@ -2956,10 +3556,10 @@ preparable_stmt:
 | explain_stmt      // EXTEND WITH HELP: EXPLAIN
 | import_stmt       // EXTEND WITH HELP: IMPORT
 | insert_stmt       // EXTEND WITH HELP: INSERT
-| pause_stmt        // EXTEND WITH HELP: PAUSE JOBS
+| pause_stmt        // help texts in sub-rule
 | reset_stmt        // help texts in sub-rule
 | restore_stmt      // EXTEND WITH HELP: RESTORE
-| resume_stmt       // EXTEND WITH HELP: RESUME JOBS
+| resume_stmt       // help texts in sub-rule
 | export_stmt       // EXTEND WITH HELP: EXPORT
 | scrub_stmt        // help texts in sub-rule
 | select_stmt       // help texts in sub-rule
@@ -2967,6 +3567,7 @@ preparable_stmt:
     $$.val = $1.slct()
   }
 | preparable_set_stmt // help texts in sub-rule
+| refresh_stmt      // EXTEND WITH HELP: REFRESH
 | show_stmt         // help texts in sub-rule
 | truncate_stmt     // EXTEND WITH HELP: TRUNCATE
 | update_stmt       // EXTEND WITH HELP: UPDATE
@@ -3230,7 +3831,7 @@ use_stmt:
 nonpreparable_set_stmt:
   set_transaction_stmt // EXTEND WITH HELP: SET TRANSACTION
 | set_exprs_internal   { /* SKIP DOC */ }
-| SET CONSTRAINTS error { return unimplemented(sqllex, "set constraints") }
+| set_constraints_stmt // EXTEND WITH HELP: SET CONSTRAINTS
 | SET LOCAL error { return unimplementedWithIssue(sqllex, 32562) }
 
 // SET SESSION / SET CLUSTER SETTING
@@ -3413,6 +4014,34 @@ set_transaction_stmt:
   }
 | SET SESSION TRANSACTION error // SHOW HELP: SET TRANSACTION
 
+// %Help: SET CONSTRAINTS - set the checking mode of constraints
+// %Category: Txn
+// %Text: SET CONSTRAINTS { ALL | <name> [, ...] } { DEFERRED | IMMEDIATE }
+//
+// Deferred constraints are checked when the current transaction commits.
+// Only constraints created with DEFERRABLE can be deferred.
+// %SeeAlso: SET TRANSACTION, CREATE TABLE
+set_constraints_stmt:
+  SET CONSTRAINTS ALL set_constraints_mode
+  {
+    $$.val = &tree.SetConstraints{Deferred: $4.bool()}
+  }
+| SET CONSTRAINTS name_list set_constraints_mode
+  {
+    $$.val = &tree.SetConstraints{Names: $3.nameList(), Deferred: $4.bool()}
+  }
+| SET CONSTRAINTS error // SHOW HELP: SET CONSTRAINTS
+
+set_constraints_mode:
+  DEFERRED
+  {
+    $$.val = true
+  }
+| IMMEDIATE
+  {
+    $$.val = false
+  }
+
 generic_set:
   var_name to_or_eq var_list
   {
@@ -3562,82 +4191,272 @@ user_priority:
   {
     $$.val = tree.High
   }
-
-// Timezone values can be:
-// - a string such as 'pst8pdt'
-// - an identifier such as "pst8pdt"
-// - an integer or floating point number
-// - a time interval per SQL99
-zone_value:
-  SCONST
+
+// Timezone values can be:
+// - a string such as 'pst8pdt'
+// - an identifier such as "pst8pdt"
+// - an integer or floating point number
+// - a time interval per SQL99
+zone_value:
+  SCONST
+  {
+    $$.val = tree.NewStrVal($1)
+  }
+| IDENT
+  {
+    $$.val = tree.NewStrVal($1)
+  }
+| interval_value
+  {
+    $$.val = $1.expr()
+  }
+| numeric_only
+| DEFAULT
+  {
+    $$.val = tree.DefaultVal{}
+  }
+| LOCAL
+  {
+    $$.val = tree.NewStrVal($1)
+  }
+
+// %Help: SHOW
+// %Category: Group
+// %Text:
+// SHOW BACKUP, SHOW CLUSTER SETTING, SHOW COLUMNS, SHOW CONSTRAINTS,
+// SHOW CREATE, SHOW DATABASES, SHOW HISTOGRAM, SHOW INDEXES, SHOW
+// PARTITIONS, SHOW JOBS, SHOW QUERIES, SHOW RANGE, SHOW RANGES,
+// SHOW ROLES, SHOW SCHEDULES, SHOW SCHEMAS, SHOW SEQUENCES, SHOW SESSION, SHOW SESSIONS,
+// SHOW STATISTICS, SHOW SYNTAX, SHOW TABLES, SHOW TRACE SHOW TRANSACTION, SHOW USERS
+show_stmt:
+  show_backup_stmt          // EXTEND WITH HELP: SHOW BACKUP
+| show_columns_stmt         // EXTEND WITH HELP: SHOW COLUMNS
+| show_constraints_stmt     // EXTEND WITH HELP: SHOW CONSTRAINTS
+| show_create_stmt          // EXTEND WITH HELP: SHOW CREATE
+| show_csettings_stmt       // EXTEND WITH HELP: SHOW CLUSTER SETTING
+| show_databases_stmt       // EXTEND WITH HELP: SHOW DATABASES
+| show_fingerprints_stmt
+| show_grants_stmt          // EXTEND WITH HELP: SHOW GRANTS
+| show_histogram_stmt       // EXTEND WITH HELP: SHOW HISTOGRAM
+| show_indexes_stmt         // EXTEND WITH HELP: SHOW INDEXES
+| show_partitions_stmt      // EXTEND WITH HELP: SHOW PARTITIONS
+| show_jobs_stmt            // EXTEND WITH HELP: SHOW JOBS
+| show_queries_stmt         // EXTEND WITH HELP: SHOW QUERIES
+| show_ranges_stmt          // EXTEND WITH HELP: SHOW RANGES
+| show_range_for_row_stmt
+| show_roles_stmt           // EXTEND WITH HELP: SHOW ROLES
+| show_savepoint_stmt       // EXTEND WITH HELP: SHOW SAVEPOINT
+| show_schedules_stmt       // EXTEND WITH HELP: SHOW SCHEDULES
+| show_schemas_stmt         // EXTEND WITH HELP: SHOW SCHEMAS
+| show_sequences_stmt       // EXTEND WITH HELP: SHOW SEQUENCES
+| show_session_stmt         // EXTEND WITH HELP: SHOW SESSION
+| show_sessions_stmt        // EXTEND WITH HELP: SHOW SESSIONS
+| show_stats_stmt           // EXTEND WITH HELP: SHOW STATISTICS
+| show_syntax_stmt          // EXTEND WITH HELP: SHOW SYNTAX
+| show_tables_stmt          // EXTEND WITH HELP: SHOW TABLES
+| show_trace_stmt           // EXTEND WITH HELP: SHOW TRACE
+| show_transaction_stmt     // EXTEND WITH HELP: SHOW TRANSACTION
+| show_users_stmt           // EXTEND WITH HELP: SHOW USERS
+| show_zone_stmt
+| SHOW error                // SHOW HELP: SHOW
+
+// %Help: CLOSE - close a cursor
+// %Category: Misc
+// %Text: CLOSE { <cursor_name> | ALL }
+// %SeeAlso: DECLARE, FETCH, MOVE
+close_cursor_stmt:
+  CLOSE ALL
+  {
+    $$.val = &tree.CloseCursor{All: true}
+  }
+| CLOSE cursor_name
+  {
+    $$.val = &tree.CloseCursor{Name: tree.Name($2)}
+  }
+| CLOSE error // SHOW HELP: CLOSE
+
+// %Help: DECLARE - declare a cursor
+// %Category: Misc
+// %Text:
+// DECLARE <cursor_name> [ BINARY ] [ INSENSITIVE | ASENSITIVE ] [ [ NO ] SCROLL ]
+//   CURSOR [ { WITH | WITHOUT } HOLD ] FOR <selectclause>
+//
+// Cursors can only be declared inside a transaction block, and are closed
+// when the transaction ends.
+// %SeeAlso: CLOSE, FETCH, MOVE
+declare_cursor_stmt:
+  DECLARE cursor_name opt_binary opt_sensitivity opt_scroll CURSOR opt_hold FOR select_stmt
+  {
+    $$.val = &tree.DeclareCursor{
+      Name: tree.Name($2),
+      Binary: $3.bool(),
+      Sensitivity: $4.cursorSensitivity(),
+      Scroll: $5.cursorScrollOption(),
+      Hold: $7.bool(),
+      Select: $9.slct(),
+    }
+  }
+| DECLARE error // SHOW HELP: DECLARE
+
+opt_binary:
+  BINARY
+  {
+    $$.val = true
+  }
+| /* EMPTY */
+  {
+    $$.val = false
+  }
+
+opt_sensitivity:
+  INSENSITIVE
+  {
+    $$.val = tree.Insensitive
+  }
+| ASENSITIVE
+  {
+    $$.val = tree.Asensitive
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.UnspecifiedSensitivity
+  }
+
+opt_scroll:
+  SCROLL
+  {
+    $$.val = tree.Scroll
+  }
+| NO SCROLL
+  {
+    $$.val = tree.NoScroll
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.UnspecifiedScroll
+  }
+
+opt_hold:
+  WITH HOLD
+  {
+    $$.val = true
+  }
+| WITHOUT HOLD
+  {
+    $$.val = false
+  }
+| /* EMPTY */
+  {
+    $$.val = false
+  }
+
+// %Help: FETCH - fetch rows from a cursor
+// %Category: Misc
+// %Text:
+// FETCH [ <direction> [ FROM | IN ] ] <cursor_name>
+//
+// Direction:
+//    NEXT
+//    FORWARD
+//    [ FORWARD ] <count>
+//    [ FORWARD ] ALL
+//    RELATIVE <count>
+//    ABSOLUTE <count>
+//    FIRST
+// %SeeAlso: CLOSE, DECLARE, MOVE
+fetch_cursor_stmt:
+  FETCH cursor_movement_specifier
+  {
+    $$.val = &tree.FetchCursor{CursorStmt: $2.cursorStmt()}
+  }
+| FETCH error // SHOW HELP: FETCH
+
+// %Help: MOVE - move a cursor without fetching rows
+// %Category: Misc
+// %Text:
+// MOVE [ <direction> [ FROM | IN ] ] <cursor_name>
+//
+// Direction:
+//    NEXT
+//    FORWARD
+//    [ FORWARD ] <count>
+//    [ FORWARD ] ALL
+//    RELATIVE <count>
+//    ABSOLUTE <count>
+//    FIRST
+// %SeeAlso: CLOSE, DECLARE, FETCH
+move_cursor_stmt:
+  MOVE cursor_movement_specifier
+  {
+    $$.val = &tree.MoveCursor{CursorStmt: $2.cursorStmt()}
+  }
+| MOVE error // SHOW HELP: MOVE
+
+cursor_movement_specifier:
+  cursor_name
+  {
+    $$.val = tree.CursorStmt{Name: tree.Name($1), Count: 1}
+  }
+| from_or_in cursor_name
+  {
+    $$.val = tree.CursorStmt{Name: tree.Name($2), Count: 1}
+  }
+| next_prior opt_from_or_in cursor_name
+  {
+    $$.val = tree.CursorStmt{Name: tree.Name($3), Count: $1.int64()}
+  }
+| forward_backward opt_from_or_in cursor_name
+  {
+    $$.val = tree.CursorStmt{Name: tree.Name($3), Count: $1.int64()}
+  }
+| opt_forward_backward signed_iconst64 opt_from_or_in cursor_name
+  {
+    $$.val = tree.CursorStmt{Name: tree.Name($4), Count: $2.int64() * $1.int64()}
+  }
+| opt_forward_backward ALL opt_from_or_in cursor_name
   {
-    $$.val = tree.NewStrVal($1)
+    fetchType := tree.FetchAll
+    if $1.int64() < 0 {
+      fetchType = tree.FetchBackwardAll
+    }
+    $$.val = tree.CursorStmt{Name: tree.Name($4), FetchType: fetchType}
   }
-| IDENT
+| ABSOLUTE signed_iconst64 opt_from_or_in cursor_name
   {
-    $$.val = tree.NewStrVal($1)
+    $$.val = tree.CursorStmt{Name: tree.Name($4), FetchType: tree.FetchAbsolute, Count: $2.int64()}
   }
-| interval_value
+| RELATIVE signed_iconst64 opt_from_or_in cursor_name
   {
-    $$.val = $1.expr()
+    $$.val = tree.CursorStmt{Name: tree.Name($4), FetchType: tree.FetchRelative, Count: $2.int64()}
   }
-| numeric_only
-| DEFAULT
+| FIRST opt_from_or_in cursor_name
   {
-    $$.val = tree.DefaultVal{}
+    $$.val = tree.CursorStmt{Name: tree.Name($3), FetchType: tree.FetchFirst}
   }
-| LOCAL
+| LAST opt_from_or_in cursor_name
   {
-    $$.val = tree.NewStrVal($1)
+    $$.val = tree.CursorStmt{Name: tree.Name($3), FetchType: tree.FetchLast}
   }
 
-// %Help: SHOW
-// %Category: Group
-// %Text:
-// SHOW BACKUP, SHOW CLUSTER SETTING, SHOW COLUMNS, SHOW CONSTRAINTS,
-// SHOW CREATE, SHOW DATABASES, SHOW HISTOGRAM, SHOW INDEXES, SHOW
-// PARTITIONS, SHOW JOBS, SHOW QUERIES, SHOW RANGE, SHOW RANGES,
-// SHOW ROLES, SHOW SCHEMAS, SHOW SEQUENCES, SHOW SESSION, SHOW SESSIONS,
-// SHOW STATISTICS, SHOW SYNTAX, SHOW TABLES, SHOW TRACE SHOW TRANSACTION, SHOW USERS
-show_stmt:
-  show_backup_stmt          // EXTEND WITH HELP: SHOW BACKUP
-| show_columns_stmt         // EXTEND WITH HELP: SHOW COLUMNS
-| show_constraints_stmt     // EXTEND WITH HELP: SHOW CONSTRAINTS
-| show_create_stmt          // EXTEND WITH HELP: SHOW CREATE
-| show_csettings_stmt       // EXTEND WITH HELP: SHOW CLUSTER SETTING
-| show_databases_stmt       // EXTEND WITH HELP: SHOW DATABASES
-| show_fingerprints_stmt
-| show_grants_stmt          // EXTEND WITH HELP: SHOW GRANTS
-| show_histogram_stmt       // EXTEND WITH HELP: SHOW HISTOGRAM
-| show_indexes_stmt         // EXTEND WITH HELP: SHOW INDEXES
-| show_partitions_stmt      // EXTEND WITH HELP: SHOW PARTITIONS
-| show_jobs_stmt            // EXTEND WITH HELP: SHOW JOBS
-| show_queries_stmt         // EXTEND WITH HELP: SHOW QUERIES
-| show_ranges_stmt          // EXTEND WITH HELP: SHOW RANGES
-| show_range_for_row_stmt
-| show_roles_stmt           // EXTEND WITH HELP: SHOW ROLES
-| show_savepoint_stmt       // EXTEND WITH HELP: SHOW SAVEPOINT
-| show_schemas_stmt         // EXTEND WITH HELP: SHOW SCHEMAS
-| show_sequences_stmt       // EXTEND WITH HELP: SHOW SEQUENCES
-| show_session_stmt         // EXTEND WITH HELP: SHOW SESSION
-| show_sessions_stmt        // EXTEND WITH HELP: SHOW SESSIONS
-| show_stats_stmt           // EXTEND WITH HELP: SHOW STATISTICS
-| show_syntax_stmt          // EXTEND WITH HELP: SHOW SYNTAX
-| show_tables_stmt          // EXTEND WITH HELP: SHOW TABLES
-| show_trace_stmt           // EXTEND WITH HELP: SHOW TRACE
-| show_transaction_stmt     // EXTEND WITH HELP: SHOW TRANSACTION
-| show_users_stmt           // EXTEND WITH HELP: SHOW USERS
-| show_zone_stmt
-| SHOW error                // SHOW HELP: SHOW
+next_prior:
+  NEXT  { $$.val = int64(1) }
+| PRIOR { $$.val = int64(-1) }
 
-// Cursors are not yet supported by CockroachDB. CLOSE ALL is safe to no-op
-// since there will be no open cursors.
-close_cursor_stmt:
-	CLOSE ALL { }
-| CLOSE cursor_name { return unimplementedWithIssue(sqllex, 41412) }
+opt_forward_backward:
+  forward_backward { $$.val = $1.int64() }
+| /* EMPTY */ { $$.val = int64(1) }
 
-declare_cursor_stmt:
-	DECLARE { return unimplementedWithIssue(sqllex, 41412) }
+forward_backward:
+  FORWARD  { $$.val = int64(1) }
+| BACKWARD { $$.val = int64(-1) }
+
+opt_from_or_in:
+  from_or_in { }
+| /* EMPTY */ { }
+
+from_or_in:
+  FROM { }
+| IN { }
 
 reindex_stmt:
   REINDEX TABLE error
@@ -3661,6 +4480,21 @@ reindex_stmt:
     return purposelyUnimplemented(sqllex, "reindex system", "CockroachDB does not require reindexing.")
   }
 
+// %Help: REFRESH - recalculate a materialized view
+// %Category: Misc
+// %Text:
+// REFRESH MATERIALIZED VIEW [CONCURRENTLY] view_name
+// %SeeAlso: CREATE VIEW
+refresh_stmt:
+  REFRESH MATERIALIZED VIEW opt_concurrently view_name
+  {
+    $$.val = &tree.RefreshMaterializedView{
+      Name: $5.unresolvedObjectName(),
+      Concurrently: $4.bool(),
+    }
+  }
+| REFRESH error // SHOW HELP: REFRESH
+
 // %Help: SHOW SESSION - display session variables
 // %Category: Cfg
 // %Text: SHOW [SESSION] { <var> | ALL }
@@ -3728,10 +4562,28 @@ show_histogram_stmt:
 
 // %Help: SHOW BACKUP - list backup contents
 // %Category: CCL
-// %Text: SHOW BACKUP [SCHEMAS|FILES|RANGES] <location>
+// %Text:
+// SHOW BACKUP [SCHEMAS|FILES|RANGES] <location>
+// SHOW BACKUP {<subdir> | LATEST} IN <collection>
+// SHOW BACKUPS IN <collection>
 // %SeeAlso: WEBDOCS/show-backup.html
 show_backup_stmt:
-  SHOW BACKUP string_or_placeholder opt_with_options
+  SHOW BACKUPS IN string_or_placeholder
+  {
+    $$.val = &tree.ShowBackups{
+      InCollection: $4.expr(),
+    }
+  }
+| SHOW BACKUP restore_subdir IN string_or_placeholder opt_with_backup_options
+  {
+    $$.val = &tree.ShowBackup{
+      Details:      tree.BackupDefaultDetails,
+      Path:         $3.expr(),
+      InCollection: $5.expr(),
+      Options:      $6.kvOptions(),
+    }
+  }
+| SHOW BACKUP string_or_placeholder opt_with_backup_options
   {
     $$.val = &tree.ShowBackup{
       Details: tree.BackupDefaultDetails,
@@ -3739,7 +4591,7 @@ show_backup_stmt:
       Options: $4.kvOptions(),
     }
   }
-| SHOW BACKUP SCHEMAS string_or_placeholder opt_with_options
+| SHOW BACKUP SCHEMAS string_or_placeholder opt_with_backup_options
   {
     $$.val = &tree.ShowBackup{
       Details: tree.BackupDefaultDetails,
@@ -3748,7 +4600,7 @@ show_backup_stmt:
       Options: $5.kvOptions(),
     }
   }
-| SHOW BACKUP RANGES string_or_placeholder opt_with_options
+| SHOW BACKUP RANGES string_or_placeholder opt_with_backup_options
   {
     /* SKIP DOC */
     $$.val = &tree.ShowBackup{
@@ -3757,7 +4609,7 @@ show_backup_stmt:
       Options: $5.kvOptions(),
     }
   }
-| SHOW BACKUP FILES string_or_placeholder opt_with_options
+| SHOW BACKUP FILES string_or_placeholder opt_with_backup_options
   {
     /* SKIP DOC */
     $$.val = &tree.ShowBackup{
@@ -3767,6 +4619,7 @@ show_backup_stmt:
     }
   }
 | SHOW BACKUP error // SHOW HELP: SHOW BACKUP
+| SHOW BACKUPS error // SHOW HELP: SHOW BACKUP
 
 // %Help: SHOW CLUSTER SETTING - display cluster settings
 // %Category: Cfg
@@ -3983,6 +4836,24 @@ show_jobs_stmt:
   }
 | SHOW JOB error // SHOW HELP: SHOW JOBS
 
+// %Help: SHOW SCHEDULES - list periodic schedules
+// %Category: Misc
+// %Text:
+// SHOW SCHEDULES
+// SHOW SCHEDULE <schedule_id>
+// %SeeAlso: PAUSE SCHEDULES, RESUME SCHEDULES, DROP SCHEDULES
+show_schedules_stmt:
+  SHOW SCHEDULES
+  {
+    $$.val = &tree.ShowSchedules{}
+  }
+| SHOW SCHEDULES error // SHOW HELP: SHOW SCHEDULES
+| SHOW SCHEDULE a_expr
+  {
+    $$.val = &tree.ShowSchedules{ScheduleID: $3.expr()}
+  }
+| SHOW SCHEDULE error  // SHOW HELP: SHOW SCHEDULES
+
 // %Help: SHOW TRACE - display an execution trace
 // %Category: Misc
 // %Text:
@@ -4485,13 +5356,22 @@ for_grantee_clause:
     $$.val = tree.NameList(nil)
   }
 
+// %Help: PAUSE
+// %Category: Misc
+// %Text:
+// PAUSE JOBS, PAUSE SCHEDULES
+pause_stmt:
+  pause_jobs_stmt       // EXTEND WITH HELP: PAUSE JOBS
+| pause_schedules_stmt  // EXTEND WITH HELP: PAUSE SCHEDULES
+| PAUSE error           // SHOW HELP: PAUSE
+
 // %Help: PAUSE JOBS - pause background jobs
 // %Category: Misc
 // %Text:
 // PAUSE JOBS <selectclause>
 // PAUSE JOB <jobid>
 // %SeeAlso: SHOW JOBS, CANCEL JOBS, RESUME JOBS
-pause_stmt:
+pause_jobs_stmt:
   PAUSE JOB a_expr
   {
     $$.val = &tree.ControlJobs{
@@ -4501,11 +5381,39 @@ pause_stmt:
       Command: tree.PauseJob,
     }
   }
+| PAUSE JOB error // SHOW HELP: PAUSE JOBS
 | PAUSE JOBS select_stmt
   {
     $$.val = &tree.ControlJobs{Jobs: $3.slct(), Command: tree.PauseJob}
   }
-| PAUSE error // SHOW HELP: PAUSE JOBS
+| PAUSE JOBS error // SHOW HELP: PAUSE JOBS
+
+// %Help: PAUSE SCHEDULES - pause scheduled jobs
+// %Category: Misc
+// %Text:
+// PAUSE SCHEDULES <selectclause>
+//   select clause: select statement returning schedule id to pause.
+// PAUSE SCHEDULE <scheduleID>
+// %SeeAlso: RESUME SCHEDULES, SHOW JOBS, CANCEL JOBS
+pause_schedules_stmt:
+  PAUSE SCHEDULE a_expr
+  {
+    $$.val = &tree.ControlSchedules{
+      Schedules: &tree.Select{
+        Select: &tree.ValuesClause{Rows: []tree.Exprs{tree.Exprs{$3.expr()}}},
+      },
+      Command: tree.PauseSchedule,
+    }
+  }
+| PAUSE SCHEDULE error // SHOW HELP: PAUSE SCHEDULES
+| PAUSE SCHEDULES select_stmt
+  {
+    $$.val = &tree.ControlSchedules{
+      Schedules: $3.slct(),
+      Command: tree.PauseSchedule,
+    }
+  }
+| PAUSE SCHEDULES error // SHOW HELP: PAUSE SCHEDULES
 
 // %Help: CREATE SCHEMA - create a new schema (not yet supported)
 // %Category: DDL
@@ -4551,7 +5459,7 @@ create_schema_stmt:
 //   FAMILY <familyname>, CREATE [IF NOT EXISTS] FAMILY [<familyname>]
 //   REFERENCES <tablename> [( <colnames...> )] [ON DELETE {NO ACTION | RESTRICT}] [ON UPDATE {NO ACTION | RESTRICT}]
 //   COLLATE <collationname>
-//   AS ( <expr> ) STORED
+//   AS ( <expr> ) {STORED | VIRTUAL}
 //
 // Interleave clause:
 //    INTERLEAVE IN PARENT <tablename> ( <colnames...> ) [CASCADE | RESTRICT]
@@ -4992,7 +5900,7 @@ col_qualification_elem:
   {
     $$.val = &tree.ColumnDefault{Expr: $2.expr()}
   }
-| REFERENCES table_name opt_name_parens key_match reference_actions
+| REFERENCES table_name opt_name_parens key_match reference_actions opt_deferrable
  {
     name := $2.unresolvedObjectName().ToTableName()
     $$.val = &tree.ColumnFKConstraint{
@@ -5000,6 +5908,7 @@ col_qualification_elem:
       Col: tree.Name($3),
       Actions: $5.referenceActions(),
       Match: $4.compositeKeyMatchMethod(),
+      Deferrable: $6.constraintDeferrability(),
     }
  }
 | generated_as '(' a_expr ')' STORED
@@ -5008,7 +5917,7 @@ col_qualification_elem:
  }
 | generated_as '(' a_expr ')' VIRTUAL
  {
-    return unimplemented(sqllex, "virtual computed columns")
+    $$.val = &tree.ColumnComputedDef{Expr: $3.expr(), Virtual: true}
  }
 | generated_as error
  {
@@ -5085,12 +5994,20 @@ table_constraint:
 constraint_elem:
   CHECK '(' a_expr ')' opt_deferrable
   {
+    if $5.constraintDeferrability() != tree.NotDeferrable {
+      return unimplementedWithIssueDetail(sqllex, 31632, "deferrable check")
+    }
     $$.val = &tree.CheckConstraintTableDef{
       Expr: $3.expr(),
     }
   }
 | UNIQUE '(' index_params ')' opt_storing opt_interleave opt_partition_by opt_deferrable opt_where_clause
   {
+    // Uniqueness is enforced by the index when the rows are written, so only
+    // foreign key constraints can be deferred; DEFERRABLE UNIQUE is unsupported.
+    if $8.constraintDeferrability() != tree.NotDeferrable {
+      return unimplementedWithIssueDetail(sqllex, 31632, "deferrable unique")
+    }
     $$.val = &tree.UniqueConstraintTableDef{
       IndexTableDef: tree.IndexTableDef{
         Columns: $3.idxElems(),
@@ -5122,6 +6039,7 @@ constraint_elem:
       ToCols: $8.nameList(),
       Match: $9.compositeKeyMatchMethod(),
       Actions: $10.referenceActions(),
+      Deferrable: $11.constraintDeferrability(),
     }
   }
 | EXCLUDE USING error
@@ -5231,13 +6149,33 @@ create_as_col_qualification_elem:
     $$.val = tree.PrimaryKeyConstraint{}
   }
 
+// INITIALLY DEFERRED implies DEFERRABLE; INITIALLY IMMEDIATE alone is the
+// default (NOT DEFERRABLE).
 opt_deferrable:
-  /* EMPTY */ { /* no error */ }
-| DEFERRABLE { return unimplementedWithIssueDetail(sqllex, 31632, "deferrable") }
-| DEFERRABLE INITIALLY DEFERRED { return unimplementedWithIssueDetail(sqllex, 31632, "def initially deferred") }
-| DEFERRABLE INITIALLY IMMEDIATE { return unimplementedWithIssueDetail(sqllex, 31632, "def initially immediate") }
-| INITIALLY DEFERRED { return unimplementedWithIssueDetail(sqllex, 31632, "initially deferred") }
-| INITIALLY IMMEDIATE { return unimplementedWithIssueDetail(sqllex, 31632, "initially immediate") }
+  /* EMPTY */
+  {
+    $$.val = tree.NotDeferrable
+  }
+| DEFERRABLE
+  {
+    $$.val = tree.DeferrableInitiallyImmediate
+  }
+| DEFERRABLE INITIALLY DEFERRED
+  {
+    $$.val = tree.DeferrableInitiallyDeferred
+  }
+| DEFERRABLE INITIALLY IMMEDIATE
+  {
+    $$.val = tree.DeferrableInitiallyImmediate
+  }
+| INITIALLY DEFERRED
+  {
+    $$.val = tree.DeferrableInitiallyDeferred
+  }
+| INITIALLY IMMEDIATE
+  {
+    $$.val = tree.NotDeferrable
+  }
 
 storing:
   COVERING
@@ -5525,8 +6463,10 @@ role_or_group_or_user:
 
 // %Help: CREATE VIEW - create a new view
 // %Category: DDL
-// %Text: CREATE [TEMPORARY | TEMP] VIEW <viewname> [( <colnames...> )] AS <source>
-// %SeeAlso: CREATE TABLE, SHOW CREATE, WEBDOCS/create-view.html
+// %Text:
+// CREATE [TEMPORARY | TEMP] VIEW <viewname> [( <colnames...> )] AS <source>
+// CREATE MATERIALIZED VIEW <viewname> [( <colnames...> )] AS <source>
+// %SeeAlso: CREATE TABLE, REFRESH, SHOW CREATE, WEBDOCS/create-view.html
 create_view_stmt:
   CREATE opt_temp opt_view_recursive VIEW view_name opt_column_list AS select_stmt
   {
@@ -5566,7 +6506,29 @@ create_view_stmt:
       Replace: false,
     }
   }
+| CREATE MATERIALIZED VIEW view_name opt_column_list AS select_stmt
+  {
+    name := $4.unresolvedObjectName().ToTableName()
+    $$.val = &tree.CreateView{
+      Name: name,
+      ColumnNames: $5.nameList(),
+      AsSource: $7.slct(),
+      Materialized: true,
+    }
+  }
+| CREATE MATERIALIZED VIEW IF NOT EXISTS view_name opt_column_list AS select_stmt
+  {
+    name := $7.unresolvedObjectName().ToTableName()
+    $$.val = &tree.CreateView{
+      Name: name,
+      ColumnNames: $8.nameList(),
+      AsSource: $10.slct(),
+      IfNotExists: true,
+      Materialized: true,
+    }
+  }
 | CREATE opt_temp opt_view_recursive VIEW error // SHOW HELP: CREATE VIEW
+| CREATE MATERIALIZED VIEW error // SHOW HELP: CREATE VIEW
 
 role_option:
   CREATEROLE
@@ -5623,9 +6585,183 @@ opt_view_recursive:
 | RECURSIVE { return unimplemented(sqllex, "create recursive view") }
 
 
+// %Help: CREATE FUNCTION - define a new function
+// %Category: DDL
+// %Text:
+// CREATE [OR REPLACE] FUNCTION <name> ( [ [<argname>] <argtype> [, ...] ] )
+//   RETURNS <rettype>
+//   { LANGUAGE SQL
+//   | IMMUTABLE | STABLE | VOLATILE
+//   | CALLED ON NULL INPUT | RETURNS NULL ON NULL INPUT | STRICT
+//   | AS '<definition>'
+//   } ...
+// %SeeAlso: DROP FUNCTION, WEBDOCS/create-function.html
+create_function_stmt:
+  CREATE opt_or_replace FUNCTION db_object_name '(' opt_func_param_list ')' RETURNS typename create_func_opt_list
+  {
+    $$.val = &tree.CreateFunction{
+      FuncName: $4.unresolvedObjectName(),
+      OrReplace: $2.bool(),
+      Params: $6.funcParams(),
+      ReturnType: $9.typeReference(),
+      Options: $10.funcOptions(),
+    }
+  }
+| CREATE opt_or_replace FUNCTION error // SHOW HELP: CREATE FUNCTION
+
+// %Help: CREATE TRIGGER - define a new trigger
+// %Category: DDL
+// %Text:
+// CREATE TRIGGER <name> { BEFORE | AFTER } <event> [OR <event> ...]
+//   ON <tablename> FOR EACH ROW AS '<statements>'
+//
+// Events:
+//   INSERT, UPDATE, DELETE
+//
+// The statements are run for every modified row and can refer to the
+// values of the row using NEW.<colname> and OLD.<colname>.
+// %SeeAlso: DROP TRIGGER, WEBDOCS/create-trigger.html
+create_trigger_stmt:
+  CREATE TRIGGER name trigger_action_time trigger_event_list ON table_name FOR EACH ROW AS SCONST
+  {
+    $$.val = &tree.CreateTrigger{
+      Name: tree.Name($3),
+      ActionTime: $4.triggerActionTime(),
+      Events: $5.triggerEvents(),
+      Table: $7.unresolvedObjectName(),
+      Body: $12,
+    }
+  }
+| CREATE TRIGGER name trigger_action_time trigger_event_list ON table_name FOR EACH ROW EXECUTE error
+  {
+    return unimplementedWithIssueDetail(sqllex, 28296, "execute function")
+  }
+| CREATE TRIGGER name trigger_action_time trigger_event_list ON table_name FOR EACH STATEMENT error
+  {
+    return unimplementedWithIssueDetail(sqllex, 28296, "for each statement")
+  }
+| CREATE TRIGGER error // SHOW HELP: CREATE TRIGGER
+
+trigger_action_time:
+  BEFORE
+  {
+    $$.val = tree.TriggerBefore
+  }
+| AFTER
+  {
+    $$.val = tree.TriggerAfter
+  }
+
+trigger_event_list:
+  trigger_event
+  {
+    $$.val = tree.TriggerEvents{$1.triggerEvent()}
+  }
+| trigger_event_list OR trigger_event
+  {
+    $$.val = append($1.triggerEvents(), $3.triggerEvent())
+  }
+
+trigger_event:
+  INSERT
+  {
+    $$.val = tree.TriggerInsert
+  }
+| UPDATE
+  {
+    $$.val = tree.TriggerUpdate
+  }
+| UPDATE OF
+  {
+    return unimplementedWithIssueDetail(sqllex, 28296, "update of")
+  }
+| DELETE
+  {
+    $$.val = tree.TriggerDelete
+  }
+| TRUNCATE
+  {
+    return unimplementedWithIssueDetail(sqllex, 28296, "truncate")
+  }
+
+opt_func_param_list:
+  func_param_list
+| /* EMPTY */
+  {
+    $$.val = tree.FuncParams(nil)
+  }
+
+func_param_list:
+  func_param
+  {
+    $$.val = tree.FuncParams{$1.funcParam()}
+  }
+| func_param_list ',' func_param
+  {
+    $$.val = append($1.funcParams(), $3.funcParam())
+  }
+
+func_param:
+  type_function_name typename
+  {
+    $$.val = tree.FuncParam{Name: tree.Name($1), Type: $2.typeReference()}
+  }
+| typename
+  {
+    $$.val = tree.FuncParam{Type: $1.typeReference()}
+  }
+
+create_func_opt_list:
+  create_func_opt_item
+| create_func_opt_list create_func_opt_item
+  {
+    opts := $1.funcOptions()
+    if err := opts.Merge($2.funcOptions()); err != nil {
+      return setErr(sqllex, err)
+    }
+    $$.val = opts
+  }
+
+create_func_opt_item:
+  LANGUAGE non_reserved_word_or_sconst
+  {
+    $$.val = tree.FuncOptions{Language: $2}
+  }
+| AS SCONST
+  {
+    body := $2
+    $$.val = tree.FuncOptions{Body: &body}
+  }
+| IMMUTABLE
+  {
+    $$.val = tree.FuncOptions{Volatility: tree.VolatilityImmutable}
+  }
+| STABLE
+  {
+    $$.val = tree.FuncOptions{Volatility: tree.VolatilityStable}
+  }
+| VOLATILE
+  {
+    $$.val = tree.FuncOptions{Volatility: tree.VolatilityVolatile}
+  }
+| CALLED ON NULL INPUT
+  {
+    $$.val = tree.FuncOptions{NullInput: tree.FuncCalledOnNullInput}
+  }
+| RETURNS NULL ON NULL INPUT
+  {
+    $$.val = tree.FuncOptions{NullInput: tree.FuncReturnsNullOnNullInput}
+  }
+| STRICT
+  {
+    $$.val = tree.FuncOptions{NullInput: tree.FuncStrict}
+  }
+
 // %Help: CREATE TYPE -- create a type
 // %Category: DDL
-// %Text: CREATE TYPE <type_name> AS ENUM (...)
+// %Text:
+// CREATE TYPE <type_name> AS ENUM (...)
+// CREATE TYPE <type_name> AS ( <attr_name> <type> [, ...] )
 // %SeeAlso: WEBDOCS/create-type.html
 create_type_stmt:
   // Enum types.
@@ -5637,9 +6773,16 @@ create_type_stmt:
       EnumLabels: $7.strs(),
     }
   }
-| CREATE TYPE error // SHOW HELP: CREATE TYPE
   // Record/Composite types.
-| CREATE TYPE type_name AS '(' error      { return unimplementedWithIssue(sqllex, 27792) }
+| CREATE TYPE type_name AS '(' opt_composite_type_list ')'
+  {
+    $$.val = &tree.CreateType{
+      TypeName: $3.unresolvedObjectName(),
+      Variety: tree.Composite,
+      CompositeTypeList: $6.compositeTypeList(),
+    }
+  }
+| CREATE TYPE error // SHOW HELP: CREATE TYPE
   // Range types.
 | CREATE TYPE type_name AS RANGE error    { return unimplementedWithIssue(sqllex, 27791) }
   // Base (primitive) types.
@@ -5669,6 +6812,26 @@ enum_val_list:
     $$.val = append($1.strs(), $3)
   }
 
+opt_composite_type_list:
+  composite_type_list
+  {
+    $$.val = $1.compositeTypeList()
+  }
+| /* EMPTY */
+  {
+    $$.val = []tree.CompositeTypeElem(nil)
+  }
+
+composite_type_list:
+  name typename
+  {
+    $$.val = []tree.CompositeTypeElem{{Label: tree.Name($1), Type: $2.typeReference()}}
+  }
+| composite_type_list ',' name typename
+  {
+    $$.val = append($1.compositeTypeList(), tree.CompositeTypeElem{Label: tree.Name($3), Type: $4.typeReference()})
+  }
+
 // %Help: CREATE INDEX - create a new index
 // %Category: DDL
 // %Text:
@@ -5813,15 +6976,6 @@ index_elem:
     e := $1.expr()
     dir := $2.dir()
     nullsOrder := $3.nullsOrder()
-    // We currently only support the opposite of Postgres defaults.
-    if nullsOrder != tree.DefaultNullsOrder {
-      if dir == tree.Descending && nullsOrder == tree.NullsFirst {
-        return unimplementedWithIssue(sqllex, 6224)
-      }
-      if dir != tree.Descending && nullsOrder == tree.NullsLast {
-        return unimplementedWithIssue(sqllex, 6224)
-      }
-    }
     if colName, ok := e.(*tree.UnresolvedName); ok && colName.NumParts == 1 {
       $$.val = tree.IndexElem{Column: tree.Name(colName.Parts[0]), Direction: dir, NullsOrder: nullsOrder}
     } else {
@@ -5924,13 +7078,22 @@ release_stmt:
   }
 | RELEASE error // SHOW HELP: RELEASE
 
+// %Help: RESUME
+// %Category: Misc
+// %Text:
+// RESUME JOBS, RESUME SCHEDULES
+resume_stmt:
+  resume_jobs_stmt       // EXTEND WITH HELP: RESUME JOBS
+| resume_schedules_stmt  // EXTEND WITH HELP: RESUME SCHEDULES
+| RESUME error           // SHOW HELP: RESUME
+
 // %Help: RESUME JOBS - resume background jobs
 // %Category: Misc
 // %Text:
 // RESUME JOBS <selectclause>
 // RESUME JOB <jobid>
 // %SeeAlso: SHOW JOBS, CANCEL JOBS, PAUSE JOBS
-resume_stmt:
+resume_jobs_stmt:
   RESUME JOB a_expr
   {
     $$.val = &tree.ControlJobs{
@@ -5940,11 +7103,39 @@ resume_stmt:
       Command: tree.ResumeJob,
     }
   }
+| RESUME JOB error // SHOW HELP: RESUME JOBS
 | RESUME JOBS select_stmt
   {
     $$.val = &tree.ControlJobs{Jobs: $3.slct(), Command: tree.ResumeJob}
   }
-| RESUME error // SHOW HELP: RESUME JOBS
+| RESUME JOBS error // SHOW HELP: RESUME JOBS
+
+// %Help: RESUME SCHEDULES - resume executing scheduled jobs
+// %Category: Misc
+// %Text:
+// RESUME SCHEDULES <selectclause>
+//   select clause: select statement returning schedule id to resume.
+// RESUME SCHEDULE <scheduleID>
+// %SeeAlso: PAUSE SCHEDULES, SHOW JOBS, RESUME JOBS
+resume_schedules_stmt:
+  RESUME SCHEDULE a_expr
+  {
+    $$.val = &tree.ControlSchedules{
+      Schedules: &tree.Select{
+        Select: &tree.ValuesClause{Rows: []tree.Exprs{tree.Exprs{$3.expr()}}},
+      },
+      Command: tree.ResumeSchedule,
+    }
+  }
+| RESUME SCHEDULE error // SHOW HELP: RESUME SCHEDULES
+| RESUME SCHEDULES select_stmt
+  {
+    $$.val = &tree.ControlSchedules{
+      Schedules: $3.slct(),
+      Command: tree.ResumeSchedule,
+    }
+  }
+| RESUME SCHEDULES error // SHOW HELP: RESUME SCHEDULES
 
 // %Help: SAVEPOINT - start a sub-transaction
 // %Category: Txn
@@ -6302,23 +7493,34 @@ insert_column_item:
 on_conflict:
   ON CONFLICT opt_conf_expr DO UPDATE SET set_clause_list opt_where_clause
   {
-    $$.val = &tree.OnConflict{Columns: $3.nameList(), Exprs: $7.updateExprs(), Where: tree.NewWhere(tree.AstWhere, $8.expr())}
+    oc := $3.onConflict()
+    oc.Exprs = $7.updateExprs()
+    oc.Where = tree.NewWhere(tree.AstWhere, $8.expr())
+    $$.val = oc
   }
 | ON CONFLICT opt_conf_expr DO NOTHING
   {
-    $$.val = &tree.OnConflict{Columns: $3.nameList(), DoNothing: true}
+    oc := $3.onConflict()
+    oc.DoNothing = true
+    $$.val = oc
   }
 
 opt_conf_expr:
   '(' name_list ')'
   {
-    $$.val = $2.nameList()
+    $$.val = &tree.OnConflict{Columns: $2.nameList()}
+  }
+| '(' name_list ')' where_clause
+  {
+    $$.val = &tree.OnConflict{Columns: $2.nameList(), ArbiterPredicate: $4.expr()}
+  }
+| ON CONSTRAINT constraint_name
+  {
+    $$.val = &tree.OnConflict{Constraint: tree.Name($3)}
   }
-| '(' name_list ')' where_clause { return unimplementedWithIssue(sqllex, 32557) }
-| ON CONSTRAINT constraint_name { return unimplementedWithIssue(sqllex, 28161) }
 | /* EMPTY */
   {
-    $$.val = tree.NameList(nil)
+    $$.val = &tree.OnConflict{}
   }
 
 returning_clause:
@@ -6844,15 +8046,6 @@ sortby:
     /* FORCE DOC */
     dir := $2.dir()
     nullsOrder := $3.nullsOrder()
-    // We currently only support the opposite of Postgres defaults.
-    if nullsOrder != tree.DefaultNullsOrder {
-      if dir == tree.Descending && nullsOrder == tree.NullsFirst {
-        return unimplementedWithIssue(sqllex, 6224)
-      }
-      if dir != tree.Descending && nullsOrder == tree.NullsLast {
-        return unimplementedWithIssue(sqllex, 6224)
-      }
-    }
     $$.val = &tree.Order{
       OrderType:  tree.OrderByColumn,
       Expr:       $1.expr(),
@@ -7557,20 +8750,19 @@ typename:
       $$.val = $1.typeReference()
     }
   }
-  // SQL standard syntax, currently only one-dimensional
+  // SQL standard syntax
   // Undocumented but support for potential Postgres compat
-| simple_typename ARRAY '[' ICONST ']' {
+| simple_typename ARRAY '[' ICONST ']' opt_array_bounds {
     /* SKIP DOC */
     var err error
-    $$.val, err = arrayOf($1.typeReference(), nil)
+    $$.val, err = arrayOf($1.typeReference(), append([]int32{-1}, $6.int32s()...))
     if err != nil {
       return setErr(sqllex, err)
     }
   }
-| simple_typename ARRAY '[' ICONST ']' '[' error { return unimplementedWithIssue(sqllex, 32552) }
 | simple_typename ARRAY {
     var err error
-    $$.val, err = arrayOf($1.typeReference(), nil)
+    $$.val, err = arrayOf($1.typeReference(), []int32{-1})
     if err != nil {
       return setErr(sqllex, err)
     }
@@ -7583,20 +8775,16 @@ cast_target:
   }
 
 opt_array_bounds:
-  // TODO(justin): reintroduce multiple array bounds
-  // opt_array_bounds '[' ']' { $$.val = append($1.int32s(), -1) }
-  '[' ']' { $$.val = []int32{-1} }
-| '[' ']' '[' error { return unimplementedWithIssue(sqllex, 32552) }
-| '[' ICONST ']'
+  opt_array_bounds '[' ']' { $$.val = append($1.int32s(), -1) }
+| opt_array_bounds '[' ICONST ']'
   {
     /* SKIP DOC */
-    bound, err := $2.numVal().AsInt32()
+    bound, err := $3.numVal().AsInt32()
     if err != nil {
       return setErr(sqllex, err)
     }
-    $$.val = []int32{bound}
+    $$.val = append($1.int32s(), bound)
   }
-| '[' ICONST ']' '[' error { return unimplementedWithIssue(sqllex, 32552) }
 | /* EMPTY */ { $$.val = []int32(nil) }
 
 // general_type_name is a variant of type_or_function_name but does not
@@ -8306,6 +9494,10 @@ a_expr:
   {
     $$.val = &tree.ComparisonExpr{Operator: tree.Overlaps, Left: $1.expr(), Right: $3.expr()}
   }
+| a_expr ADJACENT a_expr
+  {
+    $$.val = &tree.ComparisonExpr{Operator: tree.Adjacent, Left: $1.expr(), Right: $3.expr()}
+  }
 | a_expr INET_CONTAINS_OR_EQUALS a_expr
   {
     $$.val = &tree.FuncExpr{Func: tree.WrapFunction("inet_contains_or_equals"), Exprs: tree.Exprs{$1.expr(), $3.expr()}}
@@ -10226,6 +11418,7 @@ unrestricted_name:
 // "Unreserved" keywords --- available for use as any kind of name.
 unreserved_keyword:
   ABORT
+| ABSOLUTE
 | ACTION
 | ADD
 | ADMIN
@@ -10233,17 +11426,22 @@ unreserved_keyword:
 | AGGREGATE
 | ALTER
 | ALWAYS
+| ASENSITIVE
 | AT
 | ATTRIBUTE
 | AUTOMATIC
 | AUTHORIZATION
 | BACKUP
+| BACKUPS
+| BACKWARD
 | BEFORE
 | BEGIN
+| BINARY
 | BUCKET_COUNT
 | BUNDLE
 | BY
 | CACHE
+| CALLED
 | CANCEL
 | CASCADE
 | CHANGEFEED
@@ -10265,8 +11463,10 @@ unreserved_keyword:
 | COPY
 | COVERING
 | CREATEROLE
+| CSV
 | CUBE
 | CURRENT
+| CURSOR
 | CYCLE
 | DATA
 | DATABASE
@@ -10277,10 +11477,12 @@ unreserved_keyword:
 | DELETE
 | DEFAULTS
 | DEFERRED
+| DELIMITER
 | DISCARD
 | DOMAIN
 | DOUBLE
 | DROP
+| EACH
 | ENCODING
 | ENUM
 | ESCAPE
@@ -10301,6 +11503,8 @@ unreserved_keyword:
 | FIRST
 | FOLLOWING
 | FORCE_INDEX
+| FORMAT
+| FORWARD
 | FUNCTION
 | GENERATED
 | GEOMETRYCOLLECTION
@@ -10308,11 +11512,14 @@ unreserved_keyword:
 | GRANTS
 | GROUPS
 | HASH
+| HEADER
 | HIGH
 | HISTOGRAM
+| HOLD
 | HOUR
 | IDENTITY
 | IMMEDIATE
+| IMMUTABLE
 | IMPORT
 | INCLUDE
 | INCLUDING
@@ -10320,6 +11527,8 @@ unreserved_keyword:
 | INCREMENTAL
 | INDEXES
 | INJECT
+| INPUT
+| INSENSITIVE
 | INSERT
 | INTERLEAVE
 | INVERTED
@@ -10332,6 +11541,7 @@ unreserved_keyword:
 | KV
 | LANGUAGE
 | LAST
+| LATEST
 | LC_COLLATE
 | LC_CTYPE
 | LEASE
@@ -10354,6 +11564,7 @@ unreserved_keyword:
 | MULTIPOINT
 | MULTIPOLYGON
 | MONTH
+| MOVE
 | NAMES
 | NAN
 | NEXT
@@ -10389,6 +11600,7 @@ unreserved_keyword:
 | PRECEDING
 | PREPARE
 | PRESERVE
+| PRIOR
 | PRIORITY
 | PUBLIC
 | PUBLICATION
@@ -10397,9 +11609,12 @@ unreserved_keyword:
 | RANGE
 | RANGES
 | READ
+| RECURRING
 | RECURSIVE
 | REF
+| REFRESH
 | REINDEX
+| RELATIVE
 | RELEASE
 | RENAME
 | REPEATABLE
@@ -10408,6 +11623,7 @@ unreserved_keyword:
 | RESTORE
 | RESTRICT
 | RESUME
+| RETURNS
 | REVOKE
 | ROLE
 | ROLES
@@ -10417,11 +11633,15 @@ unreserved_keyword:
 | RULE
 | SETTING
 | SETTINGS
+| STABLE
 | STATUS
 | SAVEPOINT
 | SCATTER
+| SCHEDULE
+| SCHEDULES
 | SCHEMA
 | SCHEMAS
+| SCROLL
 | SCRUB
 | SEARCH
 | SECOND
@@ -10440,8 +11660,10 @@ unreserved_keyword:
 | SPLIT
 | SQL
 | START
+| STATEMENT
 | STATISTICS
 | STDIN
+| STDOUT
 | STORAGE
 | STORE
 | STORED
@@ -10479,6 +11701,7 @@ unreserved_keyword:
 | VALUE
 | VARYING
 | VIEW
+| VOLATILE
 | WITHIN
 | WITHOUT
 | WRITE
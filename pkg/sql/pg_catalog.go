@@ -206,6 +206,7 @@ var pgCatalog = virtualSchema{
 		sqlbase.PgCatalogCollationTableID:           pgCatalogCollationTable,
 		sqlbase.PgCatalogConstraintTableID:          pgCatalogConstraintTable,
 		sqlbase.PgCatalogConversionTableID:          pgCatalogConversionTable,
+		sqlbase.PgCatalogCursorsTableID:             pgCatalogCursorsTable,
 		sqlbase.PgCatalogDatabaseTableID:            pgCatalogDatabaseTable,
 		sqlbase.PgCatalogDefaultACLTableID:          pgCatalogDefaultACLTable,
 		sqlbase.PgCatalogDependTableID:              pgCatalogDependTable,
@@ -618,6 +619,7 @@ var (
 	relKindTable    = tree.NewDString("r")
 	relKindIndex    = tree.NewDString("i")
 	relKindView     = tree.NewDString("v")
+	relKindMatView  = tree.NewDString("m")
 	relKindSequence = tree.NewDString("S")
 
 	relPersistencePermanent = tree.NewDString("p")
@@ -664,7 +666,9 @@ CREATE TABLE pg_catalog.pg_class (
 		// The only difference between tables, views and sequences are the relkind and relam columns.
 		relKind := relKindTable
 		relAm := forwardIndexOid
-		if table.IsView() {
+		if table.MaterializedView() {
+			relKind = relKindMatView
+		} else if table.IsView() {
 			relKind = relKindView
 			relAm = oidZero
 		} else if table.IsSequence() {
@@ -850,6 +854,8 @@ func populateTableConstraints(
 		confupdtype := tree.DNull
 		confdeltype := tree.DNull
 		confmatchtype := tree.DNull
+		condeferrable := tree.DBoolFalse
+		condeferred := tree.DBoolFalse
 		conkey := tree.DNull
 		confkey := tree.DNull
 		consrc := tree.DNull
@@ -895,6 +901,8 @@ func populateTableConstraints(
 			if r, ok := fkMatchMap[con.FK.Match]; ok {
 				confmatchtype = r
 			}
+			condeferrable = tree.MakeDBool(tree.DBool(con.FK.Deferrable))
+			condeferred = tree.MakeDBool(tree.DBool(con.FK.InitiallyDeferred))
 			if conkey, err = colIDArrayToDatum(con.FK.OriginColumnIDs); err != nil {
 				return err
 			}
@@ -937,8 +945,8 @@ func populateTableConstraints(
 			dNameOrNull(conName), // conname
 			namespaceOid,         // connamespace
 			contype,              // contype
-			tree.DBoolFalse,      // condeferrable
-			tree.DBoolFalse,      // condeferred
+			condeferrable,        // condeferrable
+			condeferred,          // condeferred
 			tree.MakeDBool(tree.DBool(!con.Unvalidated)), // convalidated
 			tblOid,         // conrelid
 			oidZero,        // contypid
@@ -1628,13 +1636,15 @@ CREATE TABLE pg_catalog.pg_index (
 						if err := collationOids.Append(typColl(col.Type, h)); err != nil {
 							return err
 						}
-						// Currently, nulls always appear first if the order is ascending,
-						// and always appear last if the order is descending.
+						// By default, nulls appear first if the order is ascending, and
+						// last if the order is descending.
 						var thisIndOption tree.DInt
-						if index.ColumnDirections[i] == sqlbase.IndexDescriptor_ASC {
-							thisIndOption = indoptionNullsFirst
-						} else {
-							thisIndOption = indoptionDesc
+						if index.ColumnDirections[i] == sqlbase.IndexDescriptor_DESC {
+							thisIndOption |= indoptionDesc
+						}
+						if (index.ColumnDirections[i] == sqlbase.IndexDescriptor_ASC) !=
+							index.ColumnNullsReversedAt(i) {
+							thisIndOption |= indoptionNullsFirst
 						}
 						if err := indoption.Append(tree.NewDInt(thisIndOption)); err != nil {
 							return err
@@ -1740,6 +1750,7 @@ func indexDefFromDescriptor(
 		if index.ColumnDirections[i] == sqlbase.IndexDescriptor_DESC {
 			elem.Direction = tree.Descending
 		}
+		elem.NullsOrder = index.ColumnNullsOrder(i)
 		indexDef.Columns[i] = elem
 	}
 	for i, name := range index.StoreColumnNames {
@@ -2025,6 +2036,39 @@ CREATE TABLE pg_catalog.pg_prepared_xacts (
 // of the PREPARE statement.
 // The parameter_types field differs from postgres as the type names in
 // cockroach are slightly different.
+var pgCatalogCursorsTable = virtualSchemaTable{
+	comment: `open cursors
+https://www.postgresql.org/docs/9.6/view-pg-cursors.html`,
+	schema: `
+CREATE TABLE pg_catalog.pg_cursors (
+	name TEXT,
+	statement TEXT,
+	is_holdable BOOL,
+	is_binary BOOL,
+	is_scrollable BOOL,
+	creation_time TIMESTAMPTZ
+)`,
+	populate: func(ctx context.Context, p *planner, dbContext *sqlbase.ImmutableDatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		for name, c := range p.sqlCursors.cursors {
+			ts, err := tree.MakeDTimestampTZ(c.created, time.Microsecond)
+			if err != nil {
+				return err
+			}
+			if err := addRow(
+				tree.NewDString(string(name)),
+				tree.NewDString(c.statement),
+				tree.DBoolFalse, // is_holdable
+				tree.DBoolFalse, // is_binary
+				tree.DBoolFalse, // is_scrollable
+				ts,
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
 var pgCatalogPreparedStatementsTable = virtualSchemaTable{
 	comment: `prepared statements
 https://www.postgresql.org/docs/9.6/view-pg-prepared-statements.html`,
@@ -2243,7 +2287,7 @@ CREATE TABLE pg_catalog.pg_proc (
 }
 
 var pgCatalogRangeTable = virtualSchemaTable{
-	comment: `range types (empty - feature does not exist)
+	comment: `range types (incomplete)
 https://www.postgresql.org/docs/9.5/catalog-pg-range.html`,
 	schema: `
 CREATE TABLE pg_catalog.pg_range (
@@ -2255,9 +2299,22 @@ CREATE TABLE pg_catalog.pg_range (
 	rngsubdiff OID
 )`,
 	populate: func(_ context.Context, p *planner, _ *sqlbase.ImmutableDatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		// We currently do not support any range types, so this table is empty.
-		// This table should be populated when any range types are added to
-		// oidToDatum (and therefore pg_type).
+		// Only the built-in range types exist.
+		for _, o := range []oid.Oid{
+			oid.T_int4range, oid.T_int8range, oid.T_tsrange, oid.T_tstzrange, oid.T_daterange,
+		} {
+			typ := types.OidToType[o]
+			if err := addRow(
+				tree.NewDOid(tree.DInt(typ.Oid())),                 // rngtypid
+				tree.NewDOid(tree.DInt(typ.RangeContents().Oid())), // rngsubtype
+				oidZero, // rngcollation
+				oidZero, // rngsubopc
+				oidZero, // rngcanonical
+				oidZero, // rngsubdiff
+			); err != nil {
+				return err
+			}
+		}
 		return nil
 	},
 }
@@ -2583,10 +2640,8 @@ var (
 	typTypeRange     = tree.NewDString("r")
 
 	// Avoid unused warning for constants.
-	_ = typTypeComposite
 	_ = typTypeDomain
 	_ = typTypePseudo
-	_ = typTypeRange
 
 	// See https://www.postgresql.org/docs/9.6/static/catalog-pg-type.html#CATALOG-TYPCATEGORY-TABLE.
 	typCategoryArray       = tree.NewDString("A")
@@ -2606,10 +2661,8 @@ var (
 	typCategoryUnknown     = tree.NewDString("X")
 
 	// Avoid unused warning for constants.
-	_ = typCategoryComposite
 	_ = typCategoryEnum
 	_ = typCategoryGeometric
-	_ = typCategoryRange
 	_ = typCategoryBitString
 
 	typDelim = tree.NewDString(",")
@@ -2649,6 +2702,14 @@ func addPGTypeRow(
 		builtinPrefix = "enum_"
 		typType = typTypeEnum
 	}
+	if typ.Family() == types.TupleFamily && typ.UserDefined() {
+		builtinPrefix = "record_"
+		typType = typTypeComposite
+		cat = typCategoryComposite
+	}
+	if typ.Family() == types.RangeFamily {
+		typType = typTypeRange
+	}
 	if cat == typCategoryPseudo {
 		typType = typTypePseudo
 	}
@@ -2939,6 +3000,7 @@ var datumToTypeCategory = map[types.Family]*tree.DString{
 	types.ArrayFamily:       typCategoryArray,
 	types.TupleFamily:       typCategoryPseudo,
 	types.OidFamily:         typCategoryNumeric,
+	types.RangeFamily:       typCategoryRange,
 	types.UuidFamily:        typCategoryUserDefined,
 	types.INetFamily:        typCategoryNetworkAddr,
 	types.UnknownFamily:     typCategoryUnknown,
@@ -3097,16 +3159,15 @@ CREATE TABLE pg_catalog.pg_aggregate (
 // are unique across all objects and that they are stable across accesses.
 //
 // The type has a few layers of methods:
-// - write<go_type> methods write concrete types to the underlying running hash.
-// - write<db_object> methods account for single database objects like TableDescriptors
-//   or IndexDescriptors in the running hash. These methods aim to write information
-//   that would uniquely fingerprint the object to the hash using the first layer of
-//   methods.
-// - <DB_Object>Oid methods use the second layer of methods to construct a unique
-//   object identifier for the provided database object. This object identifier will
-//   be returned as a *tree.DInt, and the running hash will be reset. These are the
-//   only methods that are part of the oidHasher's external facing interface.
-//
+//   - write<go_type> methods write concrete types to the underlying running hash.
+//   - write<db_object> methods account for single database objects like TableDescriptors
+//     or IndexDescriptors in the running hash. These methods aim to write information
+//     that would uniquely fingerprint the object to the hash using the first layer of
+//     methods.
+//   - <DB_Object>Oid methods use the second layer of methods to construct a unique
+//     object identifier for the provided database object. This object identifier will
+//     be returned as a *tree.DInt, and the running hash will be reset. These are the
+//     only methods that are part of the oidHasher's external facing interface.
 type oidHasher struct {
 	h hash.Hash32
 }
@@ -30,6 +30,15 @@ const (
 	// authCleartextPassword is the pgwire auth response code to request
 	// a plaintext password during the connection handshake.
 	authCleartextPassword int32 = 3
+	// authReqSASL is the pgwire auth response code to start a SASL exchange,
+	// listing the SASL mechanisms supported by the server.
+	authReqSASL int32 = 10
+	// authReqSASLContinue is the pgwire auth response code carrying a SASL
+	// challenge.
+	authReqSASLContinue int32 = 11
+	// authReqSASLFinal is the pgwire auth response code carrying the SASL
+	// outcome, sent when the exchange succeeds.
+	authReqSASLFinal int32 = 12
 )
 
 type authOptions struct {
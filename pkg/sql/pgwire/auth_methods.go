@@ -20,6 +20,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/security"
 	"github.com/cockroachdb/cockroach/pkg/sql"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/hba"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirebase"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/errors"
@@ -62,6 +63,12 @@ func loadDefaultMethods() {
 	// the current rule.
 	RegisterAuthMethod("trust", authTrust, clusterversion.VersionAuthLocalAndTrustRejectMethods, hba.ConnAny, nil)
 
+	// The "scram-sha-256" method performs a SCRAM-SHA-256 exchange, so
+	// that the password is never sent to the server.
+	//
+	// This requires the user's password to be stored as a SCRAM
+	// verifier; see the server.user_login.password_encryption setting.
+	RegisterAuthMethod("scram-sha-256", authScram, clusterversion.VersionSCRAMAuthentication, hba.ConnAny, nil)
 }
 
 // AuthMethod defines a method for authentication of a connection.
@@ -89,7 +96,7 @@ func authPassword(
 	_ tls.ConnectionState,
 	pwRetrieveFn PasswordRetrievalFn,
 	pwValidUntilFn PasswordValidUntilFn,
-	_ *sql.ExecutorConfig,
+	execCfg *sql.ExecutorConfig,
 	_ *hba.Entry,
 ) (security.UserAuthHook, error) {
 	if err := c.SendAuthRequest(authCleartextPassword, nil /* data */); err != nil {
@@ -111,20 +118,135 @@ func authPassword(
 		c.Logf(ctx, "user has no password defined")
 	}
 
+	if err := checkPasswordValidUntil(ctx, c, pwValidUntilFn); err != nil {
+		return nil, err
+	}
+
+	hook := security.UserAuthPasswordHook(
+		false /*insecure*/, password, hashedPassword,
+	)
+	return func(requestedUser string, clientConnection bool) (func(), error) {
+		connClose, err := hook(requestedUser, clientConnection)
+		if err == nil {
+			// Now that we know the cleartext password, take the opportunity
+			// to convert its stored hash if needed.
+			sql.MaybeUpgradeStoredPasswordHash(ctx, execCfg, requestedUser, password, hashedPassword)
+		}
+		return connClose, err
+	}, nil
+}
+
+func checkPasswordValidUntil(
+	ctx context.Context, c AuthConn, pwValidUntilFn PasswordValidUntilFn,
+) error {
 	validUntil, err := pwValidUntilFn(ctx)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	if validUntil != nil {
 		if validUntil.Sub(timeutil.Now()) < 0 {
 			c.Logf(ctx, "password is expired")
-			return nil, errors.New("password is expired")
+			return errors.New("password is expired")
 		}
 	}
+	return nil
+}
 
-	return security.UserAuthPasswordHook(
-		false /*insecure*/, password, hashedPassword,
-	), nil
+func authScram(
+	ctx context.Context,
+	c AuthConn,
+	_ tls.ConnectionState,
+	pwRetrieveFn PasswordRetrievalFn,
+	pwValidUntilFn PasswordValidUntilFn,
+	_ *sql.ExecutorConfig,
+	_ *hba.Entry,
+) (security.UserAuthHook, error) {
+	// Announce the SASL mechanisms we support: a list of 0-terminated
+	// strings, terminated by an empty string.
+	if err := c.SendAuthRequest(authReqSASL, []byte(security.SCRAMMechanism+"\x00\x00")); err != nil {
+		return nil, err
+	}
+	pwdData, err := c.GetPwdData()
+	if err != nil {
+		return nil, err
+	}
+	// The client responds with a SASLInitialResponse message.
+	buf := pgwirebase.ReadBuffer{Msg: pwdData}
+	mechanism, err := buf.GetString()
+	if err != nil {
+		return nil, err
+	}
+	if mechanism != security.SCRAMMechanism {
+		return nil, pgwirebase.NewProtocolViolationErrorf(
+			"client selected an invalid SASL authentication mechanism: %q", mechanism)
+	}
+	if _, err := buf.GetUint32(); err != nil {
+		return nil, err
+	}
+	clientFirst := buf.Msg
+
+	hashedPassword, err := pwRetrieveFn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var verifier security.SCRAMVerifier
+	isMock := true
+	switch {
+	case len(hashedPassword) == 0:
+		c.Logf(ctx, "user has no password defined")
+	case !security.IsSCRAMHash(hashedPassword):
+		c.Logf(ctx, "user has no SCRAM credentials; "+
+			"the password must be reset, or used once with the password method, "+
+			"after setting server.user_login.password_encryption to scram-sha-256")
+	default:
+		if verifier, err = security.ParseSCRAMVerifier(hashedPassword); err != nil {
+			c.Logf(ctx, "invalid SCRAM credentials: %v", err)
+		} else {
+			isMock = false
+		}
+	}
+	if isMock {
+		// Carry on with the exchange so that the client cannot tell
+		// this case apart from a wrong password.
+		if verifier, err = security.MakeMockSCRAMVerifier(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := checkPasswordValidUntil(ctx, c, pwValidUntilFn); err != nil {
+		return nil, err
+	}
+
+	exchange, err := security.NewSCRAMExchange(verifier)
+	if err != nil {
+		return nil, err
+	}
+	serverFirst, err := exchange.ServerFirst(clientFirst)
+	if err != nil {
+		return nil, pgwirebase.NewProtocolViolationErrorf("%v", err)
+	}
+	if err := c.SendAuthRequest(authReqSASLContinue, serverFirst); err != nil {
+		return nil, err
+	}
+	clientFinal, err := c.GetPwdData()
+	if err != nil {
+		return nil, err
+	}
+	serverFinal, authErr := exchange.ServerFinal(clientFinal)
+	if authErr == nil {
+		authErr = c.SendAuthRequest(authReqSASLFinal, serverFinal)
+	}
+
+	return func(requestedUser string, clientConnection bool) (func(), error) {
+		if !clientConnection {
+			return nil, errors.New("SCRAM authentication is only available for client connections")
+		}
+		if authErr != nil {
+			c.Logf(ctx, "SCRAM exchange failed: %v", authErr)
+			return nil, errors.Errorf(security.ErrPasswordUserAuthFailed, requestedUser)
+		}
+		return nil, nil
+	}, nil
 }
 
 func passwordString(pwdData []byte) (string, error) {
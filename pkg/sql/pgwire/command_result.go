@@ -83,6 +83,10 @@ type commandResult struct {
 	// statements.
 	bufferingDisabled bool
 
+	// copyOut is set for COPY ... TO STDOUT statements, whose rows are sent
+	// using the Copy-out subprotocol.
+	copyOut *copyOutEncoder
+
 	// released is set when the command result has been released so that its
 	// memory can be reused. It is also used to assert against use-after-free
 	// errors.
@@ -115,6 +119,11 @@ func (r *commandResult) Close(ctx context.Context, t sql.TransactionStatusIndica
 	// Send a completion message, specific to the type of result.
 	switch r.typ {
 	case commandComplete:
+		if r.copyOut != nil {
+			if err := r.conn.bufferCopyDone(r.copyOut); err != nil {
+				panic(fmt.Sprintf("unexpected err from buffer: %s", err))
+			}
+		}
 		tag := cookTag(
 			r.cmdCompleteTag, r.conn.writerState.tagBuf[:0], r.stmtType, r.rowsAffected,
 		)
@@ -178,7 +187,13 @@ func (r *commandResult) AddRow(ctx context.Context, row tree.Datums) error {
 	}
 	r.rowsAffected++
 
-	r.conn.bufferRow(ctx, row, r.formatCodes, r.conv, r.oids)
+	if r.copyOut != nil {
+		if err := r.conn.bufferCopyRow(ctx, r.copyOut, row, r.conv, r.oids); err != nil {
+			return err
+		}
+	} else {
+		r.conn.bufferRow(ctx, row, r.formatCodes, r.conv, r.oids)
+	}
 	var err error
 	if r.bufferingDisabled {
 		err = r.conn.Flush(r.pos)
@@ -216,7 +231,11 @@ func (r *commandResult) AppendNotice(noticeErr error) {
 func (r *commandResult) SetColumns(ctx context.Context, cols sqlbase.ResultColumns) {
 	r.assertNotReleased()
 	r.conn.writerState.fi.registerCmd(r.pos)
-	if r.descOpt == sql.NeedRowDesc {
+	if r.copyOut != nil {
+		if err := r.conn.bufferCopyOutResponse(r.copyOut, cols); err != nil {
+			panic(fmt.Sprintf("unexpected err from buffer: %s", err))
+		}
+	} else if r.descOpt == sql.NeedRowDesc {
 		_ /* err */ = r.conn.writeRowDescription(ctx, cols, r.formatCodes, &r.conn.writerState.buf)
 	}
 	r.oids = make([]oid.Oid, len(cols))
@@ -323,6 +342,11 @@ func (c *conn) newCommandResult(
 		descOpt:        descOpt,
 		formatCodes:    formatCodes,
 	}
+	if copyTo, ok := stmt.(*tree.CopyTo); ok {
+		// Like Postgres, we ignore the row limit of COPY statements.
+		r.copyOut = newCopyOutEncoder(&copyTo.Options)
+		return r
+	}
 	if limit == 0 {
 		return r
 	}
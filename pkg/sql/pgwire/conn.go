@@ -657,6 +657,15 @@ func (c *conn) sendInitialConnData(
 		return sql.ConnectionHandler{}, err
 	}
 
+	// Send the key with which the client can later cancel the session's
+	// queries using a CancelRequest message.
+	c.msgBuilder.initMsg(pgwirebase.ServerMsgBackendKeyData)
+	c.msgBuilder.putInt32(int32(c.sessionArgs.BackendKeyData.ProcessID()))
+	c.msgBuilder.putInt32(int32(c.sessionArgs.BackendKeyData.SecretKey()))
+	if err := c.msgBuilder.finishMsg(c.conn); err != nil {
+		return sql.ConnectionHandler{}, err
+	}
+
 	// An initial readyForQuery message is part of the handshake.
 	c.msgBuilder.initMsg(pgwirebase.ServerMsgReady)
 	c.msgBuilder.writeByte(byte(sql.IdleTxnBlock))
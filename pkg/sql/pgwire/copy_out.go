@@ -0,0 +1,237 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pgwire
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirebase"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding/csv"
+	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
+)
+
+// copyBinarySignature is the header of the binary COPY format, followed by
+// 32-bit flags and the 32-bit length of the header extension area, both 0.
+//
+// See: https://www.postgresql.org/docs/current/sql-copy.html#id-1.9.3.55.9.4.5
+const copyBinarySignature = "PGCOPY\n\377\r\n\000"
+
+// copyOutEncoder encodes the rows of a COPY ... TO STDOUT statement, which are
+// sent to the client using the Copy-out subprotocol rather than as DataRow
+// messages. Each row is sent as a CopyData message.
+//
+// The text format uses the same representation of values as the pgwire text
+// format, escaped the way the COPY FROM machine expects. The CSV format uses
+// the same representation and writer as EXPORT.
+//
+// See: https://www.postgresql.org/docs/current/static/protocol-flow.html#PROTOCOL-COPY
+type copyOutEncoder struct {
+	format    tree.CopyFormat
+	header    bool
+	delimiter byte
+	null      string
+
+	// pending accumulates data that is to be sent with the next CopyData
+	// message; in the binary format, the header is sent with the first row.
+	pending []byte
+	// scratch is used to format values in the text format.
+	scratch writeBuffer
+
+	csvBuf    bytes.Buffer
+	csvWriter *csv.Writer
+	csvRecord []string
+	// csvQuoted marks the values of csvRecord that are quoted because they
+	// would otherwise be read back as NULL.
+	csvQuoted []bool
+	exportFmt *tree.FmtCtx
+}
+
+func newCopyOutEncoder(opts *tree.CopyOptions) *copyOutEncoder {
+	e := &copyOutEncoder{
+		format:    opts.CopyFormat,
+		header:    opts.Header,
+		delimiter: '\t',
+		null:      `\N`,
+	}
+	switch e.format {
+	case tree.CopyFormatCSV:
+		e.delimiter = ','
+		e.null = ""
+		e.csvWriter = csv.NewWriter(&e.csvBuf)
+		e.exportFmt = tree.NewFmtCtx(tree.FmtExport)
+	case tree.CopyFormatText:
+		e.scratch.init(nil /* bytecount */)
+	}
+	if opts.Delimiter != nil {
+		e.delimiter = opts.Delimiter.RawString()[0]
+	}
+	if opts.Null != nil {
+		e.null = opts.Null.RawString()
+	}
+	if e.csvWriter != nil {
+		e.csvWriter.Comma = rune(e.delimiter)
+	}
+	return e
+}
+
+// bufferCopyOutResponse sends the CopyOutResponse message that starts the
+// Copy-out subprotocol. In the CSV format, the header row is sent right after,
+// if requested.
+func (c *conn) bufferCopyOutResponse(e *copyOutEncoder, cols sqlbase.ResultColumns) error {
+	fmtCode := pgwirebase.FormatText
+	if e.format == tree.CopyFormatBinary {
+		fmtCode = pgwirebase.FormatBinary
+	}
+	c.msgBuilder.initMsg(pgwirebase.ServerMsgCopyOutResponse)
+	c.msgBuilder.writeByte(byte(fmtCode))
+	c.msgBuilder.putInt16(int16(len(cols)))
+	for range cols {
+		c.msgBuilder.putInt16(int16(fmtCode))
+	}
+	if err := c.msgBuilder.finishMsg(&c.writerState.buf); err != nil {
+		return err
+	}
+
+	switch e.format {
+	case tree.CopyFormatBinary:
+		e.pending = append(e.pending[:0], copyBinarySignature...)
+		e.pending = append(e.pending, 0, 0, 0, 0, 0, 0, 0, 0)
+	case tree.CopyFormatCSV:
+		if e.header {
+			record := make([]string, len(cols))
+			for i := range cols {
+				record[i] = cols[i].Name
+			}
+			if err := e.writeCSV(record); err != nil {
+				return err
+			}
+			return c.bufferCopyData(e, nil /* data */)
+		}
+	}
+	return nil
+}
+
+// bufferCopyRow encodes a row and sends it in a CopyData message.
+func (c *conn) bufferCopyRow(
+	ctx context.Context,
+	e *copyOutEncoder,
+	row tree.Datums,
+	conv sessiondata.DataConversionConfig,
+	oids []oid.Oid,
+) error {
+	switch e.format {
+	case tree.CopyFormatText:
+		for i, d := range row {
+			if i > 0 {
+				e.pending = append(e.pending, e.delimiter)
+			}
+			if d == tree.DNull {
+				e.pending = append(e.pending, e.null...)
+				continue
+			}
+			e.scratch.reset()
+			e.scratch.writeTextDatum(ctx, d, conv)
+			if e.scratch.err != nil {
+				return e.scratch.err
+			}
+			// Skip the length prefix written by writeTextDatum.
+			e.pending = sql.EncodeCopy(e.pending, e.scratch.wrapped.Bytes()[4:], e.delimiter)
+		}
+		e.pending = append(e.pending, '\n')
+
+	case tree.CopyFormatCSV:
+		if e.csvRecord == nil {
+			e.csvRecord = make([]string, len(row))
+			e.csvQuoted = make([]bool, len(row))
+		}
+		for i, d := range row {
+			if d == tree.DNull {
+				e.csvRecord[i] = e.null
+				e.csvQuoted[i] = false
+				continue
+			}
+			d.Format(e.exportFmt)
+			e.csvRecord[i] = e.exportFmt.String()
+			e.exportFmt.Reset()
+			// Like Postgres, quote non-NULL values that match the NULL string,
+			// e.g. empty strings by default.
+			e.csvQuoted[i] = e.csvRecord[i] == e.null
+		}
+		if err := e.writeCSVWithQuotes(e.csvRecord, e.csvQuoted); err != nil {
+			return err
+		}
+
+	case tree.CopyFormatBinary:
+		// Each tuple starts with the number of fields, and each field is encoded
+		// like in a DataRow message.
+		c.msgBuilder.initMsg(pgwirebase.ServerMsgCopyData)
+		c.msgBuilder.write(e.pending)
+		e.pending = e.pending[:0]
+		c.msgBuilder.putInt16(int16(len(row)))
+		for i, d := range row {
+			c.msgBuilder.writeBinaryDatum(ctx, d, conv.Location, oids[i])
+		}
+		return c.msgBuilder.finishMsg(&c.writerState.buf)
+
+	default:
+		return errors.AssertionFailedf("unsupported COPY format %d", e.format)
+	}
+	return c.bufferCopyData(e, nil /* data */)
+}
+
+// bufferCopyDone ends the Copy-out subprotocol. The CommandComplete message
+// follows.
+func (c *conn) bufferCopyDone(e *copyOutEncoder) error {
+	if e.format == tree.CopyFormatBinary {
+		// The file trailer is a field count of -1.
+		if err := c.bufferCopyData(e, []byte{0xff, 0xff}); err != nil {
+			return err
+		}
+	}
+	c.msgBuilder.initMsg(pgwirebase.ServerMsgCopyDone)
+	return c.msgBuilder.finishMsg(&c.writerState.buf)
+}
+
+// bufferCopyData sends the pending data, followed by data, in a CopyData
+// message.
+func (c *conn) bufferCopyData(e *copyOutEncoder, data []byte) error {
+	c.msgBuilder.initMsg(pgwirebase.ServerMsgCopyData)
+	c.msgBuilder.write(e.pending)
+	c.msgBuilder.write(data)
+	e.pending = e.pending[:0]
+	return c.msgBuilder.finishMsg(&c.writerState.buf)
+}
+
+// writeCSV appends a CSV record to the pending data.
+func (e *copyOutEncoder) writeCSV(record []string) error {
+	return e.writeCSVWithQuotes(record, nil /* forceQuote */)
+}
+
+// writeCSVWithQuotes appends a CSV record to the pending data, quoting the
+// fields for which forceQuote is true.
+func (e *copyOutEncoder) writeCSVWithQuotes(record []string, forceQuote []bool) error {
+	if err := e.csvWriter.WriteWithQuotes(record, forceQuote); err != nil {
+		return err
+	}
+	e.csvWriter.Flush()
+	if err := e.csvWriter.Error(); err != nil {
+		return err
+	}
+	e.pending = append(e.pending, e.csvBuf.Bytes()...)
+	e.csvBuf.Reset()
+	return nil
+}
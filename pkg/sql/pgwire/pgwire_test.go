@@ -14,6 +14,7 @@ import (
 	"context"
 	gosql "database/sql"
 	"database/sql/driver"
+	"encoding/binary"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -34,6 +35,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/server"
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
 	"github.com/cockroachdb/cockroach/pkg/testutils"
 	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
@@ -786,6 +788,13 @@ func TestPGPreparedQuery(t *testing.T) {
 		{"SELECT $1::INT[]", []preparedQueryTest{
 			baseTest.SetArgs(pq.Array([]int64{10})).Results(pq.Array([]int64{10})),
 		}},
+		{"SELECT $1:::INT[][]", []preparedQueryTest{
+			baseTest.SetArgs("{{1,2},{3,NULL}}").Results("{{1,2},{3,NULL}}"),
+			baseTest.SetArgs("{}").Results("{}"),
+		}},
+		{"SELECT $1:::STRING[][]", []preparedQueryTest{
+			baseTest.SetArgs(`{{a,"b c"}}`).Results(`{{a,"b c"}}`),
+		}},
 		{"INSERT INTO d.arr VALUES($1, $2)", []preparedQueryTest{
 			baseTest.SetArgs(pq.Array([]int64{}), pq.Array([]string{})),
 		}},
@@ -1898,12 +1907,142 @@ func TestCancelRequest(t *testing.T) {
 		if _, err := fe.Receive(); err != io.EOF {
 			t.Fatalf("unexpected: %v", err)
 		}
-		if count := telemetry.GetRawFeatureCounts()["pgwire.unimplemented.cancel_request"]; count != 1 {
+		if count := telemetry.GetRawFeatureCounts()["pgwire.cancel_request"]; count != 1 {
 			t.Fatalf("expected 1 cancel request, got %d", count)
 		}
 	})
 }
 
+// TestCancelRequestWithContext checks that lib/pq, which sends a
+// CancelRequest when the context of a query is canceled, can cancel a
+// running query.
+func TestCancelRequestWithContext(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s, _, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(context.Background())
+
+	pgURL, cleanupFn := sqlutils.PGUrl(t, s.ServingSQLAddr(), t.Name(), url.User(security.RootUser))
+	defer cleanupFn()
+
+	db, err := gosql.Open("postgres", pgURL.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	_, err = db.QueryContext(ctx, "SELECT pg_sleep(30)")
+	if !testutils.IsError(err, "query execution canceled") {
+		t.Fatalf("expected query to be canceled, got %v", err)
+	}
+}
+
+// TestCancelRequestAcrossNodes checks that a CancelRequest received by a
+// node other than the session's gateway is forwarded to the gateway.
+func TestCancelRequestAcrossNodes(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	tc := serverutils.StartTestCluster(t, 2, base.TestClusterArgs{
+		ServerArgs: base.TestServerArgs{Insecure: true},
+	})
+	defer tc.Stopper().Stop(ctx)
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", tc.Server(0).ServingSQLAddr())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	fe, err := pgproto3.NewFrontend(conn, conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := fe.Send(&pgproto3.StartupMessage{
+		ProtocolVersion: 196608,
+		Parameters:      map[string]string{"user": security.RootUser},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	var keyData *pgproto3.BackendKeyData
+	for {
+		msg, err := fe.Receive()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if m, ok := msg.(*pgproto3.BackendKeyData); ok {
+			// The message is reused by the next Receive call.
+			keyData = &pgproto3.BackendKeyData{ProcessID: m.ProcessID, SecretKey: m.SecretKey}
+		}
+		if _, ok := msg.(*pgproto3.ReadyForQuery); ok {
+			break
+		}
+	}
+	if keyData == nil {
+		t.Fatal("expected BackendKeyData during the handshake")
+	}
+	if keyData.ProcessID != uint32(tc.Server(0).NodeID()) {
+		t.Fatalf("expected process ID %d, got %d", tc.Server(0).NodeID(), keyData.ProcessID)
+	}
+
+	if err := fe.Send(&pgproto3.Query{String: "SELECT pg_sleep(30)"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The query may not have started running yet when the cancel request is
+	// received, so keep sending it until the query returns.
+	errCh := make(chan *pgproto3.ErrorResponse, 1)
+	go func() {
+		defer close(errCh)
+		for {
+			msg, err := fe.Receive()
+			if err != nil {
+				return
+			}
+			switch m := msg.(type) {
+			case *pgproto3.ErrorResponse:
+				errCh <- m
+				return
+			case *pgproto3.ReadyForQuery:
+				// The query finished without being canceled.
+				return
+			}
+		}
+	}()
+	cancelReq := make([]byte, 16)
+	binary.BigEndian.PutUint32(cancelReq[0:], 16)
+	binary.BigEndian.PutUint32(cancelReq[4:], 80877102 /* versionCancel */)
+	binary.BigEndian.PutUint32(cancelReq[8:], keyData.ProcessID)
+	binary.BigEndian.PutUint32(cancelReq[12:], keyData.SecretKey)
+	for {
+		cancelConn, err := d.DialContext(ctx, "tcp", tc.Server(1).ServingSQLAddr())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := cancelConn.Write(cancelReq); err != nil {
+			t.Fatal(err)
+		}
+		// The server closes the connection once it has served the request.
+		_, _ = ioutil.ReadAll(cancelConn)
+		_ = cancelConn.Close()
+
+		select {
+		case errResp := <-errCh:
+			if errResp == nil {
+				t.Fatal("query was not canceled")
+			}
+			if errResp.Code != pgcode.QueryCanceled.String() {
+				t.Fatalf("expected query to be canceled, got %+v", errResp)
+			}
+			return
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
+
 func TestFailPrepareFailsTxn(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 
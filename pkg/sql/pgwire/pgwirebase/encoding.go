@@ -29,7 +29,6 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/bitarray"
 	"github.com/cockroachdb/cockroach/pkg/util/duration"
-	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/cockroach/pkg/util/ipaddr"
 	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil/pgdate"
@@ -193,23 +192,76 @@ func NewInvalidBinaryRepresentationErrorf(format string, args ...interface{}) er
 	return pgerror.Newf(pgcode.InvalidBinaryRepresentation, format, args...)
 }
 
-// validateArrayDimensions takes the number of dimensions and elements and
-// returns an error if we don't support that combination.
-func validateArrayDimensions(nDimensions int, nElements int) error {
-	switch nDimensions {
-	case 1:
-		break
-	case 0:
-		// 0-dimensional array means 0-length array: validate that.
-		if nElements == 0 {
-			break
+// maxArrayDimensions is the maximum number of array dimensions accepted from
+// the client. It matches Postgres' MAXDIM.
+const maxArrayDimensions = 6
+
+// makeArrayFromElements builds an array with the given dimensions out of the
+// flattened elements of an array received from the client. The elements are
+// expected in row-major order, as they appear on the wire.
+func makeArrayFromElements(leaf *types.T, dims []int32, elems tree.Datums) (*tree.DArray, error) {
+	if len(dims) > maxArrayDimensions {
+		return nil, pgerror.Newf(pgcode.ProgramLimitExceeded,
+			"number of array dimensions (%d) exceeds the maximum allowed (%d)",
+			len(dims), maxArrayDimensions)
+	}
+	n := 1
+	for _, d := range dims {
+		if d < 0 {
+			return nil, NewProtocolViolationErrorf("invalid array dimension size %d", d)
 		}
-		fallthrough
-	default:
-		return unimplemented.NewWithIssuef(32552,
-			"%d-dimension arrays not supported; only 1-dimension", nDimensions)
+		n *= int(d)
 	}
-	return nil
+	if len(dims) == 0 || n == 0 {
+		// A 0-dimensional array means a 0-length array.
+		if len(elems) != 0 {
+			return nil, NewProtocolViolationErrorf("unexpected elements in empty array")
+		}
+		return tree.NewDArray(leaf), nil
+	}
+	if n != len(elems) {
+		return nil, NewProtocolViolationErrorf(
+			"array dimensions do not match number of elements (%d)", len(elems))
+	}
+	return nestArrayElements(leaf, dims, elems)
+}
+
+func nestArrayElements(leaf *types.T, dims []int32, elems tree.Datums) (*tree.DArray, error) {
+	if len(dims) == 1 {
+		arr := tree.NewDArray(leaf)
+		for _, d := range elems {
+			if err := arr.Append(d); err != nil {
+				return nil, err
+			}
+		}
+		return arr, nil
+	}
+	contents := leaf
+	for range dims[1:] {
+		contents = types.MakeArray(contents)
+	}
+	arr := tree.NewDArray(contents)
+	stride := len(elems) / int(dims[0])
+	for i := 0; i < int(dims[0]); i++ {
+		sub, err := nestArrayElements(leaf, dims[1:], elems[i*stride:(i+1)*stride])
+		if err != nil {
+			return nil, err
+		}
+		if err := arr.Append(sub); err != nil {
+			return nil, err
+		}
+	}
+	return arr, nil
+}
+
+// pgtypeArrayDimensions returns the sizes of the dimensions of an array decoded
+// by pgtype.
+func pgtypeArrayDimensions(dims []pgtype.ArrayDimension) []int32 {
+	res := make([]int32, len(dims))
+	for i := range dims {
+		res[i] = dims[i].Length
+	}
+	return res
 }
 
 // DecodeOidDatum decodes bytes with specified Oid and format code into
@@ -333,22 +385,15 @@ func DecodeOidDatum(
 			if arr.Status != pgtype.Present {
 				return tree.DNull, nil
 			}
-			if err := validateArrayDimensions(len(arr.Dimensions), len(arr.Elements)); err != nil {
-				return nil, err
-			}
-			out := tree.NewDArray(types.Int)
-			var d tree.Datum
-			for _, v := range arr.Elements {
+			elems := make(tree.Datums, len(arr.Elements))
+			for i, v := range arr.Elements {
 				if v.Status != pgtype.Present {
-					d = tree.DNull
+					elems[i] = tree.DNull
 				} else {
-					d = tree.NewDInt(tree.DInt(v.Int))
-				}
-				if err := out.Append(d); err != nil {
-					return nil, err
+					elems[i] = tree.NewDInt(tree.DInt(v.Int))
 				}
 			}
-			return out, nil
+			return makeArrayFromElements(types.Int, pgtypeArrayDimensions(arr.Dimensions), elems)
 		case oid.T__text, oid.T__name:
 			var arr pgtype.TextArray
 			if err := arr.DecodeText(nil, b); err != nil {
@@ -358,33 +403,31 @@ func DecodeOidDatum(
 			if arr.Status != pgtype.Present {
 				return tree.DNull, nil
 			}
-			if err := validateArrayDimensions(len(arr.Dimensions), len(arr.Elements)); err != nil {
-				return nil, err
-			}
-			out := tree.NewDArray(types.String)
+			leaf := types.String
 			if id == oid.T__name {
-				out.ParamTyp = types.Name
+				leaf = types.Name
 			}
-			var d tree.Datum
-			for _, v := range arr.Elements {
+			elems := make(tree.Datums, len(arr.Elements))
+			for i, v := range arr.Elements {
 				if v.Status != pgtype.Present {
-					d = tree.DNull
+					elems[i] = tree.DNull
+				} else if id == oid.T__name {
+					elems[i] = tree.NewDName(v.String)
 				} else {
-					d = tree.NewDString(v.String)
-					if id == oid.T__name {
-						d = tree.NewDNameFromDString(d.(*tree.DString))
-					}
-				}
-				if err := out.Append(d); err != nil {
-					return nil, err
+					elems[i] = tree.NewDString(v.String)
 				}
 			}
-			return out, nil
+			return makeArrayFromElements(leaf, pgtypeArrayDimensions(arr.Dimensions), elems)
 		case oid.T_jsonb:
 			if err := validateStringBytes(b); err != nil {
 				return nil, err
 			}
 			return tree.ParseDJSON(string(b))
+		case oid.T_int4range, oid.T_int8range, oid.T_tsrange, oid.T_tstzrange, oid.T_daterange:
+			if err := validateStringBytes(b); err != nil {
+				return nil, err
+			}
+			return tree.ParseDRangeFromString(ctx, string(b), types.OidToType[id])
 		}
 		if _, ok := types.ArrayOids[id]; ok {
 			// Arrays come in in their string form, so we parse them as such and later
@@ -792,9 +835,6 @@ func decodeBinaryArray(
 		ElemOid int32
 	}
 	var dim struct {
-		// The next two fields should be arrays of size Ndims. However, since
-		// we only support 1-dimensional arrays for now, for convenience we can
-		// leave them in this struct as such for `binary.Read` to parse for us.
 		DimSize int32
 		// Dim lower bound
 		_ int32
@@ -806,25 +846,34 @@ func decodeBinaryArray(
 	if elemOid != oid.Oid(hdr.ElemOid) {
 		return nil, pgerror.Newf(pgcode.DatatypeMismatch, "wrong element type")
 	}
-	arr := tree.NewDArray(types.OidToType[elemOid])
-	if hdr.Ndims == 0 {
-		return arr, nil
+	if hdr.Ndims < 0 || hdr.Ndims > maxArrayDimensions {
+		return nil, pgerror.Newf(pgcode.ProgramLimitExceeded,
+			"number of array dimensions (%d) exceeds the maximum allowed (%d)",
+			hdr.Ndims, maxArrayDimensions)
 	}
-	if err := binary.Read(r, binary.BigEndian, &dim); err != nil {
-		return nil, err
+	dims := make([]int32, hdr.Ndims)
+	nElems := 1
+	for i := range dims {
+		if err := binary.Read(r, binary.BigEndian, &dim); err != nil {
+			return nil, err
+		}
+		if dim.DimSize < 0 {
+			return nil, NewProtocolViolationErrorf("invalid array dimension size %d", dim.DimSize)
+		}
+		dims[i] = dim.DimSize
+		nElems *= int(dim.DimSize)
 	}
-	if err := validateArrayDimensions(int(hdr.Ndims), int(dim.DimSize)); err != nil {
-		return nil, err
+	if len(dims) == 0 {
+		nElems = 0
 	}
+	var elems tree.Datums
 	var vlen int32
-	for i := int32(0); i < dim.DimSize; i++ {
+	for i := 0; i < nElems; i++ {
 		if err := binary.Read(r, binary.BigEndian, &vlen); err != nil {
 			return nil, err
 		}
 		if vlen < 0 {
-			if err := arr.Append(tree.DNull); err != nil {
-				return nil, err
-			}
+			elems = append(elems, tree.DNull)
 			continue
 		}
 		buf := r.Next(int(vlen))
@@ -832,11 +881,9 @@ func decodeBinaryArray(
 		if err != nil {
 			return nil, err
 		}
-		if err := arr.Append(elem); err != nil {
-			return nil, err
-		}
+		elems = append(elems, elem)
 	}
-	return arr, nil
+	return makeArrayFromElements(types.OidToType[elemOid], dims, elems)
 }
 
 var invalidUTF8Error = pgerror.Newf(pgcode.CharacterNotInRepertoire, "invalid UTF-8 sequence")
@@ -0,0 +1,72 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package pgwirecancel contains the definitions needed to support the
+// out-of-band query cancellation mechanism of the PostgreSQL wire protocol.
+// It is a separate package so that both the pgwire and the sql packages can
+// use it.
+package pgwirecancel
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/errors"
+)
+
+// BackendKeyData is the key sent to a client in the BackendKeyData message
+// when a session is established. The client can later present it in a
+// CancelRequest message, sent unauthenticated over a separate connection
+// to any node, to cancel the queries running in that session.
+//
+// The upper 32 bits, sent as the "process ID" in the protocol, hold the ID
+// of the session's gateway node, so that a CancelRequest can be forwarded to
+// the node that is serving the session. The lower 32 bits, sent as the
+// "secret key", are random.
+//
+// The zero value denotes sessions that cannot be canceled this way, such as
+// internal sessions.
+type BackendKeyData uint64
+
+// MakeBackendKeyData generates a new, non-zero BackendKeyData for a session
+// served by the given node.
+func MakeBackendKeyData(nodeID roachpb.NodeID) (BackendKeyData, error) {
+	var buf [4]byte
+	for {
+		if _, err := rand.Read(buf[:]); err != nil {
+			return 0, errors.Wrap(err, "generating cancel key")
+		}
+		if secret := binary.BigEndian.Uint32(buf[:]); secret != 0 {
+			return FromProcessIDAndSecret(uint32(nodeID), secret), nil
+		}
+	}
+}
+
+// FromProcessIDAndSecret reassembles a BackendKeyData from the two halves
+// received in a CancelRequest message.
+func FromProcessIDAndSecret(processID, secretKey uint32) BackendKeyData {
+	return BackendKeyData(uint64(processID)<<32 | uint64(secretKey))
+}
+
+// ProcessID returns the half of the key sent as the process ID.
+func (b BackendKeyData) ProcessID() uint32 {
+	return uint32(b >> 32)
+}
+
+// SecretKey returns the half of the key sent as the secret key.
+func (b BackendKeyData) SecretKey() uint32 {
+	return uint32(b)
+}
+
+// NodeID returns the ID of the gateway node of the session.
+func (b BackendKeyData) NodeID() roachpb.NodeID {
+	return roachpb.NodeID(b.ProcessID())
+}
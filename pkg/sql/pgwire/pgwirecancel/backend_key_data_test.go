@@ -0,0 +1,31 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pgwirecancel
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackendKeyData(t *testing.T) {
+	for _, nodeID := range []roachpb.NodeID{0, 1, 12345, 1<<31 - 1} {
+		key, err := MakeBackendKeyData(nodeID)
+		require.NoError(t, err)
+		require.NotZero(t, key)
+		require.Equal(t, nodeID, key.NodeID())
+		require.NotZero(t, key.SecretKey())
+
+		// The key must survive the round trip through a CancelRequest.
+		require.Equal(t, key, FromProcessIDAndSecret(key.ProcessID(), key.SecretKey()))
+	}
+}
@@ -13,6 +13,7 @@ package pgwire
 import (
 	"context"
 	"crypto/tls"
+	"fmt"
 	"io"
 	"net"
 	"strings"
@@ -20,6 +21,8 @@ import (
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
 	"github.com/cockroachdb/cockroach/pkg/settings"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
@@ -28,6 +31,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirebase"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirecancel"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
@@ -513,11 +517,11 @@ func (s *Server) ServeConn(ctx context.Context, conn net.Conn, socketType Socket
 
 	if version == versionCancel {
 		// The cancel message is rather peculiar: it is sent without
-		// authentication, always over an unencrypted channel.
-		//
-		// Since we don't support this, close the door in the client's
-		// face. Make a note of that use in telemetry.
+		// authentication, always over an unencrypted channel, and the
+		// server never responds to it. The client is not even told whether
+		// the cancellation succeeded; we simply close the connection.
 		telemetry.Inc(sqltelemetry.CancelRequestCounter)
+		s.handleCancel(ctx, &buf)
 		_ = conn.Close()
 		return nil
 	}
@@ -549,6 +553,13 @@ func (s *Server) ServeConn(ctx context.Context, conn net.Conn, socketType Socket
 	case version30:
 		// Normal SQL connection. Proceed normally below.
 
+	case versionCancel:
+		// A cancel request sent over a secure connection.
+		telemetry.Inc(sqltelemetry.CancelRequestCounter)
+		s.handleCancel(ctx, &buf)
+		_ = conn.Close()
+		return nil
+
 	default:
 		// We don't know this protocol.
 		return s.sendErr(ctx, conn,
@@ -571,6 +582,14 @@ func (s *Server) ServeConn(ctx context.Context, conn net.Conn, socketType Socket
 		return s.sendErr(ctx, conn, err)
 	}
 
+	// Generate the key with which the client can cancel the session's
+	// queries. It embeds the ID of this node, so that a CancelRequest
+	// received by any node can be forwarded here.
+	nodeID, _ := s.execCfg.NodeID.OptionalNodeID()
+	if sArgs.BackendKeyData, err = pgwirecancel.MakeBackendKeyData(nodeID); err != nil {
+		return s.sendErr(ctx, conn, err)
+	}
+
 	// If a test is hooking in some authentication option, load it.
 	var testingAuthHook func(context.Context) error
 	if k := s.execCfg.PGWireTestingKnobs; k != nil {
@@ -592,6 +611,53 @@ func (s *Server) ServeConn(ctx context.Context, conn net.Conn, socketType Socket
 	return nil
 }
 
+// handleCancel serves a CancelRequest message. The message carries the
+// BackendKeyData of the session whose queries are to be canceled; the request
+// is forwarded to the session's gateway node if that is not this node.
+//
+// Errors are only logged, since the protocol does not allow the server to
+// respond to a CancelRequest. Also, the error messages are deliberately vague
+// so as to not help someone guessing keys.
+func (s *Server) handleCancel(ctx context.Context, buf *pgwirebase.ReadBuffer) {
+	processID, err := buf.GetUint32()
+	if err != nil {
+		log.VEventf(ctx, 1, "invalid cancel request: %v", err)
+		return
+	}
+	secretKey, err := buf.GetUint32()
+	if err != nil {
+		log.VEventf(ctx, 1, "invalid cancel request: %v", err)
+		return
+	}
+	cancelKey := pgwirecancel.FromProcessIDAndSecret(processID, secretKey)
+
+	var canceled bool
+	statusServer, err := s.execCfg.StatusServer.OptionalErr()
+	if err != nil {
+		// Without a status server (i.e. on SQL tenant servers), only the
+		// sessions served by this server can be canceled.
+		canceled, err = s.execCfg.SessionRegistry.CancelQueryByKey(cancelKey, security.RootUser)
+	} else {
+		var resp *serverpb.CancelQueryResponse
+		resp, err = statusServer.CancelQuery(ctx, &serverpb.CancelQueryRequest{
+			NodeId:         fmt.Sprintf("%d", cancelKey.NodeID()),
+			BackendKeyData: uint64(cancelKey),
+			Username:       security.RootUser,
+		})
+		if err == nil {
+			canceled = resp.Canceled
+			if resp.Error != "" {
+				err = errors.New(resp.Error)
+			}
+		}
+	}
+	if err != nil {
+		log.VEventf(ctx, 1, "cancel request failed: %v", err)
+		return
+	}
+	log.VEventf(ctx, 1, "cancel request served; queries canceled: %t", canceled)
+}
+
 // parseClientProvidedSessionParameters reads the incoming k/v pairs
 // in the startup message into a sql.SessionArgs struct.
 func parseClientProvidedSessionParameters(
@@ -29,7 +29,6 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/duration"
-	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/cockroach/pkg/util/ipaddr"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
@@ -170,6 +169,10 @@ func (b *writeBuffer) writeTextDatum(
 		b.textFormatter.FormatNode(v)
 		b.writeFromFmtCtx(b.textFormatter)
 
+	case *tree.DRange:
+		b.textFormatter.FormatNode(v)
+		b.writeFromFmtCtx(b.textFormatter)
+
 	case *tree.DArray:
 		// Arrays have custom formatting depending on their OID.
 		b.textFormatter.FormatNode(d)
@@ -435,13 +438,52 @@ func (b *writeBuffer) writeBinaryDatum(
 		subWriter := newWriteBuffer(nil /* bytecount */)
 		// Put the number of datums.
 		subWriter.putInt32(int32(len(v.D)))
-		for _, elem := range v.D {
+		fieldTypes := v.ResolvedType().TupleContents()
+		for i, elem := range v.D {
+			// Use the declared field type if it is known, so that NULL fields
+			// and fields of composite types are sent with their proper OIDs.
 			oid := elem.ResolvedType().Oid()
+			if i < len(fieldTypes) && fieldTypes[i].Family() != types.UnknownFamily {
+				oid = fieldTypes[i].Oid()
+			}
 			subWriter.putInt32(int32(oid))
 			subWriter.writeBinaryDatum(ctx, elem, sessionLoc, oid)
 		}
 		b.writeLengthPrefixedBuffer(&subWriter.wrapped)
 
+	case *tree.DRange:
+		// Ranges are serialized as a flags byte followed by the length-prefixed
+		// binary encoding of each bound that is present.
+		subWriter := newWriteBuffer(nil /* bytecount */)
+		var flags byte
+		if v.Empty {
+			flags |= pgRangeEmpty
+		} else {
+			if v.Lower.Inclusive {
+				flags |= pgRangeLowerInclusive
+			}
+			if v.Upper.Inclusive {
+				flags |= pgRangeUpperInclusive
+			}
+			if v.Lower.Unbounded() {
+				flags |= pgRangeLowerInfinite
+			}
+			if v.Upper.Unbounded() {
+				flags |= pgRangeUpperInfinite
+			}
+		}
+		subWriter.writeByte(flags)
+		if !v.Empty {
+			contentsOid := v.ResolvedType().RangeContents().Oid()
+			if !v.Lower.Unbounded() {
+				subWriter.writeBinaryDatum(ctx, v.Lower.Val, sessionLoc, contentsOid)
+			}
+			if !v.Upper.Unbounded() {
+				subWriter.writeBinaryDatum(ctx, v.Upper.Val, sessionLoc, contentsOid)
+			}
+		}
+		b.writeLengthPrefixedBuffer(&subWriter.wrapped)
+
 	case *tree.DGeography:
 		b.putInt32(int32(len(v.EWKB())))
 		b.write(v.EWKB())
@@ -451,33 +493,35 @@ func (b *writeBuffer) writeBinaryDatum(
 		b.write(v.EWKB())
 
 	case *tree.DArray:
-		if v.ParamTyp.Family() == types.ArrayFamily {
-			b.setError(unimplemented.NewWithIssueDetail(32552,
-				"binenc", "unsupported binary serialization of multidimensional arrays"))
-			return
-		}
 		// TODO(andrei): We shouldn't be allocating a new buffer for every array.
 		subWriter := newWriteBuffer(nil /* bytecount */)
-		// Put the number of dimensions. We currently support 1d arrays only.
-		var ndims int32 = 1
-		if v.Len() == 0 {
-			ndims = 0
+		// Put the number of dimensions. Empty arrays have no dimensions.
+		dims, hasNulls := arrayDimensions(v)
+		ndims := int32(len(dims))
+		for _, d := range dims {
+			if d == 0 {
+				ndims = 0
+			}
 		}
 		subWriter.putInt32(ndims)
-		hasNulls := 0
-		if v.HasNulls {
-			hasNulls = 1
+		nullFlag := 0
+		if hasNulls {
+			nullFlag = 1
+		}
+		elemTyp := v.ParamTyp
+		for elemTyp.Family() == types.ArrayFamily {
+			elemTyp = elemTyp.ArrayContents()
 		}
-		oid := v.ParamTyp.Oid()
-		subWriter.putInt32(int32(hasNulls))
+		oid := elemTyp.Oid()
+		subWriter.putInt32(int32(nullFlag))
 		subWriter.putInt32(int32(oid))
-		if v.Len() > 0 {
-			subWriter.putInt32(int32(v.Len()))
-			// Lower bound, we only support a lower bound of 1.
-			subWriter.putInt32(1)
-			for _, elem := range v.Array {
-				subWriter.writeBinaryDatum(ctx, elem, sessionLoc, oid)
+		if ndims > 0 {
+			for _, d := range dims {
+				subWriter.putInt32(d)
+				// Lower bound, we only support a lower bound of 1.
+				subWriter.putInt32(1)
 			}
+			subWriter.writeBinaryArrayElements(ctx, v, sessionLoc, oid)
 		}
 		b.writeLengthPrefixedBuffer(&subWriter.wrapped)
 	case *tree.DJSON:
@@ -494,6 +538,52 @@ func (b *writeBuffer) writeBinaryDatum(
 	}
 }
 
+// arrayDimensions returns the length of every dimension of the given array,
+// and whether any of its innermost elements is NULL. All the sub-arrays in a
+// dimension have the same length, so the lengths are those of the first
+// sub-array in each dimension, or zero if there is none.
+func arrayDimensions(v *tree.DArray) (dims []int32, hasNulls bool) {
+	for sub := v; ; {
+		dims = append(dims, int32(sub.Len()))
+		if sub.ParamTyp.Family() != types.ArrayFamily {
+			break
+		}
+		if sub.Len() == 0 {
+			for t := sub.ParamTyp; t.Family() == types.ArrayFamily; t = t.ArrayContents() {
+				dims = append(dims, 0)
+			}
+			break
+		}
+		sub = tree.MustBeDArray(sub.Array[0])
+	}
+	var findNulls func(v *tree.DArray)
+	findNulls = func(v *tree.DArray) {
+		if v.ParamTyp.Family() != types.ArrayFamily {
+			hasNulls = hasNulls || v.HasNulls
+			return
+		}
+		for _, e := range v.Array {
+			findNulls(tree.MustBeDArray(e))
+		}
+	}
+	findNulls(v)
+	return dims, hasNulls
+}
+
+// writeBinaryArrayElements writes the innermost elements of a possibly
+// multi-dimensional array in row-major order.
+func (b *writeBuffer) writeBinaryArrayElements(
+	ctx context.Context, v *tree.DArray, sessionLoc *time.Location, elemOid oid.Oid,
+) {
+	for _, elem := range v.Array {
+		if v.ParamTyp.Family() == types.ArrayFamily {
+			b.writeBinaryArrayElements(ctx, tree.MustBeDArray(elem), sessionLoc, elemOid)
+		} else {
+			b.writeBinaryDatum(ctx, elem, sessionLoc, elemOid)
+		}
+	}
+}
+
 const (
 	pgTimeFormat              = "15:04:05.999999"
 	pgTimeTZFormat            = pgTimeFormat + "-07:00"
@@ -503,6 +593,15 @@ const (
 	pgTime2400Format          = "24:00:00"
 )
 
+// Flags of the binary encoding of ranges.
+const (
+	pgRangeEmpty          = 0x01
+	pgRangeLowerInclusive = 0x02
+	pgRangeUpperInclusive = 0x04
+	pgRangeLowerInfinite  = 0x08
+	pgRangeUpperInfinite  = 0x10
+)
+
 // formatTime formats t into a format lib/pq understands, appending to the
 // provided tmp buffer and reallocating if needed. The function will then return
 // the resulting buffer.
@@ -114,6 +114,30 @@ func TestWriteBinaryArray(t *testing.T) {
 	}
 }
 
+func TestWriteBinaryComposite(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	// The fields of a value of a composite type are sent with the OIDs of the
+	// declared field types, even if the field is NULL.
+	typ := types.MakeComposite(100, 101, []*types.T{types.Int4, types.String}, []string{"a", "b"})
+	d := tree.NewDTuple(typ, tree.NewDInt(7), tree.DNull)
+
+	buf := newWriteBuffer(nil /* bytecount */)
+	buf.writeBinaryDatum(context.Background(), d, time.UTC, typ.Oid())
+
+	expected := []byte{
+		0, 0, 0, 24, // length
+		0, 0, 0, 2, // number of fields
+		0, 0, 0, byte(oid.T_int4), // field 1 OID
+		0, 0, 0, 4, // field 1 length
+		0, 0, 0, 7, // field 1 value
+		0, 0, 0, byte(oid.T_text), // field 2 OID
+		0xff, 0xff, 0xff, 0xff, // field 2 is NULL
+	}
+	if !bytes.Equal(buf.wrapped.Bytes(), expected) {
+		t.Fatalf("expected %v, got %v", expected, buf.wrapped.Bytes())
+	}
+}
+
 func TestIntArrayRoundTrip(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 
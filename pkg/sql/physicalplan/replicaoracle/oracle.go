@@ -261,7 +261,7 @@ func (o *binPackingOracle) ChoosePreferredReplica(
 func replicaSliceOrErr(
 	desc *roachpb.RangeDescriptor, gsp gossip.DeprecatedOracleGossip,
 ) (kvcoord.ReplicaSlice, error) {
-	replicas, err := kvcoord.NewReplicaSlice(context.TODO(), gsp, desc)
+	replicas, err := kvcoord.NewReplicaSlice(context.TODO(), gsp, desc, kvcoord.AllExtantReplicas)
 	if err != nil {
 		return kvcoord.ReplicaSlice{}, sqlbase.NewRangeUnavailableError(desc.RangeID, err)
 	}
@@ -63,6 +63,8 @@ func getPlanColumns(plan planNode, mut bool) sqlbase.ResultColumns {
 		return n.columns
 	case *explainPlanNode:
 		return n.run.results.columns
+	case *fetchCursorNode:
+		return n.cursor.columns
 	case *windowNode:
 		return n.columns
 	case *showTraceNode:
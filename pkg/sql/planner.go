@@ -133,6 +133,14 @@ type planner struct {
 
 	preparedStatements preparedStatementsAccessor
 
+	// sqlCursors contains the cursors declared in the current transaction.
+	sqlCursors *sqlCursors
+
+	// deferredFKChecks contains the foreign key checks which are deferred until
+	// the end of the current transaction. It is nil for internal planners, which
+	// never defer checks.
+	deferredFKChecks *deferredFKChecks
+
 	// avoidCachedDescriptors, when true, instructs all code that
 	// accesses table/view descriptors to force reading the descriptors
 	// within the transaction. This is necessary to read descriptors
@@ -142,6 +150,10 @@ type planner struct {
 	// 2. Disable the use of the table cache in tests.
 	avoidCachedDescriptors bool
 
+	// boundedStaleness is set if the current statement is a bounded staleness
+	// read, in which case its read timestamp is negotiated after planning.
+	boundedStaleness *boundedStalenessRead
+
 	// If set, the planner should skip checking for the SELECT privilege when
 	// initializing plans to read from a table. This should be used with care.
 	skipSelectPrivilegeChecks bool
@@ -285,6 +297,7 @@ func newInternalPlanner(
 	p.semaCtx.Location = &sd.DataConversion.Location
 	p.semaCtx.SearchPath = sd.SearchPath
 	p.semaCtx.TypeResolver = p
+	p.semaCtx.FunctionResolver = p
 
 	plannerMon := mon.MakeUnlimitedMonitor(ctx,
 		fmt.Sprintf("internal-planner.%s.%s", user, opName),
@@ -308,6 +321,7 @@ func newInternalPlanner(
 	p.extendedEvalCtx.TypeResolver = p
 
 	p.sessionDataMutator = dataMutator
+	p.sqlCursors = &sqlCursors{}
 	p.autoCommit = false
 
 	p.extendedEvalCtx.MemMetrics = memMetrics
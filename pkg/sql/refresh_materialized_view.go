@@ -0,0 +1,115 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/resolver"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
+)
+
+type refreshMaterializedViewNode struct {
+	n    *tree.RefreshMaterializedView
+	desc *sqlbase.MutableTableDescriptor
+}
+
+// RefreshMaterializedView recomputes the stored results of a materialized
+// view.
+// Privileges: CREATE on view.
+func (p *planner) RefreshMaterializedView(
+	ctx context.Context, n *tree.RefreshMaterializedView,
+) (planNode, error) {
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.VersionMaterializedViews) {
+		return nil, pgerror.New(pgcode.FeatureNotSupported,
+			"not all nodes are the correct version for materialized views")
+	}
+	if n.Concurrently {
+		// A refresh never blocks reads of the view, but it does block other
+		// refreshes, so it cannot be performed with the semantics Postgres
+		// gives CONCURRENTLY.
+		return nil, unimplemented.New("refresh concurrently",
+			"REFRESH MATERIALIZED VIEW CONCURRENTLY is not supported")
+	}
+	tn := n.Name.ToTableName()
+	desc, err := p.ResolveMutableTableDescriptor(
+		ctx, &tn, true /* required */, resolver.ResolveRequireViewDesc,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if !desc.MaterializedView() {
+		return nil, pgerror.Newf(pgcode.WrongObjectType, "%q is not a materialized view", desc.Name)
+	}
+	if err := p.CheckPrivilege(ctx, desc, privilege.CREATE); err != nil {
+		return nil, err
+	}
+	return &refreshMaterializedViewNode{n: n, desc: desc}, nil
+}
+
+// ReadingOwnWrites implements the planNodeReadingOwnWrites interface.
+// This is because REFRESH MATERIALIZED VIEW performs multiple KV operations
+// on descriptors and expects to see its own writes.
+func (n *refreshMaterializedViewNode) ReadingOwnWrites() {}
+
+func (n *refreshMaterializedViewNode) startExec(params runParams) error {
+	telemetry.Inc(sqltelemetry.RefreshMaterializedViewCounter)
+
+	desc := n.desc
+	// Only one refresh may be in progress at a time, since each refresh
+	// swaps out the view's indexes when it completes.
+	for i := range desc.Mutations {
+		if desc.Mutations[i].GetMaterializedViewRefresh() != nil {
+			return pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+				"materialized view %q is already being refreshed", desc.Name)
+		}
+	}
+
+	// The refresh is performed by building a new set of indexes for the view
+	// and swapping them in once they are populated. The old indexes are then
+	// garbage collected.
+	newPrimaryIndex := protoutil.Clone(&desc.PrimaryIndex).(*sqlbase.IndexDescriptor)
+	newPrimaryIndex.ID = desc.GetNextIndexID()
+	desc.NextIndexID++
+	newIndexes := make([]sqlbase.IndexDescriptor, len(desc.Indexes))
+	for i := range desc.Indexes {
+		idx := protoutil.Clone(&desc.Indexes[i]).(*sqlbase.IndexDescriptor)
+		idx.ID = desc.GetNextIndexID()
+		desc.NextIndexID++
+		newIndexes[i] = *idx
+	}
+
+	desc.AddMaterializedViewRefreshMutation(&sqlbase.MaterializedViewRefresh{
+		NewPrimaryIndex: *newPrimaryIndex,
+		NewIndexes:      newIndexes,
+		AsOf:            params.p.Txn().ReadTimestamp(),
+	})
+
+	return params.p.writeSchemaChange(
+		params.ctx,
+		desc,
+		desc.ClusterVersion.NextMutationID,
+		tree.AsStringWithFQNames(n.n, params.Ann()),
+	)
+}
+
+func (n *refreshMaterializedViewNode) Next(params runParams) (bool, error) { return false, nil }
+func (n *refreshMaterializedViewNode) Values() tree.Datums                 { return tree.Datums{} }
+func (n *refreshMaterializedViewNode) Close(ctx context.Context)           {}
@@ -191,6 +191,39 @@ func (p *planner) ResolveTypeByID(ctx context.Context, id uint32) (*types.T, err
 	return desc.MakeTypesT(name, p.makeTypeLookupFn(ctx))
 }
 
+// ResolveFunction implements the tree.FunctionReferenceResolver interface.
+func (p *planner) ResolveFunction(
+	ctx context.Context, name *tree.UnresolvedObjectName,
+) (*tree.FunctionDefinition, error) {
+	lookupFlags := tree.ObjectLookupFlags{
+		CommonLookupFlags: tree.CommonLookupFlags{Required: false},
+		DesiredObjectKind: tree.FunctionObject,
+	}
+	desc, _, err := resolver.ResolveExistingObject(ctx, p, name, lookupFlags, resolver.ResolveAnyDescType)
+	if err != nil {
+		if pgerror.GetPGCode(err) == pgcode.UndefinedFunction {
+			// The name refers to an object that is not a function.
+			return nil, nil
+		}
+		return nil, err
+	}
+	if desc == nil {
+		return nil, nil
+	}
+	fdesc := desc.(*sqlbase.ImmutableFunctionDescriptor)
+	if p.contextDatabaseID != sqlbase.InvalidID && fdesc.ParentID != p.contextDatabaseID {
+		return nil, pgerror.Newf(
+			pgcode.FeatureNotSupported, "cross database function references are not supported: %s",
+			tree.ErrString(name))
+	}
+	if err := sqlbase.HydrateTypesInFunctionDescriptor(
+		fdesc.FunctionDesc(), p.makeTypeLookupFn(ctx),
+	); err != nil {
+		return nil, err
+	}
+	return fdesc.MakeFunctionDefinition(), nil
+}
+
 // maybeHydrateTypesInDescriptor hydrates any types.T's in the input descriptor.
 // TODO (rohany): Once we lease types, this should be pushed down into the
 //  leased object collection.
@@ -531,6 +564,8 @@ func newInternalLookupCtxFromDescriptors(
 			descs[i] = sqlbase.NewImmutableTypeDescriptor(*t.Type)
 		case *sqlbase.Descriptor_Schema:
 			descs[i] = sqlbase.NewImmutableSchemaDescriptor(*t.Schema)
+		case *sqlbase.Descriptor_Function:
+			descs[i] = sqlbase.NewImmutableFunctionDescriptor(*t.Function)
 		}
 	}
 	return newInternalLookupCtx(descs, prefix)
@@ -89,6 +89,7 @@ func RevertTables(
 			})
 		}
 		b.Header.MaxSpanRequestKeys = batchSize
+		b.Header.AdmissionPriority = roachpb.BulkAdmissionPriority
 
 		if err := db.Run(ctx, &b); err != nil {
 			return err
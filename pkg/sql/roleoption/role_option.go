@@ -161,9 +161,10 @@ func (rol List) CheckRoleOptionConflicts() error {
 	return nil
 }
 
-// GetHashedPassword returns the value of the password after hashing it.
+// GetHashedPassword returns the value of the password after hashing it
+// with the given method.
 // Returns error if no password option is found or if password is invalid.
-func (rol List) GetHashedPassword() ([]byte, error) {
+func (rol List) GetHashedPassword(method security.HashMethod) ([]byte, error) {
 	var hashedPassword []byte
 	for _, ro := range rol {
 		if ro.Option == PASSWORD {
@@ -178,7 +179,7 @@ func (rol List) GetHashedPassword() ([]byte, error) {
 			if password == "" {
 				return hashedPassword, security.ErrEmptyPassword
 			}
-			hashedPassword, err = security.HashPassword(password)
+			hashedPassword, err = security.HashPasswordWithMethod(method, password)
 			if err != nil {
 				return hashedPassword, err
 			}
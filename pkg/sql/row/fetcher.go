@@ -1409,6 +1409,13 @@ func (rf *Fetcher) checkKeyOrdering(ctx context.Context) error {
 			expectedDirection = sqlbase.IndexDescriptor_ASC
 		}
 
+		if result != 0 && rf.rowReadyTable.index.ColumnNullsReversedAt(i) &&
+			(rf.rowReadyTable.decodedRow[idx] == tree.DNull) != (rf.rowReadyTable.lastDatums[idx] == tree.DNull) {
+			// NULLs are ordered after all other values in the direction of the
+			// column.
+			result = -result
+		}
+
 		if result != 0 {
 			if expectedDirection == sqlbase.IndexDescriptor_ASC && result < 0 ||
 				expectedDirection == sqlbase.IndexDescriptor_DESC && result > 0 {
@@ -1434,8 +1441,10 @@ func (rf *Fetcher) finalizeRow() error {
 		}
 		if table.neededCols.Contains(int(table.cols[i].ID)) && table.row[i].IsUnset() {
 			// If the row was deleted, we'll be missing any non-primary key
-			// columns, including nullable ones, but this is expected.
-			if !table.cols[i].Nullable && !table.rowIsDeleted {
+			// columns, including nullable ones, but this is expected. Virtual
+			// columns are never stored in the primary index, so they are missing
+			// as well.
+			if !table.cols[i].Nullable && !table.cols[i].Virtual && !table.rowIsDeleted {
 				var indexColValues []string
 				for _, idx := range table.indexColIdx {
 					if idx != -1 {
@@ -149,6 +149,14 @@ func makeUpdaterWithoutCascader(
 		if primaryKeyColChange {
 			return true
 		}
+		// A partial index may need updating even if none of its columns
+		// changed, because the row may be added to or removed from the index
+		// when columns referenced in its predicate change. The caller is
+		// responsible for passing the partial indexes that the old and new
+		// rows do not belong to; see UpdateRowWithIgnoredIndexes.
+		if index.IsPartial() && len(updateCols) > 0 {
+			return true
+		}
 		return index.RunOverAllColumns(func(id sqlbase.ColumnID) error {
 			if _, ok := updateColIDtoRowIndex[id]; ok {
 				return returnTruePseudoError
@@ -307,6 +315,31 @@ func (ru *Updater) UpdateRow(
 	updateValues []tree.Datum,
 	checkFKs checkFKConstraints,
 	traceKV bool,
+) ([]tree.Datum, error) {
+	// Without partial index predicate results, the old and new rows are assumed
+	// to have entries in all indexes.
+	var ignoreIndexes util.FastIntSet
+	return ru.UpdateRowWithIgnoredIndexes(
+		ctx, batch, oldValues, updateValues, ignoreIndexes, ignoreIndexes, checkFKs, traceKV,
+	)
+}
+
+// UpdateRowWithIgnoredIndexes is like UpdateRow, except that no index entries
+// are written for the new row in the indexes in ignoreIndexPuts, and no index
+// entries are deleted for the old row in the indexes in ignoreIndexDels. This
+// is used for partial indexes whose predicates the new or old row,
+// respectively, do not satisfy.
+//
+// The return value is only good until the next call to UpdateRow or
+// UpdateRowWithIgnoredIndexes.
+func (ru *Updater) UpdateRowWithIgnoredIndexes(
+	ctx context.Context,
+	batch *kv.Batch,
+	oldValues []tree.Datum,
+	updateValues []tree.Datum,
+	ignoreIndexPuts, ignoreIndexDels util.FastIntSet,
+	checkFKs checkFKConstraints,
+	traceKV bool,
 ) ([]tree.Datum, error) {
 	if ru.cascader != nil {
 		batch = ru.cascader.txn.NewBatch()
@@ -333,11 +366,8 @@ func (ru *Updater) UpdateRow(
 		// deletes of keys that aren't present. We choose to make this
 		// compromise in order to avoid having to read all values of
 		// the row that is being updated.
-		// TODO(mgartner): Add partial index IDs to ignoreIndexes that we should
-		// not delete entries from.
-		var ignoreIndexes util.FastIntSet
 		_, deleteOldSecondaryIndexEntries, err = ru.DeleteHelper.encodeIndexes(
-			ru.FetchColIDtoRowIndex, oldValues, ignoreIndexes, true /* includeEmpty */)
+			ru.FetchColIDtoRowIndex, oldValues, ignoreIndexDels, true /* includeEmpty */)
 		if err != nil {
 			return nil, err
 		}
@@ -384,27 +414,35 @@ func (ru *Updater) UpdateRow(
 		// empty k/v pairs during the process of the update, so
 		// set includeEmpty to false while generating the old
 		// and new index entries.
-		ru.oldIndexEntries[i], err = sqlbase.EncodeSecondaryIndex(
-			ru.Helper.Codec,
-			ru.Helper.TableDesc.TableDesc(),
-			&ru.Helper.Indexes[i],
-			ru.FetchColIDtoRowIndex,
-			oldValues,
-			false, /* includeEmpty */
-		)
-		if err != nil {
-			return nil, err
+		//
+		// Partial indexes whose predicates the old or new row do not satisfy
+		// have no entries for that row.
+		ru.oldIndexEntries[i], ru.newIndexEntries[i] = nil, nil
+		if !ignoreIndexDels.Contains(int(ru.Helper.Indexes[i].ID)) {
+			ru.oldIndexEntries[i], err = sqlbase.EncodeSecondaryIndex(
+				ru.Helper.Codec,
+				ru.Helper.TableDesc.TableDesc(),
+				&ru.Helper.Indexes[i],
+				ru.FetchColIDtoRowIndex,
+				oldValues,
+				false, /* includeEmpty */
+			)
+			if err != nil {
+				return nil, err
+			}
 		}
-		ru.newIndexEntries[i], err = sqlbase.EncodeSecondaryIndex(
-			ru.Helper.Codec,
-			ru.Helper.TableDesc.TableDesc(),
-			&ru.Helper.Indexes[i],
-			ru.FetchColIDtoRowIndex,
-			ru.newValues,
-			false, /* includeEmpty */
-		)
-		if err != nil {
-			return nil, err
+		if !ignoreIndexPuts.Contains(int(ru.Helper.Indexes[i].ID)) {
+			ru.newIndexEntries[i], err = sqlbase.EncodeSecondaryIndex(
+				ru.Helper.Codec,
+				ru.Helper.TableDesc.TableDesc(),
+				&ru.Helper.Indexes[i],
+				ru.FetchColIDtoRowIndex,
+				ru.newValues,
+				false, /* includeEmpty */
+			)
+			if err != nil {
+				return nil, err
+			}
 		}
 		if ru.Helper.Indexes[i].Type == sqlbase.IndexDescriptor_INVERTED {
 			// Deduplicate the keys we're adding and removing if we're updating an
@@ -446,11 +484,8 @@ func (ru *Updater) UpdateRow(
 		if err := ru.rd.DeleteRow(ctx, batch, oldValues, SkipFKs, traceKV); err != nil {
 			return nil, err
 		}
-		// TODO(mgartner): Add partial index IDs to ignoreIndexes that we should
-		// not write entries to.
-		var ignoreIndexes util.FastIntSet
 		if err := ru.ri.InsertRow(
-			ctx, batch, ru.newValues, ignoreIndexes, false /* ignoreConflicts */, SkipFKs, traceKV,
+			ctx, batch, ru.newValues, ignoreIndexPuts, false /* ignoreConflicts */, SkipFKs, traceKV,
 		); err != nil {
 			return nil, err
 		}
@@ -464,11 +499,19 @@ func (ru *Updater) UpdateRow(
 					// TODO(knz): verify that this is indeed correct.
 					continue
 				}
-				// * We always will have at least 1 entry in the index, so indexing 0 is safe.
+				// * We always will have at least 1 entry in the index, so indexing 0 is safe,
+				//   unless the row is not in a partial index.
 				// * The only difference between column family 0 vs other families encodings is
 				//   just the family key ending of the key, so if index[0] is different, the other
 				//   index entries will be different as well.
-				if !bytes.Equal(ru.newIndexEntries[i][0].Key, ru.oldIndexEntries[i][0].Key) {
+				newEntries, oldEntries := ru.newIndexEntries[i], ru.oldIndexEntries[i]
+				if len(newEntries) == 0 || len(oldEntries) == 0 {
+					if len(newEntries) != len(oldEntries) {
+						ru.Fks.addCheckForIndex(ru.Helper.Indexes[i].ID, ru.Helper.Indexes[i].Type)
+					}
+					continue
+				}
+				if !bytes.Equal(newEntries[0].Key, oldEntries[0].Key) {
 					ru.Fks.addCheckForIndex(ru.Helper.Indexes[i].ID, ru.Helper.Indexes[i].Type)
 				}
 			}
@@ -523,6 +566,10 @@ func (ru *Updater) UpdateRow(
 		if index.Type == sqlbase.IndexDescriptor_FORWARD {
 			oldIdx, newIdx := 0, 0
 			oldEntries, newEntries := ru.oldIndexEntries[i], ru.newIndexEntries[i]
+			if len(oldEntries) != len(newEntries) && (len(oldEntries) == 0 || len(newEntries) == 0) {
+				// The row was added to or removed from a partial index.
+				ru.Fks.addCheckForIndex(index.ID, index.Type)
+			}
 			// The index entries for a particular index are stored in
 			// family sorted order. We use this fact to update rows.
 			// The algorithm to update a row using the old k/v pairs
@@ -602,7 +649,8 @@ func (ru *Updater) UpdateRow(
 			for oldIdx < len(oldEntries) {
 				// Delete any remaining old entries that are not matched by new entries in this row.
 				oldEntry := &oldEntries[oldIdx]
-				if oldEntry.Family == sqlbase.FamilyID(0) {
+				// The old row may be the only one with entries in a partial index.
+				if oldEntry.Family == sqlbase.FamilyID(0) && len(newEntries) != 0 {
 					return nil, errors.AssertionFailedf(
 						"index entry for family 0 for table %s, index %s was not generated",
 						ru.Helper.TableDesc.Name, index.Name,
@@ -617,7 +665,8 @@ func (ru *Updater) UpdateRow(
 			for newIdx < len(newEntries) {
 				// Insert any remaining new entries that are not present in the old row.
 				newEntry := &newEntries[newIdx]
-				if newEntry.Family == sqlbase.FamilyID(0) {
+				// The new row may be the only one with entries in a partial index.
+				if newEntry.Family == sqlbase.FamilyID(0) && len(oldEntries) != 0 {
 					return nil, errors.AssertionFailedf(
 						"index entry for family 0 for table %s, index %s was not generated",
 						ru.Helper.TableDesc.Name, index.Name,
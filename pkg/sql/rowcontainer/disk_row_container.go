@@ -17,6 +17,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/diskmap"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/encoding"
@@ -280,7 +281,13 @@ func (d *DiskRowContainer) encodeRow(ctx context.Context, row sqlbase.EncDatumRo
 	for i, orderInfo := range d.ordering {
 		col := orderInfo.ColIdx
 		var err error
-		d.scratchKey, err = row[col].Encode(d.types[col], d.datumAlloc, d.encodings[i], d.scratchKey)
+		if orderInfo.NullsReversed && row[col].IsNull() {
+			d.scratchKey, err = sqlbase.EncodeTableKeyWithNulls(
+				d.scratchKey, tree.DNull, orderInfo.Direction, true, /* nullsReversed */
+			)
+		} else {
+			d.scratchKey, err = row[col].Encode(d.types[col], d.datumAlloc, d.encodings[i], d.scratchKey)
+		}
 		if err != nil {
 			return err
 		}
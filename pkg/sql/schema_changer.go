@@ -209,16 +209,34 @@ func (sc *SchemaChanger) maybeBackfillCreateTableAs(
 	if !(table.Adding() && table.IsAs()) {
 		return nil
 	}
+	return sc.backfillQueryIntoTable(ctx, table, table.CreateQuery, table.CreateAsOfTime, "ctasBackfill")
+}
+
+// maybe backfill a created materialized view by executing the view query.
+// Return nil if successfully backfilled.
+func (sc *SchemaChanger) maybeBackfillMaterializedView(
+	ctx context.Context, table *sqlbase.TableDescriptor,
+) error {
+	if !(table.Adding() && table.MaterializedView()) {
+		return nil
+	}
+	return sc.backfillQueryIntoTable(ctx, table, table.ViewQuery, table.CreateAsOfTime, "materializedViewBackfill")
+}
 
+// backfillQueryIntoTable writes the result of the given query, evaluated at
+// the given timestamp, into the indexes of table.
+func (sc *SchemaChanger) backfillQueryIntoTable(
+	ctx context.Context, table *sqlbase.TableDescriptor, query string, ts hlc.Timestamp, desc string,
+) error {
 	return sc.db.Txn(ctx, func(ctx context.Context, txn *kv.Txn) error {
-		txn.SetFixedTimestamp(ctx, table.CreateAsOfTime)
+		txn.SetFixedTimestamp(ctx, ts)
 
 		// Create an internal planner as the planner used to serve the user query
 		// would have committed by this point.
-		p, cleanup := NewInternalPlanner("ctasBackfill", txn, security.RootUser, &MemoryMetrics{}, sc.execCfg)
+		p, cleanup := NewInternalPlanner(desc, txn, security.RootUser, &MemoryMetrics{}, sc.execCfg)
 		defer cleanup()
 		localPlanner := p.(*planner)
-		stmt, err := parser.ParseOne(table.CreateQuery)
+		stmt, err := parser.ParseOne(query)
 		if err != nil {
 			return err
 		}
@@ -458,6 +476,10 @@ func (sc *SchemaChanger) exec(ctx context.Context) error {
 		return err
 	}
 
+	if err := sc.maybeBackfillMaterializedView(ctx, tableDesc); err != nil {
+		return err
+	}
+
 	if err := sc.maybeMakeAddTablePublic(ctx, tableDesc); err != nil {
 		return err
 	}
@@ -485,8 +507,10 @@ func (sc *SchemaChanger) exec(ctx context.Context) error {
 
 	if sc.mutationID == sqlbase.InvalidMutationID {
 		// Nothing more to do.
-		isCreateTableAs := tableDesc.Adding() && tableDesc.IsAs()
-		return waitToUpdateLeases(isCreateTableAs /* refreshStats */)
+		// Tables created with CREATE TABLE AS and materialized views were just
+		// backfilled, so their stats need to be refreshed.
+		wasBackfilled := tableDesc.Adding() && (tableDesc.IsAs() || tableDesc.MaterializedView())
+		return waitToUpdateLeases(wasBackfilled /* refreshStats */)
 	}
 
 	if err := sc.initJobRunningStatus(ctx); err != nil {
@@ -841,6 +865,36 @@ func (sc *SchemaChanger) done(ctx context.Context) (*sqlbase.ImmutableTableDescr
 					childJobs = append(childJobs, indexGCJob)
 				}
 			}
+			if refresh := mutation.GetMaterializedViewRefresh(); refresh != nil {
+				// The indexes that are replaced by a refresh, or the new indexes if
+				// the refresh is being rolled back, need to be garbage collected.
+				var toGC []sqlbase.IndexDescriptor
+				if mutation.Direction == sqlbase.DescriptorMutation_ADD {
+					toGC = append([]sqlbase.IndexDescriptor{scDesc.PrimaryIndex}, scDesc.Indexes...)
+				} else {
+					toGC = append([]sqlbase.IndexDescriptor{refresh.NewPrimaryIndex}, refresh.NewIndexes...)
+				}
+				dropTime := timeutil.Now().UnixNano()
+				indexGCDetails := jobspb.SchemaChangeGCDetails{ParentID: sc.tableID}
+				for i := range toGC {
+					scDesc.GCMutations = append(
+						scDesc.GCMutations,
+						sqlbase.TableDescriptor_GCDescriptorMutation{IndexID: toGC[i].ID})
+					indexGCDetails.Indexes = append(indexGCDetails.Indexes,
+						jobspb.SchemaChangeGCDetails_DroppedIndex{IndexID: toGC[i].ID, DropTime: dropTime})
+				}
+				description := sc.job.Payload().Description
+				if isRollback {
+					description = "ROLLBACK of " + description
+				}
+				gcJobRecord := CreateGCJobRecord(description, sc.job.Payload().Username, indexGCDetails)
+				indexGCJob, err := sc.jobRegistry.CreateStartableJobWithTxn(ctx, gcJobRecord, txn, nil /* resultsCh */)
+				if err != nil {
+					return err
+				}
+				log.VEventf(ctx, 2, "created index GC job %d", *indexGCJob.ID())
+				childJobs = append(childJobs, indexGCJob)
+			}
 			if constraint := mutation.GetConstraint(); constraint != nil &&
 				constraint.ConstraintType == sqlbase.ConstraintToUpdate_FOREIGN_KEY &&
 				mutation.Direction == sqlbase.DescriptorMutation_ADD &&
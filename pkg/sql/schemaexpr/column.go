@@ -122,7 +122,11 @@ func FormatColumnForDisplay(
 			return "", err
 		}
 		f.WriteString(tree.SerializeForDisplay(typed))
-		f.WriteString(") STORED")
+		if desc.Virtual {
+			f.WriteString(") VIRTUAL")
+		} else {
+			f.WriteString(") STORED")
+		}
 	}
 	return f.CloseAndGetString(), nil
 }
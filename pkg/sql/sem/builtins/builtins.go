@@ -86,6 +86,7 @@ const (
 	categoryIDGeneration  = "ID generation"
 	categoryJSON          = "JSONB"
 	categoryMultiTenancy  = "Multi-tenancy"
+	categoryRange         = "Range"
 	categorySequences     = "Sequence"
 	categoryString        = "String and byte"
 	categorySystemInfo    = "System info"
@@ -2061,6 +2062,80 @@ historical reads against a time which is recent but sufficiently old for reads
 to be performed against the closest replica as opposed to the currently
 leaseholder for a given range.
 
+Note that this function requires an enterprise license on a CCL distribution to
+return without an error.`,
+			Volatility: tree.VolatilityVolatile,
+		},
+	),
+
+	tree.WithMinTimestampFunctionName: makeBuiltin(
+		tree.FunctionProperties{Impure: true},
+		tree.Overload{
+			Types:      tree.ArgTypes{{"min_timestamp", types.TimestampTZ}},
+			ReturnType: tree.FixedReturnType(types.TimestampTZ),
+			Fn:         withMinTimestamp,
+			Info: `When used in the AS OF SYSTEM TIME clause of a single-statement,
+read-only transaction, CockroachDB chooses the newest timestamp at or after
+min_timestamp at which the query can be served by the nearest replicas of the
+data it reads, without blocking on the leaseholder. If the nearest replicas
+cannot serve the query at or after min_timestamp, an error is returned.
+
+Note that this function requires an enterprise license on a CCL distribution to
+return without an error.`,
+			Volatility: tree.VolatilityVolatile,
+		},
+		tree.Overload{
+			Types: tree.ArgTypes{
+				{"min_timestamp", types.TimestampTZ},
+				{"allow_leaseholder", types.Bool},
+			},
+			ReturnType: tree.FixedReturnType(types.TimestampTZ),
+			Fn:         withMinTimestamp,
+			Info: `When used in the AS OF SYSTEM TIME clause of a single-statement,
+read-only transaction, CockroachDB chooses the newest timestamp at or after
+min_timestamp at which the query can be served by the nearest replicas of the
+data it reads, without blocking on the leaseholder. If the nearest replicas
+cannot serve the query at or after min_timestamp and allow_leaseholder is true,
+the query is served by the leaseholder at min_timestamp; otherwise, an error is
+returned.
+
+Note that this function requires an enterprise license on a CCL distribution to
+return without an error.`,
+			Volatility: tree.VolatilityVolatile,
+		},
+	),
+
+	tree.WithMaxStalenessFunctionName: makeBuiltin(
+		tree.FunctionProperties{Impure: true},
+		tree.Overload{
+			Types:      tree.ArgTypes{{"max_staleness", types.Interval}},
+			ReturnType: tree.FixedReturnType(types.TimestampTZ),
+			Fn:         withMaxStaleness,
+			Info: `When used in the AS OF SYSTEM TIME clause of a single-statement,
+read-only transaction, CockroachDB chooses the newest timestamp within the
+staleness bound at which the query can be served by the nearest replicas of the
+data it reads, without blocking on the leaseholder. If the nearest replicas
+cannot serve the query within the staleness bound, an error is returned.
+
+Note that this function requires an enterprise license on a CCL distribution to
+return without an error.`,
+			Volatility: tree.VolatilityVolatile,
+		},
+		tree.Overload{
+			Types: tree.ArgTypes{
+				{"max_staleness", types.Interval},
+				{"allow_leaseholder", types.Bool},
+			},
+			ReturnType: tree.FixedReturnType(types.TimestampTZ),
+			Fn:         withMaxStaleness,
+			Info: `When used in the AS OF SYSTEM TIME clause of a single-statement,
+read-only transaction, CockroachDB chooses the newest timestamp within the
+staleness bound at which the query can be served by the nearest replicas of the
+data it reads, without blocking on the leaseholder. If the nearest replicas
+cannot serve the query within the staleness bound and allow_leaseholder is true,
+the query is served by the leaseholder at the oldest timestamp within the
+bound; otherwise, an error is returned.
+
 Note that this function requires an enterprise license on a CCL distribution to
 return without an error.`,
 			Volatility: tree.VolatilityVolatile,
@@ -2593,9 +2668,7 @@ may increase either contention or retry errors, or both.`,
 				dimen := int64(tree.MustBeDInt(args[1]))
 				return arrayLength(arr, dimen), nil
 			},
-			Info: "Calculates the length of `input` on the provided `array_dimension`. However, " +
-				"because CockroachDB doesn't yet support multi-dimensional arrays, the only supported" +
-				" `array_dimension` is **1**.",
+			Info:       "Calculates the length of `input` on the provided `array_dimension`.",
 			Volatility: tree.VolatilityImmutable,
 		},
 	),
@@ -2609,9 +2682,7 @@ may increase either contention or retry errors, or both.`,
 				dimen := int64(tree.MustBeDInt(args[1]))
 				return arrayLower(arr, dimen), nil
 			},
-			Info: "Calculates the minimum value of `input` on the provided `array_dimension`. " +
-				"However, because CockroachDB doesn't yet support multi-dimensional arrays, the only " +
-				"supported `array_dimension` is **1**.",
+			Info:       "Calculates the minimum value of `input` on the provided `array_dimension`.",
 			Volatility: tree.VolatilityImmutable,
 		},
 	),
@@ -2625,9 +2696,60 @@ may increase either contention or retry errors, or both.`,
 				dimen := int64(tree.MustBeDInt(args[1]))
 				return arrayLength(arr, dimen), nil
 			},
-			Info: "Calculates the maximum value of `input` on the provided `array_dimension`. " +
-				"However, because CockroachDB doesn't yet support multi-dimensional arrays, the only " +
-				"supported `array_dimension` is **1**.",
+			Info:       "Calculates the maximum value of `input` on the provided `array_dimension`.",
+			Volatility: tree.VolatilityImmutable,
+		},
+	),
+
+	"array_ndims": makeBuiltin(arrayProps(),
+		tree.Overload{
+			Types:      tree.ArgTypes{{"input", types.AnyArray}},
+			ReturnType: tree.FixedReturnType(types.Int),
+			Fn: func(_ *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				arr := tree.MustBeDArray(args[0])
+				if arr.Len() == 0 {
+					return tree.DNull, nil
+				}
+				return tree.NewDInt(tree.DInt(len(arrayDimensions(arr)))), nil
+			},
+			Info:       "Returns the number of dimensions of `input`.",
+			Volatility: tree.VolatilityImmutable,
+		},
+	),
+
+	"array_dims": makeBuiltin(arrayProps(),
+		tree.Overload{
+			Types:      tree.ArgTypes{{"input", types.AnyArray}},
+			ReturnType: tree.FixedReturnType(types.String),
+			Fn: func(_ *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				arr := tree.MustBeDArray(args[0])
+				if arr.Len() == 0 {
+					return tree.DNull, nil
+				}
+				var buf bytes.Buffer
+				for _, n := range arrayDimensions(arr) {
+					fmt.Fprintf(&buf, "[1:%d]", n)
+				}
+				return tree.NewDString(buf.String()), nil
+			},
+			Info:       "Returns a text representation of the dimensions of `input`.",
+			Volatility: tree.VolatilityImmutable,
+		},
+	),
+
+	"cardinality": makeBuiltin(arrayProps(),
+		tree.Overload{
+			Types:      tree.ArgTypes{{"input", types.AnyArray}},
+			ReturnType: tree.FixedReturnType(types.Int),
+			Fn: func(_ *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				arr := tree.MustBeDArray(args[0])
+				n := 1
+				for _, d := range arrayDimensions(arr) {
+					n *= d
+				}
+				return tree.NewDInt(tree.DInt(n)), nil
+			},
+			Info:       "Returns the total number of elements in `input`, or 0 if it is empty.",
 			Volatility: tree.VolatilityImmutable,
 		},
 	),
@@ -3849,39 +3971,25 @@ may increase either contention or retry errors, or both.`,
 			Fn: func(_ *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
 				value := args[0].(*tree.DArray)
 				scale := int32(tree.MustBeDInt(args[1]))
-
-				// Lazily allocate a new array only if/when one of its elements
-				// is rounded.
-				var newArr tree.Datums
-				for i, elem := range value.Array {
-					// Skip NULL values.
-					if elem == tree.DNull {
-						continue
-					}
-
-					rounded, err := roundDDecimal(elem.(*tree.DDecimal), scale)
-					if err != nil {
-						return nil, err
-					}
-					if rounded != elem {
-						if newArr == nil {
-							newArr = make(tree.Datums, len(value.Array))
-							copy(newArr, value.Array)
-						}
-						newArr[i] = rounded
-					}
-				}
-				if newArr != nil {
-					ret := &tree.DArray{}
-					*ret = *value
-					ret.Array = newArr
-					return ret, nil
-				}
-				return value, nil
+				return roundDecimalArray(value, scale)
 			},
 			Info:       "This function is used internally to round decimal array values during mutations.",
 			Volatility: tree.VolatilityStable,
 		},
+		tree.Overload{
+			Types: tree.ArgTypes{
+				{"val", types.MakeArray(types.AnyArray)},
+				{"scale", types.Int},
+			},
+			ReturnType: tree.IdentityReturnType(0),
+			Fn: func(_ *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				value := args[0].(*tree.DArray)
+				scale := int32(tree.MustBeDInt(args[1]))
+				return roundDecimalArray(value, scale)
+			},
+			Info:       "This function is used internally to round multi-dimensional decimal array values during mutations.",
+			Volatility: tree.VolatilityStable,
+		},
 	),
 	"crdb_internal.completed_migrations": makeBuiltin(
 		tree.FunctionProperties{
@@ -5264,6 +5372,61 @@ func arrayLength(arr *tree.DArray, dim int64) tree.Datum {
 	return arrayLength(a, dim-1)
 }
 
+// roundDecimalArray rounds all the decimals in a possibly multi-dimensional
+// DECIMAL array to the given scale.
+func roundDecimalArray(value *tree.DArray, scale int32) (*tree.DArray, error) {
+	// Lazily allocate a new array only if/when one of its elements
+	// is rounded.
+	var newArr tree.Datums
+	for i, elem := range value.Array {
+		// Skip NULL values.
+		if elem == tree.DNull {
+			continue
+		}
+
+		var rounded tree.Datum
+		var err error
+		if sub, ok := elem.(*tree.DArray); ok {
+			rounded, err = roundDecimalArray(sub, scale)
+		} else {
+			rounded, err = roundDDecimal(elem.(*tree.DDecimal), scale)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if rounded != elem {
+			if newArr == nil {
+				newArr = make(tree.Datums, len(value.Array))
+				copy(newArr, value.Array)
+			}
+			newArr[i] = rounded
+		}
+	}
+	if newArr != nil {
+		ret := &tree.DArray{}
+		*ret = *value
+		ret.Array = newArr
+		return ret, nil
+	}
+	return value, nil
+}
+
+// arrayDimensions returns the length of each dimension of arr. All sub-arrays
+// of a multi-dimensional array have the same length, so only the first one is
+// inspected at each level.
+func arrayDimensions(arr *tree.DArray) []int {
+	dims := []int{arr.Len()}
+	for arr.Len() > 0 {
+		sub, ok := tree.AsDArray(arr.Array[0])
+		if !ok {
+			break
+		}
+		dims = append(dims, sub.Len())
+		arr = sub
+	}
+	return dims
+}
+
 var intOne = tree.NewDInt(tree.DInt(1))
 
 func arrayLower(arr *tree.DArray, dim int64) tree.Datum {
@@ -5901,6 +6064,46 @@ func checkPrivilegedUser(ctx *tree.EvalContext) error {
 // if an enterprise license is not installed.
 var EvalFollowerReadOffset func(clusterID uuid.UUID, _ *cluster.Settings) (time.Duration, error)
 
+// CheckBoundedStalenessEnabled is a function used by the bounded staleness
+// builtins to determine whether bounded staleness reads can be performed. It is
+// injected by followerreadsccl. An error may be returned if an enterprise
+// license is not installed.
+var CheckBoundedStalenessEnabled func(clusterID uuid.UUID, _ *cluster.Settings) error
+
+func checkBoundedStalenessEnabled(ctx *tree.EvalContext, name string) error {
+	if CheckBoundedStalenessEnabled == nil {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"%s is only available in ccl distribution", name)
+	}
+	return CheckBoundedStalenessEnabled(ctx.ClusterID, ctx.Settings)
+}
+
+// withMinTimestamp implements the with_min_timestamp builtin. It returns the
+// minimum timestamp bound of the bounded staleness read.
+func withMinTimestamp(ctx *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+	if err := checkBoundedStalenessEnabled(ctx, tree.WithMinTimestampFunctionName); err != nil {
+		return nil, err
+	}
+	return args[0], nil
+}
+
+// withMaxStaleness implements the with_max_staleness builtin. It returns the
+// minimum timestamp bound of the bounded staleness read, i.e. the statement
+// timestamp minus the maximum staleness.
+func withMaxStaleness(ctx *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+	if err := checkBoundedStalenessEnabled(ctx, tree.WithMaxStalenessFunctionName); err != nil {
+		return nil, err
+	}
+	d := tree.MustBeDInterval(args[0])
+	if d.Duration.Compare(duration.Duration{}) <= 0 {
+		return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+			"interval must be positive, got %s", d)
+	}
+	return tree.MakeDTimestampTZ(
+		duration.Add(ctx.GetStmtTimestamp(), d.Duration.Mul(-1)), time.Microsecond,
+	)
+}
+
 func recentTimestamp(ctx *tree.EvalContext) (time.Time, error) {
 	if EvalFollowerReadOffset == nil {
 		return time.Time{}, pgerror.New(pgcode.FeatureNotSupported,
@@ -81,6 +81,10 @@ var typeBuiltinsHaveUnderscore = map[oid.Oid]struct{}{
 // is either the type's postgres display name or the type's postgres display
 // name plus an underscore, depending on the type.
 func PGIOBuiltinPrefix(typ *types.T) string {
+	if typ.Family() == types.RangeFamily {
+		// All range types share the range_ i/o builtins.
+		return "range_"
+	}
 	builtinPrefix := typ.PGName()
 	if _, ok := typeBuiltinsHaveUnderscore[typ.Oid()]; ok {
 		return builtinPrefix + "_"
@@ -104,7 +108,8 @@ func initPGBuiltins() {
 		switch typ.Oid() {
 		case oid.T_int2vector, oid.T_oidvector:
 		default:
-			if typ.Family() == types.ArrayFamily {
+			// Range types are also done separately below.
+			if typ.Family() == types.ArrayFamily || typ.Family() == types.RangeFamily {
 				continue
 			}
 		}
@@ -124,6 +129,10 @@ func initPGBuiltins() {
 	for name, builtin := range makeTypeIOBuiltins("enum_", types.AnyEnum) {
 		builtins[name] = builtin
 	}
+	// Make range type i/o builtins.
+	for name, builtin := range makeTypeIOBuiltins("range_", types.Int8Range) {
+		builtins[name] = builtin
+	}
 
 	// Make crdb_internal.create_regfoo builtins.
 	for _, typ := range []*types.T{types.RegType, types.RegProc, types.RegProcedure, types.RegClass, types.RegNamespace} {
@@ -0,0 +1,156 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package builtins
+
+import (
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+func initRangeBuiltins() {
+	for k, v := range makeRangeBuiltins() {
+		// lower and upper are also string functions, so the range overloads are
+		// added to the existing definitions.
+		if existing, ok := builtins[k]; ok {
+			existing.overloads = append(existing.overloads, v.overloads...)
+			builtins[k] = existing
+			continue
+		}
+		v.props.Category = categoryRange
+		builtins[k] = v
+	}
+}
+
+var errInvalidRangeBoundFlags = pgerror.New(pgcode.Syntax, "invalid range bound flags")
+
+func makeRangeBuiltins() map[string]builtinDefinition {
+	rangeBuiltins := map[string]builtinDefinition{
+		"int4range": makeRangeConstructor(types.Int4Range, types.Int),
+		"int8range": makeRangeConstructor(types.Int8Range, types.Int),
+		"tsrange":   makeRangeConstructor(types.TimestampRange, types.Timestamp),
+		"tstzrange": makeRangeConstructor(types.TimestampTZRange, types.TimestampTZ),
+		"daterange": makeRangeConstructor(types.DateRange, types.Date),
+		"lower":     makeRangeBoundAccessor(false /* upper */),
+		"upper":     makeRangeBoundAccessor(true /* upper */),
+		"isempty":   makeRangeBoolFunction(func(r *tree.DRange) bool { return r.Empty }, "Returns whether `val` is empty."),
+		"lower_inc": makeRangeBoolFunction(func(r *tree.DRange) bool { return !r.Empty && r.Lower.Inclusive }, "Returns whether the lower bound of `val` is inclusive."),
+		"upper_inc": makeRangeBoolFunction(func(r *tree.DRange) bool { return !r.Empty && r.Upper.Inclusive }, "Returns whether the upper bound of `val` is inclusive."),
+		"lower_inf": makeRangeBoolFunction(func(r *tree.DRange) bool { return !r.Empty && r.Lower.Unbounded() }, "Returns whether the lower bound of `val` is infinite."),
+		"upper_inf": makeRangeBoolFunction(func(r *tree.DRange) bool { return !r.Empty && r.Upper.Unbounded() }, "Returns whether the upper bound of `val` is infinite."),
+	}
+	return rangeBuiltins
+}
+
+// makeRangeConstructor returns the definition of the function that
+// constructs ranges of type typ from bounds of type subtype. A NULL bound
+// makes the range unbounded on that side.
+func makeRangeConstructor(typ, subtype *types.T) builtinDefinition {
+	construct := func(lower, upper tree.Datum, flags string) (tree.Datum, error) {
+		var lowerBound, upperBound tree.RangeBound
+		switch flags {
+		case "[)":
+			lowerBound.Inclusive = true
+		case "(]":
+			upperBound.Inclusive = true
+		case "[]":
+			lowerBound.Inclusive, upperBound.Inclusive = true, true
+		case "()":
+		default:
+			return nil, errInvalidRangeBoundFlags
+		}
+		if lower != tree.DNull {
+			lowerBound.Val = lower
+		}
+		if upper != tree.DNull {
+			upperBound.Val = upper
+		}
+		return tree.NewDRange(typ, lowerBound, upperBound)
+	}
+	return makeBuiltin(
+		tree.FunctionProperties{Category: categoryRange, NullableArgs: true},
+		tree.Overload{
+			Types:      tree.ArgTypes{{"lower", subtype}, {"upper", subtype}},
+			ReturnType: tree.FixedReturnType(typ),
+			Fn: func(_ *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				return construct(args[0], args[1], "[)")
+			},
+			Info: fmt.Sprintf("Constructs a %s that includes `lower` and excludes `upper`. "+
+				"A NULL bound makes the range unbounded on that side.", typ.Name()),
+			Volatility: tree.VolatilityImmutable,
+		},
+		tree.Overload{
+			Types:      tree.ArgTypes{{"lower", subtype}, {"upper", subtype}, {"bounds", types.String}},
+			ReturnType: tree.FixedReturnType(typ),
+			Fn: func(_ *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				if args[2] == tree.DNull {
+					return nil, errInvalidRangeBoundFlags
+				}
+				return construct(args[0], args[1], string(tree.MustBeDString(args[2])))
+			},
+			Info: fmt.Sprintf("Constructs a %s from `lower` and `upper`. `bounds` is one of "+
+				"'[)', '(]', '[]' or '()' and specifies whether each bound is inclusive. "+
+				"A NULL bound makes the range unbounded on that side.", typ.Name()),
+			Volatility: tree.VolatilityImmutable,
+		},
+	)
+}
+
+// makeRangeBoundAccessor returns the definition of lower or upper on ranges.
+// The result is NULL if the range is empty or unbounded on that side.
+func makeRangeBoundAccessor(upper bool) builtinDefinition {
+	info := "Returns the lower bound of `val`."
+	if upper {
+		info = "Returns the upper bound of `val`."
+	}
+	overloads := make([]tree.Overload, 0, len(types.Ranges))
+	for _, typ := range types.Ranges {
+		overloads = append(overloads, tree.Overload{
+			Types:      tree.ArgTypes{{"val", typ}},
+			ReturnType: tree.FixedReturnType(typ.RangeContents()),
+			Fn: func(_ *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				r := tree.MustBeDRange(args[0])
+				bound := r.Lower
+				if upper {
+					bound = r.Upper
+				}
+				if r.Empty || bound.Unbounded() {
+					return tree.DNull, nil
+				}
+				return bound.Val, nil
+			},
+			Info:       info,
+			Volatility: tree.VolatilityImmutable,
+		})
+	}
+	return makeBuiltin(tree.FunctionProperties{Category: categoryRange}, overloads...)
+}
+
+// makeRangeBoolFunction returns the definition of a function that computes a
+// boolean property of a range.
+func makeRangeBoolFunction(f func(*tree.DRange) bool, info string) builtinDefinition {
+	overloads := make([]tree.Overload, 0, len(types.Ranges))
+	for _, typ := range types.Ranges {
+		overloads = append(overloads, tree.Overload{
+			Types:      tree.ArgTypes{{"val", typ}},
+			ReturnType: tree.FixedReturnType(types.Bool),
+			Fn: func(_ *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				return tree.MakeDBool(tree.DBool(f(tree.MustBeDRange(args[0])))), nil
+			},
+			Info:       info,
+			Volatility: tree.VolatilityImmutable,
+		})
+	}
+	return makeBuiltin(tree.FunctionProperties{Category: categoryRange}, overloads...)
+}
@@ -30,13 +30,62 @@ import (
 // reads.
 const FollowerReadTimestampFunctionName = "experimental_follower_read_timestamp"
 
-var errInvalidExprForAsOf = errors.Errorf("AS OF SYSTEM TIME: only constant expressions or " +
+// WithMinTimestampFunctionName is the name of the function which can be used
+// with AOST clauses to perform a bounded staleness read that observes data at
+// or after the given timestamp.
+const WithMinTimestampFunctionName = "with_min_timestamp"
+
+// WithMaxStalenessFunctionName is the name of the function which can be used
+// with AOST clauses to perform a bounded staleness read that observes data no
+// staler than the given interval.
+const WithMaxStalenessFunctionName = "with_max_staleness"
+
+var errInvalidExprForAsOf = errors.Errorf("AS OF SYSTEM TIME: only constant expressions, " +
+	WithMinTimestampFunctionName + ", " + WithMaxStalenessFunctionName + ", or " +
 	FollowerReadTimestampFunctionName + " are allowed")
 
-// EvalAsOfTimestamp evaluates the timestamp argument to an AS OF SYSTEM TIME query.
+// AsOfSystemTime is the result of evaluating an AS OF SYSTEM TIME clause.
+type AsOfSystemTime struct {
+	// Timestamp is the HLC timestamp evaluated from the AS OF SYSTEM TIME
+	// clause. For bounded staleness reads, it is the minimum timestamp bound
+	// of the read; the read timestamp itself is negotiated during execution.
+	Timestamp hlc.Timestamp
+	// BoundedStaleness is set if the clause used with_min_timestamp or
+	// with_max_staleness, in which case the query may be evaluated at any
+	// timestamp between Timestamp and the statement time.
+	BoundedStaleness bool
+	// AllowLeaseholder is set if the allow_leaseholder argument of the bounded
+	// staleness function was true, in which case a bounded staleness read that
+	// can't be served by the nearest replica is served by the leaseholder
+	// instead of failing.
+	AllowLeaseholder bool
+}
+
+// IsBoundedStalenessFunction returns whether the function with the given name
+// can be used with AOST clauses to perform a bounded staleness read.
+func IsBoundedStalenessFunction(name string) bool {
+	return name == WithMinTimestampFunctionName || name == WithMaxStalenessFunctionName
+}
+
+// EvalAsOfTimestamp evaluates the timestamp argument to an AS OF SYSTEM TIME
+// query. Bounded staleness reads are not allowed.
 func EvalAsOfTimestamp(
 	ctx context.Context, asOf AsOfClause, semaCtx *SemaContext, evalCtx *EvalContext,
-) (tsss hlc.Timestamp, err error) {
+) (hlc.Timestamp, error) {
+	asOfSystemTime, err := EvalAsOf(ctx, asOf, semaCtx, evalCtx, false /* allowBoundedStaleness */)
+	return asOfSystemTime.Timestamp, err
+}
+
+// EvalAsOf evaluates the AS OF SYSTEM TIME clause of a query. If
+// allowBoundedStaleness is false, an error is returned if the clause specifies
+// a bounded staleness read.
+func EvalAsOf(
+	ctx context.Context,
+	asOf AsOfClause,
+	semaCtx *SemaContext,
+	evalCtx *EvalContext,
+	allowBoundedStaleness bool,
+) (AsOfSystemTime, error) {
 	// We need to save and restore the previous value of the field in
 	// semaCtx in case we are recursively called within a subquery
 	// context.
@@ -45,41 +94,73 @@ func EvalAsOfTimestamp(
 	scalarProps.Require("AS OF SYSTEM TIME", RejectSpecial|RejectSubqueries)
 
 	// In order to support the follower reads feature we permit this expression
-	// to be a simple invocation of the `FollowerReadTimestampFunction`.
+	// to be a simple invocation of the `FollowerReadTimestampFunction`, and in
+	// order to support bounded staleness reads, a simple invocation of one of
+	// the bounded staleness functions with constant arguments.
 	// Over time we could expand the set of allowed functions or expressions.
 	// All non-function expressions must be const and must TypeCheck into a
 	// string.
+	var res AsOfSystemTime
 	var te TypedExpr
 	if fe, ok := asOf.Expr.(*FuncExpr); ok {
 		def, err := fe.Func.Resolve(semaCtx.SearchPath)
 		if err != nil {
-			return hlc.Timestamp{}, errInvalidExprForAsOf
+			return AsOfSystemTime{}, errInvalidExprForAsOf
 		}
-		if def.Name != FollowerReadTimestampFunctionName {
-			return hlc.Timestamp{}, errInvalidExprForAsOf
+		switch {
+		case def.Name == FollowerReadTimestampFunctionName:
+		case IsBoundedStalenessFunction(def.Name):
+			if !allowBoundedStaleness {
+				return AsOfSystemTime{}, pgerror.Newf(pgcode.FeatureNotSupported,
+					"AS OF SYSTEM TIME: %s can only be used with a single-statement SELECT "+
+						"in an implicit transaction", def.Name)
+			}
+			res.BoundedStaleness = true
+		default:
+			return AsOfSystemTime{}, errInvalidExprForAsOf
 		}
 		if te, err = fe.TypeCheck(ctx, semaCtx, types.TimestampTZ); err != nil {
-			return hlc.Timestamp{}, err
+			return AsOfSystemTime{}, err
+		}
+		if res.BoundedStaleness {
+			args := te.(*FuncExpr).Exprs
+			for i := range args {
+				if !IsConst(evalCtx, args[i].(TypedExpr)) {
+					return AsOfSystemTime{}, errInvalidExprForAsOf
+				}
+			}
+			if len(args) > 1 {
+				d, err := args[1].(TypedExpr).Eval(evalCtx)
+				if err != nil {
+					return AsOfSystemTime{}, err
+				}
+				if allowLeaseholder, ok := d.(*DBool); ok {
+					res.AllowLeaseholder = bool(*allowLeaseholder)
+				}
+			}
 		}
 	} else {
 		var err error
 		te, err = asOf.Expr.TypeCheck(ctx, semaCtx, types.String)
 		if err != nil {
-			return hlc.Timestamp{}, err
+			return AsOfSystemTime{}, err
 		}
 		if !IsConst(evalCtx, te) {
-			return hlc.Timestamp{}, errInvalidExprForAsOf
+			return AsOfSystemTime{}, errInvalidExprForAsOf
 		}
 	}
 
 	d, err := te.Eval(evalCtx)
 	if err != nil {
-		return hlc.Timestamp{}, err
+		return AsOfSystemTime{}, err
 	}
 
 	stmtTimestamp := evalCtx.GetStmtTimestamp()
-	ts, err := DatumToHLC(evalCtx, stmtTimestamp, d)
-	return ts, errors.Wrap(err, "AS OF SYSTEM TIME")
+	res.Timestamp, err = DatumToHLC(evalCtx, stmtTimestamp, d)
+	if err != nil {
+		return AsOfSystemTime{}, errors.Wrap(err, "AS OF SYSTEM TIME")
+	}
+	return res, nil
 }
 
 // DatumToHLC performs the conversion from a Datum to an HLC timestamp.
@@ -29,6 +29,10 @@ const (
 	AllDescriptors
 )
 
+// LatestBackupSubdir is the subdirectory name which refers to the most
+// recent backup in a backup collection.
+const LatestBackupSubdir = "LATEST"
+
 // Backup represents a BACKUP statement.
 type Backup struct {
 	Targets            TargetList
@@ -37,6 +41,16 @@ type Backup struct {
 	IncrementalFrom    Exprs
 	AsOf               AsOfClause
 	Options            KVOptions
+
+	// Nested is set for BACKUP INTO, in which case To refers to a backup
+	// collection, rather than a backup location.
+	Nested bool
+	// AppendToLatest is set for BACKUP INTO LATEST IN: the backup is appended
+	// to the most recent backup in the collection.
+	AppendToLatest bool
+	// Subdir is the explicitly requested subdirectory of the collection, if
+	// any.
+	Subdir Expr
 }
 
 var _ Statement = &Backup{}
@@ -47,7 +61,17 @@ func (node *Backup) Format(ctx *FmtCtx) {
 	if node.DescriptorCoverage == RequestedDescriptors {
 		ctx.FormatNode(&node.Targets)
 	}
-	ctx.WriteString(" TO ")
+	if node.Nested {
+		ctx.WriteString(" INTO ")
+		if node.Subdir != nil {
+			ctx.FormatNode(node.Subdir)
+			ctx.WriteString(" IN ")
+		} else if node.AppendToLatest {
+			ctx.WriteString("LATEST IN ")
+		}
+	} else {
+		ctx.WriteString(" TO ")
+	}
 	ctx.FormatNode(&node.To)
 	if node.AsOf.Expr != nil {
 		ctx.WriteString(" ")
@@ -63,6 +87,66 @@ func (node *Backup) Format(ctx *FmtCtx) {
 	}
 }
 
+// FullBackupClause describes the frequency of full backups.
+type FullBackupClause struct {
+	AlwaysFull bool
+	Recurrence Expr
+}
+
+// ScheduledBackup represents scheduled backup job.
+type ScheduledBackup struct {
+	ScheduleLabel   Expr
+	Recurrence      Expr
+	FullBackup      *FullBackupClause /* nil implies choose default */
+	Targets         *TargetList       /* nil implies tree.AllDescriptors coverage */
+	To              PartitionedBackup
+	BackupOptions   KVOptions
+	ScheduleOptions KVOptions
+}
+
+var _ Statement = &ScheduledBackup{}
+
+// Format implements the NodeFormatter interface.
+func (node *ScheduledBackup) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE SCHEDULE")
+
+	if node.ScheduleLabel != nil {
+		ctx.WriteString(" ")
+		ctx.FormatNode(node.ScheduleLabel)
+	}
+
+	ctx.WriteString(" FOR BACKUP")
+	if node.Targets != nil {
+		ctx.WriteString(" ")
+		ctx.FormatNode(node.Targets)
+	}
+
+	ctx.WriteString(" INTO ")
+	ctx.FormatNode(&node.To)
+
+	if node.BackupOptions != nil {
+		ctx.WriteString(" WITH ")
+		ctx.FormatNode(&node.BackupOptions)
+	}
+
+	ctx.WriteString(" RECURRING ")
+	ctx.FormatNode(node.Recurrence)
+
+	if node.FullBackup != nil {
+		if node.FullBackup.AlwaysFull {
+			ctx.WriteString(" FULL BACKUP ALWAYS")
+		} else {
+			ctx.WriteString(" FULL BACKUP ")
+			ctx.FormatNode(node.FullBackup.Recurrence)
+		}
+	}
+
+	if node.ScheduleOptions != nil {
+		ctx.WriteString(" WITH SCHEDULE OPTIONS ")
+		ctx.FormatNode(&node.ScheduleOptions)
+	}
+}
+
 // Restore represents a RESTORE statement.
 type Restore struct {
 	Targets            TargetList
@@ -70,6 +154,10 @@ type Restore struct {
 	From               []PartitionedBackup
 	AsOf               AsOfClause
 	Options            KVOptions
+
+	// Subdir, if set, is the subdirectory of the backup collection in From to
+	// restore; LatestBackupSubdir refers to the most recent backup.
+	Subdir Expr
 }
 
 var _ Statement = &Restore{}
@@ -81,6 +169,10 @@ func (node *Restore) Format(ctx *FmtCtx) {
 		ctx.FormatNode(&node.Targets)
 	}
 	ctx.WriteString(" FROM ")
+	if node.Subdir != nil {
+		ctx.FormatNode(node.Subdir)
+		ctx.WriteString(" IN ")
+	}
 	for i := range node.From {
 		if i > 0 {
 			ctx.WriteString(", ")
@@ -159,6 +159,7 @@ var validCasts = []castInfo{
 	{from: types.INetFamily, to: types.StringFamily, volatility: VolatilityImmutable},
 	{from: types.JsonFamily, to: types.StringFamily, volatility: VolatilityImmutable},
 	{from: types.EnumFamily, to: types.StringFamily, volatility: VolatilityImmutable},
+	{from: types.RangeFamily, to: types.StringFamily, volatility: VolatilityStable},
 
 	// Casts to CollatedStringFamily.
 	{from: types.UnknownFamily, to: types.CollatedStringFamily, volatility: VolatilityImmutable},
@@ -185,6 +186,7 @@ var validCasts = []castInfo{
 	{from: types.INetFamily, to: types.CollatedStringFamily, volatility: VolatilityImmutable},
 	{from: types.JsonFamily, to: types.CollatedStringFamily, volatility: VolatilityImmutable},
 	{from: types.EnumFamily, to: types.CollatedStringFamily, volatility: VolatilityImmutable},
+	{from: types.RangeFamily, to: types.CollatedStringFamily, volatility: VolatilityStable},
 
 	// Casts to BytesFamily.
 	{from: types.UnknownFamily, to: types.BytesFamily, volatility: VolatilityImmutable},
@@ -282,6 +284,16 @@ var validCasts = []castInfo{
 	{from: types.StringFamily, to: types.EnumFamily, volatility: VolatilityImmutable},
 	{from: types.EnumFamily, to: types.EnumFamily, volatility: VolatilityImmutable},
 	{from: types.BytesFamily, to: types.EnumFamily, volatility: VolatilityImmutable},
+
+	// Casts to RangeFamily.
+	{from: types.UnknownFamily, to: types.RangeFamily, volatility: VolatilityImmutable},
+	{from: types.StringFamily, to: types.RangeFamily, volatility: VolatilityStable},
+	{from: types.CollatedStringFamily, to: types.RangeFamily, volatility: VolatilityStable},
+	{from: types.RangeFamily, to: types.RangeFamily, volatility: VolatilityImmutable},
+
+	// Casts to TupleFamily.
+	{from: types.UnknownFamily, to: types.TupleFamily, volatility: VolatilityImmutable},
+	{from: types.TupleFamily, to: types.TupleFamily, volatility: VolatilityImmutable},
 }
 
 type castsMapKey struct {
@@ -317,6 +329,21 @@ func LookupCastVolatility(from, to *types.T) (_ Volatility, ok bool) {
 	if fromFamily == types.ArrayFamily && toFamily == types.ArrayFamily {
 		return LookupCastVolatility(from.ArrayContents(), to.ArrayContents())
 	}
+	// Special case for casting between tuples.
+	if fromFamily == types.TupleFamily && toFamily == types.TupleFamily &&
+		len(from.TupleContents()) == len(to.TupleContents()) {
+		maxVolatility := VolatilityImmutable
+		for i := range from.TupleContents() {
+			v, ok := LookupCastVolatility(from.TupleContents()[i], to.TupleContents()[i])
+			if !ok {
+				return 0, false
+			}
+			if v > maxVolatility {
+				maxVolatility = v
+			}
+		}
+		return maxVolatility, true
+	}
 	cast := lookupCast(fromFamily, toFamily)
 	if cast == nil {
 		return 0, false
@@ -590,6 +617,8 @@ func PerformCast(ctx *EvalContext, d Datum, t *types.T) (Datum, error) {
 			)
 		case *DTuple:
 			s = AsStringWithFlags(d, FmtPgwireText)
+		case *DRange:
+			s = AsStringWithFlags(d, FmtBareStrings)
 		case *DArray:
 			s = AsStringWithFlags(d, FmtPgwireText)
 		case *DInterval:
@@ -901,6 +930,52 @@ func PerformCast(ctx *EvalContext, d Datum, t *types.T) (Datum, error) {
 			}
 			return dcast, nil
 		}
+	case types.RangeFamily:
+		switch v := d.(type) {
+		case *DString:
+			return ParseDRangeFromString(ctx, string(*v), t)
+		case *DCollatedString:
+			return ParseDRangeFromString(ctx, v.Contents, t)
+		case *DRange:
+			if v.ResolvedType().Oid() == t.Oid() {
+				return d, nil
+			}
+			// Only ranges over equivalent subtypes, such as int4range and
+			// int8range, can be converted into one another.
+			if !v.ResolvedType().RangeContents().Equivalent(t.RangeContents()) {
+				return nil, pgerror.Newf(pgcode.CannotCoerce,
+					"invalid cast: %s -> %s", v.ResolvedType(), t)
+			}
+			if v.Empty {
+				return NewEmptyDRange(t), nil
+			}
+			return NewDRange(t, v.Lower, v.Upper)
+		}
+	case types.TupleFamily:
+		switch v := d.(type) {
+		case *DTuple:
+			if types.IsWildcardTupleType(t) {
+				return d, nil
+			}
+			if len(v.D) != len(t.TupleContents()) {
+				return nil, pgerror.Newf(pgcode.CannotCoerce,
+					"cannot cast %s to %s: expected %d fields, got %d",
+					v.ResolvedType(), t, len(t.TupleContents()), len(v.D))
+			}
+			res := NewDTupleWithLen(t, len(v.D))
+			for i, e := range v.D {
+				ecast := DNull
+				if e != DNull {
+					var err error
+					ecast, err = PerformCast(ctx, UnwrapDatum(ctx, e), t.TupleContents()[i])
+					if err != nil {
+						return nil, err
+					}
+				}
+				res.D[i] = ecast
+			}
+			return res, nil
+		}
 	case types.OidFamily:
 		switch v := d.(type) {
 		case *DOid:
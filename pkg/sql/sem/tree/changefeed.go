@@ -15,12 +15,30 @@ type CreateChangefeed struct {
 	Targets TargetList
 	SinkURI Expr
 	Options KVOptions
+	// Select is set for CDC queries, i.e. CREATE CHANGEFEED ... AS SELECT,
+	// which filter and project the rows of their only target. The FROM clause
+	// holds the same table as Targets.
+	Select *SelectClause
 }
 
 var _ Statement = &CreateChangefeed{}
 
 // Format implements the NodeFormatter interface.
 func (node *CreateChangefeed) Format(ctx *FmtCtx) {
+	if node.Select != nil {
+		ctx.WriteString("CREATE CHANGEFEED")
+		if node.SinkURI != nil {
+			ctx.WriteString(" INTO ")
+			ctx.FormatNode(node.SinkURI)
+		}
+		if node.Options != nil {
+			ctx.WriteString(" WITH ")
+			ctx.FormatNode(&node.Options)
+		}
+		ctx.WriteString(" AS ")
+		ctx.FormatNode(node.Select)
+		return
+	}
 	if node.SinkURI != nil {
 		ctx.WriteString("CREATE ")
 	} else {
@@ -468,6 +468,10 @@ var (
 		types.INet,
 		types.Jsonb,
 		types.VarBit,
+		types.Int8Range,
+		types.TimestampRange,
+		types.TimestampTZRange,
+		types.DateRange,
 		types.AnyEnum,
 	}
 	// StrValAvailBytes is the set of types convertible to byte array.
@@ -10,6 +10,11 @@
 
 package tree
 
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+)
+
 // CopyFrom represents a COPY FROM statement.
 type CopyFrom struct {
 	Table   TableName
@@ -36,3 +41,123 @@ func (node *CopyFrom) Format(ctx *FmtCtx) {
 		ctx.FormatNode(&node.Options)
 	}
 }
+
+// CopyTo represents a COPY TO statement. Exactly one of Statement and Table is
+// set.
+type CopyTo struct {
+	Statement *Select
+	Table     TableName
+	Columns   NameList
+	Options   CopyOptions
+}
+
+// Format implements the NodeFormatter interface.
+func (node *CopyTo) Format(ctx *FmtCtx) {
+	ctx.WriteString("COPY ")
+	if node.Statement != nil {
+		ctx.WriteString("(")
+		ctx.FormatNode(node.Statement)
+		ctx.WriteString(")")
+	} else {
+		ctx.FormatNode(&node.Table)
+		if len(node.Columns) > 0 {
+			ctx.WriteString(" (")
+			ctx.FormatNode(&node.Columns)
+			ctx.WriteString(")")
+		}
+	}
+	ctx.WriteString(" TO STDOUT")
+	if !node.Options.IsDefault() {
+		ctx.WriteString(" WITH ")
+		ctx.FormatNode(&node.Options)
+	}
+}
+
+// CopyFormat identifies the data format of a COPY statement.
+type CopyFormat int
+
+const (
+	// CopyFormatText is the default, tab-separated text format.
+	CopyFormatText CopyFormat = iota
+	// CopyFormatBinary is the PostgreSQL binary format.
+	CopyFormatBinary
+	// CopyFormatCSV is the comma-separated values format.
+	CopyFormatCSV
+)
+
+// CopyOptions describes the options of a COPY statement.
+type CopyOptions struct {
+	CopyFormat CopyFormat
+	Header     bool
+	// Delimiter and Null are nil unless set explicitly, in which case the
+	// default for the format is overridden.
+	Delimiter *StrVal
+	Null      *StrVal
+}
+
+var _ NodeFormatter = &CopyOptions{}
+
+// Format implements the NodeFormatter interface. The options are formatted
+// using the older, space-separated PostgreSQL syntax.
+func (o *CopyOptions) Format(ctx *FmtCtx) {
+	sep := ""
+	switch o.CopyFormat {
+	case CopyFormatBinary:
+		ctx.WriteString("BINARY")
+		sep = " "
+	case CopyFormatCSV:
+		ctx.WriteString("CSV")
+		sep = " "
+	}
+	if o.Header {
+		ctx.WriteString(sep)
+		ctx.WriteString("HEADER")
+		sep = " "
+	}
+	if o.Delimiter != nil {
+		ctx.WriteString(sep)
+		ctx.WriteString("DELIMITER ")
+		ctx.FormatNode(o.Delimiter)
+		sep = " "
+	}
+	if o.Null != nil {
+		ctx.WriteString(sep)
+		ctx.WriteString("NULL ")
+		ctx.FormatNode(o.Null)
+	}
+}
+
+// IsDefault returns true if no option was specified.
+func (o *CopyOptions) IsDefault() bool {
+	return *o == CopyOptions{}
+}
+
+// CombineWith merges other options into this struct. An error is returned if
+// the same option is specified multiple times.
+func (o *CopyOptions) CombineWith(other *CopyOptions) error {
+	if other.CopyFormat != CopyFormatText {
+		if o.CopyFormat != CopyFormatText {
+			return pgerror.New(pgcode.Syntax, "conflicting or redundant options: format")
+		}
+		o.CopyFormat = other.CopyFormat
+	}
+	if other.Header {
+		if o.Header {
+			return pgerror.New(pgcode.Syntax, "conflicting or redundant options: header")
+		}
+		o.Header = true
+	}
+	if other.Delimiter != nil {
+		if o.Delimiter != nil {
+			return pgerror.New(pgcode.Syntax, "conflicting or redundant options: delimiter")
+		}
+		o.Delimiter = other.Delimiter
+	}
+	if other.Null != nil {
+		if o.Null != nil {
+			return pgerror.New(pgcode.Syntax, "conflicting or redundant options: null")
+		}
+		o.Null = other.Null
+	}
+	return nil
+}
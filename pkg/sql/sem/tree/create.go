@@ -199,6 +199,15 @@ type CreateType struct {
 	Variety  CreateTypeVariety
 	// EnumLabels is set when this represents a CREATE TYPE ... AS ENUM statement.
 	EnumLabels []string
+	// CompositeTypeList is set when this represents a CREATE TYPE ... AS (...)
+	// statement.
+	CompositeTypeList []CompositeTypeElem
+}
+
+// CompositeTypeElem is a single attribute of a composite type.
+type CompositeTypeElem struct {
+	Label Name
+	Type  ResolvableTypeReference
 }
 
 var _ Statement = &CreateType{}
@@ -218,6 +227,18 @@ func (node *CreateType) Format(ctx *FmtCtx) {
 			lex.EncodeSQLString(&ctx.Buffer, node.EnumLabels[i])
 		}
 		ctx.WriteString(")")
+	case Composite:
+		ctx.WriteString("AS (")
+		for i := range node.CompositeTypeList {
+			if i > 0 {
+				ctx.WriteString(", ")
+			}
+			elem := &node.CompositeTypeList[i]
+			ctx.FormatNode(&elem.Label)
+			ctx.WriteByte(' ')
+			ctx.FormatTypeReference(elem.Type)
+		}
+		ctx.WriteString(")")
 	}
 }
 
@@ -225,6 +246,231 @@ func (node *CreateType) String() string {
 	return AsString(node)
 }
 
+// FuncParam represents a parameter of a user-defined function.
+type FuncParam struct {
+	// Name is empty if the parameter is unnamed.
+	Name Name
+	Type ResolvableTypeReference
+}
+
+// FuncParams represents a list of function parameters.
+type FuncParams []FuncParam
+
+// Format implements the NodeFormatter interface.
+func (node *FuncParams) Format(ctx *FmtCtx) {
+	for i := range *node {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		p := &(*node)[i]
+		if p.Name != "" {
+			ctx.FormatNode(&p.Name)
+			ctx.WriteByte(' ')
+		}
+		ctx.FormatTypeReference(p.Type)
+	}
+}
+
+// FuncNullInputBehavior describes how a user-defined function behaves when
+// any of its arguments are NULL.
+type FuncNullInputBehavior int
+
+// FuncNullInputBehavior values.
+const (
+	// FuncNullInputUnspecified indicates that the behavior was not specified,
+	// which is equivalent to FuncCalledOnNullInput.
+	FuncNullInputUnspecified FuncNullInputBehavior = iota
+	// FuncCalledOnNullInput indicates that the function is evaluated normally
+	// when some of its arguments are NULL.
+	FuncCalledOnNullInput
+	// FuncReturnsNullOnNullInput indicates that the function returns NULL,
+	// without being evaluated, whenever any of its arguments are NULL.
+	FuncReturnsNullOnNullInput
+	// FuncStrict is a synonym of FuncReturnsNullOnNullInput.
+	FuncStrict
+)
+
+// FuncOptions represents the options of a CREATE FUNCTION statement.
+type FuncOptions struct {
+	// Language is empty if unspecified.
+	Language string
+	// Volatility is zero if unspecified.
+	Volatility Volatility
+	NullInput  FuncNullInputBehavior
+	// Body is nil if unspecified.
+	Body *string
+}
+
+// Merge adds the options set in other to opts, returning an error if any of
+// them are already set in opts.
+func (opts *FuncOptions) Merge(other FuncOptions) error {
+	conflicting := func() error {
+		return pgerror.New(pgcode.Syntax, "conflicting or redundant options")
+	}
+	if other.Language != "" {
+		if opts.Language != "" {
+			return conflicting()
+		}
+		opts.Language = other.Language
+	}
+	if other.Volatility != 0 {
+		if opts.Volatility != 0 {
+			return conflicting()
+		}
+		opts.Volatility = other.Volatility
+	}
+	if other.NullInput != FuncNullInputUnspecified {
+		if opts.NullInput != FuncNullInputUnspecified {
+			return conflicting()
+		}
+		opts.NullInput = other.NullInput
+	}
+	if other.Body != nil {
+		if opts.Body != nil {
+			return conflicting()
+		}
+		opts.Body = other.Body
+	}
+	return nil
+}
+
+// Format implements the NodeFormatter interface.
+func (opts *FuncOptions) Format(ctx *FmtCtx) {
+	if opts.Language != "" {
+		ctx.WriteString(" LANGUAGE ")
+		lex.EncodeRestrictedSQLIdent(&ctx.Buffer, opts.Language, lex.EncNoFlags)
+	}
+	switch opts.Volatility {
+	case VolatilityImmutable:
+		ctx.WriteString(" IMMUTABLE")
+	case VolatilityStable:
+		ctx.WriteString(" STABLE")
+	case VolatilityVolatile:
+		ctx.WriteString(" VOLATILE")
+	}
+	switch opts.NullInput {
+	case FuncCalledOnNullInput:
+		ctx.WriteString(" CALLED ON NULL INPUT")
+	case FuncReturnsNullOnNullInput:
+		ctx.WriteString(" RETURNS NULL ON NULL INPUT")
+	case FuncStrict:
+		ctx.WriteString(" STRICT")
+	}
+	if opts.Body != nil {
+		ctx.WriteString(" AS ")
+		lex.EncodeSQLString(&ctx.Buffer, *opts.Body)
+	}
+}
+
+// Strict returns whether the options specify that the function returns NULL
+// on NULL input.
+func (opts *FuncOptions) Strict() bool {
+	return opts.NullInput == FuncReturnsNullOnNullInput || opts.NullInput == FuncStrict
+}
+
+// CreateFunction represents a CREATE FUNCTION statement.
+type CreateFunction struct {
+	FuncName   *UnresolvedObjectName
+	OrReplace  bool
+	Params     FuncParams
+	ReturnType ResolvableTypeReference
+	Options    FuncOptions
+}
+
+var _ Statement = &CreateFunction{}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateFunction) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE ")
+	if node.OrReplace {
+		ctx.WriteString("OR REPLACE ")
+	}
+	ctx.WriteString("FUNCTION ")
+	ctx.FormatNode(node.FuncName)
+	ctx.WriteByte('(')
+	ctx.FormatNode(&node.Params)
+	ctx.WriteString(") RETURNS ")
+	ctx.FormatTypeReference(node.ReturnType)
+	ctx.FormatNode(&node.Options)
+}
+
+// TriggerActionTime indicates when a trigger runs relative to the
+// modification of a row.
+type TriggerActionTime int
+
+// TriggerActionTime values.
+const (
+	TriggerBefore TriggerActionTime = iota
+	TriggerAfter
+)
+
+var triggerActionTimeName = [...]string{
+	TriggerBefore: "BEFORE",
+	TriggerAfter:  "AFTER",
+}
+
+func (t TriggerActionTime) String() string {
+	return triggerActionTimeName[t]
+}
+
+// TriggerEvent is a kind of row modification which fires a trigger.
+type TriggerEvent int
+
+// TriggerEvent values.
+const (
+	TriggerInsert TriggerEvent = iota
+	TriggerUpdate
+	TriggerDelete
+)
+
+var triggerEventName = [...]string{
+	TriggerInsert: "INSERT",
+	TriggerUpdate: "UPDATE",
+	TriggerDelete: "DELETE",
+}
+
+func (t TriggerEvent) String() string {
+	return triggerEventName[t]
+}
+
+// TriggerEvents is a list of trigger events.
+type TriggerEvents []TriggerEvent
+
+// Format implements the NodeFormatter interface.
+func (node *TriggerEvents) Format(ctx *FmtCtx) {
+	for i, e := range *node {
+		if i > 0 {
+			ctx.WriteString(" OR ")
+		}
+		ctx.WriteString(e.String())
+	}
+}
+
+// CreateTrigger represents a CREATE TRIGGER statement.
+type CreateTrigger struct {
+	Name       Name
+	ActionTime TriggerActionTime
+	Events     TriggerEvents
+	Table      *UnresolvedObjectName
+	Body       string
+}
+
+var _ Statement = &CreateTrigger{}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateTrigger) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE TRIGGER ")
+	ctx.FormatNode(&node.Name)
+	ctx.WriteByte(' ')
+	ctx.WriteString(node.ActionTime.String())
+	ctx.WriteByte(' ')
+	ctx.FormatNode(&node.Events)
+	ctx.WriteString(" ON ")
+	ctx.FormatNode(node.Table)
+	ctx.WriteString(" FOR EACH ROW AS ")
+	lex.EncodeSQLString(&ctx.Buffer, node.Body)
+}
+
 // TableDef represents a column, index or constraint definition within a CREATE
 // TABLE statement.
 type TableDef interface {
@@ -293,10 +539,12 @@ type ColumnTableDef struct {
 		ConstraintName Name
 		Actions        ReferenceActions
 		Match          CompositeKeyMatchMethod
+		Deferrable     ConstraintDeferrability
 	}
 	Computed struct {
 		Computed bool
 		Expr     Expr
+		Virtual  bool
 	}
 	Family struct {
 		Name        Name
@@ -414,9 +662,11 @@ func NewColumnTableDef(
 			d.References.ConstraintName = c.Name
 			d.References.Actions = t.Actions
 			d.References.Match = t.Match
+			d.References.Deferrable = t.Deferrable
 		case *ColumnComputedDef:
 			d.Computed.Computed = true
 			d.Computed.Expr = t.Expr
+			d.Computed.Virtual = t.Virtual
 		case *ColumnFamilyConstraint:
 			if d.HasColumnFamily() {
 				return nil, pgerror.Newf(pgcode.InvalidTableDefinition,
@@ -522,11 +772,16 @@ func (node *ColumnTableDef) Format(ctx *FmtCtx) {
 			ctx.WriteString(node.References.Match.String())
 		}
 		ctx.FormatNode(&node.References.Actions)
+		ctx.FormatNode(&node.References.Deferrable)
 	}
 	if node.IsComputed() {
 		ctx.WriteString(" AS (")
 		ctx.FormatNode(node.Computed.Expr)
-		ctx.WriteString(") STORED")
+		if node.Computed.Virtual {
+			ctx.WriteString(") VIRTUAL")
+		} else {
+			ctx.WriteString(") STORED")
+		}
 	}
 	if node.HasColumnFamily() {
 		if node.Family.Create {
@@ -620,15 +875,17 @@ type ColumnCheckConstraint struct {
 
 // ColumnFKConstraint represents a FK-constaint on a column.
 type ColumnFKConstraint struct {
-	Table   TableName
-	Col     Name // empty-string means use PK
-	Actions ReferenceActions
-	Match   CompositeKeyMatchMethod
+	Table      TableName
+	Col        Name // empty-string means use PK
+	Actions    ReferenceActions
+	Match      CompositeKeyMatchMethod
+	Deferrable ConstraintDeferrability
 }
 
 // ColumnComputedDef represents the description of a computed column.
 type ColumnComputedDef struct {
-	Expr Expr
+	Expr    Expr
+	Virtual bool
 }
 
 // ColumnFamilyConstraint represents FAMILY on a column.
@@ -791,6 +1048,39 @@ func (node *ReferenceActions) Format(ctx *FmtCtx) {
 	}
 }
 
+// ConstraintDeferrability describes when a constraint is checked.
+type ConstraintDeferrability int
+
+// The values for ConstraintDeferrability.
+const (
+	// NotDeferrable constraints are checked at the end of each statement.
+	NotDeferrable ConstraintDeferrability = iota
+	// DeferrableInitiallyImmediate constraints are checked at the end of each
+	// statement, unless they are deferred with SET CONSTRAINTS.
+	DeferrableInitiallyImmediate
+	// DeferrableInitiallyDeferred constraints are checked when the transaction
+	// commits, unless they are made immediate with SET CONSTRAINTS.
+	DeferrableInitiallyDeferred
+)
+
+var constraintDeferrabilityName = [...]string{
+	NotDeferrable:                "",
+	DeferrableInitiallyImmediate: "DEFERRABLE",
+	DeferrableInitiallyDeferred:  "DEFERRABLE INITIALLY DEFERRED",
+}
+
+func (d ConstraintDeferrability) String() string {
+	return constraintDeferrabilityName[d]
+}
+
+// Format implements the NodeFormatter interface.
+func (d *ConstraintDeferrability) Format(ctx *FmtCtx) {
+	if *d != NotDeferrable {
+		ctx.WriteByte(' ')
+		ctx.WriteString(d.String())
+	}
+}
+
 // CompositeKeyMatchMethod is the algorithm use when matching composite keys.
 // See https://github.com/cockroachdb/cockroach/issues/20305 or
 // https://www.postgresql.org/docs/11/sql-createtable.html for details on the
@@ -816,12 +1106,13 @@ func (c CompositeKeyMatchMethod) String() string {
 
 // ForeignKeyConstraintTableDef represents a FOREIGN KEY constraint in the AST.
 type ForeignKeyConstraintTableDef struct {
-	Name     Name
-	Table    TableName
-	FromCols NameList
-	ToCols   NameList
-	Actions  ReferenceActions
-	Match    CompositeKeyMatchMethod
+	Name       Name
+	Table      TableName
+	FromCols   NameList
+	ToCols     NameList
+	Actions    ReferenceActions
+	Match      CompositeKeyMatchMethod
+	Deferrable ConstraintDeferrability
 }
 
 // Format implements the NodeFormatter interface.
@@ -849,6 +1140,7 @@ func (node *ForeignKeyConstraintTableDef) Format(ctx *FmtCtx) {
 	}
 
 	ctx.FormatNode(&node.Actions)
+	ctx.FormatNode(&node.Deferrable)
 }
 
 // SetName implements the ConstraintTableDef interface.
@@ -1184,12 +1476,13 @@ func (node *CreateTable) HoistConstraints() {
 					targetCol = append(targetCol, col.References.Col)
 				}
 				node.Defs = append(node.Defs, &ForeignKeyConstraintTableDef{
-					Table:    *col.References.Table,
-					FromCols: NameList{col.Name},
-					ToCols:   targetCol,
-					Name:     col.References.ConstraintName,
-					Actions:  col.References.Actions,
-					Match:    col.References.Match,
+					Table:      *col.References.Table,
+					FromCols:   NameList{col.Name},
+					ToCols:     targetCol,
+					Name:       col.References.ConstraintName,
+					Actions:    col.References.Actions,
+					Match:      col.References.Match,
+					Deferrable: col.References.Deferrable,
 				})
 				col.References.Table = nil
 			}
@@ -1522,12 +1815,13 @@ func (node *AlterRole) Format(ctx *FmtCtx) {
 
 // CreateView represents a CREATE VIEW statement.
 type CreateView struct {
-	Name        TableName
-	ColumnNames NameList
-	AsSource    *Select
-	IfNotExists bool
-	Temporary   bool
-	Replace     bool
+	Name         TableName
+	ColumnNames  NameList
+	AsSource     *Select
+	IfNotExists  bool
+	Temporary    bool
+	Replace      bool
+	Materialized bool
 }
 
 // Format implements the NodeFormatter interface.
@@ -1542,6 +1836,10 @@ func (node *CreateView) Format(ctx *FmtCtx) {
 		ctx.WriteString("TEMPORARY ")
 	}
 
+	if node.Materialized {
+		ctx.WriteString("MATERIALIZED ")
+	}
+
 	ctx.WriteString("VIEW ")
 
 	if node.IfNotExists {
@@ -1560,6 +1858,23 @@ func (node *CreateView) Format(ctx *FmtCtx) {
 	ctx.FormatNode(node.AsSource)
 }
 
+// RefreshMaterializedView represents a REFRESH MATERIALIZED VIEW statement.
+type RefreshMaterializedView struct {
+	Name         *UnresolvedObjectName
+	Concurrently bool
+}
+
+var _ Statement = &RefreshMaterializedView{}
+
+// Format implements the NodeFormatter interface.
+func (node *RefreshMaterializedView) Format(ctx *FmtCtx) {
+	ctx.WriteString("REFRESH MATERIALIZED VIEW ")
+	if node.Concurrently {
+		ctx.WriteString("CONCURRENTLY ")
+	}
+	ctx.FormatNode(node.Name)
+}
+
 // CreateStats represents a CREATE STATISTICS statement.
 type CreateStats struct {
 	Name        Name
@@ -3141,6 +3141,11 @@ func NewDTupleWithLen(typ *types.T, l int) *DTuple {
 	return &DTuple{D: make(Datums, l), typ: typ}
 }
 
+// MakeDTuple creates a DTuple with the provided datums. See NewDTuple.
+func MakeDTuple(typ *types.T, d ...Datum) DTuple {
+	return DTuple{D: d, typ: typ}
+}
+
 // AsDTuple attempts to retrieve a *DTuple from an Expr, returning a *DTuple and
 // a flag signifying whether the assertion was successful. The function should
 // be used instead of direct type assertions wherever a *DTuple wrapped by a
@@ -3748,8 +3753,7 @@ func (d *DArray) Append(v Datum) error {
 			if prevItem == DNull {
 				return errNonHomogeneousArray
 			}
-			expectedLen := MustBeDArray(prevItem).Len()
-			if MustBeDArray(v).Len() != expectedLen {
+			if !sameArrayDimensions(MustBeDArray(prevItem), MustBeDArray(v)) {
 				return errNonHomogeneousArray
 			}
 		}
@@ -3763,6 +3767,43 @@ func (d *DArray) Append(v Datum) error {
 	return d.Validate()
 }
 
+// sameArrayDimensions returns whether the two arrays have the same length in
+// every dimension. Since sub-arrays are checked as they are appended, it is
+// sufficient to compare the first sub-array in each dimension.
+func sameArrayDimensions(a, b *DArray) bool {
+	for {
+		if a.Len() != b.Len() {
+			return false
+		}
+		if a.Len() == 0 || a.ParamTyp.Family() != types.ArrayFamily {
+			return true
+		}
+		a, b = MustBeDArray(a.Array[0]), MustBeDArray(b.Array[0])
+	}
+}
+
+// Flatten returns the innermost elements of the array in row-major order. For
+// a one-dimensional array, these are the elements of the array.
+func (d *DArray) Flatten() Datums {
+	if d.ParamTyp.Family() != types.ArrayFamily {
+		return d.Array
+	}
+	var elements Datums
+	for _, e := range d.Array {
+		elements = append(elements, MustBeDArray(e).Flatten()...)
+	}
+	return elements
+}
+
+// leafType returns the type of the innermost elements of the array.
+func (d *DArray) leafType() *types.T {
+	t := d.ParamTyp
+	for t.Family() == types.ArrayFamily {
+		t = t.ArrayContents()
+	}
+	return t
+}
+
 // DEnum represents an ENUM value.
 type DEnum struct {
 	// EnumType is the hydrated type of this enum.
@@ -3963,6 +4004,421 @@ func (d *DEnum) AmbiguousFormat() bool {
 	return true
 }
 
+// DRange is the range Datum. A range is either empty, or contains all values
+// between a lower and an upper bound, either of which may be unbounded.
+//
+// DRanges are always kept in a canonical form, so that two ranges that
+// contain the same values compare (and encode) equal:
+//
+//   - unbounded bounds are never inclusive.
+//   - ranges over discrete subtypes (INT4RANGE, INT8RANGE and DATERANGE) have
+//     an inclusive lower bound and an exclusive upper bound, unless the bound
+//     is infinite.
+//   - ranges that contain no values are empty and have no bounds.
+type DRange struct {
+	typ *types.T
+	// Empty is true if the range contains no values. Lower and Upper are unset
+	// for empty ranges.
+	Empty bool
+	Lower RangeBound
+	Upper RangeBound
+}
+
+// RangeBound is one of the two bounds of a non-empty DRange.
+type RangeBound struct {
+	// Val is the value of the bound, or nil if the range is unbounded on this
+	// side.
+	Val Datum
+	// Inclusive is true if Val is itself contained in the range.
+	Inclusive bool
+}
+
+// Unbounded returns true if the bound has no value, meaning that the range
+// extends infinitely on that side.
+func (b RangeBound) Unbounded() bool {
+	return b.Val == nil
+}
+
+// NewEmptyDRange returns the empty range of the given range type.
+func NewEmptyDRange(typ *types.T) *DRange {
+	return &DRange{typ: typ, Empty: true}
+}
+
+// NewDRange constructs a DRange of the given range type from the given
+// bounds, and converts it into canonical form. It returns an error if the
+// lower bound is greater than the upper bound, or if the bounds cannot be
+// canonicalized.
+func NewDRange(typ *types.T, lower, upper RangeBound) (*DRange, error) {
+	subtype := typ.RangeContents()
+	for _, b := range []RangeBound{lower, upper} {
+		if i, ok := b.Val.(*DInt); ok && subtype.Width() == 32 &&
+			(*i > math.MaxInt32 || *i < math.MinInt32) {
+			return nil, ErrIntOutOfRange
+		}
+	}
+	if lower.Unbounded() {
+		lower.Inclusive = false
+	}
+	if upper.Unbounded() {
+		upper.Inclusive = false
+	}
+	if !lower.Unbounded() && !upper.Unbounded() && compareRangeValues(lower.Val, upper.Val) > 0 {
+		return nil, pgerror.New(pgcode.DataException,
+			"range lower bound must be less than or equal to range upper bound")
+	}
+
+	switch subtype.Family() {
+	case types.IntFamily, types.DateFamily:
+		// Discrete ranges are canonicalized to the [) form.
+		if !lower.Unbounded() && !lower.Inclusive {
+			next, ok, err := nextDiscreteRangeValue(subtype, lower.Val)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				lower = RangeBound{Val: next, Inclusive: true}
+			}
+		}
+		if !upper.Unbounded() && upper.Inclusive {
+			next, ok, err := nextDiscreteRangeValue(subtype, upper.Val)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				upper = RangeBound{Val: next, Inclusive: false}
+			}
+		}
+	}
+
+	if !lower.Unbounded() && !upper.Unbounded() {
+		c := compareRangeValues(lower.Val, upper.Val)
+		if c > 0 || (c == 0 && !(lower.Inclusive && upper.Inclusive)) {
+			return NewEmptyDRange(typ), nil
+		}
+	}
+	return &DRange{typ: typ, Lower: lower, Upper: upper}, nil
+}
+
+// nextDiscreteRangeValue returns the value immediately following v, which
+// must be a value of a discrete range subtype. ok is false if v is infinite,
+// and therefore has no successor.
+func nextDiscreteRangeValue(subtype *types.T, v Datum) (_ Datum, ok bool, _ error) {
+	switch t := v.(type) {
+	case *DInt:
+		if *t == math.MaxInt64 || (subtype.Width() == 32 && *t >= math.MaxInt32) {
+			return nil, false, ErrIntOutOfRange
+		}
+		return NewDInt(*t + 1), true, nil
+	case *DDate:
+		if !t.IsFinite() {
+			return nil, false, nil
+		}
+		d, err := t.AddDays(1)
+		if err != nil {
+			return nil, false, err
+		}
+		return NewDDate(d), true, nil
+	}
+	return nil, false, errors.AssertionFailedf("unexpected discrete range value %T", v)
+}
+
+// compareRangeValues compares two non-NULL values of the same range subtype.
+// Unlike Datum.Compare it does not need an EvalContext, since the values are
+// known to have identical types.
+func compareRangeValues(a, b Datum) int {
+	switch t := a.(type) {
+	case *DInt:
+		o := *b.(*DInt)
+		if *t < o {
+			return -1
+		} else if *t > o {
+			return 1
+		}
+		return 0
+	case *DDate:
+		return t.Date.Compare(b.(*DDate).Date)
+	case *DTimestamp:
+		return compareTimes(t.Time, b.(*DTimestamp).Time)
+	case *DTimestampTZ:
+		return compareTimes(t.Time, b.(*DTimestampTZ).Time)
+	}
+	panic(errors.AssertionFailedf("unexpected range value %T", a))
+}
+
+func compareTimes(a, b time.Time) int {
+	if a.Before(b) {
+		return -1
+	} else if b.Before(a) {
+		return 1
+	}
+	return 0
+}
+
+// compareRangeBounds compares two bounds, each of which is either a lower or
+// an upper bound. Unbounded lower bounds sort before and unbounded upper
+// bounds after all other bounds. Among bounds with the same value, an
+// exclusive upper bound sorts before an inclusive bound, which sorts before an
+// exclusive lower bound.
+func compareRangeBounds(a RangeBound, aIsLower bool, b RangeBound, bIsLower bool) int {
+	switch {
+	case a.Unbounded() && b.Unbounded():
+		if aIsLower == bIsLower {
+			return 0
+		}
+		if aIsLower {
+			return -1
+		}
+		return 1
+	case a.Unbounded():
+		if aIsLower {
+			return -1
+		}
+		return 1
+	case b.Unbounded():
+		if bIsLower {
+			return 1
+		}
+		return -1
+	}
+	if c := compareRangeValues(a.Val, b.Val); c != 0 {
+		return c
+	}
+	switch {
+	case !a.Inclusive && !b.Inclusive:
+		if aIsLower == bIsLower {
+			return 0
+		}
+		if aIsLower {
+			return 1
+		}
+		return -1
+	case !a.Inclusive:
+		if aIsLower {
+			return 1
+		}
+		return -1
+	case !b.Inclusive:
+		if bIsLower {
+			return -1
+		}
+		return 1
+	}
+	return 0
+}
+
+// AsDRange attempts to retrieve a *DRange from an Expr, returning a *DRange
+// and a flag signifying whether the assertion was successful. The function
+// should be used instead of direct type assertions wherever a *DRange wrapped
+// by a *DOidWrapper is possible.
+func AsDRange(e Expr) (*DRange, bool) {
+	switch t := e.(type) {
+	case *DRange:
+		return t, true
+	case *DOidWrapper:
+		return AsDRange(t.Wrapped)
+	}
+	return nil, false
+}
+
+// MustBeDRange attempts to retrieve a *DRange from an Expr, panicking if the
+// assertion fails.
+func MustBeDRange(e Expr) *DRange {
+	r, ok := AsDRange(e)
+	if !ok {
+		panic(errors.AssertionFailedf("expected *DRange, found %T", e))
+	}
+	return r
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (d *DRange) ResolvedType() *types.T {
+	return d.typ
+}
+
+// Compare implements the Datum interface. Empty ranges sort before all other
+// ranges, which are ordered by their lower bound and then by their upper
+// bound.
+func (d *DRange) Compare(ctx *EvalContext, other Datum) int {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1
+	}
+	v, ok := UnwrapDatum(ctx, other).(*DRange)
+	if !ok {
+		panic(makeUnsupportedComparisonMessage(d, other))
+	}
+	switch {
+	case d.Empty && v.Empty:
+		return 0
+	case d.Empty:
+		return -1
+	case v.Empty:
+		return 1
+	}
+	if c := compareRangeBounds(d.Lower, true, v.Lower, true); c != 0 {
+		return c
+	}
+	return compareRangeBounds(d.Upper, false, v.Upper, false)
+}
+
+// Prev implements the Datum interface.
+func (d *DRange) Prev(_ *EvalContext) (Datum, bool) {
+	return nil, false
+}
+
+// Next implements the Datum interface.
+func (d *DRange) Next(_ *EvalContext) (Datum, bool) {
+	return nil, false
+}
+
+// IsMax implements the Datum interface.
+func (d *DRange) IsMax(_ *EvalContext) bool {
+	return false
+}
+
+// IsMin implements the Datum interface.
+func (d *DRange) IsMin(_ *EvalContext) bool {
+	return d.Empty
+}
+
+// Max implements the Datum interface.
+func (d *DRange) Max(_ *EvalContext) (Datum, bool) {
+	return nil, false
+}
+
+// Min implements the Datum interface.
+func (d *DRange) Min(_ *EvalContext) (Datum, bool) {
+	return NewEmptyDRange(d.typ), true
+}
+
+// AmbiguousFormat implements the Datum interface.
+func (*DRange) AmbiguousFormat() bool {
+	return true
+}
+
+// Format implements the NodeFormatter interface. Ranges are formatted like
+// in Postgres, for example [1,10) or ["2020-01-01 00:00:00+00:00",).
+func (d *DRange) Format(ctx *FmtCtx) {
+	bareStrings := ctx.HasFlags(FmtFlags(lex.EncBareStrings))
+	if !bareStrings {
+		ctx.WriteByte('\'')
+	}
+	if d.Empty {
+		ctx.WriteString("empty")
+	} else {
+		boundFlags := FmtBareStrings
+		if ctx.HasFlags(fmtPgwireFormat) {
+			boundFlags = FmtPgwireText
+		}
+		if d.Lower.Inclusive {
+			ctx.WriteByte('[')
+		} else {
+			ctx.WriteByte('(')
+		}
+		if !d.Lower.Unbounded() {
+			formatStringInRange(&ctx.Buffer, rangeBoundString(d.Lower.Val, boundFlags))
+		}
+		ctx.WriteByte(',')
+		if !d.Upper.Unbounded() {
+			formatStringInRange(&ctx.Buffer, rangeBoundString(d.Upper.Val, boundFlags))
+		}
+		if d.Upper.Inclusive {
+			ctx.WriteByte(']')
+		} else {
+			ctx.WriteByte(')')
+		}
+	}
+	if !bareStrings {
+		ctx.WriteByte('\'')
+	}
+}
+
+// rangeBoundString returns the string form of a range bound. Like in
+// Postgres, the bounds of a tsrange are printed without a time zone offset.
+func rangeBoundString(v Datum, flags FmtFlags) string {
+	if ts, ok := v.(*DTimestamp); ok {
+		return ts.UTC().Format(timestampRangeBoundFormat)
+	}
+	return AsStringWithFlags(v, flags)
+}
+
+const timestampRangeBoundFormat = "2006-01-02 15:04:05.999999"
+
+// Size implements the Datum interface.
+func (d *DRange) Size() uintptr {
+	sz := unsafe.Sizeof(*d)
+	if !d.Lower.Unbounded() {
+		sz += d.Lower.Val.Size()
+	}
+	if !d.Upper.Unbounded() {
+		sz += d.Upper.Val.Size()
+	}
+	return sz
+}
+
+// ContainsValue returns true if v, which must be a non-NULL value of the
+// range's subtype, is contained in the range.
+func (d *DRange) ContainsValue(v Datum) bool {
+	if d.Empty {
+		return false
+	}
+	if !d.Lower.Unbounded() {
+		c := compareRangeValues(d.Lower.Val, v)
+		if c > 0 || (c == 0 && !d.Lower.Inclusive) {
+			return false
+		}
+	}
+	if !d.Upper.Unbounded() {
+		c := compareRangeValues(d.Upper.Val, v)
+		if c < 0 || (c == 0 && !d.Upper.Inclusive) {
+			return false
+		}
+	}
+	return true
+}
+
+// ContainsRange returns true if every value in other is also contained in
+// the range. The empty range is contained in every range.
+func (d *DRange) ContainsRange(other *DRange) bool {
+	if other.Empty {
+		return true
+	}
+	if d.Empty {
+		return false
+	}
+	return compareRangeBounds(d.Lower, true, other.Lower, true) <= 0 &&
+		compareRangeBounds(d.Upper, false, other.Upper, false) >= 0
+}
+
+// Overlaps returns true if the two ranges have at least one value in common.
+func (d *DRange) Overlaps(other *DRange) bool {
+	if d.Empty || other.Empty {
+		return false
+	}
+	return compareRangeBounds(d.Lower, true, other.Upper, false) <= 0 &&
+		compareRangeBounds(other.Lower, true, d.Upper, false) <= 0
+}
+
+// Adjacent returns true if the two ranges do not overlap, but there are no
+// values between them, for example [1,5) and [5,10).
+func (d *DRange) Adjacent(other *DRange) bool {
+	if d.Empty || other.Empty {
+		return false
+	}
+	return rangeBoundsAdjacent(d.Upper, other.Lower) || rangeBoundsAdjacent(other.Upper, d.Lower)
+}
+
+// rangeBoundsAdjacent returns true if the given upper bound of one range
+// directly abuts the given lower bound of another. Since ranges are kept in
+// canonical form, this is the case exactly when the bounds have the same value
+// and exactly one of them is inclusive.
+func rangeBoundsAdjacent(upper, lower RangeBound) bool {
+	if upper.Unbounded() || lower.Unbounded() {
+		return false
+	}
+	return compareRangeValues(upper.Val, lower.Val) == 0 && upper.Inclusive != lower.Inclusive
+}
+
 // DOid is the Postgres OID datum. It can represent either an OID type or any
 // of the reg* types, such as regproc or regclass.
 type DOid struct {
@@ -4394,6 +4850,8 @@ func NewDefaultDatum(evalCtx *EvalContext, t *types.T) (d Datum, err error) {
 		return NewDTuple(t, datums...), nil
 	case types.BitFamily:
 		return bitArrayZero, nil
+	case types.RangeFamily:
+		return NewEmptyDRange(t), nil
 	default:
 		return nil, errors.AssertionFailedf("unhandled type %v", t.SQLString())
 	}
@@ -4473,6 +4931,7 @@ var baseDatumTypeSizes = map[types.Family]struct {
 	types.INetFamily:           {unsafe.Sizeof(DIPAddr{}), fixedSize},
 	types.OidFamily:            {unsafe.Sizeof(DInt(0)), fixedSize},
 	types.EnumFamily:           {unsafe.Sizeof(DEnum{}), variableSize},
+	types.RangeFamily:          {unsafe.Sizeof(DRange{}), variableSize},
 
 	// TODO(jordan,justin): This seems suspicious.
 	types.ArrayFamily: {unsafe.Sizeof(DString("")), variableSize},
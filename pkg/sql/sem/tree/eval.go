@@ -348,18 +348,21 @@ func ConcatArrays(typ *types.T, left Datum, right Datum) (Datum, error) {
 	return result, nil
 }
 
-// ArrayContains return true if the haystack contains all needles.
+// ArrayContains return true if the haystack contains all needles. Like in
+// Postgres, multi-dimensional arrays are compared by their innermost elements,
+// regardless of the dimensions of either array.
 func ArrayContains(ctx *EvalContext, haystack *DArray, needles *DArray) (*DBool, error) {
-	if !haystack.ParamTyp.Equivalent(needles.ParamTyp) {
+	if !haystack.leafType().Equivalent(needles.leafType()) {
 		return DBoolFalse, pgerror.New(pgcode.DatatypeMismatch, "cannot compare arrays with different element types")
 	}
-	for _, needle := range needles.Array {
+	elements := haystack.Flatten()
+	for _, needle := range needles.Flatten() {
 		// Nulls don't compare to each other in @> syntax.
 		if needle == DNull {
 			return DBoolFalse, nil
 		}
 		var found bool
-		for _, hay := range haystack.Array {
+		for _, hay := range elements {
 			if needle.Compare(ctx, hay) == 0 {
 				found = true
 				break
@@ -1952,8 +1955,45 @@ func cmpOpFixups(cmpOps map[ComparisonOperator]cmpOpOverload) map[ComparisonOper
 		panic(errors.AssertionFailedf("could not find cmp op %s(%s,%s)", op, t, t))
 	}
 
-	// Array equality comparisons.
-	for _, t := range types.Scalar {
+	// Range comparisons.
+	for _, t := range types.Ranges {
+		cmpOps[EQ] = append(cmpOps[EQ], makeEqFn(t, t, VolatilityImmutable))
+		cmpOps[LE] = append(cmpOps[LE], makeLeFn(t, t, VolatilityImmutable))
+		cmpOps[LT] = append(cmpOps[LT], makeLtFn(t, t, VolatilityImmutable))
+		cmpOps[IsNotDistinctFrom] = append(cmpOps[IsNotDistinctFrom], makeIsFn(t, t, VolatilityImmutable))
+
+		cmpOps[Contains] = append(cmpOps[Contains],
+			makeCmpOpOverload(func(_ *EvalContext, left, right Datum) (Datum, error) {
+				return MakeDBool(DBool(MustBeDRange(left).ContainsRange(MustBeDRange(right)))), nil
+			}, t, t, false /* nullableArgs */, VolatilityImmutable),
+			makeCmpOpOverload(func(_ *EvalContext, left, right Datum) (Datum, error) {
+				return MakeDBool(DBool(MustBeDRange(left).ContainsValue(UnwrapDatum(nil, right)))), nil
+			}, t, t.RangeContents(), false /* nullableArgs */, VolatilityImmutable),
+		)
+		cmpOps[ContainedBy] = append(cmpOps[ContainedBy],
+			makeCmpOpOverload(func(_ *EvalContext, left, right Datum) (Datum, error) {
+				return MakeDBool(DBool(MustBeDRange(right).ContainsRange(MustBeDRange(left)))), nil
+			}, t, t, false /* nullableArgs */, VolatilityImmutable),
+			makeCmpOpOverload(func(_ *EvalContext, left, right Datum) (Datum, error) {
+				return MakeDBool(DBool(MustBeDRange(right).ContainsValue(UnwrapDatum(nil, left)))), nil
+			}, t.RangeContents(), t, false /* nullableArgs */, VolatilityImmutable),
+		)
+		cmpOps[Overlaps] = append(cmpOps[Overlaps],
+			makeCmpOpOverload(func(_ *EvalContext, left, right Datum) (Datum, error) {
+				return MakeDBool(DBool(MustBeDRange(left).Overlaps(MustBeDRange(right)))), nil
+			}, t, t, false /* nullableArgs */, VolatilityImmutable),
+		)
+		cmpOps[Adjacent] = append(cmpOps[Adjacent],
+			makeCmpOpOverload(func(_ *EvalContext, left, right Datum) (Datum, error) {
+				return MakeDBool(DBool(MustBeDRange(left).Adjacent(MustBeDRange(right)))), nil
+			}, t, t, false /* nullableArgs */, VolatilityImmutable),
+		)
+	}
+
+	// Array equality comparisons. The range comparisons above must already be
+	// defined, since findVolatility looks up the element comparisons.
+	arrayElemTypes := append(types.Scalar[:len(types.Scalar):len(types.Scalar)], types.Ranges...)
+	for _, t := range arrayElemTypes {
 		cmpOps[EQ] = append(cmpOps[EQ], &CmpOp{
 			LeftType:   types.MakeArray(t),
 			RightType:  types.MakeArray(t),
@@ -1982,6 +2022,36 @@ func cmpOpFixups(cmpOps map[ComparisonOperator]cmpOpOverload) map[ComparisonOper
 		})
 	}
 
+	// Multi-dimensional array comparisons. A single overload matches arrays of
+	// arrays of any element type; the type checker ensures that both sides have
+	// the same type.
+	nestedArray := types.MakeArray(types.AnyArray)
+	cmpOps[EQ] = append(cmpOps[EQ], &CmpOp{
+		LeftType:   nestedArray,
+		RightType:  nestedArray,
+		Fn:         cmpOpScalarEQFn,
+		Volatility: VolatilityImmutable,
+	})
+	cmpOps[LE] = append(cmpOps[LE], &CmpOp{
+		LeftType:   nestedArray,
+		RightType:  nestedArray,
+		Fn:         cmpOpScalarLEFn,
+		Volatility: VolatilityImmutable,
+	})
+	cmpOps[LT] = append(cmpOps[LT], &CmpOp{
+		LeftType:   nestedArray,
+		RightType:  nestedArray,
+		Fn:         cmpOpScalarLTFn,
+		Volatility: VolatilityImmutable,
+	})
+	cmpOps[IsNotDistinctFrom] = append(cmpOps[IsNotDistinctFrom], &CmpOp{
+		LeftType:     nestedArray,
+		RightType:    nestedArray,
+		Fn:           cmpOpScalarIsFn,
+		NullableArgs: true,
+		Volatility:   VolatilityImmutable,
+	})
+
 	for op, overload := range cmpOps {
 		for i, impl := range overload {
 			casted := impl.(*CmpOp)
@@ -3539,9 +3609,9 @@ func (expr *CastExpr) Eval(ctx *EvalContext) (Datum, error) {
 
 // Eval implements the TypedExpr interface.
 func (expr *IndirectionExpr) Eval(ctx *EvalContext) (Datum, error) {
-	var subscriptIdx int
+	subscripts := make([]int, len(expr.Indirection))
 	for i, t := range expr.Indirection {
-		if t.Slice || i > 0 {
+		if t.Slice {
 			return nil, errors.AssertionFailedf("unsupported feature should have been rejected during planning")
 		}
 
@@ -3552,29 +3622,32 @@ func (expr *IndirectionExpr) Eval(ctx *EvalContext) (Datum, error) {
 		if d == DNull {
 			return d, nil
 		}
-		subscriptIdx = int(MustBeDInt(d))
+		subscripts[i] = int(MustBeDInt(d))
 	}
 
 	d, err := expr.Expr.(TypedExpr).Eval(ctx)
 	if err != nil {
 		return nil, err
 	}
-	if d == DNull {
-		return d, nil
-	}
+	for _, subscriptIdx := range subscripts {
+		if d == DNull {
+			return d, nil
+		}
 
-	// Index into the DArray, using 1-indexing.
-	arr := MustBeDArray(d)
+		// Index into the DArray, using 1-indexing.
+		arr := MustBeDArray(d)
 
-	// VECTOR types use 0-indexing.
-	switch arr.customOid {
-	case oid.T_oidvector, oid.T_int2vector:
-		subscriptIdx++
-	}
-	if subscriptIdx < 1 || subscriptIdx > arr.Len() {
-		return DNull, nil
+		// VECTOR types use 0-indexing.
+		switch arr.customOid {
+		case oid.T_oidvector, oid.T_int2vector:
+			subscriptIdx++
+		}
+		if subscriptIdx < 1 || subscriptIdx > arr.Len() {
+			return DNull, nil
+		}
+		d = arr.Array[subscriptIdx-1]
 	}
-	return arr.Array[subscriptIdx-1], nil
+	return d, nil
 }
 
 // Eval implements the TypedExpr interface.
@@ -3603,6 +3676,11 @@ func (expr *ColumnAccessExpr) Eval(ctx *EvalContext) (Datum, error) {
 	if err != nil {
 		return nil, err
 	}
+	// Accessing a field of a NULL value, such as a NULL value of a composite
+	// type, results in NULL.
+	if d == DNull {
+		return d, nil
+	}
 	return d.(*DTuple).D[expr.ColIndex], nil
 }
 
@@ -4029,6 +4107,15 @@ func (t *ArrayFlatten) Eval(ctx *EvalContext) (Datum, error) {
 	if !ok {
 		return nil, errors.AssertionFailedf("array subquery result (%v) is not DTuple", d)
 	}
+	if array.ParamTyp.Family() == types.ArrayFamily {
+		// Append verifies that all the sub-arrays have the same dimensions.
+		for _, e := range tuple.D {
+			if err := array.Append(e); err != nil {
+				return nil, err
+			}
+		}
+		return array, nil
+	}
 	array.Array = tuple.D
 	return array, nil
 }
@@ -4108,6 +4195,11 @@ func (t *DEnum) Eval(_ *EvalContext) (Datum, error) {
 	return t, nil
 }
 
+// Eval implements the TypedExpr interface.
+func (t *DRange) Eval(_ *EvalContext) (Datum, error) {
+	return t, nil
+}
+
 // Eval implements the TypedExpr interface.
 func (t *DJSON) Eval(_ *EvalContext) (Datum, error) {
 	return t, nil
@@ -96,6 +96,11 @@ type FunctionProperties struct {
 	// the Postgres ones at test time.
 	// This should be used with caution.
 	IgnoreVolatilityCheck bool
+
+	// UserDefined is set to true for functions created with CREATE FUNCTION.
+	// The overloads of these functions cannot be evaluated directly; instead
+	// their bodies are inlined by the optimizer.
+	UserDefined bool
 }
 
 // ShouldDocument returns whether the built-in function should be included in
@@ -157,6 +162,31 @@ func NewFunctionDefinition(
 	}
 }
 
+// NewUserDefinedFunctionDefinition allocates a FunctionDefinition for a
+// user-defined function with the given overloads. Unlike
+// NewFunctionDefinition, it does not set up telemetry for the overloads.
+func NewUserDefinedFunctionDefinition(name string, def []Overload) *FunctionDefinition {
+	overloads := make([]overloadImpl, len(def))
+	impure := false
+	for i := range def {
+		overloads[i] = &def[i]
+		if def[i].Volatility == VolatilityVolatile {
+			impure = true
+		}
+	}
+	return &FunctionDefinition{
+		Name:       name,
+		Definition: overloads,
+		FunctionProperties: FunctionProperties{
+			// The strictness of user-defined functions is handled per overload
+			// when they are inlined.
+			NullableArgs: true,
+			Impure:       impure,
+			UserDefined:  true,
+		},
+	}
+}
+
 // FunDefs holds pre-allocated FunctionDefinition instances
 // for every builtin function. Initialized by builtins.init().
 var FunDefs map[string]*FunctionDefinition
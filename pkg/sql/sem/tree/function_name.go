@@ -11,8 +11,11 @@
 package tree
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/errors"
@@ -62,6 +65,46 @@ func (fn *ResolvableFunctionReference) Resolve(
 	}
 }
 
+// FunctionReferenceResolver manages resolving the names of user-defined
+// functions into FunctionDefinitions.
+type FunctionReferenceResolver interface {
+	// ResolveFunction returns the definition of the user-defined function with
+	// the given name, or nil if there is no such function.
+	ResolveFunction(ctx context.Context, name *UnresolvedObjectName) (*FunctionDefinition, error)
+}
+
+// ResolveWithResolver is like Resolve, but names that do not refer to a
+// builtin function are then resolved as user-defined functions using
+// resolver, if it is non-nil. Unlike builtins, the definitions of
+// user-defined functions are not cached in the reference, since they may
+// change between executions of a prepared statement.
+func (fn *ResolvableFunctionReference) ResolveWithResolver(
+	ctx context.Context, searchPath sessiondata.SearchPath, resolver FunctionReferenceResolver,
+) (*FunctionDefinition, error) {
+	fd, err := fn.Resolve(searchPath)
+	if err == nil || resolver == nil || pgerror.GetPGCode(err) != pgcode.UndefinedFunction {
+		return fd, err
+	}
+	n, ok := fn.FunctionReference.(*UnresolvedName)
+	if !ok {
+		return nil, err
+	}
+	un, nameErr := NewUnresolvedObjectName(
+		n.NumParts, [3]string{n.Parts[0], n.Parts[1], n.Parts[2]}, NoAnnotation,
+	)
+	if nameErr != nil {
+		return nil, err
+	}
+	udf, resolveErr := resolver.ResolveFunction(ctx, un)
+	if resolveErr != nil {
+		return nil, resolveErr
+	}
+	if udf == nil {
+		return nil, err
+	}
+	return udf, nil
+}
+
 // WrapFunction creates a new ResolvableFunctionReference
 // holding a pre-resolved function. Helper for grammar rules.
 func WrapFunction(n string) ResolvableFunctionReference {
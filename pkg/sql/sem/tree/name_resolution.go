@@ -583,6 +583,9 @@ const (
 	TableObject DesiredObjectKind = iota
 	// TypeObject is used when a type-like object is desired from resolution.
 	TypeObject
+	// FunctionObject is used when a user-defined function is desired from
+	// resolution.
+	FunctionObject
 )
 
 // NewQualifiedObjectName returns an ObjectName of the corresponding kind.
@@ -596,6 +599,11 @@ func NewQualifiedObjectName(catalog, schema, object string, kind DesiredObjectKi
 	case TypeObject:
 		name := MakeNewQualifiedTypeName(catalog, schema, object)
 		return &name
+	case FunctionObject:
+		// There is no dedicated ObjectName for functions; their names are
+		// formatted like table names.
+		name := MakeTableNameWithSchema(Name(catalog), Name(schema), Name(object))
+		return &name
 	}
 	return nil
 }
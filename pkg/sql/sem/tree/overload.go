@@ -73,6 +73,16 @@ type Overload struct {
 	// statement which will be executed as a common table expression in the query.
 	SQLFn func(*EvalContext, Datums) (string, error)
 
+	// Body is set for the overloads of user-defined functions. It is the SQL
+	// body of the function, which is inlined by the optimizer rather than
+	// evaluated using Fn.
+	Body string
+
+	// Strict is set for the overloads of user-defined functions that return
+	// NULL, without evaluating their body, whenever any of their arguments are
+	// NULL.
+	Strict bool
+
 	// counter, if non-nil, should be incremented upon successful
 	// type check of expressions using this overload.
 	counter telemetry.Counter
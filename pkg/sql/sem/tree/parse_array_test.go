@@ -22,6 +22,15 @@ import (
 
 func TestParseArray(t *testing.T) {
 	defer leaktest.AfterTest(t)()
+	intArray := func(vals ...int) *DArray {
+		arr := NewDArray(types.Int)
+		for _, v := range vals {
+			if err := arr.Append(NewDInt(DInt(v))); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return arr
+	}
 	testData := []struct {
 		str      string
 		typ      *types.T
@@ -39,6 +48,15 @@ func TestParseArray(t *testing.T) {
 		{` { "1" , 2}`, types.Int, Datums{NewDInt(1), NewDInt(2)}},
 		{`{1,NULL}`, types.Int, Datums{NewDInt(1), DNull}},
 
+		{`{{1,2},{3,4}}`, types.IntArray, Datums{intArray(1, 2), intArray(3, 4)}},
+		{` { { 1 } , {"2"} } `, types.IntArray, Datums{intArray(1), intArray(2)}},
+		{`{{},{}}`, types.IntArray, Datums{intArray(), intArray()}},
+		{`{}`, types.IntArray, Datums{}},
+		{`{{{1},{2}},{{3},{4}}}`, types.MakeArray(types.IntArray), Datums{
+			&DArray{ParamTyp: types.IntArray, Array: Datums{intArray(1), intArray(2)}, HasNonNulls: true},
+			&DArray{ParamTyp: types.IntArray, Array: Datums{intArray(3), intArray(4)}, HasNonNulls: true},
+		}},
+
 		{`{hello}`, types.String, Datums{NewDString(`hello`)}},
 		{`{hel
 lo}`, types.String, Datums{NewDString(`hel
@@ -168,8 +186,13 @@ func TestParseArrayError(t *testing.T) {
 		{`{,}`, types.Int, `could not parse "{,}" as type int[]: malformed array`},
 		{`{}{}`, types.Int, `could not parse "{}{}" as type int[]: extra text after closing right brace`},
 		{`{} {}`, types.Int, `could not parse "{} {}" as type int[]: extra text after closing right brace`},
-		{`{{}}`, types.Int, `could not parse "{{}}" as type int[]: unimplemented: nested arrays not supported`},
-		{`{1, {1}}`, types.Int, `could not parse "{1, {1}}" as type int[]: unimplemented: nested arrays not supported`},
+		{`{{}}`, types.Int, `could not parse "{{}}" as type int[]: number of array dimensions does not match type`},
+		{`{1, {1}}`, types.Int, `could not parse "{1, {1}}" as type int[]: number of array dimensions does not match type`},
+		{`{1,2}`, types.IntArray, `could not parse "{1,2}" as type int[][]: number of array dimensions does not match type`},
+		{`{{1},NULL}`, types.IntArray, `could not parse "{{1},NULL}" as type int[][]: number of array dimensions does not match type`},
+		{`{{1,2},{3}}`, types.IntArray, `could not parse "{{1,2},{3}}" as type int[][]: ` +
+			`multidimensional arrays must have array expressions with matching dimensions`},
+		{`{{1,2}`, types.IntArray, `could not parse "{{1,2}" as type int[][]: array must be enclosed in { and }`},
 		{`{hello}`, types.Int, `could not parse "{hello}" as type int[]: could not parse "hello" as type int: strconv.ParseInt: parsing "hello": invalid syntax`},
 		{`{"hello}`, types.String, `could not parse "{\"hello}" as type string[]: malformed array`},
 		// It might be unnecessary to disallow this, but Postgres does.
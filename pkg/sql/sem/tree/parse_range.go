@@ -0,0 +1,134 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+import (
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+// ParseDRangeFromString parses the string form of a range, such as '[1,10)',
+// '(,"2020-01-01 00:00:00"]' or 'empty', into a DRange of the given range
+// type. A bound that is omitted makes the range unbounded on that side.
+func ParseDRangeFromString(ctx ParseTimeContext, s string, t *types.T) (*DRange, error) {
+	ret, err := doParseDRangeFromString(ctx, s, t)
+	if err != nil {
+		return ret, makeParseError(s, t, err)
+	}
+	return ret, nil
+}
+
+var malformedRangeError = pgerror.New(pgcode.InvalidTextRepresentation, "malformed range literal")
+
+func doParseDRangeFromString(ctx ParseTimeContext, s string, t *types.T) (*DRange, error) {
+	in := strings.TrimSpace(s)
+	if strings.EqualFold(in, "empty") {
+		return NewEmptyDRange(t), nil
+	}
+	if len(in) < 3 {
+		return nil, malformedRangeError
+	}
+
+	var lower, upper RangeBound
+	switch in[0] {
+	case '[':
+		lower.Inclusive = true
+	case '(':
+	default:
+		return nil, malformedRangeError
+	}
+	switch in[len(in)-1] {
+	case ']':
+		upper.Inclusive = true
+	case ')':
+	default:
+		return nil, malformedRangeError
+	}
+
+	lowerStr, lowerQuoted, rest, ok := scanRangeBound(in[1 : len(in)-1])
+	if !ok || rest == "" {
+		return nil, malformedRangeError
+	}
+	upperStr, upperQuoted, rest, ok := scanRangeBound(rest[1:])
+	if !ok || rest != "" {
+		return nil, malformedRangeError
+	}
+
+	var err error
+	if lowerStr != "" || lowerQuoted {
+		if lower.Val, err = ParseAndRequireString(t.RangeContents(), lowerStr, ctx); err != nil {
+			return nil, err
+		}
+	}
+	if upperStr != "" || upperQuoted {
+		if upper.Val, err = ParseAndRequireString(t.RangeContents(), upperStr, ctx); err != nil {
+			return nil, err
+		}
+	}
+	return NewDRange(t, lower, upper)
+}
+
+// scanRangeBound scans a single bound of a range literal from the start of s
+// up to the first unquoted comma or the end of s. It returns the bound's
+// contents, whether any part of the bound was quoted, and the unscanned
+// remainder of s, which starts with the comma if there was one. Within
+// double quotes a doubled double quote stands for itself, and anywhere a
+// backslash escapes the following character. ok is false if the bound is
+// malformed.
+func scanRangeBound(s string) (val string, quoted bool, rest string, ok bool) {
+	var buf strings.Builder
+	inQuote := false
+	i := 0
+	for ; i < len(s); i++ {
+		ch := s[i]
+		if ch == '\\' {
+			i++
+			if i == len(s) {
+				return "", false, "", false
+			}
+			buf.WriteByte(s[i])
+			continue
+		}
+		if ch == '"' {
+			if inQuote && i+1 < len(s) && s[i+1] == '"' {
+				buf.WriteByte('"')
+				i++
+			} else {
+				inQuote = !inQuote
+				quoted = true
+			}
+			continue
+		}
+		if inQuote {
+			buf.WriteByte(ch)
+			continue
+		}
+		if ch == ',' {
+			break
+		}
+		switch ch {
+		case '(', ')', '[', ']':
+			return "", false, "", false
+		}
+		buf.WriteByte(ch)
+	}
+	if inQuote {
+		return "", false, "", false
+	}
+	val = buf.String()
+	if !quoted {
+		val = strings.TrimSpace(val)
+	}
+	return val, quoted, s[i:], true
+}
@@ -0,0 +1,165 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+)
+
+func TestParseRange(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	testData := []struct {
+		str      string
+		typ      *types.T
+		expected string
+	}{
+		{`empty`, types.Int8Range, `empty`},
+		{` EMPTY `, types.Int8Range, `empty`},
+		{`[1,10)`, types.Int8Range, `[1,10)`},
+		{`[1,10]`, types.Int8Range, `[1,11)`},
+		{`(1,10)`, types.Int8Range, `[2,10)`},
+		{`(1,10]`, types.Int4Range, `[2,11)`},
+		{`[ 1 , 10 )`, types.Int8Range, `[1,10)`},
+		{`["1","10")`, types.Int8Range, `[1,10)`},
+		{`(,10)`, types.Int8Range, `(,10)`},
+		{`[,10)`, types.Int8Range, `(,10)`},
+		{`[1,]`, types.Int8Range, `[1,)`},
+		{`(,)`, types.Int8Range, `(,)`},
+		{`[5,5)`, types.Int8Range, `empty`},
+		{`(5,5]`, types.Int8Range, `empty`},
+		{`[5,5]`, types.Int8Range, `[5,6)`},
+		{`(4,5)`, types.Int8Range, `empty`},
+		{`[2020-01-01,2020-01-31]`, types.DateRange, `[2020-01-01,2020-02-01)`},
+		{`[2020-01-01,infinity]`, types.DateRange, `[2020-01-01,infinity]`},
+		{`["2020-01-01 10:00","2020-01-01 12:00"]`, types.TimestampRange,
+			`["2020-01-01 10:00:00","2020-01-01 12:00:00"]`},
+		{`("2020-01-01 10:00",)`, types.TimestampRange, `("2020-01-01 10:00:00",)`},
+		{`[2020-01-01 10:00,2020-01-01 10:00]`, types.TimestampRange,
+			`["2020-01-01 10:00:00","2020-01-01 10:00:00"]`},
+		{`(2020-01-01 10:00,2020-01-01 10:00]`, types.TimestampRange, `empty`},
+	}
+	evalCtx := NewTestingEvalContext(cluster.MakeTestingClusterSettings())
+	for _, td := range testData {
+		t.Run(td.str, func(t *testing.T) {
+			actual, err := ParseDRangeFromString(evalCtx, td.str, td.typ)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if s := AsStringWithFlags(actual, FmtBareStrings); s != td.expected {
+				t.Fatalf("expected %s, got %s", td.expected, s)
+			}
+			// The formatted range must parse back to the same range.
+			reparsed, err := ParseDRangeFromString(evalCtx, td.expected, td.typ)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if reparsed.Compare(evalCtx, actual) != 0 {
+				t.Fatalf("%s did not round trip: got %s", td.expected, reparsed)
+			}
+		})
+	}
+}
+
+func TestParseRangeError(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	testData := []struct {
+		str           string
+		typ           *types.T
+		expectedError string
+	}{
+		{``, types.Int8Range, `could not parse "" as type int8range: malformed range literal`},
+		{`1,2`, types.Int8Range, `could not parse "1,2" as type int8range: malformed range literal`},
+		{`[1,2`, types.Int8Range, `could not parse "[1,2" as type int8range: malformed range literal`},
+		{`[1]`, types.Int8Range, `could not parse "[1]" as type int8range: malformed range literal`},
+		{`[1,2,3]`, types.Int8Range, `could not parse "[1,2,3]" as type int8range: malformed range literal`},
+		{`[(1,2]`, types.Int8Range, `could not parse "[(1,2]" as type int8range: malformed range literal`},
+		{`["1,2]`, types.Int8Range, `could not parse "[\"1,2]" as type int8range: malformed range literal`},
+		{`[10,1]`, types.Int8Range, `could not parse "[10,1]" as type int8range: ` +
+			`range lower bound must be less than or equal to range upper bound`},
+		{`[1,3000000000]`, types.Int4Range,
+			`could not parse "[1,3000000000]" as type int4range: integer out of range`},
+		{`[a,b]`, types.Int8Range, `could not parse "[a,b]" as type int8range: ` +
+			`could not parse "a" as type int: strconv.ParseInt: parsing "a": invalid syntax`},
+	}
+	evalCtx := NewTestingEvalContext(cluster.MakeTestingClusterSettings())
+	for _, td := range testData {
+		t.Run(td.str, func(t *testing.T) {
+			_, err := ParseDRangeFromString(evalCtx, td.str, td.typ)
+			if err == nil {
+				t.Fatalf("expected %#v to error with message %#v", td.str, td.expectedError)
+			}
+			if err.Error() != td.expectedError {
+				t.Fatalf("%s: got error %s, expected error %s", td.str, err.Error(), td.expectedError)
+			}
+		})
+	}
+}
+
+func TestRangeOperations(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	evalCtx := NewTestingEvalContext(cluster.MakeTestingClusterSettings())
+	parse := func(s string) *DRange {
+		r, err := ParseDRangeFromString(evalCtx, s, types.Int8Range)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return r
+	}
+	testData := []struct {
+		left, right                       string
+		cmp                               int
+		containsRange, overlaps, adjacent bool
+	}{
+		{`empty`, `empty`, 0, true, false, false},
+		{`empty`, `[1,2)`, -1, false, false, false},
+		{`[1,2)`, `empty`, 1, true, false, false},
+		{`[1,10)`, `[2,5)`, -1, true, true, false},
+		{`[1,10)`, `[1,10)`, 0, true, true, false},
+		{`[1,5)`, `[5,10)`, -1, false, false, true},
+		{`[5,10)`, `[1,5)`, 1, false, false, true},
+		{`[1,6)`, `[5,10)`, -1, false, true, false},
+		{`(,5)`, `[1,5)`, -1, true, true, false},
+		{`(,)`, `[1,5)`, -1, true, true, false},
+		{`[1,)`, `[1,5)`, 1, true, true, false},
+		{`[1,5)`, `[7,10)`, -1, false, false, false},
+	}
+	for _, td := range testData {
+		t.Run(td.left+" "+td.right, func(t *testing.T) {
+			l, r := parse(td.left), parse(td.right)
+			if cmp := l.Compare(evalCtx, r); cmp != td.cmp {
+				t.Errorf("expected compare %d, got %d", td.cmp, cmp)
+			}
+			if c := l.ContainsRange(r); c != td.containsRange {
+				t.Errorf("expected contains %t, got %t", td.containsRange, c)
+			}
+			if o := l.Overlaps(r); o != td.overlaps {
+				t.Errorf("expected overlaps %t, got %t", td.overlaps, o)
+			}
+			if o := r.Overlaps(l); o != td.overlaps {
+				t.Errorf("expected commuted overlaps %t, got %t", td.overlaps, o)
+			}
+			if a := l.Adjacent(r); a != td.adjacent {
+				t.Errorf("expected adjacent %t, got %t", td.adjacent, a)
+			}
+		})
+	}
+
+	r := parse(`[1,5)`)
+	for v, expected := range map[DInt]bool{0: false, 1: true, 4: true, 5: false} {
+		if c := r.ContainsValue(NewDInt(v)); c != expected {
+			t.Errorf("expected %s @> %d to be %t, got %t", r, v, expected, c)
+		}
+	}
+}
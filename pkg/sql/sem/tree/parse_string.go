@@ -66,6 +66,8 @@ func ParseAndRequireString(t *types.T, s string, ctx ParseTimeContext) (Datum, e
 		return ParseDUuidFromString(s)
 	case types.EnumFamily:
 		return MakeDEnumFromLogicalRepresentation(t, s)
+	case types.RangeFamily:
+		return ParseDRangeFromString(ctx, s, t)
 	default:
 		return nil, errors.AssertionFailedf("unknown type %s (%T)", t, t)
 	}
@@ -109,6 +109,10 @@ func (d *DArray) pgwireFormat(ctx *FmtCtx) {
 		switch dv := UnwrapDatum(nil, v).(type) {
 		case dNull:
 			ctx.WriteString("NULL")
+		case *DArray:
+			// Sub-arrays of multi-dimensional arrays are written without
+			// quoting.
+			ctx.FormatNode(dv)
 		case *DString:
 			pgwireFormatStringInArray(&ctx.Buffer, string(*dv))
 		case *DCollatedString:
@@ -126,7 +130,7 @@ func (d *DArray) pgwireFormat(ctx *FmtCtx) {
 	ctx.WriteByte('}')
 }
 
-var tupleQuoteSet, arrayQuoteSet asciiSet
+var tupleQuoteSet, arrayQuoteSet, rangeQuoteSet asciiSet
 
 func init() {
 	var ok bool
@@ -138,6 +142,10 @@ func init() {
 	if !ok {
 		panic("array asciiset")
 	}
+	rangeQuoteSet, ok = makeASCIISet(" \t\v\f\r\n()[],\"\\")
+	if !ok {
+		panic("range asciiset")
+	}
 }
 
 func pgwireQuoteStringInTuple(in string) bool {
@@ -178,6 +186,27 @@ func pgwireFormatStringInArray(buf *bytes.Buffer, in string) {
 	}
 }
 
+// formatStringInRange writes the string form of a range bound. Like
+// Postgres, bounds that contain special characters are quoted, and double
+// quotes and backslashes within them are doubled.
+func formatStringInRange(buf *bytes.Buffer, in string) {
+	quote := in == "" || rangeQuoteSet.in(in)
+	if quote {
+		buf.WriteByte('"')
+	}
+	for _, r := range in {
+		if r == '"' || r == '\\' {
+			buf.WriteByte(byte(r))
+			buf.WriteByte(byte(r))
+		} else {
+			buf.WriteRune(r)
+		}
+	}
+	if quote {
+		buf.WriteByte('"')
+	}
+}
+
 // From: https://github.com/golang/go/blob/master/src/strings/strings.go
 
 // asciiSet is a 32-byte value, where each bit represents the presence of a
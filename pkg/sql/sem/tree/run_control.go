@@ -40,6 +40,45 @@ func (n *ControlJobs) Format(ctx *FmtCtx) {
 	ctx.FormatNode(n.Jobs)
 }
 
+// ScheduleCommand determines which type of action to effect on the selected
+// schedule(s).
+type ScheduleCommand int
+
+// ScheduleCommand values
+const (
+	PauseSchedule ScheduleCommand = iota
+	ResumeSchedule
+	DropSchedule
+)
+
+func (c ScheduleCommand) String() string {
+	switch c {
+	case PauseSchedule:
+		return "PAUSE"
+	case ResumeSchedule:
+		return "RESUME"
+	case DropSchedule:
+		return "DROP"
+	default:
+		panic("unhandled schedule command")
+	}
+}
+
+// ControlSchedules represents PAUSE/RESUME/DROP SCHEDULES statement.
+type ControlSchedules struct {
+	Schedules *Select
+	Command   ScheduleCommand
+}
+
+var _ Statement = &ControlSchedules{}
+
+// Format implements the NodeFormatter interface.
+func (n *ControlSchedules) Format(ctx *FmtCtx) {
+	ctx.WriteString(n.Command.String())
+	ctx.WriteString(" SCHEDULES ")
+	ctx.FormatNode(n.Schedules)
+}
+
 // CancelQueries represents a CANCEL QUERIES statement.
 type CancelQueries struct {
 	Queries  *Select
@@ -631,6 +631,16 @@ var nullsOrderName = [...]string{
 	NullsLast:         "NULLS LAST",
 }
 
+// IsReversed returns true if the NULL ordering is the opposite of the default
+// for the given direction. By default, NULLs sort before all other values, so
+// they come first in an ascending ordering and last in a descending one.
+func (n NullsOrder) IsReversed(dir Direction) bool {
+	if dir == Descending {
+		return n == NullsFirst
+	}
+	return n == NullsLast
+}
+
 func (n NullsOrder) String() string {
 	if n < 0 || n > NullsOrder(len(nullsOrderName)-1) {
 		return fmt.Sprintf("NullsOrder(%d)", n)
@@ -84,6 +84,7 @@ const (
 // ShowBackup represents a SHOW BACKUP statement.
 type ShowBackup struct {
 	Path                 Expr
+	InCollection         Expr
 	Details              BackupDetails
 	ShouldIncludeSchemas bool
 	Options              KVOptions
@@ -101,12 +102,27 @@ func (node *ShowBackup) Format(ctx *FmtCtx) {
 		ctx.WriteString("SCHEMAS ")
 	}
 	ctx.FormatNode(node.Path)
+	if node.InCollection != nil {
+		ctx.WriteString(" IN ")
+		ctx.FormatNode(node.InCollection)
+	}
 	if len(node.Options) > 0 {
 		ctx.WriteString(" WITH ")
 		ctx.FormatNode(&node.Options)
 	}
 }
 
+// ShowBackups represents a SHOW BACKUPS statement.
+type ShowBackups struct {
+	InCollection Expr
+}
+
+// Format implements the NodeFormatter interface.
+func (node *ShowBackups) Format(ctx *FmtCtx) {
+	ctx.WriteString("SHOW BACKUPS IN ")
+	ctx.FormatNode(node.InCollection)
+}
+
 // ShowColumns represents a SHOW COLUMNS statement.
 type ShowColumns struct {
 	Table       *UnresolvedObjectName
@@ -244,6 +260,24 @@ func (node *ShowJobs) Format(ctx *FmtCtx) {
 	}
 }
 
+// ShowSchedules represents a SHOW SCHEDULES statement.
+type ShowSchedules struct {
+	// If non-nil, the ID of the single schedule to show.
+	ScheduleID Expr
+}
+
+var _ Statement = &ShowSchedules{}
+
+// Format implements the NodeFormatter interface.
+func (n *ShowSchedules) Format(ctx *FmtCtx) {
+	if n.ScheduleID != nil {
+		ctx.WriteString("SHOW SCHEDULE ")
+		ctx.FormatNode(n.ScheduleID)
+		return
+	}
+	ctx.WriteString("SHOW SCHEDULES")
+}
+
 // ShowSessions represents a SHOW SESSIONS statement
 type ShowSessions struct {
 	All     bool
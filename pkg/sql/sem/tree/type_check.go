@@ -54,6 +54,10 @@ type SemaContext struct {
 	// TypeResolver manages resolving type names into *types.T's.
 	TypeResolver TypeReferenceResolver
 
+	// FunctionResolver manages resolving the names of user-defined functions.
+	// If it is nil, only builtin functions can be used.
+	FunctionResolver FunctionReferenceResolver
+
 	// AsOfTimestamp denotes the explicit AS OF SYSTEM TIME timestamp for the
 	// query, if any. If the query is not an AS OF SYSTEM TIME query,
 	// AsOfTimestamp is nil.
@@ -145,6 +149,10 @@ const (
 	// RejectSubqueries rejects subqueries in scalar contexts.
 	RejectSubqueries
 
+	// RejectUserDefinedFunctions rejects any use of functions created with
+	// CREATE FUNCTION.
+	RejectUserDefinedFunctions
+
 	// RejectSpecial is used in common places like the LIMIT clause.
 	RejectSpecial = RejectAggregates | RejectGenerators | RejectWindowApplications
 )
@@ -217,6 +225,14 @@ func (sc *SemaContext) GetRelativeParseTime() time.Time {
 	return timeutil.Now().In(sc.GetLocation())
 }
 
+// GetFunctionResolver returns the FunctionReferenceResolver.
+func (sc *SemaContext) GetFunctionResolver() FunctionReferenceResolver {
+	if sc == nil {
+		return nil
+	}
+	return sc.FunctionResolver
+}
+
 // GetTypeResolver returns the TypeReferenceResolver.
 func (sc *SemaContext) GetTypeResolver() TypeReferenceResolver {
 	if sc == nil {
@@ -442,6 +458,28 @@ func isCastDeepValid(castFrom, castTo *types.T) (bool, telemetry.Counter) {
 		// Casts from ENUM to ENUM type can only succeed if the two enums
 		// types are equivalent.
 		return castFrom.Equivalent(castTo), sqltelemetry.EnumCastCounter
+	case toFamily == types.RangeFamily && fromFamily == types.RangeFamily:
+		// Casts between range types are only valid if their subtypes are
+		// equivalent, like int4range and int8range.
+		if !castFrom.RangeContents().Equivalent(castTo.RangeContents()) {
+			return false, nil
+		}
+		return true, lookupCast(fromFamily, toFamily).counter
+	case toFamily == types.TupleFamily && fromFamily == types.TupleFamily:
+		// Casts between tuple types are valid if every field can be cast to
+		// the corresponding field of the target type.
+		if types.IsWildcardTupleType(castTo) {
+			return true, lookupCast(fromFamily, toFamily).counter
+		}
+		if len(castFrom.TupleContents()) != len(castTo.TupleContents()) {
+			return false, nil
+		}
+		for i := range castFrom.TupleContents() {
+			if ok, _ := isCastDeepValid(castFrom.TupleContents()[i], castTo.TupleContents()[i]); !ok {
+				return false, nil
+			}
+		}
+		return true, lookupCast(fromFamily, toFamily).counter
 	}
 
 	cast := lookupCast(fromFamily, toFamily)
@@ -522,13 +560,17 @@ func (expr *CastExpr) TypeCheck(
 func (expr *IndirectionExpr) TypeCheck(
 	ctx context.Context, semaCtx *SemaContext, desired *types.T,
 ) (TypedExpr, error) {
-	for i, t := range expr.Indirection {
+	desiredArray := types.AnyArray
+	if desired.Family() != types.AnyFamily {
+		desiredArray = desired
+		for range expr.Indirection {
+			desiredArray = types.MakeArray(desiredArray)
+		}
+	}
+	for _, t := range expr.Indirection {
 		if t.Slice {
 			return nil, unimplemented.NewWithIssuef(32551, "ARRAY slicing in %s", expr)
 		}
-		if i > 0 {
-			return nil, unimplemented.NewWithIssueDetailf(32552, "ind", "multidimensional indexing: %s", expr)
-		}
 
 		beginExpr, err := typeCheckAndRequire(ctx, semaCtx, t.Begin, types.Int, "ARRAY subscript")
 		if err != nil {
@@ -537,16 +579,20 @@ func (expr *IndirectionExpr) TypeCheck(
 		t.Begin = beginExpr
 	}
 
-	subExpr, err := expr.Expr.TypeCheck(ctx, semaCtx, types.MakeArray(desired))
+	subExpr, err := expr.Expr.TypeCheck(ctx, semaCtx, desiredArray)
 	if err != nil {
 		return nil, err
 	}
+	// Each subscript strips one dimension off the array type.
 	typ := subExpr.ResolvedType()
-	if typ.Family() != types.ArrayFamily {
-		return nil, pgerror.Newf(pgcode.DatatypeMismatch, "cannot subscript type %s because it is not an array", typ)
+	for range expr.Indirection {
+		if typ.Family() != types.ArrayFamily {
+			return nil, pgerror.Newf(pgcode.DatatypeMismatch, "cannot subscript type %s because it is not an array", typ)
+		}
+		typ = typ.ArrayContents()
 	}
 	expr.Expr = subExpr
-	expr.typ = typ.ArrayContents()
+	expr.typ = typ
 
 	telemetry.Inc(sqltelemetry.ArraySubscriptCounter)
 	return expr, nil
@@ -845,6 +891,10 @@ func (sc *SemaContext) checkFunctionUsage(expr *FuncExpr, def *FunctionDefinitio
 		}
 		sc.Properties.Derived.SeenGenerator = true
 	}
+	if def.UserDefined && sc.Properties.required.rejectFlags&RejectUserDefinedFunctions != 0 {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"user-defined functions are not allowed in %s", sc.Properties.required.context)
+	}
 	if def.Impure {
 		if sc.Properties.required.rejectFlags&RejectImpureFunctions != 0 {
 			// The code FeatureNotSupported is a bit misleading here,
@@ -880,7 +930,7 @@ func (expr *FuncExpr) TypeCheck(
 	if semaCtx != nil {
 		searchPath = semaCtx.SearchPath
 	}
-	def, err := expr.Func.Resolve(searchPath)
+	def, err := expr.Func.ResolveWithResolver(ctx, searchPath, semaCtx.GetFunctionResolver())
 	if err != nil {
 		return nil, err
 	}
@@ -1404,6 +1454,23 @@ func (expr *Tuple) TypeCheck(
 			labels[i] = lex.NormalizeName(expr.Labels[i])
 		}
 	}
+	// A tuple which is desired to be of a composite type takes on that type if
+	// all of its elements fit the fields of the composite type. This allows
+	// tuples with NULL elements to be used as values of composite types.
+	if labels == nil && desired.Family() == types.TupleFamily && desired.UserDefined() &&
+		len(desired.TupleContents()) == len(contents) {
+		fits := true
+		for i := range contents {
+			if contents[i].Family() != types.UnknownFamily && !contents[i].Equivalent(desired.TupleContents()[i]) {
+				fits = false
+				break
+			}
+		}
+		if fits {
+			expr.typ = desired
+			return expr, nil
+		}
+	}
 	expr.typ = types.MakeLabeledTuple(contents, labels)
 	return expr, nil
 }
@@ -1532,6 +1599,12 @@ func (d *DEnum) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedE
 	return d, nil
 }
 
+// TypeCheck implements the Expr interface. It is implemented as an idempotent
+// identity function for Datum.
+func (d *DRange) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedExpr, error) {
+	return d, nil
+}
+
 // TypeCheck implements the Expr interface. It is implemented as an idempotent
 // identity function for Datum.
 func (d *DDecimal) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedExpr, error) {
@@ -0,0 +1,78 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+)
+
+type setConstraintsNode struct {
+	n *tree.SetConstraints
+}
+
+// SetConstraints sets whether the checks of deferrable foreign key
+// constraints are deferred until the end of the current transaction.
+// Privileges: None.
+func (p *planner) SetConstraints(ctx context.Context, n *tree.SetConstraints) (planNode, error) {
+	for _, name := range n.Names {
+		rows, err := p.ExecCfg().InternalExecutor.QueryEx(
+			ctx, "set-constraints", p.txn,
+			sqlbase.InternalExecutorSessionDataOverride{
+				User: p.User(), Database: p.CurrentDatabase(),
+			},
+			`SELECT condeferrable FROM pg_catalog.pg_constraint WHERE conname = $1`,
+			string(name),
+		)
+		if err != nil {
+			return nil, err
+		}
+		if len(rows) == 0 {
+			return nil, pgerror.Newf(pgcode.UndefinedObject,
+				"constraint %q does not exist", string(name))
+		}
+		for _, row := range rows {
+			if !bool(tree.MustBeDBool(row[0])) {
+				return nil, pgerror.Newf(pgcode.WrongObjectType,
+					"constraint %q is not deferrable", string(name))
+			}
+		}
+	}
+	return &setConstraintsNode{n: n}, nil
+}
+
+func (n *setConstraintsNode) startExec(params runParams) error {
+	state := params.p.deferredFKChecks
+	if state == nil || params.p.EvalContext().TxnImplicit {
+		params.p.SendClientNotice(params.ctx, pgnotice.NewWithSeverityf("WARNING",
+			"SET CONSTRAINTS can only be used in transaction blocks"))
+		return nil
+	}
+	state.setMode(n.n.Names, n.n.Deferred)
+	if n.n.Deferred {
+		return nil
+	}
+	// The pending checks of the constraints which are now immediate are run
+	// right away.
+	return state.run(
+		params.ctx, params.ExecCfg().InternalExecutor, params.p.txn,
+		func(c *deferredFKCheck) bool { return !state.isDeferred(c) },
+	)
+}
+
+func (n *setConstraintsNode) Next(runParams) (bool, error) { return false, nil }
+func (n *setConstraintsNode) Values() tree.Datums          { return nil }
+func (n *setConstraintsNode) Close(context.Context)        {}
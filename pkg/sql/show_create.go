@@ -182,6 +182,10 @@ func ShowCreateTable(
 		}
 	}
 
+	if err := showTriggers(desc, &f.Buffer); err != nil {
+		return "", err
+	}
+
 	return f.CloseAndGetString(), nil
 }
 
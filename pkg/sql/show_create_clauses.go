@@ -83,13 +83,23 @@ func ShowCreateView(
 	if desc.Temporary {
 		f.WriteString("TEMP ")
 	}
+	if desc.MaterializedView() {
+		f.WriteString("MATERIALIZED ")
+	}
 	f.WriteString("VIEW ")
 	f.FormatNode(tn)
 	f.WriteString(" (")
+	first := true
 	for i := range desc.Columns {
-		if i > 0 {
+		// Materialized views have a hidden row ID column that is not part of
+		// the view definition.
+		if desc.Columns[i].Hidden {
+			continue
+		}
+		if !first {
 			f.WriteString(", ")
 		}
+		first = false
 		f.FormatNameP(&desc.Columns[i].Name)
 	}
 	f.WriteString(") AS ")
@@ -134,6 +144,42 @@ func showComments(
 	return nil
 }
 
+// showTriggers prints out the CREATE TRIGGER statements sufficient to
+// recreate a table's triggers.
+func showTriggers(table *sqlbase.ImmutableTableDescriptor, buf *bytes.Buffer) error {
+	if len(table.Triggers) == 0 {
+		return nil
+	}
+	tableName, err := tree.NewUnresolvedObjectName(1, [3]string{table.Name}, 0 /* annotationIdx */)
+	if err != nil {
+		return err
+	}
+	for i := range table.Triggers {
+		trigger := &table.Triggers[i]
+		stmt := tree.CreateTrigger{
+			Name:       tree.Name(trigger.Name),
+			ActionTime: tree.TriggerBefore,
+			Table:      tableName,
+			Body:       trigger.Body,
+		}
+		if trigger.ActionTime == sqlbase.TableDescriptor_Trigger_AFTER {
+			stmt.ActionTime = tree.TriggerAfter
+		}
+		if trigger.OnInsert {
+			stmt.Events = append(stmt.Events, tree.TriggerInsert)
+		}
+		if trigger.OnUpdate {
+			stmt.Events = append(stmt.Events, tree.TriggerUpdate)
+		}
+		if trigger.OnDelete {
+			stmt.Events = append(stmt.Events, tree.TriggerDelete)
+		}
+		buf.WriteString(";\n")
+		buf.WriteString(tree.AsString(&stmt))
+	}
+	return nil
+}
+
 // showForeignKeyConstraint returns a valid SQL representation of a FOREIGN KEY
 // clause for a given index.
 func showForeignKeyConstraint(
@@ -193,6 +239,11 @@ func showForeignKeyConstraint(
 		buf.WriteString(" ON UPDATE ")
 		buf.WriteString(fk.OnUpdate.String())
 	}
+	if fk.InitiallyDeferred {
+		buf.WriteString(" DEFERRABLE INITIALLY DEFERRED")
+	} else if fk.Deferrable {
+		buf.WriteString(" DEFERRABLE")
+	}
 	return nil
 }
 
@@ -316,7 +316,7 @@ func (s *Builder) encodeConstraintKey(
 			}
 			key = keys[0]
 		} else {
-			key, err = sqlbase.EncodeTableKey(key, val, dir)
+			key, err = sqlbase.EncodeTableKeyWithNulls(key, val, dir, s.index.ColumnNullsReversedAt(i))
 			if err != nil {
 				return nil, false, err
 			}
@@ -0,0 +1,514 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/storage/enginepb"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+)
+
+// sqlCursor is a cursor created by a DECLARE statement. Cursors are scoped to
+// the transaction in which they were declared.
+//
+// The query of a cursor is planned when the cursor is declared, and is run on
+// a goroutine of its own as FETCH and MOVE request its rows. The query only
+// makes progress while a row is being requested: in between, it is paused
+// while handing out its last row. This way the query does not use its
+// transaction while the transaction is in use by the other statements of the
+// session. The query is planned locally so that its flow is fused into the
+// goroutine running it.
+type sqlCursor struct {
+	name      tree.Name
+	statement string
+	created   time.Time
+	// seq is the sequence number of the cursor among those declared in its
+	// transaction. It determines whether the cursor was declared after a
+	// savepoint that is rolled back.
+	seq int
+
+	columns sqlbase.ResultColumns
+	// planner is used to plan and run the cursor's query. It is not shared
+	// with the session, since the query outlives the DECLARE statement.
+	planner *planner
+	// mon accounts for the memory used by the cursor's query. It is a child of
+	// the session monitor.
+	mon *mon.BytesMonitor
+	// readSeq is the sequencing point of the transaction when the cursor was
+	// declared. The query reads at it, so that it does not observe the writes
+	// performed by the transaction since.
+	readSeq enginepb.TxnSeq
+	// run runs the cursor's query, writing its rows to the given result.
+	run func(res *cursorResult)
+	// res receives the rows of the query. It is nil until the first row is
+	// requested, at which point the query is started.
+	res *cursorResult
+	// done is set once the query has stopped producing rows.
+	done bool
+	// total is the number of rows produced by the cursor's query. It is only
+	// known once done is set.
+	total int64
+	// pos is the position of the cursor: 0 before the first row, total+1 after
+	// the last row, and otherwise the 1-based index of the current row.
+	pos int64
+	// curRow is the row at pos, if any. It is kept around since FETCH can
+	// return the current row again.
+	curRow tree.Datums
+}
+
+var errBackwardScan = errors.WithHint(
+	pgerror.New(pgcode.ObjectNotInPrerequisiteState, "cursor can only scan forward"),
+	"Declare it with SCROLL option to enable backward scan.",
+)
+
+// pull resumes the cursor's query until it produces its next row. It returns
+// nil once the query has no more rows.
+func (c *sqlCursor) pull(ctx context.Context) (tree.Datums, error) {
+	txn := c.planner.txn
+	prevSeq := txn.GetReadSeqNum()
+	if err := txn.SetReadSeqNum(ctx, c.readSeq); err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := txn.SetReadSeqNum(ctx, prevSeq); err != nil {
+			log.Warningf(ctx, "unable to restore the read sequence number of %s: %v", txn, err)
+		}
+	}()
+	if c.res == nil {
+		c.res = &cursorResult{
+			rowCh:  make(chan tree.Datums),
+			nextCh: make(chan bool),
+			doneCh: make(chan struct{}),
+		}
+		go func(res *cursorResult) {
+			defer close(res.doneCh)
+			c.run(res)
+		}(c.res)
+	} else {
+		c.res.nextCh <- true
+	}
+	select {
+	case row := <-c.res.rowCh:
+		return row, nil
+	case <-c.res.doneCh:
+		if c.res.commErr != nil {
+			return nil, c.res.commErr
+		}
+		return nil, c.res.err
+	}
+}
+
+// next advances the cursor by one row. It returns false if the cursor moved
+// past the last row.
+func (c *sqlCursor) next(ctx context.Context) (bool, error) {
+	if c.done {
+		c.pos = c.total + 1
+		c.curRow = nil
+		return false, nil
+	}
+	row, err := c.pull(ctx)
+	if err != nil || row == nil {
+		c.done = true
+		c.total = c.pos
+		c.pos = c.total + 1
+		c.curRow = nil
+		return false, err
+	}
+	c.curRow = row
+	c.pos++
+	return true, nil
+}
+
+// seek positions the cursor for the given FETCH or MOVE statement. It
+// returns whether the current row is to be returned again, and the number of
+// rows that are then to be read with next.
+func (c *sqlCursor) seek(
+	ctx context.Context, s *tree.CursorStmt,
+) (current bool, count int64, _ error) {
+	var target int64
+	switch s.FetchType {
+	case tree.FetchNormal:
+		if s.Count < 0 {
+			return false, 0, errBackwardScan
+		}
+		if s.Count == 0 {
+			return c.curRow != nil, 0, nil
+		}
+		return false, s.Count, nil
+	case tree.FetchAll:
+		return false, math.MaxInt64, nil
+	case tree.FetchBackwardAll:
+		return false, 0, errBackwardScan
+	case tree.FetchRelative:
+		target = c.pos + s.Count
+	case tree.FetchAbsolute:
+		// Positions relative to the end of the results can only be reached by
+		// moving past the end and back.
+		if s.Count < 0 {
+			return false, 0, errBackwardScan
+		}
+		target = s.Count
+	case tree.FetchFirst:
+		target = 1
+	case tree.FetchLast:
+		// The last row is only known once the cursor moved past it, so keep it
+		// around while reading the remaining rows.
+		last, lastPos := c.curRow, c.pos
+		for {
+			if ok, err := c.next(ctx); err != nil {
+				return false, 0, err
+			} else if !ok {
+				break
+			}
+			last, lastPos = c.curRow, c.pos
+		}
+		if last == nil {
+			return false, 0, nil
+		}
+		c.curRow, c.pos = last, lastPos
+		return true, 0, nil
+	default:
+		return false, 0, errors.AssertionFailedf("unknown fetch type %d", s.FetchType)
+	}
+	if target == c.pos {
+		return c.curRow != nil, 0, nil
+	}
+	if target < c.pos {
+		return false, 0, errBackwardScan
+	}
+	// Skip to the row before the target, so that the target row is read by the
+	// caller.
+	for c.pos < target-1 {
+		if ok, err := c.next(ctx); err != nil {
+			return false, 0, err
+		} else if !ok {
+			break
+		}
+	}
+	return false, 1, nil
+}
+
+// close stops the cursor's query and releases the resources held by the
+// cursor.
+func (c *sqlCursor) close(ctx context.Context) {
+	if c.res != nil {
+		// Unless it finished, the query is paused after handing out its last
+		// row. Tell it to stop instead, and wait for it to wind down.
+		select {
+		case <-c.res.doneCh:
+		case c.res.nextCh <- false:
+			<-c.res.doneCh
+		}
+	}
+	c.done = true
+	c.res = nil
+	c.planner.curPlan.close(ctx)
+	c.mon.Stop(ctx)
+	c.curRow = nil
+}
+
+// sqlCursors is the set of cursors of a transaction.
+type sqlCursors struct {
+	cursors map[tree.Name]*sqlCursor
+	// seq is the sequence number of the next cursor to be declared.
+	seq int
+}
+
+func (cs *sqlCursors) get(name tree.Name) (*sqlCursor, error) {
+	c, ok := cs.cursors[name]
+	if !ok {
+		return nil, pgerror.Newf(pgcode.InvalidCursorName, "cursor %q does not exist", name)
+	}
+	return c, nil
+}
+
+func (cs *sqlCursors) add(c *sqlCursor) {
+	if cs.cursors == nil {
+		cs.cursors = make(map[tree.Name]*sqlCursor)
+	}
+	c.seq = cs.seq
+	cs.seq++
+	cs.cursors[c.name] = c
+}
+
+func (cs *sqlCursors) closeCursor(ctx context.Context, name tree.Name) error {
+	c, err := cs.get(name)
+	if err != nil {
+		return err
+	}
+	c.close(ctx)
+	delete(cs.cursors, name)
+	return nil
+}
+
+func (cs *sqlCursors) closeAll(ctx context.Context) {
+	for name, c := range cs.cursors {
+		c.close(ctx)
+		delete(cs.cursors, name)
+	}
+}
+
+// closeSince closes the cursors declared since the given sequence number was
+// current, as they are when rolling back to a savepoint created then.
+func (cs *sqlCursors) closeSince(ctx context.Context, seq int) {
+	for name, c := range cs.cursors {
+		if c.seq >= seq {
+			c.close(ctx)
+			delete(cs.cursors, name)
+		}
+	}
+}
+
+// cursorResult is the result that the query of a cursor is run with. It
+// hands each row over to the goroutine requesting it, and then waits for the
+// next row to be requested before letting the query continue.
+type cursorResult struct {
+	// rowCh receives the rows produced by the query.
+	rowCh chan tree.Datums
+	// nextCh receives true when another row is requested, or false when the
+	// cursor is closed before the query finished.
+	nextCh chan bool
+	// doneCh is closed once the query stopped running.
+	doneCh chan struct{}
+
+	err          error
+	commErr      error
+	rowsAffected int
+}
+
+var _ RestrictedCommandResult = &cursorResult{}
+
+// AddRow is part of the RestrictedCommandResult interface.
+func (r *cursorResult) AddRow(ctx context.Context, row tree.Datums) error {
+	// The row is reused by the caller once AddRow returns.
+	r.rowCh <- append(tree.Datums(nil), row...)
+	if !<-r.nextCh {
+		return ErrLimitedResultClosed
+	}
+	return nil
+}
+
+// SetError is part of the RestrictedCommandResult interface.
+func (r *cursorResult) SetError(err error) { r.err = err }
+
+// Err is part of the RestrictedCommandResult interface.
+func (r *cursorResult) Err() error { return r.err }
+
+// AppendParamStatusUpdate is part of the RestrictedCommandResult interface.
+func (r *cursorResult) AppendParamStatusUpdate(string, string) {}
+
+// AppendNotice is part of the RestrictedCommandResult interface.
+func (r *cursorResult) AppendNotice(error) {}
+
+// SetColumns is part of the RestrictedCommandResult interface.
+func (r *cursorResult) SetColumns(context.Context, sqlbase.ResultColumns) {}
+
+// ResetStmtType is part of the RestrictedCommandResult interface.
+func (r *cursorResult) ResetStmtType(tree.Statement) {}
+
+// IncrementRowsAffected is part of the RestrictedCommandResult interface.
+func (r *cursorResult) IncrementRowsAffected(n int) { r.rowsAffected += n }
+
+// RowsAffected is part of the RestrictedCommandResult interface.
+func (r *cursorResult) RowsAffected() int { return r.rowsAffected }
+
+// DisableBuffering is part of the RestrictedCommandResult interface.
+func (r *cursorResult) DisableBuffering() {}
+
+// declareCursor validates a DECLARE statement and returns the cursor it
+// declares. The cursor's query is then set up with the cursor's planner and
+// planned by openCursor. The cursor must be closed.
+func (ex *connExecutor) declareCursor(
+	ctx context.Context, s *tree.DeclareCursor, sql string, stmtTS time.Time,
+) (*sqlCursor, error) {
+	if _, ok := ex.extraTxnState.sqlCursors.cursors[s.Name]; ok {
+		return nil, pgerror.Newf(pgcode.DuplicateCursor, "cursor %q already exists", s.Name)
+	}
+	if s.Scroll == tree.Scroll {
+		return nil, unimplemented.NewWithIssue(41412, "DECLARE SCROLL CURSOR")
+	}
+	if s.Hold {
+		return nil, unimplemented.NewWithIssue(41412, "DECLARE CURSOR WITH HOLD")
+	}
+	if s.Binary {
+		return nil, unimplemented.NewWithIssue(41412, "DECLARE BINARY CURSOR")
+	}
+	telemetry.Inc(sqltelemetry.DeclareCursorCounter)
+
+	memMon := mon.MakeMonitor(
+		"cursor",
+		mon.MemoryResource,
+		nil, /* curCount */
+		nil, /* maxHist */
+		-1,  /* increment */
+		noteworthyMemoryUsageBytes,
+		ex.server.cfg.Settings,
+	)
+	memMon.Start(ctx, ex.sessionMon, mon.BoundAccount{})
+
+	p := &planner{execCfg: ex.server.cfg, alloc: &sqlbase.DatumAlloc{}}
+	ex.initPlanner(ctx, p)
+	ex.resetPlanner(ctx, p, ex.state.mu.txn, stmtTS)
+	p.extendedEvalCtx.Mon = &memMon
+	return &sqlCursor{
+		name:      s.Name,
+		statement: sql,
+		created:   timeutil.Now(),
+		planner:   p,
+		mon:       &memMon,
+	}, nil
+}
+
+// openCursor plans the query of a cursor with the cursor's planner, after its
+// statement was set up by execStmtInOpenState. The query is run once rows
+// are requested from the cursor.
+func (ex *connExecutor) openCursor(ctx context.Context, c *sqlCursor) error {
+	p := c.planner
+	if err := ex.makeExecPlan(ctx, p); err != nil {
+		return err
+	}
+	c.columns = p.curPlan.main.planColumns()
+	c.readSeq = p.txn.GetReadSeqNum()
+
+	// The query is run in the context of the transaction, which it can't
+	// outlive, rather than in that of the DECLARE statement.
+	runCtx := ex.state.Ctx
+	p.cancelChecker = sqlbase.NewCancelChecker(runCtx)
+	c.run = func(res *cursorResult) {
+		_, _, res.commErr = ex.execWithDistSQLEngine(
+			runCtx, p, tree.Rows, res, false /* distribute */, nil, /* progressAtomic */
+		)
+	}
+	return nil
+}
+
+type fetchCursorNode struct {
+	n      *tree.FetchCursor
+	cursor *sqlCursor
+
+	// current is set if the cursor's current row is to be returned first.
+	current bool
+	// remaining is the number of rows left to read.
+	remaining int64
+}
+
+// FetchCursor returns rows from a cursor.
+// Privileges: None.
+func (p *planner) FetchCursor(_ context.Context, n *tree.FetchCursor) (planNode, error) {
+	c, err := p.sqlCursors.get(n.Name)
+	if err != nil {
+		return nil, err
+	}
+	return &fetchCursorNode{n: n, cursor: c}, nil
+}
+
+func (n *fetchCursorNode) startExec(params runParams) error {
+	var err error
+	n.current, n.remaining, err = n.cursor.seek(params.ctx, &n.n.CursorStmt)
+	return err
+}
+
+func (n *fetchCursorNode) Next(params runParams) (bool, error) {
+	if n.current {
+		n.current = false
+		return true, nil
+	}
+	if n.remaining == 0 {
+		return false, nil
+	}
+	if err := params.p.cancelChecker.Check(); err != nil {
+		return false, err
+	}
+	n.remaining--
+	return n.cursor.next(params.ctx)
+}
+
+func (n *fetchCursorNode) Values() tree.Datums       { return n.cursor.curRow }
+func (n *fetchCursorNode) Close(ctx context.Context) {}
+
+type moveCursorNode struct {
+	n       *tree.MoveCursor
+	cursor  *sqlCursor
+	numRows int
+}
+
+// MoveCursor repositions a cursor without returning any rows.
+// Privileges: None.
+func (p *planner) MoveCursor(_ context.Context, n *tree.MoveCursor) (planNode, error) {
+	c, err := p.sqlCursors.get(n.Name)
+	if err != nil {
+		return nil, err
+	}
+	return &moveCursorNode{n: n, cursor: c}, nil
+}
+
+// FastPathResults implements the planNodeFastPath interface.
+func (n *moveCursorNode) FastPathResults() (int, bool) {
+	return n.numRows, true
+}
+
+func (n *moveCursorNode) startExec(params runParams) error {
+	current, count, err := n.cursor.seek(params.ctx, &n.n.CursorStmt)
+	if err != nil {
+		return err
+	}
+	if current {
+		n.numRows++
+	}
+	for ; count > 0; count-- {
+		if ok, err := n.cursor.next(params.ctx); err != nil {
+			return err
+		} else if !ok {
+			break
+		}
+		n.numRows++
+	}
+	return nil
+}
+
+func (n *moveCursorNode) Next(params runParams) (bool, error) { return false, nil }
+func (n *moveCursorNode) Values() tree.Datums                 { return tree.Datums{} }
+func (n *moveCursorNode) Close(ctx context.Context)           {}
+
+type closeCursorNode struct {
+	n *tree.CloseCursor
+}
+
+// CloseCursor closes one or all of the cursors of the current transaction.
+// Privileges: None.
+func (p *planner) CloseCursor(_ context.Context, n *tree.CloseCursor) (planNode, error) {
+	return &closeCursorNode{n: n}, nil
+}
+
+func (n *closeCursorNode) startExec(params runParams) error {
+	if n.n.All {
+		params.p.sqlCursors.closeAll(params.ctx)
+		return nil
+	}
+	return params.p.sqlCursors.closeCursor(params.ctx, n.n.Name)
+}
+
+func (n *closeCursorNode) Next(params runParams) (bool, error) { return false, nil }
+func (n *closeCursorNode) Values() tree.Datums                 { return tree.Datums{} }
+func (n *closeCursorNode) Close(ctx context.Context)           {}
@@ -35,6 +35,45 @@ import (
 // types to either index keys or to store in the value part of column
 // families.
 
+// EncodeTableKeyWithNulls is like EncodeTableKey, except that if
+// nullsReversed is set, NULL is encoded so that it sorts after all other
+// values when dir is Ascending, and before them when dir is Descending. It is
+// used to encode index columns with a non-default NULL ordering.
+func EncodeTableKeyWithNulls(
+	b []byte, val tree.Datum, dir encoding.Direction, nullsReversed bool,
+) ([]byte, error) {
+	if val == tree.DNull && nullsReversed {
+		return encodeReversedNullKey(b, dir)
+	}
+	return EncodeTableKey(b, val, dir)
+}
+
+// encodeReversedNullKey encodes NULL using the marker of the direction opposite
+// to dir. Both markers are recognized when decoding NULLs.
+func encodeReversedNullKey(b []byte, dir encoding.Direction) ([]byte, error) {
+	switch dir {
+	case encoding.Ascending:
+		return encoding.EncodeNullDescending(b), nil
+	case encoding.Descending:
+		return encoding.EncodeNullAscending(b), nil
+	default:
+		return nil, errors.Errorf("invalid direction: %d", dir)
+	}
+}
+
+// isReversedNullKey returns true if buf starts with a NULL that was encoded by
+// encodeReversedNullKey for the given key encoding.
+func isReversedNullKey(enc DatumEncoding, buf []byte) bool {
+	switch enc {
+	case DatumEncoding_ASCENDING_KEY:
+		return encoding.HasNullDescendingPrefix(buf)
+	case DatumEncoding_DESCENDING_KEY:
+		return encoding.HasNullAscendingPrefix(buf)
+	default:
+		return false
+	}
+}
+
 // EncodeTableKey encodes `val` into `b` and returns the new buffer.
 // This is suitable to generate index/lookup keys in KV.
 //
@@ -176,6 +215,15 @@ func EncodeTableKey(b []byte, val tree.Datum, dir encoding.Direction) ([]byte, e
 			return encoding.EncodeBytesAscending(b, t.PhysicalRep), nil
 		}
 		return encoding.EncodeBytesDescending(b, t.PhysicalRep), nil
+	case *tree.DRange:
+		data, err := encodeRange(nil /* appendTo */, t)
+		if err != nil {
+			return nil, err
+		}
+		if dir == encoding.Ascending {
+			return encoding.EncodeBytesAscending(b, data), nil
+		}
+		return encoding.EncodeBytesDescending(b, data), nil
 	}
 	return nil, errors.Errorf("unable to encode table key: %T", val)
 }
@@ -395,6 +443,18 @@ func DecodeTableKey(
 			return nil, nil, err
 		}
 		return a.NewDEnum(tree.DEnum{EnumTyp: valType, PhysicalRep: phys, LogicalRep: log}), rkey, nil
+	case types.RangeFamily:
+		var r []byte
+		if dir == encoding.Ascending {
+			rkey, r, err = encoding.DecodeBytesAscending(key, nil)
+		} else {
+			rkey, r, err = encoding.DecodeBytesDescending(key, nil)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		d, err := decodeRange(a, valType, r)
+		return d, rkey, err
 	default:
 		return nil, nil, errors.Errorf("unable to decode table key: %s", valType)
 	}
@@ -472,6 +532,12 @@ func EncodeTableValue(
 		return encoding.EncodeIntValue(appendTo, uint32(colID), int64(t.DInt)), nil
 	case *tree.DEnum:
 		return encoding.EncodeBytesValue(appendTo, uint32(colID), t.PhysicalRep), nil
+	case *tree.DRange:
+		data, err := encodeRange(scratch[:0], t)
+		if err != nil {
+			return nil, err
+		}
+		return encoding.EncodeBytesValue(appendTo, uint32(colID), data), nil
 	default:
 		return nil, errors.Errorf("unable to encode table value: %T", t)
 	}
@@ -630,6 +696,13 @@ func DecodeUntaggedDatum(a *DatumAlloc, t *types.T, buf []byte) (tree.Datum, []b
 			return nil, nil, err
 		}
 		return a.NewDEnum(tree.DEnum{EnumTyp: t, PhysicalRep: phys, LogicalRep: log}), b, nil
+	case types.RangeFamily:
+		b, data, err := encoding.DecodeUntaggedBytesValue(buf)
+		if err != nil {
+			return nil, b, err
+		}
+		d, err := decodeRange(a, t, data)
+		return d, b, err
 	default:
 		return nil, buf, errors.Errorf("couldn't decode type %s", t)
 	}
@@ -781,6 +854,24 @@ func MarshalColumnValue(col *ColumnDescriptor, val tree.Datum) (roachpb.Value, e
 			r.SetBytes(v.PhysicalRep)
 			return r, nil
 		}
+	case types.TupleFamily:
+		if v, ok := val.(*tree.DTuple); ok {
+			b, err := encodeUntaggedTuple(v, nil /* appendTo */, nil /* scratch */)
+			if err != nil {
+				return r, err
+			}
+			r.SetBytes(b)
+			return r, nil
+		}
+	case types.RangeFamily:
+		if v, ok := val.(*tree.DRange); ok {
+			b, err := encodeRange(nil /* appendTo */, v)
+			if err != nil {
+				return r, err
+			}
+			r.SetBytes(b)
+			return r, nil
+		}
 	default:
 		return r, errors.AssertionFailedf("unsupported column type: %s", col.Type.Family())
 	}
@@ -954,14 +1045,114 @@ func UnmarshalColumnValue(a *DatumAlloc, typ *types.T, value roachpb.Value) (tre
 			return nil, err
 		}
 		return a.NewDEnum(tree.DEnum{EnumTyp: typ, PhysicalRep: phys, LogicalRep: log}), nil
+	case types.TupleFamily:
+		v, err := value.GetBytes()
+		if err != nil {
+			return nil, err
+		}
+		datum, _, err := decodeTuple(a, typ, v)
+		return datum, err
+	case types.RangeFamily:
+		v, err := value.GetBytes()
+		if err != nil {
+			return nil, err
+		}
+		return decodeRange(a, typ, v)
 	default:
 		return nil, errors.Errorf("unsupported column type: %s", typ.Family())
 	}
 }
 
+// encodeRange appends the encoding of a range to appendTo. The encoding is
+// used both inside key encodings, where it is wrapped as a byte string, and
+// as the value encoding of ranges. It is ordered the same way as
+// DRange.Compare, so the encoding consists of:
+//  - 0 for the empty range, or 1 followed by the two bounds;
+//  - for the lower bound, 0 if it is unbounded, or 1 followed by the
+//    ascending key encoding of its value and then 0 if it is inclusive or 1
+//    if it is exclusive;
+//  - for the upper bound, 1 followed by the ascending key encoding of its
+//    value and then 0 if it is exclusive or 1 if it is inclusive, or 2 if it
+//    is unbounded.
+// All markers are encoded with EncodeUvarintAscending.
+func encodeRange(appendTo []byte, r *tree.DRange) ([]byte, error) {
+	if r.Empty {
+		return encoding.EncodeUvarintAscending(appendTo, 0), nil
+	}
+	b := encoding.EncodeUvarintAscending(appendTo, 1)
+	var err error
+	if r.Lower.Unbounded() {
+		b = encoding.EncodeUvarintAscending(b, 0)
+	} else {
+		b = encoding.EncodeUvarintAscending(b, 1)
+		if b, err = EncodeTableKey(b, r.Lower.Val, encoding.Ascending); err != nil {
+			return nil, err
+		}
+		if r.Lower.Inclusive {
+			b = encoding.EncodeUvarintAscending(b, 0)
+		} else {
+			b = encoding.EncodeUvarintAscending(b, 1)
+		}
+	}
+	if r.Upper.Unbounded() {
+		return encoding.EncodeUvarintAscending(b, 2), nil
+	}
+	b = encoding.EncodeUvarintAscending(b, 1)
+	if b, err = EncodeTableKey(b, r.Upper.Val, encoding.Ascending); err != nil {
+		return nil, err
+	}
+	if r.Upper.Inclusive {
+		return encoding.EncodeUvarintAscending(b, 1), nil
+	}
+	return encoding.EncodeUvarintAscending(b, 0), nil
+}
+
+// decodeRange decodes a range of type typ encoded by encodeRange.
+func decodeRange(a *DatumAlloc, typ *types.T, b []byte) (*tree.DRange, error) {
+	b, marker, err := encoding.DecodeUvarintAscending(b)
+	if err != nil {
+		return nil, err
+	}
+	if marker == 0 {
+		return tree.NewEmptyDRange(typ), nil
+	}
+	var lower, upper tree.RangeBound
+	if b, marker, err = encoding.DecodeUvarintAscending(b); err != nil {
+		return nil, err
+	}
+	if marker == 1 {
+		if lower.Val, b, err = DecodeTableKey(a, typ.RangeContents(), b, encoding.Ascending); err != nil {
+			return nil, err
+		}
+		if b, marker, err = encoding.DecodeUvarintAscending(b); err != nil {
+			return nil, err
+		}
+		lower.Inclusive = marker == 0
+	}
+	if b, marker, err = encoding.DecodeUvarintAscending(b); err != nil {
+		return nil, err
+	}
+	if marker == 1 {
+		if upper.Val, b, err = DecodeTableKey(a, typ.RangeContents(), b, encoding.Ascending); err != nil {
+			return nil, err
+		}
+		if _, marker, err = encoding.DecodeUvarintAscending(b); err != nil {
+			return nil, err
+		}
+		upper.Inclusive = marker == 1
+	}
+	return tree.NewDRange(typ, lower, upper)
+}
+
 // encodeTuple produces the value encoding for a tuple.
 func encodeTuple(t *tree.DTuple, appendTo []byte, colID uint32, scratch []byte) ([]byte, error) {
 	appendTo = encoding.EncodeValueTag(appendTo, colID, encoding.Tuple)
+	return encodeUntaggedTuple(t, appendTo, scratch)
+}
+
+// encodeUntaggedTuple produces the value encoding of a tuple without a value
+// tag. It is also used for tuples which are elements of arrays.
+func encodeUntaggedTuple(t *tree.DTuple, appendTo []byte, scratch []byte) ([]byte, error) {
 	appendTo = encoding.EncodeNonsortingUvarint(appendTo, uint64(len(t.D)))
 
 	var err error
@@ -982,9 +1173,7 @@ func decodeTuple(a *DatumAlloc, tupTyp *types.T, b []byte) (tree.Datum, []byte,
 		return nil, nil, err
 	}
 
-	result := tree.DTuple{
-		D: a.NewDatums(len(tupTyp.TupleContents())),
-	}
+	result := tree.MakeDTuple(tupTyp, a.NewDatums(len(tupTyp.TupleContents()))...)
 
 	var datum tree.Datum
 	for i := range tupTyp.TupleContents() {
@@ -1059,23 +1248,29 @@ func decodeArrayKey(
 	return result, buf, nil
 }
 
-// encodeArray produces the value encoding for an array.
+// encodeArray produces the value encoding for an array. Multi-dimensional
+// arrays are encoded as the flattened list of their innermost elements,
+// preceded in the header by the length of every dimension.
 func encodeArray(d *tree.DArray, scratch []byte) ([]byte, error) {
 	if err := d.Validate(); err != nil {
 		return scratch, err
 	}
 	scratch = scratch[0:0]
-	elementType, err := datumTypeToArrayElementEncodingType(d.ParamTyp)
+	dimensions, elements, hasNulls := flattenArray(d)
+	if len(dimensions) > maxArrayDimensions {
+		return nil, errors.AssertionFailedf("too many array dimensions: %d", len(dimensions))
+	}
+	elementType, err := datumTypeToArrayElementEncodingType(arrayLeafType(d.ParamTyp))
 
 	if err != nil {
 		return nil, err
 	}
 	header := arrayHeader{
-		hasNulls: d.HasNulls,
-		// TODO(justin): support multiple dimensions.
-		numDimensions: 1,
+		hasNulls:      hasNulls,
+		numDimensions: len(dimensions),
+		dimensions:    dimensions,
 		elementType:   elementType,
-		length:        uint64(d.Len()),
+		length:        uint64(len(elements)),
 		// We don't encode the NULL bitmap in this function because we do it in lockstep with the
 		// main data.
 	}
@@ -1084,14 +1279,14 @@ func encodeArray(d *tree.DArray, scratch []byte) ([]byte, error) {
 		return nil, err
 	}
 	nullBitmapStart := len(scratch)
-	if d.HasNulls {
-		for i := 0; i < numBytesInBitArray(d.Len()); i++ {
+	if hasNulls {
+		for i := 0; i < numBytesInBitArray(len(elements)); i++ {
 			scratch = append(scratch, 0)
 		}
 	}
-	for i, e := range d.Array {
+	for i, e := range elements {
 		var err error
-		if d.HasNulls && e == tree.DNull {
+		if hasNulls && e == tree.DNull {
 			setBit(scratch[nullBitmapStart:], i)
 		} else {
 			scratch, err = encodeArrayElement(scratch, e)
@@ -1103,6 +1298,63 @@ func encodeArray(d *tree.DArray, scratch []byte) ([]byte, error) {
 	return scratch, nil
 }
 
+// flattenArray returns the length of every dimension of the given array, and
+// its innermost elements in row-major order. Since all the sub-arrays in a
+// dimension have the same length, the lengths are those of the first sub-array
+// in each dimension, or zero if the array is empty.
+func flattenArray(d *tree.DArray) (dimensions []uint64, elements tree.Datums, hasNulls bool) {
+	if d.ParamTyp.Family() != types.ArrayFamily {
+		return []uint64{uint64(d.Len())}, d.Array, d.HasNulls
+	}
+	for t, sub := d.ParamTyp, d; ; t = t.ArrayContents() {
+		var n int
+		if sub != nil {
+			n = sub.Len()
+		}
+		dimensions = append(dimensions, uint64(n))
+		if t.Family() != types.ArrayFamily {
+			break
+		}
+		if n > 0 {
+			sub = tree.MustBeDArray(sub.Array[0])
+		} else {
+			sub = nil
+		}
+	}
+	var flatten func(d *tree.DArray)
+	flatten = func(d *tree.DArray) {
+		if d.ParamTyp.Family() != types.ArrayFamily {
+			elements = append(elements, d.Array...)
+			hasNulls = hasNulls || d.HasNulls
+			return
+		}
+		for _, e := range d.Array {
+			flatten(tree.MustBeDArray(e))
+		}
+	}
+	flatten(d)
+	return dimensions, elements, hasNulls
+}
+
+// arrayNumDimensions returns the number of dimensions of an array with
+// elements of type t.
+func arrayNumDimensions(t *types.T) int {
+	n := 1
+	for ; t.Family() == types.ArrayFamily; t = t.ArrayContents() {
+		n++
+	}
+	return n
+}
+
+// arrayLeafType returns the type of the innermost elements of a possibly
+// multi-dimensional array with elements of type t.
+func arrayLeafType(t *types.T) *types.T {
+	for t.Family() == types.ArrayFamily {
+		t = t.ArrayContents()
+	}
+	return t
+}
+
 // decodeArray decodes the value encoding for an array.
 func decodeArray(a *DatumAlloc, elementType *types.T, b []byte) (tree.Datum, []byte, error) {
 	b, _, _, err := encoding.DecodeNonsortingUvarint(b)
@@ -1121,9 +1373,14 @@ func decodeArrayNoMarshalColumnValue(
 	if err != nil {
 		return nil, b, err
 	}
+	leafType := arrayLeafType(elementType)
+	if numDimensions := arrayNumDimensions(elementType); header.numDimensions != numDimensions {
+		return nil, b, errors.AssertionFailedf(
+			"expected %d array dimensions, found %d", numDimensions, header.numDimensions)
+	}
 	result := tree.DArray{
 		Array:    make(tree.Datums, header.length),
-		ParamTyp: elementType,
+		ParamTyp: leafType,
 	}
 	var val tree.Datum
 	for i := uint64(0); i < header.length; i++ {
@@ -1132,16 +1389,46 @@ func decodeArrayNoMarshalColumnValue(
 			result.HasNulls = true
 		} else {
 			result.HasNonNulls = true
-			val, b, err = DecodeUntaggedDatum(a, elementType, b)
+			val, b, err = DecodeUntaggedDatum(a, leafType, b)
 			if err != nil {
 				return nil, b, err
 			}
 			result.Array[i] = val
 		}
 	}
+	if header.numDimensions > 1 {
+		return unflattenArray(&result, elementType, header.dimensions), b, nil
+	}
 	return &result, b, nil
 }
 
+// unflattenArray is the inverse of flattenArray. It builds the array with
+// elements of type t and the given dimensions from the innermost elements of
+// the flat array.
+func unflattenArray(flat *tree.DArray, t *types.T, dimensions []uint64) *tree.DArray {
+	if t.Family() != types.ArrayFamily {
+		return flat
+	}
+	result := &tree.DArray{ParamTyp: t, Array: make(tree.Datums, dimensions[0])}
+	if dimensions[0] == 0 {
+		return result
+	}
+	result.HasNonNulls = true
+	stride := uint64(len(flat.Array)) / dimensions[0]
+	for i := range result.Array {
+		sub := &tree.DArray{ParamTyp: flat.ParamTyp, Array: flat.Array[uint64(i)*stride : uint64(i+1)*stride]}
+		for _, e := range sub.Array {
+			if e == tree.DNull {
+				sub.HasNulls = true
+			} else {
+				sub.HasNonNulls = true
+			}
+		}
+		result.Array[i] = unflattenArray(sub, t.ArrayContents(), dimensions[1:])
+	}
+	return result
+}
+
 // arrayHeader is a parameter passing struct between
 // encodeArray/decodeArray and encodeArrayHeader/decodeArrayHeader.
 //
@@ -1152,6 +1439,10 @@ type arrayHeader struct {
 	hasNulls bool
 	// numDimensions is the number of dimensions in the array.
 	numDimensions int
+	// dimensions is the length of each dimension of a multi-dimensional
+	// array. It is not encoded for one-dimensional arrays, whose length is
+	// the number of elements.
+	dimensions []uint64
 	// elementType is the encoding type of the array elements.
 	elementType encoding.Type
 	// length is the total number of elements encoded.
@@ -1190,6 +1481,10 @@ func makeBitVec(src []byte, length int) (b, bitVec []byte) {
 
 const hasNullFlag = 1 << 4
 
+// maxArrayDimensions is the largest number of dimensions that can be encoded
+// in the low 4 bits of the array header.
+const maxArrayDimensions = hasNullFlag - 1
+
 // encodeArrayHeader is used by encodeArray to encode the header
 // at the beginning of the value encoding.
 func encodeArrayHeader(h arrayHeader, buf []byte) ([]byte, error) {
@@ -1203,6 +1498,11 @@ func encodeArrayHeader(h arrayHeader, buf []byte) ([]byte, error) {
 	}
 	buf = append(buf, byte(headerByte))
 	buf = encoding.EncodeValueTag(buf, encoding.NoColumnID, h.elementType)
+	if h.numDimensions > 1 {
+		for _, d := range h.dimensions {
+			buf = encoding.EncodeNonsortingUvarint(buf, d)
+		}
+	}
 	buf = encoding.EncodeNonsortingUvarint(buf, h.length)
 	return buf, nil
 }
@@ -1214,12 +1514,23 @@ func decodeArrayHeader(b []byte) (arrayHeader, []byte, error) {
 		return arrayHeader{}, b, errors.Errorf("buffer too small")
 	}
 	hasNulls := b[0]&hasNullFlag != 0
+	numDimensions := int(b[0] & maxArrayDimensions)
 	b = b[1:]
 	_, dataOffset, _, encType, err := encoding.DecodeValueTag(b)
 	if err != nil {
 		return arrayHeader{}, b, err
 	}
 	b = b[dataOffset:]
+	var dimensions []uint64
+	if numDimensions > 1 {
+		dimensions = make([]uint64, numDimensions)
+		for i := range dimensions {
+			b, _, dimensions[i], err = encoding.DecodeNonsortingUvarint(b)
+			if err != nil {
+				return arrayHeader{}, b, err
+			}
+		}
+	}
 	b, _, length, err := encoding.DecodeNonsortingUvarint(b)
 	if err != nil {
 		return arrayHeader{}, b, err
@@ -1229,9 +1540,9 @@ func decodeArrayHeader(b []byte) (arrayHeader, []byte, error) {
 		b, nullBitmap = makeBitVec(b, int(length))
 	}
 	return arrayHeader{
-		hasNulls: hasNulls,
-		// TODO(justin): support multiple dimensions.
-		numDimensions: 1,
+		hasNulls:      hasNulls,
+		numDimensions: numDimensions,
+		dimensions:    dimensions,
 		elementType:   encType,
 		length:        length,
 		nullBitmap:    nullBitmap,
@@ -1276,6 +1587,10 @@ func datumTypeToArrayElementEncodingType(t *types.T) (encoding.Type, error) {
 		return encoding.UUID, nil
 	case types.INetFamily:
 		return encoding.IPAddr, nil
+	case types.TupleFamily:
+		return encoding.Tuple, nil
+	case types.RangeFamily:
+		return encoding.Bytes, nil
 	default:
 		return 0, errors.Errorf("Don't know encoding type for %s", t)
 	}
@@ -1345,6 +1660,14 @@ func encodeArrayElement(b []byte, d tree.Datum) ([]byte, error) {
 		return encodeArrayElement(b, t.Wrapped)
 	case *tree.DEnum:
 		return encoding.EncodeUntaggedBytesValue(b, t.PhysicalRep), nil
+	case *tree.DTuple:
+		return encodeUntaggedTuple(t, b, nil /* scratch */)
+	case *tree.DRange:
+		data, err := encodeRange(nil /* appendTo */, t)
+		if err != nil {
+			return nil, err
+		}
+		return encoding.EncodeUntaggedBytesValue(b, data), nil
 	default:
 		return nil, errors.Errorf("don't know how to encode %s (%T)", d, d)
 	}
@@ -232,6 +232,41 @@ func TestEncodeTableKey(t *testing.T) {
 		genEncodingDirection(),
 	))
 
+	// A NULL encoded with a reversed NULL ordering must sort after all other
+	// values in ascending order and before them in descending order, and must
+	// still decode as NULL.
+	properties.Property("nulls-reversed", prop.ForAll(
+		func(d tree.Datum, dir encoding.Direction) string {
+			b, err := EncodeTableKeyWithNulls(nil, d, dir, true /* nullsReversed */)
+			if err != nil {
+				return "error: " + err.Error()
+			}
+			nullKey, err := EncodeTableKeyWithNulls(nil, tree.DNull, dir, true /* nullsReversed */)
+			if err != nil {
+				return "error: " + err.Error()
+			}
+			cmp := bytes.Compare(nullKey, b)
+			if (dir == encoding.Ascending && cmp <= 0) || (dir == encoding.Descending && cmp >= 0) {
+				return fmt.Sprintf("NULL is not ordered on the reversed side: \n%v\n%v", nullKey, b)
+			}
+			newD, leftoverBytes, err := DecodeTableKey(a, d.ResolvedType(), nullKey, dir)
+			if err != nil {
+				return "error: " + err.Error()
+			}
+			if len(leftoverBytes) > 0 {
+				return "Leftover bytes"
+			}
+			if newD != tree.DNull {
+				return fmt.Sprintf("expected NULL, got %s", newD)
+			}
+			return ""
+		},
+		genColumnType().
+			SuchThat(hasKeyEncoding).
+			FlatMap(genDatumWithType, reflect.TypeOf((*tree.Datum)(nil)).Elem()),
+		genEncodingDirection(),
+	))
+
 	properties.TestingRun(t)
 }
 
@@ -106,6 +106,11 @@ func (desc *DatabaseDescriptor) TypeDesc() *TypeDescriptor {
 	return nil
 }
 
+// FunctionDesc implements the ObjectDescriptor interface.
+func (desc *DatabaseDescriptor) FunctionDesc() *FunctionDescriptor {
+	return nil
+}
+
 // NameResolutionResult implements the ObjectDescriptor interface.
 func (desc *ImmutableDatabaseDescriptor) NameResolutionResult() {}
 
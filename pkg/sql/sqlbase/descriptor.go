@@ -37,6 +37,10 @@ type DescriptorInterface interface {
 	// TypeDesc returns the underlying type descriptor, or nil if the
 	// descriptor is not a type backed object.
 	TypeDesc() *TypeDescriptor
+
+	// FunctionDesc returns the underlying function descriptor, or nil if the
+	// descriptor is not a function backed object.
+	FunctionDesc() *FunctionDescriptor
 }
 
 // BaseDescriptorInterface is an interface to be shared by individual descriptor
@@ -132,6 +132,12 @@ func EncDatumFromBuffer(typ *types.T, enc DatumEncoding, buf []byte) (EncDatum,
 		if err != nil {
 			return EncDatum{}, nil, err
 		}
+		if isReversedNullKey(enc, buf) {
+			// Index columns with reversed NULL ordering encode NULL using the marker
+			// of the opposite direction. Don't keep that encoding around, so that
+			// the encoded bytes of any key-encoded NULL are the same.
+			return DatumToEncDatum(typ, tree.DNull), buf[encLen:], nil
+		}
 		ed := EncDatumFromEncoded(enc, buf[:encLen])
 		return ed, buf[encLen:], nil
 	case DatumEncoding_VALUE:
@@ -475,10 +481,7 @@ func (r EncDatumRow) Compare(
 			return 0, err
 		}
 		if cmp != 0 {
-			if c.Direction == encoding.Descending {
-				cmp = -cmp
-			}
-			return cmp, nil
+			return c.adjustCompare(cmp, r[c.ColIdx].IsNull(), rhs[c.ColIdx].IsNull()), nil
 		}
 	}
 	return 0, nil
@@ -498,10 +501,7 @@ func (r EncDatumRow) CompareToDatums(
 		}
 		cmp := r[c.ColIdx].Datum.Compare(evalCtx, rhs[c.ColIdx])
 		if cmp != 0 {
-			if c.Direction == encoding.Descending {
-				cmp = -cmp
-			}
-			return cmp, nil
+			return c.adjustCompare(cmp, r[c.ColIdx].Datum == tree.DNull, rhs[c.ColIdx] == tree.DNull), nil
 		}
 	}
 	return 0, nil
@@ -0,0 +1,235 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sqlbase
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
+	"github.com/cockroachdb/errors"
+)
+
+// FunctionDescriptorInterface will eventually be called funcdesc.Descriptor.
+// It is implemented by (Imm|M)utableFunctionDescriptor.
+type FunctionDescriptorInterface interface {
+	BaseDescriptorInterface
+	FunctionDesc() *FunctionDescriptor
+}
+
+var _ FunctionDescriptorInterface = (*ImmutableFunctionDescriptor)(nil)
+var _ FunctionDescriptorInterface = (*MutableFunctionDescriptor)(nil)
+
+// NameResolutionResult implements the NameResolutionResult interface.
+func (desc *FunctionDescriptor) NameResolutionResult() {}
+
+// ImmutableFunctionDescriptor is a custom type for wrapping
+// FunctionDescriptors when used in a read only way.
+type ImmutableFunctionDescriptor struct {
+	FunctionDescriptor
+}
+
+// MutableFunctionDescriptor is a custom type for FunctionDescriptors
+// undergoing any types of modifications.
+type MutableFunctionDescriptor struct {
+	ImmutableFunctionDescriptor
+
+	// ClusterVersion represents the version of the function descriptor read
+	// from the store.
+	ClusterVersion *ImmutableFunctionDescriptor
+}
+
+// NewMutableCreatedFunctionDescriptor returns a MutableFunctionDescriptor
+// from the given function descriptor with the cluster version being the zero
+// function. This is for a function that is created in the same transaction.
+func NewMutableCreatedFunctionDescriptor(desc FunctionDescriptor) *MutableFunctionDescriptor {
+	return &MutableFunctionDescriptor{
+		ImmutableFunctionDescriptor: ImmutableFunctionDescriptor{FunctionDescriptor: desc},
+	}
+}
+
+// NewMutableExistingFunctionDescriptor returns a MutableFunctionDescriptor
+// from the given function descriptor with the cluster version also set to the
+// descriptor. This is for functions that already exist.
+func NewMutableExistingFunctionDescriptor(desc FunctionDescriptor) *MutableFunctionDescriptor {
+	return &MutableFunctionDescriptor{
+		ImmutableFunctionDescriptor: ImmutableFunctionDescriptor{
+			FunctionDescriptor: *protoutil.Clone(&desc).(*FunctionDescriptor),
+		},
+		ClusterVersion: NewImmutableFunctionDescriptor(desc),
+	}
+}
+
+// NewImmutableFunctionDescriptor returns an ImmutableFunctionDescriptor from
+// the given FunctionDescriptor.
+func NewImmutableFunctionDescriptor(desc FunctionDescriptor) *ImmutableFunctionDescriptor {
+	return &ImmutableFunctionDescriptor{FunctionDescriptor: desc}
+}
+
+// DescriptorProto returns a Descriptor for serialization.
+func (desc *ImmutableFunctionDescriptor) DescriptorProto() *Descriptor {
+	return &Descriptor{
+		Union: &Descriptor_Function{
+			Function: &desc.FunctionDescriptor,
+		},
+	}
+}
+
+// DatabaseDesc implements the ObjectDescriptor interface.
+func (desc *ImmutableFunctionDescriptor) DatabaseDesc() *DatabaseDescriptor {
+	return nil
+}
+
+// SchemaDesc implements the ObjectDescriptor interface.
+func (desc *ImmutableFunctionDescriptor) SchemaDesc() *SchemaDescriptor {
+	return nil
+}
+
+// TableDesc implements the ObjectDescriptor interface.
+func (desc *ImmutableFunctionDescriptor) TableDesc() *TableDescriptor {
+	return nil
+}
+
+// TypeDesc implements the ObjectDescriptor interface.
+func (desc *ImmutableFunctionDescriptor) TypeDesc() *TypeDescriptor {
+	return nil
+}
+
+// FunctionDesc implements the ObjectDescriptor interface.
+func (desc *ImmutableFunctionDescriptor) FunctionDesc() *FunctionDescriptor {
+	return &desc.FunctionDescriptor
+}
+
+// GetAuditMode implements the DescriptorProto interface.
+func (desc *ImmutableFunctionDescriptor) GetAuditMode() TableDescriptor_AuditMode {
+	return TableDescriptor_DISABLED
+}
+
+// GetPrivileges implements the DescriptorProto interface.
+//
+// Functions do not carry privileges.
+func (desc *ImmutableFunctionDescriptor) GetPrivileges() *PrivilegeDescriptor {
+	return nil
+}
+
+// TypeName implements the DescriptorProto interface.
+func (desc *ImmutableFunctionDescriptor) TypeName() string {
+	return "function"
+}
+
+// FindOverload returns the index of the overload of the function with the
+// given parameter types, or -1 if there is none.
+func (desc *FunctionDescriptor) FindOverload(paramTypes []*types.T) int {
+	for i := range desc.Overloads {
+		params := desc.Overloads[i].Params
+		if len(params) != len(paramTypes) {
+			continue
+		}
+		match := true
+		for j := range params {
+			if !params[j].Type.Equivalent(paramTypes[j]) {
+				match = false
+				break
+			}
+		}
+		if match {
+			return i
+		}
+	}
+	return -1
+}
+
+// HydrateTypesInFunctionDescriptor uses typeLookup to install metadata in the
+// user defined types of the parameters and return values of the overloads of
+// a function descriptor.
+func HydrateTypesInFunctionDescriptor(desc *FunctionDescriptor, typeLookup TypeLookupFunc) error {
+	hydrate := func(typ *types.T) error {
+		if !typ.UserDefined() {
+			return nil
+		}
+		name, typDesc, err := typeLookup(ID(typ.StableTypeID()))
+		if err != nil {
+			return err
+		}
+		return typDesc.HydrateTypeInfoWithName(typ, name, typeLookup)
+	}
+	for i := range desc.Overloads {
+		o := &desc.Overloads[i]
+		for j := range o.Params {
+			if err := hydrate(o.Params[j].Type); err != nil {
+				return err
+			}
+		}
+		if err := hydrate(o.ReturnType); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MakeFunctionDescriptorVolatility converts a tree.Volatility to its
+// FunctionDescriptor representation.
+func MakeFunctionDescriptorVolatility(v tree.Volatility) FunctionDescriptor_Volatility {
+	switch v {
+	case tree.VolatilityLeakProof:
+		return FunctionDescriptor_LEAKPROOF
+	case tree.VolatilityImmutable:
+		return FunctionDescriptor_IMMUTABLE
+	case tree.VolatilityStable:
+		return FunctionDescriptor_STABLE
+	default:
+		return FunctionDescriptor_VOLATILE
+	}
+}
+
+// TreeVolatility returns the tree.Volatility corresponding to v.
+func (v FunctionDescriptor_Volatility) TreeVolatility() tree.Volatility {
+	switch v {
+	case FunctionDescriptor_LEAKPROOF:
+		return tree.VolatilityLeakProof
+	case FunctionDescriptor_IMMUTABLE:
+		return tree.VolatilityImmutable
+	case FunctionDescriptor_STABLE:
+		return tree.VolatilityStable
+	default:
+		return tree.VolatilityVolatile
+	}
+}
+
+// errUDFNotEvaluable is returned if the overload of a user-defined function
+// is evaluated directly, rather than being inlined by the optimizer.
+var errUDFNotEvaluable = errors.AssertionFailedf(
+	"user-defined functions cannot be evaluated in this context")
+
+// MakeFunctionDefinition returns the tree.FunctionDefinition for the function
+// described by desc, with one overload per overload of the function. The
+// overloads carry the bodies of the functions, to be inlined by the optimizer.
+func (desc *ImmutableFunctionDescriptor) MakeFunctionDefinition() *tree.FunctionDefinition {
+	overloads := make([]tree.Overload, len(desc.Overloads))
+	for i := range desc.Overloads {
+		o := &desc.Overloads[i]
+		argTypes := make(tree.ArgTypes, len(o.Params))
+		for j := range o.Params {
+			argTypes[j].Name = o.Params[j].Name
+			argTypes[j].Typ = o.Params[j].Type
+		}
+		overloads[i] = tree.Overload{
+			Types:      argTypes,
+			ReturnType: tree.FixedReturnType(o.ReturnType),
+			Volatility: o.Volatility.TreeVolatility(),
+			Body:       o.Body,
+			Strict:     o.Strict,
+			Fn: func(*tree.EvalContext, tree.Datums) (tree.Datum, error) {
+				return nil, errUDFNotEvaluable
+			},
+		}
+	}
+	return tree.NewUserDefinedFunctionDefinition(desc.Name, overloads)
+}
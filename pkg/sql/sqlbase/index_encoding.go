@@ -131,6 +131,7 @@ func EncodePartialIndexKey(
 	copy(key, keyPrefix)
 
 	dirs := directions(index.ColumnDirections)
+	nulls := nullsOrders(index.ColumnNullsReversed)
 
 	if len(index.Interleave.Ancestors) > 0 {
 		for i, ancestor := range index.Interleave.Ancestors {
@@ -146,7 +147,7 @@ func EncodePartialIndexKey(
 				partial = true
 			}
 			var n bool
-			key, n, err = EncodeColumns(colIDs[:length], dirs[:length], colMap, values, key)
+			key, n, err = encodeColumnsWithNulls(colIDs[:length], dirs[:length], nulls, colMap, values, key)
 			if err != nil {
 				return nil, false, err
 			}
@@ -157,7 +158,7 @@ func EncodePartialIndexKey(
 				// that results in a more specific key.
 				return key, containsNull, nil
 			}
-			colIDs, dirs = colIDs[length:], dirs[length:]
+			colIDs, dirs, nulls = colIDs[length:], dirs[length:], nulls.skip(length)
 			// Each ancestor is separated by an interleaved
 			// sentinel (0xfe).
 			key = encoding.EncodeInterleavedSentinel(key)
@@ -167,7 +168,7 @@ func EncodePartialIndexKey(
 	}
 
 	var n bool
-	key, n, err = EncodeColumns(colIDs, dirs, colMap, values, key)
+	key, n, err = encodeColumnsWithNulls(colIDs, dirs, nulls, colMap, values, key)
 	if err != nil {
 		return nil, false, err
 	}
@@ -190,6 +191,23 @@ func (d directions) get(i int) (encoding.Direction, error) {
 	return encoding.Ascending, nil
 }
 
+// nullsOrders holds, for a sequence of index columns, whether each column
+// orders NULLs opposite to the default. It may be shorter than the sequence of
+// columns, in which case the remaining columns use the default NULL ordering.
+type nullsOrders []bool
+
+func (n nullsOrders) get(i int) bool {
+	return i < len(n) && n[i]
+}
+
+// skip returns the NULL orderings of the columns after the first i columns.
+func (n nullsOrders) skip(i int) nullsOrders {
+	if i >= len(n) {
+		return nil
+	}
+	return n[i:]
+}
+
 // MakeSpanFromEncDatums creates a minimal index key span on the input
 // values. A minimal index key span is a span that includes the fewest possible
 // keys after the start key generated by the input values.
@@ -408,6 +426,7 @@ func makeKeyFromEncDatums(
 	// so make it bigger from the get-go.
 	key := make(roachpb.Key, len(keyPrefix), len(keyPrefix)*2)
 	copy(key, keyPrefix)
+	nulls := nullsOrders(index.ColumnNullsReversed)
 
 	if len(index.Interleave.Ancestors) > 0 {
 		for i, ancestor := range index.Interleave.Ancestors {
@@ -426,7 +445,7 @@ func makeKeyFromEncDatums(
 				err error
 				n   bool
 			)
-			key, n, err = appendEncDatumsToKey(key, types[:length], values[:length], dirs[:length], alloc)
+			key, n, err = appendEncDatumsToKey(key, types[:length], values[:length], dirs[:length], nulls, alloc)
 			if err != nil {
 				return nil, false, false, err
 			}
@@ -436,7 +455,7 @@ func makeKeyFromEncDatums(
 				// left in the current interleave.
 				return key, false, false, nil
 			}
-			types, values, dirs = types[length:], values[length:], dirs[length:]
+			types, values, dirs, nulls = types[length:], values[length:], dirs[length:], nulls.skip(length)
 
 			// Each ancestor is separated by an interleaved
 			// sentinel (0xfe).
@@ -449,7 +468,7 @@ func makeKeyFromEncDatums(
 		err error
 		n   bool
 	)
-	key, n, err = appendEncDatumsToKey(key, types, values, dirs, alloc)
+	key, n, err = appendEncDatumsToKey(key, types, values, dirs, nulls, alloc)
 	if err != nil {
 		return key, false, false, err
 	}
@@ -475,6 +494,7 @@ func appendEncDatumsToKey(
 	types []*types.T,
 	values EncDatumRow,
 	dirs []IndexDescriptor_Direction,
+	nulls nullsOrders,
 	alloc *DatumAlloc,
 ) (_ roachpb.Key, containsNull bool, _ error) {
 	for i, val := range values {
@@ -482,10 +502,20 @@ func appendEncDatumsToKey(
 		if dirs[i] == IndexDescriptor_DESC {
 			encoding = DatumEncoding_DESCENDING_KEY
 		}
+		var err error
 		if val.IsNull() {
 			containsNull = true
+			if nulls.get(i) {
+				dir, err := dirs[i].ToEncodingDirection()
+				if err != nil {
+					return nil, false, err
+				}
+				if key, err = encodeReversedNullKey(key, dir); err != nil {
+					return nil, false, err
+				}
+				continue
+			}
 		}
-		var err error
 		key, err = val.Encode(types[i], alloc, encoding, key)
 		if err != nil {
 			return nil, false, err
@@ -876,9 +906,12 @@ func EncodeInvertedIndexTableKeys(val tree.Datum, inKey []byte) (key [][]byte, e
 // prefixed to all returned keys.
 // N.B.: This won't return any keys for
 func encodeArrayInvertedIndexTableKeys(val *tree.DArray, inKey []byte) (key [][]byte, err error) {
-	outKeys := make([][]byte, 0, len(val.Array))
-	for i := range val.Array {
-		d := val.Array[i]
+	// Multi-dimensional arrays are indexed by their innermost elements, which
+	// is what containment compares.
+	elements := val.Flatten()
+	outKeys := make([][]byte, 0, len(elements))
+	for i := range elements {
+		d := elements[i]
 		if d == tree.DNull {
 			// We don't need to make keys for NULL, since in SQL:
 			// SELECT ARRAY[1, NULL, 2] @> ARRAY[NULL]
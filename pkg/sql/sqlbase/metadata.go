@@ -51,6 +51,10 @@ func wrapDescriptor(descriptor protoutil.Message) *Descriptor {
 		desc.Union = &Descriptor_Type{Type: t}
 	case *SchemaDescriptor:
 		desc.Union = &Descriptor_Schema{Schema: t}
+	case *MutableFunctionDescriptor:
+		desc.Union = &Descriptor_Function{Function: &t.FunctionDescriptor}
+	case *FunctionDescriptor:
+		desc.Union = &Descriptor_Function{Function: t}
 	default:
 		panic(fmt.Sprintf("unknown descriptor type: %T", descriptor))
 	}
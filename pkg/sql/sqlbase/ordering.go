@@ -20,6 +20,23 @@ import (
 type ColumnOrderInfo struct {
 	ColIdx    int
 	Direction encoding.Direction
+	// NullsReversed is set if NULLs are ordered opposite to the default for the
+	// direction: after all other values when ascending, and before them when
+	// descending.
+	NullsReversed bool
+}
+
+// adjustCompare converts the result of comparing two values of the column
+// (where NULLs sort before all other values) to the result according to the
+// direction and NULL ordering of the column.
+func (c ColumnOrderInfo) adjustCompare(cmp int, lhsNull, rhsNull bool) int {
+	if c.NullsReversed && lhsNull != rhsNull {
+		cmp = -cmp
+	}
+	if c.Direction == encoding.Descending {
+		cmp = -cmp
+	}
+	return cmp
 }
 
 // ColumnOrdering is used to describe a desired column ordering. For example,
@@ -41,10 +58,7 @@ func CompareDatums(ordering ColumnOrdering, evalCtx *tree.EvalContext, lhs, rhs
 		// types for a column for different rows. Investigate how other RDBMs
 		// handle this.
 		if cmp := lhs[c.ColIdx].Compare(evalCtx, rhs[c.ColIdx]); cmp != 0 {
-			if c.Direction == encoding.Descending {
-				cmp = -cmp
-			}
-			return cmp
+			return c.adjustCompare(cmp, lhs[c.ColIdx] == tree.DNull, rhs[c.ColIdx] == tree.DNull)
 		}
 	}
 	return 0
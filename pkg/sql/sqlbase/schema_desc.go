@@ -96,6 +96,11 @@ func (desc *ImmutableSchemaDescriptor) TypeDesc() *TypeDescriptor {
 	return nil
 }
 
+// FunctionDesc implements the ObjectDescriptor interface.
+func (desc *ImmutableSchemaDescriptor) FunctionDesc() *FunctionDescriptor {
+	return nil
+}
+
 // DescriptorProto wraps a SchemaDescriptor in a Descriptor.
 func (desc *ImmutableSchemaDescriptor) DescriptorProto() *Descriptor {
 	return &Descriptor{
@@ -518,7 +518,8 @@ func (desc *IndexDescriptor) allocateName(tableDesc *MutableTableDescriptor) {
 func (desc *IndexDescriptor) FillColumns(elems tree.IndexElemList) error {
 	desc.ColumnNames = make([]string, 0, len(elems))
 	desc.ColumnDirections = make([]IndexDescriptor_Direction, 0, len(elems))
-	for _, c := range elems {
+	desc.ColumnNullsReversed = nil
+	for i, c := range elems {
 		desc.ColumnNames = append(desc.ColumnNames, string(c.Column))
 		switch c.Direction {
 		case tree.Ascending, tree.DefaultDirection:
@@ -528,10 +529,45 @@ func (desc *IndexDescriptor) FillColumns(elems tree.IndexElemList) error {
 		default:
 			return fmt.Errorf("invalid direction %s for column %s", c.Direction, c.Column)
 		}
+		if c.NullsOrder.IsReversed(c.Direction) {
+			if desc.ColumnNullsReversed == nil {
+				desc.ColumnNullsReversed = make([]bool, len(elems))
+			}
+			desc.ColumnNullsReversed[i] = true
+		}
 	}
 	return nil
 }
 
+// ColumnNullsReversedAt returns true if NULLs in the i-th column in ColumnIDs
+// are ordered opposite to the default for the column's direction.
+func (desc *IndexDescriptor) ColumnNullsReversedAt(i int) bool {
+	return i < len(desc.ColumnNullsReversed) && desc.ColumnNullsReversed[i]
+}
+
+// ColumnNullsOrder returns the NULL ordering of the i-th column in ColumnIDs,
+// as it would be written in an index definition.
+func (desc *IndexDescriptor) ColumnNullsOrder(i int) tree.NullsOrder {
+	if !desc.ColumnNullsReversedAt(i) {
+		return tree.DefaultNullsOrder
+	}
+	if desc.ColumnDirections[i] == IndexDescriptor_DESC {
+		return tree.NullsFirst
+	}
+	return tree.NullsLast
+}
+
+// HasReversedNulls returns true if any column of the index orders NULLs
+// opposite to the default for its direction.
+func (desc *IndexDescriptor) HasReversedNulls() bool {
+	for _, r := range desc.ColumnNullsReversed {
+		if r {
+			return true
+		}
+	}
+	return false
+}
+
 type returnTrue struct{}
 
 func (returnTrue) Error() string { panic("unimplemented") }
@@ -584,6 +620,10 @@ func (desc *IndexDescriptor) ColNamesFormat(ctx *tree.FmtCtx) {
 		if desc.Type != IndexDescriptor_INVERTED {
 			ctx.WriteByte(' ')
 			ctx.WriteString(desc.ColumnDirections[i].String())
+			if nullsOrder := desc.ColumnNullsOrder(i); nullsOrder != tree.DefaultNullsOrder {
+				ctx.WriteByte(' ')
+				ctx.WriteString(nullsOrder.String())
+			}
 		}
 	}
 }
@@ -696,6 +736,12 @@ func (desc *TableDescriptor) IsView() bool {
 	return desc.ViewQuery != ""
 }
 
+// MaterializedView returns true if the TableDescriptor describes a
+// materialized view, whose data is stored in the KV layer like a table's.
+func (desc *TableDescriptor) MaterializedView() bool {
+	return desc.IsMaterializedView
+}
+
 // IsAs returns true if the TableDescriptor actually describes
 // a Table resource with an As source.
 func (desc *TableDescriptor) IsAs() bool {
@@ -738,10 +784,11 @@ func IsVirtualTable(id ID) bool {
 // physical Table that needs to be stored in the kv layer, as opposed to a
 // different resource like a view or a virtual table. Physical tables have
 // primary keys, column families, and indexes (unlike virtual tables).
-// Sequences count as physical tables because their values are stored in
-// the KV layer.
+// Sequences and materialized views count as physical tables because their
+// values are stored in the KV layer.
 func (desc *TableDescriptor) IsPhysicalTable() bool {
-	return desc.IsSequence() || (desc.IsTable() && !desc.IsVirtualTable())
+	return desc.IsSequence() || (desc.IsTable() && !desc.IsVirtualTable()) ||
+		desc.MaterializedView()
 }
 
 // KeysPerRow returns the maximum number of keys used to encode a row for the
@@ -1246,8 +1293,9 @@ func (desc *MutableTableDescriptor) AllocateIDs() error {
 		}
 	}
 
-	// Only tables can have / need indexes and column families.
-	if desc.IsTable() {
+	// Only tables and materialized views can have / need indexes and column
+	// families.
+	if desc.IsTable() || desc.MaterializedView() {
 		if err := desc.allocateIndexIDs(columnNames); err != nil {
 			return err
 		}
@@ -1492,6 +1540,10 @@ func (desc *MutableTableDescriptor) allocateColumnFamilyIDs(columnNames map[stri
 		if _, ok := columnsInFamilies[col.ID]; ok {
 			return
 		}
+		if col.Virtual {
+			// Virtual columns are not stored, so they don't belong to any family.
+			return
+		}
 		if _, ok := primaryIndexColIDs[col.ID]; ok {
 			// Primary index columns are required to be assigned to family 0.
 			desc.Families[0].ColumnNames = append(desc.Families[0].ColumnNames, col.Name)
@@ -1878,6 +1930,11 @@ func (desc *TableDescriptor) ValidateTable() error {
 				return errors.AssertionFailedf(
 					"computed column swap mutation in state %s, direction %s", errors.Safe(m.State), errors.Safe(m.Direction))
 			}
+		case *DescriptorMutation_MaterializedViewRefresh:
+			if m.Direction == DescriptorMutation_NONE {
+				return errors.AssertionFailedf(
+					"materialized view refresh mutation in state %s, direction %s", errors.Safe(m.State), errors.Safe(m.Direction))
+			}
 		default:
 			return errors.AssertionFailedf(
 				"mutation in state %s, direction %s, and no column/index descriptor",
@@ -1964,6 +2021,18 @@ func (desc *TableDescriptor) validateColumnFamilies(columnIDs map[ColumnID]strin
 		return fmt.Errorf("the 0th family must have ID 0")
 	}
 
+	virtualColIDs := map[ColumnID]struct{}{}
+	for i := range desc.Columns {
+		if desc.Columns[i].Virtual {
+			virtualColIDs[desc.Columns[i].ID] = struct{}{}
+		}
+	}
+	for i := range desc.Mutations {
+		if col := desc.Mutations[i].GetColumn(); col != nil && col.Virtual {
+			virtualColIDs[col.ID] = struct{}{}
+		}
+	}
+
 	familyNames := map[string]struct{}{}
 	familyIDs := map[FamilyID]string{}
 	colIDToFamilyID := map[ColumnID]FamilyID{}
@@ -2012,6 +2081,10 @@ func (desc *TableDescriptor) validateColumnFamilies(columnIDs map[ColumnID]strin
 				return fmt.Errorf("family %q column %d should have name %q, but found name %q",
 					family.Name, colID, name, family.ColumnNames[i])
 			}
+			if _, ok := virtualColIDs[colID]; ok {
+				return pgerror.Newf(pgcode.InvalidTableDefinition,
+					"virtual column %q cannot be assigned to a column family", name)
+			}
 		}
 
 		for _, colID := range family.ColumnIDs {
@@ -2022,6 +2095,9 @@ func (desc *TableDescriptor) validateColumnFamilies(columnIDs map[ColumnID]strin
 		}
 	}
 	for colID := range columnIDs {
+		if _, ok := virtualColIDs[colID]; ok {
+			continue
+		}
 		if _, ok := colIDToFamilyID[colID]; !ok {
 			return fmt.Errorf("column %d is not in any column family", colID)
 		}
@@ -2081,6 +2157,11 @@ func (desc *TableDescriptor) validateTableIndexes(columnNames map[string]ColumnI
 			return fmt.Errorf("mismatched column IDs (%d) and directions (%d)",
 				len(index.ColumnIDs), len(index.ColumnDirections))
 		}
+		if len(index.ColumnNullsReversed) != 0 &&
+			len(index.ColumnIDs) != len(index.ColumnNullsReversed) {
+			return fmt.Errorf("mismatched column IDs (%d) and NULL orderings (%d)",
+				len(index.ColumnIDs), len(index.ColumnNullsReversed))
+		}
 
 		if len(index.ColumnIDs) == 0 {
 			return fmt.Errorf("index %q must contain at least 1 column", index.Name)
@@ -2101,6 +2182,14 @@ func (desc *TableDescriptor) validateTableIndexes(columnNames map[string]ColumnI
 			}
 			validateIndexDup[colID] = struct{}{}
 		}
+		for _, name := range index.StoreColumnNames {
+			if colID, ok := columnNames[name]; ok {
+				if col, err := desc.FindColumnByID(colID); err == nil && col.Virtual {
+					return pgerror.Newf(pgcode.InvalidTableDefinition,
+						"index %q cannot store virtual column %q", index.Name, name)
+				}
+			}
+		}
 		if index.IsSharded() {
 			if err := desc.ensureShardedIndexNotComputed(index); err != nil {
 				return err
@@ -2112,6 +2201,13 @@ func (desc *TableDescriptor) validateTableIndexes(columnNames map[string]ColumnI
 		}
 	}
 
+	for _, colID := range desc.PrimaryIndex.ColumnIDs {
+		if col, err := desc.FindColumnByID(colID); err == nil && col.Virtual {
+			return pgerror.Newf(pgcode.InvalidTableDefinition,
+				"virtual column %q cannot be part of the primary key", col.Name)
+		}
+	}
+
 	return nil
 }
 
@@ -3177,6 +3273,10 @@ func (desc *MutableTableDescriptor) MakeMutationComplete(m DescriptorMutation) e
 			primaryIndexCopy := protoutil.Clone(&desc.PrimaryIndex).(*IndexDescriptor)
 			primaryIndexCopy.EncodingType = PrimaryIndexEncoding
 			for _, col := range desc.Columns {
+				if col.Virtual {
+					// Virtual columns are not stored.
+					continue
+				}
 				containsCol := false
 				for _, colID := range primaryIndexCopy.ColumnIDs {
 					if colID == col.ID {
@@ -3240,6 +3340,11 @@ func (desc *MutableTableDescriptor) MakeMutationComplete(m DescriptorMutation) e
 			if err := desc.performComputedColumnSwap(t.ComputedColumnSwap); err != nil {
 				return err
 			}
+		case *DescriptorMutation_MaterializedViewRefresh:
+			// Swap in the new indexes, which now hold the refreshed data. The
+			// caller is responsible for cleaning up the data in the old indexes.
+			desc.PrimaryIndex = t.MaterializedViewRefresh.NewPrimaryIndex
+			desc.Indexes = t.MaterializedViewRefresh.NewIndexes
 		}
 
 	case DescriptorMutation_DROP:
@@ -3498,6 +3603,18 @@ func (desc *MutableTableDescriptor) AddComputedColumnSwapMutation(swap *Computed
 	desc.addMutation(m)
 }
 
+// AddMaterializedViewRefreshMutation adds a MaterializedViewRefresh mutation
+// to the table descriptor.
+func (desc *MutableTableDescriptor) AddMaterializedViewRefreshMutation(
+	refresh *MaterializedViewRefresh,
+) {
+	m := DescriptorMutation{
+		Descriptor_: &DescriptorMutation_MaterializedViewRefresh{MaterializedViewRefresh: refresh},
+		Direction:   DescriptorMutation_ADD,
+	}
+	desc.addMutation(m)
+}
+
 func (desc *MutableTableDescriptor) addMutation(m DescriptorMutation) {
 	switch m.Direction {
 	case DescriptorMutation_ADD:
@@ -3678,6 +3795,8 @@ func (desc *Descriptor) GetID() ID {
 		return t.Type.ID
 	case *Descriptor_Schema:
 		return t.Schema.ID
+	case *Descriptor_Function:
+		return t.Function.ID
 	default:
 		panic(errors.AssertionFailedf("GetID: unknown Descriptor type %T", t))
 	}
@@ -3694,6 +3813,8 @@ func (desc *Descriptor) GetName() string {
 		return t.Type.Name
 	case *Descriptor_Schema:
 		return t.Schema.Name
+	case *Descriptor_Function:
+		return t.Function.Name
 	default:
 		panic(errors.AssertionFailedf("GetName: unknown Descriptor type %T", t))
 	}
@@ -3710,6 +3831,8 @@ func (desc *Descriptor) GetVersion() DescriptorVersion {
 		return t.Type.Version
 	case *Descriptor_Schema:
 		return t.Schema.Version
+	case *Descriptor_Function:
+		return t.Function.Version
 	default:
 		panic(errors.AssertionFailedf("GetVersion: unknown Descriptor type %T", t))
 	}
@@ -3726,6 +3849,8 @@ func (desc *Descriptor) GetModificationTime() hlc.Timestamp {
 		return t.Type.ModificationTime
 	case *Descriptor_Schema:
 		return t.Schema.ModificationTime
+	case *Descriptor_Function:
+		return t.Function.ModificationTime
 	default:
 		debug.PrintStack()
 		panic(errors.AssertionFailedf("GetModificationTime: unknown Descriptor type %T", t))
@@ -3743,6 +3868,8 @@ func (desc *Descriptor) setModificationTime(ts hlc.Timestamp) {
 		t.Type.ModificationTime = ts
 	case *Descriptor_Schema:
 		t.Schema.ModificationTime = ts
+	case *Descriptor_Function:
+		t.Function.ModificationTime = ts
 	default:
 		panic(errors.AssertionFailedf("setModificationTime: unknown Descriptor type %T", t))
 	}
@@ -3790,10 +3917,11 @@ func (desc *Descriptor) MaybeSetModificationTimeFromMVCCTimestamp(
 
 		// Ensure that if the table is in the process of being added and relies on
 		// CreateAsOfTime that it is now set.
-		if t.Table.Adding() && t.Table.IsAs() && t.Table.CreateAsOfTime.IsEmpty() {
+		if t.Table.Adding() && (t.Table.IsAs() || t.Table.MaterializedView()) &&
+			t.Table.CreateAsOfTime.IsEmpty() {
 			log.Fatalf(context.TODO(), "table descriptor for %q (%d.%d) is in the "+
-				"ADD state and was created with CREATE TABLE ... AS but does not have a "+
-				"CreateAsOfTime set", t.Table.Name, t.Table.ParentID, t.Table.ID)
+				"ADD state and was created with CREATE TABLE ... AS or CREATE MATERIALIZED "+
+				"VIEW but does not have a CreateAsOfTime set", t.Table.Name, t.Table.ParentID, t.Table.ID)
 		}
 	}
 	// Set the ModificationTime based on the passed ts if we should.
@@ -3899,7 +4027,11 @@ func (desc *ColumnDescriptor) SQLString() string {
 	if desc.IsComputed() {
 		f.WriteString(" AS (")
 		f.WriteString(*desc.ComputeExpr)
-		f.WriteString(") STORED")
+		if desc.Virtual {
+			f.WriteString(") VIRTUAL")
+		} else {
+			f.WriteString(") STORED")
+		}
 	}
 	return f.CloseAndGetString()
 }
@@ -4073,24 +4205,20 @@ func (desc *ColumnDescriptor) DatumType() *types.T {
 
 // ColTypePrecision is part of the cat.Column interface.
 func (desc *ColumnDescriptor) ColTypePrecision() int {
-	if desc.Type.Family() == types.ArrayFamily {
-		if desc.Type.ArrayContents().Family() == types.ArrayFamily {
-			panic(errors.AssertionFailedf("column type should never be a nested array"))
-		}
-		return int(desc.Type.ArrayContents().Precision())
+	typ := desc.Type
+	for typ.Family() == types.ArrayFamily {
+		typ = typ.ArrayContents()
 	}
-	return int(desc.Type.Precision())
+	return int(typ.Precision())
 }
 
 // ColTypeWidth is part of the cat.Column interface.
 func (desc *ColumnDescriptor) ColTypeWidth() int {
-	if desc.Type.Family() == types.ArrayFamily {
-		if desc.Type.ArrayContents().Family() == types.ArrayFamily {
-			panic(errors.AssertionFailedf("column type should never be a nested array"))
-		}
-		return int(desc.Type.ArrayContents().Width())
+	typ := desc.Type
+	for typ.Family() == types.ArrayFamily {
+		typ = typ.ArrayContents()
 	}
-	return int(desc.Type.Width())
+	return int(typ.Width())
 }
 
 // ColTypeStr is part of the cat.Column interface.
@@ -4113,6 +4241,11 @@ func (desc *ColumnDescriptor) IsComputed() bool {
 	return desc.ComputeExpr != nil
 }
 
+// IsVirtualComputed is part of the cat.Column interface.
+func (desc *ColumnDescriptor) IsVirtualComputed() bool {
+	return desc.Virtual
+}
+
 // DefaultExprStr is part of the cat.Column interface.
 func (desc *ColumnDescriptor) DefaultExprStr() string {
 	return *desc.DefaultExpr
@@ -4294,6 +4427,11 @@ func (desc *MutableTableDescriptor) TypeDesc() *TypeDescriptor {
 	return nil
 }
 
+// FunctionDesc implements the ObjectDescriptor interface.
+func (desc *MutableTableDescriptor) FunctionDesc() *FunctionDescriptor {
+	return nil
+}
+
 // DatabaseDesc implements the ObjectDescriptor interface.
 func (desc *ImmutableTableDescriptor) DatabaseDesc() *DatabaseDescriptor {
 	return nil
@@ -4314,6 +4452,11 @@ func (desc *ImmutableTableDescriptor) TypeDesc() *TypeDescriptor {
 	return nil
 }
 
+// FunctionDesc implements the ObjectDescriptor interface.
+func (desc *ImmutableTableDescriptor) FunctionDesc() *FunctionDescriptor {
+	return nil
+}
+
 // DatabaseKey implements DescriptorKey.
 type DatabaseKey struct {
 	name string
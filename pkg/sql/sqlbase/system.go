@@ -43,6 +43,8 @@ func ShouldSplitAtDesc(rawDesc *roachpb.Value) bool {
 		return false
 	case *Descriptor_Schema:
 		return false
+	case *Descriptor_Function:
+		return false
 	default:
 		panic(fmt.Sprintf("unexpected descriptor type %#v", &desc))
 	}
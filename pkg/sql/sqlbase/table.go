@@ -50,8 +50,10 @@ func SanitizeVarFreeExpr(
 	// which uses the properties field.
 	defer semaCtx.Properties.Restore(semaCtx.Properties)
 
-	// Ensure that the expression doesn't contain special functions.
-	flags := tree.RejectSpecial
+	// Ensure that the expression doesn't contain special functions. The bodies
+	// of user-defined functions are only inlined by the optimizer, so they
+	// cannot be stored in expressions evaluated outside of it.
+	flags := tree.RejectSpecial | tree.RejectUserDefinedFunctions
 	if !allowImpure {
 		flags |= tree.RejectImpureFunctions
 	}
@@ -94,10 +96,6 @@ func ValidateColumnDefType(t *types.T) error {
 		}
 
 	case types.ArrayFamily:
-		if t.ArrayContents().Family() == types.ArrayFamily {
-			// Nested arrays are not supported as a column type.
-			return errors.Errorf("nested array unsupported as column type: %s", t.String())
-		}
 		if err := types.CheckArrayElementType(t.ArrayContents()); err != nil {
 			return err
 		}
@@ -106,9 +104,21 @@ func ValidateColumnDefType(t *types.T) error {
 	case types.BitFamily, types.IntFamily, types.FloatFamily, types.BoolFamily, types.BytesFamily, types.DateFamily,
 		types.INetFamily, types.IntervalFamily, types.JsonFamily, types.OidFamily, types.TimeFamily,
 		types.TimestampFamily, types.TimestampTZFamily, types.UuidFamily, types.TimeTZFamily,
-		types.GeographyFamily, types.GeometryFamily, types.EnumFamily:
+		types.GeographyFamily, types.GeometryFamily, types.EnumFamily, types.RangeFamily:
 		// These types are OK.
 
+	case types.TupleFamily:
+		// Only composite types may be used for columns; anonymous tuples may not.
+		if !t.UserDefined() {
+			return pgerror.Newf(pgcode.InvalidTableDefinition,
+				"value type %s cannot be used for table columns", t.String())
+		}
+		for _, fieldTyp := range t.TupleContents() {
+			if err := ValidateColumnDefType(fieldTyp); err != nil {
+				return err
+			}
+		}
+
 	default:
 		return pgerror.Newf(pgcode.InvalidTableDefinition,
 			"value type %s cannot be used for table columns", t.String())
@@ -192,6 +202,17 @@ func MakeColumnDefDescs(
 	if d.IsComputed() {
 		s := tree.Serialize(d.Computed.Expr)
 		col.ComputeExpr = &s
+		if d.Computed.Virtual {
+			if d.PrimaryKey.IsPrimaryKey {
+				return nil, nil, nil, pgerror.Newf(pgcode.InvalidTableDefinition,
+					"virtual column %q cannot be part of the primary key", d.Name)
+			}
+			if d.HasColumnFamily() {
+				return nil, nil, nil, pgerror.Newf(pgcode.InvalidTableDefinition,
+					"virtual column %q cannot be assigned to a column family", d.Name)
+			}
+			col.Virtual = true
+		}
 	}
 
 	var idx *IndexDescriptor
@@ -271,6 +292,20 @@ func EncodeColumns(
 	colMap map[ColumnID]int,
 	values []tree.Datum,
 	keyPrefix []byte,
+) (key []byte, containsNull bool, err error) {
+	return encodeColumnsWithNulls(columnIDs, directions, nil /* nullsReversed */, colMap, values, keyPrefix)
+}
+
+// encodeColumnsWithNulls is like EncodeColumns, but additionally takes the
+// NULL ordering of each column. If nullsReversed is shorter than columnIDs, the
+// remaining columns use the default NULL ordering.
+func encodeColumnsWithNulls(
+	columnIDs []ColumnID,
+	directions directions,
+	nullsReversed nullsOrders,
+	colMap map[ColumnID]int,
+	values []tree.Datum,
+	keyPrefix []byte,
 ) (key []byte, containsNull bool, err error) {
 	key = keyPrefix
 	for colIdx, id := range columnIDs {
@@ -284,7 +319,7 @@ func EncodeColumns(
 			return nil, containsNull, err
 		}
 
-		if key, err = EncodeTableKey(key, val, dir); err != nil {
+		if key, err = EncodeTableKeyWithNulls(key, val, dir, nullsReversed.get(colIdx)); err != nil {
 			return nil, containsNull, err
 		}
 	}
@@ -224,11 +224,11 @@ func RandDatumWithNullChance(rng *rand.Rand, typ *types.T, nullChance int) tree.
 		}
 		return &tree.DJSON{JSON: j}
 	case types.TupleFamily:
-		tuple := tree.DTuple{D: make(tree.Datums, len(typ.TupleContents()))}
+		datums := make(tree.Datums, len(typ.TupleContents()))
 		for i := range typ.TupleContents() {
-			tuple.D[i] = RandDatum(rng, typ.TupleContents()[i], true)
+			datums[i] = RandDatum(rng, typ.TupleContents()[i], true)
 		}
-		return &tuple
+		return tree.NewDTuple(typ, datums...)
 	case types.BitFamily:
 		width := typ.Width()
 		if width == 0 {
@@ -285,11 +285,41 @@ func RandDatumWithNullChance(rng *rand.Rand, typ *types.T, nullChance int) tree.
 	case types.EnumFamily:
 		// We don't yet have the ability to generate random user defined types.
 		return tree.DNull
+	case types.RangeFamily:
+		return randRange(rng, typ)
 	default:
 		panic(fmt.Sprintf("invalid type %v", typ.DebugString()))
 	}
 }
 
+// randRange generates a random DRange of the given range type. Each bound has
+// a chance of being unbounded.
+func randRange(rng *rand.Rand, typ *types.T) tree.Datum {
+	if rng.Intn(10) == 0 {
+		return tree.NewEmptyDRange(typ)
+	}
+	for {
+		lower := tree.RangeBound{Inclusive: rng.Intn(2) == 0}
+		upper := tree.RangeBound{Inclusive: rng.Intn(2) == 0}
+		if rng.Intn(5) != 0 {
+			lower.Val = RandDatum(rng, typ.RangeContents(), false /* nullOk */)
+		}
+		if rng.Intn(5) != 0 {
+			upper.Val = RandDatum(rng, typ.RangeContents(), false /* nullOk */)
+		}
+		if r, err := tree.NewDRange(typ, lower, upper); err == nil {
+			return r
+		}
+		// The lower bound was greater than the upper bound.
+		lower.Val, upper.Val = upper.Val, lower.Val
+		if r, err := tree.NewDRange(typ, lower, upper); err == nil {
+			return r
+		}
+		// Canonicalizing a discrete range can fail if a bound is the largest
+		// value of its type, in which case we try again.
+	}
+}
+
 // RandArray generates a random DArray where the contents have nullChance
 // of being null.
 func RandArray(rng *rand.Rand, typ *types.T, nullChance int) tree.Datum {
@@ -297,9 +327,25 @@ func RandArray(rng *rand.Rand, typ *types.T, nullChance int) tree.Datum {
 	if contents.Family() == types.AnyFamily {
 		contents = RandArrayContentsType(rng)
 	}
+	// All sub-arrays of a multi-dimensional array must have the same
+	// dimensions, so pick them up front.
+	dims := []int{rng.Intn(10)}
+	for t := contents; t.Family() == types.ArrayFamily; t = t.ArrayContents() {
+		dims = append(dims, rng.Intn(5))
+	}
+	return randArrayWithDims(rng, contents, dims, nullChance)
+}
+
+func randArrayWithDims(rng *rand.Rand, contents *types.T, dims []int, nullChance int) *tree.DArray {
 	arr := tree.NewDArray(contents)
-	for i := 0; i < rng.Intn(10); i++ {
-		if err := arr.Append(RandDatumWithNullChance(rng, contents, nullChance)); err != nil {
+	for i := 0; i < dims[0]; i++ {
+		var d tree.Datum
+		if len(dims) > 1 {
+			d = randArrayWithDims(rng, contents.ArrayContents(), dims[1:], nullChance)
+		} else {
+			d = RandDatumWithNullChance(rng, contents, nullChance)
+		}
+		if err := arr.Append(d); err != nil {
 			panic(err)
 		}
 	}
@@ -1258,6 +1304,10 @@ func ColumnFamilyMutator(rng *rand.Rand, stmt tree.Statement) (changed bool) {
 			if def.HasColumnFamily() {
 				return false
 			}
+			// Virtual computed columns don't belong to any family.
+			if def.IsComputed() && def.Computed.Virtual {
+				continue
+			}
 			columns = append(columns, def.Name)
 		}
 	}
@@ -1308,7 +1358,10 @@ func IndexStoringMutator(rng *rand.Rand, stmts []tree.Statement) ([]tree.Stateme
 		for _, def := range ct.Defs {
 			switch ast := def.(type) {
 			case *tree.ColumnTableDef:
-				columnNames = append(columnNames, ast.Name)
+				// Virtual computed columns cannot be stored in indexes.
+				if !(ast.IsComputed() && ast.Computed.Virtual) {
+					columnNames = append(columnNames, ast.Name)
+				}
 				if ast.PrimaryKey.IsPrimaryKey {
 					pkCols = []tree.Name{ast.Name}
 				}
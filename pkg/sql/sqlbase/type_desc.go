@@ -152,6 +152,11 @@ func (desc *ImmutableTypeDescriptor) TypeDesc() *TypeDescriptor {
 	return &desc.TypeDescriptor
 }
 
+// FunctionDesc implements the ObjectDescriptor interface.
+func (desc *ImmutableTypeDescriptor) FunctionDesc() *FunctionDescriptor {
+	return nil
+}
+
 // GetAuditMode implements the DescriptorProto interface.
 func (desc *ImmutableTypeDescriptor) GetAuditMode() TableDescriptor_AuditMode {
 	return TableDescriptor_DISABLED
@@ -180,6 +185,18 @@ func (desc *ImmutableTypeDescriptor) MakeTypesT(
 			return nil, err
 		}
 		return typ, nil
+	case TypeDescriptor_COMPOSITE:
+		contents := make([]*types.T, len(desc.CompositeFields))
+		labels := make([]string, len(desc.CompositeFields))
+		for i := range desc.CompositeFields {
+			contents[i] = desc.CompositeFields[i].Type
+			labels[i] = desc.CompositeFields[i].Name
+		}
+		typ := types.MakeComposite(uint32(desc.GetID()), uint32(desc.ArrayTypeID), contents, labels)
+		if err := desc.HydrateTypeInfoWithName(typ, name, typeLookup); err != nil {
+			return nil, err
+		}
+		return typ, nil
 	case TypeDescriptor_ALIAS:
 		// Hydrate the alias and return it.
 		if err := desc.HydrateTypeInfoWithName(desc.Alias, name, typeLookup); err != nil {
@@ -258,6 +275,24 @@ func (desc *ImmutableTypeDescriptor) HydrateTypeInfoWithName(
 			PhysicalRepresentations: physical,
 		}
 		return nil
+	case TypeDescriptor_COMPOSITE:
+		if typ.Family() != types.TupleFamily {
+			return errors.New("cannot hydrate a non-tuple type with a composite type descriptor")
+		}
+		// Hydrate the user defined field types.
+		for _, fieldTyp := range typ.TupleContents() {
+			if !fieldTyp.UserDefined() {
+				continue
+			}
+			fieldTypName, fieldTypDesc, err := typeLookup(ID(fieldTyp.StableTypeID()))
+			if err != nil {
+				return err
+			}
+			if err := fieldTypDesc.HydrateTypeInfoWithName(fieldTyp, fieldTypName, typeLookup); err != nil {
+				return err
+			}
+		}
+		return nil
 	case TypeDescriptor_ALIAS:
 		if typ.UserDefined() {
 			switch typ.Family() {
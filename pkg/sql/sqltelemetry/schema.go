@@ -46,6 +46,10 @@ var (
 	// CreateTempViewCounter is to be incremented every time a TEMP VIEW
 	// has been created.
 	CreateTempViewCounter = telemetry.GetCounterOnce("sql.schema.create_temp_view")
+
+	// RefreshMaterializedViewCounter is to be incremented every time a
+	// materialized view is refreshed.
+	RefreshMaterializedViewCounter = telemetry.GetCounterOnce("sql.schema.refresh_materialized_view")
 )
 
 var (
@@ -31,3 +31,6 @@ var ForceSavepointRestartCounter = telemetry.GetCounterOnce("sql.force_savepoint
 func UnimplementedSessionVarValueCounter(varName, val string) telemetry.Counter {
 	return telemetry.GetCounter(fmt.Sprintf("unimplemented.sql.session_var.%s.%s", varName, val))
 }
+
+// DeclareCursorCounter is to be incremented every time a cursor is declared.
+var DeclareCursorCounter = telemetry.GetCounterOnce("sql.cursor.declare")
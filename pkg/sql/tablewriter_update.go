@@ -46,12 +46,19 @@ func (tu *tableUpdater) row(context.Context, tree.Datums, util.FastIntSet, bool)
 	panic("unimplemented")
 }
 
-// rowForUpdate extends row() from the tableWriter interface.
+// rowForUpdate extends row() from the tableWriter interface. No index entries
+// are written for the updated row in the indexes in ignoreIndexPuts, and none
+// are deleted for the existing row in the indexes in ignoreIndexDels.
 func (tu *tableUpdater) rowForUpdate(
-	ctx context.Context, oldValues, updateValues tree.Datums, traceKV bool,
+	ctx context.Context,
+	oldValues, updateValues tree.Datums,
+	ignoreIndexPuts, ignoreIndexDels util.FastIntSet,
+	traceKV bool,
 ) (tree.Datums, error) {
 	tu.batchSize++
-	return tu.ru.UpdateRow(ctx, tu.b, oldValues, updateValues, row.CheckFKs, traceKV)
+	return tu.ru.UpdateRowWithIgnoredIndexes(
+		ctx, tu.b, oldValues, updateValues, ignoreIndexPuts, ignoreIndexDels, row.CheckFKs, traceKV,
+	)
 }
 
 // atBatchEnd is part of the tableWriter interface.
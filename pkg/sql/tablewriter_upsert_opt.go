@@ -247,11 +247,24 @@ func (tu *optTableUpserter) makeResultFromRow(
 // desc is part of the tableWriter interface.
 func (*optTableUpserter) desc() string { return "opt upserter" }
 
-// row is part of the tableWriter interface.
-// TODO(mgartner): Use ignoreIndexes to avoid writing to partial indexes when
-// the row does not match the partial index predicate.
+// row is part of the tableWriter interface. The upserted row is not written to
+// the indexes in ignoreIndexes. Existing rows are assumed to have entries in
+// all indexes; use upsertRow to specify otherwise.
 func (tu *optTableUpserter) row(
 	ctx context.Context, row tree.Datums, ignoreIndexes util.FastIntSet, traceKV bool,
+) error {
+	return tu.upsertRow(ctx, row, ignoreIndexes, util.FastIntSet{} /* ignoreIndexDels */, traceKV)
+}
+
+// upsertRow inserts the given row or updates the conflicting existing row. The
+// ignoreIndexPuts parameter is the set of IDs of partial indexes that the
+// upserted row should not be written to, and ignoreIndexDels is the set of IDs
+// of partial indexes in which the existing row has no entry to delete.
+func (tu *optTableUpserter) upsertRow(
+	ctx context.Context,
+	row tree.Datums,
+	ignoreIndexPuts, ignoreIndexDels util.FastIntSet,
+	traceKV bool,
 ) error {
 	tu.batchSize++
 	tu.resultCount++
@@ -263,11 +276,15 @@ func (tu *optTableUpserter) row(
 	if tu.canaryOrdinal == -1 {
 		// No canary column means that existing row should be overwritten (i.e.
 		// the insert and update columns are the same, so no need to choose).
-		return tu.insertNonConflictingRow(ctx, tu.b, row[:insertEnd], true /* overwrite */, traceKV)
+		return tu.insertNonConflictingRow(
+			ctx, tu.b, row[:insertEnd], ignoreIndexPuts, true /* overwrite */, traceKV,
+		)
 	}
 	if row[tu.canaryOrdinal] == tree.DNull {
 		// No conflict, so insert a new row.
-		return tu.insertNonConflictingRow(ctx, tu.b, row[:insertEnd], false /* overwrite */, traceKV)
+		return tu.insertNonConflictingRow(
+			ctx, tu.b, row[:insertEnd], ignoreIndexPuts, false /* overwrite */, traceKV,
+		)
 	}
 
 	// If no columns need to be updated, then possibly collect the unchanged row.
@@ -287,6 +304,8 @@ func (tu *optTableUpserter) row(
 		tu.b,
 		row[insertEnd:fetchEnd],
 		row[fetchEnd:updateEnd],
+		ignoreIndexPuts,
+		ignoreIndexDels,
 		tu.tableDesc(),
 		traceKV,
 	)
@@ -300,14 +319,16 @@ func (tu *optTableUpserter) atBatchEnd(ctx context.Context, traceKV bool) error
 
 // insertNonConflictingRow inserts the given source row into the table when
 // there was no conflict. If the RETURNING clause was specified, then the
-// inserted row is stored in the rowsUpserted collection.
+// inserted row is stored in the rowsUpserted collection. No index entries are
+// written for the indexes in ignoreIndexes.
 func (tu *optTableUpserter) insertNonConflictingRow(
-	ctx context.Context, b *kv.Batch, insertRow tree.Datums, overwrite, traceKV bool,
+	ctx context.Context,
+	b *kv.Batch,
+	insertRow tree.Datums,
+	ignoreIndexes util.FastIntSet,
+	overwrite, traceKV bool,
 ) error {
 	// Perform the insert proper.
-	// TODO(mgartner): Pass ignoreIndexes to InsertRow and do not write index
-	// entries for indexes in the set.
-	var ignoreIndexes util.FastIntSet
 	if err := tu.ri.InsertRow(ctx, b, insertRow, ignoreIndexes, overwrite, row.CheckFKs, traceKV); err != nil {
 		return err
 	}
@@ -347,12 +368,14 @@ func (tu *optTableUpserter) insertNonConflictingRow(
 // updated values are provided in updateValues. The updater is assumed to
 // already be initialized with the descriptors for the fetch and update values.
 // If the RETURNING clause was specified, then the updated row is stored in the
-// rowsUpserted collection.
+// rowsUpserted collection. See upsertRow for the meaning of ignoreIndexPuts and
+// ignoreIndexDels.
 func (tu *optTableUpserter) updateConflictingRow(
 	ctx context.Context,
 	b *kv.Batch,
 	fetchRow tree.Datums,
 	updateValues tree.Datums,
+	ignoreIndexPuts, ignoreIndexDels util.FastIntSet,
 	tableDesc *sqlbase.ImmutableTableDescriptor,
 	traceKV bool,
 ) error {
@@ -370,7 +393,9 @@ func (tu *optTableUpserter) updateConflictingRow(
 	// Queue the update in KV. This also returns an "update row"
 	// containing the updated values for every column in the
 	// table. This is useful for RETURNING, which we collect below.
-	_, err := tu.ru.UpdateRow(ctx, b, fetchRow, updateValues, row.CheckFKs, traceKV)
+	_, err := tu.ru.UpdateRowWithIgnoredIndexes(
+		ctx, b, fetchRow, updateValues, ignoreIndexPuts, ignoreIndexDels, row.CheckFKs, traceKV,
+	)
 	if err != nil {
 		return err
 	}
@@ -157,6 +157,17 @@ import (
 // When these types are themselves made into arrays, the Oids become T__int2vector and
 // T__oidvector, respectively.
 //
+// Range types
+// -----------
+//
+// | SQL type          | Family         | Oid           | RangeContents |
+// |-------------------|----------------|---------------|---------------|
+// | INT4RANGE         | RANGE          | T_int4range   | Int4          |
+// | INT8RANGE         | RANGE          | T_int8range   | Int           |
+// | TSRANGE           | RANGE          | T_tsrange     | Timestamp     |
+// | TSTZRANGE         | RANGE          | T_tstzrange   | TimestampTZ   |
+// | DATERANGE         | RANGE          | T_daterange   | Date          |
+//
 // User defined types
 // ------------------
 //
@@ -433,6 +444,36 @@ var (
 		},
 	}
 
+	// Int4Range is the type of a range of Int4 values.
+	Int4Range = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_int4range, Locale: &emptyLocale}}
+
+	// Int8Range is the type of a range of Int values.
+	Int8Range = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_int8range, Locale: &emptyLocale}}
+
+	// TimestampRange is the type of a range of Timestamp values.
+	TimestampRange = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_tsrange, Locale: &emptyLocale}}
+
+	// TimestampTZRange is the type of a range of TimestampTZ values.
+	TimestampTZRange = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_tstzrange, Locale: &emptyLocale}}
+
+	// DateRange is the type of a range of Date values.
+	DateRange = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_daterange, Locale: &emptyLocale}}
+
+	// Ranges contains one range type for each distinct (non-Equivalent) range
+	// subtype. INT4RANGE is omitted since it is Equivalent to INT8RANGE. It is
+	// useful for defining operators and builtins over all range types.
+	Ranges = []*T{
+		Int8Range,
+		TimestampRange,
+		TimestampTZRange,
+		DateRange,
+	}
+
 	// Scalar contains all types that meet this criteria:
 	//
 	//   1. Scalar type (no ArrayFamily or TupleFamily types).
@@ -986,6 +1027,24 @@ func MakeEnum(typeID, arrayTypeID uint32) *T {
 	}}
 }
 
+// MakeComposite constructs a new instance of a TupleFamily type that is backed
+// by the composite type descriptor with the given stable type ID. Unlike enums,
+// the field types and labels are stored in the type itself so that values can
+// be encoded and decoded without hydrating the type.
+func MakeComposite(typeID, arrayTypeID uint32, contents []*T, labels []string) *T {
+	return &T{InternalType: InternalType{
+		Family:        TupleFamily,
+		Oid:           StableTypeIDToOID(typeID),
+		TupleContents: contents,
+		TupleLabels:   labels,
+		Locale:        &emptyLocale,
+		UDTMetadata: &PersistentUserDefinedTypeMetadata{
+			StableTypeID:      typeID,
+			StableArrayTypeID: arrayTypeID,
+		},
+	}}
+}
+
 // MakeArray constructs a new instance of an ArrayFamily type with the given
 // element type (which may itself be an ArrayFamily type).
 func MakeArray(typ *T) *T {
@@ -1155,6 +1214,25 @@ func (t *T) ArrayContents() *T {
 	return t.InternalType.ArrayContents
 }
 
+// RangeContents returns the type of the bounds of a range (the "subtype" of
+// the range in Postgres terminology). This is nil for types that are not in
+// the RangeFamily.
+func (t *T) RangeContents() *T {
+	if t.Family() != RangeFamily {
+		return nil
+	}
+	return rangeContents[t.Oid()]
+}
+
+// rangeContents maps the Oid of each range type to its subtype.
+var rangeContents = map[oid.Oid]*T{
+	oid.T_int4range: Int4,
+	oid.T_int8range: Int,
+	oid.T_tsrange:   Timestamp,
+	oid.T_tstzrange: TimestampTZ,
+	oid.T_daterange: Date,
+}
+
 // TupleContents returns a slice containing the type of each tuple field. This
 // is nil for non-TupleFamily types.
 func (t *T) TupleContents() []*T {
@@ -1204,6 +1282,7 @@ var familyNames = map[Family]string{
 	IntervalFamily:       "interval",
 	JsonFamily:           "jsonb",
 	OidFamily:            "oid",
+	RangeFamily:          "range",
 	StringFamily:         "string",
 	TimeFamily:           "time",
 	TimestampFamily:      "timestamp",
@@ -1294,7 +1373,14 @@ func (t *T) Name() string {
 		panic(errors.AssertionFailedf("unexpected OID: %d", t.Oid()))
 
 	case TupleFamily:
-		// Tuple types are currently anonymous, with no name.
+		if t.UserDefined() {
+			// This can be nil during unit testing.
+			if t.TypeMeta.Name == nil {
+				return "unknown_composite"
+			}
+			return t.TypeMeta.Name.Basename()
+		}
+		// Other tuple types are anonymous, with no name.
 		return ""
 
 	case EnumFamily:
@@ -1307,6 +1393,9 @@ func (t *T) Name() string {
 		}
 		return t.TypeMeta.Name.Basename()
 
+	case RangeFamily:
+		return t.PGName()
+
 	default:
 		return fam.Name()
 	}
@@ -1524,7 +1613,12 @@ func (t *T) SQLStandardNameWithTypmod(haveTypmod bool, typmod int) string {
 		}
 		return fmt.Sprintf("timestamp(%d) with time zone", typmod)
 	case TupleFamily:
+		if t.UserDefined() {
+			return t.TypeMeta.Name.FQName()
+		}
 		return "record"
+	case RangeFamily:
+		return t.PGName()
 	case UnknownFamily:
 		return "unknown"
 	case UuidFamily:
@@ -1647,6 +1741,10 @@ func (t *T) SQLString() string {
 			return "anyenum"
 		}
 		return t.TypeMeta.Name.FQName()
+	case TupleFamily:
+		if t.UserDefined() {
+			return t.TypeMeta.Name.FQName()
+		}
 	}
 	return strings.ToUpper(t.Name())
 }
@@ -1697,6 +1795,11 @@ func (t *T) Equivalent(other *T) bool {
 			return false
 		}
 
+	case RangeFamily:
+		if !t.RangeContents().Equivalent(other.RangeContents()) {
+			return false
+		}
+
 	case EnumFamily:
 		// If one of the types is anyenum, then allow the comparison to
 		// go through -- anyenum is used when matching overloads.
@@ -2024,11 +2127,6 @@ func (t *T) upgradeType() error {
 			t.InternalType.Oid = calcArrayOid(t.ArrayContents())
 		}
 
-		// Marshaling/unmarshaling nested arrays is not yet supported.
-		if t.ArrayContents().Family() == ArrayFamily {
-			return errors.AssertionFailedf("nested array should never be unmarshaled")
-		}
-
 		// Zero out fields that may have been used to store information about
 		// the array element type, or which are no longer in use.
 		t.InternalType.Width = 0
@@ -2145,11 +2243,6 @@ func (t *T) downgradeType() error {
 		}
 
 	case ArrayFamily:
-		// Marshaling/unmarshaling nested arrays is not yet supported.
-		if t.ArrayContents().Family() == ArrayFamily {
-			return errors.AssertionFailedf("nested array should never be marshaled")
-		}
-
 		// Downgrade to array representation used before 19.2, in which the array
 		// type fields specified the width, locale, etc. of the element type.
 		temp := *t.InternalType.ArrayContents
@@ -2201,6 +2294,9 @@ func (t *T) String() string {
 		return t.ArrayContents().String() + "[]"
 
 	case TupleFamily:
+		if t.UserDefined() {
+			return t.Name()
+		}
 		var buf bytes.Buffer
 		buf.WriteString("tuple")
 		if len(t.TupleContents()) != 0 && !IsWildcardTupleType(t) {
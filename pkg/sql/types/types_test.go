@@ -519,13 +519,7 @@ func TestTypes(t *testing.T) {
 				t.Errorf("expected <%v>, got <%v>", tc.expected.DebugString(), tc.actual.DebugString())
 			}
 
-			// Roundtrip type by marshaling, then unmarshaling. Only do this for non-
-			// nested array types (since we don't yet support marshaling/ummarshaling
-			// nested arrays).
-			if tc.actual.Family() == ArrayFamily && tc.actual.ArrayContents().Family() == ArrayFamily {
-				return
-			}
-
+			// Roundtrip type by marshaling, then unmarshaling.
 			data, err := protoutil.Marshal(tc.actual)
 			if err != nil {
 				t.Errorf("error during marshal of type <%v>: %v", tc.actual.DebugString(), err)
@@ -119,6 +119,13 @@ type updateRun struct {
 	// columns of the target table being returned, that we must pass through
 	// from the input node.
 	numPassthrough int
+
+	// partialIndexPutCols and partialIndexDelCols are the number of source
+	// columns, following the check columns, that contain the results of
+	// evaluating partial index predicates on the updated row and on the
+	// existing row, respectively.
+	partialIndexPutCols int
+	partialIndexDelCols int
 }
 
 // maxUpdateBatchSize is the max number of entries in the KV batch for
@@ -298,15 +305,36 @@ func (u *updateNode) processSourceRow(params runParams, sourceVals tree.Datums)
 	// Run the CHECK constraints, if any. CheckHelper will either evaluate the
 	// constraints itself, or else inspect boolean columns from the input that
 	// contain the results of evaluation.
+	checkBegin := len(u.run.tu.ru.FetchCols) + len(u.run.tu.ru.UpdateCols) + u.run.numPassthrough
+	checkEnd := checkBegin + u.run.checkOrds.Len()
 	if !u.run.checkOrds.Empty() {
-		checkVals := sourceVals[len(u.run.tu.ru.FetchCols)+len(u.run.tu.ru.UpdateCols)+u.run.numPassthrough:]
+		checkVals := sourceVals[checkBegin:checkEnd]
 		if err := checkMutationInput(params.ctx, &params.p.semaCtx, u.run.tu.tableDesc(), u.run.checkOrds, checkVals); err != nil {
 			return err
 		}
 	}
 
+	// Create the sets of partial index IDs that the updated row should not be
+	// written to, and that the existing row does not have entries in. The
+	// partial index predicate columns follow the check columns: first the
+	// predicates evaluated on the updated row and then the predicates evaluated
+	// on the existing row.
+	putEnd := checkEnd + u.run.partialIndexPutCols
+	ignoreIndexPuts, err := partialIndexIgnoreSet(u.run.tu.tableDesc(), sourceVals[checkEnd:putEnd])
+	if err != nil {
+		return err
+	}
+	ignoreIndexDels, err := partialIndexIgnoreSet(
+		u.run.tu.tableDesc(), sourceVals[putEnd:putEnd+u.run.partialIndexDelCols],
+	)
+	if err != nil {
+		return err
+	}
+
 	// Queue the insert in the KV batch.
-	newValues, err := u.run.tu.rowForUpdate(params.ctx, oldValues, u.run.updateValues, u.run.traceKV)
+	newValues, err := u.run.tu.rowForUpdate(
+		params.ctx, oldValues, u.run.updateValues, ignoreIndexPuts, ignoreIndexDels, u.run.traceKV,
+	)
 	if err != nil {
 		return err
 	}
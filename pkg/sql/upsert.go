@@ -16,7 +16,6 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
-	"github.com/cockroachdb/cockroach/pkg/util"
 	"github.com/cockroachdb/cockroach/pkg/util/tracing"
 )
 
@@ -42,6 +41,13 @@ type upsertRun struct {
 	tw        optTableUpserter
 	checkOrds checkSet
 
+	// partialIndexPutCols and partialIndexDelCols are the number of source
+	// columns, following the check columns, that contain the results of
+	// evaluating partial index predicates on the upserted row and on the
+	// existing row, respectively.
+	partialIndexPutCols int
+	partialIndexDelCols int
+
 	// insertCols are the columns being inserted/upserted into.
 	insertCols []sqlbase.ColumnDescriptor
 
@@ -153,13 +159,33 @@ func (n *upsertNode) processSourceRow(params runParams, rowVals tree.Datums) err
 		return err
 	}
 
+	ord := len(n.run.insertCols) + len(n.run.tw.fetchCols) + len(n.run.tw.updateCols)
+	if n.run.tw.canaryOrdinal != -1 {
+		ord++
+	}
+	checkEnd := ord + n.run.checkOrds.Len()
+
+	// Create the sets of partial index IDs that the upserted row should not be
+	// written to, and that the existing row does not have entries in. The
+	// partial index predicate columns follow the check columns: first the
+	// predicates evaluated on the upserted row and then the predicates
+	// evaluated on the existing row.
+	putEnd := checkEnd + n.run.partialIndexPutCols
+	putVals := rowVals[checkEnd:putEnd]
+	delVals := rowVals[putEnd : putEnd+n.run.partialIndexDelCols]
+	ignoreIndexPuts, err := partialIndexIgnoreSet(n.run.tw.tableDesc(), putVals)
+	if err != nil {
+		return err
+	}
+	ignoreIndexDels, err := partialIndexIgnoreSet(n.run.tw.tableDesc(), delVals)
+	if err != nil {
+		return err
+	}
+	rowVals = rowVals[:checkEnd]
+
 	// Verify the CHECK constraints by inspecting boolean columns from the input that
 	// contain the results of evaluation.
 	if !n.run.checkOrds.Empty() {
-		ord := len(n.run.insertCols) + len(n.run.tw.fetchCols) + len(n.run.tw.updateCols)
-		if n.run.tw.canaryOrdinal != -1 {
-			ord++
-		}
 		checkVals := rowVals[ord:]
 		if err := checkMutationInput(params.ctx, &params.p.semaCtx, n.run.tw.tableDesc(), n.run.checkOrds, checkVals); err != nil {
 			return err
@@ -169,10 +195,7 @@ func (n *upsertNode) processSourceRow(params runParams, rowVals tree.Datums) err
 
 	// Process the row. This is also where the tableWriter will accumulate
 	// the row for later.
-	// TODO(mgartner): Add partial index IDs to ignoreIndexes that we should
-	// not write entries to.
-	var ignoreIndexes util.FastIntSet
-	return n.run.tw.row(params.ctx, rowVals, ignoreIndexes, n.run.traceKV)
+	return n.run.tw.upsertRow(params.ctx, rowVals, ignoreIndexPuts, ignoreIndexDels, n.run.traceKV)
 }
 
 // BatchedCount implements the batchedPlanNode interface.
@@ -14,8 +14,10 @@ import (
 	"context"
 	"time"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/security"
 	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/resolver"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlbase"
@@ -185,6 +187,67 @@ var userLoginTimeout = settings.RegisterPublicNonNegativeDurationSetting(
 	10*time.Second,
 )
 
+var passwordHashMethod = settings.RegisterPublicEnumSetting(
+	"server.user_login.password_encryption",
+	"which method to use to hash new passwords and, when set to scram-sha-256, "+
+		"to convert existing bcrypt hashes to when users next log in with a password",
+	"crdb-bcrypt",
+	map[int64]string{
+		int64(security.HashBCrypt):      "crdb-bcrypt",
+		int64(security.HashSCRAMSHA256): "scram-sha-256",
+	},
+)
+
+// GetConfiguredPasswordHashMethod returns the method to use to hash new
+// passwords. SCRAM verifiers are only used once all the nodes in the cluster
+// know how to check them.
+func GetConfiguredPasswordHashMethod(
+	ctx context.Context, st *cluster.Settings,
+) security.HashMethod {
+	if !st.Version.IsActive(ctx, clusterversion.VersionSCRAMAuthentication) {
+		return security.HashBCrypt
+	}
+	return security.HashMethod(passwordHashMethod.Get(&st.SV))
+}
+
+// MaybeUpgradeStoredPasswordHash replaces the bcrypt hash of a user's
+// password with a SCRAM verifier, if the cluster is configured to use
+// SCRAM. It is called after the user has successfully authenticated with
+// the given cleartext password, which is the only time the server can
+// compute the verifier. This lets existing users migrate to scram-sha-256
+// authentication without having to reset their password.
+//
+// Failures are logged but do not cause the authentication to fail.
+func MaybeUpgradeStoredPasswordHash(
+	ctx context.Context,
+	execCfg *ExecutorConfig,
+	username string,
+	cleartext string,
+	currentHash []byte,
+) {
+	if len(currentHash) == 0 || security.IsSCRAMHash(currentHash) ||
+		GetConfiguredPasswordHashMethod(ctx, execCfg.Settings) != security.HashSCRAMSHA256 {
+		return
+	}
+	newHash, err := security.HashPasswordSCRAM(cleartext)
+	if err != nil {
+		log.Warningf(ctx, "could not compute SCRAM verifier for user %q: %v", username, err)
+		return
+	}
+	// The update is conditional on the stored hash not having changed since
+	// it was used to check the password, so that a concurrent password change
+	// is not overwritten.
+	const upgradeHash = `UPDATE system.users SET "hashedPassword" = $2 ` +
+		`WHERE username = $1 AND "hashedPassword" = $3`
+	if _, err := execCfg.InternalExecutor.ExecEx(
+		ctx, "upgrade-password-hash", nil, /* txn */
+		sqlbase.InternalExecutorSessionDataOverride{User: security.RootUser},
+		upgradeHash, tree.Name(username).Normalize(), newHash, currentHash,
+	); err != nil {
+		log.Warningf(ctx, "could not convert password hash of user %q to SCRAM: %v", username, err)
+	}
+}
+
 // GetAllRoles returns a "set" (map) of Roles -> true.
 func (p *planner) GetAllRoles(ctx context.Context) (map[string]bool, error) {
 	query := `SELECT username FROM system.users`
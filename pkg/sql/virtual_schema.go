@@ -241,6 +241,7 @@ func (v virtualSchemaView) initVirtualTableDesc(
 		nil,   /* semaCtx */
 		nil,   /* evalCtx */
 		false, /* temporary */
+		false, /* materialized */
 	)
 	return mutDesc.TableDescriptor, err
 }
@@ -356,6 +357,9 @@ func (v virtualSchemaEntry) GetObjectByName(
 			desc:    sqlbase.MakeSimpleAliasTypeDescriptor(typ),
 			mutable: flags.RequireMutable,
 		}, nil
+	case tree.FunctionObject:
+		// Virtual schemas contain no user-defined functions.
+		return nil, nil
 	default:
 		return nil, errors.AssertionFailedf("unknown desired object kind %d", flags.DesiredObjectKind)
 	}
@@ -0,0 +1,145 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package cloud
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/cockroachdb/errors"
+)
+
+// KMSRegionParam is the query parameter for the region in an AWS KMS URI.
+const KMSRegionParam = "REGION"
+
+type awsKMS struct {
+	kms                 *kms.KMS
+	customerMasterKeyID string
+}
+
+var _ KMS = &awsKMS{}
+
+func init() {
+	RegisterKMSFromURIFactory(MakeAWSKMS, "aws")
+}
+
+// MakeAWSKMS constructs an AWS KMS from a URI of the form
+// aws:///<key ID or ARN>?AUTH=...&REGION=..., where the credentials are
+// specified using the same parameters as for S3 storage.
+func MakeAWSKMS(uri string, env KMSEnv) (KMS, error) {
+	kmsURI, err := url.ParseRequestURI(uri)
+	if err != nil {
+		return nil, err
+	}
+	params := kmsURI.Query()
+	region := params.Get(KMSRegionParam)
+	endpoint := params.Get(S3EndpointParam)
+
+	config := &aws.Config{}
+	if endpoint != "" {
+		if env.KMSConfig().DisableHTTP {
+			return nil, errors.New(
+				"custom endpoints disallowed for aws kms due to --external-io-disable-http flag")
+		}
+		config.Endpoint = &endpoint
+		if region == "" {
+			region = "default-region"
+		}
+		client, err := makeHTTPClient(env.ClusterSettings())
+		if err != nil {
+			return nil, err
+		}
+		config.HTTPClient = client
+	}
+
+	// The AUTH parameter is handled as for S3 storage: "specified" (the default)
+	// uses the credentials in the URI, and "implicit" those from the
+	// environment. The custom endpoint, if any, is used in both cases.
+	opts := session.Options{}
+	switch auth := params.Get(AuthParam); auth {
+	case "", authParamSpecified:
+		if params.Get(S3AccessKeyParam) == "" {
+			return nil, errors.Errorf(
+				"%s is set to '%s', but %s is not set", AuthParam, authParamSpecified, S3AccessKeyParam)
+		}
+		if params.Get(S3SecretParam) == "" {
+			return nil, errors.Errorf(
+				"%s is set to '%s', but %s is not set", AuthParam, authParamSpecified, S3SecretParam)
+		}
+		config.Credentials = credentials.NewStaticCredentials(
+			params.Get(S3AccessKeyParam), params.Get(S3SecretParam), params.Get(S3TempTokenParam),
+		)
+	case authParamImplicit:
+		if env.KMSConfig().DisableImplicitCredentials {
+			return nil, errors.New(
+				"implicit credentials disallowed for aws kms due to --external-io-implicit-credentials flag")
+		}
+		opts.SharedConfigState = session.SharedConfigEnable
+	default:
+		return nil, errors.Errorf("unsupported value %s for %s", auth, AuthParam)
+	}
+	opts.Config.MergeIn(config)
+
+	sess, err := session.NewSessionWithOptions(opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "new aws session")
+	}
+	if region == "" {
+		return nil, errors.Errorf("aws kms URI must specify the %s parameter", KMSRegionParam)
+	}
+	sess.Config.Region = aws.String(region)
+
+	keyID := strings.TrimPrefix(kmsURI.Path, "/")
+	if keyID == "" {
+		return nil, errors.New("aws kms URI must specify the key ID or ARN as its path")
+	}
+	return &awsKMS{
+		kms:                 kms.New(sess),
+		customerMasterKeyID: keyID,
+	}, nil
+}
+
+// MasterKeyID implements the KMS interface.
+func (k *awsKMS) MasterKeyID() (string, error) {
+	return k.customerMasterKeyID, nil
+}
+
+// Encrypt implements the KMS interface.
+func (k *awsKMS) Encrypt(ctx context.Context, data []byte) ([]byte, error) {
+	out, err := k.kms.EncryptWithContext(ctx, &kms.EncryptInput{
+		KeyId:     aws.String(k.customerMasterKeyID),
+		Plaintext: data,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "aws kms encrypt")
+	}
+	return out.CiphertextBlob, nil
+}
+
+// Decrypt implements the KMS interface.
+func (k *awsKMS) Decrypt(ctx context.Context, data []byte) ([]byte, error) {
+	// The master key used to encrypt data is recorded in the ciphertext itself.
+	out, err := k.kms.DecryptWithContext(ctx, &kms.DecryptInput{CiphertextBlob: data})
+	if err != nil {
+		return nil, errors.Wrap(err, "aws kms decrypt")
+	}
+	return out.Plaintext, nil
+}
+
+// Close implements the KMS interface.
+func (k *awsKMS) Close() error {
+	return nil
+}
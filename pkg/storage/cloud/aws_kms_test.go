@@ -0,0 +1,156 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package cloud
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/stretchr/testify/require"
+)
+
+type testKMSEnv struct {
+	settings *cluster.Settings
+	conf     *base.ExternalIODirConfig
+}
+
+var _ KMSEnv = &testKMSEnv{}
+
+func (e *testKMSEnv) ClusterSettings() *cluster.Settings {
+	return e.settings
+}
+
+func (e *testKMSEnv) KMSConfig() *base.ExternalIODirConfig {
+	return e.conf
+}
+
+func TestAWSKMSURIValidation(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	env := &testKMSEnv{settings: testSettings, conf: &base.ExternalIODirConfig{}}
+	for _, tc := range []struct {
+		uri string
+		err string
+	}{
+		{
+			uri: "aws:///key?REGION=us-east-1",
+			err: fmt.Sprintf("%s is set to '%s', but %s is not set",
+				AuthParam, authParamSpecified, S3AccessKeyParam),
+		},
+		{
+			uri: "aws:///key?AUTH=specified&AWS_ACCESS_KEY_ID=a&REGION=us-east-1",
+			err: fmt.Sprintf("%s is set to '%s', but %s is not set",
+				AuthParam, authParamSpecified, S3SecretParam),
+		},
+		{
+			uri: "aws:///key?AUTH=bogus&REGION=us-east-1",
+			err: "unsupported value bogus for AUTH",
+		},
+		{
+			uri: "aws:///key?AWS_ACCESS_KEY_ID=a&AWS_SECRET_ACCESS_KEY=b",
+			err: "aws kms URI must specify the REGION parameter",
+		},
+		{
+			uri: "aws:///?AWS_ACCESS_KEY_ID=a&AWS_SECRET_ACCESS_KEY=b&REGION=us-east-1",
+			err: "aws kms URI must specify the key ID or ARN as its path",
+		},
+		{
+			uri: "bogus:///key",
+			err: `no KMS scheme found for "bogus"`,
+		},
+	} {
+		_, err := KMSFromURI(tc.uri, env)
+		require.EqualError(t, err, tc.err, tc.uri)
+	}
+
+	_, err := KMSFromURI("aws:///key?AUTH=implicit&REGION=us-east-1", &testKMSEnv{
+		settings: testSettings,
+		conf:     &base.ExternalIODirConfig{DisableImplicitCredentials: true},
+	})
+	require.EqualError(t, err,
+		"implicit credentials disallowed for aws kms due to --external-io-implicit-credentials flag")
+
+	kms, err := KMSFromURI("aws:///arn:aws:kms:us-east-1:123:key/abc?AUTH=implicit&REGION=us-east-1", env)
+	require.NoError(t, err)
+	id, err := kms.MasterKeyID()
+	require.NoError(t, err)
+	require.Equal(t, "arn:aws:kms:us-east-1:123:key/abc", id)
+}
+
+func TestAWSKMSEndpoint(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	env := &testKMSEnv{settings: testSettings, conf: &base.ExternalIODirConfig{}}
+	const endpoint = "http://localhost:4566"
+	for _, params := range []string{
+		"AWS_ACCESS_KEY_ID=a&AWS_SECRET_ACCESS_KEY=b",
+		"AUTH=specified&AWS_ACCESS_KEY_ID=a&AWS_SECRET_ACCESS_KEY=b",
+		"AUTH=implicit",
+	} {
+		uri := fmt.Sprintf("aws:///key?%s&%s=%s", params, S3EndpointParam, url.QueryEscape(endpoint))
+		kms, err := KMSFromURI(uri, env)
+		require.NoError(t, err, uri)
+		require.Equal(t, endpoint, kms.(*awsKMS).kms.Endpoint, uri)
+	}
+
+	_, err := KMSFromURI(
+		fmt.Sprintf("aws:///key?AUTH=implicit&%s=%s", S3EndpointParam, url.QueryEscape(endpoint)),
+		&testKMSEnv{settings: testSettings, conf: &base.ExternalIODirConfig{DisableHTTP: true}},
+	)
+	require.EqualError(t, err,
+		"custom endpoints disallowed for aws kms due to --external-io-disable-http flag")
+}
+
+func TestEncryptDecryptAWS(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	// If environment credentials are not present, we want to skip all AWS KMS
+	// tests.
+	creds, err := credentials.NewEnvCredentials().Get()
+	if err != nil {
+		t.Skip("No AWS credentials")
+	}
+	keyARN := os.Getenv("AWS_KMS_KEY_ARN")
+	if keyARN == "" {
+		t.Skip("AWS_KMS_KEY_ARN env var must be set")
+	}
+	region := os.Getenv("AWS_KMS_REGION")
+	if region == "" {
+		t.Skip("AWS_KMS_REGION env var must be set")
+	}
+
+	q := make(url.Values)
+	q.Set(S3AccessKeyParam, creds.AccessKeyID)
+	q.Set(S3SecretParam, creds.SecretAccessKey)
+	q.Set(S3TempTokenParam, creds.SessionToken)
+	q.Set(KMSRegionParam, region)
+	uri := fmt.Sprintf("aws:///%s?%s", keyARN, q.Encode())
+
+	ctx := context.Background()
+	kms, err := KMSFromURI(uri, &testKMSEnv{settings: testSettings, conf: &base.ExternalIODirConfig{}})
+	require.NoError(t, err)
+	defer kms.Close()
+
+	plaintext := []byte("data key")
+	ciphertext, err := kms.Encrypt(ctx, plaintext)
+	require.NoError(t, err)
+	require.NotEqual(t, plaintext, ciphertext)
+	decrypted, err := kms.Decrypt(ctx, ciphertext)
+	require.NoError(t, err)
+	require.Equal(t, plaintext, decrypted)
+}
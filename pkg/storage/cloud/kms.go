@@ -0,0 +1,72 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package cloud
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/errors"
+)
+
+// KMS provides an API to interact with a key management service, which holds
+// master keys that are used to encrypt and decrypt (i.e. wrap and unwrap)
+// other, typically randomly generated, data keys.
+type KMS interface {
+	// MasterKeyID returns the identifier of the master key used by this KMS.
+	MasterKeyID() (string, error)
+	// Encrypt returns the ciphertext version of data after encrypting it using
+	// the master key.
+	Encrypt(ctx context.Context, data []byte) ([]byte, error)
+	// Decrypt returns the plaintext version of data after decrypting it using
+	// the master key.
+	Decrypt(ctx context.Context, data []byte) ([]byte, error)
+	// Close may be used to perform the necessary cleanup and shutdown of the
+	// KMS connection.
+	Close() error
+}
+
+// KMSEnv is the environment in which a KMS is configured and used.
+type KMSEnv interface {
+	ClusterSettings() *cluster.Settings
+	KMSConfig() *base.ExternalIODirConfig
+}
+
+// KMSFromURIFactory describes a factory function for KMS given a URI.
+type KMSFromURIFactory func(uri string, env KMSEnv) (KMS, error)
+
+// kmsFactoryMap maps a KMS URI scheme to the factory constructing KMSs for it.
+var kmsFactoryMap = make(map[string]KMSFromURIFactory)
+
+// RegisterKMSFromURIFactory registers the factory used to construct KMSs for
+// URIs with the given scheme.
+func RegisterKMSFromURIFactory(factory KMSFromURIFactory, scheme string) {
+	if _, ok := kmsFactoryMap[scheme]; ok {
+		panic("factory method for " + scheme + " has already been registered")
+	}
+	kmsFactoryMap[scheme] = factory
+}
+
+// KMSFromURI constructs the KMS referenced by the given URI, using the factory
+// registered for its scheme.
+func KMSFromURI(uri string, env KMSEnv) (KMS, error) {
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return nil, err
+	}
+	factory, ok := kmsFactoryMap[parsed.Scheme]
+	if !ok {
+		return nil, errors.Newf("no KMS scheme found for %q", parsed.Scheme)
+	}
+	return factory(uri, env)
+}
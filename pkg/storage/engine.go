@@ -503,6 +503,7 @@ type Stats struct {
 	TableReadersMemEstimate        int64
 	PendingCompactionBytesEstimate int64
 	L0FileCount                    int64
+	L0SublevelCount                int64 // Pebble only
 }
 
 // EnvStats is a set of RocksDB env stats, including encryption status.
@@ -620,6 +620,78 @@ var charts = []sectionDescription{
 			},
 		},
 	},
+	{
+		Organization: [][]string{{KVTransactionLayer, "Requests", "Admission Control"}},
+		Charts: []chartDescription{
+			{
+				Title:       "Requests",
+				Rate:        DescribeDerivative_NON_NEGATIVE_DERIVATIVE,
+				Percentiles: false,
+				Metrics: []string{
+					"admission.requested.kv",
+					"admission.admitted.kv",
+					"admission.errored.kv",
+				},
+			},
+			{
+				Title:   "Wait Durations",
+				Metrics: []string{"admission.wait_durations.kv"},
+			},
+			{
+				Title:       "Wait Queue Length",
+				Downsampler: DescribeAggregator_MAX,
+				Percentiles: false,
+				Metrics:     []string{"admission.wait_queue_length.kv"},
+			},
+			{
+				Title:       "Slots",
+				Downsampler: DescribeAggregator_MAX,
+				Percentiles: false,
+				Metrics: []string{
+					"admission.granter.total_slots.kv",
+					"admission.granter.used_slots.kv",
+				},
+			},
+			{
+				Title:       "IO Overload",
+				Downsampler: DescribeAggregator_MAX,
+				Percentiles: false,
+				Metrics:     []string{"admission.granter.io_overloaded.kv"},
+			},
+		},
+	},
+	{
+		Organization: [][]string{{KVTransactionLayer, "Requests", "Tenant Rate Limiting"}},
+		Charts: []chartDescription{
+			{
+				Title:       "Tenants",
+				Downsampler: DescribeAggregator_MAX,
+				Percentiles: false,
+				Metrics:     []string{"kv.tenant_rate_limit.num_tenants"},
+			},
+			{
+				Title:       "Blocked Requests",
+				Downsampler: DescribeAggregator_MAX,
+				Percentiles: false,
+				Metrics:     []string{"kv.tenant_rate_limit.all_tenants.current_blocked"},
+			},
+			{
+				Title:       "Admitted Requests",
+				Rate:        DescribeDerivative_NON_NEGATIVE_DERIVATIVE,
+				Percentiles: false,
+				Metrics:     []string{"kv.tenant_rate_limit.all_tenants.requests_admitted"},
+			},
+			{
+				Title:       "Admitted Bytes",
+				Rate:        DescribeDerivative_NON_NEGATIVE_DERIVATIVE,
+				Percentiles: false,
+				Metrics: []string{
+					"kv.tenant_rate_limit.all_tenants.read_bytes_admitted",
+					"kv.tenant_rate_limit.all_tenants.write_bytes_admitted",
+				},
+			},
+		},
+	},
 	{
 		Organization: [][]string{
 			{KVTransactionLayer, "Requests", "Slow"},
@@ -0,0 +1,436 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package admission implements admission control for the KV work that a node
+// performs. Work is admitted based on the availability of two resources:
+//
+// - CPU: admitted work occupies a slot until it is done. The number of slots
+//   is adjusted continuously based on the latency of the Go scheduler, i.e.
+//   how long runnable goroutines wait for a processor. It is decreased while
+//   the processors are overloaded, and increased while all slots are in use
+//   but the processors are not overloaded, which includes the case in which
+//   admitted work is blocked on something other than CPU.
+//
+// - IO: admitted work that writes takes a token. While the LSM of a store is
+//   unhealthy, i.e. while L0 has too many files or sublevels, the number of
+//   tokens that are handed out per interval is reduced multiplicatively.
+//   Otherwise, tokens are unlimited.
+//
+// Work that can't be admitted waits in a queue, which is ordered by priority
+// and then by arrival. System work is not subject to admission control.
+package admission
+
+import (
+	"container/heap"
+	"context"
+	"math"
+	"runtime"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+)
+
+// Enabled controls whether KV work is subject to admission control.
+var Enabled = settings.RegisterBoolSetting(
+	"admission.kv.enabled",
+	"when true, work performed by the KV layer is subject to admission control",
+	false,
+)
+
+var schedulerLatencyThreshold = settings.RegisterNonNegativeDurationSetting(
+	"admission.kv.scheduler_latency_threshold",
+	"the latency of the Go scheduler above which the processors are considered overloaded",
+	time.Millisecond,
+)
+
+var l0FileCountThreshold = settings.RegisterPositiveIntSetting(
+	"admission.l0_file_count_overload_threshold",
+	"the number of files in L0 of a store above which the store is considered overloaded",
+	1000,
+)
+
+var l0SublevelCountThreshold = settings.RegisterPositiveIntSetting(
+	"admission.l0_sublevel_count_overload_threshold",
+	"the number of sublevels in L0 of a store above which the store is considered overloaded",
+	20,
+)
+
+// WorkPriority is the priority of work that is subject to admission control.
+// Waiting work with a higher priority is always admitted before waiting work
+// with a lower priority.
+type WorkPriority int8
+
+const (
+	// BulkPri is the priority of bulk work, like IMPORT and index backfills.
+	BulkPri WorkPriority = iota
+	// UserPri is the priority of work performed on behalf of users.
+	UserPri
+	// SystemPri is the priority of work that the cluster needs to function,
+	// like node liveness heartbeats and meta range lookups. Such work bypasses
+	// admission control, since delaying it while the node is overloaded, e.g.
+	// until leases expire, would only make the overload worse.
+	SystemPri
+)
+
+func (p WorkPriority) String() string {
+	switch p {
+	case BulkPri:
+		return "bulk"
+	case UserPri:
+		return "user"
+	case SystemPri:
+		return "system"
+	default:
+		return "unknown"
+	}
+}
+
+// WorkInfo describes work that is subject to admission control.
+type WorkInfo struct {
+	Priority WorkPriority
+	// IsWrite is set if the work writes to the storage engine, in which case it
+	// is subject to IO admission.
+	IsWrite bool
+}
+
+// StoreL0Metrics are the metrics of the LSM of a store that IO admission is
+// based on.
+type StoreL0Metrics struct {
+	FileCount     int64
+	SublevelCount int64
+}
+
+const (
+	// cpuSampleInterval is the interval at which the number of slots is
+	// adjusted.
+	cpuSampleInterval = 10 * time.Millisecond
+	// ioAdjustmentInterval is the interval at which IO tokens are replenished.
+	ioAdjustmentInterval = time.Second
+	// minIOTokens is the minimum number of IO tokens that are handed out per
+	// interval while a store is overloaded.
+	minIOTokens = 10
+	// unlimitedIOTokens is the number of IO tokens while no store is
+	// overloaded.
+	unlimitedIOTokens = math.MaxInt64
+)
+
+// Controller performs admission control for the KV work of a node. It is
+// safe for concurrent use.
+type Controller struct {
+	settings *cluster.Settings
+	metrics  Metrics
+	// schedulerLatency samples the latency of the Go scheduler.
+	schedulerLatency func() time.Duration
+	// storeMetrics returns the L0 metrics of each of the node's stores.
+	storeMetrics func() []StoreL0Metrics
+
+	mu struct {
+		syncutil.Mutex
+		usedSlots  int
+		totalSlots int
+		// slotsPinned is set if the number of slots is no longer adjusted.
+		slotsPinned bool
+		// ioTokens is the number of IO tokens left in the current interval.
+		ioTokens int64
+		// admittedWrites is the number of writes that were admitted in the
+		// current interval.
+		admittedWrites int64
+		waiting        waitingWorkHeap
+		seq            uint64
+	}
+}
+
+// NewController constructs a new Controller. The storeMetrics function is
+// used to determine the health of the node's stores.
+func NewController(
+	st *cluster.Settings, histogramWindow time.Duration, storeMetrics func() []StoreL0Metrics,
+) *Controller {
+	c := &Controller{
+		settings:         st,
+		metrics:          makeMetrics(histogramWindow),
+		schedulerLatency: schedulerLatency,
+		storeMetrics:     storeMetrics,
+	}
+	c.mu.totalSlots = runtime.GOMAXPROCS(0)
+	c.mu.ioTokens = unlimitedIOTokens
+	c.updateGaugesLocked()
+	return c
+}
+
+// Metrics returns the metrics of the Controller.
+func (c *Controller) Metrics() *Metrics {
+	return &c.metrics
+}
+
+// Start starts the goroutines that sample the CPU and IO load of the node, and
+// adjust the resources available for admission accordingly. Nothing is
+// sampled while admission control is disabled.
+func (c *Controller) Start(ctx context.Context, stopper *stop.Stopper) {
+	stopper.RunWorker(ctx, func(ctx context.Context) {
+		ticker := time.NewTicker(cpuSampleInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if !Enabled.Get(&c.settings.SV) {
+					continue
+				}
+				c.adjustSlots(c.schedulerLatency())
+			case <-stopper.ShouldStop():
+				return
+			}
+		}
+	})
+	stopper.RunWorker(ctx, func(ctx context.Context) {
+		ticker := time.NewTicker(ioAdjustmentInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if !Enabled.Get(&c.settings.SV) {
+					continue
+				}
+				c.adjustIOTokens(ctx, c.storeMetrics())
+			case <-stopper.ShouldStop():
+				return
+			}
+		}
+	})
+}
+
+// PinSlotsForTesting sets the number of slots, and stops it from being
+// adjusted based on the latency of the Go scheduler.
+func (c *Controller) PinSlotsForTesting(slots int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.mu.totalSlots = slots
+	c.mu.slotsPinned = true
+	c.grantLocked()
+	c.updateGaugesLocked()
+}
+
+// Admit blocks until the specified work is admitted, or until the context is
+// canceled, in which case the context's error is returned. If the work is
+// admitted, AdmittedWorkDone must be called once it is done. Whether the work
+// needs to call AdmittedWorkDone is returned, since admission control may be
+// disabled, and system work is always admitted immediately. It is never
+// needed if an error is returned.
+func (c *Controller) Admit(ctx context.Context, info WorkInfo) (enabled bool, _ error) {
+	if !Enabled.Get(&c.settings.SV) || info.Priority == SystemPri {
+		return false, nil
+	}
+	c.metrics.Requested.Inc(1)
+
+	c.mu.Lock()
+	// Work may only skip the queue if no work of the same or a higher
+	// priority is waiting.
+	if (len(c.mu.waiting) == 0 || c.mu.waiting[0].Priority < info.Priority) &&
+		c.tryTakeLocked(info) {
+		c.updateGaugesLocked()
+		c.mu.Unlock()
+		c.metrics.Admitted.Inc(1)
+		return true, nil
+	}
+	c.mu.seq++
+	w := &waitingWork{WorkInfo: info, seq: c.mu.seq, granted: make(chan struct{})}
+	heap.Push(&c.mu.waiting, w)
+	// The work may be admissible ahead of waiting writes that are blocked on IO
+	// tokens.
+	c.grantLocked()
+	c.updateGaugesLocked()
+	c.mu.Unlock()
+
+	start := timeutil.Now()
+	select {
+	case <-w.granted:
+		c.metrics.WaitDurations.RecordValue(timeutil.Since(start).Nanoseconds())
+		c.metrics.Admitted.Inc(1)
+		return true, nil
+	case <-ctx.Done():
+		c.mu.Lock()
+		if w.index >= 0 {
+			heap.Remove(&c.mu.waiting, w.index)
+			c.metrics.WaitQueueLength.Update(int64(len(c.mu.waiting)))
+			c.mu.Unlock()
+		} else {
+			// The work was admitted concurrently with the cancellation. Return the
+			// slot, since the work won't be done.
+			c.mu.Unlock()
+			c.AdmittedWorkDone()
+		}
+		c.metrics.Errored.Inc(1)
+		return false, ctx.Err()
+	}
+}
+
+// AdmittedWorkDone is called when admitted work is done.
+func (c *Controller) AdmittedWorkDone() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.mu.usedSlots--
+	c.grantLocked()
+	c.updateGaugesLocked()
+}
+
+// tryTakeLocked takes the resources that the specified work needs, if they
+// are available.
+func (c *Controller) tryTakeLocked(info WorkInfo) bool {
+	if c.mu.usedSlots >= c.mu.totalSlots {
+		return false
+	}
+	if info.IsWrite {
+		if c.mu.ioTokens <= 0 {
+			return false
+		}
+		if c.mu.ioTokens != unlimitedIOTokens {
+			c.mu.ioTokens--
+		}
+		c.mu.admittedWrites++
+	}
+	c.mu.usedSlots++
+	return true
+}
+
+// grantLocked admits waiting work in order, for as long as resources are
+// available. Waiting reads are not blocked behind waiting writes when only IO
+// tokens are exhausted, since they don't need them.
+func (c *Controller) grantLocked() {
+	var blockedWrites []*waitingWork
+	for len(c.mu.waiting) > 0 && c.mu.usedSlots < c.mu.totalSlots {
+		w := heap.Pop(&c.mu.waiting).(*waitingWork)
+		if !c.tryTakeLocked(w.WorkInfo) {
+			blockedWrites = append(blockedWrites, w)
+			continue
+		}
+		close(w.granted)
+	}
+	for _, w := range blockedWrites {
+		heap.Push(&c.mu.waiting, w)
+	}
+	c.metrics.WaitQueueLength.Update(int64(len(c.mu.waiting)))
+}
+
+// adjustSlots adjusts the number of slots based on a sample of the latency of
+// the Go scheduler.
+func (c *Controller) adjustSlots(latency time.Duration) {
+	threshold := schedulerLatencyThreshold.Get(&c.settings.SV)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.mu.slotsPinned {
+		return
+	}
+	if latency > threshold {
+		if c.mu.totalSlots > 1 {
+			c.mu.totalSlots--
+		}
+	} else if c.mu.usedSlots >= c.mu.totalSlots {
+		c.mu.totalSlots++
+		c.grantLocked()
+	}
+	c.updateGaugesLocked()
+}
+
+// adjustIOTokens replenishes the IO tokens for the next interval based on the
+// health of the stores. While a store is overloaded, the number of tokens is
+// half of the number of writes admitted in the last interval.
+func (c *Controller) adjustIOTokens(ctx context.Context, stores []StoreL0Metrics) {
+	fileThreshold := l0FileCountThreshold.Get(&c.settings.SV)
+	sublevelThreshold := l0SublevelCountThreshold.Get(&c.settings.SV)
+	overloaded := false
+	for _, m := range stores {
+		if m.FileCount > fileThreshold || m.SublevelCount > sublevelThreshold {
+			overloaded = true
+			break
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if overloaded {
+		tokens := c.mu.admittedWrites / 2
+		if tokens < minIOTokens {
+			tokens = minIOTokens
+		}
+		if c.mu.ioTokens == unlimitedIOTokens {
+			log.Infof(ctx, "store overloaded, limiting admission of writes to %d per %s",
+				tokens, ioAdjustmentInterval)
+		}
+		c.mu.ioTokens = tokens
+		c.metrics.IOOverloaded.Update(1)
+	} else {
+		if c.mu.ioTokens != unlimitedIOTokens {
+			log.Infof(ctx, "stores no longer overloaded, admitting writes without limit")
+		}
+		c.mu.ioTokens = unlimitedIOTokens
+		c.metrics.IOOverloaded.Update(0)
+	}
+	c.mu.admittedWrites = 0
+	c.grantLocked()
+	c.updateGaugesLocked()
+}
+
+func (c *Controller) updateGaugesLocked() {
+	c.metrics.TotalSlots.Update(int64(c.mu.totalSlots))
+	c.metrics.UsedSlots.Update(int64(c.mu.usedSlots))
+}
+
+// waitingWork is work that is waiting for admission.
+type waitingWork struct {
+	WorkInfo
+	// seq orders work of the same priority by arrival.
+	seq uint64
+	// granted is closed when the work is admitted.
+	granted chan struct{}
+	// index is the position of the work in the waitingWorkHeap, or -1 if it
+	// was removed from it.
+	index int
+}
+
+// waitingWorkHeap implements heap.Interface. Work is ordered by priority,
+// and then by arrival.
+type waitingWorkHeap []*waitingWork
+
+var _ heap.Interface = (*waitingWorkHeap)(nil)
+
+func (h waitingWorkHeap) Len() int { return len(h) }
+
+func (h waitingWorkHeap) Less(i, j int) bool {
+	if h[i].Priority != h[j].Priority {
+		return h[i].Priority > h[j].Priority
+	}
+	return h[i].seq < h[j].seq
+}
+
+func (h waitingWorkHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *waitingWorkHeap) Push(x interface{}) {
+	w := x.(*waitingWork)
+	w.index = len(*h)
+	*h = append(*h, w)
+}
+
+func (h *waitingWorkHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	w := old[n-1]
+	old[n-1] = nil
+	w.index = -1
+	*h = old[:n-1]
+	return w
+}
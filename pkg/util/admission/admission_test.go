@@ -0,0 +1,329 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package admission
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestController(t *testing.T, slots int) *Controller {
+	st := cluster.MakeTestingClusterSettings()
+	Enabled.Override(&st.SV, true)
+	c := NewController(st, time.Minute, nil /* storeMetrics */)
+	c.mu.totalSlots = slots
+	return c
+}
+
+// admitAsync requests admission for the specified work in a goroutine and
+// waits until it is queued.
+func admitAsync(t *testing.T, c *Controller, ctx context.Context, info WorkInfo) <-chan error {
+	queued := c.metrics.WaitQueueLength.Value()
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := c.Admit(ctx, info)
+		errCh <- err
+	}()
+	testutils.SucceedsSoon(t, func() error {
+		if l := c.metrics.WaitQueueLength.Value(); l != queued+1 {
+			return errors.Errorf("expected %d queued requests, found %d", queued+1, l)
+		}
+		return nil
+	})
+	return errCh
+}
+
+func requireAdmitted(t *testing.T, errCh <-chan error) {
+	select {
+	case err := <-errCh:
+		require.NoError(t, err)
+	case <-time.After(testutils.DefaultSucceedsSoonDuration):
+		t.Fatal("work was not admitted")
+	}
+}
+
+func requireWaiting(t *testing.T, errCh <-chan error) {
+	select {
+	case err := <-errCh:
+		t.Fatalf("work unexpectedly finished waiting: %v", err)
+	case <-time.After(10 * time.Millisecond):
+	}
+}
+
+func TestControllerDisabled(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	c := newTestController(t, 1)
+	Enabled.Override(&c.settings.SV, false)
+	for i := 0; i < 3; i++ {
+		enabled, err := c.Admit(context.Background(), WorkInfo{Priority: UserPri})
+		require.NoError(t, err)
+		require.False(t, enabled)
+	}
+	require.Equal(t, int64(0), c.metrics.Requested.Count())
+}
+
+func TestControllerPriority(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	c := newTestController(t, 1)
+	enabled, err := c.Admit(ctx, WorkInfo{Priority: UserPri})
+	require.NoError(t, err)
+	require.True(t, enabled)
+	require.Equal(t, int64(1), c.metrics.UsedSlots.Value())
+
+	// Waiting work is admitted in order of priority, and then of arrival.
+	bulk := admitAsync(t, c, ctx, WorkInfo{Priority: BulkPri})
+	user1 := admitAsync(t, c, ctx, WorkInfo{Priority: UserPri})
+	user2 := admitAsync(t, c, ctx, WorkInfo{Priority: UserPri})
+
+	// System work bypasses admission control, even while other work waits.
+	enabled, err = c.Admit(ctx, WorkInfo{Priority: SystemPri, IsWrite: true})
+	require.NoError(t, err)
+	require.False(t, enabled)
+	require.Equal(t, int64(1), c.metrics.UsedSlots.Value())
+
+	for _, errCh := range []<-chan error{user1, user2, bulk} {
+		requireWaiting(t, errCh)
+		c.AdmittedWorkDone()
+		requireAdmitted(t, errCh)
+	}
+	c.AdmittedWorkDone()
+	require.Equal(t, int64(0), c.metrics.UsedSlots.Value())
+	require.Equal(t, int64(0), c.metrics.WaitQueueLength.Value())
+	require.Equal(t, int64(4), c.metrics.Admitted.Count())
+}
+
+func TestControllerCancel(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	c := newTestController(t, 1)
+	_, err := c.Admit(context.Background(), WorkInfo{Priority: UserPri})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	type result struct {
+		enabled bool
+		err     error
+	}
+	resCh := make(chan result, 1)
+	go func() {
+		enabled, err := c.Admit(ctx, WorkInfo{Priority: UserPri})
+		resCh <- result{enabled, err}
+	}()
+	testutils.SucceedsSoon(t, func() error {
+		if l := c.metrics.WaitQueueLength.Value(); l != 1 {
+			return errors.Errorf("expected 1 queued request, found %d", l)
+		}
+		return nil
+	})
+	cancel()
+	// The canceled work was not admitted, so it must not call
+	// AdmittedWorkDone.
+	res := <-resCh
+	require.Equal(t, context.Canceled, res.err)
+	require.False(t, res.enabled)
+	require.Equal(t, int64(0), c.metrics.WaitQueueLength.Value())
+	require.Equal(t, int64(1), c.metrics.Errored.Count())
+	require.Equal(t, int64(1), c.metrics.UsedSlots.Value())
+
+	// The canceled work doesn't hold on to a slot.
+	c.AdmittedWorkDone()
+	_, err = c.Admit(context.Background(), WorkInfo{Priority: UserPri})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), c.metrics.UsedSlots.Value())
+}
+
+func TestControllerPinSlots(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	c := newTestController(t, 1)
+	_, err := c.Admit(ctx, WorkInfo{Priority: UserPri})
+	require.NoError(t, err)
+	errCh := admitAsync(t, c, ctx, WorkInfo{Priority: UserPri})
+
+	// Pinning the number of slots admits waiting work if slots are added.
+	c.PinSlotsForTesting(2)
+	requireAdmitted(t, errCh)
+
+	// The number of slots is no longer adjusted.
+	errCh = admitAsync(t, c, ctx, WorkInfo{Priority: UserPri})
+	c.adjustSlots(0)
+	requireWaiting(t, errCh)
+	c.adjustSlots(schedulerLatencyThreshold.Get(&c.settings.SV) + time.Millisecond)
+	require.Equal(t, int64(2), c.metrics.TotalSlots.Value())
+	c.AdmittedWorkDone()
+	requireAdmitted(t, errCh)
+}
+
+func TestControllerAdjustSlots(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	c := newTestController(t, 2)
+	overloaded := schedulerLatencyThreshold.Get(&c.settings.SV) + time.Millisecond
+
+	// Slots are removed while the processors are overloaded, down to a single
+	// slot.
+	c.adjustSlots(overloaded)
+	require.Equal(t, int64(1), c.metrics.TotalSlots.Value())
+	c.adjustSlots(overloaded)
+	require.Equal(t, int64(1), c.metrics.TotalSlots.Value())
+
+	// Slots are not added while they aren't all in use.
+	c.adjustSlots(0)
+	require.Equal(t, int64(1), c.metrics.TotalSlots.Value())
+
+	// Slots are added while they are all in use and the processors are not
+	// overloaded, which admits waiting work.
+	_, err := c.Admit(ctx, WorkInfo{Priority: UserPri})
+	require.NoError(t, err)
+	errCh := admitAsync(t, c, ctx, WorkInfo{Priority: UserPri})
+	c.adjustSlots(overloaded)
+	requireWaiting(t, errCh)
+	c.adjustSlots(0)
+	requireAdmitted(t, errCh)
+	require.Equal(t, int64(2), c.metrics.TotalSlots.Value())
+	require.Equal(t, int64(2), c.metrics.UsedSlots.Value())
+}
+
+// TestControllerAdjustSlotsUnderLoad verifies that the number of slots follows
+// the latency of the Go scheduler, as sampled by a started Controller.
+func TestControllerAdjustSlotsUnderLoad(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	stopper := stop.NewStopper()
+	defer stopper.Stop(ctx)
+	const slots = 4
+	c := newTestController(t, slots)
+	c.Start(ctx, stopper)
+
+	// Saturate the processors with many more goroutines than there are
+	// processors, which makes the scheduler latency exceed the threshold.
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 16*runtime.GOMAXPROCS(0); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+			}
+		}()
+	}
+	testutils.SucceedsSoon(t, func() error {
+		if n := c.metrics.TotalSlots.Value(); n >= slots {
+			return errors.Errorf("expected fewer than %d slots, found %d", slots, n)
+		}
+		return nil
+	})
+	close(done)
+	wg.Wait()
+
+	// Once the processors are no longer overloaded, slots are added back for
+	// work that is waiting for them.
+	errChs := make([]<-chan error, slots)
+	for i := range errChs {
+		errCh := make(chan error, 1)
+		go func() {
+			_, err := c.Admit(ctx, WorkInfo{Priority: UserPri})
+			errCh <- err
+		}()
+		errChs[i] = errCh
+	}
+	for _, errCh := range errChs {
+		requireAdmitted(t, errCh)
+	}
+	require.GreaterOrEqual(t, c.metrics.TotalSlots.Value(), int64(slots))
+	for range errChs {
+		c.AdmittedWorkDone()
+	}
+}
+
+func TestControllerIOTokens(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	c := newTestController(t, 1000)
+	healthy := []StoreL0Metrics{{FileCount: 10, SublevelCount: 2}}
+	tooManyFiles := []StoreL0Metrics{healthy[0], {FileCount: 1001}}
+	tooManySublevels := []StoreL0Metrics{{SublevelCount: 21}}
+
+	write := WorkInfo{Priority: UserPri, IsWrite: true}
+	read := WorkInfo{Priority: UserPri}
+	admit := func(info WorkInfo) {
+		_, err := c.Admit(ctx, info)
+		require.NoError(t, err)
+	}
+
+	// Writes are not limited while the stores are healthy.
+	c.adjustIOTokens(ctx, healthy)
+	for i := 0; i < 100; i++ {
+		admit(write)
+	}
+	require.Equal(t, int64(0), c.metrics.IOOverloaded.Value())
+
+	// Once a store is overloaded, the writes admitted per interval are halved.
+	c.adjustIOTokens(ctx, tooManyFiles)
+	require.Equal(t, int64(1), c.metrics.IOOverloaded.Value())
+	for i := 0; i < 50; i++ {
+		admit(write)
+	}
+	blocked := admitAsync(t, c, ctx, write)
+	// Reads are not subject to IO admission, and are not blocked behind writes
+	// that are.
+	admit(read)
+	admit(read)
+	requireWaiting(t, blocked)
+
+	// The next interval admits the blocked write.
+	c.adjustIOTokens(ctx, tooManySublevels)
+	requireAdmitted(t, blocked)
+	for i := 0; i < 24; i++ {
+		admit(write)
+	}
+	blocked = admitAsync(t, c, ctx, write)
+
+	// The number of writes admitted per interval doesn't drop below a minimum.
+	for i := 0; i < 3; i++ {
+		c.adjustIOTokens(ctx, tooManySublevels)
+	}
+	requireAdmitted(t, blocked)
+	for i := 0; i < minIOTokens; i++ {
+		admit(write)
+	}
+	blocked = admitAsync(t, c, ctx, write)
+
+	// Once the stores are healthy again, writes are no longer limited.
+	c.adjustIOTokens(ctx, healthy)
+	requireAdmitted(t, blocked)
+	require.Equal(t, int64(0), c.metrics.IOOverloaded.Value())
+	for i := 0; i < 100; i++ {
+		admit(write)
+	}
+}
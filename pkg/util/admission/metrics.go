@@ -0,0 +1,98 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package admission
+
+import (
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/util/metric"
+)
+
+var (
+	metaRequested = metric.Metadata{
+		Name:        "admission.requested.kv",
+		Help:        "Number of KV requests that requested admission",
+		Measurement: "Requests",
+		Unit:        metric.Unit_COUNT,
+	}
+	metaAdmitted = metric.Metadata{
+		Name:        "admission.admitted.kv",
+		Help:        "Number of KV requests that were admitted",
+		Measurement: "Requests",
+		Unit:        metric.Unit_COUNT,
+	}
+	metaErrored = metric.Metadata{
+		Name:        "admission.errored.kv",
+		Help:        "Number of KV requests that were canceled while waiting for admission",
+		Measurement: "Requests",
+		Unit:        metric.Unit_COUNT,
+	}
+	metaWaitDurations = metric.Metadata{
+		Name:        "admission.wait_durations.kv",
+		Help:        "Wait time of KV requests that waited for admission",
+		Measurement: "Wait time",
+		Unit:        metric.Unit_NANOSECONDS,
+	}
+	metaWaitQueueLength = metric.Metadata{
+		Name:        "admission.wait_queue_length.kv",
+		Help:        "Number of KV requests waiting for admission",
+		Measurement: "Requests",
+		Unit:        metric.Unit_COUNT,
+	}
+	metaTotalSlots = metric.Metadata{
+		Name:        "admission.granter.total_slots.kv",
+		Help:        "Number of slots available to admitted KV requests",
+		Measurement: "Slots",
+		Unit:        metric.Unit_COUNT,
+	}
+	metaUsedSlots = metric.Metadata{
+		Name:        "admission.granter.used_slots.kv",
+		Help:        "Number of slots used by admitted KV requests",
+		Measurement: "Slots",
+		Unit:        metric.Unit_COUNT,
+	}
+	metaIOOverloaded = metric.Metadata{
+		Name:        "admission.granter.io_overloaded.kv",
+		Help:        "Set to 1 while the admission of KV writes is limited due to an overloaded store",
+		Measurement: "Overloaded",
+		Unit:        metric.Unit_COUNT,
+	}
+)
+
+// Metrics is a metric.Struct for the admission Controller.
+type Metrics struct {
+	Requested       *metric.Counter
+	Admitted        *metric.Counter
+	Errored         *metric.Counter
+	WaitDurations   *metric.Histogram
+	WaitQueueLength *metric.Gauge
+	TotalSlots      *metric.Gauge
+	UsedSlots       *metric.Gauge
+	IOOverloaded    *metric.Gauge
+}
+
+var _ metric.Struct = (*Metrics)(nil)
+
+// MetricStruct implements the metric.Struct interface.
+func (*Metrics) MetricStruct() {}
+
+func makeMetrics(histogramWindow time.Duration) Metrics {
+	return Metrics{
+		Requested:       metric.NewCounter(metaRequested),
+		Admitted:        metric.NewCounter(metaAdmitted),
+		Errored:         metric.NewCounter(metaErrored),
+		WaitDurations:   metric.NewLatency(metaWaitDurations, histogramWindow),
+		WaitQueueLength: metric.NewGauge(metaWaitQueueLength),
+		TotalSlots:      metric.NewGauge(metaTotalSlots),
+		UsedSlots:       metric.NewGauge(metaUsedSlots),
+		IOOverloaded:    metric.NewGauge(metaIOOverloaded),
+	}
+}
@@ -0,0 +1,29 @@
+// Copyright 2020 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package admission
+
+import (
+	"runtime"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+)
+
+// schedulerLatency samples the latency of the Go scheduler, i.e. how long a
+// goroutine that is ready to run waits for a processor. runtime.Gosched puts
+// the calling goroutine on the global run queue, so the time it takes to
+// return grows with the number of goroutines that are waiting to be run
+// ahead of it.
+func schedulerLatency() time.Duration {
+	start := timeutil.Now()
+	runtime.Gosched()
+	return timeutil.Since(start)
+}
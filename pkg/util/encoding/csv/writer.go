@@ -48,6 +48,18 @@ func NewWriter(w io.Writer) *Writer {
 // Writer writes a single CSV record to w along with any necessary quoting.
 // A record is a slice of strings with each string being one field.
 func (w *Writer) Write(record []string) error {
+	return w.write(record, nil /* forceQuote */)
+}
+
+// WriteWithQuotes is like Write, but the fields for which forceQuote is true
+// are quoted even if they do not need to be. This is used to distinguish
+// values from an unquoted NULL marker, such as the empty string in Postgres
+// CSV.
+func (w *Writer) WriteWithQuotes(record []string, forceQuote []bool) error {
+	return w.write(record, forceQuote)
+}
+
+func (w *Writer) write(record []string, forceQuote []bool) error {
 	if !validDelim(w.Comma) {
 		return errInvalidDelim
 	}
@@ -61,7 +73,7 @@ func (w *Writer) Write(record []string) error {
 
 		// If we don't have to have a quoted field then just
 		// write out the field and continue to the next field.
-		if !w.fieldNeedsQuotes(field) {
+		if (forceQuote == nil || !forceQuote[n]) && !w.fieldNeedsQuotes(field) {
 			if _, err := w.w.WriteString(field); err != nil {
 				return err
 			}
@@ -68,6 +68,18 @@ func TestWrite(t *testing.T) {
 	}
 }
 
+func TestWriteWithQuotes(t *testing.T) {
+	b := &bytes.Buffer{}
+	f := NewWriter(b)
+	if err := f.WriteWithQuotes([]string{"", "", "a", "b,c"}, []bool{true, false, true, false}); err != nil {
+		t.Fatalf("Unexpected error: %s\n", err)
+	}
+	f.Flush()
+	if out, expected := b.String(), `"",,"a","b,c"`+"\n"; out != expected {
+		t.Errorf("out=%q want %q", out, expected)
+	}
+}
+
 type errorWriter struct{}
 
 func (e errorWriter) Write(b []byte) (int, error) {
@@ -827,6 +827,18 @@ func EncodeNullDescending(b []byte) []byte {
 	return append(b, encodedNullDesc)
 }
 
+// HasNullAscendingPrefix returns true if the buffer starts with the NULL marker
+// written by EncodeNullAscending.
+func HasNullAscendingPrefix(b []byte) bool {
+	return len(b) > 0 && b[0] == encodedNull
+}
+
+// HasNullDescendingPrefix returns true if the buffer starts with the NULL marker
+// written by EncodeNullDescending.
+func HasNullDescendingPrefix(b []byte) bool {
+	return len(b) > 0 && b[0] == encodedNullDesc
+}
+
 // EncodeNotNullAscending encodes a value that is larger than the NULL marker encoded by
 // EncodeNull but less than any encoded value returned by EncodeVarint,
 // EncodeFloat, EncodeBytes or EncodeString.
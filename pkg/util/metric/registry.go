@@ -74,6 +74,22 @@ func (r *Registry) AddMetric(metric Iterable) {
 	}
 }
 
+// RemoveMetric removes the passed-in metric from the registry. It is a no-op
+// if the metric isn't in the registry.
+func (r *Registry) RemoveMetric(metric Iterable) {
+	r.Lock()
+	defer r.Unlock()
+	for i, m := range r.tracked {
+		if m == metric {
+			r.tracked = append(r.tracked[:i], r.tracked[i+1:]...)
+			if log.V(2) {
+				log.Infof(context.TODO(), "Removed metric: %s (%T)", metric.GetName(), metric)
+			}
+			return
+		}
+	}
+}
+
 // AddMetricStruct examines all fields of metricStruct and adds
 // all Iterable or metric.Struct objects to the registry.
 func (r *Registry) AddMetricStruct(metricStruct interface{}) {
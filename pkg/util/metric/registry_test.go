@@ -140,4 +140,15 @@ func TestRegistry(t *testing.T) {
 	if c := r.getCounter("top.histogram"); c != nil {
 		t.Errorf("getCounter returned non-nil %v of type %T when requesting non-counter, expected nil", c, c)
 	}
+
+	// Test RemoveMetric.
+	r.RemoveMetric(topCounter)
+	if c := r.getCounter("top.counter"); c != nil {
+		t.Errorf("getCounter returned non-nil %v after removal, expected nil", c)
+	}
+	if g := r.getGauge("top.gauge"); g != topGauge {
+		t.Errorf("getGauge returned %v, expected %v", g, topGauge)
+	}
+	// Removing a metric which isn't in the registry is a no-op.
+	r.RemoveMetric(topCounter)
 }